@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -35,6 +37,33 @@ const (
 	//   `{"email": ["kilgore@kilgore.trout"], "groups": ["devops", "maintainers"]}`
 	AnnotationKeyOIDCClaims = "rbac.kargo.akuity.io/claims"
 
+	// AnnotationKeyRoleMapping is an annotation key that Kargo sets on a
+	// ServiceAccount to associate it with any user authenticated via OIDC and
+	// satisfying any of the criteria of the RoleMappings that reference it in
+	// the Project's ProjectConfig. The value is a string representation of a
+	// JSON RoleMappingCriteria object. The annotation is owned by Kargo and is
+	// kept in sync with the ProjectConfig, so it should not be edited by hand.
+	AnnotationKeyRoleMapping = "rbac.kargo.akuity.io/role-mapping"
+
+	// AnnotationKeyAPITokenExpiresAt is an annotation key that can be set on a
+	// Kargo API token Secret to indicate the time, in RFC 3339 format, after
+	// which the token is no longer accepted. Expired tokens are eventually
+	// deleted.
+	AnnotationKeyAPITokenExpiresAt = "rbac.kargo.akuity.io/expires-at"
+
+	// AnnotationKeyAPITokenScopes is an annotation key that can be set on a
+	// Kargo API token Secret to narrow the permissions the token confers to a
+	// subset of those held by the Kargo Role it belongs to. The value is
+	// expected to be a string representation of a JSON array of RBAC
+	// PolicyRules. A request made using the token is permitted only if it is
+	// permitted by both the Kargo Role and at least one of these rules.
+	AnnotationKeyAPITokenScopes = "rbac.kargo.akuity.io/scopes"
+
+	// AnnotationKeyAPITokenLastUsed is an annotation key that Kargo sets on a
+	// Kargo API token Secret to record the approximate time, in RFC 3339 format,
+	// at which the token was last used to authenticate to the Kargo API server.
+	AnnotationKeyAPITokenLastUsed = "rbac.kargo.akuity.io/last-used"
+
 	// AnnotationValueTrue is a value that can be set on an annotation to indicate
 	// that it applies.
 	AnnotationValueTrue = "true"
@@ -125,3 +154,72 @@ func SetOIDCClaimsAnnotation(sa *corev1.ServiceAccount, claims map[string][]stri
 	}
 	return nil
 }
+
+// APITokenExpiryFromAnnotationValues returns the expiry recorded in the
+// rbac.kargo.akuity.io/expires-at annotation of a Kargo API token Secret. If
+// the token does not expire, nil is returned.
+func APITokenExpiryFromAnnotationValues(annotations map[string]string) (*time.Time, error) {
+	val, ok := annotations[AnnotationKeyAPITokenExpiresAt]
+	if !ok {
+		return nil, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("parsing API token expiry from annotation value: %w", err)
+	}
+	return &expiry, nil
+}
+
+// APITokenScopesFromAnnotationValues returns the RBAC PolicyRules recorded in
+// the rbac.kargo.akuity.io/scopes annotation of a Kargo API token Secret. If
+// the token is not scoped, nil is returned.
+func APITokenScopesFromAnnotationValues(annotations map[string]string) ([]rbacv1.PolicyRule, error) {
+	val, ok := annotations[AnnotationKeyAPITokenScopes]
+	if !ok {
+		return nil, nil
+	}
+	scopes := []rbacv1.PolicyRule{}
+	if err := json.Unmarshal([]byte(val), &scopes); err != nil {
+		return nil, fmt.Errorf("unmarshaling API token scopes from annotation value: %w", err)
+	}
+	return scopes, nil
+}
+
+// RoleMappingCriteria is the consolidated set of criteria, from all of a
+// Project's RoleMappings that reference a given Kargo Role, that map users
+// authenticated via OIDC to that Role. It is recorded on the Role's
+// ServiceAccount using the AnnotationKeyRoleMapping annotation.
+//
+// +kubebuilder:object:generate=false
+type RoleMappingCriteria struct {
+	// Groups are groups, any of which a user's "groups" claim must include.
+	Groups []string `json:"groups,omitempty"`
+	// EmailDomains are domains, any of which a user's "email" claim must be an
+	// address in.
+	EmailDomains []string `json:"emailDomains,omitempty"`
+	// Expressions are CEL expressions, any of which must evaluate to true
+	// against a user's claims.
+	Expressions []string `json:"expressions,omitempty"`
+}
+
+// IsEmpty returns true if the RoleMappingCriteria is nil or specifies no
+// criteria at all.
+func (c *RoleMappingCriteria) IsEmpty() bool {
+	return c == nil ||
+		(len(c.Groups) == 0 && len(c.EmailDomains) == 0 && len(c.Expressions) == 0)
+}
+
+// RoleMappingCriteriaFromAnnotationValues returns the RoleMappingCriteria
+// recorded in the rbac.kargo.akuity.io/role-mapping annotation of a
+// ServiceAccount. If the annotation is not present, nil is returned.
+func RoleMappingCriteriaFromAnnotationValues(annotations map[string]string) (*RoleMappingCriteria, error) {
+	val, ok := annotations[AnnotationKeyRoleMapping]
+	if !ok {
+		return nil, nil
+	}
+	criteria := &RoleMappingCriteria{}
+	if err := json.Unmarshal([]byte(val), criteria); err != nil {
+		return nil, fmt.Errorf("unmarshaling role mapping criteria from annotation value: %w", err)
+	}
+	return criteria, nil
+}
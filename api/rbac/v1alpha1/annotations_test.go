@@ -2,9 +2,11 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -162,3 +164,79 @@ func TestSetOIDCClaimsAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestAPITokenExpiryFromAnnotationValues(t *testing.T) {
+	t.Run("no expiry", func(t *testing.T) {
+		expiry, err := APITokenExpiryFromAnnotationValues(map[string]string{})
+		require.NoError(t, err)
+		require.Nil(t, expiry)
+	})
+	t.Run("invalid expiry", func(t *testing.T) {
+		_, err := APITokenExpiryFromAnnotationValues(map[string]string{
+			AnnotationKeyAPITokenExpiresAt: "tomorrow",
+		})
+		require.ErrorContains(t, err, "parsing API token expiry")
+	})
+	t.Run("valid expiry", func(t *testing.T) {
+		expiry, err := APITokenExpiryFromAnnotationValues(map[string]string{
+			AnnotationKeyAPITokenExpiresAt: "2030-01-02T03:04:05Z",
+		})
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), *expiry)
+	})
+}
+
+func TestAPITokenScopesFromAnnotationValues(t *testing.T) {
+	t.Run("not scoped", func(t *testing.T) {
+		scopes, err := APITokenScopesFromAnnotationValues(map[string]string{})
+		require.NoError(t, err)
+		require.Nil(t, scopes)
+	})
+	t.Run("invalid scopes", func(t *testing.T) {
+		_, err := APITokenScopesFromAnnotationValues(map[string]string{
+			AnnotationKeyAPITokenScopes: "promote",
+		})
+		require.ErrorContains(t, err, "unmarshaling API token scopes")
+	})
+	t.Run("valid scopes", func(t *testing.T) {
+		scopes, err := APITokenScopesFromAnnotationValues(map[string]string{
+			AnnotationKeyAPITokenScopes: `[{"apiGroups":["kargo.akuity.io"],"resources":["stages"],` +
+				`"resourceNames":["uat"],"verbs":["promote"]}]`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []rbacv1.PolicyRule{{
+			APIGroups:     []string{"kargo.akuity.io"},
+			Resources:     []string{"stages"},
+			ResourceNames: []string{"uat"},
+			Verbs:         []string{"promote"},
+		}}, scopes)
+	})
+}
+
+func TestRoleMappingCriteriaFromAnnotationValues(t *testing.T) {
+	t.Run("not mapped", func(t *testing.T) {
+		criteria, err := RoleMappingCriteriaFromAnnotationValues(map[string]string{})
+		require.NoError(t, err)
+		require.Nil(t, criteria)
+		require.True(t, criteria.IsEmpty())
+	})
+	t.Run("invalid criteria", func(t *testing.T) {
+		_, err := RoleMappingCriteriaFromAnnotationValues(map[string]string{
+			AnnotationKeyRoleMapping: "devops",
+		})
+		require.ErrorContains(t, err, "unmarshaling role mapping criteria")
+	})
+	t.Run("valid criteria", func(t *testing.T) {
+		criteria, err := RoleMappingCriteriaFromAnnotationValues(map[string]string{
+			AnnotationKeyRoleMapping: `{"groups":["devops"],"emailDomains":["example.com"],` +
+				`"expressions":["claims.department == 'payments'"]}`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &RoleMappingCriteria{
+			Groups:       []string{"devops"},
+			EmailDomains: []string{"example.com"},
+			Expressions:  []string{"claims.department == 'payments'"},
+		}, criteria)
+		require.False(t, criteria.IsEmpty())
+	})
+}
@@ -131,4 +131,10 @@ const (
 	// AnnotationKeyReplicatedAt is set on replicated resources to record the
 	// UTC timestamp of the most recent replication.
 	AnnotationKeyReplicatedAt = "kargo.akuity.io/replicated-at"
+
+	// AnnotationKeyCredentialHealth is set on repository credential Secrets by
+	// the management controller to record the outcome of the most recent health
+	// check of the credentials and which Warehouses and Stages last used them.
+	// The value is a JSON-encoded CredentialHealth.
+	AnnotationKeyCredentialHealth = "kargo.akuity.io/credential-health"
 )
@@ -101,4 +101,15 @@ const (
 	// the criteria have been satisfied, and the absence of the condition or
 	// a status of "False" indicates that no new Freight was created.
 	ConditionTypeFreightCreated = "FreightCreated"
+
+	// ConditionTypeThrottled denotes that artifact discovery for a Warehouse
+	// has been deferred because an upstream registry or repository asked for
+	// requests to be paused, e.g. by responding with HTTP 429.
+	//
+	// This is a "normal-false" or "negative polarity" condition, meaning
+	// that the presence of the condition with a status of "True" indicates
+	// that discovery is being throttled, and the absence of the condition or
+	// a status of "False" indicates that it is not. Throttling is not a
+	// failure; discovery is retried once the pause has elapsed.
+	ConditionTypeThrottled = "Throttled"
 )
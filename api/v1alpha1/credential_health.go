@@ -0,0 +1,78 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialHealth describes the outcome of the most recent health check of a
+// set of repository credentials and which resources last used them. It is
+// recorded on credential Secrets using the AnnotationKeyCredentialHealth
+// annotation. To avoid needlessly updating Secrets, it is only recorded when
+// it differs from the previously recorded CredentialHealth in something other
+// than CheckedAt.
+//
+// Usage of credentials is not recorded at the time they are used. Instead,
+// which resources used them, and when, is inferred from the repositories
+// Warehouses subscribe to, the repositories referenced by the Freight of each
+// Stage's last Promotion, and the repository URLs that appear literally in
+// the git steps of each Stage's promotion template.
+//
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type CredentialHealth struct {
+	// CheckedAt is the time of the check that produced this CredentialHealth.
+	// Subsequent checks with the same outcome are not recorded, so this is the
+	// time at which the outcome last changed rather than the time of the most
+	// recent check.
+	CheckedAt *metav1.Time `json:"checkedAt,omitempty"`
+	// RepoURL is the URL of the repository against which the credentials were
+	// last checked.
+	RepoURL string `json:"repoURL,omitempty"`
+	// Conditions contains the last observations of the credentials' state. The
+	// only condition type currently in use is ConditionTypeHealthy.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// UsedBy lists the Warehouses and Stages that, judging by their specs and
+	// statuses, use the credentials.
+	UsedBy []CredentialUser `json:"usedBy,omitempty"`
+}
+
+// GetConditions implements the conditions.Getter interface.
+func (h *CredentialHealth) GetConditions() []metav1.Condition {
+	return h.Conditions
+}
+
+// String returns the JSON string representation of the CredentialHealth, or
+// an empty string if the CredentialHealth is nil.
+func (h *CredentialHealth) String() string {
+	if h == nil {
+		return ""
+	}
+	b, _ := json.Marshal(h)
+	if b == nil {
+		return ""
+	}
+	return string(b)
+}
+
+// CredentialUser describes a resource that used a set of repository
+// credentials.
+//
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type CredentialUser struct {
+	// Kind is the kind of the resource. e.g. Warehouse or Stage.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// RepoURL is the URL of the repository the resource accessed using the
+	// credentials.
+	RepoURL string `json:"repoURL,omitempty"`
+	// LastUsedAt is the last time the resource is known to have used the
+	// credentials. For a Warehouse, this is the time it last discovered
+	// artifacts. For a Stage, this is the time its last Promotion finished.
+	LastUsedAt *metav1.Time `json:"lastUsedAt,omitempty"`
+}
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -134,6 +135,11 @@ type FreightStatus struct {
 	// might wish to promote a piece of Freight to a given Stage without
 	// transiting the entire pipeline.
 	ApprovedFor map[string]ApprovedStage `json:"approvedFor,omitempty"`
+	// PendingApprovals describes the Stages for which this Freight has been
+	// approved by some, but not yet enough, users to satisfy the Stage's
+	// ApprovalPolicy. Once the policy is satisfied, the Stage's entry is moved
+	// to ApprovedFor.
+	PendingApprovals map[string]PendingApproval `json:"pendingApprovals,omitempty"`
 	// Metadata is a map of arbitrary metadata associated with the Freight.
 	// This is useful for storing additional information about the Freight
 	// or Promotion that can be shared across steps or stages.
@@ -256,6 +262,47 @@ func (f *FreightStatus) AddApprovedStage(stage string, approvedAt time.Time) {
 	}
 }
 
+// AddApprover records the provided approver's approval of the Freight for the
+// specified Stage. If, together with any approvals already pending, the
+// approval satisfies the provided ApprovalPolicy, the Freight is approved for
+// the Stage and its pending approvals are cleared. Approvals by an approver
+// who has already approved the Freight for the Stage are ignored. The return
+// value indicates whether the Freight is approved for the Stage.
+func (f *FreightStatus) AddApprover(
+	stage string,
+	approver Approver,
+	policy *ApprovalPolicy,
+) bool {
+	if _, approved := f.ApprovedFor[stage]; approved {
+		return true
+	}
+	pending := f.PendingApprovals[stage]
+	if !slices.ContainsFunc(pending.Approvers, func(a Approver) bool {
+		return a.Name == approver.Name
+	}) {
+		pending.Approvers = append(pending.Approvers, approver)
+	}
+	if !policy.IsSatisfiedBy(pending.Approvers) {
+		if f.PendingApprovals == nil {
+			f.PendingApprovals = make(map[string]PendingApproval, 1)
+		}
+		f.PendingApprovals[stage] = pending
+		return false
+	}
+	delete(f.PendingApprovals, stage)
+	if len(f.PendingApprovals) == 0 {
+		f.PendingApprovals = nil
+	}
+	if f.ApprovedFor == nil {
+		f.ApprovedFor = make(map[string]ApprovedStage, 1)
+	}
+	f.ApprovedFor[stage] = ApprovedStage{
+		ApprovedAt: approver.ApprovedAt,
+		Approvers:  pending.Approvers,
+	}
+	return true
+}
+
 // UpsertMetadata inserts or updates the given key in Freight status Metadata
 func (f *FreightStatus) UpsertMetadata(key string, data any) error {
 	if len(f.Metadata) == 0 {
@@ -315,6 +362,28 @@ type VerifiedStage struct {
 type ApprovedStage struct {
 	// ApprovedAt is the time at which the Freight was approved for the Stage.
 	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+	// Approvers lists the users whose approvals satisfied the Stage's
+	// ApprovalPolicy. It is empty for approvals that predate approval policies
+	// or that were not made by a user.
+	Approvers []Approver `json:"approvers,omitempty"`
+}
+
+// PendingApproval describes approvals of Freight for a Stage that do not yet
+// satisfy the Stage's ApprovalPolicy.
+type PendingApproval struct {
+	// Approvers lists the users who have approved the Freight for the Stage so
+	// far.
+	Approvers []Approver `json:"approvers,omitempty"`
+}
+
+// Approver describes a user's approval of Freight for a Stage.
+type Approver struct {
+	// Name identifies the user who approved the Freight.
+	Name string `json:"name"`
+	// Groups lists the groups the user belonged to when approving the Freight.
+	Groups []string `json:"groups,omitempty"`
+	// ApprovedAt is the time at which the user approved the Freight.
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -496,6 +496,47 @@ func TestFreightStatus_AddApprovedStage(t *testing.T) {
 	})
 }
 
+func TestFreightStatus_AddApprover(t *testing.T) {
+	const testStage = "fake-stage"
+	policy := &ApprovalPolicy{
+		RequiredApprovals: 2,
+		RequiredGroups:    []string{"sre"},
+	}
+	t.Run("already approved", func(t *testing.T) {
+		status := FreightStatus{
+			ApprovedFor: map[string]ApprovedStage{testStage: {}},
+		}
+		require.True(t, status.AddApprover(testStage, Approver{Name: "alice"}, policy))
+		require.Empty(t, status.ApprovedFor[testStage].Approvers)
+		require.Empty(t, status.PendingApprovals)
+	})
+	t.Run("no policy", func(t *testing.T) {
+		status := FreightStatus{}
+		require.True(t, status.AddApprover(testStage, Approver{Name: "alice"}, nil))
+		require.Equal(t, []Approver{{Name: "alice"}}, status.ApprovedFor[testStage].Approvers)
+		require.Nil(t, status.PendingApprovals)
+	})
+	t.Run("policy satisfied over several approvals", func(t *testing.T) {
+		status := FreightStatus{}
+		require.False(t, status.AddApprover(testStage, Approver{Name: "alice"}, policy))
+		require.Len(t, status.PendingApprovals[testStage].Approvers, 1)
+		// Repeated approvals by the same approver are ignored.
+		require.False(t, status.AddApprover(testStage, Approver{Name: "alice"}, policy))
+		require.Len(t, status.PendingApprovals[testStage].Approvers, 1)
+		// Enough approvers, but no one from a required group.
+		require.False(t, status.AddApprover(testStage, Approver{Name: "bob"}, policy))
+		require.Len(t, status.PendingApprovals[testStage].Approvers, 2)
+		require.NotContains(t, status.ApprovedFor, testStage)
+		require.True(t, status.AddApprover(
+			testStage,
+			Approver{Name: "carol", Groups: []string{"sre"}},
+			policy,
+		))
+		require.Len(t, status.ApprovedFor[testStage].Approvers, 3)
+		require.Nil(t, status.PendingApprovals)
+	})
+}
+
 func TestFreightStatus_UpsertMetadata(t *testing.T) {
 	testCases := []struct {
 		name         string
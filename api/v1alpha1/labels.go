@@ -24,6 +24,15 @@ const (
 	// credentials. A Secret with this label value is expected to contain
 	// credentials for a container image registry.
 	LabelValueCredentialTypeImage = "image"
+	// LabelValueCredentialTypeHTTP is the value for HTTP credentials. A Secret
+	// with this label value is expected to contain credentials for an HTTP
+	// server or S3-compatible object storage that artifacts are discovered from.
+	LabelValueCredentialTypeHTTP = "http"
+	// LabelValueCredentialTypePackage is the value for language package
+	// registry credentials. A Secret with this label value is expected to
+	// contain credentials for an npm registry, a Maven repository or a Python
+	// package index.
+	LabelValueCredentialTypePackage = "package"
 	// LabelValueCredentialTypeGeneric is the value for generic credentials.
 	// A Secret with this label can contain any type of credential, and is
 	// allowed to be managed through the Kargo API.
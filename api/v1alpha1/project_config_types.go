@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -57,6 +59,42 @@ type ProjectConfigSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	PromotionWindows []PromotionWindow `json:"promotionWindows,omitempty"`
+	// RoleMappings declaratively map users authenticated via OIDC to Kargo
+	// Roles in this Project on the basis of their claims. Mappings are applied
+	// in addition to, and never replace, any claims associated with a Role by
+	// other means.
+	//
+	// +optional
+	RoleMappings []RoleMapping `json:"roleMappings,omitempty"`
+}
+
+// RoleMapping maps users authenticated via OIDC to a Kargo Role on the basis
+// of their claims. A user is mapped to the Role if they satisfy ANY of the
+// criteria specified.
+type RoleMapping struct {
+	// Role is the name of a Kargo Role in the Project.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// Groups maps any user whose "groups" claim includes any of the specified
+	// groups to the Role.
+	//
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// EmailDomains maps any user whose "email" claim is an address in any of
+	// the specified domains to the Role. Users whose "email_verified" claim is
+	// false are never mapped on the basis of their email address.
+	//
+	// +optional
+	EmailDomains []string `json:"emailDomains,omitempty"`
+	// Expressions maps any user for whom any of the specified CEL expressions
+	// evaluates to true to the Role. Each expression has access to all of the
+	// user's claims via the claims variable.
+	// e.g. 'claims.department == "payments"'
+	//
+	// +optional
+	// +kubebuilder:validation:items:MaxLength=1024
+	Expressions []string `json:"expressions,omitempty"`
 }
 
 // ProjectConfigStatus describes the current status of a ProjectConfig.
@@ -174,6 +212,68 @@ type PromotionPolicy struct {
 	//
 	// Kargo Enterprise only: This field is ignored in Kargo OSS.
 	AutoRollback *AutoRollbackConfig `json:"autoRollback,omitempty"`
+	// Approval describes the approvals Freight requires before it is approved
+	// for promotion to the Stage. When nil, a single approval by any user
+	// permitted to promote to the Stage suffices.
+	Approval *ApprovalPolicy `json:"approval,omitempty"`
+}
+
+// ApprovalPolicy describes the approvals Freight requires before it is
+// approved for promotion to a Stage.
+type ApprovalPolicy struct {
+	// RequiredApprovals is the number of distinct users who must approve Freight
+	// before it is approved for promotion to the Stage. Defaults to 1.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	RequiredApprovals int32 `json:"requiredApprovals,omitempty"`
+	// RequiredGroups is a list of groups, each of which must be represented by
+	// at least one of the users approving Freight. Group membership is
+	// determined using the groups claim of each user's OIDC identity.
+	//
+	// +kubebuilder:validation:items:MinLength=1
+	// +listType=set
+	RequiredGroups []string `json:"requiredGroups,omitempty"`
+	// AllowCommitAuthorApproval indicates whether users who authored a commit
+	// referenced by Freight may approve that Freight. Authors are identified by
+	// email address. Defaults to false.
+	AllowCommitAuthorApproval bool `json:"allowCommitAuthorApproval,omitempty"`
+}
+
+// GetRequiredApprovals returns the number of distinct users who must approve
+// Freight. It is never less than one, including when the ApprovalPolicy is
+// nil.
+func (a *ApprovalPolicy) GetRequiredApprovals() int32 {
+	if a == nil || a.RequiredApprovals < 1 {
+		return 1
+	}
+	return a.RequiredApprovals
+}
+
+// Outstanding returns the number of additional approvals required for the
+// provided approvers to satisfy the ApprovalPolicy, along with any required
+// groups not yet represented among them.
+func (a *ApprovalPolicy) Outstanding(approvers []Approver) (int32, []string) {
+	remaining := max(a.GetRequiredApprovals()-int32(len(approvers)), 0) // nolint: gosec
+	if a == nil {
+		return remaining, nil
+	}
+	var missingGroups []string
+	for _, group := range a.RequiredGroups {
+		if !slices.ContainsFunc(approvers, func(approver Approver) bool {
+			return slices.Contains(approver.Groups, group)
+		}) {
+			missingGroups = append(missingGroups, group)
+		}
+	}
+	return remaining, missingGroups
+}
+
+// IsSatisfiedBy returns whether the provided approvers satisfy the
+// ApprovalPolicy.
+func (a *ApprovalPolicy) IsSatisfiedBy(approvers []Approver) bool {
+	remaining, missingGroups := a.Outstanding(approvers)
+	return remaining == 0 && len(missingGroups) == 0
 }
 
 // WebhookReceiverConfig describes the configuration for a single webhook
@@ -214,6 +314,21 @@ type WebhookReceiverConfig struct {
 	// Gitea contains the configuration for a webhook receiver that is compatible
 	// with Gitea payloads.
 	Gitea *GiteaWebhookReceiverConfig `json:"gitea,omitempty"`
+	// ECR contains the configuration for a webhook receiver that is compatible
+	// with Amazon Elastic Container Registry (ECR) events delivered by Amazon
+	// EventBridge via an API destination.
+	ECR *ECRWebhookReceiverConfig `json:"ecr,omitempty"`
+	// ArtifactRegistry contains the configuration for a webhook receiver that is
+	// compatible with Google Artifact Registry notifications delivered by a
+	// Google Cloud Pub/Sub push subscription.
+	ArtifactRegistry *ArtifactRegistryWebhookReceiverConfig `json:"artifactRegistry,omitempty"`
+	// AzureEventGrid contains the configuration for a webhook receiver that is
+	// compatible with Azure Container Registry (ACR) events delivered by Azure
+	// Event Grid.
+	AzureEventGrid *AzureEventGridWebhookReceiverConfig `json:"azureEventGrid,omitempty"`
+	// CloudEvents contains the configuration for a webhook receiver that accepts
+	// CloudEvents from any compliant producer.
+	CloudEvents *CloudEventsWebhookReceiverConfig `json:"cloudEvents,omitempty"`
 	// Generic contains the configuration for a generic webhook receiver.
 	Generic *GenericWebhookReceiverConfig `json:"generic,omitempty"`
 }
@@ -237,6 +352,130 @@ type GiteaWebhookReceiverConfig struct {
 	SecretRef corev1.LocalObjectReference `json:"secretRef"`
 }
 
+// ECRWebhookReceiverConfig describes a webhook receiver that is compatible
+// with Amazon Elastic Container Registry (ECR) events delivered by Amazon
+// EventBridge via an API destination.
+type ECRWebhookReceiverConfig struct {
+	// SecretRef contains a reference to a Secret. For Project-scoped webhook
+	// receivers, the referenced Secret must be in the same namespace as the
+	// ProjectConfig.
+	//
+	// For cluster-scoped webhook receivers, the referenced Secret must be in the
+	// designated "system resources" namespace.
+	//
+	// The Secret's data map is expected to contain a `secret` key whose value is
+	// the shared secret used to authenticate the webhook requests sent by
+	// EventBridge. The EventBridge connection used by the API destination must be
+	// configured to use API key authorization, with `Authorization` as the API
+	// key name and the shared secret as the value. For more information please
+	// refer to the Amazon EventBridge documentation:
+	//   https://docs.aws.amazon.com/eventbridge/latest/userguide/eb-api-destinations.html
+	//
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// ArtifactRegistryWebhookReceiverConfig describes a webhook receiver that is
+// compatible with Google Artifact Registry notifications delivered by a Google
+// Cloud Pub/Sub push subscription.
+type ArtifactRegistryWebhookReceiverConfig struct {
+	// SecretRef contains a reference to a Secret. For Project-scoped webhook
+	// receivers, the referenced Secret must be in the same namespace as the
+	// ProjectConfig.
+	//
+	// For cluster-scoped webhook receivers, the referenced Secret must be in the
+	// designated "system resources" namespace.
+	//
+	// The Secret's data map is expected to contain a `secret` key whose value
+	// does NOT need to be shared directly with Google Cloud when creating a push
+	// subscription. It is used only by Kargo to create a complex, hard-to-guess
+	// URL, which implicitly serves as a shared secret. For more information
+	// about Artifact Registry notifications, please refer to the Google Cloud
+	// documentation:
+	//   https://cloud.google.com/artifact-registry/docs/configure-notifications
+	//
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// AzureEventGridWebhookReceiverConfig describes a webhook receiver that is
+// compatible with Azure Container Registry (ACR) events delivered by Azure
+// Event Grid.
+type AzureEventGridWebhookReceiverConfig struct {
+	// SecretRef contains a reference to a Secret. For Project-scoped webhook
+	// receivers, the referenced Secret must be in the same namespace as the
+	// ProjectConfig.
+	//
+	// For cluster-scoped webhook receivers, the referenced Secret must be in the
+	// designated "system resources" namespace.
+	//
+	// The Secret's data map is expected to contain a `secret` key whose value
+	// does NOT need to be shared directly with Azure when creating an Event Grid
+	// subscription. It is used only by Kargo to create a complex, hard-to-guess
+	// URL, which implicitly serves as a shared secret. For more information
+	// about Azure Container Registry events, please refer to the Azure
+	// documentation:
+	//   https://learn.microsoft.com/en-us/azure/event-grid/event-schema-container-registry
+	//
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// CloudEventsWebhookReceiverConfig describes a webhook receiver that accepts
+// CloudEvents in either the binary or structured content mode of the
+// CloudEvents HTTP protocol binding and refreshes the Warehouses or Stages
+// selected by the routes matching each event.
+type CloudEventsWebhookReceiverConfig struct {
+	// SecretRef contains a reference to a Secret. For Project-scoped webhook
+	// receivers, the referenced Secret must be in the same namespace as the
+	// ProjectConfig.
+	//
+	// For cluster-scoped webhook receivers, the referenced Secret must be in the
+	// designated "system resources" namespace.
+	//
+	// The Secret's data map is expected to contain a `secret` key whose value is
+	// used to authenticate requests. Senders must either present the secret as
+	// a bearer token in the `Authorization` header, or use it to compute an
+	// HMAC-SHA256 signature of the request body, which must be sent, hex-encoded
+	// and prefixed with `sha256=`, in the `X-Signature-256` header.
+	//
+	// +kubebuilder:validation:Required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Routes is a list of routes that determine which resources are refreshed
+	// upon receipt of an event. Every route matching an event is applied.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Routes []CloudEventsWebhookRoute `json:"routes,omitempty"`
+}
+
+// CloudEventsWebhookRoute describes which CloudEvents a route matches and
+// which resources should be refreshed when it does. Type, Source and Subject
+// each match exactly unless prefixed with "glob:" or "regex:". A route
+// specifying none of them matches every event.
+type CloudEventsWebhookRoute struct {
+	// Type is a pattern that the `type` attribute of an event must match.
+	//
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Source is a pattern that the `source` attribute of an event must match.
+	//
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Subject is a pattern that the `subject` attribute of an event must match.
+	// An event without a subject never matches a route specifying one.
+	//
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// TargetSelectionCriteria is a list of selection criteria for the
+	// resources to be refreshed when an event matches this route. Only the
+	// Warehouse and Stage kinds are supported. Values may be expressions
+	// referencing the event as `event`.
+	//
+	// +kubebuilder:validation:MinItems=1
+	TargetSelectionCriteria []GenericWebhookTargetSelectionCriteria `json:"targetSelectionCriteria,omitempty"`
+}
+
 // BitbucketWebhookReceiverConfig describes a webhook receiver that is
 // compatible with Bitbucket payloads.
 type BitbucketWebhookReceiverConfig struct {
@@ -430,11 +669,11 @@ type AzureWebhookReceiverConfig struct {
 // configured to respond to any arbitrary POST by applying user-defined actions
 // on user-defined sets of resources selected by name, labels and/or values in pre-built indices.
 // Both types of selectors support using values extracted from the request by
-// means of expressions. Currently, refreshing resources is the only supported
-// action and Warehouse is the only supported kind. "Refreshing" means
-// immediately enqueuing the target resource for reconciliation by its
-// controller. The practical effect of refreshing a Warehouses is triggering its
-// artifact discovery process.
+// means of expressions. Supported actions are refreshing resources, promoting
+// Freight to Stages, approving Freight for Stages, and setting metadata on
+// Stages or Freight. "Refreshing" means immediately enqueuing the target
+// resource for reconciliation by its controller. The practical effect of
+// refreshing a Warehouses is triggering its artifact discovery process.
 type GenericWebhookReceiverConfig struct {
 	// SecretRef contains a reference to a Secret. For Project-scoped webhook
 	// receivers, the referenced Secret must be in the same namespace as the
@@ -460,10 +699,23 @@ type GenericWebhookReceiverConfig struct {
 // GenericWebhookAction describes an action to be performed on a resource
 // and the conditions under which it should be performed.
 type GenericWebhookAction struct {
-	// ActionType indicates the type of action to be performed. `Refresh` is the
-	// only currently supported action.
+	// ActionType indicates the type of action to be performed.
+	//
+	// `Refresh` enqueues target resources of any kind for immediate
+	// reconciliation.
+	//
+	// `Promote` creates a Promotion of the Freight specified by the `freight`
+	// (name or alias) or `origin` (e.g. `Warehouse/my-warehouse`) parameter for
+	// each target Stage.
+	//
+	// `Approve` approves the Freight specified by the `freight` (name or alias)
+	// parameter for each target Stage.
+	//
+	// `SetMetadata` sets a metadata entry on each target Stage or Freight for
+	// every parameter, using the parameter's key as the metadata key and the
+	// result of evaluating the parameter's value as the metadata value.
 	//
-	// +kubebuilder:validation:Enum=Refresh;
+	// +kubebuilder:validation:Enum=Refresh;Promote;Approve;SetMetadata;
 	ActionType GenericWebhookActionType `json:"action"`
 
 	// WhenExpression defines criteria that a request must meet to run this
@@ -473,7 +725,8 @@ type GenericWebhookAction struct {
 	WhenExpression string `json:"whenExpression,omitempty"`
 
 	// Parameters contains additional, action-specific parameters. Values may be
-	// static or extracted from the request using expressions.
+	// static or extracted from the request using expressions. Refer to
+	// ActionType for the parameters supported by each action.
 	//
 	// +optional
 	Parameters map[string]string `json:"parameters,omitempty"`
@@ -491,6 +744,15 @@ type GenericWebhookActionType string
 const (
 	// GenericWebhookActionTypeRefresh indicates a request to refresh the resource.
 	GenericWebhookActionTypeRefresh GenericWebhookActionType = "Refresh"
+	// GenericWebhookActionTypePromote indicates a request to promote Freight to
+	// the resource, which must be a Stage.
+	GenericWebhookActionTypePromote GenericWebhookActionType = "Promote"
+	// GenericWebhookActionTypeApprove indicates a request to approve Freight for
+	// the resource, which must be a Stage.
+	GenericWebhookActionTypeApprove GenericWebhookActionType = "Approve"
+	// GenericWebhookActionTypeSetMetadata indicates a request to set metadata on
+	// the resource, which must be a Stage or Freight.
+	GenericWebhookActionTypeSetMetadata GenericWebhookActionType = "SetMetadata"
 )
 
 // GenericWebhookTargetSelectionCriteria describes selection criteria for resources to which some
@@ -500,7 +762,7 @@ const (
 type GenericWebhookTargetSelectionCriteria struct {
 	// Kind is the kind of the target resource.
 	//
-	// +kubebuilder:validation:Enum=Warehouse;
+	// +kubebuilder:validation:Enum=Warehouse;Promotion;Stage;Freight;
 	Kind GenericWebhookTargetKind `json:"kind"`
 
 	// Name is the name of the target resource. If LabelSelector and/or IndexSelectors
@@ -528,6 +790,8 @@ type GenericWebhookTargetKind string
 const (
 	GenericWebhookTargetKindWarehouse GenericWebhookTargetKind = "Warehouse"
 	GenericWebhookTargetKindPromotion GenericWebhookTargetKind = "Promotion"
+	GenericWebhookTargetKindStage     GenericWebhookTargetKind = "Stage"
+	GenericWebhookTargetKindFreight   GenericWebhookTargetKind = "Freight"
 )
 
 // IndexSelector defines selection criteria that match resources on the basis of
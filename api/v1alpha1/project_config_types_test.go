@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalPolicy_Outstanding(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		policy                *ApprovalPolicy
+		approvers             []Approver
+		expectedRemaining     int32
+		expectedMissingGroups []string
+	}{
+		{
+			name:              "nil policy without approvers",
+			expectedRemaining: 1,
+		},
+		{
+			name:      "nil policy with approver",
+			approvers: []Approver{{Name: "alice"}},
+		},
+		{
+			name:              "required approvals not met",
+			policy:            &ApprovalPolicy{RequiredApprovals: 3},
+			approvers:         []Approver{{Name: "alice"}},
+			expectedRemaining: 2,
+		},
+		{
+			name: "required groups not met",
+			policy: &ApprovalPolicy{
+				RequiredApprovals: 2,
+				RequiredGroups:    []string{"sre", "security"},
+			},
+			approvers: []Approver{
+				{Name: "alice", Groups: []string{"sre"}},
+				{Name: "bob", Groups: []string{"developers"}},
+			},
+			expectedMissingGroups: []string{"security"},
+		},
+		{
+			name: "satisfied",
+			policy: &ApprovalPolicy{
+				RequiredApprovals: 2,
+				RequiredGroups:    []string{"sre", "security"},
+			},
+			approvers: []Approver{
+				{Name: "alice", Groups: []string{"sre", "security"}},
+				{Name: "bob"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			remaining, missingGroups := testCase.policy.Outstanding(testCase.approvers)
+			require.Equal(t, testCase.expectedRemaining, remaining)
+			require.Equal(t, testCase.expectedMissingGroups, missingGroups)
+			require.Equal(
+				t,
+				remaining == 0 && len(missingGroups) == 0,
+				testCase.policy.IsSatisfiedBy(testCase.approvers),
+			)
+		})
+	}
+}
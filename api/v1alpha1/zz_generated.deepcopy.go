@@ -85,6 +85,26 @@ func (in *AnalysisTemplateReference) DeepCopy() *AnalysisTemplateReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalPolicy) DeepCopyInto(out *ApprovalPolicy) {
+	*out = *in
+	if in.RequiredGroups != nil {
+		in, out := &in.RequiredGroups, &out.RequiredGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicy.
+func (in *ApprovalPolicy) DeepCopy() *ApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovedStage) DeepCopyInto(out *ApprovedStage) {
 	*out = *in
@@ -92,6 +112,13 @@ func (in *ApprovedStage) DeepCopyInto(out *ApprovedStage) {
 		in, out := &in.ApprovedAt, &out.ApprovedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Approver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovedStage.
@@ -104,6 +131,30 @@ func (in *ApprovedStage) DeepCopy() *ApprovedStage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approver) DeepCopyInto(out *Approver) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovedAt != nil {
+		in, out := &in.ApprovedAt, &out.ApprovedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approver.
+func (in *Approver) DeepCopy() *Approver {
+	if in == nil {
+		return nil
+	}
+	out := new(Approver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDAppHealthStatus) DeepCopyInto(out *ArgoCDAppHealthStatus) {
 	*out = *in
@@ -176,6 +227,22 @@ func (in *ArtifactReference) DeepCopy() *ArtifactReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRegistryWebhookReceiverConfig) DeepCopyInto(out *ArtifactRegistryWebhookReceiverConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRegistryWebhookReceiverConfig.
+func (in *ArtifactRegistryWebhookReceiverConfig) DeepCopy() *ArtifactRegistryWebhookReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRegistryWebhookReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactoryWebhookReceiverConfig) DeepCopyInto(out *ArtifactoryWebhookReceiverConfig) {
 	*out = *in
@@ -252,6 +319,22 @@ func (in *AutoRollbackConfig) DeepCopy() *AutoRollbackConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureEventGridWebhookReceiverConfig) DeepCopyInto(out *AzureEventGridWebhookReceiverConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureEventGridWebhookReceiverConfig.
+func (in *AzureEventGridWebhookReceiverConfig) DeepCopy() *AzureEventGridWebhookReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureEventGridWebhookReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureWebhookReceiverConfig) DeepCopyInto(out *AzureWebhookReceiverConfig) {
 	*out = *in
@@ -334,6 +417,51 @@ func (in *ChartSubscription) DeepCopy() *ChartSubscription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsWebhookReceiverConfig) DeepCopyInto(out *CloudEventsWebhookReceiverConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]CloudEventsWebhookRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsWebhookReceiverConfig.
+func (in *CloudEventsWebhookReceiverConfig) DeepCopy() *CloudEventsWebhookReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsWebhookReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsWebhookRoute) DeepCopyInto(out *CloudEventsWebhookRoute) {
+	*out = *in
+	if in.TargetSelectionCriteria != nil {
+		in, out := &in.TargetSelectionCriteria, &out.TargetSelectionCriteria
+		*out = make([]GenericWebhookTargetSelectionCriteria, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsWebhookRoute.
+func (in *CloudEventsWebhookRoute) DeepCopy() *CloudEventsWebhookRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsWebhookRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -698,6 +826,22 @@ func (in *DockerHubWebhookReceiverConfig) DeepCopy() *DockerHubWebhookReceiverCo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ECRWebhookReceiverConfig) DeepCopyInto(out *ECRWebhookReceiverConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ECRWebhookReceiverConfig.
+func (in *ECRWebhookReceiverConfig) DeepCopy() *ECRWebhookReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ECRWebhookReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpressionVariable) DeepCopyInto(out *ExpressionVariable) {
 	*out = *in
@@ -995,6 +1139,13 @@ func (in *FreightStatus) DeepCopyInto(out *FreightStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PendingApprovals != nil {
+		in, out := &in.PendingApprovals, &out.PendingApprovals
+		*out = make(map[string]PendingApproval, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]apiextensionsv1.JSON, len(*in))
@@ -1462,6 +1613,28 @@ func (in *IndexSelectorRequirement) DeepCopy() *IndexSelectorRequirement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingApproval) DeepCopyInto(out *PendingApproval) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Approver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingApproval.
+func (in *PendingApproval) DeepCopy() *PendingApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
@@ -1581,6 +1754,13 @@ func (in *ProjectConfigSpec) DeepCopyInto(out *ProjectConfigSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]RoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectConfigSpec.
@@ -1768,6 +1948,11 @@ func (in *PromotionPolicy) DeepCopyInto(out *PromotionPolicy) {
 		*out = new(AutoRollbackConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPolicy.
@@ -2413,6 +2598,36 @@ func (in *RepoSubscription) DeepCopy() *RepoSubscription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailDomains != nil {
+		in, out := &in.EmailDomains, &out.EmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMapping.
+func (in *RoleMapping) DeepCopy() *RoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stage) DeepCopyInto(out *Stage) {
 	*out = *in
@@ -3084,6 +3299,26 @@ func (in *WebhookReceiverConfig) DeepCopyInto(out *WebhookReceiverConfig) {
 		*out = new(GiteaWebhookReceiverConfig)
 		**out = **in
 	}
+	if in.ECR != nil {
+		in, out := &in.ECR, &out.ECR
+		*out = new(ECRWebhookReceiverConfig)
+		**out = **in
+	}
+	if in.ArtifactRegistry != nil {
+		in, out := &in.ArtifactRegistry, &out.ArtifactRegistry
+		*out = new(ArtifactRegistryWebhookReceiverConfig)
+		**out = **in
+	}
+	if in.AzureEventGrid != nil {
+		in, out := &in.AzureEventGrid, &out.AzureEventGrid
+		*out = new(AzureEventGridWebhookReceiverConfig)
+		**out = **in
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEventsWebhookReceiverConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Generic != nil {
 		in, out := &in.Generic, &out.Generic
 		*out = new(GenericWebhookReceiverConfig)
@@ -17,6 +17,12 @@ type ChartSubscription struct {
 	// specified by the repoURL field. This field is required when the repoURL field points to a
 	// classic chart repository and MUST otherwise be empty.
 	Name string `json:"name,omitempty"`
+	// RateLimit is an optional limit, in requests per second, on requests made to the chart
+	// repository on behalf of this subscription. It may lower, but never raise, the limit
+	// configured for all interactions with the chart repository, which continues to apply to
+	// these requests as well. When left unspecified, only that limit applies. Subscriptions to
+	// the same chart repository with the same limit share it.
+	RateLimit int64 `json:"rateLimit,omitempty"`
 	// RepoURL specifies the URL of a Helm chart repository. It may be a classic chart
 	// repository (using HTTP/S) OR a repository within an OCI registry. Classic chart
 	// repositories can contain differently named charts. When this field points to such a
@@ -144,6 +150,12 @@ type ImageSubscription struct {
 	// Care should be taken to set this value correctly in cases where the image will run on a
 	// Kubernetes node with a different OS/architecture than the Kargo controller.
 	Platform string `json:"platform,omitempty"`
+	// RateLimit is an optional limit, in requests per second, on requests made to the image
+	// registry on behalf of this subscription. It may lower, but never raise, the limit
+	// configured for all interactions with the image registry, which continues to apply to
+	// these requests as well. When left unspecified, only that limit applies. Subscriptions to
+	// the same image registry with the same limit share it.
+	RateLimit int64 `json:"rateLimit,omitempty"`
 	// RepoURL specifies the URL of the image repository to subscribe to. The value in this
 	// field MUST NOT include an image tag. This field is required.
 	RepoURL string `json:"repoURL"`
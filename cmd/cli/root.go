@@ -12,6 +12,8 @@ import (
 	"github.com/akuity/kargo/pkg/cli/cmd/create"
 	"github.com/akuity/kargo/pkg/cli/cmd/dashboard"
 	"github.com/akuity/kargo/pkg/cli/cmd/delete"
+	"github.com/akuity/kargo/pkg/cli/cmd/diff"
+	"github.com/akuity/kargo/pkg/cli/cmd/discover"
 	"github.com/akuity/kargo/pkg/cli/cmd/get"
 	"github.com/akuity/kargo/pkg/cli/cmd/grant"
 	"github.com/akuity/kargo/pkg/cli/cmd/login"
@@ -20,6 +22,7 @@ import (
 	"github.com/akuity/kargo/pkg/cli/cmd/promote"
 	"github.com/akuity/kargo/pkg/cli/cmd/refresh"
 	"github.com/akuity/kargo/pkg/cli/cmd/revoke"
+	"github.com/akuity/kargo/pkg/cli/cmd/run"
 	"github.com/akuity/kargo/pkg/cli/cmd/server"
 	"github.com/akuity/kargo/pkg/cli/cmd/update"
 	"github.com/akuity/kargo/pkg/cli/cmd/verify"
@@ -44,10 +47,12 @@ func NewRootCommand(cfg clicfg.CLIConfig) *cobra.Command {
 
 	// Register the subcommands.
 	cmd.AddCommand(apply.NewCommand(cfg, streams))
-	cmd.AddCommand(approve.NewCommand(cfg))
+	cmd.AddCommand(approve.NewCommand(cfg, streams))
 	cmd.AddCommand(cliconfigcmd.NewCommand(cfg, streams))
 	cmd.AddCommand(create.NewCommand(cfg, streams))
 	cmd.AddCommand(delete.NewCommand(cfg, streams))
+	cmd.AddCommand(diff.NewCommand(cfg, streams))
+	cmd.AddCommand(discover.NewCommand(cfg, streams))
 	cmd.AddCommand(get.NewCommand(cfg, streams))
 	cmd.AddCommand(grant.NewCommand(cfg, streams))
 	cmd.AddCommand(login.NewCommand(cfg))
@@ -55,6 +60,7 @@ func NewRootCommand(cfg clicfg.CLIConfig) *cobra.Command {
 	cmd.AddCommand(logout.NewCommand())
 	cmd.AddCommand(refresh.NewCommand(cfg))
 	cmd.AddCommand(revoke.NewCommand(cfg, streams))
+	cmd.AddCommand(run.NewCommand(cfg, streams))
 	cmd.AddCommand(update.NewCommand(cfg, streams))
 	cmd.AddCommand(dashboard.NewCommand(cfg))
 	cmd.AddCommand(promote.NewCommand(cfg, streams))
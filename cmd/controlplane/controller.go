@@ -34,6 +34,7 @@ import (
 	"github.com/akuity/kargo/pkg/health"
 	healthCheckers "github.com/akuity/kargo/pkg/health/checker/builtin"
 	"github.com/akuity/kargo/pkg/heartbeat"
+	"github.com/akuity/kargo/pkg/image"
 	"github.com/akuity/kargo/pkg/indexer"
 	"github.com/akuity/kargo/pkg/logging"
 	"github.com/akuity/kargo/pkg/os"
@@ -44,11 +45,16 @@ import (
 	versionpkg "github.com/akuity/kargo/pkg/x/version"
 
 	_ "github.com/akuity/kargo/pkg/credentials/acr"
+	_ "github.com/akuity/kargo/pkg/credentials/azuredevops"
 	_ "github.com/akuity/kargo/pkg/credentials/basic"
 	_ "github.com/akuity/kargo/pkg/credentials/ecr"
 	_ "github.com/akuity/kargo/pkg/credentials/gar"
+	_ "github.com/akuity/kargo/pkg/credentials/gitea"
 	_ "github.com/akuity/kargo/pkg/credentials/github"
+	_ "github.com/akuity/kargo/pkg/credentials/gitlab"
+	_ "github.com/akuity/kargo/pkg/credentials/helper"
 	_ "github.com/akuity/kargo/pkg/credentials/ssh"
+	_ "github.com/akuity/kargo/pkg/credentials/vault"
 	_ "github.com/akuity/kargo/pkg/promotion/runner/builtin"
 )
 
@@ -142,6 +148,15 @@ func (o *controllerOptions) run(ctx context.Context) error {
 		)
 	}
 
+	// Image metadata is cached in memory unless the controller is configured to
+	// use another backend. Only the controller discovers images, so this is not
+	// done by other components that merely import the package.
+	imageCache, err := image.NewCacheFromEnv()
+	if err != nil {
+		return fmt.Errorf("error initializing image cache: %w", err)
+	}
+	image.SetCache(imageCache)
+
 	kargoMgr, localClusterClient, stagesReconcilerCfg, err := o.setupKargoManager(
 		ctx,
 		stages.ReconcilerConfigFromEnv(),
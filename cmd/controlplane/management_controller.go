@@ -16,7 +16,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/management/apitokens"
 	"github.com/akuity/kargo/pkg/controller/management/clusterconfigs"
+	"github.com/akuity/kargo/pkg/controller/management/credentialhealth"
 	"github.com/akuity/kargo/pkg/controller/management/namespaces"
 	"github.com/akuity/kargo/pkg/controller/management/projectconfigs"
 	"github.com/akuity/kargo/pkg/controller/management/projects"
@@ -179,6 +181,29 @@ func (o *managementControllerOptions) run(ctx context.Context) error {
 		return fmt.Errorf("error setting up shared ConfigMap replication reconciler: %w", err)
 	}
 
+	// Expired API tokens are deleted periodically. Secrets are not cached in all
+	// namespaces in which API tokens may exist, so they are listed directly from
+	// the API server.
+	if err := kargoMgr.Add(apitokens.NewCollector(
+		kargoMgr.GetAPIReader(),
+		kargoMgr.GetClient(),
+		apitokens.CollectorConfigFromEnv(),
+	)); err != nil {
+		return fmt.Errorf("error adding expired API token collector: %w", err)
+	}
+
+	// Repository credentials are checked periodically. As above, Secrets are
+	// listed directly from the API server.
+	if credHealthCfg := credentialhealth.CheckerConfigFromEnv(); credHealthCfg.Interval > 0 {
+		if err := kargoMgr.Add(credentialhealth.NewChecker(
+			kargoMgr.GetAPIReader(),
+			kargoMgr.GetClient(),
+			credHealthCfg,
+		)); err != nil {
+			return fmt.Errorf("error adding credential health checker: %w", err)
+		}
+	}
+
 	if err := kargoMgr.Start(ctx); err != nil {
 		return fmt.Errorf("error starting kargo manager: %w", err)
 	}
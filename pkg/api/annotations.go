@@ -167,3 +167,19 @@ func CreateActorAnnotationValue(promo *kargoapi.Promotion) string {
 	}
 	return creator
 }
+
+// CredentialHealthAnnotationValue returns the value of the
+// AnnotationKeyCredentialHealth annotation, unmarshalled into a
+// CredentialHealth struct, and a boolean indicating whether the annotation was
+// present and valid.
+func CredentialHealthAnnotationValue(annotations map[string]string) (*kargoapi.CredentialHealth, bool) {
+	val, ok := annotations[kargoapi.AnnotationKeyCredentialHealth]
+	if !ok {
+		return nil, false
+	}
+	health := &kargoapi.CredentialHealth{}
+	if err := json.Unmarshal([]byte(val), health); err != nil {
+		return nil, false
+	}
+	return health, true
+}
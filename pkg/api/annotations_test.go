@@ -352,3 +352,33 @@ func TestCreateActorAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialHealthAnnotationValue(t *testing.T) {
+	t.Run("has credential health annotation with valid JSON", func(t *testing.T) {
+		result, ok := CredentialHealthAnnotationValue(map[string]string{
+			kargoapi.AnnotationKeyCredentialHealth: `{"repoURL":"https://github.com/example/repo",` +
+				`"conditions":[{"type":"Healthy","status":"True","reason":"CheckSucceeded"}],` +
+				`"usedBy":[{"kind":"Warehouse","namespace":"fake-project","name":"fake-warehouse"}]}`,
+		})
+		require.True(t, ok)
+		require.Equal(t, "https://github.com/example/repo", result.RepoURL)
+		require.Len(t, result.Conditions, 1)
+		require.Equal(t, kargoapi.ConditionTypeHealthy, result.Conditions[0].Type)
+		require.Len(t, result.UsedBy, 1)
+		require.Equal(t, "fake-warehouse", result.UsedBy[0].Name)
+	})
+
+	t.Run("does not have credential health annotation", func(t *testing.T) {
+		result, ok := CredentialHealthAnnotationValue(nil)
+		require.False(t, ok)
+		require.Nil(t, result)
+	})
+
+	t.Run("has credential health annotation with invalid JSON", func(t *testing.T) {
+		result, ok := CredentialHealthAnnotationValue(map[string]string{
+			kargoapi.AnnotationKeyCredentialHealth: "{",
+		})
+		require.False(t, ok)
+		require.Nil(t, result)
+	})
+}
@@ -13,6 +13,12 @@ func FormatEventControllerActor(name string) string {
 	return kargoapi.EventActorControllerPrefix + name
 }
 
+// FormatEventWebhookReceiverActor returns a string representation of a webhook
+// receiver acting in an event in "webhook-receiver:<name>" format.
+func FormatEventWebhookReceiverActor(name string) string {
+	return kargoapi.EventActorWebhookReceiverPrefix + name
+}
+
 // FormatEventUserActor returns a string representation of the user acting in an event
 // that can be used as a value of AnnotationKeyEventActor.
 //
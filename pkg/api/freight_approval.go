@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// GetApprovalPolicy returns the ApprovalPolicy of the PromotionPolicy that
+// applies to the supplied Stage metadata. It returns nil when no
+// PromotionPolicy applies to the Stage or the applicable policy does not
+// specify an ApprovalPolicy, in which case a single approval suffices.
+func GetApprovalPolicy(
+	ctx context.Context,
+	c client.Client,
+	stage metav1.ObjectMeta,
+) (*kargoapi.ApprovalPolicy, error) {
+	policy, err := findMatchingPromotionPolicy(ctx, c, stage)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	return policy.Approval, nil
+}
+
+// IsFreightCommitAuthor returns whether the provided email address belongs to
+// the author of any commit referenced by the provided Freight. Commit authors
+// are expected to be formatted as "Name <email>"; authors without a parsable
+// email address never match. Email addresses are compared case-insensitively.
+func IsFreightCommitAuthor(freight *kargoapi.Freight, email string) bool {
+	if email == "" {
+		return false
+	}
+	for _, commit := range freight.Commits {
+		author, err := mail.ParseAddress(commit.Author)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(author.Address, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestGetApprovalPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+
+	stageMeta := metav1.ObjectMeta{
+		Name:      "prod",
+		Namespace: "fake-project",
+	}
+	testCases := []struct {
+		name     string
+		objects  []runtime.Object
+		expected *kargoapi.ApprovalPolicy
+	}{
+		{
+			name: "no ProjectConfig",
+		},
+		{
+			name: "matching policy without approval policy",
+			objects: []runtime.Object{
+				&kargoapi.ProjectConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-project",
+						Namespace: "fake-project",
+					},
+					Spec: kargoapi.ProjectConfigSpec{
+						PromotionPolicies: []kargoapi.PromotionPolicy{{
+							StageSelector: &kargoapi.PromotionPolicySelector{Name: "prod"},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "matching policy with approval policy",
+			objects: []runtime.Object{
+				&kargoapi.ProjectConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-project",
+						Namespace: "fake-project",
+					},
+					Spec: kargoapi.ProjectConfigSpec{
+						PromotionPolicies: []kargoapi.PromotionPolicy{
+							{
+								StageSelector: &kargoapi.PromotionPolicySelector{Name: "test"},
+								Approval:      &kargoapi.ApprovalPolicy{RequiredApprovals: 3},
+							},
+							{
+								StageSelector: &kargoapi.PromotionPolicySelector{Name: "prod"},
+								Approval:      &kargoapi.ApprovalPolicy{RequiredApprovals: 2},
+							},
+						},
+					},
+				},
+			},
+			expected: &kargoapi.ApprovalPolicy{RequiredApprovals: 2},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(testCase.objects...).
+				Build()
+			policy, err := GetApprovalPolicy(t.Context(), c, stageMeta)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, policy)
+		})
+	}
+}
+
+func TestIsFreightCommitAuthor(t *testing.T) {
+	freight := &kargoapi.Freight{
+		Commits: []kargoapi.GitCommit{
+			{Author: "Kilgore Trout <Kilgore@Example.com>"},
+			{Author: "not an address"},
+		},
+	}
+	require.True(t, IsFreightCommitAuthor(freight, "kilgore@example.com"))
+	require.False(t, IsFreightCommitAuthor(freight, "eliot@example.com"))
+	require.False(t, IsFreightCommitAuthor(freight, ""))
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Backend identifies a Cache implementation.
+type Backend string
+
+const (
+	// BackendMemory selects the in-memory Cache implementation. Cached values
+	// are local to the process and are lost when it exits.
+	BackendMemory Backend = "memory"
+	// BackendRedis selects a Cache implementation backed by a server speaking
+	// the Redis protocol. Cached values are shared by all processes using the
+	// same server and survive restarts.
+	BackendRedis Backend = "redis"
+	// BackendDisk selects a Cache implementation backed by a file on local
+	// disk. Cached values survive restarts of the process.
+	BackendDisk Backend = "disk"
+)
+
+// Config represents configuration for the Cache implementations returned by
+// New. The environment variables named below are prefixed by whatever prefix
+// is provided to ConfigFromEnv.
+type Config struct {
+	// Backend selects the Cache implementation.
+	Backend Backend `envconfig:"CACHE_BACKEND" default:"memory"`
+	// TTL is the maximum amount of time a cached value is retained. A value of
+	// zero means cached values do not expire.
+	TTL time.Duration `envconfig:"CACHE_TTL" default:"0"`
+	// MaxValueBytes is the maximum size, in bytes, of a single encoded value
+	// stored by the redis and disk backends. Larger values are silently not
+	// cached. A value of zero means there is no limit.
+	MaxValueBytes int `envconfig:"CACHE_MAX_VALUE_BYTES" default:"1048576"`
+	// RedisAddress is the address (host:port) of the server used by the redis
+	// backend.
+	RedisAddress string `envconfig:"CACHE_REDIS_ADDRESS"`
+	// RedisUsername is the username used to authenticate to the server used by
+	// the redis backend.
+	RedisUsername string `envconfig:"CACHE_REDIS_USERNAME"`
+	// RedisPassword is the password used to authenticate to the server used by
+	// the redis backend.
+	RedisPassword string `envconfig:"CACHE_REDIS_PASSWORD"`
+	// RedisDB is the number of the database used by the redis backend.
+	RedisDB int `envconfig:"CACHE_REDIS_DB" default:"0"`
+	// RedisTLS indicates whether connections to the server used by the redis
+	// backend use TLS.
+	RedisTLS bool `envconfig:"CACHE_REDIS_TLS" default:"false"`
+	// RedisKeyPrefix is prepended to all keys written by the redis backend. It
+	// permits multiple Kargo installations to share a server.
+	RedisKeyPrefix string `envconfig:"CACHE_REDIS_KEY_PREFIX" default:"kargo"`
+	// DiskPath is the path of the file used by the disk backend. It is
+	// created if it does not exist.
+	DiskPath string `envconfig:"CACHE_DISK_PATH"`
+}
+
+// ConfigFromEnv returns a Config populated from environment variables whose
+// names begin with the provided prefix. e.g. With the prefix "IMAGE", the
+// backend is selected by IMAGE_CACHE_BACKEND. Each consumer of a Cache is
+// expected to use its own prefix, so that unrelated variables in the
+// environment are never mistaken for its configuration.
+func ConfigFromEnv(prefix string) (Config, error) {
+	cfg := Config{}
+	if err := envconfig.Process(prefix, &cfg); err != nil {
+		return cfg, fmt.Errorf("error reading %s cache configuration: %w", prefix, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	var errs []error
+	switch c.Backend {
+	case BackendMemory:
+	case BackendRedis:
+		if c.RedisAddress == "" {
+			errs = append(errs, errors.New("a Redis address is required for the redis cache backend"))
+		}
+	case BackendDisk:
+		if c.DiskPath == "" {
+			errs = append(errs, errors.New("a path is required for the disk cache backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf(
+			"unsupported cache backend %q; must be one of: %s, %s, %s",
+			c.Backend, BackendMemory, BackendRedis, BackendDisk,
+		))
+	}
+	if c.TTL < 0 {
+		errs = append(errs, errors.New("TTL must not be negative"))
+	}
+	if c.MaxValueBytes < 0 {
+		errs = append(errs, errors.New("maximum value size must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// New returns the Cache implementation selected by the provided Config. The
+// provided name distinguishes the returned Cache from others that share the
+// same backend, e.g. a Redis server or a file on disk, and must be unique
+// within the process. maxEntries bounds the number of entries retained by the
+// memory and disk backends. The redis backend relies on the server's own
+// eviction policy instead.
+func New[V any](cfg Config, name string, maxEntries int) (Cache[V], error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Backend {
+	case BackendRedis:
+		return NewRedisCache[V](
+			newRedisClient(cfg),
+			RedisCacheConfig{
+				KeyPrefix:     cfg.RedisKeyPrefix + ":" + name,
+				TTL:           cfg.TTL,
+				MaxValueBytes: cfg.MaxValueBytes,
+			},
+		)
+	case BackendDisk:
+		return NewDiskCache[V](DiskCacheConfig{
+			Path:          cfg.DiskPath,
+			Bucket:        name,
+			TTL:           cfg.TTL,
+			MaxEntries:    maxEntries,
+			MaxValueBytes: cfg.MaxValueBytes,
+		})
+	default:
+		if cfg.TTL > 0 {
+			return NewExpirableInMemoryCache[V](maxEntries, cfg.TTL)
+		}
+		return NewInMemoryCache[V](maxEntries)
+	}
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       Config
+		expectErr string
+	}{
+		{
+			name: "memory",
+			cfg:  Config{Backend: BackendMemory},
+		},
+		{
+			name:      "redis without address",
+			cfg:       Config{Backend: BackendRedis},
+			expectErr: "a Redis address is required",
+		},
+		{
+			name:      "disk without path",
+			cfg:       Config{Backend: BackendDisk},
+			expectErr: "a path is required",
+		},
+		{
+			name:      "unsupported backend",
+			cfg:       Config{Backend: "bogus"},
+			expectErr: "unsupported cache backend",
+		},
+		{
+			name:      "negative TTL",
+			cfg:       Config{Backend: BackendMemory, TTL: -time.Second},
+			expectErr: "TTL must not be negative",
+		},
+		{
+			name:      "negative max value bytes",
+			cfg:       Config{Backend: BackendMemory, MaxValueBytes: -1},
+			expectErr: "maximum value size must not be negative",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.cfg.Validate()
+			if testCase.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, testCase.expectErr)
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("CACHE_BACKEND", "bogus")
+	t.Setenv("IMAGE_CACHE_BACKEND", "redis")
+	t.Setenv("IMAGE_CACHE_REDIS_ADDRESS", "redis:6379")
+	t.Setenv("IMAGE_CACHE_TTL", "1h")
+
+	cfg, err := ConfigFromEnv("IMAGE")
+	require.NoError(t, err)
+	require.Equal(t, BackendRedis, cfg.Backend)
+	require.Equal(t, "redis:6379", cfg.RedisAddress)
+	require.Equal(t, time.Hour, cfg.TTL)
+	require.Equal(t, "kargo", cfg.RedisKeyPrefix)
+
+	t.Setenv("IMAGE_CACHE_TTL", "bogus")
+	_, err = ConfigFromEnv("IMAGE")
+	require.ErrorContains(t, err, "error reading IMAGE cache configuration")
+}
+
+func TestNew(t *testing.T) {
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := New[string](Config{Backend: "bogus"}, "test", 1)
+		require.Error(t, err)
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		c, err := New[string](Config{Backend: BackendMemory}, "test", 1)
+		require.NoError(t, err)
+		require.IsType(t, &inMemoryCache[string]{}, c)
+	})
+
+	t.Run("memory with TTL", func(t *testing.T) {
+		c, err := New[string](Config{Backend: BackendMemory, TTL: time.Minute}, "test", 1)
+		require.NoError(t, err)
+		require.IsType(t, &inMemoryCache[string]{}, c)
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		c, err := New[string](
+			Config{Backend: BackendRedis, RedisAddress: server.Addr(), RedisKeyPrefix: "kargo"},
+			"test",
+			1,
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Set(t.Context(), "key", "value"))
+		require.True(t, server.Exists("kargo:test:key"))
+	})
+
+	t.Run("disk", func(t *testing.T) {
+		c, err := New[string](
+			Config{Backend: BackendDisk, DiskPath: filepath.Join(t.TempDir(), "cache.db")},
+			"test",
+			1,
+		)
+		require.NoError(t, err)
+		require.IsType(t, &diskCache[string]{}, c)
+	})
+}
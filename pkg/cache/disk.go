@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// DiskCacheConfig represents configuration for a Cache backed by a file on
+// local disk.
+type DiskCacheConfig struct {
+	// Path is the path of the file. It is created if it does not exist.
+	Path string
+	// Bucket is the name of the bucket within the file in which values are
+	// stored. It permits multiple caches to share a file.
+	Bucket string
+	// TTL is the maximum amount of time a cached value is retained. A value of
+	// zero means cached values do not expire.
+	TTL time.Duration
+	// MaxEntries is the maximum number of entries retained. When it is
+	// exceeded, the least recently written entries are evicted.
+	MaxEntries int
+	// MaxValueBytes is the maximum size, in bytes, of a single encoded value.
+	// Larger values are silently not cached. A value of zero means there is no
+	// limit.
+	MaxValueBytes int
+}
+
+var (
+	entriesBucket = []byte("entries")
+	orderBucket   = []byte("order")
+	countKey      = []byte("count")
+)
+
+// diskEntry is the envelope in which values are stored on disk.
+type diskEntry struct {
+	// Sequence is the position of the entry in the order in which entries were
+	// written. It is the key of the entry in the order bucket.
+	Sequence uint64 `json:"s"`
+	// ExpiresAt is the time, in nanoseconds since the Unix epoch, after which
+	// the entry is no longer valid. A value of zero means it does not expire.
+	ExpiresAt int64 `json:"e,omitempty"`
+	// Value is the JSON-encoded value.
+	Value json.RawMessage `json:"v"`
+}
+
+// diskCache is an implementation of the Cache interface backed by a file on
+// local disk. Values are stored JSON-encoded.
+type diskCache[V any] struct {
+	db     *bbolt.DB
+	bucket []byte
+	cfg    DiskCacheConfig
+	// nowFn returns the current time. It is overridable for testing purposes.
+	nowFn func() time.Time
+}
+
+// NewDiskCache returns an implementation of the Cache interface that stores
+// values in a file on local disk.
+func NewDiskCache[V any](cfg DiskCacheConfig) (Cache[V], error) {
+	if cfg.Path == "" {
+		return nil, errors.New("error initializing cache: must provide a path")
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("error initializing cache: must provide a bucket")
+	}
+	if cfg.MaxEntries <= 0 {
+		return nil, errors.New("error initializing cache: must provide a positive maximum number of entries")
+	}
+	if cfg.TTL < 0 {
+		return nil, errors.New("error initializing cache: TTL must not be negative")
+	}
+	db, err := openDiskDB(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cache: %w", err)
+	}
+	c := &diskCache[V]{
+		db:     db,
+		bucket: []byte(cfg.Bucket),
+		cfg:    cfg,
+		nowFn:  time.Now,
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(c.bucket)
+		if err != nil {
+			return err
+		}
+		if _, err = b.CreateBucketIfNotExists(entriesBucket); err != nil {
+			return err
+		}
+		_, err = b.CreateBucketIfNotExists(orderBucket)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("error initializing cache bucket %q: %w", cfg.Bucket, err)
+	}
+	return c, nil
+}
+
+// Get implements Cache.
+func (c *diskCache[V]) Get(_ context.Context, key string) (V, bool, error) {
+	var val V
+	var entry *diskEntry
+	if err := c.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(c.bucket).Bucket(entriesBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		entry = &diskEntry{}
+		return json.Unmarshal(data, entry)
+	}); err != nil {
+		return val, false, fmt.Errorf("error getting value for key %q from cache: %w", key, err)
+	}
+	if entry == nil || c.expired(entry) {
+		return val, false, nil
+	}
+	if err := json.Unmarshal(entry.Value, &val); err != nil {
+		return val, false, fmt.Errorf("error decoding value for key %q from cache: %w", key, err)
+	}
+	return val, true, nil
+}
+
+// Set implements Cache.
+func (c *diskCache[V]) Set(_ context.Context, key string, value V) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding value for key %q: %w", key, err)
+	}
+	if c.cfg.MaxValueBytes > 0 && len(data) > c.cfg.MaxValueBytes {
+		return nil
+	}
+	// Writes are batched with any made concurrently so that they share the cost
+	// of syncing the file to disk. Batch may invoke the function more than once,
+	// but each invocation that fails is rolled back, so that is harmless.
+	if err = c.db.Batch(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(c.bucket)
+		entries := b.Bucket(entriesBucket)
+		order := b.Bucket(orderBucket)
+		count := decodeUint64(b.Get(countKey))
+
+		// Remove any existing entry for the key so that it moves to the back of
+		// the eviction order.
+		removed, err := removeDiskEntry(entries, order, []byte(key))
+		if err != nil {
+			return err
+		}
+		if removed {
+			count--
+		}
+
+		entry := diskEntry{Value: data}
+		if entry.Sequence, err = order.NextSequence(); err != nil {
+			return err
+		}
+		if c.cfg.TTL > 0 {
+			entry.ExpiresAt = c.nowFn().Add(c.cfg.TTL).UnixNano()
+		}
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if err = entries.Put([]byte(key), encoded); err != nil {
+			return err
+		}
+		if err = order.Put(encodeUint64(entry.Sequence), []byte(key)); err != nil {
+			return err
+		}
+		count++
+
+		// Evict the least recently written entries until the cache is within
+		// its size limit.
+		cursor := order.Cursor()
+		for seq, oldest := cursor.First(); seq != nil && count > uint64(c.cfg.MaxEntries); seq, oldest = cursor.First() {
+			if removed, err = removeDiskEntry(entries, order, oldest); err != nil {
+				return err
+			}
+			if !removed {
+				// The order bucket references a key with no entry. This
+				// shouldn't happen, but don't loop forever if it does.
+				if err = order.Delete(seq); err != nil {
+					return err
+				}
+				continue
+			}
+			count--
+		}
+		return b.Put(countKey, encodeUint64(count))
+	}); err != nil {
+		return fmt.Errorf("error setting value for key %q in cache: %w", key, err)
+	}
+	return nil
+}
+
+// expired returns true if the provided entry has expired.
+func (c *diskCache[V]) expired(entry *diskEntry) bool {
+	return entry.ExpiresAt != 0 && c.nowFn().UnixNano() >= entry.ExpiresAt
+}
+
+// removeDiskEntry removes the entry for the provided key, if any, from the
+// provided entries and order buckets. It returns true if an entry was
+// removed.
+func removeDiskEntry(entries, order *bbolt.Bucket, key []byte) (bool, error) {
+	data := entries.Get(key)
+	if data == nil {
+		return false, nil
+	}
+	existing := diskEntry{}
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return false, err
+	}
+	if err := order.Delete(encodeUint64(existing.Sequence)); err != nil {
+		return false, err
+	}
+	return true, entries.Delete(key)
+}
+
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func decodeUint64(b []byte) uint64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+var (
+	diskDBs   = map[string]*bbolt.DB{}
+	diskDBsMu sync.Mutex
+)
+
+// openDiskDB opens the file at the provided path, or returns the handle to it
+// if it is already open. A file can only be opened once per process, so
+// caches sharing a file must share the handle.
+func openDiskDB(path string) (*bbolt.DB, error) {
+	diskDBsMu.Lock()
+	defer diskDBsMu.Unlock()
+	if db, ok := diskDBs[path]; ok {
+		return db, nil
+	}
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+	diskDBs[path] = db
+	return db, nil
+}
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDiskCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	testCases := []struct {
+		name      string
+		cfg       DiskCacheConfig
+		expectErr string
+	}{
+		{
+			name:      "missing path",
+			cfg:       DiskCacheConfig{Bucket: "test", MaxEntries: 1},
+			expectErr: "must provide a path",
+		},
+		{
+			name:      "missing bucket",
+			cfg:       DiskCacheConfig{Path: path, MaxEntries: 1},
+			expectErr: "must provide a bucket",
+		},
+		{
+			name:      "zero max entries",
+			cfg:       DiskCacheConfig{Path: path, Bucket: "test"},
+			expectErr: "must provide a positive maximum number of entries",
+		},
+		{
+			name:      "negative TTL",
+			cfg:       DiskCacheConfig{Path: path, Bucket: "test", MaxEntries: 1, TTL: -time.Second},
+			expectErr: "TTL must not be negative",
+		},
+		{
+			name: "success",
+			cfg:  DiskCacheConfig{Path: path, Bucket: "test", MaxEntries: 1},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c, err := NewDiskCache[string](testCase.cfg)
+			if testCase.expectErr != "" {
+				require.ErrorContains(t, err, testCase.expectErr)
+				require.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, c)
+		})
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	alice := testStruct{Name: "Alice", Age: 30}
+	bob := testStruct{Name: "Bob", Age: 25}
+	carol := testStruct{Name: "Carol", Age: 40}
+
+	path := filepath.Join(t.TempDir(), "cache.db")
+	cfg := DiskCacheConfig{
+		Path:          path,
+		Bucket:        "test",
+		TTL:           time.Minute,
+		MaxEntries:    2,
+		MaxValueBytes: 64,
+	}
+	c, err := NewDiskCache[testStruct](cfg)
+	require.NoError(t, err)
+	now := time.Now()
+	c.(*diskCache[testStruct]).nowFn = func() time.Time { return now } // nolint: forcetypeassert
+
+	// Miss
+	_, found, err := c.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Initial write
+	require.NoError(t, c.Set(t.Context(), "alice", alice))
+	value, found, err := c.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, alice, value)
+
+	// Overwrite moves alice to the back of the eviction order
+	require.NoError(t, c.Set(t.Context(), "bob", bob))
+	require.NoError(t, c.Set(t.Context(), "alice", alice))
+
+	// Exceeding the size limit evicts the least recently written entry
+	require.NoError(t, c.Set(t.Context(), "carol", carol))
+	_, found, err = c.Get(t.Context(), "bob")
+	require.NoError(t, err)
+	require.False(t, found)
+	_, found, err = c.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// Values exceeding the size limit are not cached
+	require.NoError(t, c.Set(t.Context(), "big", testStruct{Name: string(make([]byte, 64))}))
+	_, found, err = c.Get(t.Context(), "big")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// A second cache sharing the file has its own entries
+	other, err := NewDiskCache[testStruct](DiskCacheConfig{Path: path, Bucket: "other", MaxEntries: 1})
+	require.NoError(t, err)
+	_, found, err = other.Get(t.Context(), "carol")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Entries survive reinitialization of the cache
+	c, err = NewDiskCache[testStruct](cfg)
+	require.NoError(t, err)
+	value, found, err = c.Get(t.Context(), "carol")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, carol, value)
+
+	// Expiry
+	c.(*diskCache[testStruct]).nowFn = func() time.Time { return now.Add(time.Minute) } // nolint: forcetypeassert
+	_, found, err = c.Get(t.Context(), "carol")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestDiskCache_concurrentSet(t *testing.T) {
+	const maxEntries = 10
+	c, err := NewDiskCache[int](DiskCacheConfig{
+		Path:       filepath.Join(t.TempDir(), "cache.db"),
+		Bucket:     "test",
+		MaxEntries: maxEntries,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 5 * maxEntries {
+		wg.Go(func() {
+			assert.NoError(t, c.Set(t.Context(), strconv.Itoa(i), i))
+		})
+	}
+	wg.Wait()
+
+	// Writes batched together still respect the size limit.
+	var found int
+	for i := range 5 * maxEntries {
+		value, ok, err := c.Get(t.Context(), strconv.Itoa(i))
+		require.NoError(t, err)
+		if ok {
+			require.Equal(t, i, value)
+			found++
+		}
+	}
+	require.Equal(t, maxEntries, found)
+}
@@ -2,16 +2,26 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 )
 
+// lruCache is the subset of the least recently used caches' methods used by
+// inMemoryCache.
+type lruCache[V any] interface {
+	Get(key string) (V, bool)
+	Add(key string, value V) bool
+}
+
 // inMemoryCache is an in-memory implementation of the Cache interface.
 type inMemoryCache[V any] struct {
 	// cache is a simple, internal cache that utilizes a least recently used key
 	// eviction policy.
-	cache *lru.Cache[string, V]
+	cache lruCache[V]
 }
 
 // NewInMemoryCache returns an in-memory implementation of the Cache interface
@@ -24,6 +34,19 @@ func NewInMemoryCache[V any](size int) (Cache[V], error) {
 	return &inMemoryCache[V]{cache: cache}, nil
 }
 
+// NewExpirableInMemoryCache returns an in-memory implementation of the Cache
+// interface configured with the specified size (maximum number of entries)
+// whose entries expire after the specified TTL.
+func NewExpirableInMemoryCache[V any](size int, ttl time.Duration) (Cache[V], error) {
+	if size <= 0 {
+		return nil, errors.New("error initializing cache: must provide a positive size")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("error initializing cache: must provide a positive TTL")
+	}
+	return &inMemoryCache[V]{cache: expirable.NewLRU[string, V](size, nil, ttl)}, nil
+}
+
 // Get implements Cache.
 func (c *inMemoryCache[V]) Get(_ context.Context, key string) (V, bool, error) {
 	val, found := c.cache.Get(key)
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/stretchr/testify/require"
@@ -178,3 +179,44 @@ func TestInMemoryCache_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestNewExpirableInMemoryCache(t *testing.T) {
+	testCases := []struct {
+		name      string
+		size      int
+		ttl       time.Duration
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			size: 10,
+			ttl:  time.Minute,
+		},
+		{
+			name:      "zero size",
+			ttl:       time.Minute,
+			expectErr: true,
+		},
+		{
+			name:      "zero TTL",
+			size:      10,
+			expectErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache, err := NewExpirableInMemoryCache[string](testCase.size, testCase.ttl)
+			if testCase.expectErr {
+				require.Error(t, err)
+				require.Nil(t, cache)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, cache.Set(t.Context(), "key", "value"))
+			value, found, err := cache.Get(t.Context(), "key")
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, "value", value)
+		})
+	}
+}
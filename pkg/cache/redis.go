@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCacheConfig represents configuration for a Cache backed by a server
+// speaking the Redis protocol.
+type RedisCacheConfig struct {
+	// KeyPrefix is prepended, followed by a colon, to all keys. It permits
+	// multiple caches to share a server.
+	KeyPrefix string
+	// TTL is the maximum amount of time a cached value is retained. A value of
+	// zero means cached values do not expire.
+	TTL time.Duration
+	// MaxValueBytes is the maximum size, in bytes, of a single encoded value.
+	// Larger values are silently not cached. A value of zero means there is no
+	// limit.
+	MaxValueBytes int
+}
+
+// redisCache is an implementation of the Cache interface backed by a server
+// speaking the Redis protocol. Values are stored JSON-encoded.
+type redisCache[V any] struct {
+	client redis.Cmdable
+	cfg    RedisCacheConfig
+}
+
+// NewRedisCache returns an implementation of the Cache interface that stores
+// values using the provided client.
+func NewRedisCache[V any](client redis.Cmdable, cfg RedisCacheConfig) (Cache[V], error) {
+	if client == nil {
+		return nil, errors.New("error initializing cache: must provide a client")
+	}
+	if cfg.TTL < 0 {
+		return nil, errors.New("error initializing cache: TTL must not be negative")
+	}
+	return &redisCache[V]{client: client, cfg: cfg}, nil
+}
+
+// Get implements Cache.
+func (c *redisCache[V]) Get(ctx context.Context, key string) (V, bool, error) {
+	var val V
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return val, false, nil
+	}
+	if err != nil {
+		return val, false, fmt.Errorf("error getting value for key %q from cache: %w", key, err)
+	}
+	if err = json.Unmarshal(data, &val); err != nil {
+		return val, false, fmt.Errorf("error decoding value for key %q from cache: %w", key, err)
+	}
+	return val, true, nil
+}
+
+// Set implements Cache.
+func (c *redisCache[V]) Set(ctx context.Context, key string, value V) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding value for key %q: %w", key, err)
+	}
+	if c.cfg.MaxValueBytes > 0 && len(data) > c.cfg.MaxValueBytes {
+		return nil
+	}
+	if err = c.client.Set(ctx, c.key(key), data, c.cfg.TTL).Err(); err != nil {
+		return fmt.Errorf("error setting value for key %q in cache: %w", key, err)
+	}
+	return nil
+}
+
+// key returns the key under which the value for the provided key is stored on
+// the server.
+func (c *redisCache[V]) key(key string) string {
+	if c.cfg.KeyPrefix == "" {
+		return key
+	}
+	return c.cfg.KeyPrefix + ":" + key
+}
+
+// newRedisClient returns a client for the server described by the provided
+// Config.
+func newRedisClient(cfg Config) *redis.Client {
+	opts := &redis.Options{
+		Addr:     cfg.RedisAddress,
+		Username: cfg.RedisUsername,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	}
+	if cfg.RedisTLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return redis.NewClient(opts)
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisCache(t *testing.T) {
+	t.Run("nil client", func(t *testing.T) {
+		_, err := NewRedisCache[string](nil, RedisCacheConfig{})
+		require.ErrorContains(t, err, "must provide a client")
+	})
+
+	t.Run("negative TTL", func(t *testing.T) {
+		_, err := NewRedisCache[string](
+			redis.NewClient(&redis.Options{Addr: "localhost:6379"}),
+			RedisCacheConfig{TTL: -time.Second},
+		)
+		require.ErrorContains(t, err, "TTL must not be negative")
+	})
+}
+
+func TestRedisCache(t *testing.T) {
+	type testStruct struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	alice := testStruct{Name: "Alice", Age: 30}
+	bob := testStruct{Name: "Bob", Age: 25}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	c, err := NewRedisCache[testStruct](client, RedisCacheConfig{
+		KeyPrefix:     "kargo:test",
+		TTL:           time.Minute,
+		MaxValueBytes: 64,
+	})
+	require.NoError(t, err)
+
+	// Miss
+	_, found, err := c.Get(t.Context(), "key")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Initial write
+	require.NoError(t, c.Set(t.Context(), "key", alice))
+	require.True(t, server.Exists("kargo:test:key"))
+	require.Equal(t, time.Minute, server.TTL("kargo:test:key"))
+	value, found, err := c.Get(t.Context(), "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, alice, value)
+
+	// Overwrite
+	require.NoError(t, c.Set(t.Context(), "key", bob))
+	value, found, err = c.Get(t.Context(), "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, bob, value)
+
+	// Expiry
+	server.FastForward(time.Minute)
+	_, found, err = c.Get(t.Context(), "key")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Values exceeding the size limit are not cached
+	require.NoError(t, c.Set(t.Context(), "big", testStruct{Name: string(make([]byte, 64))}))
+	require.False(t, server.Exists("kargo:test:big"))
+
+	// Values that cannot be decoded
+	require.NoError(t, server.Set("kargo:test:bogus", "{"))
+	_, found, err = c.Get(t.Context(), "bogus")
+	require.ErrorContains(t, err, "error decoding value")
+	require.False(t, found)
+
+	// Server errors
+	server.Close()
+	_, found, err = c.Get(t.Context(), "key")
+	require.Error(t, err)
+	require.False(t, found)
+	require.Error(t, c.Set(t.Context(), "key", alice))
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
 
 	"github.com/akuity/kargo/pkg/cli/client"
 	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/io"
 	"github.com/akuity/kargo/pkg/cli/option"
 	"github.com/akuity/kargo/pkg/cli/templates"
 )
 
 type approvalOptions struct {
+	genericiooptions.IOStreams
+
 	Config        config.CLIConfig
 	ClientOptions client.Options
 
@@ -23,9 +28,10 @@ type approvalOptions struct {
 	Stage        string
 }
 
-func NewCommand(cfg config.CLIConfig) *cobra.Command {
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
 	cmdOpts := &approvalOptions{
-		Config: cfg,
+		Config:    cfg,
+		IOStreams: streams,
 	}
 
 	cmd := &cobra.Command{
@@ -59,6 +65,9 @@ kargo approve --freight-alias=wonky-wombat --stage=qa
 	// Register the option flags on the command.
 	cmdOpts.addFlags(cmd)
 
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
 	return cmd
 }
 
@@ -116,7 +125,7 @@ func (o *approvalOptions) run(ctx context.Context) error {
 		freightNameOrAlias = o.FreightAlias
 	}
 
-	httpRes, err := apiClient.CoreAPI.
+	res, httpRes, err := apiClient.CoreAPI.
 		ApproveFreight(ctx, o.Project, freightNameOrAlias).
 		Stage(o.Stage).
 		Execute()
@@ -126,5 +135,25 @@ func (o *approvalOptions) run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("approve freight: %w", client.APIError(err))
 	}
+	if res == nil || res.GetApproved() {
+		return nil
+	}
+
+	// The stage requires further approvals by other users.
+	var pending []string
+	if remaining := res.GetRemainingApprovals(); remaining > 0 {
+		pending = append(pending, fmt.Sprintf("%d more approval(s)", remaining))
+	}
+	if missingGroups := res.GetMissingGroups(); len(missingGroups) > 0 {
+		pending = append(
+			pending,
+			fmt.Sprintf("approval(s) from group(s) %s", strings.Join(missingGroups, ", ")),
+		)
+	}
+	_, _ = fmt.Fprintf(
+		o.Out,
+		"Approval recorded; freight still requires %s\n",
+		strings.Join(pending, " and "),
+	)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,8 @@ type createTokenOptions struct {
 	Project     string
 	RoleName    string
 	Name        string
+	TTL         time.Duration
+	Scopes      []string
 }
 
 func newTokenCommand(
@@ -60,6 +63,14 @@ kargo create token --role=my-role my-token
 
 # Create a token for system-level role kargo-admin
 kargo create token --system --role=kargo-admin my-token
+
+# Create a token for role my-role in my-project that expires after 24 hours
+kargo create token --project=my-project --role=my-role --ttl=24h my-token
+
+# Create a token for role my-role in my-project that can only promote Freight
+# to Stage uat
+kargo create token --project=my-project --role=my-role \
+  --scope=get,promote:stages:uat --scope=get,list:freights my-token
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmdOpts.complete(args)
@@ -101,6 +112,17 @@ func (o *createTokenOptions) addFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive(option.ProjectFlag, option.SystemFlag)
 
 	option.Role(cmd.Flags(), &o.RoleName, "The role for which to create a token.")
+	option.TTL(
+		cmd.Flags(), &o.TTL,
+		"How long the token remains valid (e.g. 24h). If not set, the token "+
+			"never expires.",
+	)
+	option.Scopes(
+		cmd.Flags(), &o.Scopes,
+		"Narrows the token's permissions to a subset of the role's, in the form "+
+			"VERB[,VERB...]:RESOURCE_TYPE[:RESOURCE_NAME]. May be specified "+
+			"multiple times.",
+	)
 	if err := cmd.MarkFlagRequired(option.RoleFlag); err != nil {
 		panic(fmt.Errorf(
 			"could not mark %s flag as required: %w", option.RoleFlag, err,
@@ -127,9 +149,52 @@ func (o *createTokenOptions) validate() error {
 	if o.RoleName == "" {
 		errs = append(errs, fmt.Errorf("%s is required", option.RoleFlag))
 	}
+	if o.TTL < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative", option.TTLFlag))
+	}
+	for _, scope := range o.Scopes {
+		if _, err := parseTokenScope(scope); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errors.Join(errs...)
 }
 
+// parseTokenScope parses a token scope in the form
+// VERB[,VERB...]:RESOURCE_TYPE[:RESOURCE_NAME].
+func parseTokenScope(scope string) (kargogen.ResourceDetails, error) {
+	parts := strings.Split(scope, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return kargogen.ResourceDetails{}, fmt.Errorf(
+			"invalid %s %q: expected VERB[,VERB...]:RESOURCE_TYPE[:RESOURCE_NAME]",
+			option.ScopeFlag, scope,
+		)
+	}
+	var verbs []string
+	for verb := range strings.SplitSeq(parts[0], ",") {
+		if verb = strings.TrimSpace(verb); verb != "" {
+			verbs = append(verbs, verb)
+		}
+	}
+	resourceType := strings.TrimSpace(parts[1])
+	if len(verbs) == 0 || resourceType == "" {
+		return kargogen.ResourceDetails{}, fmt.Errorf(
+			"invalid %s %q: verbs and resource type must not be empty",
+			option.ScopeFlag, scope,
+		)
+	}
+	details := kargogen.ResourceDetails{
+		ResourceType: &resourceType,
+		Verbs:        verbs,
+	}
+	if len(parts) == 3 {
+		if resourceName := strings.TrimSpace(parts[2]); resourceName != "" {
+			details.ResourceName = &resourceName
+		}
+	}
+	return details, nil
+}
+
 // run creates an API token and prints it to the console.
 func (o *createTokenOptions) run(ctx context.Context) error {
 	apiClient, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
@@ -141,6 +206,14 @@ func (o *createTokenOptions) run(ctx context.Context) error {
 	// send -- there is no "leave unchanged" semantics to worry about here
 	// since this is a create operation, not a partial update.
 	body := kargogen.CreateAPITokenRequest{Name: &o.Name}
+	if o.TTL > 0 {
+		body.Ttl = new(o.TTL.String())
+	}
+	for _, scope := range o.Scopes {
+		// Already validated.
+		details, _ := parseTokenScope(scope)
+		body.Scopes = append(body.Scopes, details)
+	}
 
 	var payload *kargogen.V1Secret
 	var httpRes *http.Response
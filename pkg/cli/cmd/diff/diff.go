@@ -0,0 +1,30 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+)
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff TYPE NAME",
+		Short: "Show the differences between resources",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Show what would change if a piece of freight were promoted to the prod stage
+kargo diff freight --project=my-project abc1234 --stage=prod
+
+# Show the differences between two pieces of freight
+kargo diff freight --project=my-project abc1234 --from=def5678
+`),
+	}
+
+	// Register subcommands.
+	cmd.AddCommand(newFreightCommand(cfg, streams))
+
+	return cmd
+}
@@ -0,0 +1,178 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/pkg/cli/client"
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/io"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/freightdiff"
+)
+
+type freightOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project string
+	Name    string
+	From    string
+	Stage   string
+	Output  string
+}
+
+func newFreightCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &freightOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use: "freight [--project=project] NAME (--stage=stage | --from=freight) " +
+			"[-o text|json]",
+		Short: "Show the commits and artifact versions that differ between two pieces of freight",
+		Args:  option.ExactArgs(1),
+		Example: templates.Example(`
+# Show what would change if a piece of freight were promoted to the prod stage
+kargo diff freight --project=my-project abc1234 --stage=prod
+
+# Show the differences between two pieces of freight specified by alias
+kargo diff freight --project=my-project wonky-wombat --from=fuzzy-fox
+
+# Show the differences as JSON
+kargo diff freight --project=my-project abc1234 --stage=prod -o json
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the diff freight options to the provided
+// command.
+func (o *freightOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the freight belongs to. If not set, the default project will be used.",
+	)
+	option.Stage(
+		cmd.Flags(), &o.Stage,
+		"The stage whose current freight should be compared against.",
+	)
+	option.From(
+		cmd.Flags(), &o.From,
+		"The name or alias of the freight to compare against.",
+	)
+	option.Output(
+		cmd.Flags(), &o.Output, string(freightdiff.FormatText),
+		fmt.Sprintf("Output format. One of: %s.", strings.Join(formatNames(), "|")),
+	)
+
+	cmd.MarkFlagsOneRequired(option.StageFlag, option.FromFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.StageFlag, option.FromFlag)
+}
+
+// complete sets the options from the command arguments.
+func (o *freightOptions) complete(args []string) {
+	o.Name = strings.TrimSpace(args[0])
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *freightOptions) validate() error {
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		return fmt.Errorf("%s is required", option.ProjectFlag)
+	}
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	if o.Stage == "" && o.From == "" {
+		return fmt.Errorf("one of %s or %s is required", option.StageFlag, option.FromFlag)
+	}
+	if !slices.Contains(formatNames(), o.Output) {
+		return fmt.Errorf(
+			"unsupported output format %q; must be one of: %s",
+			o.Output, strings.Join(formatNames(), ", "),
+		)
+	}
+	return nil
+}
+
+// run gets the differences between the freight from the server and prints
+// them to the console.
+func (o *freightOptions) run(ctx context.Context) error {
+	apiClient, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	req := apiClient.CoreAPI.GetFreightDiff(ctx, o.Project, o.Name)
+	if o.Stage != "" {
+		req = req.Stage(o.Stage)
+	}
+	if o.From != "" {
+		req = req.From(o.From)
+	}
+	res, httpRes, err := req.Execute()
+	if httpRes != nil {
+		_ = httpRes.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("diff freight: %w", client.APIError(err))
+	}
+
+	diffJSON, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("marshal freight diff: %w", err)
+	}
+	diff := &freightdiff.Diff{}
+	if err = json.Unmarshal(diffJSON, diff); err != nil {
+		return fmt.Errorf("unmarshal freight diff: %w", err)
+	}
+
+	if err = freightdiff.Write(o.Out, diff, freightdiff.Format(o.Output)); err != nil {
+		return fmt.Errorf("print freight diff: %w", err)
+	}
+	return nil
+}
+
+func formatNames() []string {
+	formats := freightdiff.Formats()
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return names
+}
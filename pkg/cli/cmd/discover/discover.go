@@ -0,0 +1,303 @@
+package discover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	libClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/cli/client"
+	"github.com/akuity/kargo/pkg/cli/config"
+	kargoio "github.com/akuity/kargo/pkg/cli/io"
+	"github.com/akuity/kargo/pkg/cli/kubernetes"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/controller/warehouses"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/credentials/basic"
+	credsdb "github.com/akuity/kargo/pkg/credentials/kubernetes"
+	"github.com/akuity/kargo/pkg/subscription"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+
+	// defaultProject is the project the Warehouse is placed in when neither a
+	// project nor a default project has been configured.
+	defaultProject = "local"
+)
+
+type discoverOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Filename         string
+	CredentialsFiles []string
+	Project          string
+	Remote           bool
+	Output           string
+}
+
+// NewCommand creates a new cobra command for discovering the artifacts a
+// Warehouse would discover.
+func NewCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &discoverOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use: "discover -f FILENAME [--credentials=FILENAME]... [--project=project] " +
+			"[--remote] [-o text|json]",
+		Short: "Preview the artifacts and Freight a Warehouse would discover",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Preview what a Warehouse would discover, accessing public repositories only
+kargo discover -f warehouse.yaml
+
+# Preview what a Warehouse would discover, supplying repository credentials
+# from a Secret manifest
+kargo discover -f warehouse.yaml --credentials creds.yaml
+
+# Preview what a Warehouse would discover using the API server and the
+# credentials of project my-project
+kargo discover -f warehouse.yaml --project my-project --remote
+
+# Output the result as JSON
+kargo discover -f warehouse.yaml -o json
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	kargoio.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the discover options to the provided command.
+func (o *discoverOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	cmd.Flags().StringVarP(
+		&o.Filename, option.FilenameFlag, option.FilenameShortFlag, "",
+		"File containing the Warehouse to discover artifacts for.",
+	)
+	option.Credentials(
+		cmd.Flags(), &o.CredentialsFiles,
+		"File containing Kargo credential Secrets to use for discovery. May be "+
+			"specified multiple times. Not supported with --"+option.RemoteFlag+".",
+	)
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the Warehouse belongs to. If not set, the default project "+
+			"will be used, or \""+defaultProject+"\" if there is none and "+
+			"discovery runs locally.",
+	)
+	option.Remote(
+		cmd.Flags(), &o.Remote,
+		"Perform discovery using the Kargo API server and the credentials of "+
+			"the project instead of locally.",
+	)
+	option.Output(
+		cmd.Flags(), &o.Output, outputFormatText,
+		"Output format. One of: "+outputFormatText+"|"+outputFormatJSON+".",
+	)
+
+	if err := cmd.MarkFlagRequired(option.FilenameFlag); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as required: %w", err))
+	}
+	cmd.MarkFlagsMutuallyExclusive(option.RemoteFlag, option.CredentialsFlag)
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *discoverOptions) validate() error {
+	var errs []error
+	if o.Filename == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.FilenameFlag))
+	}
+	if o.Remote && o.Project == "" {
+		errs = append(errs, fmt.Errorf(
+			"%s is required when %s is set", option.ProjectFlag, option.RemoteFlag,
+		))
+	}
+	if o.Remote && len(o.CredentialsFiles) > 0 {
+		errs = append(errs, fmt.Errorf(
+			"%s is not supported when %s is set", option.CredentialsFlag, option.RemoteFlag,
+		))
+	}
+	if o.Output != outputFormatText && o.Output != outputFormatJSON {
+		errs = append(errs, fmt.Errorf(
+			"unsupported output format %q; must be one of: %s, %s",
+			o.Output, outputFormatText, outputFormatJSON,
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// run discovers artifacts for the Warehouse, either locally or using the API
+// server, and prints the result.
+func (o *discoverOptions) run(ctx context.Context) error {
+	var res *warehouses.DryRunResult
+	var err error
+	if o.Remote {
+		res, err = o.discoverRemotely(ctx)
+	} else {
+		res, err = o.discoverLocally(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return o.printResult(res)
+}
+
+// discoverLocally discovers artifacts for the Warehouse without contacting the
+// API server. Only the credentials loaded from the provided files are
+// available.
+func (o *discoverOptions) discoverLocally(ctx context.Context) (*warehouses.DryRunResult, error) {
+	project := o.Project
+	if project == "" {
+		project = defaultProject
+	}
+
+	warehouse, err := loadWarehouse(project, o.Filename)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := kubernetes.LoadCredentials(project, o.CredentialsFiles...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Credentials are served from memory, so nothing is ever read from a
+	// cluster.
+	objs := make([]libClient.Object, 0, len(secrets))
+	for _, s := range secrets {
+		objs = append(objs, s)
+	}
+	memClient, err := kubernetes.NewMemoryClient(objs...)
+	if err != nil {
+		return nil, err
+	}
+	basicProvider := &basic.CredentialProvider{}
+	credsDB := credsdb.NewDatabase(
+		memClient,
+		nil,
+		credentials.MustNewProviderRegistry(credentials.ProviderRegistration{
+			Predicate: basicProvider.Supports,
+			Value:     basicProvider,
+		}),
+		credsdb.DatabaseConfig{},
+	)
+
+	res, err := warehouses.DryRun(ctx, credsDB, subscription.DefaultSubscriberRegistry, warehouse)
+	if err != nil {
+		return nil, fmt.Errorf("discover artifacts: %w", err)
+	}
+	return res, nil
+}
+
+// discoverRemotely submits the Warehouse manifest to the API server, which
+// discovers artifacts using the credentials of the project.
+func (o *discoverOptions) discoverRemotely(ctx context.Context) (*warehouses.DryRunResult, error) {
+	manifest, err := os.ReadFile(o.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", o.Filename, err)
+	}
+
+	apiClient, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("get client from config: %w", err)
+	}
+
+	genRes, httpRes, err := apiClient.CoreAPI.
+		DiscoverWarehouseArtifacts(ctx, o.Project).
+		Manifest(string(manifest)).
+		Execute()
+	if httpRes != nil && httpRes.Body != nil {
+		_ = httpRes.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("discover artifacts: %w", client.APIError(err))
+	}
+
+	resJSON, err := json.Marshal(genRes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal result: %w", err)
+	}
+	res := &warehouses.DryRunResult{}
+	if err = json.Unmarshal(resJSON, res); err != nil {
+		return nil, fmt.Errorf("unmarshal result: %w", err)
+	}
+	return res, nil
+}
+
+// printResult prints the provided result in the configured output format.
+func (o *discoverOptions) printResult(res *warehouses.DryRunResult) error {
+	if o.Output == outputFormatJSON {
+		enc := json.NewEncoder(o.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+		return nil
+	}
+	if err := warehouses.WriteDryRunResult(o.Out, res); err != nil {
+		return fmt.Errorf("print result: %w", err)
+	}
+	return nil
+}
+
+// loadWarehouse loads the single Warehouse from the provided file, placing it
+// in the provided project.
+func loadWarehouse(project, filename string) (*kargoapi.Warehouse, error) {
+	var warehouse *kargoapi.Warehouse
+	err := kubernetes.ForEachDocument(
+		[]string{filename},
+		func(filename string, doc []byte) error {
+			obj, err := kubernetes.Decode(doc)
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", filename, err)
+			}
+			w, ok := obj.(*kargoapi.Warehouse)
+			if !ok {
+				return fmt.Errorf("expected only a Warehouse in %s, found %T", filename, obj)
+			}
+			if warehouse != nil {
+				return fmt.Errorf("expected a single Warehouse in %s, found more", filename)
+			}
+			warehouse = w
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if warehouse == nil {
+		return nil, fmt.Errorf("no Warehouse found in %s", filename)
+	}
+	warehouse.Namespace = project
+	return warehouse, nil
+}
@@ -0,0 +1,130 @@
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestDiscoverOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       discoverOptions
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "valid local",
+			opts: discoverOptions{
+				Filename:         "warehouse.yaml",
+				CredentialsFiles: []string{"creds.yaml"},
+				Output:           outputFormatText,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "valid remote",
+			opts: discoverOptions{
+				Filename: "warehouse.yaml",
+				Project:  "fake-project",
+				Remote:   true,
+				Output:   outputFormatJSON,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "missing filename",
+			opts: discoverOptions{Output: outputFormatText},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "filename is required")
+			},
+		},
+		{
+			name: "remote without project",
+			opts: discoverOptions{
+				Filename: "warehouse.yaml",
+				Remote:   true,
+				Output:   outputFormatText,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "project is required when remote is set")
+			},
+		},
+		{
+			name: "remote with credentials",
+			opts: discoverOptions{
+				Filename:         "warehouse.yaml",
+				CredentialsFiles: []string{"creds.yaml"},
+				Project:          "fake-project",
+				Remote:           true,
+				Output:           outputFormatText,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "credentials is not supported when remote is set")
+			},
+		},
+		{
+			name: "unsupported output format",
+			opts: discoverOptions{Filename: "warehouse.yaml", Output: "yaml"},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "unsupported output format")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(t, testCase.opts.validate())
+		})
+	}
+}
+
+func Test_loadWarehouse(t *testing.T) {
+	const warehouse = `apiVersion: kargo.akuity.io/v1alpha1
+kind: Warehouse
+metadata:
+  name: fake-warehouse
+  namespace: other
+spec:
+  subscriptions:
+  - image:
+      repoURL: example/image
+`
+	t.Run("success", func(t *testing.T) {
+		w, err := loadWarehouse("fake-project", writeFile(t, warehouse))
+		require.NoError(t, err)
+		require.Equal(t, "fake-warehouse", w.Name)
+		require.Equal(t, "fake-project", w.Namespace)
+		require.Len(t, w.Spec.InternalSubscriptions, 1)
+	})
+
+	t.Run("no Warehouse", func(t *testing.T) {
+		_, err := loadWarehouse("fake-project", writeFile(t, ""))
+		require.ErrorContains(t, err, "no Warehouse found")
+	})
+
+	t.Run("more than one Warehouse", func(t *testing.T) {
+		_, err := loadWarehouse("fake-project", writeFile(t, warehouse+"---\n"+warehouse))
+		require.ErrorContains(t, err, "expected a single Warehouse")
+	})
+
+	t.Run("not a Warehouse", func(t *testing.T) {
+		_, err := loadWarehouse("fake-project", writeFile(t, `apiVersion: v1
+kind: Secret
+metadata:
+  name: fake-secret
+`))
+		require.ErrorContains(t, err, "expected only a Warehouse")
+	})
+}
@@ -47,6 +47,7 @@ kargo get promotions --project=my-project --stage=my-stage
 	cmd.AddCommand(newGetClusterConfigCommand(cfg, streams, cmdOpts))
 	cmd.AddCommand(newGetConfigMapsCommand(cfg, streams, cmdOpts))
 	cmd.AddCommand(newGetGenericCredentialsCommand(cfg, streams, cmdOpts))
+	cmd.AddCommand(newGetLineageCommand(cfg, streams))
 	cmd.AddCommand(newGetRepoCredentialsCommand(cfg, streams, cmdOpts))
 	cmd.AddCommand(newGetFreightCommand(cfg, streams, cmdOpts))
 	cmd.AddCommand(newGetProjectConfigCommand(cfg, streams, cmdOpts))
@@ -0,0 +1,160 @@
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/pkg/cli/client"
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/io"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/lineage"
+)
+
+type getLineageOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project      string
+	Commit       string
+	Digest       string
+	ChartVersion string
+	Output       string
+}
+
+func newGetLineageCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &getLineageOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use: "lineage [--project=project] [--commit=sha] [--digest=digest] " +
+			"[--chart-version=version] [-o json|dot|mermaid]",
+		Short: "Display a graph of where freight came from and which stages it reached",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Show the lineage of all freight in my-project
+kargo get lineage --project=my-project
+
+# Show which stages a commit has reached
+kargo get lineage --project=my-project --commit=abc1234
+
+# Show which stages an image digest has reached as a Graphviz graph
+kargo get lineage --project=my-project --digest=sha256:abc... -o dot | dot -Tsvg > lineage.svg
+
+# Show which stages a chart version has reached as a Mermaid flowchart
+kargo get lineage --project=my-project --chart-version=1.2.3 -o mermaid
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the get lineage options to the provided command.
+func (o *getLineageOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project for which to get lineage. If not set, the default project will be used.",
+	)
+	option.Commit(cmd.Flags(), &o.Commit, "Only include freight referencing a Git commit with this SHA or SHA prefix.")
+	option.Digest(cmd.Flags(), &o.Digest, "Only include freight referencing a container image with this digest.")
+	option.ChartVersion(cmd.Flags(), &o.ChartVersion, "Only include freight referencing this Helm chart version.")
+	option.Output(
+		cmd.Flags(), &o.Output, string(lineage.FormatJSON),
+		fmt.Sprintf("Output format. One of: %s.", strings.Join(formatNames(), "|")),
+	)
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *getLineageOptions) validate() error {
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		return fmt.Errorf("%s is required", option.ProjectFlag)
+	}
+	if !slices.Contains(formatNames(), o.Output) {
+		return fmt.Errorf(
+			"unsupported output format %q; must be one of: %s",
+			o.Output, strings.Join(formatNames(), ", "),
+		)
+	}
+	return nil
+}
+
+// run gets the lineage graph from the server and prints it to the console.
+func (o *getLineageOptions) run(ctx context.Context) error {
+	apiClient, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	req := apiClient.CoreAPI.GetLineage(ctx, o.Project)
+	if o.Commit != "" {
+		req = req.Commit(o.Commit)
+	}
+	if o.Digest != "" {
+		req = req.Digest(o.Digest)
+	}
+	if o.ChartVersion != "" {
+		req = req.ChartVersion(o.ChartVersion)
+	}
+	res, httpRes, err := req.Execute()
+	if httpRes != nil {
+		_ = httpRes.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("get lineage: %w", client.APIError(err))
+	}
+
+	graphJSON, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("marshal lineage: %w", err)
+	}
+	graph := &lineage.Graph{}
+	if err = json.Unmarshal(graphJSON, graph); err != nil {
+		return fmt.Errorf("unmarshal lineage: %w", err)
+	}
+
+	if err = lineage.Write(o.Out, graph, lineage.Format(o.Output)); err != nil {
+		return fmt.Errorf("print lineage: %w", err)
+	}
+	return nil
+}
+
+func formatNames() []string {
+	formats := lineage.Formats()
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return names
+}
@@ -6,22 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
 	"github.com/akuity/kargo/pkg/cli/client"
 	"github.com/akuity/kargo/pkg/cli/config"
 	"github.com/akuity/kargo/pkg/cli/io"
 	"github.com/akuity/kargo/pkg/cli/kubernetes"
 	"github.com/akuity/kargo/pkg/cli/option"
 	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/conditions"
 	libCreds "github.com/akuity/kargo/pkg/credentials"
 )
 
@@ -36,6 +41,7 @@ type getRepoCredentialsOptions struct {
 
 	Shared  bool
 	Project string
+	Health  bool
 	Names   []string
 }
 
@@ -52,7 +58,7 @@ func newGetRepoCredentialsCommand(
 	}
 
 	cmd := &cobra.Command{
-		Use: "repo-credentials [--project=project] [NAME ...] [--no-headers]",
+		Use: "repo-credentials [--project=project] [NAME ...] [--no-headers] [--health]",
 		Aliases: []string{
 			"repo-credential",
 			"repo-creds",
@@ -83,6 +89,9 @@ kargo get repo-credentials --shared
 
 # Get specific shared repository credentials
 kargo get repo-credentials --shared my-credentials
+
+# Show the health and usage of all repository credentials in my-project
+kargo get repo-credentials --project=my-project --health
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmdOpts.complete(args)
@@ -118,8 +127,15 @@ func (o *getRepoCredentialsOptions) addFlags(cmd *cobra.Command) {
 		cmd.Flags(), &o.Shared, false,
 		"Whether to list shared credentials instead of project-specific credentials.",
 	)
+	option.Health(
+		cmd.Flags(), &o.Health,
+		"Whether to display the outcome of the most recent health check of the "+
+			"credentials and which Warehouses and Stages last used them.",
+	)
 	// project and shared flags are mutually exclusive
 	cmd.MarkFlagsMutuallyExclusive(option.ProjectFlag, option.SharedFlag)
+	// health and output flags are mutually exclusive
+	cmd.MarkFlagsMutuallyExclusive(option.HealthFlag, option.OutputFlag)
 }
 
 // complete sets the options from the command arguments.
@@ -178,7 +194,7 @@ func (o *getRepoCredentialsOptions) run(ctx context.Context) error {
 		if err = json.Unmarshal(credsJSON, &creds); err != nil {
 			return err
 		}
-		return PrintObjects(creds.Items, o.PrintFlags, o.IOStreams, o.NoHeaders)
+		return o.print(creds.Items)
 	}
 
 	res := make([]*corev1.Secret, 0, len(o.Names))
@@ -220,12 +236,29 @@ func (o *getRepoCredentialsOptions) run(ctx context.Context) error {
 		res = append(res, cred)
 	}
 
-	if err = PrintObjects(res, o.PrintFlags, o.IOStreams, o.NoHeaders); err != nil {
+	if err = o.print(res); err != nil {
 		return fmt.Errorf("print credentials: %w", err)
 	}
 	return errors.Join(errs...)
 }
 
+// print prints the provided credentials to the console, as a table describing
+// their health if requested.
+func (o *getRepoCredentialsOptions) print(secrets []*corev1.Secret) error {
+	if !o.Health {
+		return PrintObjects(secrets, o.PrintFlags, o.IOStreams, o.NoHeaders)
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	items := make([]runtime.RawExtension, len(secrets))
+	for i, secret := range secrets {
+		items[i] = runtime.RawExtension{Object: secret}
+	}
+	return printers.NewTablePrinter(printers.PrintOptions{NoHeaders: o.NoHeaders}).
+		PrintObj(newRepoCredentialsHealthTable(&metav1.List{Items: items}), o.Out)
+}
+
 func newRepoCredentialsTable(list *metav1.List) *metav1.Table {
 	rows := make([]metav1.TableRow, len(list.Items))
 	for i, item := range list.Items {
@@ -252,3 +285,80 @@ func newRepoCredentialsTable(list *metav1.List) *metav1.Table {
 		Rows: rows,
 	}
 }
+
+func newRepoCredentialsHealthTable(list *metav1.List) *metav1.Table {
+	rows := make([]metav1.TableRow, len(list.Items))
+	for i, item := range list.Items {
+		secret := item.Object.(*corev1.Secret) // nolint: forcetypeassert
+		repoURL := secret.StringData[libCreds.FieldRepoURL]
+		healthy, reason, changed, lastUsed, usedBy, message :=
+			"Unknown", "", "Never", "Never", "", ""
+		if health, ok := api.CredentialHealthAnnotationValue(secret.Annotations); ok {
+			if health.RepoURL != "" {
+				repoURL = health.RepoURL
+			}
+			if cond := conditions.Get(health, kargoapi.ConditionTypeHealthy); cond != nil {
+				healthy = string(cond.Status)
+				reason = cond.Reason
+				message = cond.Message
+			}
+			if health.CheckedAt != nil {
+				changed = duration.HumanDuration(time.Since(health.CheckedAt.Time)) + " ago"
+			}
+			lastUsed, usedBy = credentialUsage(secret.Namespace, health.UsedBy)
+		}
+		rows[i] = metav1.TableRow{
+			Cells: []any{
+				secret.Name,
+				secret.Labels[kargoapi.LabelKeyCredentialType],
+				repoURL,
+				healthy,
+				reason,
+				changed,
+				lastUsed,
+				usedBy,
+				message,
+			},
+			Object: list.Items[i],
+		}
+	}
+	return &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "Repo", Type: "string"},
+			{Name: "Healthy", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Changed", Type: "string"},
+			{Name: "Last Used", Type: "string"},
+			{Name: "Used By", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+		Rows: rows,
+	}
+}
+
+// credentialUsage summarizes the provided users of credentials stored in the
+// specified namespace. It returns a description of when the credentials were
+// most recently used and a comma-separated list of the resources that used
+// them. Resources in a namespace other than that of the credentials (i.e.
+// users of shared credentials) are qualified with their namespace.
+func credentialUsage(namespace string, users []kargoapi.CredentialUser) (string, string) {
+	var lastUsed *metav1.Time
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		if user.LastUsedAt != nil && (lastUsed == nil || lastUsed.Before(user.LastUsedAt)) {
+			lastUsed = user.LastUsedAt
+		}
+		name := fmt.Sprintf("%s/%s", strings.ToLower(user.Kind), user.Name)
+		if user.Namespace != namespace {
+			name = fmt.Sprintf("%s:%s", user.Namespace, name)
+		}
+		names = append(names, name)
+	}
+	lastUsedStr := "Never"
+	if lastUsed != nil {
+		lastUsedStr = duration.HumanDuration(time.Since(lastUsed.Time)) + " ago"
+	}
+	return lastUsedStr, strings.Join(slices.Compact(names), ",")
+}
@@ -234,6 +234,9 @@ func newAPITokensTable(list *metav1.List) *metav1.Table {
 				tokenSecret.Name,
 				tokenSecret.Annotations["kubernetes.io/service-account.name"],
 				tokenSecret.Annotations[rbacapi.AnnotationKeyManaged] == rbacapi.AnnotationValueTrue,
+				tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenScopes] != "",
+				apiTokenExpiry(tokenSecret),
+				apiTokenLastUsed(tokenSecret),
 				duration.HumanDuration(time.Since(tokenSecret.CreationTimestamp.Time)),
 			},
 			Object: list.Items[i],
@@ -244,8 +247,41 @@ func newAPITokensTable(list *metav1.List) *metav1.Table {
 			{Name: "Name", Type: "string"},
 			{Name: "Role", Type: "string"},
 			{Name: "Kargo Managed", Type: "bool"},
+			{Name: "Scoped", Type: "bool"},
+			{Name: "Expires", Type: "string"},
+			{Name: "Last Used", Type: "string"},
 			{Name: "Age", Type: "string"},
 		},
 		Rows: rows,
 	}
 }
+
+// apiTokenExpiry returns a human-readable description of when the provided
+// API token expires.
+func apiTokenExpiry(tokenSecret *corev1.Secret) string {
+	expiresAt, err := rbacapi.APITokenExpiryFromAnnotationValues(tokenSecret.Annotations)
+	switch {
+	case err != nil:
+		return "Unknown"
+	case expiresAt == nil:
+		return "Never"
+	case !time.Now().Before(*expiresAt):
+		return "Expired"
+	default:
+		return "in " + duration.HumanDuration(time.Until(*expiresAt))
+	}
+}
+
+// apiTokenLastUsed returns a human-readable description of when the provided
+// API token was last used.
+func apiTokenLastUsed(tokenSecret *corev1.Secret) string {
+	lastUsedStr, ok := tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenLastUsed]
+	if !ok {
+		return "Never"
+	}
+	lastUsed, err := time.Parse(time.RFC3339, lastUsedStr)
+	if err != nil {
+		return "Unknown"
+	}
+	return duration.HumanDuration(time.Since(lastUsed)) + " ago"
+}
@@ -63,6 +63,9 @@ kargo logs --project=my-project some-analysis-run --metric=some-metric --contain
 	// Register the option flags on the command.
 	cmdOpts.addFlags(cmd)
 
+	// Register subcommands.
+	cmd.AddCommand(newPromotionCommand(cfg, streams))
+
 	// Set the input/output streams for the command.
 	io.SetIOStreams(cmd, cmdOpts.IOStreams)
 
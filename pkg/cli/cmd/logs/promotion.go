@@ -0,0 +1,138 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/pkg/cli/client"
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/io"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/client/watch"
+)
+
+type promotionLogsOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project string
+	Name    string
+	Step    string
+	Follow  bool
+}
+
+func newPromotionCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &promotionLogsOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "promotion [--project=project] NAME [--step=alias] [--follow]",
+		Short: "View output captured from the steps of a Promotion",
+		Args:  option.ExactArgs(1),
+		Example: templates.Example(`
+# Show output from all steps of a Promotion
+kargo logs promotion --project=my-project some-promotion
+
+# Show output from a specific step of a Promotion
+kargo logs promotion --project=my-project some-promotion --step=render
+
+# Follow output from a Promotion until it completes
+kargo logs promotion --project=my-project some-promotion --follow
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the promotion logs options to the provided
+// command.
+func (o *promotionLogsOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project for which to get logs. If not set, the default project will be used.",
+	)
+	option.Step(cmd.Flags(), &o.Step, "The alias of a specific step of the Promotion")
+	option.Follow(cmd.Flags(), &o.Follow, "Continue streaming output until the Promotion completes")
+}
+
+// complete sets the options from the command arguments.
+func (o *promotionLogsOptions) complete(args []string) {
+	o.Name = strings.TrimSpace(args[0])
+}
+
+// run retrieves logs for the specified Promotion.
+func (o *promotionLogsOptions) run(ctx context.Context) error {
+	if o.Project == "" {
+		return errors.New("project is required")
+	}
+
+	watchClient, err := client.GetWatchClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	logCh, errCh := watchClient.StreamPromotionLogs(
+		ctx,
+		o.Project,
+		o.Name,
+		o.Step,
+		o.Follow,
+	)
+
+	return o.displayLogs(ctx, logCh, errCh)
+}
+
+func (o *promotionLogsOptions) displayLogs(
+	ctx context.Context,
+	logCh <-chan watch.PromotionStepLog,
+	errCh <-chan error,
+) error {
+	var lastAlias string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-errCh:
+			if ok && err != nil {
+				return fmt.Errorf("receive logs: %w", err)
+			}
+		case log, ok := <-logCh:
+			if !ok {
+				// Channel closed, stream complete
+				return nil
+			}
+			// When showing output from all steps, make it clear where the output
+			// of one step ends and the next begins.
+			if o.Step == "" && log.Alias != lastAlias {
+				_, _ = fmt.Fprintf(o.Out, "==> %s <==\n", log.Alias)
+				lastAlias = log.Alias
+			}
+			_, _ = fmt.Fprint(o.Out, log.Log)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/yaml"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/cli/client"
@@ -19,6 +20,7 @@ import (
 	"github.com/akuity/kargo/pkg/cli/option"
 	"github.com/akuity/kargo/pkg/cli/templates"
 	"github.com/akuity/kargo/pkg/client/watch"
+	"github.com/akuity/kargo/pkg/promotion"
 	kargogen "github.com/akuity/kargo/pkg/x/client/generated"
 )
 
@@ -38,6 +40,7 @@ type promotionOptions struct {
 	DownstreamFrom string
 	Abort          bool
 	Wait           bool
+	DryRun         bool
 }
 
 func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
@@ -50,7 +53,7 @@ func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra
 	cmd := &cobra.Command{
 		Use: "promote [--project=project] " +
 			"(--freight=freight | --freight-alias=alias | --warehouse=warehouse | --name=name) " +
-			"[(--stage=stage | --downstream-from=stage) | --abort]",
+			"[(--stage=stage [--dry-run] | --downstream-from=stage) | --abort]",
 		Short: "Promote a piece of freight",
 		Args:  option.NoArgs,
 		// nolint: lll
@@ -70,6 +73,10 @@ kargo promote --project=my-project --freight-alias=wonky-wombat --downstream-fro
 # Promote freight from my-warehouse to QA, selected as auto-promotion would (on success, this clears any active auto-promotion hold)
 kargo promote --project=my-project --warehouse=my-warehouse --stage=qa
 
+# Show what promoting a piece of freight to the QA stage would do, without
+# pushing any changes or creating a promotion
+kargo promote --project=my-project --freight=abc123 --stage=qa --dry-run
+
 # Abort a Promotion by name
 kargo promote --project=my-project --name=my-promotion --abort
 
@@ -152,6 +159,11 @@ func (o *promotionOptions) addFlags(cmd *cobra.Command) {
 		"Abort a non-terminal promotion. If set, --%s must be set.", option.NameFlag,
 	))
 	option.Wait(cmd.Flags(), &o.Wait, false, "Wait for the promotion(s) to complete.")
+	option.DryRun(cmd.Flags(), &o.DryRun, fmt.Sprintf(
+		"Execute the promotion steps of the stage without creating a promotion, "+
+			"skipping any steps that would change external state, and show what "+
+			"they did. If set, --%s must be set.", option.StageFlag,
+	))
 	cmd.MarkFlagsOneRequired(option.FreightFlag, option.FreightAliasFlag, option.WarehouseFlag, option.NameFlag)
 	cmd.MarkFlagsMutuallyExclusive(option.FreightFlag, option.FreightAliasFlag, option.WarehouseFlag, option.NameFlag)
 
@@ -160,6 +172,11 @@ func (o *promotionOptions) addFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive(option.WarehouseFlag, option.DownstreamFromFlag)
 
 	cmd.MarkFlagsRequiredTogether(option.NameFlag, option.AbortFlag)
+
+	cmd.MarkFlagsMutuallyExclusive(option.DryRunFlag, option.DownstreamFromFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.DryRunFlag, option.AbortFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.DryRunFlag, option.WarehouseFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.DryRunFlag, option.WaitFlag)
 }
 
 // validate performs validation of the options. If the options are invalid, an
@@ -194,6 +211,9 @@ func (o *promotionOptions) validate() error {
 				fmt.Errorf("either %s or %s is required", option.StageFlag, option.DownstreamFromFlag),
 			)
 		}
+		if o.DryRun && o.Stage == "" {
+			errs = append(errs, fmt.Errorf("%s is required for a dry run", option.StageFlag))
+		}
 	}
 
 	return errors.Join(errs...)
@@ -221,6 +241,8 @@ func (o *promotionOptions) run(ctx context.Context) error {
 			return fmt.Errorf("abort promotion: %w", client.APIError(abortErr))
 		}
 		return nil
+	case o.DryRun:
+		return o.runDryRun(ctx, apiClient)
 	case o.Stage != "":
 		var origin string
 		if o.Warehouse != "" {
@@ -297,6 +319,57 @@ func (o *promotionOptions) run(ctx context.Context) error {
 	return nil
 }
 
+// runDryRun performs a dry run of the promotion of the freight to the stage
+// and prints the result.
+func (o *promotionOptions) runDryRun(
+	ctx context.Context,
+	apiClient *kargogen.APIClient,
+) error {
+	res, httpRes, err := apiClient.CoreAPI.
+		PromoteToStageDryRun(ctx, o.Project, o.Stage).
+		Body(kargogen.PromoteToStageDryRunRequest{
+			Freight:      &o.FreightName,
+			FreightAlias: &o.FreightAlias,
+		}).
+		Execute()
+	if httpRes != nil {
+		_ = httpRes.Body.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("dry run promotion: %w", client.APIError(err))
+	}
+
+	resJSON, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("marshal dry run result: %w", err)
+	}
+	switch {
+	case o.OutputFormat == nil || *o.OutputFormat == "":
+		result := &promotion.DryRunResult{}
+		if err = json.Unmarshal(resJSON, result); err != nil {
+			return fmt.Errorf("unmarshal dry run result: %w", err)
+		}
+		if err = promotion.WriteDryRunResult(o.Out, result); err != nil {
+			return fmt.Errorf("print dry run result: %w", err)
+		}
+	case *o.OutputFormat == "json":
+		if _, err = fmt.Fprintln(o.Out, string(resJSON)); err != nil {
+			return fmt.Errorf("print dry run result: %w", err)
+		}
+	case *o.OutputFormat == "yaml":
+		resYAML, err := yaml.JSONToYAML(resJSON)
+		if err != nil {
+			return fmt.Errorf("marshal dry run result: %w", err)
+		}
+		if _, err = o.Out.Write(resYAML); err != nil {
+			return fmt.Errorf("print dry run result: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q for a dry run", *o.OutputFormat)
+	}
+	return nil
+}
+
 func (o *promotionOptions) waitForPromotions(
 	ctx context.Context,
 	p ...*kargoapi.Promotion,
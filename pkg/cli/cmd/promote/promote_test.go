@@ -33,6 +33,18 @@ func TestPromotionOptionsValidate(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "dry run without stage",
+			opts: promotionOptions{
+				Project:        "fake-project",
+				FreightName:    "fake-freight",
+				DownstreamFrom: "fake-stage",
+				DryRun:         true,
+			},
+			assertions: func(t *testing.T, _ promotionOptions, err error) {
+				require.ErrorContains(t, err, "stage is required for a dry run")
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
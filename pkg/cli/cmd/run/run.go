@@ -0,0 +1,27 @@
+package run
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+)
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run SUBCOMMAND",
+		Short: "Run things locally",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Run the promotion steps defined in a file
+kargo run steps -f promotion.yaml --freight freight.yaml --work-dir ./tmp
+`),
+	}
+
+	// Register subcommands.
+	cmd.AddCommand(newStepsCommand(cfg, streams))
+
+	return cmd
+}
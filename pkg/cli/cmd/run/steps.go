@@ -0,0 +1,469 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	"github.com/akuity/kargo/pkg/cli/config"
+	"github.com/akuity/kargo/pkg/cli/credentials"
+	kargoio "github.com/akuity/kargo/pkg/cli/io"
+	"github.com/akuity/kargo/pkg/cli/kubernetes"
+	"github.com/akuity/kargo/pkg/cli/option"
+	"github.com/akuity/kargo/pkg/cli/templates"
+	"github.com/akuity/kargo/pkg/promotion"
+
+	_ "github.com/akuity/kargo/pkg/promotion/runner/builtin"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+
+	// defaultProject is the project steps are run in when neither a project nor
+	// a default project has been configured.
+	defaultProject = "local"
+	// localPromotionName is the name of the Promotion steps are run on behalf
+	// of.
+	localPromotionName = "local"
+)
+
+type stepsOptions struct {
+	genericiooptions.IOStreams
+
+	Config config.CLIConfig
+
+	Filenames        []string
+	FreightFiles     []string
+	CredentialsFiles []string
+	Vars             []string
+	WorkDir          string
+	Project          string
+	AllowSideEffects bool
+	AllowedSteps     []string
+	Output           string
+}
+
+func newStepsCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &stepsOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use: "steps -f FILENAME [--freight=FILENAME]... [--credentials=FILENAME]... " +
+			"[--var=name=value]... [--work-dir=DIR] [--allow-side-effects | --allow-step=KIND...] " +
+			"[-o text|json]",
+		Short: "Run promotion steps locally",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Run the steps of a promotion template against a piece of freight, keeping
+# the working directory for inspection afterwards
+kargo run steps -f promotion.yaml --freight freight.yaml --work-dir ./tmp
+
+# Run the steps of a stage's promotion template, supplying repository
+# credentials from a Secret manifest. Repositories that no Secret applies to
+# are accessed using credentials from Git credential helpers and Docker's
+# configuration, if they have any.
+kargo run steps -f stage.yaml --freight freight.yaml --credentials creds.yaml
+
+# Run a promotion task, supplying values for its variables
+kargo run steps -f task.yaml --freight freight.yaml --var repoURL=https://github.com/example/repo
+
+# Also execute git-push steps, but no other steps with side effects
+kargo run steps -f promotion.yaml --freight freight.yaml --allow-step git-push
+
+# Execute all steps, including those with side effects
+kargo run steps -f promotion.yaml --freight freight.yaml --allow-side-effects
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	kargoio.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the run steps options to the provided command.
+func (o *stepsOptions) addFlags(cmd *cobra.Command) {
+	option.Filenames(
+		cmd.Flags(), &o.Filenames,
+		"File containing a Stage, a promotion template, or PromotionTasks to run.",
+	)
+	option.FreightFiles(
+		cmd.Flags(), &o.FreightFiles,
+		"File containing Freight to run the steps against. May be specified "+
+			"multiple times. The first piece of Freight is the one being promoted.",
+	)
+	option.Credentials(
+		cmd.Flags(), &o.CredentialsFiles,
+		"File containing Kargo credential Secrets to make available to the "+
+			"steps. May be specified multiple times. Repositories that none of "+
+			"these apply to are accessed using credentials from Git credential "+
+			"helpers or, for container and OCI chart registries, Docker's "+
+			"configuration. SSH agents are not used.",
+	)
+	option.Vars(
+		cmd.Flags(), &o.Vars,
+		"A variable to make available to the steps, in the form name=value. "+
+			"May be specified multiple times.",
+	)
+	option.WorkDir(
+		cmd.Flags(), &o.WorkDir,
+		"Directory in which to run the steps. It is left in place afterwards. "+
+			"If not set, a temporary directory is used and removed afterwards.",
+	)
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project to run the steps as part of. If not set, the default "+
+			"project will be used, or \""+defaultProject+"\" if there is none.",
+	)
+	option.AllowSideEffects(
+		cmd.Flags(), &o.AllowSideEffects,
+		"Execute all steps, including those with side effects, such as pushing "+
+			"commits or opening pull requests.",
+	)
+	option.AllowSteps(
+		cmd.Flags(), &o.AllowedSteps,
+		"A kind of step with side effects that should be executed anyway. May "+
+			"be specified multiple times.",
+	)
+	option.Output(
+		cmd.Flags(), &o.Output, outputFormatText,
+		"Output format. One of: "+outputFormatText+"|"+outputFormatJSON+".",
+	)
+
+	if err := cmd.MarkFlagRequired(option.FilenameFlag); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as required: %w", err))
+	}
+	cmd.MarkFlagsMutuallyExclusive(option.AllowSideEffectsFlag, option.AllowStepFlag)
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *stepsOptions) validate() error {
+	var errs []error
+	if len(o.Filenames) == 0 {
+		errs = append(errs, fmt.Errorf("%s is required", option.FilenameFlag))
+	}
+	if _, err := parseVars(o.Vars); err != nil {
+		errs = append(errs, err)
+	}
+	if o.Output != outputFormatText && o.Output != outputFormatJSON {
+		errs = append(errs, fmt.Errorf(
+			"unsupported output format %q; must be one of: %s, %s",
+			o.Output, outputFormatText, outputFormatJSON,
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// run loads the steps, Freight and credentials from the provided files and
+// executes the steps locally.
+func (o *stepsOptions) run(ctx context.Context) error {
+	project := o.Project
+	if project == "" {
+		project = defaultProject
+	}
+
+	manifests, err := loadManifests(project, o.Filenames...)
+	if err != nil {
+		return err
+	}
+	freight, err := loadFreight(project, o.FreightFiles...)
+	if err != nil {
+		return err
+	}
+	secrets, err := kubernetes.LoadCredentials(project, o.CredentialsFiles...)
+	if err != nil {
+		return err
+	}
+	vars, err := parseVars(o.Vars)
+	if err != nil {
+		return err
+	}
+
+	promo, stage, err := manifests.buildPromotion(project, vars)
+	if err != nil {
+		return err
+	}
+
+	// Everything the steps may look up is served from memory, so nothing is
+	// ever read from or written to a cluster. Steps that attempt to write
+	// anything fail.
+	objs := make([]client.Object, 0, len(freight)+len(secrets)+len(manifests.objects))
+	for _, f := range freight {
+		objs = append(objs, f)
+	}
+	for _, s := range secrets {
+		objs = append(objs, s)
+	}
+	objs = append(objs, manifests.objects...)
+	memClient, err := kubernetes.NewMemoryClient(objs...)
+	if err != nil {
+		return err
+	}
+
+	if err = api.InflateSteps(ctx, memClient, promo); err != nil {
+		return fmt.Errorf("inflate steps: %w", err)
+	}
+
+	var targetFreightAlias string
+	if len(freight) > 0 {
+		targetFreightAlias = freight[0].Alias
+		targetFreightRef := freightReference(freight[0])
+		promo.Status.Freight = &targetFreightRef
+		promo.Status.FreightCollection = &kargoapi.FreightCollection{}
+		for _, f := range freight {
+			promo.Status.FreightCollection.UpdateOrPush(freightReference(f))
+		}
+	}
+	promoCtx := promotion.NewContext(
+		promo,
+		stage,
+		promotion.WithTargetFreightAlias(targetFreightAlias),
+	)
+	if stage == nil {
+		// Without a Stage, every piece of Freight provided is treated as
+		// having been requested.
+		for _, f := range freight {
+			promoCtx.FreightRequests = append(
+				promoCtx.FreightRequests,
+				kargoapi.FreightRequest{Origin: f.Origin},
+			)
+		}
+	}
+
+	res, err := promotion.NewDryRunEngine(
+		memClient,
+		credentials.NewDatabase(memClient),
+		promotion.NewGitUserResolver(memClient, "", promotion.GitUserFromEnv()),
+		promotion.DefaultExprDataCacheFn,
+	).DryRun(ctx, promoCtx, promotion.NewSteps(promo), &promotion.DryRunOptions{
+		WorkDir:          o.WorkDir,
+		AllowSideEffects: o.AllowSideEffects,
+		AllowedStepKinds: o.AllowedSteps,
+	})
+	if err != nil {
+		return fmt.Errorf("run steps: %w", err)
+	}
+
+	if err = o.printResult(res); err != nil {
+		return err
+	}
+	if res.Status != kargoapi.PromotionPhaseSucceeded {
+		return fmt.Errorf("steps finished with status %s", res.Status)
+	}
+	return nil
+}
+
+// printResult prints the provided result in the configured output format.
+func (o *stepsOptions) printResult(res *promotion.DryRunResult) error {
+	if o.Output == outputFormatJSON {
+		enc := json.NewEncoder(o.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("print result: %w", err)
+		}
+		return nil
+	}
+	if err := promotion.WriteDryRunResult(o.Out, res); err != nil {
+		return fmt.Errorf("print result: %w", err)
+	}
+	return nil
+}
+
+// stepManifests holds the resources loaded from the files passed to
+// --filename.
+type stepManifests struct {
+	// templates holds any promotion templates that were provided without
+	// being wrapped in a Kubernetes resource.
+	templates []kargoapi.PromotionTemplateSpec
+	stages    []*kargoapi.Stage
+	tasks     []*kargoapi.PromotionTask
+	// clusterTasks holds any ClusterPromotionTasks that were provided.
+	clusterTasks []*kargoapi.ClusterPromotionTask
+	// objects holds all of the above that are Kubernetes resources, so that
+	// they can be looked up by the steps.
+	objects []client.Object
+}
+
+// buildPromotion builds the Promotion that runs the steps defined by the
+// manifests. If the steps are defined by a Stage, that Stage is returned as
+// well.
+func (m stepManifests) buildPromotion(
+	project string,
+	vars []kargoapi.ExpressionVariable,
+) (*kargoapi.Promotion, *kargoapi.Stage, error) {
+	promo := &kargoapi.Promotion{}
+	promo.Namespace = project
+	promo.Name = localPromotionName
+
+	var stage *kargoapi.Stage
+	switch entrypoints := len(m.templates) + len(m.stages); {
+	case entrypoints > 1:
+		return nil, nil, errors.New(
+			"found more than one Stage or promotion template; only one may be run at a time",
+		)
+	case len(m.templates) == 1:
+		promo.Spec.Steps = m.templates[0].Steps
+		promo.Spec.Vars = append(slices.Clone(m.templates[0].Vars), vars...)
+	case len(m.stages) == 1:
+		stage = m.stages[0]
+		if stage.Spec.PromotionTemplate == nil {
+			return nil, nil, fmt.Errorf("Stage %q has no promotion template", stage.Name)
+		}
+		promo.Spec.Stage = stage.Name
+		promo.Spec.Steps = stage.Spec.PromotionTemplate.Spec.Steps
+		promo.Spec.Vars = append(
+			append(slices.Clone(stage.Spec.Vars), stage.Spec.PromotionTemplate.Spec.Vars...),
+			vars...,
+		)
+	case len(m.tasks)+len(m.clusterTasks) == 1:
+		// A lone task is run by a single step that references it, with the
+		// variables passed to that step as the task's inputs.
+		ref := &kargoapi.PromotionTaskReference{}
+		if len(m.tasks) == 1 {
+			ref.Name = m.tasks[0].Name
+			ref.Kind = "PromotionTask"
+		} else {
+			ref.Name = m.clusterTasks[0].Name
+			ref.Kind = "ClusterPromotionTask"
+		}
+		promo.Spec.Steps = []kargoapi.PromotionStep{{Task: ref, Vars: vars}}
+	case len(m.tasks)+len(m.clusterTasks) > 1:
+		return nil, nil, errors.New(
+			"found more than one PromotionTask but no Stage or promotion template " +
+				"to determine which to run",
+		)
+	default:
+		return nil, nil, errors.New(
+			"found no Stage, promotion template, or PromotionTask to run",
+		)
+	}
+	if len(promo.Spec.Steps) == 0 {
+		return nil, nil, errors.New("there are no steps to run")
+	}
+	return promo, stage, nil
+}
+
+// loadManifests loads the Stages, promotion templates and PromotionTasks from
+// the provided files, placing any namespaced resources in the provided
+// project.
+func loadManifests(project string, filenames ...string) (stepManifests, error) {
+	var m stepManifests
+	err := kubernetes.ForEachDocument(filenames, func(filename string, doc []byte) error {
+		var typeMeta struct {
+			Kind string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return fmt.Errorf("parse %s: %w", filename, err)
+		}
+		if typeMeta.Kind == "" {
+			// Without a kind, the document is expected to be the spec of a
+			// promotion template, as it would appear in a Stage.
+			var tmpl kargoapi.PromotionTemplateSpec
+			if err := yaml.UnmarshalStrict(doc, &tmpl); err != nil {
+				return fmt.Errorf("parse promotion template in %s: %w", filename, err)
+			}
+			m.templates = append(m.templates, tmpl)
+			return nil
+		}
+		obj, err := kubernetes.Decode(doc)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", filename, err)
+		}
+		switch o := obj.(type) {
+		case *kargoapi.Stage:
+			o.Namespace = project
+			m.stages = append(m.stages, o)
+		case *kargoapi.PromotionTask:
+			o.Namespace = project
+			m.tasks = append(m.tasks, o)
+		case *kargoapi.ClusterPromotionTask:
+			m.clusterTasks = append(m.clusterTasks, o)
+		default:
+			return fmt.Errorf(
+				"unsupported kind %q in %s; expected Stage, PromotionTask or ClusterPromotionTask",
+				typeMeta.Kind, filename,
+			)
+		}
+		m.objects = append(m.objects, obj.(client.Object)) // nolint: forcetypeassert
+		return nil
+	})
+	return m, err
+}
+
+// loadFreight loads the Freight from the provided files, placing it in the
+// provided project.
+func loadFreight(project string, filenames ...string) ([]*kargoapi.Freight, error) {
+	var freight []*kargoapi.Freight
+	err := kubernetes.ForEachDocument(filenames, func(filename string, doc []byte) error {
+		obj, err := kubernetes.Decode(doc)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", filename, err)
+		}
+		f, ok := obj.(*kargoapi.Freight)
+		if !ok {
+			return fmt.Errorf("expected only Freight in %s, found %T", filename, obj)
+		}
+		f.Namespace = project
+		if f.Name == "" {
+			f.Name = fmt.Sprintf("local-%d", len(freight))
+		}
+		freight = append(freight, f)
+		return nil
+	})
+	return freight, err
+}
+
+// parseVars parses variables provided in the form name=value.
+func parseVars(raw []string) ([]kargoapi.ExpressionVariable, error) {
+	vars := make([]kargoapi.ExpressionVariable, 0, len(raw))
+	for _, r := range raw {
+		name, value, ok := strings.Cut(r, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid %s %q; must be in the form name=value", option.VarFlag, r)
+		}
+		vars = append(vars, kargoapi.ExpressionVariable{
+			Name:  strings.TrimSpace(name),
+			Value: value,
+		})
+	}
+	return vars, nil
+}
+
+// freightReference returns a reference to the provided Freight.
+func freightReference(f *kargoapi.Freight) kargoapi.FreightReference {
+	return kargoapi.FreightReference{
+		Name:      f.Name,
+		Commits:   f.Commits,
+		Images:    f.Images,
+		Charts:    f.Charts,
+		Artifacts: f.Artifacts,
+		Origin:    f.Origin,
+	}
+}
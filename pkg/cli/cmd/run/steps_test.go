@@ -0,0 +1,306 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStepsOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		opts       stepsOptions
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "valid",
+			opts: stepsOptions{
+				Filenames: []string{"promotion.yaml"},
+				Vars:      []string{"foo=bar=baz"},
+				Output:    outputFormatText,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "missing filename",
+			opts: stepsOptions{Output: outputFormatText},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "filename is required")
+			},
+		},
+		{
+			name: "invalid var",
+			opts: stepsOptions{
+				Filenames: []string{"promotion.yaml"},
+				Vars:      []string{"foo"},
+				Output:    outputFormatText,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, `invalid var "foo"`)
+			},
+		},
+		{
+			name: "unsupported output format",
+			opts: stepsOptions{
+				Filenames: []string{"promotion.yaml"},
+				Output:    "yaml",
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, `unsupported output format "yaml"`)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(t, testCase.opts.validate())
+		})
+	}
+}
+
+func Test_parseVars(t *testing.T) {
+	vars, err := parseVars([]string{"foo=bar", " baz =a=b", "empty="})
+	require.NoError(t, err)
+	require.Equal(t, []kargoapi.ExpressionVariable{
+		{Name: "foo", Value: "bar"},
+		{Name: "baz", Value: "a=b"},
+		{Name: "empty", Value: ""},
+	}, vars)
+
+	_, err = parseVars([]string{"=bar"})
+	require.ErrorContains(t, err, "must be in the form name=value")
+}
+
+func Test_buildPromotion(t *testing.T) {
+	vars := []kargoapi.ExpressionVariable{{Name: "extra", Value: "value"}}
+
+	testCases := []struct {
+		name       string
+		manifest   string
+		assertions func(*testing.T, *kargoapi.Promotion, *kargoapi.Stage, error)
+	}{
+		{
+			name: "promotion template",
+			manifest: `
+vars:
+- name: foo
+  value: bar
+steps:
+- uses: git-clone
+`,
+			assertions: func(t *testing.T, promo *kargoapi.Promotion, stage *kargoapi.Stage, err error) {
+				require.NoError(t, err)
+				require.Nil(t, stage)
+				require.Equal(t, "fake-project", promo.Namespace)
+				require.Len(t, promo.Spec.Steps, 1)
+				require.Equal(t, "git-clone", promo.Spec.Steps[0].Uses)
+				require.Equal(t, []kargoapi.ExpressionVariable{
+					{Name: "foo", Value: "bar"},
+					{Name: "extra", Value: "value"},
+				}, promo.Spec.Vars)
+			},
+		},
+		{
+			name: "stage",
+			manifest: `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: Stage
+metadata:
+  name: test
+  namespace: other-project
+spec:
+  vars:
+  - name: stage
+    value: var
+  promotionTemplate:
+    spec:
+      vars:
+      - name: template
+        value: var
+      steps:
+      - uses: git-clone
+`,
+			assertions: func(t *testing.T, promo *kargoapi.Promotion, stage *kargoapi.Stage, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, stage)
+				require.Equal(t, "fake-project", stage.Namespace)
+				require.Equal(t, "test", promo.Spec.Stage)
+				require.Len(t, promo.Spec.Steps, 1)
+				require.Equal(t, []kargoapi.ExpressionVariable{
+					{Name: "stage", Value: "var"},
+					{Name: "template", Value: "var"},
+					{Name: "extra", Value: "value"},
+				}, promo.Spec.Vars)
+			},
+		},
+		{
+			name: "stage and tasks",
+			manifest: `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: Stage
+metadata:
+  name: test
+spec:
+  promotionTemplate:
+    spec:
+      steps:
+      - task:
+          name: task
+---
+apiVersion: kargo.akuity.io/v1alpha1
+kind: PromotionTask
+metadata:
+  name: task
+spec:
+  steps:
+  - uses: git-clone
+---
+apiVersion: kargo.akuity.io/v1alpha1
+kind: ClusterPromotionTask
+metadata:
+  name: other-task
+spec:
+  steps:
+  - uses: git-clone
+`,
+			assertions: func(t *testing.T, promo *kargoapi.Promotion, stage *kargoapi.Stage, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, stage)
+				require.Len(t, promo.Spec.Steps, 1)
+				require.Equal(t, "task", promo.Spec.Steps[0].Task.Name)
+			},
+		},
+		{
+			name: "single task",
+			manifest: `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: ClusterPromotionTask
+metadata:
+  name: task
+spec:
+  steps:
+  - uses: git-clone
+`,
+			assertions: func(t *testing.T, promo *kargoapi.Promotion, _ *kargoapi.Stage, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.PromotionStep{{
+					Task: &kargoapi.PromotionTaskReference{
+						Name: "task",
+						Kind: "ClusterPromotionTask",
+					},
+					Vars: vars,
+				}}, promo.Spec.Steps)
+			},
+		},
+		{
+			name: "multiple tasks",
+			manifest: `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: PromotionTask
+metadata:
+  name: task
+spec:
+  steps:
+  - uses: git-clone
+---
+apiVersion: kargo.akuity.io/v1alpha1
+kind: PromotionTask
+metadata:
+  name: other-task
+spec:
+  steps:
+  - uses: git-clone
+`,
+			assertions: func(t *testing.T, _ *kargoapi.Promotion, _ *kargoapi.Stage, err error) {
+				require.ErrorContains(t, err, "found more than one PromotionTask")
+			},
+		},
+		{
+			name: "multiple templates",
+			manifest: `
+steps:
+- uses: git-clone
+---
+steps:
+- uses: git-clone
+`,
+			assertions: func(t *testing.T, _ *kargoapi.Promotion, _ *kargoapi.Stage, err error) {
+				require.ErrorContains(t, err, "found more than one Stage or promotion template")
+			},
+		},
+		{
+			name: "no steps",
+			manifest: `
+vars:
+- name: foo
+`,
+			assertions: func(t *testing.T, _ *kargoapi.Promotion, _ *kargoapi.Stage, err error) {
+				require.ErrorContains(t, err, "there are no steps to run")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			manifests, err := loadManifests("fake-project", writeFile(t, testCase.manifest))
+			require.NoError(t, err)
+			promo, stage, err := manifests.buildPromotion("fake-project", vars)
+			testCase.assertions(t, promo, stage, err)
+		})
+	}
+}
+
+func Test_loadManifests_unsupportedKind(t *testing.T) {
+	_, err := loadManifests("fake-project", writeFile(t, `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: Warehouse
+metadata:
+  name: test
+`))
+	require.ErrorContains(t, err, `unsupported kind "Warehouse"`)
+}
+
+func Test_loadFreight(t *testing.T) {
+	freight, err := loadFreight("fake-project", writeFile(t, `
+apiVersion: kargo.akuity.io/v1alpha1
+kind: Freight
+metadata:
+  name: abc
+  namespace: other-project
+alias: fuzzy-fox
+origin:
+  kind: Warehouse
+  name: test
+---
+apiVersion: kargo.akuity.io/v1alpha1
+kind: Freight
+origin:
+  kind: Warehouse
+  name: other
+`))
+	require.NoError(t, err)
+	require.Len(t, freight, 2)
+	require.Equal(t, "abc", freight[0].Name)
+	require.Equal(t, "fake-project", freight[0].Namespace)
+	require.Equal(t, "fuzzy-fox", freight[0].Alias)
+	require.Equal(t, "local-1", freight[1].Name)
+
+	_, err = loadFreight("fake-project", writeFile(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+`))
+	require.ErrorContains(t, err, "expected only Freight")
+}
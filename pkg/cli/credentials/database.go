@@ -0,0 +1,162 @@
+package credentials
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libCreds "github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/credentials/basic"
+	credsdb "github.com/akuity/kargo/pkg/credentials/kubernetes"
+)
+
+// database is a credentials.Database for commands that run locally. It serves
+// credentials from Kargo credential Secrets and falls back to credentials
+// found in the local environment.
+type database struct {
+	secrets  libCreds.Database
+	keychain authn.Keychain
+}
+
+// NewDatabase returns a credentials.Database for commands that run locally.
+// Credentials are served from the Kargo credential Secrets available through
+// the provided client. For any repository to which none of those Secrets
+// apply, credentials are obtained from the local environment:
+//
+//   - For Git repositories accessed over HTTP(S), from the Git credential
+//     helpers configured for the current user.
+//   - For container image repositories and OCI Helm chart repositories, from
+//     the current user's Docker configuration, including any credential
+//     helpers configured there.
+//
+// No other local credentials, such as those held by an SSH agent, are used.
+func NewDatabase(kubeClient client.Client) libCreds.Database {
+	basicProvider := &basic.CredentialProvider{}
+	return &database{
+		secrets: credsdb.NewDatabase(
+			kubeClient,
+			nil,
+			libCreds.MustNewProviderRegistry(libCreds.ProviderRegistration{
+				Predicate: basicProvider.Supports,
+				Value:     basicProvider,
+			}),
+			credsdb.DatabaseConfig{},
+		),
+		keychain: authn.DefaultKeychain,
+	}
+}
+
+// Get implements credentials.Database.
+func (d *database) Get(
+	ctx context.Context,
+	namespace string,
+	credType libCreds.Type,
+	repoURL string,
+) (*libCreds.Credentials, error) {
+	creds, err := d.secrets.Get(ctx, namespace, credType, repoURL)
+	if err != nil || creds != nil {
+		return creds, err
+	}
+	switch credType {
+	case libCreds.TypeGit:
+		return gitHelperCredentials(ctx, repoURL)
+	case libCreds.TypeImage:
+		return d.registryCredentials(repoURL)
+	case libCreds.TypeHelm:
+		if repo, ok := strings.CutPrefix(repoURL, "oci://"); ok {
+			return d.registryCredentials(repo)
+		}
+	}
+	return nil, nil
+}
+
+// gitHelperCredentials asks the Git credential helpers configured for the
+// current user for credentials for the specified repository. Nil is returned
+// if the repository is not accessed over HTTP(S) or if no helper has
+// credentials for it. The user is never prompted for credentials.
+func gitHelperCredentials(ctx context.Context, repoURL string) (*libCreds.Credentials, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, nil // nolint: nilerr
+	}
+	req := fmt.Sprintf(
+		"protocol=%s\nhost=%s\npath=%s\n",
+		u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/"),
+	)
+	if username := u.User.Username(); username != "" {
+		req += "username=" + username + "\n"
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-c", "core.askPass=", "credential", "fill")
+	cmd.Stdin = strings.NewReader(req + "\n")
+	// Without these, Git would prompt for credentials that no helper has.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	out, err := cmd.Output()
+	if err != nil {
+		// Git exits with an error when no helper had credentials and it was not
+		// permitted to prompt for them.
+		return nil, nil // nolint: nilerr
+	}
+
+	creds := &libCreds.Credentials{}
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "username":
+			creds.Username = value
+		case "password":
+			creds.Password = value
+		}
+	}
+	if creds.Password == "" {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// registryCredentials returns credentials for the registry hosting the
+// specified repository from the current user's Docker configuration. Nil is
+// returned if there are none, or if they are not a username and password.
+func (d *database) registryCredentials(repoURL string) (*libCreds.Credentials, error) {
+	repo, err := name.NewRepository(repoURL)
+	if err != nil {
+		return nil, nil // nolint: nilerr
+	}
+	authenticator, err := d.keychain.Resolve(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error reading local credentials for %s: %w", repo.RegistryStr(), err)
+	}
+	if authenticator == authn.Anonymous {
+		return nil, nil
+	}
+	cfg, err := authenticator.Authorization()
+	if err != nil {
+		return nil, fmt.Errorf("error reading local credentials for %s: %w", repo.RegistryStr(), err)
+	}
+	if cfg.Password == "" && cfg.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(cfg.Auth)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error decoding local credentials for %s: %w", repo.RegistryStr(), err,
+			)
+		}
+		cfg.Username, cfg.Password, _ = strings.Cut(string(decoded), ":")
+	}
+	if cfg.Password == "" {
+		return nil, nil
+	}
+	return &libCreds.Credentials{
+		Username: cfg.Username,
+		Password: cfg.Password,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/cli/kubernetes"
+	libCreds "github.com/akuity/kargo/pkg/credentials"
+)
+
+func TestDatabase_Get(t *testing.T) {
+	// Set up a Git credential helper that only has credentials for one host
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	helper := filepath.Join(home, "git-credential-fake")
+	require.NoError(t, os.WriteFile(helper, []byte(`#!/bin/sh
+test "$1" = get || exit 0
+grep -q host=git.example.com || exit 0
+echo username=helper-user
+echo password=helper-password
+`), 0o700))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(home, ".gitconfig"),
+		[]byte("[credential]\n\thelper = "+helper+"\n"),
+		0o600,
+	))
+
+	// Set up a Docker configuration that only has credentials for one registry
+	dockerConfig := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dockerConfig)
+	require.NoError(t, os.WriteFile(filepath.Join(dockerConfig, "config.json"), []byte(`{
+  "auths": {
+    "registry.example.com": {"auth": "ZG9ja2VyLXVzZXI6ZG9ja2VyLXBhc3N3b3Jk"}
+  }
+}`), 0o600))
+
+	kubeClient, err := kubernetes.NewMemoryClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "git",
+			Labels:    map[string]string{kargoapi.LabelKeyCredentialType: "git"},
+		},
+		Data: map[string][]byte{
+			libCreds.FieldRepoURL: []byte("https://git.example.com/example/repo"),
+			"username":            []byte("secret-user"),
+			"password":            []byte("secret-password"),
+		},
+	})
+	require.NoError(t, err)
+	db := NewDatabase(kubeClient)
+
+	testCases := []struct {
+		name     string
+		credType libCreds.Type
+		repoURL  string
+		expected *libCreds.Credentials
+	}{
+		{
+			name:     "Secret takes precedence",
+			credType: libCreds.TypeGit,
+			repoURL:  "https://git.example.com/example/repo",
+			expected: &libCreds.Credentials{Username: "secret-user", Password: "secret-password"},
+		},
+		{
+			name:     "Git credential helper",
+			credType: libCreds.TypeGit,
+			repoURL:  "https://git.example.com/example/other",
+			expected: &libCreds.Credentials{Username: "helper-user", Password: "helper-password"},
+		},
+		{
+			name:     "Git credential helper without credentials",
+			credType: libCreds.TypeGit,
+			repoURL:  "https://elsewhere.example.com/example/repo",
+		},
+		{
+			name:     "SSH URL",
+			credType: libCreds.TypeGit,
+			repoURL:  "git@git.example.com:example/repo.git",
+		},
+		{
+			name:     "Docker configuration for image",
+			credType: libCreds.TypeImage,
+			repoURL:  "registry.example.com/example/app",
+			expected: &libCreds.Credentials{Username: "docker-user", Password: "docker-password"},
+		},
+		{
+			name:     "Docker configuration for OCI chart",
+			credType: libCreds.TypeHelm,
+			repoURL:  "oci://registry.example.com/example/charts/app",
+			expected: &libCreds.Credentials{Username: "docker-user", Password: "docker-password"},
+		},
+		{
+			name:     "Docker configuration without credentials",
+			credType: libCreds.TypeImage,
+			repoURL:  "elsewhere.example.com/example/app",
+		},
+		{
+			name:     "classic chart repository",
+			credType: libCreds.TypeHelm,
+			repoURL:  "https://registry.example.com/charts",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			creds, err := db.Get(t.Context(), "fake-project", testCase.credType, testCase.repoURL)
+			require.NoError(t, err)
+			if testCase.expected == nil {
+				require.Nil(t, creds)
+				return
+			}
+			require.NotNil(t, creds)
+			require.Equal(t, testCase.expected.Username, creds.Username)
+			require.Equal(t, testCase.expected.Password, creds.Password)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// LoadCredentials loads the credential Secrets from the provided files,
+// placing them in the provided project.
+func LoadCredentials(project string, filenames ...string) ([]*corev1.Secret, error) {
+	var secrets []*corev1.Secret
+	err := ForEachDocument(filenames, func(filename string, doc []byte) error {
+		obj, err := Decode(doc)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", filename, err)
+		}
+		s, ok := obj.(*corev1.Secret)
+		if !ok {
+			return fmt.Errorf("expected only Secrets in %s, found %T", filename, obj)
+		}
+		if s.Labels[kargoapi.LabelKeyCredentialType] == "" {
+			return fmt.Errorf(
+				"Secret %q in %s is not labeled with %s",
+				s.Name, filename, kargoapi.LabelKeyCredentialType,
+			)
+		}
+		s.Namespace = project
+		// The API server would normally merge stringData into data.
+		for k, v := range s.StringData {
+			if s.Data == nil {
+				s.Data = map[string][]byte{}
+			}
+			s.Data[k] = []byte(v)
+		}
+		s.StringData = nil
+		secrets = append(secrets, s)
+		return nil
+	})
+	return secrets, err
+}
+
+// Decode decodes the provided YAML or JSON document into a typed Kubernetes
+// resource.
+func Decode(doc []byte) (runtime.Object, error) {
+	obj, _, err := serializer.NewCodecFactory(GetScheme()).
+		UniversalDeserializer().
+		Decode(doc, nil, nil)
+	return obj, err
+}
+
+// ForEachDocument calls the provided function for each non-empty YAML
+// document in the provided files.
+func ForEachDocument(
+	filenames []string,
+	fn func(filename string, doc []byte) error,
+) error {
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filename, err)
+		}
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+		for {
+			doc, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("read %s: %w", filename, err)
+			}
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+			if err = fn(filename, doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadCredentials(t *testing.T) {
+	secrets, err := LoadCredentials("fake-project", writeFile(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+  labels:
+    kargo.akuity.io/cred-type: git
+stringData:
+  repoURL: https://github.com/example/repo
+  username: user
+data:
+  password: cGFzc3dvcmQ=
+`))
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "fake-project", secrets[0].Namespace)
+	require.Nil(t, secrets[0].StringData)
+	require.Equal(t, map[string][]byte{
+		"repoURL":  []byte("https://github.com/example/repo"),
+		"username": []byte("user"),
+		"password": []byte("password"),
+	}, secrets[0].Data)
+
+	_, err = LoadCredentials("fake-project", writeFile(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+`))
+	require.ErrorContains(t, err, "is not labeled with")
+}
+
+func TestForEachDocument(t *testing.T) {
+	var docs []string
+	err := ForEachDocument(
+		[]string{writeFile(t, "---\nkind: A\n---\n\n---\nkind: B\n")},
+		func(_ string, doc []byte) error {
+			docs = append(docs, string(doc))
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, docs[0], "kind: A")
+	require.Contains(t, docs[1], "kind: B")
+
+	err = ForEachDocument([]string{filepath.Join(t.TempDir(), "missing.yaml")}, nil)
+	require.ErrorContains(t, err, "read")
+}
@@ -0,0 +1,236 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// errReadOnly is returned by a memoryClient for every attempted write.
+var errReadOnly = errors.New("resources cannot be written to when running locally")
+
+// memoryClient is a client.Client that serves a fixed set of resources from
+// memory. Only getting and listing resources is supported. All writes fail.
+type memoryClient struct {
+	scheme  *runtime.Scheme
+	objects map[schema.GroupVersionKind]map[client.ObjectKey]client.Object
+}
+
+// NewMemoryClient returns a read-only client.Client that serves the provided
+// resources, which must be of types known to the scheme returned by GetScheme,
+// from memory. It permits local commands to look up resources without
+// contacting a cluster.
+func NewMemoryClient(objs ...client.Object) (client.Client, error) {
+	c := &memoryClient{
+		scheme:  GetScheme(),
+		objects: map[schema.GroupVersionKind]map[client.ObjectKey]client.Object{},
+	}
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return nil, fmt.Errorf("determine kind of %T: %w", obj, err)
+		}
+		if c.objects[gvk] == nil {
+			c.objects[gvk] = map[client.ObjectKey]client.Object{}
+		}
+		stored := obj.DeepCopyObject().(client.Object) // nolint: forcetypeassert
+		c.objects[gvk][client.ObjectKeyFromObject(obj)] = stored
+	}
+	return c, nil
+}
+
+// Get implements client.Reader.
+func (c *memoryClient) Get(
+	_ context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	_ ...client.GetOption,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	stored, ok := c.objects[gvk][key]
+	if !ok {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		return apierrors.NewNotFound(gvr.GroupResource(), key.Name)
+	}
+	dst := reflect.ValueOf(obj)
+	src := reflect.ValueOf(stored.DeepCopyObject())
+	if dst.Type() != src.Type() {
+		return fmt.Errorf("cannot get %s into %T", gvk.Kind, obj)
+	}
+	dst.Elem().Set(src.Elem())
+	return nil
+}
+
+// List implements client.Reader. Field selectors are not supported.
+func (c *memoryClient) List(
+	_ context.Context,
+	list client.ObjectList,
+	opts ...client.ListOption,
+) error {
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		return fmt.Errorf("field selectors are not supported when running locally")
+	}
+
+	keys := make([]client.ObjectKey, 0, len(c.objects[gvk]))
+	for key := range c.objects[gvk] {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(lhs, rhs client.ObjectKey) int {
+		return strings.Compare(lhs.String(), rhs.String())
+	})
+
+	items := make([]runtime.Object, 0, len(keys))
+	for _, key := range keys {
+		obj := c.objects[gvk][key]
+		if listOpts.Namespace != "" && key.Namespace != listOpts.Namespace {
+			continue
+		}
+		if listOpts.LabelSelector != nil &&
+			!listOpts.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		items = append(items, obj.DeepCopyObject())
+	}
+	return meta.SetList(list, items)
+}
+
+// Apply implements client.Writer.
+func (c *memoryClient) Apply(
+	context.Context,
+	runtime.ApplyConfiguration,
+	...client.ApplyOption,
+) error {
+	return errReadOnly
+}
+
+// Create implements client.Writer.
+func (c *memoryClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errReadOnly
+}
+
+// Delete implements client.Writer.
+func (c *memoryClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return errReadOnly
+}
+
+// Update implements client.Writer.
+func (c *memoryClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errReadOnly
+}
+
+// Patch implements client.Writer.
+func (c *memoryClient) Patch(
+	context.Context,
+	client.Object,
+	client.Patch,
+	...client.PatchOption,
+) error {
+	return errReadOnly
+}
+
+// DeleteAllOf implements client.Writer.
+func (c *memoryClient) DeleteAllOf(
+	context.Context,
+	client.Object,
+	...client.DeleteAllOfOption,
+) error {
+	return errReadOnly
+}
+
+// Status implements client.StatusClient.
+func (c *memoryClient) Status() client.SubResourceWriter {
+	return memorySubResourceClient{}
+}
+
+// SubResource implements client.SubResourceClientConstructor.
+func (c *memoryClient) SubResource(string) client.SubResourceClient {
+	return memorySubResourceClient{}
+}
+
+// Scheme implements client.Client.
+func (c *memoryClient) Scheme() *runtime.Scheme {
+	return c.scheme
+}
+
+// RESTMapper implements client.Client.
+func (c *memoryClient) RESTMapper() meta.RESTMapper {
+	return meta.NewDefaultRESTMapper(nil)
+}
+
+// GroupVersionKindFor implements client.Client.
+func (c *memoryClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, c.scheme)
+}
+
+// IsObjectNamespaced implements client.Client.
+func (c *memoryClient) IsObjectNamespaced(runtime.Object) (bool, error) {
+	return false, errors.New("resource scopes are not known when running locally")
+}
+
+// memorySubResourceClient is a client.SubResourceClient for a memoryClient.
+// Resources are served without any subresources, so all reads and writes fail.
+type memorySubResourceClient struct{}
+
+func (memorySubResourceClient) Get(
+	context.Context,
+	client.Object,
+	client.Object,
+	...client.SubResourceGetOption,
+) error {
+	return errors.New("subresources are not available when running locally")
+}
+
+func (memorySubResourceClient) Create(
+	context.Context,
+	client.Object,
+	client.Object,
+	...client.SubResourceCreateOption,
+) error {
+	return errReadOnly
+}
+
+func (memorySubResourceClient) Update(
+	context.Context,
+	client.Object,
+	...client.SubResourceUpdateOption,
+) error {
+	return errReadOnly
+}
+
+func (memorySubResourceClient) Patch(
+	context.Context,
+	client.Object,
+	client.Patch,
+	...client.SubResourcePatchOption,
+) error {
+	return errReadOnly
+}
+
+func (memorySubResourceClient) Apply(
+	context.Context,
+	runtime.ApplyConfiguration,
+	...client.SubResourceApplyOption,
+) error {
+	return errReadOnly
+}
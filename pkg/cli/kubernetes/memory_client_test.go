@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestNewMemoryClient(t *testing.T) {
+	c, err := NewMemoryClient(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "git",
+				Labels:    map[string]string{kargoapi.LabelKeyCredentialType: "git"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "image",
+				Labels:    map[string]string{kargoapi.LabelKeyCredentialType: "image"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "other-project",
+				Name:      "git",
+				Labels:    map[string]string{kargoapi.LabelKeyCredentialType: "git"},
+			},
+		},
+		&kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fake-project", Name: "fake-freight"},
+			Alias:      "fake-alias",
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		freight := &kargoapi.Freight{}
+		require.NoError(t, c.Get(
+			t.Context(),
+			client.ObjectKey{Namespace: "fake-project", Name: "fake-freight"},
+			freight,
+		))
+		require.Equal(t, "fake-alias", freight.Alias)
+
+		// Changes to what was returned are not retained
+		freight.Alias = "changed"
+		freight = &kargoapi.Freight{}
+		require.NoError(t, c.Get(
+			t.Context(),
+			client.ObjectKey{Namespace: "fake-project", Name: "fake-freight"},
+			freight,
+		))
+		require.Equal(t, "fake-alias", freight.Alias)
+
+		err := c.Get(
+			t.Context(),
+			client.ObjectKey{Namespace: "fake-project", Name: "missing"},
+			&kargoapi.Freight{},
+		)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		secrets := &corev1.SecretList{}
+		require.NoError(t, c.List(
+			t.Context(),
+			secrets,
+			client.InNamespace("fake-project"),
+			client.MatchingLabels{kargoapi.LabelKeyCredentialType: "git"},
+		))
+		require.Len(t, secrets.Items, 1)
+		require.Equal(t, "fake-project", secrets.Items[0].Namespace)
+		require.Equal(t, "git", secrets.Items[0].Name)
+
+		require.NoError(t, c.List(t.Context(), secrets))
+		require.Len(t, secrets.Items, 3)
+
+		require.ErrorContains(
+			t,
+			c.List(t.Context(), secrets, client.MatchingFields{"currentlyIn": "fake-stage"}),
+			"field selectors are not supported",
+		)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		freight := &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fake-project", Name: "new-freight"},
+		}
+		require.ErrorIs(t, c.Create(t.Context(), freight), errReadOnly)
+		require.ErrorIs(t, c.Update(t.Context(), freight), errReadOnly)
+		require.ErrorIs(t, c.Delete(t.Context(), freight), errReadOnly)
+		require.ErrorIs(t, c.Status().Update(t.Context(), freight), errReadOnly)
+	})
+}
@@ -1,6 +1,8 @@
 package option
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 
 	"github.com/akuity/kargo/pkg/credentials"
@@ -18,6 +20,12 @@ const (
 	// AliasShortFlag is the short flag name for the alias flag.
 	AliasShortFlag = "a"
 
+	// AllowSideEffectsFlag is the flag name for the allow-side-effects flag.
+	AllowSideEffectsFlag = "allow-side-effects"
+
+	// AllowStepFlag is the flag name for the allow-step flag.
+	AllowStepFlag = "allow-step"
+
 	// AsKubernetesResourcesFlag is the flag name for the as-kubernetes-resources
 	// flag.
 	AsKubernetesResourcesFlag = "as-kubernetes-resources"
@@ -25,32 +33,58 @@ const (
 	// as-kubernetes-resources flag.
 	AsKubernetesResourcesShortFlag = "k"
 
+	// ChartVersionFlag is the flag name for the chart-version flag.
+	ChartVersionFlag = "chart-version"
+
 	// ClaimFlag is a flag name for the claim flag.
 	ClaimFlag = "claim"
 
+	// CommitFlag is the flag name for the commit flag.
+	CommitFlag = "commit"
+
 	// ContainerFlag is the flag name for the container flag.
 	ContainerFlag = "container"
 
+	// CredentialsFlag is the flag name for the credentials flag.
+	CredentialsFlag = "credentials"
+
 	// DescriptionFlag is the flag name for the description flag.
 	DescriptionFlag = "description"
 
+	// DigestFlag is the flag name for the digest flag.
+	DigestFlag = "digest"
+
 	// DownstreamFromFlag is the flag name for the downstream-from flag.
 	DownstreamFromFlag = "downstream-from"
 
+	// DryRunFlag is the flag name for the dry-run flag.
+	DryRunFlag = "dry-run"
+
 	// FilenameFlag is the flag name for the filename flag.
 	FilenameFlag = "filename"
 	// FilenameShortFlag is the short flag name for the filename flag.
 	FilenameShortFlag = "f"
 
+	// FollowFlag is the flag name for the follow flag.
+	FollowFlag = "follow"
+	// FollowShortFlag is the short flag name for the follow flag.
+	FollowShortFlag = "f"
+
 	// FreightFlag is the flag name for the freight flag.
 	FreightFlag = "freight"
 
 	// FreightAliasFlag is the flag name for the freight-alias flag.
 	FreightAliasFlag = "freight-alias"
 
+	// FromFlag is the flag name for the from flag.
+	FromFlag = "from"
+
 	// GitFlag is the flag name for the git flag.
 	GitFlag = string(credentials.TypeGit)
 
+	// HealthFlag is the flag name for the health flag.
+	HealthFlag = "health"
+
 	// HelmFlag is the flag name for the helm flag.
 	HelmFlag = string(credentials.TypeHelm)
 
@@ -84,6 +118,11 @@ const (
 	// OriginFlag is the flag name for the origin flag.
 	OriginFlag = "origin"
 
+	// OutputFlag is the flag name for the output flag.
+	OutputFlag = "output"
+	// OutputShortFlag is the short flag name for the output flag.
+	OutputShortFlag = "o"
+
 	// PasswordFlag is the flag name for the password flag.
 	PasswordFlag = "password"
 
@@ -100,6 +139,9 @@ const (
 	// RegexFlag is the flag name for the regex flag.
 	RegexFlag = "regex"
 
+	// RemoteFlag is the flag name for the remote flag.
+	RemoteFlag = "remote"
+
 	// RepoURLFlag is the flag name for the repo-url flag.
 	RepoURLFlag = "repo-url"
 
@@ -112,21 +154,33 @@ const (
 	// RoleFlag is the flag name for the role flag.
 	RoleFlag = "role"
 
+	// ScopeFlag is the flag name for the scope flag.
+	ScopeFlag = "scope"
+
 	// SharedFlag is the flag name for the shared flag.
 	SharedFlag = "shared"
 
 	// StageFlag is the flag name for the stage flag.
 	StageFlag = "stage"
 
+	// StepFlag is the flag name for the step flag.
+	StepFlag = "step"
+
 	// SystemFlag is the flag name for the system flag.
 	SystemFlag = "system"
 
+	// TTLFlag is the flag name for the ttl flag.
+	TTLFlag = "ttl"
+
 	// TypeFlag is the flag name for the type flag.
 	TypeFlag = "type"
 
 	// UsernameFlag is the flag name for the username flag.
 	UsernameFlag = "username"
 
+	// VarFlag is the flag name for the var flag.
+	VarFlag = "var"
+
 	// VerbFlag is the flag name for the verb flag.
 	VerbFlag = "verb"
 
@@ -135,6 +189,9 @@ const (
 
 	// WaitFlag is the flag name for the wait flag.
 	WaitFlag = "wait"
+
+	// WorkDirFlag is the flag name for the work-dir flag.
+	WorkDirFlag = "work-dir"
 )
 
 // Abort adds the AbortFlag to the provided flag set.
@@ -157,6 +214,16 @@ func Aliases(fs *pflag.FlagSet, stage *[]string, usage string) {
 	fs.StringArrayVar(stage, AliasFlag, nil, usage)
 }
 
+// AllowSideEffects adds the AllowSideEffectsFlag to the provided flag set.
+func AllowSideEffects(fs *pflag.FlagSet, allow *bool, usage string) {
+	fs.BoolVar(allow, AllowSideEffectsFlag, false, usage)
+}
+
+// AllowSteps adds the AllowStepFlag to the provided flag set.
+func AllowSteps(fs *pflag.FlagSet, kinds *[]string, usage string) {
+	fs.StringArrayVar(kinds, AllowStepFlag, nil, usage)
+}
+
 // AsKubernetesResources adds the AsKubernetesResourcesFlag and
 // AsKubernetesResourcesShortFlag to the provided flag set.
 func AsKubernetesResources(fs *pflag.FlagSet, asKubernetesResources *bool, usage string) {
@@ -169,45 +236,91 @@ func AsKubernetesResources(fs *pflag.FlagSet, asKubernetesResources *bool, usage
 	)
 }
 
+// ChartVersion adds the ChartVersionFlag to the provided flag set.
+func ChartVersion(fs *pflag.FlagSet, chartVersion *string, usage string) {
+	fs.StringVar(chartVersion, ChartVersionFlag, "", usage)
+}
+
 // Claims adds a multi-value ClaimFlag to the provided flag set.
 func Claims(fs *pflag.FlagSet, claims *[]string, usage string) {
 	fs.StringSliceVar(claims, ClaimFlag, nil, usage)
 }
 
+// Commit adds the CommitFlag to the provided flag set.
+func Commit(fs *pflag.FlagSet, commit *string, usage string) {
+	fs.StringVar(commit, CommitFlag, "", usage)
+}
+
 func Container(fs *pflag.FlagSet, container *string, usage string) {
 	fs.StringVar(container, ContainerFlag, "", usage)
 }
 
+// Credentials adds the CredentialsFlag to the provided flag set.
+func Credentials(fs *pflag.FlagSet, filenames *[]string, usage string) {
+	fs.StringArrayVar(filenames, CredentialsFlag, nil, usage)
+}
+
 // Description adds the DescriptionFlag to the provided flag set.
 func Description(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, DescriptionFlag, "", usage)
 }
 
+// Digest adds the DigestFlag to the provided flag set.
+func Digest(fs *pflag.FlagSet, digest *string, usage string) {
+	fs.StringVar(digest, DigestFlag, "", usage)
+}
+
 // DownstreamFrom adds the DownstreamFromFlag to the provided flag set.
 func DownstreamFrom(fs *pflag.FlagSet, downstreamFrom *string, usage string) {
 	fs.StringVar(downstreamFrom, DownstreamFromFlag, "", usage)
 }
 
+// DryRun adds the DryRunFlag to the provided flag set.
+func DryRun(fs *pflag.FlagSet, dryRun *bool, usage string) {
+	fs.BoolVar(dryRun, DryRunFlag, false, usage)
+}
+
 // Filenames adds the FilenameFlag and FilenameShortFlag to the provided flag set.
 func Filenames(fs *pflag.FlagSet, filenames *[]string, usage string) {
 	fs.StringSliceVarP(filenames, FilenameFlag, FilenameShortFlag, nil, usage)
 }
 
+// Follow adds the FollowFlag to the provided flag set.
+func Follow(fs *pflag.FlagSet, follow *bool, usage string) {
+	fs.BoolVarP(follow, FollowFlag, FollowShortFlag, false, usage)
+}
+
 // Freight adds the FreightFlag to the provided flag set.
 func Freight(fs *pflag.FlagSet, freight *string, usage string) {
 	fs.StringVar(freight, FreightFlag, "", usage)
 }
 
+// FreightFiles adds the FreightFlag to the provided flag set, for use by
+// commands that read Freight from files instead of referencing it by name.
+func FreightFiles(fs *pflag.FlagSet, filenames *[]string, usage string) {
+	fs.StringArrayVar(filenames, FreightFlag, nil, usage)
+}
+
 // FreightAlias adds the FreightAliasFlag to the provided flag set.
 func FreightAlias(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, FreightAliasFlag, "", usage)
 }
 
+// From adds the FromFlag to the provided flag set.
+func From(fs *pflag.FlagSet, from *string, usage string) {
+	fs.StringVar(from, FromFlag, "", usage)
+}
+
 // Git adds the GitFlag to the provided flag set.
 func Git(fs *pflag.FlagSet, git *bool, usage string) {
 	fs.BoolVar(git, GitFlag, false, usage)
 }
 
+// Health adds the HealthFlag to the provided flag set.
+func Health(fs *pflag.FlagSet, health *bool, usage string) {
+	fs.BoolVar(health, HealthFlag, false, usage)
+}
+
 // Helm adds the HelmFlag to the provided flag set.
 func Helm(fs *pflag.FlagSet, helm *bool, usage string) {
 	fs.BoolVar(helm, HelmFlag, false, usage)
@@ -273,6 +386,11 @@ func Origins(fs *pflag.FlagSet, origin *[]string, usage string) {
 	fs.StringArrayVar(origin, OriginFlag, nil, usage)
 }
 
+// Output adds the OutputFlag and OutputShortFlag to the provided flag set.
+func Output(fs *pflag.FlagSet, output *string, defaultOutput, usage string) {
+	fs.StringVarP(output, OutputFlag, OutputShortFlag, defaultOutput, usage)
+}
+
 // Warehouse adds the WarehouseFlag to the provided flag set.
 func Warehouse(fs *pflag.FlagSet, warehouse *string, usage string) {
 	fs.StringVar(warehouse, WarehouseFlag, "", usage)
@@ -302,6 +420,11 @@ func Recursive(fs *pflag.FlagSet, recursive *bool) {
 	)
 }
 
+// Remote adds the RemoteFlag to the provided flag set.
+func Remote(fs *pflag.FlagSet, remote *bool, usage string) {
+	fs.BoolVar(remote, RemoteFlag, false, usage)
+}
+
 // RepoURL adds the RepoURLFlag to the provided flag set.
 func RepoURL(fs *pflag.FlagSet, repoURL *string, usage string) {
 	fs.StringVar(repoURL, RepoURLFlag, "", usage)
@@ -327,6 +450,11 @@ func Role(fs *pflag.FlagSet, role *string, usage string) {
 	fs.StringVar(role, RoleFlag, "", usage)
 }
 
+// Scopes adds the ScopeFlag to the provided flag set.
+func Scopes(fs *pflag.FlagSet, scopes *[]string, usage string) {
+	fs.StringArrayVar(scopes, ScopeFlag, nil, usage)
+}
+
 // Shared adds the SharedFlag to the provided flag set.
 func Shared(fs *pflag.FlagSet, shared *bool, defaultShared bool, usage string) {
 	fs.BoolVar(shared, SharedFlag, defaultShared, usage)
@@ -337,6 +465,11 @@ func Stage(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, StageFlag, "", usage)
 }
 
+// Step adds the StepFlag to the provided flag set.
+func Step(fs *pflag.FlagSet, step *string, usage string) {
+	fs.StringVar(step, StepFlag, "", usage)
+}
+
 // System adds the SystemFlag to the provided flag set.
 
 // Wait adds the WaitFlag to the provided flag set.
@@ -344,6 +477,11 @@ func System(fs *pflag.FlagSet, system *bool, defaultSystem bool, usage string) {
 	fs.BoolVar(system, SystemFlag, defaultSystem, usage)
 }
 
+// TTL adds the TTLFlag to the provided flag set.
+func TTL(fs *pflag.FlagSet, ttl *time.Duration, usage string) {
+	fs.DurationVar(ttl, TTLFlag, 0, usage)
+}
+
 // Type adds the TypeFlag to the provided flag set.
 func Type(fs *pflag.FlagSet, repoType *string, usage string) {
 	fs.StringVar(repoType, TypeFlag, "", usage)
@@ -354,6 +492,11 @@ func Username(fs *pflag.FlagSet, username *string, usage string) {
 	fs.StringVar(username, UsernameFlag, "", usage)
 }
 
+// Vars adds the VarFlag to the provided flag set.
+func Vars(fs *pflag.FlagSet, vars *[]string, usage string) {
+	fs.StringArrayVar(vars, VarFlag, nil, usage)
+}
+
 // Verbs adds a multi-value VerbFlag to the provided flag set.
 func Verbs(fs *pflag.FlagSet, verbs *[]string, usage string) {
 	fs.StringSliceVar(verbs, VerbFlag, nil, usage)
@@ -363,3 +506,8 @@ func Verbs(fs *pflag.FlagSet, verbs *[]string, usage string) {
 func Wait(fs *pflag.FlagSet, wait *bool, defaultWait bool, usage string) {
 	fs.BoolVar(wait, WaitFlag, defaultWait, usage)
 }
+
+// WorkDir adds the WorkDirFlag to the provided flag set.
+func WorkDir(fs *pflag.FlagSet, workDir *string, usage string) {
+	fs.StringVar(workDir, WorkDirFlag, "", usage)
+}
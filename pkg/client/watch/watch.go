@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 
@@ -328,6 +330,73 @@ func (c *Client) StreamAnalysisRunLogs(
 	return streamLogs(ctx, c, url)
 }
 
+// PromotionStepLog represents output captured from a single execution of a
+// single step of a Promotion.
+type PromotionStepLog struct {
+	StepIndex  int64     `json:"stepIndex"`
+	Alias      string    `json:"alias"`
+	Log        string    `json:"log"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
+
+// StreamPromotionLogs streams output captured from the steps of a Promotion.
+// If step is non-empty, only output from the step with that alias is streamed.
+// If follow is true, streaming continues until the Promotion completes.
+// Otherwise, it stops once all output captured so far has been streamed.
+func (c *Client) StreamPromotionLogs(
+	ctx context.Context,
+	project string,
+	promotion string,
+	step string,
+	follow bool,
+) (<-chan PromotionStepLog, <-chan error) {
+	reqURL := fmt.Sprintf("%s/v1beta1/projects/%s/promotions/%s/logs", c.baseURL, project, promotion)
+
+	// Add query parameters if provided
+	params := url.Values{}
+	if step != "" {
+		params.Set("step", step)
+	}
+	if follow {
+		params.Set("follow", "true")
+	}
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	logCh := make(chan PromotionStepLog)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(logCh)
+		defer close(errCh)
+
+		// Make sure the underlying stream is torn down if we stop reading early.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		dataCh, dataErrCh := streamLogs(ctx, c, reqURL)
+		for data := range dataCh {
+			var log PromotionStepLog
+			if err := json.Unmarshal([]byte(data), &log); err != nil {
+				errCh <- fmt.Errorf("unmarshaling step log: %w", err)
+				return
+			}
+			select {
+			case logCh <- log:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := <-dataErrCh; err != nil {
+			errCh <- err
+		}
+	}()
+
+	return logCh, errCh
+}
+
 // streamLogs streams log data from an SSE endpoint.
 func streamLogs(
 	ctx context.Context,
@@ -671,6 +671,96 @@ func TestStreamAnalysisRunLogs_Error(t *testing.T) {
 	}
 }
 
+func TestStreamPromotionLogs(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          string
+		follow        bool
+		expectedQuery string
+	}{
+		{
+			name:          "no query params",
+			expectedQuery: "",
+		},
+		{
+			name:          "with step",
+			step:          "render",
+			expectedQuery: "step=render",
+		},
+		{
+			name:          "with step and follow",
+			step:          "render",
+			follow:        true,
+			expectedQuery: "follow=true&step=render",
+		},
+		{
+			name:          "with step requiring escaping",
+			step:          "task::render&diff",
+			expectedQuery: "step=task%3A%3Arender%26diff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1beta1/projects/test-project/promotions/test-promo/logs", r.URL.Path)
+				assert.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+				assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, ": keepalive\n\n")
+				fmt.Fprint(w, `data: {"stepIndex":1,"alias":"render","log":"line 1\nline 2\n","truncated":true}`+"\n\n")
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, server.Client(), "test-token")
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			logCh, errCh := client.StreamPromotionLogs(ctx, "test-project", "test-promo", tt.step, tt.follow)
+
+			logs := make([]PromotionStepLog, 0)
+			for log := range logCh {
+				logs = append(logs, log)
+			}
+			require.NoError(t, <-errCh)
+
+			require.Len(t, logs, 1)
+			assert.Equal(t, int64(1), logs[0].StepIndex)
+			assert.Equal(t, "render", logs[0].Alias)
+			assert.Equal(t, "line 1\nline 2\n", logs[0].Log)
+			assert.True(t, logs[0].Truncated)
+		})
+	}
+}
+
+func TestStreamPromotionLogs_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "promotion not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.Client(), "test-token")
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	logCh, errCh := client.StreamPromotionLogs(ctx, "test-project", "test-promo", "", false)
+
+	// Drain log channel
+	for range logCh {
+	}
+
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status 404")
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for error")
+	}
+}
+
 func TestReadLogStream(t *testing.T) {
 	tests := []struct {
 		name         string
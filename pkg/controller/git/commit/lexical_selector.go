@@ -9,6 +9,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/controller/git"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -64,11 +65,15 @@ func (l *lexicalSelector) Select(ctx context.Context) (
 		return nil, err
 	}
 
-	tags = l.filterTags(tags)
+	filteredTags := l.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, l.tagRejectionReason)
+	tags = filteredTags
 
-	if tags, err = l.filterTagsByExpression(tags); err != nil {
+	if filteredTags, err = l.filterTagsByExpression(tags); err != nil {
 		return nil, fmt.Errorf("error filtering tags by expression: %w", err)
 	}
+	explain.Filtered(ctx, tags, filteredTags, tagName, expressionRejectionReason)
+	tags = filteredTags
 
 	// Sort in reverse lexicographic order.
 	slices.SortFunc(tags, func(i, j git.TagMetadata) int {
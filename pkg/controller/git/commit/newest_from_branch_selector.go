@@ -13,6 +13,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/controller/git"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 const (
@@ -204,6 +205,7 @@ func (n *newestFromBranchSelector) selectCommits(
 				return nil, fmt.Errorf("error evaluating filter expression: %w", err)
 			}
 			if !include {
+				explain.Reject(ctx, commit.ID, "commit does not satisfy expressionFilter")
 				continue
 			}
 
@@ -219,6 +221,11 @@ func (n *newestFromBranchSelector) selectCommits(
 					)
 				}
 				if !n.MatchesPaths(diffPaths) {
+					explain.Reject(
+						ctx,
+						commit.ID,
+						"no path changed by the commit satisfies includePaths/excludePaths",
+					)
 					continue
 				}
 			}
@@ -7,6 +7,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/controller/git"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -62,11 +63,15 @@ func (n *newestTagSelector) Select(ctx context.Context) (
 		return nil, err
 	}
 
-	tags = n.filterTags(tags)
+	filteredTags := n.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, n.tagRejectionReason)
+	tags = filteredTags
 
-	if tags, err = n.filterTagsByExpression(tags); err != nil {
+	if filteredTags, err = n.filterTagsByExpression(tags); err != nil {
 		return nil, fmt.Errorf("error filtering tags by expression: %w", err)
 	}
+	explain.Filtered(ctx, tags, filteredTags, tagName, expressionRejectionReason)
+	tags = filteredTags
 
 	// Note: Tags are already sorted in descending order by creation date when
 	// retrieved. No further sorting is required.
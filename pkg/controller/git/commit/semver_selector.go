@@ -12,6 +12,7 @@ import (
 	"github.com/akuity/kargo/pkg/controller/git"
 	libSemver "github.com/akuity/kargo/pkg/controller/semver"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -87,6 +88,23 @@ func (s *semverSelector) matchesTag(tag string) bool {
 	return s.constraint == nil || s.constraint.Check(sv)
 }
 
+// tagRejectionReason returns a human-readable explanation of why the given tag
+// does not satisfy the constraints defined by the s.matchesTag method. It is
+// only meaningful for tags that s.matchesTag has already rejected.
+func (s *semverSelector) tagRejectionReason(tag git.TagMetadata) string {
+	name := strings.TrimPrefix(tag.Tag, tagPrefix)
+	if !s.tagBasedSelector.matchesTag(name) {
+		return s.tagBasedSelector.tagRejectionReason(tag)
+	}
+	if libSemver.Parse(name, s.strictSemvers) == nil {
+		if s.strictSemvers {
+			return "tag is not a strict semantic version"
+		}
+		return "tag is not a semantic version"
+	}
+	return fmt.Sprintf("tag does not satisfy semverConstraint %q", s.constraint.String())
+}
+
 // ListRefs implements Selector. Note: This uses this type's own matchesTag()
 // implementation, which imposes semver-aware criteria beyond tagBasedSelector's.
 func (s *semverSelector) ListRefs(
@@ -122,11 +140,15 @@ func (s *semverSelector) Select(ctx context.Context) (
 
 	// Note: This is calling this type's own implementation of filterTags() and
 	// NOT directly calling tagBasedSelector's implementation.
-	tags = s.filterTags(tags)
+	filteredTags := s.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, s.tagRejectionReason)
+	tags = filteredTags
 
-	if tags, err = s.filterTagsByExpression(tags); err != nil {
+	if filteredTags, err = s.filterTagsByExpression(tags); err != nil {
 		return nil, fmt.Errorf("error filtering tags by expression: %w", err)
 	}
+	explain.Filtered(ctx, tags, filteredTags, tagName, expressionRejectionReason)
+	tags = filteredTags
 
 	s.sort(tags)
 
@@ -567,3 +567,29 @@ func Test_semverSelector_Select(t *testing.T) {
 		})
 	}
 }
+
+func Test_semverSelector_tagRejectionReason(t *testing.T) {
+	constraint, err := semver.NewConstraint("^1.0.0")
+	require.NoError(t, err)
+	s := &semverSelector{
+		tagBasedSelector: &tagBasedSelector{
+			allowTagsRegexes: []*regexp.Regexp{regexp.MustCompile(`^v`)},
+		},
+		constraint: constraint,
+	}
+	require.Equal(
+		t,
+		"tag does not match any allowTagsRegexes entry",
+		s.tagRejectionReason(git.TagMetadata{Tag: "1.0.0"}),
+	)
+	require.Equal(
+		t,
+		"tag is not a semantic version",
+		s.tagRejectionReason(git.TagMetadata{Tag: "refs/tags/vfoo"}),
+	)
+	require.Equal(
+		t,
+		`tag does not satisfy semverConstraint "^1.0.0"`,
+		s.tagRejectionReason(git.TagMetadata{Tag: "v2.0.0"}),
+	)
+}
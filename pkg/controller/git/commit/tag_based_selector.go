@@ -16,6 +16,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/controller/git"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 const tagPrefix = "refs/tags/"
@@ -187,6 +188,31 @@ func (t *tagBasedSelector) matchesTag(tag string) bool {
 	return false
 }
 
+// tagRejectionReason returns a human-readable explanation of why the given tag
+// does not satisfy the constraints defined by the t.matchesTag method. It is
+// only meaningful for tags that t.matchesTag has already rejected.
+func (t *tagBasedSelector) tagRejectionReason(tag git.TagMetadata) string {
+	name := strings.TrimPrefix(tag.Tag, tagPrefix)
+	for _, regex := range t.ignoreTagsRegexes {
+		if regex.MatchString(name) {
+			return fmt.Sprintf("tag matches ignoreTagsRegexes entry %q", regex.String())
+		}
+	}
+	return "tag does not match any allowTagsRegexes entry"
+}
+
+// tagName returns the name of the given tag. It is used for identifying tags
+// when explaining why they were filtered out.
+func tagName(tag git.TagMetadata) string {
+	return tag.Tag
+}
+
+// expressionRejectionReason returns a human-readable explanation of why the
+// given tag was filtered out by a user-defined expression.
+func expressionRejectionReason(git.TagMetadata) string {
+	return "tag does not satisfy expressionFilter"
+}
+
 // clone clones a Git repository specified by the selector's repoURL field using
 // options suitable for selectors that selects commits on the basis of tag names
 // or metadata.
@@ -295,13 +321,20 @@ func (t *tagBasedSelector) filterTagsByDiffPaths(
 				err,
 			)
 		}
-		if t.MatchesPaths(diffPaths) {
-			filteredTags = append(filteredTags, tag)
+		if !t.MatchesPaths(diffPaths) {
+			explain.Reject(
+				ctx,
+				tag.Tag,
+				"no path changed by the tagged commit satisfies includePaths/excludePaths",
+			)
+			continue
 		}
+		filteredTags = append(filteredTags, tag)
 		if len(filteredTags) >= t.discoveryLimit {
 			break
 		}
 	}
+	explain.Filtered(ctx, tags, filteredTags, tagName, t.limitRejectionReason)
 	return filteredTags, nil
 }
 
@@ -315,7 +348,9 @@ func (t *tagBasedSelector) tagsToAPICommits(
 	tags []git.TagMetadata,
 ) []kargoapi.DiscoveredCommit {
 	logger := logging.LoggerFromContext(ctx)
-	tags = trimSlice(tags, t.discoveryLimit)
+	trimmedTags := trimSlice(tags, t.discoveryLimit)
+	explain.Filtered(ctx, tags, trimmedTags, tagName, t.limitRejectionReason)
+	tags = trimmedTags
 	commits := make([]kargoapi.DiscoveredCommit, len(tags))
 	for i, tag := range tags {
 		commits[i] = kargoapi.DiscoveredCommit{
@@ -340,3 +375,9 @@ func (t *tagBasedSelector) tagsToAPICommits(
 	logger.Debug("discovered commits", "count", len(commits))
 	return commits
 }
+
+// limitRejectionReason returns a human-readable explanation of why the given tag
+// was excluded after the selector's discovery limit was reached.
+func (t *tagBasedSelector) limitRejectionReason(git.TagMetadata) string {
+	return fmt.Sprintf("discovery limit of %d reached", t.discoveryLimit)
+}
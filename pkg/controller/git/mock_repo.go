@@ -28,6 +28,7 @@ type MockRepo struct {
 	) error
 	CurrentBranchFn           func(ctx context.Context) (string, error)
 	DeleteBranchFn            func(ctx context.Context, branch string) error
+	DiffFromFn                func(ctx context.Context, commitID string) (string, error)
 	DirFn                     func() string
 	FetchFn                   func(ctx context.Context, opts *FetchOptions) error
 	HasDiffsFn                func(ctx context.Context) (bool, error)
@@ -130,6 +131,10 @@ func (m *MockRepo) DeleteBranch(ctx context.Context, branch string) error {
 	return m.DeleteBranchFn(ctx, branch)
 }
 
+func (m *MockRepo) DiffFrom(ctx context.Context, commitID string) (string, error) {
+	return m.DiffFromFn(ctx, commitID)
+}
+
 func (m *MockRepo) Dir() string {
 	return m.DirFn()
 }
@@ -52,6 +52,15 @@ type CloneOptions struct {
 	// large repositories. The server must support partial clones; if it does
 	// not, the clone will fail.
 	Blobless bool
+	// Treeless enables treeless cloning (--filter=tree:0). When set, the
+	// initial clone downloads all commits but defers tree and blob downloads
+	// until they are needed. This is suited to inspecting commit history only
+	// and takes precedence over Blobless. The server must support partial
+	// clones; if it does not, the clone will fail.
+	Treeless bool
+	// NoCheckout indicates that no working tree should be checked out after
+	// cloning.
+	NoCheckout bool
 	// SingleBranch indicates whether the clone should be a single-branch clone.
 	// This option is ignored if Bare is true.
 	SingleBranch bool
@@ -117,9 +126,15 @@ func (r *repo) clone(ctx context.Context, opts *CloneOptions) error {
 	if opts.Depth > 0 {
 		args = append(args, "--depth", fmt.Sprint(opts.Depth))
 	}
-	if opts.Blobless {
+	switch {
+	case opts.Treeless:
+		args = append(args, "--filter", "tree:0")
+	case opts.Blobless:
 		args = append(args, "--filter", "blob:none")
 	}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
 	args = append(args, r.accessURL, r.dir)
 	cmd := r.buildGitCommand(ctx, args...)
 	cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
@@ -52,6 +52,11 @@ type WorkTree interface {
 	CurrentBranch(ctx context.Context) (string, error)
 	// DeleteBranch deletes the specified branch
 	DeleteBranch(ctx context.Context, branch string) error
+	// DiffFrom returns a unified diff between the commit with the specified ID
+	// and the current contents of the working tree, including changes that have
+	// not been committed and files that are not yet tracked. If the commit ID is
+	// empty, the diff is taken from an empty tree.
+	DiffFrom(ctx context.Context, commitID string) (string, error)
 	// Dir returns an absolute path to the working tree.
 	Dir() string
 	// Fetch fetches updates from the remote repository.
@@ -136,6 +141,13 @@ type ListCommitsOptions struct {
 	Skip uint
 	// Since limits commits to those at or after this time.
 	Since *time.Time
+	// Ref is the commit, branch, or tag whose history is listed. If not
+	// specified, the history of the current branch is listed.
+	Ref string
+	// ExcludeRef, if specified, excludes commits reachable from this commit,
+	// branch, or tag from the results. Combined with Ref, this lists the
+	// commits in the range ExcludeRef..Ref.
+	ExcludeRef string
 }
 
 // workTree is an implementation of the WorkTree interface for interacting with
@@ -418,6 +430,34 @@ type FetchOptions struct {
 	Depth uint
 }
 
+func (w *workTree) DiffFrom(ctx context.Context, commitID string) (string, error) {
+	if commitID == "" {
+		// The ID of the empty tree depends on the repository's object format, so
+		// ask Git for it rather than hard-coding it.
+		res, err := libExec.Exec(w.buildGitCommand(
+			ctx, "hash-object", "-t", "tree", os.DevNull,
+		))
+		if err != nil {
+			return "", fmt.Errorf("error obtaining ID of empty tree: %w", err)
+		}
+		commitID = strings.TrimSpace(string(res))
+	}
+	// Record untracked files in the index, without their contents, so that they
+	// are included in the diff.
+	if _, err := libExec.Exec(w.buildGitCommand(
+		ctx, "add", "--all", "--intent-to-add",
+	)); err != nil {
+		return "", fmt.Errorf("error recording untracked files: %w", err)
+	}
+	res, err := libExec.Exec(w.buildGitCommand(
+		ctx, "diff", "--no-color", "--no-ext-diff", commitID,
+	))
+	if err != nil {
+		return "", fmt.Errorf("error diffing working tree from %q: %w", commitID, err)
+	}
+	return string(res), nil
+}
+
 func (w *workTree) Fetch(ctx context.Context, opts *FetchOptions) error {
 	if opts == nil {
 		opts = &FetchOptions{}
@@ -583,6 +623,16 @@ func (w *workTree) ListCommits(
 	if opts.Since != nil {
 		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
 	}
+	switch {
+	case opts.ExcludeRef != "":
+		ref := opts.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+		args = append(args, fmt.Sprintf("%s..%s", opts.ExcludeRef, ref))
+	case opts.Ref != "":
+		args = append(args, opts.Ref)
+	}
 
 	b, err := libExec.Exec(w.buildGitCommand(ctx, args...))
 	if err != nil {
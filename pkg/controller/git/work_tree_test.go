@@ -328,6 +328,29 @@ func TestListCommits(t *testing.T) {
 		},
 		subjects,
 	)
+
+	// Listing a range of commits should exclude those reachable from the
+	// start of the range.
+	commits, err = rep.ListCommits(
+		t.Context(),
+		&ListCommitsOptions{
+			Ref:        commits[0].ID,
+			ExcludeRef: commits[2].ID,
+		},
+	)
+	require.NoError(t, err)
+	subjects = make([]string, len(commits))
+	for i, c := range commits {
+		subjects[i] = c.Subject
+	}
+	require.Equal(
+		t,
+		[]string{
+			"main: merge feature",
+			"main: second commit",
+		},
+		subjects,
+	)
 }
 
 func TestGetDiffPathsForMergeCommit(t *testing.T) {
@@ -519,3 +542,53 @@ func TestGetDiffPathsForCommitWithManyModifiedRenames(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, paths, fileCount*2)
 }
+
+func TestDiffFrom(t *testing.T) {
+	testServer, testRepoURL, testRepoCreds := setupRemoteRepo(t)
+	defer testServer.Close()
+
+	rep, err := Clone(
+		t.Context(),
+		testRepoURL,
+		&ClientOptions{Credentials: &testRepoCreds},
+		nil,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, rep)
+	defer rep.Close(t.Context())
+
+	require.NoError(t, os.WriteFile(
+		fmt.Sprintf("%s/committed.txt", rep.Dir()),
+		[]byte("before\n"),
+		0o600,
+	))
+	require.NoError(t, rep.AddAllAndCommit(t.Context(), "initial commit", nil))
+	baseCommitID, err := rep.LastCommitID(t.Context())
+	require.NoError(t, err)
+
+	// Commit one change and leave another untracked
+	require.NoError(t, os.WriteFile(
+		fmt.Sprintf("%s/committed.txt", rep.Dir()),
+		[]byte("after\n"),
+		0o600,
+	))
+	require.NoError(t, rep.AddAllAndCommit(t.Context(), "second commit", nil))
+	require.NoError(t, os.WriteFile(
+		fmt.Sprintf("%s/untracked.txt", rep.Dir()),
+		[]byte("new\n"),
+		0o600,
+	))
+
+	diff, err := rep.DiffFrom(t.Context(), baseCommitID)
+	require.NoError(t, err)
+	require.Contains(t, diff, "-before\n+after\n")
+	require.Contains(t, diff, "+++ b/untracked.txt")
+	require.Contains(t, diff, "+new\n")
+
+	// From an empty tree, every file is added
+	diff, err = rep.DiffFrom(t.Context(), "")
+	require.NoError(t, err)
+	require.Contains(t, diff, "+++ b/committed.txt")
+	require.Contains(t, diff, "+after\n")
+	require.NotContains(t, diff, "before")
+}
@@ -0,0 +1,136 @@
+package apitokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+// CollectorConfig represents configuration for the expired API token
+// collector.
+type CollectorConfig struct {
+	// Interval is how often the collector looks for expired API tokens.
+	Interval time.Duration `envconfig:"API_TOKEN_COLLECTION_INTERVAL" default:"5m"`
+}
+
+// CollectorConfigFromEnv returns a CollectorConfig populated from environment
+// variables.
+func CollectorConfigFromEnv() CollectorConfig {
+	cfg := CollectorConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// collector is an implementation of controller-runtime's manager.Runnable
+// interface that periodically deletes Kargo API token Secrets that have
+// expired.
+type collector struct {
+	cfg CollectorConfig
+	// reader is used to list token Secrets. It is expected to read directly
+	// from the Kubernetes API server, since Secrets are not cached in all
+	// namespaces in which API tokens may exist.
+	reader client.Reader
+	client client.Client
+	nowFn  func() time.Time
+}
+
+// NewCollector returns an implementation of controller-runtime's
+// manager.Runnable interface that periodically deletes Kargo API token Secrets
+// that have expired. Deleting a token's Secret causes Kubernetes to stop
+// recognizing the token.
+func NewCollector(
+	reader client.Reader,
+	c client.Client,
+	cfg CollectorConfig,
+) manager.Runnable {
+	if cfg.Interval <= 0 {
+		panic(fmt.Sprintf(
+			"apitokens: collection interval must be positive; got %v",
+			cfg.Interval,
+		))
+	}
+	return &collector{
+		cfg:    cfg,
+		reader: reader,
+		client: c,
+		nowFn:  time.Now,
+	}
+}
+
+// Start implements controller-runtime's manager.Runnable interface.
+func (c *collector) Start(ctx context.Context) error {
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"interval", c.cfg.Interval,
+	)
+	logger.Info("Starting expired API token collector")
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	c.collect(ctx)
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.collect(ctx)
+		case <-ctx.Done():
+			logger.Debug("expired API token collector stopped")
+			return nil
+		}
+	}
+}
+
+// collect deletes all expired API token Secrets. Errors are logged rather than
+// returned, as another attempt is made on the next tick.
+func (c *collector) collect(ctx context.Context) {
+	logger := logging.LoggerFromContext(ctx)
+
+	// Only metadata is needed, so there is no reason to read token data.
+	tokenSecrets := &metav1.PartialObjectMetadataList{}
+	tokenSecrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := c.reader.List(
+		ctx,
+		tokenSecrets,
+		client.MatchingLabels{rbacapi.LabelKeyAPIToken: rbacapi.LabelValueTrue},
+	); err != nil {
+		logger.Error(err, "error listing API token Secrets")
+		return
+	}
+
+	now := c.nowFn()
+	for i := range tokenSecrets.Items {
+		tokenSecret := &tokenSecrets.Items[i]
+		secretLogger := logger.WithValues(
+			"namespace", tokenSecret.Namespace,
+			"secret", tokenSecret.Name,
+		)
+		expiresAt, err := rbacapi.APITokenExpiryFromAnnotationValues(tokenSecret.Annotations)
+		if err != nil {
+			secretLogger.Error(err, "error determining API token expiry")
+			continue
+		}
+		if expiresAt == nil || now.Before(*expiresAt) {
+			continue
+		}
+		if err = c.client.Delete(
+			ctx,
+			tokenSecret,
+			// Guards against deleting a token that was replaced by another of the
+			// same name since it was listed.
+			client.Preconditions{UID: &tokenSecret.UID},
+		); client.IgnoreNotFound(err) != nil && !apierrors.IsConflict(err) {
+			secretLogger.Error(err, "error deleting expired API token Secret")
+			continue
+		}
+		secretLogger.Debug("deleted expired API token Secret", "expiredAt", expiresAt)
+	}
+}
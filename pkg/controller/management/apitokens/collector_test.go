@@ -0,0 +1,86 @@
+package apitokens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+)
+
+func TestNewCollector(t *testing.T) {
+	require.Panics(t, func() {
+		NewCollector(nil, nil, CollectorConfig{})
+	})
+	c := NewCollector(nil, nil, CollectorConfig{Interval: time.Minute})
+	require.NotNil(t, c)
+}
+
+func Test_collector_collect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newSecret := func(namespace, name string, isToken bool, expiresAt string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+			},
+		}
+		if isToken {
+			s.Type = corev1.SecretTypeServiceAccountToken
+			s.Labels[rbacapi.LabelKeyAPIToken] = rbacapi.LabelValueTrue
+		}
+		if expiresAt != "" {
+			s.Annotations[rbacapi.AnnotationKeyAPITokenExpiresAt] = expiresAt
+		}
+		return s
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		newSecret("kargo-demo", "expired", true, "2026-01-01T11:00:00Z"),
+		newSecret("kargo", "expired-system", true, "2025-12-31T00:00:00Z"),
+		newSecret("kargo-demo", "unexpired", true, "2026-01-01T13:00:00Z"),
+		newSecret("kargo-demo", "no-expiry", true, ""),
+		newSecret("kargo-demo", "malformed-expiry", true, "tomorrow"),
+		// Not an API token, so never collected even though it looks expired.
+		newSecret("kargo-demo", "not-a-token", false, "2026-01-01T11:00:00Z"),
+	).Build()
+
+	c := &collector{
+		cfg:    CollectorConfig{Interval: time.Minute},
+		reader: kubeClient,
+		client: kubeClient,
+		nowFn:  func() time.Time { return now },
+	}
+	c.collect(t.Context())
+
+	for _, testCase := range []struct {
+		namespace string
+		name      string
+		exists    bool
+	}{
+		{namespace: "kargo-demo", name: "expired"},
+		{namespace: "kargo", name: "expired-system"},
+		{namespace: "kargo-demo", name: "unexpired", exists: true},
+		{namespace: "kargo-demo", name: "no-expiry", exists: true},
+		{namespace: "kargo-demo", name: "malformed-expiry", exists: true},
+		{namespace: "kargo-demo", name: "not-a-token", exists: true},
+	} {
+		err := kubeClient.Get(
+			t.Context(),
+			types.NamespacedName{Namespace: testCase.namespace, Name: testCase.name},
+			&corev1.Secret{},
+		)
+		if testCase.exists {
+			require.NoError(t, err, testCase.name)
+		} else {
+			require.True(t, apierrors.IsNotFound(err), testCase.name)
+		}
+	}
+}
@@ -0,0 +1,574 @@
+package credentialhealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	"github.com/akuity/kargo/pkg/component"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/credentials/basic"
+	credsdb "github.com/akuity/kargo/pkg/credentials/kubernetes"
+	"github.com/akuity/kargo/pkg/credentials/ssh"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	// ReasonCheckSucceeded is the reason used for a Healthy condition when an
+	// authenticated call to the repository using the credentials succeeded.
+	ReasonCheckSucceeded = "CheckSucceeded"
+	// ReasonCheckFailed is the reason used for a Healthy condition when an
+	// authenticated call to the repository using the credentials failed.
+	ReasonCheckFailed = "CheckFailed"
+	// ReasonNoRepository is the reason used for a Healthy condition when the
+	// credentials could not be checked because no concrete repository URL to
+	// check them against is known.
+	ReasonNoRepository = "NoRepository"
+	// ReasonUnsupported is the reason used for a Healthy condition when the
+	// credentials could not be checked because they are not of a kind that can
+	// be checked.
+	ReasonUnsupported = "Unsupported"
+	// ReasonInsecureRepository is the reason used for a Healthy condition when
+	// the credentials were not checked because they would have been sent over
+	// plain HTTP.
+	ReasonInsecureRepository = "InsecureRepository"
+)
+
+// CheckerConfig represents configuration for the credential health checker.
+type CheckerConfig struct {
+	// Interval is how often all repository credentials are checked. A
+	// non-positive value disables the checker.
+	Interval time.Duration `envconfig:"CREDENTIAL_HEALTH_CHECK_INTERVAL" default:"15m"`
+	// Timeout bounds the length of time a single check may take.
+	Timeout time.Duration `envconfig:"CREDENTIAL_HEALTH_CHECK_TIMEOUT" default:"30s"`
+	// SharedResourcesNamespace is the namespace in which credentials shared by
+	// all Projects are stored.
+	SharedResourcesNamespace string `envconfig:"SHARED_RESOURCES_NAMESPACE" default:""`
+	// AllowCredentialsOverHTTP indicates whether credentials may be sent to
+	// repositories over plain HTTP.
+	AllowCredentialsOverHTTP bool `envconfig:"ALLOW_CREDENTIALS_OVER_HTTP" default:"false"`
+}
+
+// CheckerConfigFromEnv returns a CheckerConfig populated from environment
+// variables.
+func CheckerConfigFromEnv() CheckerConfig {
+	cfg := CheckerConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// probeFn makes a cheap, authenticated call to the repository at the specified
+// URL using the provided credentials and returns an error if the call fails.
+type probeFn func(ctx context.Context, repoURL string, creds *credentials.Credentials) error
+
+// checker is an implementation of controller-runtime's manager.Runnable
+// interface that periodically checks the health of every Project's repository
+// credentials and records which Warehouses and Stages last used them.
+type checker struct {
+	cfg CheckerConfig
+	// reader is used to list Secrets, Warehouses, and Stages. It is expected to
+	// read directly from the Kubernetes API server, since Secrets are not
+	// cached in all namespaces in which repository credentials may exist.
+	reader    client.Reader
+	client    client.Client
+	providers credentials.ProviderRegistry
+	probes    map[credentials.Type]probeFn
+	nowFn     func() time.Time
+}
+
+// NewChecker returns an implementation of controller-runtime's
+// manager.Runnable interface that periodically checks the health of every
+// Project's repository credentials using a cheap, authenticated call to a
+// repository they apply to. The outcome, along with the Warehouses and Stages
+// that last used the credentials, is recorded on each credential Secret using
+// the AnnotationKeyCredentialHealth annotation.
+func NewChecker(
+	reader client.Reader,
+	c client.Client,
+	cfg CheckerConfig,
+) manager.Runnable {
+	if cfg.Interval <= 0 {
+		panic(fmt.Sprintf(
+			"credentialhealth: check interval must be positive; got %v",
+			cfg.Interval,
+		))
+	}
+	// Only credentials stored directly in Secrets are checked. Credentials
+	// obtained from an external identity provider are minted on demand by the
+	// controller and are not ours to obtain.
+	basicProvider := &basic.CredentialProvider{}
+	sshProvider := &ssh.CredentialProvider{}
+	return &checker{
+		cfg:    cfg,
+		reader: reader,
+		client: c,
+		providers: credentials.MustNewProviderRegistry(
+			credentials.ProviderRegistration{
+				Predicate: basicProvider.Supports,
+				Value:     basicProvider,
+			},
+			credentials.ProviderRegistration{
+				Predicate: sshProvider.Supports,
+				Value:     sshProvider,
+			},
+		),
+		probes: map[credentials.Type]probeFn{
+			credentials.TypeGit:   probeGitRepo,
+			credentials.TypeImage: probeImageRepo,
+			credentials.TypeHelm:  probeChartRepo,
+		},
+		nowFn: time.Now,
+	}
+}
+
+// Start implements controller-runtime's manager.Runnable interface.
+func (c *checker) Start(ctx context.Context) error {
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"interval", c.cfg.Interval,
+	)
+	logger.Info("Starting credential health checker")
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	c.checkAll(ctx)
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.checkAll(ctx)
+		case <-ctx.Done():
+			logger.Debug("credential health checker stopped")
+			return nil
+		}
+	}
+}
+
+// checkAll checks the health and usage of all repository credentials in all
+// Project namespaces and the shared resources namespace. Errors are logged
+// rather than returned, as another attempt is made on the next tick.
+func (c *checker) checkAll(ctx context.Context) {
+	logger := logging.LoggerFromContext(ctx)
+
+	projects := &kargoapi.ProjectList{}
+	if err := c.reader.List(ctx, projects); err != nil {
+		logger.Error(err, "error listing Projects")
+		return
+	}
+	namespaces := make(map[string]struct{}, len(projects.Items)+1)
+	for _, project := range projects.Items {
+		namespaces[project.Name] = struct{}{}
+	}
+	if c.cfg.SharedResourcesNamespace != "" {
+		namespaces[c.cfg.SharedResourcesNamespace] = struct{}{}
+	}
+
+	secrets, err := c.listCredentialSecrets(ctx, namespaces)
+	if err != nil {
+		logger.Error(err, "error listing repository credential Secrets")
+		return
+	}
+	if len(secrets) == 0 {
+		return
+	}
+
+	users, err := c.getUsers(ctx, namespaces, secrets)
+	if err != nil {
+		logger.Error(err, "error determining usage of repository credentials")
+		return
+	}
+
+	now := c.nowFn()
+	for _, secret := range secrets.all() {
+		secretLogger := logger.WithValues(
+			"namespace", secret.Namespace,
+			"secret", secret.Name,
+		)
+		key := client.ObjectKeyFromObject(secret)
+		health := c.check(
+			logging.ContextWithLogger(ctx, secretLogger),
+			secret,
+			users[key],
+			now,
+		)
+		if err = c.recordHealth(ctx, secret, health); err != nil {
+			secretLogger.Error(err, "error recording repository credential health")
+		}
+	}
+}
+
+// credentialSecrets indexes repository credential Secrets by namespace and
+// credential type.
+type credentialSecrets map[string]map[credentials.Type][]corev1.Secret
+
+// all returns pointers to all the indexed Secrets.
+func (s credentialSecrets) all() []*corev1.Secret {
+	var all []*corev1.Secret
+	for _, byType := range s {
+		for _, secrets := range byType {
+			for i := range secrets {
+				all = append(all, &secrets[i])
+			}
+		}
+	}
+	return all
+}
+
+// listCredentialSecrets lists all git, image, and helm credential Secrets in
+// the specified namespaces.
+func (c *checker) listCredentialSecrets(
+	ctx context.Context,
+	namespaces map[string]struct{},
+) (credentialSecrets, error) {
+	req, err := labels.NewRequirement(
+		kargoapi.LabelKeyCredentialType,
+		selection.In,
+		[]string{
+			credentials.TypeGit.String(),
+			credentials.TypeImage.String(),
+			credentials.TypeHelm.String(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	secretList := &corev1.SecretList{}
+	if err = c.reader.List(
+		ctx,
+		secretList,
+		client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*req)},
+	); err != nil {
+		return nil, err
+	}
+	secrets := credentialSecrets{}
+	for _, secret := range secretList.Items {
+		if _, ok := namespaces[secret.Namespace]; !ok {
+			continue
+		}
+		credType := credentials.Type(secret.Labels[kargoapi.LabelKeyCredentialType])
+		if secrets[secret.Namespace] == nil {
+			secrets[secret.Namespace] = map[credentials.Type][]corev1.Secret{}
+		}
+		secrets[secret.Namespace][credType] = append(
+			secrets[secret.Namespace][credType],
+			secret,
+		)
+	}
+	return secrets, nil
+}
+
+// getUsers returns the Warehouses and Stages in the specified namespaces that
+// last used each of the provided Secrets, indexed by the Secrets' keys. The
+// Secret used for a given repository is selected exactly as the controller
+// selects it: a matching Secret from the Project's own namespace takes
+// precedence over one from the shared resources namespace.
+func (c *checker) getUsers(
+	ctx context.Context,
+	namespaces map[string]struct{},
+	secrets credentialSecrets,
+) (map[client.ObjectKey][]kargoapi.CredentialUser, error) {
+	users := map[client.ObjectKey][]kargoapi.CredentialUser{}
+	addUser := func(credType credentials.Type, user kargoapi.CredentialUser) {
+		if user.RepoURL == "" {
+			return
+		}
+		secret := credsdb.SelectSecret(
+			ctx, secrets[user.Namespace][credType], credType, user.RepoURL,
+		)
+		if secret == nil && c.cfg.SharedResourcesNamespace != "" {
+			secret = credsdb.SelectSecret(
+				ctx,
+				secrets[c.cfg.SharedResourcesNamespace][credType],
+				credType,
+				user.RepoURL,
+			)
+		}
+		if secret == nil {
+			return
+		}
+		key := client.ObjectKeyFromObject(secret)
+		users[key] = append(users[key], user)
+	}
+
+	warehouses := &kargoapi.WarehouseList{}
+	if err := c.reader.List(ctx, warehouses); err != nil {
+		return nil, fmt.Errorf("error listing Warehouses: %w", err)
+	}
+	for _, warehouse := range warehouses.Items {
+		if _, ok := namespaces[warehouse.Namespace]; !ok {
+			continue
+		}
+		user := kargoapi.CredentialUser{
+			Kind:      "Warehouse",
+			Namespace: warehouse.Namespace,
+			Name:      warehouse.Name,
+		}
+		if discovered := warehouse.Status.DiscoveredArtifacts; discovered != nil &&
+			!discovered.DiscoveredAt.IsZero() {
+			user.LastUsedAt = discovered.DiscoveredAt.DeepCopy()
+		}
+		for _, sub := range warehouse.Spec.InternalSubscriptions {
+			switch {
+			case sub.Git != nil:
+				user.RepoURL = sub.Git.RepoURL
+				addUser(credentials.TypeGit, user)
+			case sub.Image != nil:
+				user.RepoURL = sub.Image.RepoURL
+				addUser(credentials.TypeImage, user)
+			case sub.Chart != nil:
+				user.RepoURL = sub.Chart.RepoURL
+				addUser(credentials.TypeHelm, user)
+			}
+		}
+	}
+
+	stages := &kargoapi.StageList{}
+	if err := c.reader.List(ctx, stages); err != nil {
+		return nil, fmt.Errorf("error listing Stages: %w", err)
+	}
+	for _, stage := range stages.Items {
+		if _, ok := namespaces[stage.Namespace]; !ok {
+			continue
+		}
+		lastPromo := stage.Status.LastPromotion
+		if lastPromo == nil {
+			// A Stage that has never been promoted to has never used any
+			// credentials.
+			continue
+		}
+		user := kargoapi.CredentialUser{
+			Kind:       "Stage",
+			Namespace:  stage.Namespace,
+			Name:       stage.Name,
+			LastUsedAt: lastPromo.FinishedAt.DeepCopy(),
+		}
+		if freight := lastPromo.Freight; freight != nil {
+			for _, commit := range freight.Commits {
+				user.RepoURL = commit.RepoURL
+				addUser(credentials.TypeGit, user)
+			}
+			for _, image := range freight.Images {
+				user.RepoURL = image.RepoURL
+				addUser(credentials.TypeImage, user)
+			}
+			for _, chart := range freight.Charts {
+				user.RepoURL = chart.RepoURL
+				addUser(credentials.TypeHelm, user)
+			}
+		}
+		if stage.Spec.PromotionTemplate != nil {
+			for _, repoURL := range gitStepRepoURLs(stage.Spec.PromotionTemplate.Spec.Steps) {
+				user.RepoURL = repoURL
+				addUser(credentials.TypeGit, user)
+			}
+		}
+	}
+
+	for key, keyUsers := range users {
+		users[key] = dedupeUsers(keyUsers)
+	}
+	return users, nil
+}
+
+// gitStepRepoURLs returns the literal (i.e. non-expression) repository URLs
+// found in the configuration of any git-* promotion steps.
+func gitStepRepoURLs(steps []kargoapi.PromotionStep) []string {
+	var repoURLs []string
+	for _, step := range steps {
+		if !strings.HasPrefix(step.Uses, "git-") || step.Config == nil {
+			continue
+		}
+		cfg := struct {
+			RepoURL string `json:"repoURL"`
+		}{}
+		if err := json.Unmarshal(step.Config.Raw, &cfg); err != nil {
+			continue
+		}
+		if cfg.RepoURL == "" || strings.Contains(cfg.RepoURL, "${{") {
+			continue
+		}
+		repoURLs = append(repoURLs, cfg.RepoURL)
+	}
+	return repoURLs
+}
+
+// dedupeUsers returns the provided users sorted by kind, namespace, name, and
+// repository URL, retaining only the first entry for each distinct resource and
+// repository URL.
+func dedupeUsers(users []kargoapi.CredentialUser) []kargoapi.CredentialUser {
+	slices.SortFunc(users, func(lhs, rhs kargoapi.CredentialUser) int {
+		if c := strings.Compare(lhs.Kind, rhs.Kind); c != 0 {
+			return c
+		}
+		if c := strings.Compare(lhs.Namespace, rhs.Namespace); c != 0 {
+			return c
+		}
+		if c := strings.Compare(lhs.Name, rhs.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(lhs.RepoURL, rhs.RepoURL)
+	})
+	return slices.CompactFunc(users, func(lhs, rhs kargoapi.CredentialUser) bool {
+		return lhs.Kind == rhs.Kind && lhs.Namespace == rhs.Namespace &&
+			lhs.Name == rhs.Name && lhs.RepoURL == rhs.RepoURL
+	})
+}
+
+// check checks the health of the credentials stored in the provided Secret
+// and returns the resulting CredentialHealth. The Healthy condition of any
+// previously recorded CredentialHealth is carried forward so that its last
+// transition time is preserved when the outcome is unchanged.
+func (c *checker) check(
+	ctx context.Context,
+	secret *corev1.Secret,
+	users []kargoapi.CredentialUser,
+	now time.Time,
+) *kargoapi.CredentialHealth {
+	health := &kargoapi.CredentialHealth{
+		CheckedAt: &metav1.Time{Time: now},
+		UsedBy:    users,
+	}
+	if prev, ok := api.CredentialHealthAnnotationValue(secret.Annotations); ok {
+		health.Conditions = prev.Conditions
+	}
+	setHealthy := func(status metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(&health.Conditions, metav1.Condition{
+			Type:    kargoapi.ConditionTypeHealthy,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+
+	credType := credentials.Type(secret.Labels[kargoapi.LabelKeyCredentialType])
+	health.RepoURL = checkRepoURL(secret, users)
+	if health.RepoURL == "" {
+		setHealthy(
+			metav1.ConditionUnknown,
+			ReasonNoRepository,
+			"Credentials apply to a pattern of repository URLs and are not yet "+
+				"used by any Warehouse or Stage",
+		)
+		return health
+	}
+
+	if !c.cfg.AllowCredentialsOverHTTP && strings.HasPrefix(health.RepoURL, "http://") {
+		setHealthy(
+			metav1.ConditionUnknown,
+			ReasonInsecureRepository,
+			"Credentials are not sent to repositories over plain HTTP",
+		)
+		return health
+	}
+
+	req := credentials.Request{
+		Project: secret.Namespace,
+		Type:    credType,
+		RepoURL: health.RepoURL,
+		Data:    secret.Data,
+	}
+	providerReg, err := c.providers.Get(ctx, req)
+	if err != nil {
+		if !component.IsNotFoundError(err) {
+			setHealthy(metav1.ConditionFalse, ReasonCheckFailed, err.Error())
+			return health
+		}
+		setHealthy(
+			metav1.ConditionUnknown,
+			ReasonUnsupported,
+			"Only username/password and SSH key credentials can be checked",
+		)
+		return health
+	}
+	creds, err := providerReg.Value.GetCredentials(ctx, req)
+	if err != nil {
+		setHealthy(metav1.ConditionFalse, ReasonCheckFailed, err.Error())
+		return health
+	}
+	probe, ok := c.probes[credType]
+	if !ok || creds == nil {
+		setHealthy(
+			metav1.ConditionUnknown,
+			ReasonUnsupported,
+			fmt.Sprintf("Credentials of type %q cannot be checked", credType),
+		)
+		return health
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+	if err = probe(probeCtx, health.RepoURL, creds); err != nil {
+		logging.LoggerFromContext(ctx).Debug(
+			"repository credential health check failed",
+			"repoURL", health.RepoURL,
+			"error", err.Error(),
+		)
+		setHealthy(metav1.ConditionFalse, ReasonCheckFailed, err.Error())
+		return health
+	}
+	setHealthy(
+		metav1.ConditionTrue,
+		ReasonCheckSucceeded,
+		fmt.Sprintf("Successfully accessed %s", health.RepoURL),
+	)
+	return health
+}
+
+// checkRepoURL returns the URL of the repository against which the credentials
+// stored in the provided Secret should be checked. This is the URL specified by
+// the Secret itself, unless that is a pattern, in which case it is the URL of
+// the first repository a user accessed using the credentials. If no such URL
+// is known, an empty string is returned.
+func checkRepoURL(secret *corev1.Secret, users []kargoapi.CredentialUser) string {
+	if string(secret.Data[credentials.FieldRepoURLIsRegex]) != "true" {
+		return string(secret.Data[credentials.FieldRepoURL])
+	}
+	for _, user := range users {
+		if user.RepoURL != "" {
+			return user.RepoURL
+		}
+	}
+	return ""
+}
+
+// recordHealth patches the provided Secret's AnnotationKeyCredentialHealth
+// annotation with the provided CredentialHealth.
+func (c *checker) recordHealth(
+	ctx context.Context,
+	secret *corev1.Secret,
+	health *kargoapi.CredentialHealth,
+) error {
+	if prev, ok := api.CredentialHealthAnnotationValue(secret.Annotations); ok &&
+		sameOutcome(prev, health) {
+		// Updating the Secret only to record the time of the check would
+		// trigger watches on it for no good reason.
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, 1)
+	}
+	secret.Annotations[kargoapi.AnnotationKeyCredentialHealth] = health.String()
+	return client.IgnoreNotFound(c.client.Patch(ctx, secret, patch))
+}
+
+// sameOutcome returns true if the provided CredentialHealths differ in nothing
+// but the time of the check that produced them.
+func sameOutcome(lhs, rhs *kargoapi.CredentialHealth) bool {
+	l, r := *lhs, *rhs
+	l.CheckedAt, r.CheckedAt = nil, nil
+	return l.String() == r.String()
+}
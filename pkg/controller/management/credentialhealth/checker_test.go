@@ -0,0 +1,370 @@
+package credentialhealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	"github.com/akuity/kargo/pkg/conditions"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func TestNewChecker(t *testing.T) {
+	require.Panics(t, func() {
+		NewChecker(nil, nil, CheckerConfig{})
+	})
+	c := NewChecker(nil, nil, CheckerConfig{Interval: time.Minute})
+	require.NotNil(t, c)
+}
+
+func Test_checker_checkAll(t *testing.T) {
+	const (
+		testProject = "fake-project"
+		testShared  = "shared"
+	)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	discoveredAt := metav1.NewTime(now.Add(-time.Hour))
+	promotedAt := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	newSecret := func(
+		namespace, name string,
+		credType credentials.Type,
+		data map[string]string,
+	) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					kargoapi.LabelKeyCredentialType: credType.String(),
+				},
+			},
+			Data: map[string][]byte{},
+		}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(testScheme))
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(testScheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&kargoapi.Project{ObjectMeta: metav1.ObjectMeta{Name: testProject}},
+		&kargoapi.Warehouse{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testProject, Name: "fake-warehouse"},
+			Spec: kargoapi.WarehouseSpec{
+				InternalSubscriptions: []kargoapi.RepoSubscription{
+					{Git: &kargoapi.GitSubscription{RepoURL: "https://github.com/example/repo"}},
+					{Image: &kargoapi.ImageSubscription{RepoURL: "ghcr.io/example/app"}},
+				},
+			},
+			Status: kargoapi.WarehouseStatus{
+				DiscoveredArtifacts: &kargoapi.DiscoveredArtifacts{DiscoveredAt: discoveredAt},
+			},
+		},
+		&kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testProject, Name: "fake-stage"},
+			Status: kargoapi.StageStatus{
+				LastPromotion: &kargoapi.PromotionReference{
+					Name: "fake-promotion",
+					Freight: &kargoapi.FreightReference{
+						Commits: []kargoapi.GitCommit{{RepoURL: "https://github.com/example/repo"}},
+						Charts:  []kargoapi.Chart{{RepoURL: "oci://ghcr.io/example/charts/app"}},
+					},
+					FinishedAt: &promotedAt,
+				},
+			},
+		},
+		// Used by both the Warehouse and the Stage; healthy.
+		newSecret(testProject, "git", credentials.TypeGit, map[string]string{
+			credentials.FieldRepoURL: "https://github.com/example/repo",
+			"username":               "user",
+			"password":               "good",
+		}),
+		// Used by the Warehouse; unhealthy.
+		newSecret(testProject, "image", credentials.TypeImage, map[string]string{
+			credentials.FieldRepoURL: "ghcr.io/example/app",
+			"username":               "user",
+			"password":               "expired",
+		}),
+		// A pattern that is used by the Stage; checked against the chart it
+		// was used for.
+		newSecret(testShared, "charts", credentials.TypeHelm, map[string]string{
+			credentials.FieldRepoURL:        "^oci://ghcr.io/example/charts/.*$",
+			credentials.FieldRepoURLIsRegex: "true",
+			"username":                      "user",
+			"password":                      "good",
+		}),
+		// A pattern that nothing uses.
+		newSecret(testProject, "unused-pattern", credentials.TypeGit, map[string]string{
+			credentials.FieldRepoURL:        "^https://gitlab.com/.*$",
+			credentials.FieldRepoURLIsRegex: "true",
+			"username":                      "user",
+			"password":                      "good",
+		}),
+		// Credentials obtained from elsewhere that can't be checked.
+		newSecret(testProject, "github-app", credentials.TypeGit, map[string]string{
+			credentials.FieldRepoURL: "https://github.com/example/other",
+			"githubAppID":            "1",
+		}),
+		// Not in a Project or shared namespace, so ignored.
+		newSecret("elsewhere", "ignored", credentials.TypeGit, map[string]string{
+			credentials.FieldRepoURL: "https://github.com/example/repo",
+			"username":               "user",
+			"password":               "good",
+		}),
+	).Build()
+
+	var probed []string
+	probe := func(_ context.Context, repoURL string, creds *credentials.Credentials) error {
+		probed = append(probed, repoURL)
+		if creds.Password != "good" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	c := NewChecker(kubeClient, kubeClient, CheckerConfig{
+		Interval:                 time.Minute,
+		Timeout:                  time.Second,
+		SharedResourcesNamespace: testShared,
+	}).(*checker) // nolint: forcetypeassert
+	c.probes = map[credentials.Type]probeFn{
+		credentials.TypeGit:   probe,
+		credentials.TypeImage: probe,
+		credentials.TypeHelm:  probe,
+	}
+	c.nowFn = func() time.Time { return now }
+	c.checkAll(t.Context())
+
+	require.ElementsMatch(
+		t,
+		[]string{
+			"https://github.com/example/repo",
+			"ghcr.io/example/app",
+			"oci://ghcr.io/example/charts/app",
+		},
+		probed,
+	)
+
+	getHealth := func(namespace, name string) *kargoapi.CredentialHealth {
+		secret := &corev1.Secret{}
+		require.NoError(t, kubeClient.Get(
+			t.Context(),
+			types.NamespacedName{Namespace: namespace, Name: name},
+			secret,
+		))
+		health, _ := api.CredentialHealthAnnotationValue(secret.Annotations)
+		return health
+	}
+	requireHealthy := func(
+		health *kargoapi.CredentialHealth,
+		status metav1.ConditionStatus,
+		reason string,
+	) {
+		t.Helper()
+		require.NotNil(t, health)
+		require.Equal(t, now, health.CheckedAt.UTC())
+		cond := conditions.Get(health, kargoapi.ConditionTypeHealthy)
+		require.NotNil(t, cond)
+		require.Equal(t, status, cond.Status)
+		require.Equal(t, reason, cond.Reason)
+	}
+
+	health := getHealth(testProject, "git")
+	requireHealthy(health, metav1.ConditionTrue, ReasonCheckSucceeded)
+	require.Len(t, health.UsedBy, 2)
+	require.Equal(t, "Stage", health.UsedBy[0].Kind)
+	require.Equal(t, "fake-stage", health.UsedBy[0].Name)
+	require.Equal(t, promotedAt.Time, health.UsedBy[0].LastUsedAt.UTC())
+	require.Equal(t, "Warehouse", health.UsedBy[1].Kind)
+	require.Equal(t, "fake-warehouse", health.UsedBy[1].Name)
+	require.Equal(t, discoveredAt.Time, health.UsedBy[1].LastUsedAt.UTC())
+
+	health = getHealth(testProject, "image")
+	requireHealthy(health, metav1.ConditionFalse, ReasonCheckFailed)
+	require.Contains(
+		t,
+		conditions.Get(health, kargoapi.ConditionTypeHealthy).Message,
+		"unauthorized",
+	)
+	require.Len(t, health.UsedBy, 1)
+
+	health = getHealth(testShared, "charts")
+	requireHealthy(health, metav1.ConditionTrue, ReasonCheckSucceeded)
+	require.Equal(t, "oci://ghcr.io/example/charts/app", health.RepoURL)
+	require.Len(t, health.UsedBy, 1)
+	require.Equal(t, testProject, health.UsedBy[0].Namespace)
+
+	requireHealthy(
+		getHealth(testProject, "unused-pattern"),
+		metav1.ConditionUnknown,
+		ReasonNoRepository,
+	)
+	requireHealthy(
+		getHealth(testProject, "github-app"),
+		metav1.ConditionUnknown,
+		ReasonUnsupported,
+	)
+	require.Nil(t, getHealth("elsewhere", "ignored"))
+
+	// Checking again with the same outcome does not update the Secrets
+	getResourceVersion := func(namespace, name string) string {
+		secret := &corev1.Secret{}
+		require.NoError(t, kubeClient.Get(
+			t.Context(),
+			types.NamespacedName{Namespace: namespace, Name: name},
+			secret,
+		))
+		return secret.ResourceVersion
+	}
+	gitVersion := getResourceVersion(testProject, "git")
+	imageVersion := getResourceVersion(testProject, "image")
+	later := now.Add(time.Hour)
+	c.nowFn = func() time.Time { return later }
+	c.checkAll(t.Context())
+	require.Equal(t, gitVersion, getResourceVersion(testProject, "git"))
+	require.Equal(t, imageVersion, getResourceVersion(testProject, "image"))
+	requireHealthy(getHealth(testProject, "git"), metav1.ConditionTrue, ReasonCheckSucceeded)
+
+	// Checking again with a different outcome does
+	secret := &corev1.Secret{}
+	require.NoError(t, kubeClient.Get(
+		t.Context(),
+		types.NamespacedName{Namespace: testProject, Name: "git"},
+		secret,
+	))
+	secret.Data["password"] = []byte("revoked")
+	require.NoError(t, kubeClient.Update(t.Context(), secret))
+	c.checkAll(t.Context())
+	health = getHealth(testProject, "git")
+	require.Equal(t, later, health.CheckedAt.UTC())
+	cond := conditions.Get(health, kargoapi.ConditionTypeHealthy)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+}
+
+func Test_checker_check(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	previous := &kargoapi.CredentialHealth{
+		Conditions: []metav1.Condition{{
+			Type:               kargoapi.ConditionTypeHealthy,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonCheckSucceeded,
+			LastTransitionTime: metav1.NewTime(now.Add(-24 * time.Hour)),
+		}},
+	}
+	newSecret := func(repoURL string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "fake-secret",
+				Labels: map[string]string{
+					kargoapi.LabelKeyCredentialType: credentials.TypeGit.String(),
+				},
+				Annotations: map[string]string{
+					kargoapi.AnnotationKeyCredentialHealth: previous.String(),
+				},
+			},
+			Data: map[string][]byte{
+				credentials.FieldRepoURL: []byte(repoURL),
+				"username":               []byte("user"),
+				"password":               []byte("pass"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		repoURL    string
+		probeErr   error
+		assertions func(*testing.T, *metav1.Condition)
+	}{
+		{
+			name:    "still healthy",
+			repoURL: "https://github.com/example/repo",
+			assertions: func(t *testing.T, cond *metav1.Condition) {
+				require.Equal(t, metav1.ConditionTrue, cond.Status)
+				// The last transition time is preserved
+				require.Equal(
+					t,
+					previous.Conditions[0].LastTransitionTime.Time,
+					cond.LastTransitionTime.UTC(),
+				)
+			},
+		},
+		{
+			name:     "newly unhealthy",
+			repoURL:  "https://github.com/example/repo",
+			probeErr: errors.New("authentication failed"),
+			assertions: func(t *testing.T, cond *metav1.Condition) {
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(t, ReasonCheckFailed, cond.Reason)
+				require.Equal(t, "authentication failed", cond.Message)
+				require.NotEqual(
+					t,
+					previous.Conditions[0].LastTransitionTime.Time,
+					cond.LastTransitionTime.UTC(),
+				)
+			},
+		},
+		{
+			name:    "plain HTTP",
+			repoURL: "http://example.com/repo.git",
+			assertions: func(t *testing.T, cond *metav1.Condition) {
+				require.Equal(t, metav1.ConditionUnknown, cond.Status)
+				require.Equal(t, ReasonInsecureRepository, cond.Reason)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := NewChecker(nil, nil, CheckerConfig{
+				Interval: time.Minute,
+				Timeout:  time.Second,
+			}).(*checker) // nolint: forcetypeassert
+			c.probes = map[credentials.Type]probeFn{
+				credentials.TypeGit: func(context.Context, string, *credentials.Credentials) error {
+					return testCase.probeErr
+				},
+			}
+			health := c.check(t.Context(), newSecret(testCase.repoURL), nil, now)
+			require.Equal(t, testCase.repoURL, health.RepoURL)
+			cond := conditions.Get(health, kargoapi.ConditionTypeHealthy)
+			require.NotNil(t, cond)
+			testCase.assertions(t, cond)
+		})
+	}
+}
+
+func Test_gitStepRepoURLs(t *testing.T) {
+	step := func(uses, config string) kargoapi.PromotionStep {
+		s := kargoapi.PromotionStep{Uses: uses}
+		if config != "" {
+			s.Config = &apiextensionsv1.JSON{Raw: []byte(config)}
+		}
+		return s
+	}
+	require.Equal(
+		t,
+		[]string{"https://github.com/example/repo"},
+		gitStepRepoURLs([]kargoapi.PromotionStep{
+			step("git-clone", `{"repoURL":"https://github.com/example/repo"}`),
+			step("git-push", `{"repoURL":"${{ vars.repoURL }}"}`),
+			step("git-commit", ""),
+			step("http", `{"repoURL":"https://example.com"}`),
+		}),
+	)
+}
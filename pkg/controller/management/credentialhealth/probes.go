@@ -0,0 +1,130 @@
+package credentialhealth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/hashicorp/go-cleanhttp"
+
+	"github.com/akuity/kargo/pkg/controller/git"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+// probeGitRepo checks credentials for a Git repository by resolving the
+// repository's HEAD using git ls-remote, which requires no clone.
+func probeGitRepo(
+	ctx context.Context,
+	repoURL string,
+	creds *credentials.Credentials,
+) error {
+	_, err := git.LsRemote(
+		ctx,
+		repoURL,
+		&git.ClientOptions{
+			Credentials: &git.RepoCredentials{
+				Username:      creds.Username,
+				Password:      creds.Password,
+				SSHPrivateKey: creds.SSHPrivateKey,
+			},
+		},
+		"HEAD",
+	)
+	return err
+}
+
+// probeImageRepo checks credentials for a container image repository by
+// obtaining pull access to the repository and pinging the registry's API
+// using that access.
+func probeImageRepo(
+	ctx context.Context,
+	repoURL string,
+	creds *credentials.Credentials,
+) error {
+	return pingRegistry(ctx, repoURL, creds)
+}
+
+// probeChartRepo checks credentials for a Helm chart repository. For an OCI
+// repository, this pings the registry in the same manner as probeImageRepo.
+// For a classic HTTP/S repository, this fetches the repository's index.
+func probeChartRepo(
+	ctx context.Context,
+	repoURL string,
+	creds *credentials.Credentials,
+) error {
+	if ociRepoURL, ok := strings.CutPrefix(repoURL, "oci://"); ok {
+		return pingRegistry(ctx, ociRepoURL, creds)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/index.yaml", strings.TrimSuffix(repoURL, "/")),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error building request for chart repository index: %w", err)
+	}
+	req.SetBasicAuth(creds.Username, creds.Password)
+	return doRequest(cleanhttp.DefaultClient(), req)
+}
+
+// pingRegistry obtains pull access to the specified repository using the
+// provided credentials and then makes a request to the base of the registry's
+// API using that access. When the registry uses token authentication, bad
+// credentials are rejected during the token exchange. When it uses basic
+// authentication, they are rejected by the API request.
+func pingRegistry(
+	ctx context.Context,
+	repoURL string,
+	creds *credentials.Credentials,
+) error {
+	repo, err := name.NewRepository(repoURL)
+	if err != nil {
+		return fmt.Errorf("error parsing repository URL %q: %w", repoURL, err)
+	}
+	reg := repo.Registry
+	rt, err := transport.NewWithContext(
+		ctx,
+		reg,
+		&authn.Basic{Username: creds.Username, Password: creds.Password},
+		cleanhttp.DefaultTransport(),
+		[]string{repo.Scope(transport.PullScope)},
+	)
+	if err != nil {
+		return fmt.Errorf("error authenticating to registry %s: %w", reg.RegistryStr(), err)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr()),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error building request for registry API: %w", err)
+	}
+	return doRequest(&http.Client{Transport: rt}, req)
+}
+
+// doRequest makes the provided request and returns an error if the response
+// status does not indicate success. The response body is discarded.
+func doRequest(httpClient *http.Client, req *http.Request) error {
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error requesting %s: %w", req.URL.Redacted(), err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<20))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf(
+			"request to %s returned unexpected status %s",
+			req.URL.Redacted(),
+			res.Status,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package credentialhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func Test_probeChartRepo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/charts/index.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("apiVersion: v1\nentries: {}\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	err := probeChartRepo(
+		t.Context(),
+		srv.URL+"/charts/",
+		&credentials.Credentials{Username: "user", Password: "good"},
+	)
+	require.NoError(t, err)
+
+	err = probeChartRepo(
+		t.Context(),
+		srv.URL+"/charts",
+		&credentials.Credentials{Username: "user", Password: "expired"},
+	)
+	require.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func Test_probeImageRepo(t *testing.T) {
+	// A registry that uses basic authentication.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "good" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	// go-containerregistry assumes plain HTTP for localhost registries.
+	repoURL := strings.TrimPrefix(srv.URL, "http://") + "/example/app"
+
+	err := probeImageRepo(
+		t.Context(),
+		repoURL,
+		&credentials.Credentials{Username: "user", Password: "good"},
+	)
+	require.NoError(t, err)
+
+	err = probeImageRepo(
+		t.Context(),
+		repoURL,
+		&credentials.Credentials{Username: "user", Password: "expired"},
+	)
+	require.ErrorContains(t, err, "401 Unauthorized")
+}
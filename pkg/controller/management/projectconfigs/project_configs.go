@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/api"
@@ -47,7 +51,7 @@ func SetupReconcilerWithManager(
 	mgr manager.Manager,
 	cfg ReconcilerConfig,
 ) error {
-	_, err := ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&kargoapi.ProjectConfig{}).
 		WithOptions(controller.CommonOptions(cfg.MaxConcurrentReconciles)).
 		WithEventFilter(intpredicate.IgnoreDelete[client.Object]{}).
@@ -62,6 +66,22 @@ func SetupReconcilerWithManager(
 		return fmt.Errorf("error creating ProjectConfig reconciler: %w", err)
 	}
 
+	// Watch for Kargo-managed ServiceAccounts being created, deleted or having
+	// their role mapping drift, and enqueue the ProjectConfig of their Project
+	// so that RoleMappings referencing Roles that did not previously exist (or
+	// that were recreated) are applied without waiting for the ProjectConfig to
+	// change.
+	if err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.ServiceAccount{},
+			handler.TypedEnqueueRequestsFromMapFunc(projectConfigForServiceAccount),
+			serviceAccountRoleMappingChanged{},
+		),
+	); err != nil {
+		return fmt.Errorf("unable to watch ServiceAccounts: %w", err)
+	}
+
 	logging.LoggerFromContext(ctx).Info(
 		"Initialized ProjectConfig reconciler",
 		"maxConcurrentReconciles", cfg.MaxConcurrentReconciles,
@@ -104,8 +124,18 @@ func (r *reconciler) Reconcile(
 	}
 
 	if !projectConfig.DeletionTimestamp.IsZero() {
-		logger.Debug("ProjectConfig is being deleted; nothing to do")
-		return ctrl.Result{}, nil
+		logger.Debug("ProjectConfig is being deleted; handling deletion")
+		return ctrl.Result{}, r.handleDelete(ctx, projectConfig)
+	}
+
+	// Ensure the ProjectConfig has a finalizer and requeue if it was added. The
+	// finalizer gives us the chance to revoke role mappings when the
+	// ProjectConfig is deleted. The reason to requeue is to ensure that a
+	// possible deletion of the ProjectConfig directly after the finalizer was
+	// added is handled without delay.
+	if ok, err := api.EnsureFinalizer(ctx, r.client, projectConfig); ok || err != nil {
+		logger.Debug("ensured finalizer on ProjectConfig; requeuing")
+		return ctrl.Result{RequeueAfter: 100 * time.Millisecond}, err
 	}
 
 	logger.Debug("reconciling ProjectConfig")
@@ -147,6 +177,25 @@ func (r *reconciler) Reconcile(
 	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 }
 
+// handleDelete revokes the role mappings applied on behalf of the provided
+// ProjectConfig, which is being deleted, and then removes its finalizer.
+func (r *reconciler) handleDelete(
+	ctx context.Context,
+	projectCfg *kargoapi.ProjectConfig,
+) error {
+	if !controllerutil.ContainsFinalizer(projectCfg, kargoapi.FinalizerName) {
+		return nil
+	}
+	if err := r.clearRoleMappings(ctx, projectCfg.Namespace); err != nil {
+		return err
+	}
+	if err := api.RemoveFinalizer(ctx, r.client, projectCfg); err != nil {
+		return fmt.Errorf("error removing finalizer from ProjectConfig: %w", err)
+	}
+	logging.LoggerFromContext(ctx).Debug("removed finalizer from ProjectConfig")
+	return nil
+}
+
 func (r *reconciler) reconcile(
 	ctx context.Context,
 	projectCfg *kargoapi.ProjectConfig,
@@ -179,6 +228,11 @@ func (r *reconciler) reconcile(
 		reconcile: func() (kargoapi.ProjectConfigStatus, error) {
 			return r.syncWebhookReceivers(ctx, working)
 		},
+	}, {
+		name: "syncing RoleMappings",
+		reconcile: func() (kargoapi.ProjectConfigStatus, error) {
+			return r.syncRoleMappings(ctx, working)
+		},
 	}}
 	for _, subR := range subReconcilers {
 		logger.Debug(subR.name)
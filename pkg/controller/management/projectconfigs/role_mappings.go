@@ -0,0 +1,279 @@
+package projectconfigs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/conditions"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/rolemapping"
+)
+
+// syncRoleMappings brings the role mapping annotations of all ServiceAccounts
+// in the Project namespace into agreement with the ProjectConfig's
+// RoleMappings. Each mapped Role's ServiceAccount is annotated with the
+// consolidated criteria of all RoleMappings referencing it, and the annotation
+// is removed from any ServiceAccount that is no longer mapped.
+//
+// Conflicts -- RoleMappings that cannot be applied because the Role does not
+// exist or is not managed by Kargo, or because an expression is invalid -- do
+// not prevent the remaining RoleMappings from being applied. They are reported
+// using the Ready condition and do not result in an error, since requeueing
+// cannot resolve them.
+func (r *reconciler) syncRoleMappings(
+	ctx context.Context,
+	projectCfg *kargoapi.ProjectConfig,
+) (kargoapi.ProjectConfigStatus, error) {
+	logger := logging.LoggerFromContext(ctx)
+	status := projectCfg.Status.DeepCopy()
+
+	desired, conflicts := consolidateRoleMappings(projectCfg.Spec.RoleMappings)
+
+	saList := &corev1.ServiceAccountList{}
+	if err := r.client.List(
+		ctx,
+		saList,
+		client.InNamespace(projectCfg.Namespace),
+	); err != nil {
+		err = fmt.Errorf(
+			"error listing ServiceAccounts in namespace %q: %w",
+			projectCfg.Namespace, err,
+		)
+		conditions.Set(status, &metav1.Condition{
+			Type:               kargoapi.ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SyncRoleMappingsFailed",
+			Message:            "Failed to sync RoleMappings: " + err.Error(),
+			ObservedGeneration: projectCfg.GetGeneration(),
+		})
+		return *status, err
+	}
+
+	found := make(map[string]struct{}, len(desired))
+	var errs []error
+	for i := range saList.Items {
+		sa := &saList.Items[i]
+		criteria, mapped := desired[sa.Name]
+		if mapped {
+			found[sa.Name] = struct{}{}
+			if sa.Annotations[rbacapi.AnnotationKeyManaged] != rbacapi.AnnotationValueTrue {
+				conflicts = append(
+					conflicts,
+					fmt.Sprintf("Role %q is not managed by Kargo", sa.Name),
+				)
+				criteria = nil
+			}
+		}
+		updated, err := r.syncServiceAccountRoleMapping(ctx, sa, criteria)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if updated {
+			logger.Debug(
+				"synced ServiceAccount role mapping",
+				"serviceAccount", sa.Name,
+			)
+		}
+	}
+	for role := range desired {
+		if _, ok := found[role]; !ok {
+			conflicts = append(conflicts, fmt.Sprintf("Role %q does not exist", role))
+		}
+	}
+
+	if len(errs) != 0 {
+		flattenedErrs := kerrors.Flatten(kerrors.NewAggregate(errs))
+		conditions.Set(status, &metav1.Condition{
+			Type:               kargoapi.ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SyncRoleMappingsFailed",
+			Message:            "Failed to sync RoleMappings: " + flattenedErrs.Error(),
+			ObservedGeneration: projectCfg.GetGeneration(),
+		})
+		return *status, flattenedErrs
+	}
+
+	if len(conflicts) != 0 {
+		slices.Sort(conflicts)
+		conditions.Set(status, &metav1.Condition{
+			Type:               kargoapi.ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "RoleMappingConflict",
+			Message:            "Some RoleMappings could not be applied: " + strings.Join(conflicts, "; "),
+			ObservedGeneration: projectCfg.GetGeneration(),
+		})
+	}
+
+	return *status, nil
+}
+
+// clearRoleMappings removes the role mapping annotation from all
+// ServiceAccounts in the provided namespace.
+func (r *reconciler) clearRoleMappings(ctx context.Context, namespace string) error {
+	saList := &corev1.ServiceAccountList{}
+	if err := r.client.List(ctx, saList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf(
+			"error listing ServiceAccounts in namespace %q: %w", namespace, err,
+		)
+	}
+	var errs []error
+	for i := range saList.Items {
+		if _, err := r.syncServiceAccountRoleMapping(ctx, &saList.Items[i], nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.Flatten(kerrors.NewAggregate(errs))
+}
+
+// projectConfigForServiceAccount maps a ServiceAccount to a request for the
+// ProjectConfig of the Project whose namespace the ServiceAccount is in.
+func projectConfigForServiceAccount(
+	_ context.Context,
+	sa *corev1.ServiceAccount,
+) []reconcile.Request {
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: sa.Namespace,
+			Name:      sa.Namespace,
+		},
+	}}
+}
+
+// serviceAccountRoleMappingChanged is a predicate that admits events for
+// ServiceAccounts that represent Kargo-managed Roles or carry a role mapping,
+// limiting updates to those that change either of those facts.
+type serviceAccountRoleMappingChanged struct {
+	predicate.TypedFuncs[*corev1.ServiceAccount]
+}
+
+func (serviceAccountRoleMappingChanged) Create(
+	e event.TypedCreateEvent[*corev1.ServiceAccount],
+) bool {
+	return isRoleMappingRelevant(e.Object)
+}
+
+func (serviceAccountRoleMappingChanged) Delete(
+	e event.TypedDeleteEvent[*corev1.ServiceAccount],
+) bool {
+	return isRoleMappingRelevant(e.Object)
+}
+
+func (serviceAccountRoleMappingChanged) Update(
+	e event.TypedUpdateEvent[*corev1.ServiceAccount],
+) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	oldAnnotations := e.ObjectOld.GetAnnotations()
+	newAnnotations := e.ObjectNew.GetAnnotations()
+	return oldAnnotations[rbacapi.AnnotationKeyManaged] != newAnnotations[rbacapi.AnnotationKeyManaged] ||
+		oldAnnotations[rbacapi.AnnotationKeyRoleMapping] != newAnnotations[rbacapi.AnnotationKeyRoleMapping]
+}
+
+// isRoleMappingRelevant returns true if the provided ServiceAccount represents
+// a Kargo-managed Role or carries a role mapping.
+func isRoleMappingRelevant(sa *corev1.ServiceAccount) bool {
+	if sa == nil {
+		return false
+	}
+	annotations := sa.GetAnnotations()
+	_, hasRoleMapping := annotations[rbacapi.AnnotationKeyRoleMapping]
+	return hasRoleMapping ||
+		annotations[rbacapi.AnnotationKeyManaged] == rbacapi.AnnotationValueTrue
+}
+
+// consolidateRoleMappings merges the criteria of all provided RoleMappings
+// referencing the same Role, dropping duplicates and any invalid expressions.
+// It returns the merged criteria indexed by Role name along with a description
+// of each invalid expression encountered.
+func consolidateRoleMappings(
+	mappings []kargoapi.RoleMapping,
+) (map[string]*rbacapi.RoleMappingCriteria, []string) {
+	criteriaByRole := make(map[string]*rbacapi.RoleMappingCriteria, len(mappings))
+	var conflicts []string
+	for _, mapping := range mappings {
+		criteria, ok := criteriaByRole[mapping.Role]
+		if !ok {
+			criteria = &rbacapi.RoleMappingCriteria{}
+			criteriaByRole[mapping.Role] = criteria
+		}
+		criteria.Groups = append(criteria.Groups, mapping.Groups...)
+		for _, domain := range mapping.EmailDomains {
+			criteria.EmailDomains = append(criteria.EmailDomains, strings.ToLower(domain))
+		}
+		for _, expression := range mapping.Expressions {
+			if _, err := rolemapping.CompileExpression(expression); err != nil {
+				conflicts = append(
+					conflicts,
+					fmt.Sprintf("invalid expression for Role %q: %s", mapping.Role, err),
+				)
+				continue
+			}
+			criteria.Expressions = append(criteria.Expressions, expression)
+		}
+	}
+	for _, criteria := range criteriaByRole {
+		slices.Sort(criteria.Groups)
+		criteria.Groups = slices.Compact(criteria.Groups)
+		slices.Sort(criteria.EmailDomains)
+		criteria.EmailDomains = slices.Compact(criteria.EmailDomains)
+		slices.Sort(criteria.Expressions)
+		criteria.Expressions = slices.Compact(criteria.Expressions)
+	}
+	return criteriaByRole, conflicts
+}
+
+// syncServiceAccountRoleMapping patches the role mapping annotation of the
+// provided ServiceAccount to reflect the provided criteria, removing the
+// annotation if the criteria are empty. It returns true if the ServiceAccount
+// was patched.
+func (r *reconciler) syncServiceAccountRoleMapping(
+	ctx context.Context,
+	sa *corev1.ServiceAccount,
+	criteria *rbacapi.RoleMappingCriteria,
+) (bool, error) {
+	current, hasCurrent := sa.Annotations[rbacapi.AnnotationKeyRoleMapping]
+	var desired string
+	if !criteria.IsEmpty() {
+		criteriaBytes, err := json.Marshal(criteria)
+		if err != nil {
+			return false, fmt.Errorf(
+				"error marshaling role mapping for ServiceAccount %q: %w", sa.Name, err,
+			)
+		}
+		desired = string(criteriaBytes)
+	}
+	if (desired == "" && !hasCurrent) || (desired != "" && desired == current) {
+		return false, nil
+	}
+	patch := client.MergeFrom(sa.DeepCopy())
+	if desired == "" {
+		delete(sa.Annotations, rbacapi.AnnotationKeyRoleMapping)
+	} else {
+		if sa.Annotations == nil {
+			sa.Annotations = make(map[string]string, 1)
+		}
+		sa.Annotations[rbacapi.AnnotationKeyRoleMapping] = desired
+	}
+	if err := client.IgnoreNotFound(r.client.Patch(ctx, sa, patch)); err != nil {
+		return false, fmt.Errorf(
+			"error patching role mapping of ServiceAccount %q: %w", sa.Name, err,
+		)
+	}
+	return true, nil
+}
@@ -0,0 +1,325 @@
+package projectconfigs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/conditions"
+)
+
+func TestReconciler_syncRoleMappings(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(testScheme))
+
+	const testProjectName = "fake-project"
+
+	newSA := func(name string, managed bool, annotations map[string]string) *corev1.ServiceAccount {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if managed {
+			annotations[rbacapi.AnnotationKeyManaged] = rbacapi.AnnotationValueTrue
+		}
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testProjectName,
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+
+	getRoleMapping := func(t *testing.T, c client.Client, name string) (string, bool) {
+		sa := &corev1.ServiceAccount{}
+		require.NoError(t, c.Get(
+			t.Context(),
+			client.ObjectKey{Namespace: testProjectName, Name: name},
+			sa,
+		))
+		val, ok := sa.Annotations[rbacapi.AnnotationKeyRoleMapping]
+		return val, ok
+	}
+
+	testCases := []struct {
+		name         string
+		objects      []client.Object
+		roleMappings []kargoapi.RoleMapping
+		assertions   func(*testing.T, client.Client, kargoapi.ProjectConfigStatus, error)
+	}{
+		{
+			name: "no role mappings",
+			objects: []client.Object{
+				newSA("developer", true, nil),
+			},
+			assertions: func(t *testing.T, c client.Client, status kargoapi.ProjectConfigStatus, err error) {
+				require.NoError(t, err)
+				require.Nil(t, conditions.Get(&status, kargoapi.ConditionTypeReady))
+				_, ok := getRoleMapping(t, c, "developer")
+				require.False(t, ok)
+			},
+		},
+		{
+			name: "role mappings are consolidated and applied",
+			objects: []client.Object{
+				newSA("developer", true, map[string]string{
+					rbacapi.AnnotationKeyOIDCClaims: `{"sub":["kilgore"]}`,
+				}),
+				newSA("viewer", true, map[string]string{
+					rbacapi.AnnotationKeyRoleMapping: `{"groups":["stale"]}`,
+				}),
+				newSA("unrelated", false, nil),
+			},
+			roleMappings: []kargoapi.RoleMapping{
+				{
+					Role:         "developer",
+					Groups:       []string{"devops", "admins"},
+					EmailDomains: []string{"Example.com"},
+				},
+				{
+					Role:        "developer",
+					Groups:      []string{"devops"},
+					Expressions: []string{`claims.department == "payments"`},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, status kargoapi.ProjectConfigStatus, err error) {
+				require.NoError(t, err)
+				require.Nil(t, conditions.Get(&status, kargoapi.ConditionTypeReady))
+
+				val, ok := getRoleMapping(t, c, "developer")
+				require.True(t, ok)
+				require.JSONEq(
+					t,
+					`{
+						"groups": ["admins", "devops"],
+						"emailDomains": ["example.com"],
+						"expressions": ["claims.department == \"payments\""]
+					}`,
+					val,
+				)
+				// Other annotations are left alone.
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					t.Context(),
+					client.ObjectKey{Namespace: testProjectName, Name: "developer"},
+					sa,
+				))
+				require.Equal(t, `{"sub":["kilgore"]}`, sa.Annotations[rbacapi.AnnotationKeyOIDCClaims])
+
+				// Stale role mappings are removed.
+				_, ok = getRoleMapping(t, c, "viewer")
+				require.False(t, ok)
+			},
+		},
+		{
+			name: "conflicts are reported",
+			objects: []client.Object{
+				newSA("developer", true, nil),
+				newSA("unmanaged", false, map[string]string{
+					rbacapi.AnnotationKeyRoleMapping: `{"groups":["devops"]}`,
+				}),
+			},
+			roleMappings: []kargoapi.RoleMapping{
+				{
+					Role:        "developer",
+					Groups:      []string{"devops"},
+					Expressions: []string{`claims.department ==`},
+				},
+				{
+					Role:   "unmanaged",
+					Groups: []string{"devops"},
+				},
+				{
+					Role:   "missing",
+					Groups: []string{"devops"},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, status kargoapi.ProjectConfigStatus, err error) {
+				require.NoError(t, err)
+				readyCondition := conditions.Get(&status, kargoapi.ConditionTypeReady)
+				require.NotNil(t, readyCondition)
+				require.Equal(t, metav1.ConditionFalse, readyCondition.Status)
+				require.Equal(t, "RoleMappingConflict", readyCondition.Reason)
+				require.Contains(t, readyCondition.Message, `Role "missing" does not exist`)
+				require.Contains(t, readyCondition.Message, `Role "unmanaged" is not managed by Kargo`)
+				require.Contains(t, readyCondition.Message, `invalid expression for Role "developer"`)
+
+				// Valid criteria are still applied.
+				val, ok := getRoleMapping(t, c, "developer")
+				require.True(t, ok)
+				require.JSONEq(t, `{"groups":["devops"]}`, val)
+
+				// Unmanaged ServiceAccounts are never mapped.
+				_, ok = getRoleMapping(t, c, "unmanaged")
+				require.False(t, ok)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(testCase.objects...).
+				Build()
+			r := &reconciler{client: c, apiReader: c}
+			status, err := r.syncRoleMappings(
+				t.Context(),
+				&kargoapi.ProjectConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testProjectName,
+						Name:      testProjectName,
+					},
+					Spec: kargoapi.ProjectConfigSpec{
+						RoleMappings: testCase.roleMappings,
+					},
+				},
+			)
+			testCase.assertions(t, c, status, err)
+		})
+	}
+}
+
+func TestReconciler_handleDelete(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(testScheme))
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	const testProjectName = "fake-project"
+
+	projectCfg := &kargoapi.ProjectConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         testProjectName,
+			Name:              testProjectName,
+			Finalizers:        []string{kargoapi.FinalizerName},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(
+			projectCfg,
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "developer",
+					Annotations: map[string]string{
+						rbacapi.AnnotationKeyManaged:     rbacapi.AnnotationValueTrue,
+						rbacapi.AnnotationKeyRoleMapping: `{"groups":["devops"]}`,
+						rbacapi.AnnotationKeyOIDCClaims:  `{"sub":["kilgore"]}`,
+					},
+				},
+			},
+		).
+		Build()
+
+	r := &reconciler{client: c, apiReader: c}
+	require.NoError(t, r.handleDelete(t.Context(), projectCfg))
+
+	sa := &corev1.ServiceAccount{}
+	require.NoError(t, c.Get(
+		t.Context(),
+		client.ObjectKey{Namespace: testProjectName, Name: "developer"},
+		sa,
+	))
+	// The role mapping is revoked, but other annotations are left alone.
+	require.NotContains(t, sa.Annotations, rbacapi.AnnotationKeyRoleMapping)
+	require.Equal(t, `{"sub":["kilgore"]}`, sa.Annotations[rbacapi.AnnotationKeyOIDCClaims])
+
+	// With the finalizer removed, the fake client completes the deletion.
+	err := c.Get(
+		t.Context(),
+		client.ObjectKey{Namespace: testProjectName, Name: testProjectName},
+		&kargoapi.ProjectConfig{},
+	)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func Test_projectConfigForServiceAccount(t *testing.T) {
+	require.Equal(
+		t,
+		[]reconcile.Request{{
+			NamespacedName: types.NamespacedName{
+				Namespace: "fake-project",
+				Name:      "fake-project",
+			},
+		}},
+		projectConfigForServiceAccount(
+			t.Context(),
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-project",
+					Name:      "developer",
+				},
+			},
+		),
+	)
+}
+
+func Test_serviceAccountRoleMappingChanged(t *testing.T) {
+	newSA := func(annotations map[string]string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "fake-project",
+				Name:        "developer",
+				Annotations: annotations,
+			},
+		}
+	}
+	managed := map[string]string{
+		rbacapi.AnnotationKeyManaged: rbacapi.AnnotationValueTrue,
+	}
+	mapped := map[string]string{
+		rbacapi.AnnotationKeyManaged:     rbacapi.AnnotationValueTrue,
+		rbacapi.AnnotationKeyRoleMapping: `{"groups":["devops"]}`,
+	}
+
+	p := serviceAccountRoleMappingChanged{}
+
+	t.Run("create", func(t *testing.T) {
+		require.True(t, p.Create(event.TypedCreateEvent[*corev1.ServiceAccount]{
+			Object: newSA(managed),
+		}))
+		require.False(t, p.Create(event.TypedCreateEvent[*corev1.ServiceAccount]{
+			Object: newSA(nil),
+		}))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.True(t, p.Delete(event.TypedDeleteEvent[*corev1.ServiceAccount]{
+			Object: newSA(mapped),
+		}))
+		require.False(t, p.Delete(event.TypedDeleteEvent[*corev1.ServiceAccount]{
+			Object: newSA(nil),
+		}))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		require.True(t, p.Update(event.TypedUpdateEvent[*corev1.ServiceAccount]{
+			ObjectOld: newSA(nil),
+			ObjectNew: newSA(managed),
+		}))
+		require.True(t, p.Update(event.TypedUpdateEvent[*corev1.ServiceAccount]{
+			ObjectOld: newSA(mapped),
+			ObjectNew: newSA(managed),
+		}))
+		require.False(t, p.Update(event.TypedUpdateEvent[*corev1.ServiceAccount]{
+			ObjectOld: newSA(managed),
+			ObjectNew: newSA(map[string]string{
+				rbacapi.AnnotationKeyManaged:    rbacapi.AnnotationValueTrue,
+				rbacapi.AnnotationKeyOIDCClaims: `{"sub":["kilgore"]}`,
+			}),
+		}))
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/akuity/kargo/pkg/logging"
 	intpredicate "github.com/akuity/kargo/pkg/predicate"
 	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 )
 
 // ReconcilerConfig represents configuration for the promotion reconciler.
@@ -62,6 +63,7 @@ type reconciler struct {
 	kargoClient    client.Client
 	promoEngine    promotion.Engine
 	apiReader      client.Reader
+	stepLogStore   steplog.Store
 	shardPredicate controller.ResponsibleFor[kargoapi.Promotion]
 
 	cfg ReconcilerConfig
@@ -236,11 +238,12 @@ func newReconciler(
 	cfg ReconcilerConfig,
 ) *reconciler {
 	r := &reconciler{
-		kargoClient: kargoClient,
-		apiReader:   apiReader,
-		promoEngine: promoEngine,
-		sender:      sender,
-		cfg:         cfg,
+		kargoClient:  kargoClient,
+		apiReader:    apiReader,
+		stepLogStore: steplog.NewConfigMapStore(kargoClient, apiReader),
+		promoEngine:  promoEngine,
+		sender:       sender,
+		cfg:          cfg,
 		shardPredicate: controller.ResponsibleFor[kargoapi.Promotion]{
 			IsDefaultController: cfg.IsDefaultController,
 			ShardName:           cfg.ShardName,
@@ -617,6 +620,7 @@ func (r *reconciler) promote(
 		return nil, nil, fmt.Errorf("error creating working directory: %w", err)
 	}
 	res, err := r.promoEngine.Promote(ctx, promoCtx, steps)
+	r.persistStepLogs(ctx, workingPromo, res.StepLogs)
 	workingPromo.Status.Phase = res.Status
 	workingPromo.Status.Message = res.Message
 	workingPromo.Status.CurrentStep = res.CurrentStep
@@ -669,6 +673,22 @@ func (r *reconciler) promote(
 	return &workingPromo.Status, nil, nil
 }
 
+// persistStepLogs persists output captured from the execution of the provided
+// Promotion's steps. This is best-effort. Failures are logged, but are not
+// allowed to affect the outcome of the Promotion.
+func (r *reconciler) persistStepLogs(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	chunks []steplog.Chunk,
+) {
+	logger := logging.LoggerFromContext(ctx)
+	for _, chunk := range chunks {
+		if err := r.stepLogStore.Append(ctx, promo, chunk); err != nil {
+			logger.Error(err, "error persisting step output", "step", chunk.Alias)
+		}
+	}
+}
+
 // buildTargetFreightCollection constructs a FreightCollection that contains all
 // FreightReferences from the previous Promotion (excepting those that are no
 // longer requested), plus a FreightReference for the provided targetFreight.
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,6 +23,7 @@ import (
 	k8sevent "github.com/akuity/kargo/pkg/event/kubernetes"
 	fakeevent "github.com/akuity/kargo/pkg/kubernetes/event/fake"
 	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 )
 
 var (
@@ -41,6 +43,7 @@ func TestNewPromotionReconciler(t *testing.T) {
 	require.NotNil(t, r.kargoClient)
 	require.NotNil(t, r.sender)
 	require.NotNil(t, r.promoEngine)
+	require.NotNil(t, r.stepLogStore)
 	require.NotNil(t, r.getStageFn)
 	require.NotNil(t, r.promoteFn)
 	require.NotNil(t, r.cleanupWorkDirFn)
@@ -52,6 +55,7 @@ func newFakeReconciler(
 	objects ...client.Object,
 ) *reconciler {
 	scheme := k8sruntime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
 	kargoClient := fake.NewClientBuilder().WithScheme(scheme).
 		WithObjects(objects...).WithStatusSubresource(objects...).Build()
@@ -1152,6 +1156,28 @@ func newPromo(namespace, name, stage string,
 	}
 }
 
+func Test_reconciler_persistStepLogs(t *testing.T) {
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-promotion",
+			UID:       "fake-uid",
+		},
+	}
+	r := newFakeReconciler(t, fakeevent.NewEventRecorder(0))
+	r.persistStepLogs(context.Background(), promo, []steplog.Chunk{
+		{StepIndex: 0, Alias: "step-1", Data: []byte("foo")},
+		{StepIndex: 1, Alias: "step-2", Data: []byte("bar")},
+	})
+	chunks, err := r.stepLogStore.List(context.Background(), promo)
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	require.Equal(t, "step-1", chunks[0].Alias)
+	require.Equal(t, "foo", string(chunks[0].Data))
+	require.Equal(t, "step-2", chunks[1].Alias)
+	require.Equal(t, "bar", string(chunks[1].Data))
+}
+
 func Test_buildTargetFreightCollection(t *testing.T) {
 	testCases := []struct {
 		name                      string
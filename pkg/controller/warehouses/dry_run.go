@@ -0,0 +1,269 @@
+package warehouses
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/conditions"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/subscription"
+	"github.com/akuity/kargo/pkg/subscription/explain"
+)
+
+// defaultDiscoveryLimit is the discovery limit applied to generic
+// subscriptions that do not specify one. It matches the default applied by the
+// Warehouse defaulting webhook.
+const defaultDiscoveryLimit = int32(20)
+
+// DryRunResult is the outcome of a dry run of a Warehouse's subscriptions.
+type DryRunResult struct {
+	// DiscoveredArtifacts are the artifacts the Warehouse would have discovered
+	// using the subscriptions that did not fail.
+	DiscoveredArtifacts *kargoapi.DiscoveredArtifacts `json:"discoveredArtifacts,omitempty"`
+	// Subscriptions describes the outcome of discovery for each of the
+	// Warehouse's subscriptions.
+	Subscriptions []DryRunSubscription `json:"subscriptions"`
+	// Freight is the Freight that would have been built from the discovered
+	// artifacts.
+	Freight []DryRunFreight `json:"freight,omitempty"`
+	// FreightCreationCriteriaSatisfied indicates whether the Warehouse's
+	// FreightCreationCriteria, if any, were satisfied by the discovered
+	// artifacts.
+	FreightCreationCriteriaSatisfied bool `json:"freightCreationCriteriaSatisfied"`
+	// Message is an optional message that provides additional context about
+	// why Freight would or would not have been created.
+	Message string `json:"message,omitempty"`
+} // @name WarehouseDryRunResult
+
+// DryRunSubscription describes the outcome of discovery for a single
+// subscription during a dry run.
+type DryRunSubscription struct {
+	// Name is the name of the subscription.
+	Name string `json:"name,omitempty"`
+	// Type is the type of the subscription, e.g. "git", "image" or "chart".
+	Type string `json:"type"`
+	// RepoURL is the URL of the repository the subscription is for, if the
+	// type of subscription has one.
+	RepoURL string `json:"repoURL,omitempty"`
+	// Discovered is the number of artifacts that were discovered.
+	Discovered int `json:"discovered"`
+	// Rejected lists candidate artifacts that were filtered out, along with the
+	// reason each was filtered out.
+	Rejected []explain.Rejection `json:"rejected,omitempty"`
+	// Error is set if discovery failed for the subscription.
+	Error string `json:"error,omitempty"`
+} // @name WarehouseDryRunSubscription
+
+// DryRunFreight describes Freight that would have been built during a dry
+// run.
+type DryRunFreight struct {
+	// Freight is the Freight that would have been built.
+	Freight *kargoapi.Freight `json:"freight"`
+	// Created indicates whether the Freight would actually have been created,
+	// which depends upon the Warehouse's FreightCreationPolicy and
+	// FreightCreationCriteria.
+	Created bool `json:"created"`
+} // @name WarehouseDryRunFreight
+
+// DryRun applies defaults to the provided Warehouse's subscriptions, validates
+// them, and then discovers artifacts using each of them. It reports the
+// artifacts discovered, the reasons candidate artifacts were filtered out, and
+// the Freight that would have been created from the discovered artifacts.
+// Nothing is written to the cluster. Failure to discover artifacts using any
+// one subscription is reported in the result rather than returned as an
+// error. An error is returned if the Warehouse is invalid.
+func DryRun(
+	ctx context.Context,
+	credentialsDB credentials.Database,
+	subscriberRegistry subscription.SubscriberRegistry,
+	warehouse *kargoapi.Warehouse,
+) (*DryRunResult, error) {
+	r := &reconciler{
+		credentialsDB:      credentialsDB,
+		subscriberRegistry: subscriberRegistry,
+	}
+	return r.dryRun(ctx, warehouse.DeepCopy())
+}
+
+func (r *reconciler) dryRun(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+) (*DryRunResult, error) {
+	subscribers, err := r.prepareDryRunSubscriptions(ctx, warehouse)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DryRunResult{
+		DiscoveredArtifacts: &kargoapi.DiscoveredArtifacts{
+			DiscoveredAt: metav1.Now(),
+			Charts:       []kargoapi.ChartDiscoveryResult{},
+			Git:          []kargoapi.GitDiscoveryResult{},
+			Images:       []kargoapi.ImageDiscoveryResult{},
+			Results:      []kargoapi.DiscoveryResult{},
+		},
+		Subscriptions: make([]DryRunSubscription, len(subscribers)),
+	}
+	var failed bool
+	for i, sub := range warehouse.Spec.InternalSubscriptions {
+		subRes := &res.Subscriptions[i]
+		subRes.Name = sub.Name
+		subRes.Type, subRes.RepoURL = subscriptionTypeAndRepoURL(sub)
+
+		recorder := explain.NewRecorder()
+		discovered, err := subscribers[i].DiscoverArtifacts(
+			explain.ContextWithRecorder(ctx, recorder),
+			warehouse.Namespace,
+			sub,
+			nil,
+		)
+		subRes.Rejected = recorder.Rejections()
+		if err == nil {
+			subRes.Discovered, err = appendDiscoveryResult(res.DiscoveredArtifacts, discovered)
+		}
+		if err != nil {
+			subRes.Error = err.Error()
+			failed = true
+		}
+	}
+
+	if failed {
+		res.Message = "Artifact discovery failed for one or more subscriptions"
+		return res, nil
+	}
+
+	status := kargoapi.WarehouseStatus{DiscoveredArtifacts: res.DiscoveredArtifacts}
+	if !validateDiscoveredArtifacts(warehouse, &status) {
+		if ready := conditions.Get(&status, kargoapi.ConditionTypeReady); ready != nil {
+			res.Message = ready.Message
+		}
+		return res, nil
+	}
+
+	if res.FreightCreationCriteriaSatisfied, err = freightCreationCriteriaSatisfied(
+		ctx,
+		warehouse.Spec.FreightCreationCriteria,
+		res.DiscoveredArtifacts,
+	); err != nil {
+		res.Message = fmt.Sprintf(
+			"Evaluation of Freight creation criteria failed: %s", err.Error(),
+		)
+		return res, nil
+	}
+
+	latest, err := r.buildFreightFromLatestArtifacts(warehouse.Namespace, res.DiscoveredArtifacts)
+	if err != nil {
+		res.Message = fmt.Sprintf(
+			"Error building Freight from latest artifacts: %s", err.Error(),
+		)
+		return res, nil
+	}
+	latest.Origin = kargoapi.FreightOrigin{
+		Kind: kargoapi.FreightOriginKindWarehouse,
+		Name: warehouse.Name,
+	}
+
+	perArtifactSubs := make(map[string]struct{})
+	for i, sub := range warehouse.Spec.InternalSubscriptions {
+		if s, ok := subscribers[i].(subscription.FreightPerArtifactSubscriber); ok &&
+			sub.Subscription != nil && s.FreightPerArtifact(sub) {
+			perArtifactSubs[sub.Name] = struct{}{}
+		}
+	}
+
+	automatic := warehouse.Spec.FreightCreationPolicy == kargoapi.FreightCreationPolicyAutomatic ||
+		warehouse.Spec.FreightCreationPolicy == ""
+	created := automatic && res.FreightCreationCriteriaSatisfied
+	res.Freight = append(res.Freight, DryRunFreight{Freight: latest, Created: created})
+	for _, freight := range buildFreightPerArtifact(latest, res.DiscoveredArtifacts, perArtifactSubs) {
+		res.Freight = append(res.Freight, DryRunFreight{Freight: freight, Created: created})
+	}
+
+	switch {
+	case !automatic:
+		res.Message = "Freight creation policy is Manual; Freight would not be created automatically"
+	case !res.FreightCreationCriteriaSatisfied:
+		res.Message = "Freight creation criteria were not satisfied"
+	default:
+		res.Message = "Freight creation criteria satisfied"
+	}
+	return res, nil
+}
+
+// prepareDryRunSubscriptions applies defaults to all of the provided
+// Warehouse's subscriptions, in the same manner as the Warehouse defaulting
+// webhook, and validates them. It returns a Subscriber for each subscription.
+func (r *reconciler) prepareDryRunSubscriptions(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+) ([]subscription.Subscriber, error) {
+	f := field.NewPath("spec").Child("subscriptions")
+	if len(warehouse.Spec.InternalSubscriptions) == 0 {
+		return nil, apierrors.NewInvalid(
+			warehouseGroupKind,
+			warehouse.Name,
+			field.ErrorList{field.Required(f, "at least one subscription is required")},
+		)
+	}
+	subscribers := make([]subscription.Subscriber, len(warehouse.Spec.InternalSubscriptions))
+	var errs field.ErrorList
+	for i := range warehouse.Spec.InternalSubscriptions {
+		sub := &warehouse.Spec.InternalSubscriptions[i]
+		subType, _ := subscriptionTypeAndRepoURL(*sub)
+		subPath := f.Index(i).Child(subType)
+		subReg, err := r.subscriberRegistry.Get(ctx, *sub)
+		if err != nil {
+			errs = append(errs, field.Invalid(
+				subPath,
+				"",
+				fmt.Sprintf("subscriber registry lookup failed: %v", err),
+			))
+			continue
+		}
+		// The registration's value is a factory function
+		subscriber, err := subReg.Value(ctx, r.credentialsDB)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating subscriber: %w", err)
+		}
+		if sub.Subscription != nil && sub.Subscription.DiscoveryLimit == 0 {
+			sub.Subscription.DiscoveryLimit = defaultDiscoveryLimit
+		}
+		if err = subscriber.ApplySubscriptionDefaults(ctx, sub); err != nil {
+			return nil, fmt.Errorf("error applying defaults to subscriptions: %w", err)
+		}
+		errs = append(errs, subscriber.ValidateSubscription(ctx, subPath, *sub)...)
+		subscribers[i] = subscriber
+	}
+	if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(warehouseGroupKind, warehouse.Name, errs)
+	}
+	return subscribers, nil
+}
+
+var warehouseGroupKind = schema.GroupKind{
+	Group: kargoapi.GroupVersion.Group,
+	Kind:  "Warehouse",
+}
+
+// subscriptionTypeAndRepoURL returns the type of the provided subscription and,
+// if the type of subscription has one, the URL of the repository it is for.
+func subscriptionTypeAndRepoURL(sub kargoapi.RepoSubscription) (string, string) {
+	switch {
+	case sub.Git != nil:
+		return "git", sub.Git.RepoURL
+	case sub.Image != nil:
+		return "image", sub.Image.RepoURL
+	case sub.Chart != nil:
+		return "chart", sub.Chart.RepoURL
+	case sub.Subscription != nil:
+		return sub.Subscription.SubscriptionType, ""
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,127 @@
+package warehouses
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDryRunResult renders the provided DryRunResult to the provided
+// io.Writer in a human-readable format.
+func WriteDryRunResult(w io.Writer, res *DryRunResult) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Subscriptions:\n")
+	for i, sub := range res.Subscriptions {
+		printf("  %d. %s", i+1, sub.Type)
+		if sub.Name != "" {
+			printf(" %q", sub.Name)
+		}
+		if sub.RepoURL != "" {
+			printf(" %s", sub.RepoURL)
+		}
+		switch {
+		case sub.Error != "":
+			printf(": failed\n")
+			printf("     ! %s\n", sub.Error)
+		default:
+			printf(": %d discovered, %d filtered out\n", sub.Discovered, len(sub.Rejected))
+		}
+		for _, rejection := range sub.Rejected {
+			printf("     - %s: %s\n", rejection.Candidate, rejection.Reason)
+		}
+	}
+
+	if artifacts := res.DiscoveredArtifacts; artifacts != nil {
+		printf("\nDiscovered artifacts:\n")
+		for _, result := range artifacts.Git {
+			printf("  %s:\n", result.RepoURL)
+			for _, commit := range result.Commits {
+				printf("    %s", shortCommitID(commit.ID))
+				if commit.Tag != "" {
+					printf(" (tag %s)", commit.Tag)
+				} else if commit.Branch != "" {
+					printf(" (branch %s)", commit.Branch)
+				}
+				if commit.Subject != "" {
+					printf(" %s", commit.Subject)
+				}
+				printf("\n")
+			}
+		}
+		for _, result := range artifacts.Images {
+			printf("  %s:\n", result.RepoURL)
+			for _, ref := range result.References {
+				printf("    %s", ref.Tag)
+				if ref.Digest != "" {
+					printf(" (%s)", ref.Digest)
+				}
+				printf("\n")
+			}
+		}
+		for _, result := range artifacts.Charts {
+			printf("  %s:\n", chartName(result.RepoURL, result.Name))
+			for _, version := range result.Versions {
+				printf("    %s\n", version)
+			}
+		}
+		for _, result := range artifacts.Results {
+			printf("  %s:\n", result.SubscriptionName)
+			for _, ref := range result.ArtifactReferences {
+				printf("    %s\n", ref.Version)
+			}
+		}
+	}
+
+	if len(res.Freight) > 0 {
+		printf("\nFreight:\n")
+		for _, f := range res.Freight {
+			if f.Created {
+				printf("  %s (would be created)\n", f.Freight.Name)
+			} else {
+				printf("  %s (would not be created)\n", f.Freight.Name)
+			}
+			for _, commit := range f.Freight.Commits {
+				printf("    %s@%s\n", commit.RepoURL, shortCommitID(commit.ID))
+			}
+			for _, image := range f.Freight.Images {
+				printf("    %s:%s\n", image.RepoURL, image.Tag)
+			}
+			for _, chart := range f.Freight.Charts {
+				printf("    %s:%s\n", chartName(chart.RepoURL, chart.Name), chart.Version)
+			}
+			for _, artifact := range f.Freight.Artifacts {
+				printf("    %s: %s\n", artifact.SubscriptionName, artifact.Version)
+			}
+		}
+	}
+
+	if res.Message != "" {
+		printf("\n%s\n", res.Message)
+	}
+
+	return err
+}
+
+// shortCommitID returns the abbreviated form of the provided commit ID.
+func shortCommitID(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+// chartName returns a string identifying the chart with the provided name in
+// the repository with the provided URL. For OCI repositories, the name is
+// already part of the URL.
+func chartName(repoURL, name string) string {
+	if name == "" || strings.HasPrefix(repoURL, "oci://") {
+		return repoURL
+	}
+	return strings.TrimSuffix(repoURL, "/") + "/" + name
+}
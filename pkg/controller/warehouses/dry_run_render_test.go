@@ -0,0 +1,110 @@
+package warehouses
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/subscription/explain"
+)
+
+func TestWriteDryRunResult(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteDryRunResult(buf, &DryRunResult{
+		DiscoveredArtifacts: &kargoapi.DiscoveredArtifacts{
+			Git: []kargoapi.GitDiscoveryResult{{
+				RepoURL: "https://github.com/example/repo",
+				Commits: []kargoapi.DiscoveredCommit{{
+					ID:      "abcdef0123456789",
+					Tag:     "v1.0.0",
+					Subject: "Release v1.0.0",
+				}},
+			}},
+			Images: []kargoapi.ImageDiscoveryResult{{
+				RepoURL: "example/image",
+				References: []kargoapi.DiscoveredImageReference{
+					{Tag: "v1.1.0", Digest: "sha256:1"},
+					{Tag: "v1.0.0"},
+				},
+			}},
+			Charts: []kargoapi.ChartDiscoveryResult{{
+				RepoURL:  "https://charts.example.com/",
+				Name:     "chart",
+				Versions: []string{"1.0.0"},
+			}},
+			Results: []kargoapi.DiscoveryResult{{
+				SubscriptionName: "prs",
+				ArtifactReferences: []kargoapi.ArtifactReference{
+					{SubscriptionName: "prs", Version: "pr-1@abc"},
+				},
+			}},
+		},
+		Subscriptions: []DryRunSubscription{
+			{
+				Type:       "git",
+				RepoURL:    "https://github.com/example/repo",
+				Discovered: 1,
+				Rejected: []explain.Rejection{
+					{Candidate: "v0.1.0", Reason: `tag does not satisfy semverConstraint "^1.0.0"`},
+				},
+			},
+			{Type: "image", RepoURL: "example/image", Discovered: 2},
+			{Type: "chart", RepoURL: "https://charts.example.com/", Discovered: 1},
+			{Name: "prs", Type: "pullrequest", Discovered: 1},
+			{Name: "broken", Type: "http", Error: "something went wrong"},
+		},
+		Freight: []DryRunFreight{{
+			Freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+				Commits: []kargoapi.GitCommit{{
+					RepoURL: "https://github.com/example/repo",
+					ID:      "abcdef0123456789",
+				}},
+				Images: []kargoapi.Image{{RepoURL: "example/image", Tag: "v1.1.0"}},
+				Charts: []kargoapi.Chart{{
+					RepoURL: "https://charts.example.com/",
+					Name:    "chart",
+					Version: "1.0.0",
+				}},
+				Artifacts: []kargoapi.ArtifactReference{
+					{SubscriptionName: "prs", Version: "pr-1@abc"},
+				},
+			},
+			Created: true,
+		}},
+		FreightCreationCriteriaSatisfied: true,
+		Message:                          "Freight creation criteria satisfied",
+	}))
+	require.Equal(t, `Subscriptions:
+  1. git https://github.com/example/repo: 1 discovered, 1 filtered out
+     - v0.1.0: tag does not satisfy semverConstraint "^1.0.0"
+  2. image example/image: 2 discovered, 0 filtered out
+  3. chart https://charts.example.com/: 1 discovered, 0 filtered out
+  4. pullrequest "prs": 1 discovered, 0 filtered out
+  5. http "broken": failed
+     ! something went wrong
+
+Discovered artifacts:
+  https://github.com/example/repo:
+    abcdef0 (tag v1.0.0) Release v1.0.0
+  example/image:
+    v1.1.0 (sha256:1)
+    v1.0.0
+  https://charts.example.com/chart:
+    1.0.0
+  prs:
+    pr-1@abc
+
+Freight:
+  fake-freight (would be created)
+    https://github.com/example/repo@abcdef0
+    example/image:v1.1.0
+    https://charts.example.com/chart:1.0.0
+    prs: pr-1@abc
+
+Freight creation criteria satisfied
+`, buf.String())
+}
@@ -0,0 +1,262 @@
+package warehouses
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/subscription"
+	"github.com/akuity/kargo/pkg/subscription/explain"
+)
+
+func TestDryRun(t *testing.T) {
+	newRegistry := func(subscriber *subscription.MockSubscriber) subscription.SubscriberRegistry {
+		return subscription.MustNewSubscriberRegistry(
+			subscription.SubscriberRegistration{
+				Predicate: func(context.Context, kargoapi.RepoSubscription) (bool, error) {
+					return true, nil
+				},
+				Value: func(context.Context, credentials.Database) (subscription.Subscriber, error) {
+					return subscriber, nil
+				},
+			},
+		)
+	}
+	noopDefaults := func(context.Context, *kargoapi.RepoSubscription) error { return nil }
+	noopValidate := func(context.Context, *field.Path, kargoapi.RepoSubscription) field.ErrorList {
+		return nil
+	}
+	discoverImages := func(
+		ctx context.Context,
+		project string,
+		sub kargoapi.RepoSubscription,
+		_ any,
+	) (any, error) {
+		if project != "fake-project" {
+			return nil, errors.New("unexpected project")
+		}
+		explain.Reject(ctx, "v0.1.0", "tag does not satisfy semverConstraint")
+		return kargoapi.ImageDiscoveryResult{
+			RepoURL: sub.Image.RepoURL,
+			References: []kargoapi.DiscoveredImageReference{
+				{Tag: "v1.1.0"},
+				{Tag: "v1.0.0"},
+			},
+		}, nil
+	}
+	newWarehouse := func() *kargoapi.Warehouse {
+		return &kargoapi.Warehouse{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "fake-warehouse",
+			},
+			Spec: kargoapi.WarehouseSpec{
+				InternalSubscriptions: []kargoapi.RepoSubscription{{
+					Image: &kargoapi.ImageSubscription{RepoURL: "example/image"},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		subscriber *subscription.MockSubscriber
+		warehouse  func() *kargoapi.Warehouse
+		assertions func(*testing.T, *DryRunResult, error)
+	}{
+		{
+			name:       "no subscriptions",
+			subscriber: &subscription.MockSubscriber{},
+			warehouse: func() *kargoapi.Warehouse {
+				w := newWarehouse()
+				w.Spec.InternalSubscriptions = nil
+				return w
+			},
+			assertions: func(t *testing.T, _ *DryRunResult, err error) {
+				require.True(t, apierrors.IsInvalid(err))
+				require.ErrorContains(t, err, "at least one subscription is required")
+			},
+		},
+		{
+			name: "invalid subscription",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn: func(
+					_ context.Context,
+					f *field.Path,
+					_ kargoapi.RepoSubscription,
+				) field.ErrorList {
+					return field.ErrorList{field.Invalid(f.Child("semverConstraint"), "~>", "bogus")}
+				},
+			},
+			warehouse: newWarehouse,
+			assertions: func(t *testing.T, _ *DryRunResult, err error) {
+				require.True(t, apierrors.IsInvalid(err))
+				require.ErrorContains(t, err, "spec.subscriptions[0].image.semverConstraint")
+			},
+		},
+		{
+			name: "discovery fails",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn:      noopValidate,
+				DiscoverArtifactsFn: func(
+					ctx context.Context,
+					_ string,
+					_ kargoapi.RepoSubscription,
+					_ any,
+				) (any, error) {
+					explain.Reject(ctx, "v0.1.0", "some reason")
+					return nil, errors.New("something went wrong")
+				},
+			},
+			warehouse: newWarehouse,
+			assertions: func(t *testing.T, res *DryRunResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Subscriptions, 1)
+				require.Equal(t, "something went wrong", res.Subscriptions[0].Error)
+				require.Len(t, res.Subscriptions[0].Rejected, 1)
+				require.Empty(t, res.Freight)
+				require.Contains(t, res.Message, "Artifact discovery failed")
+			},
+		},
+		{
+			name: "criteria not satisfied",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn:      noopValidate,
+				DiscoverArtifactsFn:         discoverImages,
+			},
+			warehouse: func() *kargoapi.Warehouse {
+				w := newWarehouse()
+				w.Spec.FreightCreationCriteria = &kargoapi.FreightCreationCriteria{
+					Expression: `imageFrom("example/image").Tag == "v2.0.0"`,
+				}
+				return w
+			},
+			assertions: func(t *testing.T, res *DryRunResult, err error) {
+				require.NoError(t, err)
+				require.False(t, res.FreightCreationCriteriaSatisfied)
+				require.Len(t, res.Freight, 1)
+				require.False(t, res.Freight[0].Created)
+				require.Equal(t, "Freight creation criteria were not satisfied", res.Message)
+			},
+		},
+		{
+			name: "success",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn:      noopValidate,
+				DiscoverArtifactsFn:         discoverImages,
+			},
+			warehouse: func() *kargoapi.Warehouse {
+				w := newWarehouse()
+				w.Spec.FreightCreationCriteria = &kargoapi.FreightCreationCriteria{
+					Expression: `imageFrom("example/image").Tag == "v1.1.0"`,
+				}
+				return w
+			},
+			assertions: func(t *testing.T, res *DryRunResult, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]DryRunSubscription{{
+						Type:       "image",
+						RepoURL:    "example/image",
+						Discovered: 2,
+						Rejected: []explain.Rejection{{
+							Candidate: "v0.1.0",
+							Reason:    "tag does not satisfy semverConstraint",
+						}},
+					}},
+					res.Subscriptions,
+				)
+				require.Len(t, res.DiscoveredArtifacts.Images, 1)
+				require.True(t, res.FreightCreationCriteriaSatisfied)
+				require.Len(t, res.Freight, 1)
+				require.True(t, res.Freight[0].Created)
+				freight := res.Freight[0].Freight
+				require.Equal(t, "fake-project", freight.Namespace)
+				require.NotEmpty(t, freight.Name)
+				require.Equal(t, "fake-warehouse", freight.Origin.Name)
+				require.Equal(t, "v1.1.0", freight.Images[0].Tag)
+			},
+		},
+		{
+			name: "manual freight creation policy",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn:      noopValidate,
+				DiscoverArtifactsFn:         discoverImages,
+			},
+			warehouse: func() *kargoapi.Warehouse {
+				w := newWarehouse()
+				w.Spec.FreightCreationPolicy = kargoapi.FreightCreationPolicyManual
+				return w
+			},
+			assertions: func(t *testing.T, res *DryRunResult, err error) {
+				require.NoError(t, err)
+				require.True(t, res.FreightCreationCriteriaSatisfied)
+				require.Len(t, res.Freight, 1)
+				require.False(t, res.Freight[0].Created)
+				require.Contains(t, res.Message, "Manual")
+			},
+		},
+		{
+			name: "freight per artifact",
+			subscriber: &subscription.MockSubscriber{
+				ApplySubscriptionDefaultsFn: noopDefaults,
+				ValidateSubscriptionFn:      noopValidate,
+				DiscoverArtifactsFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscription,
+					any,
+				) (any, error) {
+					return kargoapi.DiscoveryResult{
+						SubscriptionName: "prs",
+						ArtifactReferences: []kargoapi.ArtifactReference{
+							{ArtifactType: "pullrequest", SubscriptionName: "prs", Version: "pr-2@b"},
+							{ArtifactType: "pullrequest", SubscriptionName: "prs", Version: "pr-1@a"},
+						},
+					}, nil
+				},
+				FreightPerArtifactFn: func(kargoapi.RepoSubscription) bool { return true },
+			},
+			warehouse: func() *kargoapi.Warehouse {
+				w := newWarehouse()
+				w.Spec.InternalSubscriptions = []kargoapi.RepoSubscription{{
+					Name: "prs",
+					Subscription: &kargoapi.Subscription{
+						SubscriptionType: "pullrequest",
+						Config:           &apiextensionsv1.JSON{Raw: []byte(`{}`)},
+					},
+				}}
+				return w
+			},
+			assertions: func(t *testing.T, res *DryRunResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "pullrequest", res.Subscriptions[0].Type)
+				require.Len(t, res.Freight, 2)
+				require.Equal(t, "pr-2@b", res.Freight[0].Freight.Artifacts[0].Version)
+				require.Equal(t, "pr-1@a", res.Freight[1].Freight.Artifacts[0].Version)
+				require.True(t, res.Freight[1].Created)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			warehouse := testCase.warehouse()
+			res, err := DryRun(t.Context(), nil, newRegistry(testCase.subscriber), warehouse)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/akuity/kargo/pkg/logging"
 	intpredicate "github.com/akuity/kargo/pkg/predicate"
 	"github.com/akuity/kargo/pkg/subscription"
+	"github.com/akuity/kargo/pkg/throttle"
 )
 
 type ReconcilerConfig struct {
@@ -179,8 +181,19 @@ func (r *reconciler) Reconcile(
 		return ctrl.Result{}, nil
 	}
 
+	var requeueAfter time.Duration
 	newStatus, err := r.syncWarehouse(ctx, warehouse)
-	if err != nil {
+	if throttledErr := (*throttle.ThrottledError)(nil); errors.As(err, &throttledErr) {
+		// Being throttled is not a failure. Rather than backing off
+		// progressively, retry as soon as the pause has elapsed.
+		logger.Info(
+			"artifact discovery throttled",
+			"host", throttledErr.Host,
+			"until", throttledErr.Until,
+		)
+		requeueAfter = max(time.Until(throttledErr.Until), time.Second)
+		err = nil
+	} else if err != nil {
 		logger.Error(err, "error syncing Warehouse")
 	}
 
@@ -209,6 +222,10 @@ func (r *reconciler) Reconcile(
 		return ctrl.Result{}, err
 	}
 
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// Everything succeeded, look for new changes on the defined interval.
 	return ctrl.Result{
 		RequeueAfter: warehouse.GetInterval(r.cfg.MinReconciliationInterval),
@@ -281,6 +298,31 @@ func (r *reconciler) syncWarehouse(
 			warehouse.Spec.InternalSubscriptions,
 			lastArtifacts,
 		)
+		if throttledErr := (*throttle.ThrottledError)(nil); errors.As(err, &throttledErr) {
+			// An upstream registry or repository asked for requests to be
+			// paused. This is not a failure, so the Warehouse is not marked as
+			// unhealthy. Discovery remains in progress until the pause has
+			// elapsed.
+			conditions.Set(
+				&status,
+				&metav1.Condition{
+					Type:               kargoapi.ConditionTypeThrottled,
+					Status:             metav1.ConditionTrue,
+					Reason:             "RateLimited",
+					Message:            fmt.Sprintf("Artifact discovery deferred: %s", throttledErr.Error()),
+					ObservedGeneration: warehouse.GetGeneration(),
+				},
+				&metav1.Condition{
+					Type:               kargoapi.ConditionTypeReady,
+					Status:             metav1.ConditionFalse,
+					Reason:             "DiscoveryThrottled",
+					Message:            "Waiting for rate limits to permit artifact discovery",
+					ObservedGeneration: warehouse.GetGeneration(),
+				},
+			)
+			return status, fmt.Errorf("error discovering artifacts: %w", err)
+		}
+		conditions.Delete(&status, kargoapi.ConditionTypeThrottled)
 		if err != nil {
 			// Mark the Warehouse as unhealthy and not ready if we failed to
 			// discover artifacts.
@@ -520,6 +562,38 @@ func (r *reconciler) syncWarehouse(
 				}
 			}
 
+			// Some subscribers surface each artifact they discover, and not only
+			// the latest, as Freight of its own.
+			if err = r.createFreightPerArtifact(
+				ctx,
+				warehouse,
+				freight,
+				status.DiscoveredArtifacts,
+			); err != nil {
+				msg := fmt.Sprintf(
+					"Error creating Freight per discovered artifact: %s",
+					err.Error(),
+				)
+				conditions.Set(
+					&status,
+					&metav1.Condition{
+						Type:               kargoapi.ConditionTypeHealthy,
+						Status:             metav1.ConditionFalse,
+						Reason:             "FreightBuildFailure",
+						Message:            msg,
+						ObservedGeneration: warehouse.GetGeneration(),
+					},
+					&metav1.Condition{
+						Type:               kargoapi.ConditionTypeReady,
+						Status:             metav1.ConditionFalse,
+						Reason:             "FreightCreationFailure",
+						Message:            msg,
+						ObservedGeneration: warehouse.GetGeneration(),
+					},
+				)
+				return status, fmt.Errorf("error creating Freight per discovered artifact: %w", err)
+			}
+
 			status.LastFreightID = freight.Name
 		}
 	}
@@ -606,25 +680,42 @@ func (r *reconciler) discoverArtifacts(
 		if err != nil {
 			return nil, fmt.Errorf("error discovering artifacts: %w", err)
 		}
-		switch typedRes := res.(type) {
-		case kargoapi.ChartDiscoveryResult:
-			discovered.Charts = append(discovered.Charts, typedRes)
-		case kargoapi.GitDiscoveryResult:
-			discovered.Git = append(discovered.Git, typedRes)
-		case kargoapi.ImageDiscoveryResult:
-			discovered.Images = append(discovered.Images, typedRes)
-		case kargoapi.DiscoveryResult:
-			discovered.Results = append(discovered.Results, typedRes)
-		default:
-			return nil, fmt.Errorf(
-				"subscriber returned unrecognized result type %T", typedRes,
-			)
+		if _, err = appendDiscoveryResult(discovered, res); err != nil {
+			return nil, err
 		}
 	}
 	discovered.DiscoveredAt = metav1.Now()
 	return discovered, nil
 }
 
+// appendDiscoveryResult appends the result returned by a Subscriber to the
+// appropriate field of the provided kargoapi.DiscoveredArtifacts and returns
+// the number of artifacts it contains. An error is returned if the result is of
+// an unrecognized type.
+func appendDiscoveryResult(
+	discovered *kargoapi.DiscoveredArtifacts,
+	res any,
+) (int, error) {
+	switch typedRes := res.(type) {
+	case kargoapi.ChartDiscoveryResult:
+		discovered.Charts = append(discovered.Charts, typedRes)
+		return len(typedRes.Versions), nil
+	case kargoapi.GitDiscoveryResult:
+		discovered.Git = append(discovered.Git, typedRes)
+		return len(typedRes.Commits), nil
+	case kargoapi.ImageDiscoveryResult:
+		discovered.Images = append(discovered.Images, typedRes)
+		return len(typedRes.References), nil
+	case kargoapi.DiscoveryResult:
+		discovered.Results = append(discovered.Results, typedRes)
+		return len(typedRes.ArtifactReferences), nil
+	default:
+		return 0, fmt.Errorf(
+			"subscriber returned unrecognized result type %T", typedRes,
+		)
+	}
+}
+
 func (r *reconciler) buildFreightFromLatestArtifacts(
 	namespace string,
 	artifacts *kargoapi.DiscoveredArtifacts,
@@ -703,6 +794,115 @@ func (r *reconciler) buildFreightFromLatestArtifacts(
 	return freight, nil
 }
 
+// createFreightPerArtifact creates, for each generic subscription whose
+// Subscriber is a subscription.FreightPerArtifactSubscriber asking for it,
+// Freight for each discovered artifact other than the latest one (which is
+// already part of the provided Freight built from the latest artifacts). Each
+// such Freight is identical to the provided one, except for the artifact
+// discovered by that subscription. Freight that already exists is left alone.
+func (r *reconciler) createFreightPerArtifact(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+	latest *kargoapi.Freight,
+	artifacts *kargoapi.DiscoveredArtifacts,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	subs := make(map[string]struct{})
+	for _, sub := range warehouse.Spec.InternalSubscriptions {
+		if sub.Subscription == nil {
+			continue
+		}
+		subReg, err := r.subscriberRegistry.Get(ctx, sub)
+		if err != nil {
+			return fmt.Errorf("error finding subscriber for subscription: %w", err)
+		}
+		subscriber, err := subReg.Value(ctx, r.credentialsDB)
+		if err != nil {
+			return fmt.Errorf("error instantiating subscriber: %w", err)
+		}
+		if s, ok := subscriber.(subscription.FreightPerArtifactSubscriber); ok &&
+			s.FreightPerArtifact(sub) {
+			subs[sub.Name] = struct{}{}
+		}
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	for _, freight := range buildFreightPerArtifact(latest, artifacts, subs) {
+		if err := r.createFreightFn(ctx, freight); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return fmt.Errorf(
+				"error creating Freight %q in namespace %q: %w",
+				freight.Name,
+				freight.Namespace,
+				err,
+			)
+		}
+		logger.Debug(
+			"created Freight",
+			"freight", freight.Name,
+			"namespace", freight.Namespace,
+		)
+		if r.eventSender != nil {
+			evt := kargoEvent.NewFreightCreated(
+				"Freight created from discovered artifacts",
+				api.FormatEventControllerActor(r.cfg.Name()),
+				freight,
+			)
+			if err := r.eventSender.Send(ctx, evt); err != nil {
+				logger.Error(err, "failed to send FreightCreated event",
+					"freight", freight.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// buildFreightPerArtifact builds, for each of the discovery results of the
+// named subscriptions, Freight for every discovered artifact other than the
+// latest one. Each such Freight is a copy of the provided Freight built from
+// the latest artifacts, in which only the artifact discovered by that
+// subscription is replaced.
+func buildFreightPerArtifact(
+	latest *kargoapi.Freight,
+	artifacts *kargoapi.DiscoveredArtifacts,
+	subs map[string]struct{},
+) []*kargoapi.Freight {
+	if latest == nil || artifacts == nil {
+		return nil
+	}
+	var freight []*kargoapi.Freight
+	for _, result := range artifacts.Results {
+		if _, ok := subs[result.SubscriptionName]; !ok {
+			continue
+		}
+		idx := slices.IndexFunc(
+			latest.Artifacts,
+			func(ref kargoapi.ArtifactReference) bool {
+				return ref.SubscriptionName == result.SubscriptionName
+			},
+		)
+		if idx < 0 || len(result.ArtifactReferences) < 2 {
+			continue
+		}
+		for _, ref := range result.ArtifactReferences[1:] {
+			f := latest.DeepCopy()
+			// Start from pristine object metadata, since the Freight built from
+			// the latest artifacts may have been populated by the API server.
+			f.ObjectMeta = metav1.ObjectMeta{Namespace: latest.Namespace}
+			f.Status = kargoapi.FreightStatus{}
+			f.Artifacts[idx] = *ref.DeepCopy()
+			f.Name = api.GenerateFreightID(f)
+			freight = append(freight, f)
+		}
+	}
+	return freight
+}
+
 func (r *reconciler) patchStatus(
 	ctx context.Context,
 	warehouse *kargoapi.Warehouse,
@@ -3,6 +3,7 @@ package warehouses
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
 	"github.com/akuity/kargo/pkg/conditions"
 	"github.com/akuity/kargo/pkg/controller"
 	"github.com/akuity/kargo/pkg/credentials"
@@ -26,6 +28,7 @@ import (
 	fakeevent "github.com/akuity/kargo/pkg/kubernetes/event/fake"
 	"github.com/akuity/kargo/pkg/logging"
 	"github.com/akuity/kargo/pkg/subscription"
+	"github.com/akuity/kargo/pkg/throttle"
 )
 
 type testEventSender struct {
@@ -154,6 +157,93 @@ func TestSyncWarehouse(t *testing.T) {
 			},
 		},
 
+		{
+			name: "discovery throttled",
+			reconciler: &reconciler{
+				discoverArtifactsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+					*kargoapi.DiscoveredArtifacts,
+				) (*kargoapi.DiscoveredArtifacts, error) {
+					return nil, fmt.Errorf(
+						"error listing tags: %w",
+						&throttle.ThrottledError{Host: "registry.example.com", Until: time.Now().Add(time.Minute)},
+					)
+				},
+				patchStatusFn: func(context.Context, *kargoapi.Warehouse, func(*kargoapi.WarehouseStatus)) error {
+					return nil
+				},
+			},
+			warehouse: &kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status: kargoapi.WarehouseStatus{
+					DiscoveredArtifacts: &kargoapi.DiscoveredArtifacts{},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.WarehouseStatus, err error) {
+				var throttledErr *throttle.ThrottledError
+				require.ErrorAs(t, err, &throttledErr)
+
+				// Ensure previous discovered artifacts are preserved.
+				require.NotNil(t, status.DiscoveredArtifacts)
+
+				require.Len(t, status.GetConditions(), 4)
+
+				// Ensure that the Throttled condition is set to True.
+				throttledCondition := conditions.Get(&status, kargoapi.ConditionTypeThrottled)
+				require.NotNil(t, throttledCondition)
+				require.Equal(t, metav1.ConditionTrue, throttledCondition.Status)
+				require.Equal(t, "RateLimited", throttledCondition.Reason)
+				require.Contains(t, throttledCondition.Message, "registry.example.com")
+
+				// Ensure that the Ready condition is set to False.
+				readyCondition := conditions.Get(&status, kargoapi.ConditionTypeReady)
+				require.NotNil(t, readyCondition)
+				require.Equal(t, metav1.ConditionFalse, readyCondition.Status)
+				require.Equal(t, "DiscoveryThrottled", readyCondition.Reason)
+
+				// Ensure that the Warehouse is not marked as unhealthy.
+				healthyCondition := conditions.Get(&status, kargoapi.ConditionTypeHealthy)
+				require.NotNil(t, healthyCondition)
+				require.Equal(t, metav1.ConditionUnknown, healthyCondition.Status)
+
+				// Ensure that the Reconciling condition is still set to True.
+				reconcilingCondition := conditions.Get(&status, kargoapi.ConditionTypeReconciling)
+				require.NotNil(t, reconcilingCondition)
+				require.Equal(t, metav1.ConditionTrue, reconcilingCondition.Status)
+			},
+		},
+
+		{
+			name: "throttled condition is cleared after discovery",
+			reconciler: &reconciler{
+				discoverArtifactsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+					*kargoapi.DiscoveredArtifacts,
+				) (*kargoapi.DiscoveredArtifacts, error) {
+					return nil, errors.New("something went wrong")
+				},
+				patchStatusFn: func(context.Context, *kargoapi.Warehouse, func(*kargoapi.WarehouseStatus)) error {
+					return nil
+				},
+			},
+			warehouse: &kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status: kargoapi.WarehouseStatus{
+					Conditions: []metav1.Condition{{
+						Type:   kargoapi.ConditionTypeThrottled,
+						Status: metav1.ConditionTrue,
+						Reason: "RateLimited",
+					}},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.WarehouseStatus, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Nil(t, conditions.Get(&status, kargoapi.ConditionTypeThrottled))
+			},
+		},
+
 		{
 			name: "validation error discovered artifacts",
 			reconciler: &reconciler{
@@ -1308,6 +1398,169 @@ func TestBuildFreightFromLatestArtifacts(t *testing.T) {
 	}
 }
 
+func Test_buildFreightPerArtifact(t *testing.T) {
+	latest := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "fake-namespace",
+			Name:            "fake-freight",
+			ResourceVersion: "1",
+		},
+		Origin: kargoapi.FreightOrigin{
+			Kind: kargoapi.FreightOriginKindWarehouse,
+			Name: "fake-warehouse",
+		},
+		Images: []kargoapi.Image{{RepoURL: "fake-image", Tag: "v1.0.0"}},
+		Artifacts: []kargoapi.ArtifactReference{
+			{SubscriptionName: "other", Version: "v1"},
+			{SubscriptionName: "prs", Version: "pr-3"},
+		},
+	}
+	artifacts := &kargoapi.DiscoveredArtifacts{
+		Results: []kargoapi.DiscoveryResult{
+			{
+				SubscriptionName: "other",
+				ArtifactReferences: []kargoapi.ArtifactReference{
+					{SubscriptionName: "other", Version: "v1"},
+					{SubscriptionName: "other", Version: "v0"},
+				},
+			},
+			{
+				SubscriptionName: "prs",
+				ArtifactReferences: []kargoapi.ArtifactReference{
+					{SubscriptionName: "prs", Version: "pr-3"},
+					{SubscriptionName: "prs", Version: "pr-2"},
+					{SubscriptionName: "prs", Version: "pr-1"},
+				},
+			},
+		},
+	}
+
+	require.Empty(t, buildFreightPerArtifact(latest, nil, map[string]struct{}{"prs": {}}))
+	require.Empty(t, buildFreightPerArtifact(latest, artifacts, map[string]struct{}{}))
+
+	freight := buildFreightPerArtifact(latest, artifacts, map[string]struct{}{"prs": {}})
+	require.Len(t, freight, 2)
+	for i, version := range []string{"pr-2", "pr-1"} {
+		f := freight[i]
+		require.Equal(t, "fake-namespace", f.Namespace)
+		require.Empty(t, f.ResourceVersion)
+		require.Equal(t, latest.Origin, f.Origin)
+		require.Equal(t, latest.Images, f.Images)
+		require.Equal(t, []kargoapi.ArtifactReference{
+			{SubscriptionName: "other", Version: "v1"},
+			{SubscriptionName: "prs", Version: version},
+		}, f.Artifacts)
+		require.Equal(t, api.GenerateFreightID(f), f.Name)
+		require.NotEqual(t, latest.Name, f.Name)
+	}
+	// The Freight built from the latest artifacts must not have been modified.
+	require.Equal(t, "pr-3", latest.Artifacts[1].Version)
+}
+
+func Test_createFreightPerArtifact(t *testing.T) {
+	warehouse := &kargoapi.Warehouse{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-namespace",
+			Name:      "fake-warehouse",
+		},
+		Spec: kargoapi.WarehouseSpec{
+			InternalSubscriptions: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{RepoURL: "fake-image"}},
+				{
+					Name:         "prs",
+					Subscription: &kargoapi.Subscription{SubscriptionType: "fake"},
+				},
+			},
+		},
+	}
+	latest := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fake-namespace"},
+		Artifacts: []kargoapi.ArtifactReference{
+			{SubscriptionName: "prs", Version: "pr-2"},
+		},
+	}
+	artifacts := &kargoapi.DiscoveredArtifacts{
+		Results: []kargoapi.DiscoveryResult{{
+			SubscriptionName: "prs",
+			ArtifactReferences: []kargoapi.ArtifactReference{
+				{SubscriptionName: "prs", Version: "pr-2"},
+				{SubscriptionName: "prs", Version: "pr-1"},
+			},
+		}},
+	}
+	newRegistry := func(freightPerArtifact bool) subscription.SubscriberRegistry {
+		return subscription.MustNewSubscriberRegistry(
+			subscription.SubscriberRegistration{
+				Predicate: func(
+					_ context.Context,
+					sub kargoapi.RepoSubscription,
+				) (bool, error) {
+					return sub.Subscription != nil, nil
+				},
+				Value: func(
+					context.Context,
+					credentials.Database,
+				) (subscription.Subscriber, error) {
+					return &subscription.MockSubscriber{
+						FreightPerArtifactFn: func(sub kargoapi.RepoSubscription) bool {
+							require.Equal(t, "prs", sub.Name)
+							return freightPerArtifact
+						},
+					}, nil
+				},
+			},
+		)
+	}
+
+	t.Run("subscriber does not ask for Freight per artifact", func(t *testing.T) {
+		r := &reconciler{
+			subscriberRegistry: newRegistry(false),
+			createFreightFn: func(context.Context, client.Object, ...client.CreateOption) error {
+				require.Fail(t, "no Freight should have been created")
+				return nil
+			},
+		}
+		require.NoError(t, r.createFreightPerArtifact(t.Context(), warehouse, latest, artifacts))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var created []string
+		r := &reconciler{
+			subscriberRegistry: newRegistry(true),
+			createFreightFn: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				freight, ok := obj.(*kargoapi.Freight)
+				require.True(t, ok)
+				created = append(created, freight.Artifacts[0].Version)
+				return nil
+			},
+		}
+		require.NoError(t, r.createFreightPerArtifact(t.Context(), warehouse, latest, artifacts))
+		require.Equal(t, []string{"pr-1"}, created)
+	})
+
+	t.Run("Freight already exists", func(t *testing.T) {
+		r := &reconciler{
+			subscriberRegistry: newRegistry(true),
+			createFreightFn: func(context.Context, client.Object, ...client.CreateOption) error {
+				return apierrors.NewAlreadyExists(schema.GroupResource{}, "")
+			},
+		}
+		require.NoError(t, r.createFreightPerArtifact(t.Context(), warehouse, latest, artifacts))
+	})
+
+	t.Run("error creating Freight", func(t *testing.T) {
+		r := &reconciler{
+			subscriberRegistry: newRegistry(true),
+			createFreightFn: func(context.Context, client.Object, ...client.CreateOption) error {
+				return errors.New("something went wrong")
+			},
+		}
+		err := r.createFreightPerArtifact(t.Context(), warehouse, latest, artifacts)
+		require.ErrorContains(t, err, "error creating Freight")
+		require.ErrorContains(t, err, "something went wrong")
+	})
+}
+
 func TestValidateDiscoveredArtifacts(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -0,0 +1,222 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	// azureDevOpsScope is the Microsoft Entra ID scope required for access to
+	// Azure DevOps. The GUID is the well-known application ID of Azure DevOps
+	// itself and is the same in every tenant.
+	azureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+
+	// accessTokenUsername is the username presented along with an access token.
+	// Azure DevOps ignores it, but Git requires one.
+	accessTokenUsername = "kargo"
+
+	tokenCacheExpiryMargin = 5 * time.Minute
+
+	// tokenAcquisitionTimeout bounds a single token acquisition. Because an
+	// acquisition executes under a context detached from any caller's, this is
+	// the only thing bounding its duration. It is generous because it serves only
+	// as a fail-safe: exceeding it fails every caller waiting on that
+	// acquisition, and no fresh acquisition for the same organization can begin
+	// until it returns.
+	tokenAcquisitionTimeout = 30 * time.Second
+)
+
+var (
+	// modernURLRegex matches Azure DevOps Git URLs of the form
+	// https://dev.azure.com/<org>/<project>/_git/<repo> and captures the
+	// organization.
+	modernURLRegex = regexp.MustCompile(`^https://(?:[^@/]+@)?dev\.azure\.com/([^/]+)/`)
+	// legacyURLRegex matches Azure DevOps Git URLs of the form
+	// https://<org>.visualstudio.com/<project>/_git/<repo> and captures the
+	// organization.
+	legacyURLRegex = regexp.MustCompile(`^https://(?:[^@/]+@)?([a-zA-Z0-9-]+)\.visualstudio\.com/`)
+)
+
+func init() {
+	if !credentials.ProvidersEnabled() {
+		return
+	}
+	if provider := NewWorkloadIdentityProvider(context.Background()); provider != nil {
+		credentials.DefaultProviderRegistry.MustRegister(
+			credentials.ProviderRegistration{
+				Predicate: provider.Supports,
+				Value:     provider,
+			},
+		)
+	}
+}
+
+// WorkloadIdentityProvider implements credentials.Provider for Azure DevOps Git
+// repositories using Azure Workload Identity.
+type WorkloadIdentityProvider struct {
+	// tokenCache is an in-memory cache of Microsoft Entra ID access tokens keyed
+	// by Azure DevOps organization. It fills its own misses, coalescing
+	// concurrent loads for any given organization.
+	tokenCache coalescing.Cache[string, string]
+
+	credential azcore.TokenCredential
+
+	getAccessTokenFn func(ctx context.Context) (azcore.AccessToken, error)
+}
+
+// NewWorkloadIdentityProvider returns a new WorkloadIdentityProvider if Azure
+// workload identity credentials are available. Otherwise, it returns nil.
+func NewWorkloadIdentityProvider(ctx context.Context) credentials.Provider {
+	logger := logging.LoggerFromContext(ctx)
+
+	credential, err := azidentity.NewWorkloadIdentityCredential(nil)
+	if err != nil {
+		logger.Info("Azure workload identity not available", "error", err.Error())
+		return nil
+	}
+
+	logger.Info("Azure DevOps workload identity credential provider initialized")
+
+	p := &WorkloadIdentityProvider{credential: credential}
+	p.getAccessTokenFn = p.getAccessToken
+	tokenCache, err := coalescing.NewCache(
+		p.loadAccessToken,
+		&coalescing.CacheOptions{
+			LoadTimeout: new(tokenAcquisitionTimeout),
+			// Access tokens typically live for between one and 24 hours. We'll hang
+			// on to them for 40 minutes by default. When the actual token expiry is
+			// available, it is used (minus a safety margin) instead of this
+			// default.
+			DefaultTTL:      new(40 * time.Minute),
+			CleanupInterval: new(time.Hour),
+		},
+	)
+	if err != nil {
+		logger.Error(
+			err, "error creating token cache; Azure DevOps credentials integration will be disabled",
+		)
+		return nil
+	}
+	p.tokenCache = tokenCache
+	return p
+}
+
+// Supports implements credentials.Provider. It returns true for requests for
+// Git credentials for an Azure DevOps repository accessed over HTTPS, but only
+// when no Secret supplied credentials for that repository.
+func (p *WorkloadIdentityProvider) Supports(
+	_ context.Context,
+	req credentials.Request,
+) (bool, error) {
+	if req.Type != credentials.TypeGit || len(req.Data) > 0 {
+		return false, nil
+	}
+	return organizationFromURL(req.RepoURL) != "", nil
+}
+
+// GetCredentials implements credentials.Provider. It returns a Microsoft Entra
+// ID access token scoped to Azure DevOps, which Git accepts as a password and
+// the Azure DevOps REST API accepts as a bearer token.
+func (p *WorkloadIdentityProvider) GetCredentials(
+	ctx context.Context,
+	req credentials.Request,
+) (*credentials.Credentials, error) {
+	org := organizationFromURL(req.RepoURL)
+	if org == "" { // This doesn't look like an Azure DevOps URL
+		return nil, nil
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"provider", "azureDevOpsWorkloadIdentity",
+		"repoURL", req.RepoURL,
+		"organization", org,
+	)
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	accessToken, err := p.tokenCache.Get(ctx, org, org)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we didn't get a token, we'll treat this as no credentials found
+	if accessToken == "" {
+		return nil, nil
+	}
+
+	return &credentials.Credentials{
+		Username: accessTokenUsername,
+		Password: accessToken,
+	}, nil
+}
+
+// loadAccessToken obtains an access token for use with the given organization.
+// It is the Loader for this provider's token cache.
+func (p *WorkloadIdentityProvider) loadAccessToken(
+	ctx context.Context,
+	_ string,
+) (string, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	token, err := p.getAccessTokenFn(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting Azure DevOps access token: %w", err)
+	}
+
+	// If we didn't get a token, we'll treat this as no credentials found
+	if token.Token == "" {
+		return "", nil, nil
+	}
+	logger.Debug("obtained new access token")
+
+	ttl := credentials.CalculateCacheTTL(token.ExpiresOn, tokenCacheExpiryMargin)
+	if ttl == nil {
+		logger.Debug("token expires too soon to be worth caching", "expiry", token.ExpiresOn)
+		return token.Token, nil, nil
+	}
+	logger.Debug(
+		"caching access token",
+		"expiry", token.ExpiresOn,
+		"ttl", *ttl,
+	)
+	return token.Token, ttl, nil
+}
+
+// getAccessToken returns a Microsoft Entra ID access token scoped to Azure
+// DevOps using Azure workload identity.
+func (p *WorkloadIdentityProvider) getAccessToken(
+	ctx context.Context,
+) (azcore.AccessToken, error) {
+	token, err := p.credential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{azureDevOpsScope},
+	})
+	if err != nil {
+		return azcore.AccessToken{}, fmt.Errorf(
+			"failed to get Microsoft Entra ID access token for Azure DevOps: %w", err,
+		)
+	}
+	return token, nil
+}
+
+// organizationFromURL returns the Azure DevOps organization of the repository
+// with the given URL. An empty string is returned if the URL is not that of an
+// Azure DevOps repository accessed over HTTPS.
+func organizationFromURL(repoURL string) string {
+	if matches := modernURLRegex.FindStringSubmatch(repoURL); len(matches) == 2 {
+		return strings.ToLower(matches[1])
+	}
+	if matches := legacyURLRegex.FindStringSubmatch(repoURL); len(matches) == 2 {
+		return strings.ToLower(matches[1])
+	}
+	return ""
+}
@@ -0,0 +1,276 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func TestNewWorkloadIdentityProvider(t *testing.T) {
+	const azFederatedTokenFile = "AZURE_FEDERATED_TOKEN_FILE"
+	const azClientID = "AZURE_CLIENT_ID"
+	const azTenantID = "AZURE_TENANT_ID"
+	t.Run("workload identity not available", func(t *testing.T) {
+		// Make it look unavailable by ensuring key env vars are unset
+		t.Setenv(azFederatedTokenFile, "") // Ensures cleanup
+		os.Unsetenv(azFederatedTokenFile)  // Actually unsets
+		t.Setenv(azClientID, "")           // Ensures cleanup
+		os.Unsetenv(azClientID)            // Actually unsets
+		t.Setenv(azTenantID, "")           // Ensures cleanup
+		os.Unsetenv(azTenantID)            // Actually unsets
+		require.Nil(t, NewWorkloadIdentityProvider(t.Context()))
+	})
+	t.Run("workload identity available", func(t *testing.T) {
+		// Make it look available by ensuring key env vars are set, albeit with
+		// nonsense values.
+		const nonsense = "nonsense"
+		t.Setenv(azFederatedTokenFile, nonsense)
+		t.Setenv(azClientID, nonsense)
+		t.Setenv(azTenantID, nonsense)
+		require.NotNil(t, NewWorkloadIdentityProvider(t.Context()))
+	})
+}
+
+func TestWorkloadIdentityProvider_Supports(t *testing.T) {
+	testCases := []struct {
+		name     string
+		credType credentials.Type
+		repoURL  string
+		data     map[string][]byte
+		expected bool
+	}{
+		{
+			name:     "modern URL supported",
+			credType: credentials.TypeGit,
+			repoURL:  "https://dev.azure.com/akuity/kargo/_git/kargo",
+			expected: true,
+		},
+		{
+			name:     "legacy URL supported",
+			credType: credentials.TypeGit,
+			repoURL:  "https://akuity.visualstudio.com/kargo/_git/kargo",
+			expected: true,
+		},
+		{
+			name:     "SSH URL not supported",
+			credType: credentials.TypeGit,
+			repoURL:  "ssh://git@ssh.dev.azure.com/v3/akuity/kargo/kargo",
+			expected: false,
+		},
+		{
+			name:     "non-Azure DevOps URL not supported",
+			credType: credentials.TypeGit,
+			repoURL:  "https://github.com/akuity/kargo",
+			expected: false,
+		},
+		{
+			name:     "image credential type not supported",
+			credType: credentials.TypeImage,
+			repoURL:  "https://dev.azure.com/akuity/kargo/_git/kargo",
+			expected: false,
+		},
+		{
+			name:     "credentials from Secret take precedence",
+			credType: credentials.TypeGit,
+			repoURL:  "https://dev.azure.com/akuity/kargo/_git/kargo",
+			data: map[string][]byte{
+				"username": []byte("user"),
+				"password": []byte("pat"),
+			},
+			expected: false,
+		},
+	}
+
+	p := &WorkloadIdentityProvider{}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			supports, err := p.Supports(
+				t.Context(),
+				credentials.Request{
+					Type:    testCase.credType,
+					RepoURL: testCase.repoURL,
+					Data:    testCase.data,
+				},
+			)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, supports)
+		})
+	}
+}
+
+func TestWorkloadIdentityProvider_GetCredentials(t *testing.T) {
+	const testRepoURL = "https://dev.azure.com/akuity/kargo/_git/kargo"
+	const testToken = "fake-access-token"
+
+	testCases := []struct {
+		name       string
+		provider   *WorkloadIdentityProvider
+		repoURL    string
+		assertions func(*testing.T, *credentials.Credentials, error)
+	}{
+		{
+			name:     "non-Azure DevOps URL",
+			provider: &WorkloadIdentityProvider{},
+			repoURL:  "https://github.com/akuity/kargo",
+			assertions: func(t *testing.T, creds *credentials.Credentials, err error) {
+				assert.NoError(t, err)
+				assert.Nil(t, creds)
+			},
+		},
+		{
+			name: "token obtained",
+			provider: &WorkloadIdentityProvider{
+				getAccessTokenFn: func(context.Context) (azcore.AccessToken, error) {
+					return azcore.AccessToken{
+						Token:     testToken,
+						ExpiresOn: time.Now().Add(time.Hour),
+					}, nil
+				},
+			},
+			repoURL: testRepoURL,
+			assertions: func(t *testing.T, creds *credentials.Credentials, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &credentials.Credentials{
+					Username: accessTokenUsername,
+					Password: testToken,
+				}, creds)
+			},
+		},
+		{
+			name: "error getting token",
+			provider: &WorkloadIdentityProvider{
+				getAccessTokenFn: func(context.Context) (azcore.AccessToken, error) {
+					return azcore.AccessToken{}, errors.New("access token error")
+				},
+			},
+			repoURL: testRepoURL,
+			assertions: func(t *testing.T, creds *credentials.Credentials, err error) {
+				assert.ErrorContains(t, err, "error getting Azure DevOps access token")
+				assert.Nil(t, creds)
+			},
+		},
+		{
+			name: "empty token",
+			provider: &WorkloadIdentityProvider{
+				getAccessTokenFn: func(context.Context) (azcore.AccessToken, error) {
+					return azcore.AccessToken{}, nil
+				},
+			},
+			repoURL: testRepoURL,
+			assertions: func(t *testing.T, creds *credentials.Credentials, err error) {
+				assert.NoError(t, err)
+				assert.Nil(t, creds)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokenCache, err := coalescing.NewCache(
+				testCase.provider.loadAccessToken,
+				&coalescing.CacheOptions{
+					LoadTimeout:  new(tokenAcquisitionTimeout),
+					CacheNothing: true,
+				},
+			)
+			require.NoError(t, err)
+			testCase.provider.tokenCache = tokenCache
+			creds, err := testCase.provider.GetCredentials(
+				t.Context(),
+				credentials.Request{
+					Type:    credentials.TypeGit,
+					RepoURL: testCase.repoURL,
+				},
+			)
+			testCase.assertions(t, creds, err)
+		})
+	}
+}
+
+func TestWorkloadIdentityProvider_GetCredentials_perOrganization(t *testing.T) {
+	var loads atomic.Int32
+	provider := &WorkloadIdentityProvider{
+		getAccessTokenFn: func(context.Context) (azcore.AccessToken, error) {
+			loads.Add(1)
+			return azcore.AccessToken{
+				Token:     "token",
+				ExpiresOn: time.Now().Add(time.Hour),
+			}, nil
+		},
+	}
+	tokenCache, err := coalescing.NewCache(
+		provider.loadAccessToken,
+		&coalescing.CacheOptions{
+			LoadTimeout: new(tokenAcquisitionTimeout),
+			DefaultTTL:  new(time.Hour),
+		},
+	)
+	require.NoError(t, err)
+	provider.tokenCache = tokenCache
+
+	for _, repoURL := range []string{
+		"https://dev.azure.com/org-a/project/_git/repo-1",
+		"https://org-a.visualstudio.com/project/_git/repo-2",
+		"https://dev.azure.com/org-b/project/_git/repo-1",
+		"https://dev.azure.com/ORG-A/other/_git/repo-3",
+	} {
+		creds, err := provider.GetCredentials(
+			t.Context(),
+			credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: repoURL,
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, creds)
+	}
+	// One load for each of the two distinct organizations.
+	require.Equal(t, int32(2), loads.Load())
+}
+
+func Test_organizationFromURL(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://dev.azure.com/akuity/kargo/_git/kargo",
+			expected: "akuity",
+		},
+		{
+			url:      "https://user@dev.azure.com/Akuity/kargo/_git/kargo",
+			expected: "akuity",
+		},
+		{
+			url:      "https://akuity.visualstudio.com/kargo/_git/kargo",
+			expected: "akuity",
+		},
+		{
+			url:      "https://akuity.visualstudio.com/DefaultCollection/kargo/_git/kargo",
+			expected: "akuity",
+		},
+		{
+			url:      "git@ssh.dev.azure.com:v3/akuity/kargo/kargo",
+			expected: "",
+		},
+		{
+			url:      "https://github.com/akuity/kargo",
+			expected: "",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.url, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, organizationFromURL(testCase.url))
+		})
+	}
+}
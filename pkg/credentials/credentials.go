@@ -26,6 +26,12 @@ const (
 	TypeHelm Type = "helm"
 	// TypeImage represents credentials for an image repository.
 	TypeImage Type = "image"
+	// TypeHTTP represents credentials for an HTTP server or S3-compatible object
+	// storage that artifacts are discovered from.
+	TypeHTTP Type = "http"
+	// TypePackage represents credentials for a language package registry, such
+	// as an npm registry, a Maven repository or a Python package index.
+	TypePackage Type = "package"
 )
 
 type Request struct {
@@ -0,0 +1,328 @@
+package gitea
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/hashicorp/go-cleanhttp"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	usernameKey = "giteaUsername"
+	passwordKey = "giteaPassword"
+	scopesKey   = "giteaTokenScopes"
+	ttlKey      = "giteaTokenTTL"
+
+	// defaultTokenTTL is how long a minted token is used for before it is
+	// replaced. Gitea access tokens do not expire on their own, so this provider
+	// bounds their lifetime itself by revoking them once they are superseded.
+	defaultTokenTTL = time.Hour
+
+	// tokenAcquisitionTimeout bounds a single token acquisition, including the
+	// revocation of tokens it supersedes. Because an acquisition executes under a
+	// context detached from any caller's, this is the only thing bounding its
+	// duration.
+	tokenAcquisitionTimeout = 30 * time.Second
+
+	listPageSize = 50
+)
+
+// defaultScopes permits pushing to repositories and opening, labeling and
+// merging pull requests. The SDK predates the fine-grained scopes introduced in
+// Gitea 1.19, so these are not among its constants.
+var defaultScopes = []gitea.AccessTokenScope{"write:repository", "write:issue"}
+
+func init() {
+	if !credentials.ProvidersEnabled() {
+		return
+	}
+	if provider := NewAccessTokenProvider(); provider != nil {
+		credentials.DefaultProviderRegistry.MustRegister(
+			credentials.ProviderRegistration{
+				Predicate: provider.Supports,
+				Value:     provider,
+			},
+		)
+	}
+}
+
+// accessTokenInput identifies the access token a load should mint. The cache
+// key is a hash of these values, so they cannot be recovered from it.
+type accessTokenInput struct {
+	baseURL  string
+	username string
+	password string
+	scopes   []gitea.AccessTokenScope
+	ttl      time.Duration
+	// tokenNamePrefix begins the name of every token minted by Kargo for the
+	// same Kargo Project and repository. Gitea requires token names to be unique,
+	// so each is suffixed with the time it was minted. The prefix is how tokens
+	// superseded by a newly minted one are found and revoked.
+	tokenNamePrefix string
+}
+
+// AccessTokenProvider is an implementation of credentials.Provider that mints
+// short-lived Gitea access tokens using a bootstrap username and password.
+type AccessTokenProvider struct {
+	// tokenCache holds access tokens keyed by a hash of the repository they were
+	// minted for and the means by which they were minted. It fills its own
+	// misses, coalescing concurrent mints for any given key.
+	tokenCache coalescing.Cache[accessTokenInput, string]
+
+	httpClient *http.Client
+
+	// shardName distinguishes tokens minted by different controllers that might
+	// share a bootstrap account so that one never revokes tokens minted by
+	// another.
+	shardName string
+}
+
+// NewAccessTokenProvider returns an implementation of credentials.Provider.
+func NewAccessTokenProvider() credentials.Provider {
+	p := &AccessTokenProvider{
+		httpClient: cleanhttp.DefaultPooledClient(),
+		// SHARD_NAME carries the controller's own name, when it has one.
+		shardName: os.Getenv("SHARD_NAME"),
+	}
+	tokenCache, err := coalescing.NewCache(
+		p.loadAccessToken,
+		&coalescing.CacheOptions{
+			LoadTimeout: new(tokenAcquisitionTimeout),
+			// Every load returns a TTL of its own, so this default is never
+			// consulted.
+			DefaultTTL:      new(defaultTokenTTL),
+			CleanupInterval: new(time.Hour),
+		},
+	)
+	if err != nil {
+		logging.LoggerFromContext(context.Background()).Error(
+			err, "error creating token cache; this provider will not be registered",
+		)
+		return nil
+	}
+	p.tokenCache = tokenCache
+	return p
+}
+
+// Supports implements credentials.Provider. It returns true for requests for
+// Git credentials for a repository accessed over HTTP/S when the Secret found
+// for that repository contains a Gitea bootstrap username and password.
+func (p *AccessTokenProvider) Supports(
+	_ context.Context,
+	req credentials.Request,
+) (bool, error) {
+	if req.Type != credentials.TypeGit || len(req.Data) == 0 {
+		return false, nil
+	}
+	return (strings.HasPrefix(req.RepoURL, "http://") || strings.HasPrefix(req.RepoURL, "https://")) &&
+		string(req.Data[usernameKey]) != "" &&
+		string(req.Data[passwordKey]) != "", nil
+}
+
+// GetCredentials implements credentials.Provider. It returns a Gitea access
+// token belonging to the bootstrap account. Gitea does not permit tokens to be
+// scoped to individual repositories, so the token grants access to every
+// repository the bootstrap account can access, limited by the configured
+// scopes.
+func (p *AccessTokenProvider) GetCredentials(
+	ctx context.Context,
+	req credentials.Request,
+) (*credentials.Credentials, error) {
+	baseURL, repoPath, err := parseRepoURL(req.RepoURL)
+	if err != nil {
+		// Doesn't look like a URL we can do anything with.
+		return nil, nil
+	}
+
+	input := accessTokenInput{
+		baseURL:  baseURL,
+		username: string(req.Data[usernameKey]),
+		password: string(req.Data[passwordKey]),
+		scopes:   defaultScopes,
+		ttl:      defaultTokenTTL,
+	}
+	if scopesStr := string(req.Data[scopesKey]); scopesStr != "" {
+		input.scopes = nil
+		for scope := range strings.SplitSeq(scopesStr, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				input.scopes = append(input.scopes, gitea.AccessTokenScope(scope))
+			}
+		}
+	}
+	if ttlStr := string(req.Data[ttlKey]); ttlStr != "" {
+		if input.ttl, err = time.ParseDuration(ttlStr); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", ttlKey, err)
+		}
+		if input.ttl <= 0 {
+			return nil, fmt.Errorf("%s must be positive", ttlKey)
+		}
+	}
+	input.tokenNamePrefix = fmt.Sprintf(
+		"kargo-%s-%s-",
+		req.Project,
+		shortHash(p.shardName, baseURL, repoPath),
+	)
+
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"provider", "giteaAccessToken",
+		"repoURL", req.RepoURL,
+	)
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	scopeStrs := make([]string, len(input.scopes))
+	for i, s := range input.scopes {
+		scopeStrs[i] = string(s)
+	}
+	accessToken, err := p.tokenCache.Get(
+		ctx,
+		tokenCacheKey(
+			input.tokenNamePrefix,
+			input.username,
+			input.password,
+			strings.Join(scopeStrs, ","),
+			input.ttl.String(),
+		),
+		input,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if accessToken == "" {
+		return nil, nil
+	}
+	return &credentials.Credentials{
+		Username: input.username,
+		Password: accessToken,
+	}, nil
+}
+
+// loadAccessToken mints a new access token and then revokes tokens it
+// supersedes. It is the Loader for this provider's token cache.
+func (p *AccessTokenProvider) loadAccessToken(
+	ctx context.Context,
+	input accessTokenInput,
+) (string, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	client, err := gitea.NewClient(
+		input.baseURL,
+		gitea.SetBasicAuth(input.username, input.password),
+		gitea.SetHTTPClient(p.httpClient),
+		gitea.SetContext(ctx),
+		// Skip the server version lookup the client would otherwise perform.
+		gitea.SetGiteaVersion(""),
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating Gitea client: %w", err)
+	}
+
+	// Tokens that this one supersedes are found before it is minted so that the
+	// new token is never mistaken for one of them.
+	previous, err := listTokens(client, input.tokenNamePrefix)
+	if err != nil {
+		return "", nil, fmt.Errorf("error listing Gitea access tokens: %w", err)
+	}
+
+	token, _, err := client.CreateAccessToken(gitea.CreateAccessTokenOption{
+		Name:   input.tokenNamePrefix + strconv.FormatInt(time.Now().UnixNano(), 10),
+		Scopes: input.scopes,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("error minting Gitea access token: %w", err)
+	}
+	if token.Token == "" {
+		return "", nil, nil
+	}
+	logger.Debug("minted new access token", "tokenID", token.ID)
+
+	// Tokens minted previously are revoked now that they have been superseded,
+	// except for the most recent one, which may still be in use by an operation
+	// that obtained it shortly before it was due to be replaced. It is revoked
+	// when this token is itself superseded. Failure to revoke is not fatal, as
+	// another attempt is made each time a token is minted.
+	var newestID int64
+	for _, t := range previous {
+		newestID = max(newestID, t.ID)
+	}
+	for _, t := range previous {
+		if t.ID == newestID {
+			continue
+		}
+		if _, err = client.DeleteAccessToken(t.ID); err != nil {
+			logger.Error(err, "error revoking superseded access token", "tokenID", t.ID)
+			continue
+		}
+		logger.Debug("revoked superseded access token", "tokenID", t.ID)
+	}
+
+	logger.Debug("caching access token", "ttl", input.ttl)
+	return token.Token, &input.ttl, nil
+}
+
+// listTokens returns all of the bootstrap account's access tokens whose names
+// begin with the specified prefix.
+func listTokens(client *gitea.Client, prefix string) ([]*gitea.AccessToken, error) {
+	var tokens []*gitea.AccessToken
+	for page := 1; ; page++ {
+		pageTokens, _, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: listPageSize},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range pageTokens {
+			if strings.HasPrefix(t.Name, prefix) {
+				tokens = append(tokens, t)
+			}
+		}
+		if len(pageTokens) < listPageSize {
+			return tokens, nil
+		}
+	}
+}
+
+// parseRepoURL returns the base URL of the Gitea server and the full path of
+// the repository with the given URL.
+func parseRepoURL(repoURL string) (string, string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing repository URL %q: %w", repoURL, err)
+	}
+	repoPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if u.Host == "" || strings.Count(repoPath, "/") != 1 {
+		return "", "", fmt.Errorf("could not extract repository from URL %q", repoURL)
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host), repoPath, nil
+}
+
+// tokenCacheKey returns a cache key in the form of a hash for the given parts.
+// Using a hash ensures that any sensitive data is not stored in a decodable
+// form.
+func tokenCacheKey(parts ...string) string {
+	h := sha256.New()
+	for i := range parts {
+		if i > 0 {
+			_, _ = h.Write([]byte(":"))
+		}
+		_, _ = h.Write([]byte(parts[i]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// shortHash returns a short, stable hash of the given parts suitable for use in
+// a token name.
+func shortHash(parts ...string) string {
+	return tokenCacheKey(parts...)[:12]
+}
@@ -0,0 +1,307 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+const (
+	testUsername = "kargo-bot"
+	testPassword = "hunter2"
+	tokensPath   = "/api/v1/users/" + testUsername + "/tokens"
+)
+
+type fakeToken struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	Token  string   `json:"sha1,omitempty"`
+}
+
+// fakeGitea is a minimal fake of the Gitea user access tokens API.
+type fakeGitea struct {
+	t      *testing.T
+	mu     sync.Mutex
+	nextID int64
+	tokens []*fakeToken
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if u, p, ok := r.BasicAuth(); !ok || u != testUsername || p != testPassword {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"user does not exist"}`))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == tokensPath:
+		tokens := []*fakeToken{}
+		for _, t := range f.tokens {
+			tokens = append(tokens, &fakeToken{ID: t.ID, Name: t.Name, Scopes: t.Scopes})
+		}
+		require.NoError(f.t, json.NewEncoder(w).Encode(tokens))
+	case r.Method == http.MethodPost && r.URL.Path == tokensPath:
+		var token fakeToken
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&token))
+		for _, t := range f.tokens {
+			if t.Name == token.Name {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"access token name has been used already"}`))
+				return
+			}
+		}
+		f.nextID++
+		token.ID = f.nextID
+		token.Token = fmt.Sprintf("minted-%d", token.ID)
+		f.tokens = append(f.tokens, &token)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(token))
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, tokensPath+"/"):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, tokensPath+"/"), 10, 64)
+		require.NoError(f.t, err)
+		for i, t := range f.tokens {
+			if t.ID == id {
+				f.tokens = append(f.tokens[:i], f.tokens[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitea) tokenIDs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := make([]int64, len(f.tokens))
+	for i, t := range f.tokens {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func newFakeGitea(t *testing.T) (*fakeGitea, string) {
+	fake := &fakeGitea{t: t}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return fake, srv.URL
+}
+
+func newTestProvider(t *testing.T) *AccessTokenProvider {
+	p := NewAccessTokenProvider()
+	require.NotNil(t, p)
+	return p.(*AccessTokenProvider) // nolint: forcetypeassert
+}
+
+func TestAccessTokenProvider_Supports(t *testing.T) {
+	bootstrap := map[string][]byte{
+		usernameKey: []byte(testUsername),
+		passwordKey: []byte(testPassword),
+	}
+	testCases := []struct {
+		name     string
+		req      credentials.Request
+		expected bool
+	}{
+		{
+			name: "bootstrap credentials present",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitea.example.com/org/repo.git",
+				Data:    bootstrap,
+			},
+			expected: true,
+		},
+		{
+			name: "no bootstrap password",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitea.example.com/org/repo.git",
+				Data:    map[string][]byte{usernameKey: []byte(testUsername)},
+			},
+		},
+		{
+			name: "no Secret",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitea.example.com/org/repo.git",
+			},
+		},
+		{
+			name: "SSH URL",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "ssh://git@gitea.example.com/org/repo.git",
+				Data:    bootstrap,
+			},
+		},
+		{
+			name: "not Git",
+			req: credentials.Request{
+				Type:    credentials.TypeImage,
+				RepoURL: "https://gitea.example.com/org/repo.git",
+				Data:    bootstrap,
+			},
+		},
+	}
+	p := newTestProvider(t)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			supported, err := p.Supports(context.Background(), tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, supported)
+		})
+	}
+}
+
+func TestAccessTokenProvider_GetCredentials(t *testing.T) {
+	t.Run("token minted", func(t *testing.T) {
+		fake, baseURL := newFakeGitea(t)
+		p := newTestProvider(t)
+		req := credentials.Request{
+			Project: "kargo-demo",
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo.git",
+			Data: map[string][]byte{
+				usernameKey: []byte(testUsername),
+				passwordKey: []byte(testPassword),
+				scopesKey:   []byte("write:repository, read:user"),
+			},
+		}
+		creds, err := p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, &credentials.Credentials{
+			Username: testUsername,
+			Password: "minted-1",
+		}, creds)
+		require.Len(t, fake.tokens, 1)
+		assert.True(t, strings.HasPrefix(fake.tokens[0].Name, "kargo-kargo-demo-"))
+		assert.Equal(t, []string{"write:repository", "read:user"}, fake.tokens[0].Scopes)
+
+		// The token is cached.
+		creds, err = p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "minted-1", creds.Password)
+		assert.Len(t, fake.tokens, 1)
+	})
+
+	t.Run("default scopes", func(t *testing.T) {
+		fake, baseURL := newFakeGitea(t)
+		_, err := newTestProvider(t).GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				usernameKey: []byte(testUsername),
+				passwordKey: []byte(testPassword),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, fake.tokens, 1)
+		assert.Equal(t, []string{"write:repository", "write:issue"}, fake.tokens[0].Scopes)
+	})
+
+	t.Run("invalid TTL", func(t *testing.T) {
+		_, baseURL := newFakeGitea(t)
+		_, err := newTestProvider(t).GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				usernameKey: []byte(testUsername),
+				passwordKey: []byte(testPassword),
+				ttlKey:      []byte("-1h"),
+			},
+		})
+		require.ErrorContains(t, err, "giteaTokenTTL must be positive")
+	})
+
+	t.Run("bootstrap credentials rejected", func(t *testing.T) {
+		_, baseURL := newFakeGitea(t)
+		_, err := newTestProvider(t).GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				usernameKey: []byte(testUsername),
+				passwordKey: []byte("wrong"),
+			},
+		})
+		require.ErrorContains(t, err, "error listing Gitea access tokens")
+	})
+
+	t.Run("superseded tokens are revoked", func(t *testing.T) {
+		fake, baseURL := newFakeGitea(t)
+		req := credentials.Request{
+			Project: "kargo-demo",
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				usernameKey: []byte(testUsername),
+				passwordKey: []byte(testPassword),
+			},
+		}
+		// An unrelated token that must never be revoked.
+		fake.tokens = []*fakeToken{{ID: 100, Name: "unrelated"}}
+		fake.nextID = 100
+		// Each new provider starts with an empty cache and so mints a new token,
+		// just as the controller would after its cached token expired or after
+		// it restarted.
+		for i := range 3 {
+			creds, err := newTestProvider(t).GetCredentials(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("minted-%d", 101+i), creds.Password)
+		}
+		// The most recently minted token and the one it superseded remain. The
+		// first was revoked when the third was minted.
+		assert.ElementsMatch(t, []int64{100, 102, 103}, fake.tokenIDs())
+	})
+}
+
+func Test_parseRepoURL(t *testing.T) {
+	testCases := []struct {
+		url             string
+		expectedBaseURL string
+		expectedRepo    string
+		expectErr       bool
+	}{
+		{
+			url:             "https://gitea.example.com/org/repo.git",
+			expectedBaseURL: "https://gitea.example.com",
+			expectedRepo:    "org/repo",
+		},
+		{
+			url:             "http://gitea.example.com:3000/org/repo",
+			expectedBaseURL: "http://gitea.example.com:3000",
+			expectedRepo:    "org/repo",
+		},
+		{
+			url:       "https://gitea.example.com/repo",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			baseURL, repo, err := parseRepoURL(tc.url)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBaseURL, baseURL)
+			assert.Equal(t, tc.expectedRepo, repo)
+		})
+	}
+}
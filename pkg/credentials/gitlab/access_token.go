@@ -0,0 +1,436 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	bootstrapTokenKey = "gitlabBootstrapToken"
+	groupKey          = "gitlabGroup"
+	accessLevelKey    = "gitlabAccessLevel"
+	scopesKey         = "gitlabTokenScopes"
+
+	accessTokenUsername = "kargo"
+
+	tokenCacheExpiryMargin = 5 * time.Minute
+
+	// tokenLifetime is the minimum lifetime of a minted token. GitLab only
+	// permits an expiry date (not time) to be specified for access tokens and
+	// tokens expire at the start of that date (UTC), so the actual lifetime of a
+	// token will be somewhere between this and 24 hours more than this.
+	tokenLifetime = time.Hour
+
+	// tokenAcquisitionTimeout bounds a single token acquisition, including the
+	// revocation of tokens it supersedes. Because an acquisition executes under a
+	// context detached from any caller's, this is the only thing bounding its
+	// duration.
+	tokenAcquisitionTimeout = 30 * time.Second
+)
+
+// defaultScopes permit minted tokens to clone from and push to repositories,
+// but not to use the rest of the GitLab API. Steps that require the latter, such
+// as those that open merge requests, require the api scope to be requested
+// explicitly.
+var defaultScopes = []string{"read_repository", "write_repository"}
+
+func init() {
+	if !credentials.ProvidersEnabled() {
+		return
+	}
+	if provider := NewAccessTokenProvider(); provider != nil {
+		credentials.DefaultProviderRegistry.MustRegister(
+			credentials.ProviderRegistration{
+				Predicate: provider.Supports,
+				Value:     provider,
+			},
+		)
+	}
+}
+
+// accessTokenInput identifies the access token a load should mint. The cache
+// key is a hash of these values, so they cannot be recovered from it.
+type accessTokenInput struct {
+	baseURL        string
+	bootstrapToken string
+	// group is the full path of the group to mint a group access token for. When
+	// empty, a project access token is minted for project instead.
+	group       string
+	project     string
+	accessLevel gitlab.AccessLevelValue
+	scopes      []string
+	// tokenName is the name given to minted tokens. All tokens minted by Kargo
+	// for the same Kargo Project and GitLab project or group share a name, which
+	// is how tokens superseded by a newly minted one are found and revoked.
+	tokenName string
+}
+
+// AccessTokenProvider is an implementation of credentials.Provider that mints
+// short-lived GitLab project or group access tokens using a longer-lived
+// bootstrap token.
+type AccessTokenProvider struct {
+	// tokenCache holds access tokens keyed by a hash of the project or group they
+	// were minted for and the means by which they were minted. It fills its own
+	// misses, coalescing concurrent mints for any given key.
+	tokenCache coalescing.Cache[accessTokenInput, string]
+
+	httpClient *http.Client
+
+	// shardName distinguishes tokens minted by different controllers that might
+	// share a bootstrap token so that one never revokes tokens minted by
+	// another.
+	shardName string
+}
+
+// NewAccessTokenProvider returns an implementation of credentials.Provider.
+func NewAccessTokenProvider() credentials.Provider {
+	p := &AccessTokenProvider{
+		httpClient: cleanhttp.DefaultPooledClient(),
+		// SHARD_NAME carries the controller's own name, when it has one.
+		shardName: os.Getenv("SHARD_NAME"),
+	}
+	tokenCache, err := coalescing.NewCache(
+		p.loadAccessToken,
+		&coalescing.CacheOptions{
+			LoadTimeout: new(tokenAcquisitionTimeout),
+			// The actual token expiry is always available and is used (minus a
+			// safety margin). This default is never consulted.
+			DefaultTTL:      new(tokenLifetime),
+			CleanupInterval: new(time.Hour),
+		},
+	)
+	if err != nil {
+		logging.LoggerFromContext(context.Background()).Error(
+			err, "error creating token cache; this provider will not be registered",
+		)
+		return nil
+	}
+	p.tokenCache = tokenCache
+	return p
+}
+
+// Supports implements credentials.Provider. It returns true for requests for
+// Git credentials for a repository accessed over HTTP/S when the Secret found
+// for that repository contains a GitLab bootstrap token.
+func (p *AccessTokenProvider) Supports(
+	_ context.Context,
+	req credentials.Request,
+) (bool, error) {
+	if req.Type != credentials.TypeGit || len(req.Data) == 0 {
+		return false, nil
+	}
+	return (strings.HasPrefix(req.RepoURL, "http://") || strings.HasPrefix(req.RepoURL, "https://")) &&
+		string(req.Data[bootstrapTokenKey]) != "", nil
+}
+
+// GetCredentials implements credentials.Provider. It returns a GitLab access
+// token scoped to the repository's project or, if the Secret specifies one, to
+// a group.
+func (p *AccessTokenProvider) GetCredentials(
+	ctx context.Context,
+	req credentials.Request,
+) (*credentials.Credentials, error) {
+	baseURL, projectPath, err := parseRepoURL(req.RepoURL)
+	if err != nil {
+		// Doesn't look like a URL we can do anything with.
+		return nil, nil
+	}
+
+	input := accessTokenInput{
+		baseURL:        baseURL,
+		bootstrapToken: string(req.Data[bootstrapTokenKey]),
+		group:          strings.Trim(string(req.Data[groupKey]), "/"),
+		project:        projectPath,
+		accessLevel:    gitlab.DeveloperPermissions,
+		scopes:         defaultScopes,
+	}
+	if input.group != "" && !strings.HasPrefix(projectPath, input.group+"/") {
+		return nil, fmt.Errorf(
+			"repository %q does not belong to group %q", req.RepoURL, input.group,
+		)
+	}
+	if levelStr := string(req.Data[accessLevelKey]); levelStr != "" {
+		level, err := strconv.Atoi(levelStr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", accessLevelKey, err)
+		}
+		input.accessLevel = gitlab.AccessLevelValue(level)
+	}
+	if scopesStr := string(req.Data[scopesKey]); scopesStr != "" {
+		input.scopes = nil
+		for scope := range strings.SplitSeq(scopesStr, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				input.scopes = append(input.scopes, scope)
+			}
+		}
+	}
+	target := input.project
+	if input.group != "" {
+		target = "group:" + input.group
+	}
+	input.tokenName = fmt.Sprintf(
+		"kargo-%s-%s",
+		req.Project,
+		shortHash(p.shardName, baseURL, target),
+	)
+
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"provider", "gitlabAccessToken",
+		"repoURL", req.RepoURL,
+	)
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	accessToken, err := p.tokenCache.Get(
+		ctx,
+		tokenCacheKey(
+			input.tokenName,
+			input.bootstrapToken,
+			strconv.Itoa(int(input.accessLevel)),
+			strings.Join(input.scopes, ","),
+		),
+		input,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if accessToken == "" {
+		return nil, nil
+	}
+	return &credentials.Credentials{
+		Username: accessTokenUsername,
+		Password: accessToken,
+	}, nil
+}
+
+// loadAccessToken mints a new access token and then revokes tokens it
+// supersedes. It is the Loader for this provider's token cache.
+func (p *AccessTokenProvider) loadAccessToken(
+	ctx context.Context,
+	input accessTokenInput,
+) (string, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	client, err := gitlab.NewClient(
+		input.bootstrapToken,
+		gitlab.WithBaseURL(input.baseURL),
+		gitlab.WithHTTPClient(p.httpClient),
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating GitLab client: %w", err)
+	}
+	tokens := newTokenClient(client, input)
+
+	// Tokens that this one supersedes are found before it is minted so that the
+	// new token is never mistaken for one of them.
+	previous, err := tokens.list(ctx, input.tokenName)
+	if err != nil {
+		return "", nil, fmt.Errorf("error listing GitLab access tokens: %w", err)
+	}
+
+	expiresAt := gitlab.ISOTime(time.Now().UTC().Add(tokenLifetime + 24*time.Hour))
+	token, err := tokens.create(ctx, &createTokenOptions{
+		Name:        &input.tokenName,
+		Description: new("Minted by Kargo"),
+		Scopes:      &input.scopes,
+		AccessLevel: &input.accessLevel,
+		ExpiresAt:   &expiresAt,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("error minting GitLab access token: %w", err)
+	}
+	if token.Token == "" {
+		return "", nil, nil
+	}
+	logger.Debug("minted new access token", "tokenID", token.ID)
+
+	// Tokens minted previously are revoked now that they have been superseded,
+	// except for the most recent one, which may still be in use by an operation
+	// that obtained it shortly before it was due to be replaced. It is left to
+	// expire or to be revoked when this token is itself superseded. Failure to
+	// revoke is not fatal, as the tokens will expire on their own regardless.
+	var newestID int64
+	for _, t := range previous {
+		newestID = max(newestID, t.ID)
+	}
+	for _, t := range previous {
+		if t.ID == newestID {
+			continue
+		}
+		if err = tokens.revoke(ctx, t.ID); err != nil {
+			logger.Error(err, "error revoking superseded access token", "tokenID", t.ID)
+			continue
+		}
+		logger.Debug("revoked superseded access token", "tokenID", t.ID)
+	}
+
+	var expiry time.Time
+	if token.ExpiresAt != nil {
+		expiry = time.Time(*token.ExpiresAt)
+	}
+	ttl := credentials.CalculateCacheTTL(expiry, tokenCacheExpiryMargin)
+	if ttl == nil {
+		logger.Debug("token expires too soon to be worth caching", "expiry", expiry)
+		return token.Token, nil, nil
+	}
+	logger.Debug("caching access token", "expiry", expiry, "ttl", *ttl)
+	return token.Token, ttl, nil
+}
+
+// createTokenOptions are the options for minting either a project or group
+// access token, which are identical.
+type createTokenOptions = gitlab.CreateProjectAccessTokenOptions
+
+// tokenClient abstracts over the near-identical GitLab APIs for project and
+// group access tokens.
+type tokenClient struct {
+	list   func(ctx context.Context, name string) ([]*gitlab.PersonalAccessToken, error)
+	create func(ctx context.Context, opts *createTokenOptions) (*gitlab.PersonalAccessToken, error)
+	revoke func(ctx context.Context, id int64) error
+}
+
+func newTokenClient(client *gitlab.Client, input accessTokenInput) tokenClient {
+	if input.group != "" {
+		return tokenClient{
+			list: func(ctx context.Context, name string) ([]*gitlab.PersonalAccessToken, error) {
+				return listAll(ctx, name, func(page int64) ([]*gitlab.GroupAccessToken, *gitlab.Response, error) {
+					return client.GroupAccessTokens.ListGroupAccessTokens(
+						input.group,
+						&gitlab.ListGroupAccessTokensOptions{
+							ListOptions: gitlab.ListOptions{Page: page, PerPage: 100},
+							State:       new(gitlab.AccessTokenStateActive),
+							Search:      &name,
+						},
+						gitlab.WithContext(ctx),
+					)
+				}, func(t *gitlab.GroupAccessToken) *gitlab.PersonalAccessToken {
+					return &t.PersonalAccessToken
+				})
+			},
+			create: func(ctx context.Context, opts *createTokenOptions) (*gitlab.PersonalAccessToken, error) {
+				t, _, err := client.GroupAccessTokens.CreateGroupAccessToken(
+					input.group,
+					(*gitlab.CreateGroupAccessTokenOptions)(opts),
+					gitlab.WithContext(ctx),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &t.PersonalAccessToken, nil
+			},
+			revoke: func(ctx context.Context, id int64) error {
+				_, err := client.GroupAccessTokens.RevokeGroupAccessToken(
+					input.group, id, gitlab.WithContext(ctx),
+				)
+				return err
+			},
+		}
+	}
+	return tokenClient{
+		list: func(ctx context.Context, name string) ([]*gitlab.PersonalAccessToken, error) {
+			return listAll(ctx, name, func(page int64) ([]*gitlab.ProjectAccessToken, *gitlab.Response, error) {
+				return client.ProjectAccessTokens.ListProjectAccessTokens(
+					input.project,
+					&gitlab.ListProjectAccessTokensOptions{
+						ListOptions: gitlab.ListOptions{Page: page, PerPage: 100},
+						State:       new("active"),
+					},
+					gitlab.WithContext(ctx),
+				)
+			}, func(t *gitlab.ProjectAccessToken) *gitlab.PersonalAccessToken {
+				return &t.PersonalAccessToken
+			})
+		},
+		create: func(ctx context.Context, opts *createTokenOptions) (*gitlab.PersonalAccessToken, error) {
+			t, _, err := client.ProjectAccessTokens.CreateProjectAccessToken(
+				input.project, opts, gitlab.WithContext(ctx),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &t.PersonalAccessToken, nil
+		},
+		revoke: func(ctx context.Context, id int64) error {
+			_, err := client.ProjectAccessTokens.RevokeProjectAccessToken(
+				input.project, id, gitlab.WithContext(ctx),
+			)
+			return err
+		},
+	}
+}
+
+// listAll pages through access tokens, returning those that are active and
+// have exactly the specified name.
+func listAll[T any](
+	ctx context.Context,
+	name string,
+	listPage func(page int64) ([]T, *gitlab.Response, error),
+	convert func(T) *gitlab.PersonalAccessToken,
+) ([]*gitlab.PersonalAccessToken, error) {
+	var tokens []*gitlab.PersonalAccessToken
+	for page := int64(1); ; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pageTokens, resp, err := listPage(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, pt := range pageTokens {
+			if t := convert(pt); t.Name == name && t.Active && !t.Revoked {
+				tokens = append(tokens, t)
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return tokens, nil
+		}
+		page = resp.NextPage
+	}
+}
+
+// parseRepoURL returns the base URL of the GitLab API and the full path of the
+// project for the repository with the given URL.
+func parseRepoURL(repoURL string) (string, string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing repository URL %q: %w", repoURL, err)
+	}
+	projectPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if u.Host == "" || !strings.Contains(projectPath, "/") {
+		return "", "", fmt.Errorf("could not extract project from repository URL %q", repoURL)
+	}
+	return fmt.Sprintf("%s://%s/api/v4", u.Scheme, u.Host), projectPath, nil
+}
+
+// tokenCacheKey returns a cache key in the form of a hash for the given parts.
+// Using a hash ensures that any sensitive data is not stored in a decodable
+// form.
+func tokenCacheKey(parts ...string) string {
+	h := sha256.New()
+	for i := range parts {
+		if i > 0 {
+			_, _ = h.Write([]byte(":"))
+		}
+		_, _ = h.Write([]byte(parts[i]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// shortHash returns a short, stable hash of the given parts suitable for use in
+// a token name.
+func shortHash(parts ...string) string {
+	return tokenCacheKey(parts...)[:12]
+}
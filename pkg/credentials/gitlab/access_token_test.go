@@ -0,0 +1,337 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+const testBootstrapToken = "glpat-bootstrap"
+
+type fakeToken struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Active      bool     `json:"active"`
+	Revoked     bool     `json:"revoked"`
+	Scopes      []string `json:"scopes"`
+	AccessLevel int      `json:"access_level"`
+	ExpiresAt   string   `json:"expires_at"`
+	Token       string   `json:"token,omitempty"`
+}
+
+// fakeGitLab is a minimal fake of the GitLab project and group access tokens
+// APIs.
+type fakeGitLab struct {
+	t      *testing.T
+	mu     sync.Mutex
+	nextID int64
+	// tokens is keyed by the escaped path of the access tokens collection (e.g.
+	// "/api/v4/projects/org%2Frepo/access_tokens").
+	tokens map[string][]*fakeToken
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("PRIVATE-TOKEN") != testBootstrapToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"401 Unauthorized"}`))
+		return
+	}
+	path := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/access_tokens"):
+		tokens := []*fakeToken{}
+		for _, t := range f.tokens[path] {
+			if t.Active {
+				tokens = append(tokens, &fakeToken{
+					ID: t.ID, Name: t.Name, Active: t.Active, ExpiresAt: t.ExpiresAt,
+				})
+			}
+		}
+		require.NoError(f.t, json.NewEncoder(w).Encode(tokens))
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/access_tokens"):
+		var token fakeToken
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&token))
+		f.nextID++
+		token.ID = f.nextID
+		token.Active = true
+		token.Token = fmt.Sprintf("glpat-minted-%d", token.ID)
+		f.tokens[path] = append(f.tokens[path], &token)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(token))
+	case r.Method == http.MethodDelete:
+		idx := strings.LastIndex(path, "/")
+		id, err := strconv.ParseInt(path[idx+1:], 10, 64)
+		require.NoError(f.t, err)
+		for _, t := range f.tokens[path[:idx]] {
+			if t.ID == id {
+				t.Active = false
+				t.Revoked = true
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitLab) activeTokenIDs(collection string) []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ids []int64
+	for _, t := range f.tokens[collection] {
+		if t.Active {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+func newFakeGitLab(t *testing.T) (*fakeGitLab, string) {
+	fake := &fakeGitLab{t: t, tokens: map[string][]*fakeToken{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return fake, srv.URL
+}
+
+func newTestProvider(t *testing.T) *AccessTokenProvider {
+	p := NewAccessTokenProvider()
+	require.NotNil(t, p)
+	return p.(*AccessTokenProvider) // nolint: forcetypeassert
+}
+
+func TestAccessTokenProvider_Supports(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      credentials.Request
+		expected bool
+	}{
+		{
+			name: "bootstrap token present",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitlab.com/org/repo.git",
+				Data:    map[string][]byte{bootstrapTokenKey: []byte("token")},
+			},
+			expected: true,
+		},
+		{
+			name: "no bootstrap token",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitlab.com/org/repo.git",
+				Data:    map[string][]byte{"password": []byte("token")},
+			},
+		},
+		{
+			name: "no Secret",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://gitlab.com/org/repo.git",
+			},
+		},
+		{
+			name: "SSH URL",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "ssh://git@gitlab.com/org/repo.git",
+				Data:    map[string][]byte{bootstrapTokenKey: []byte("token")},
+			},
+		},
+		{
+			name: "not Git",
+			req: credentials.Request{
+				Type:    credentials.TypeHelm,
+				RepoURL: "https://gitlab.com/org/repo.git",
+				Data:    map[string][]byte{bootstrapTokenKey: []byte("token")},
+			},
+		},
+	}
+	p := newTestProvider(t)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			supported, err := p.Supports(context.Background(), tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, supported)
+		})
+	}
+}
+
+func TestAccessTokenProvider_GetCredentials(t *testing.T) {
+	t.Run("project access token", func(t *testing.T) {
+		fake, baseURL := newFakeGitLab(t)
+		p := newTestProvider(t)
+		req := credentials.Request{
+			Project: "kargo-demo",
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/sub/repo.git",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte(testBootstrapToken),
+				scopesKey:         []byte("api, read_repository, write_repository"),
+				accessLevelKey:    []byte("40"),
+			},
+		}
+		creds, err := p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, &credentials.Credentials{
+			Username: accessTokenUsername,
+			Password: "glpat-minted-1",
+		}, creds)
+
+		const collection = "/api/v4/projects/org%2Fsub%2Frepo/access_tokens"
+		tokens := fake.tokens[collection]
+		require.Len(t, tokens, 1)
+		assert.True(t, strings.HasPrefix(tokens[0].Name, "kargo-kargo-demo-"))
+		assert.Equal(t, []string{"api", "read_repository", "write_repository"}, tokens[0].Scopes)
+		assert.Equal(t, 40, tokens[0].AccessLevel)
+		expiresAt, err := time.Parse(time.DateOnly, tokens[0].ExpiresAt)
+		require.NoError(t, err)
+		assert.Greater(t, time.Until(expiresAt), tokenLifetime-time.Minute)
+
+		// The token is cached.
+		creds, err = p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "glpat-minted-1", creds.Password)
+		assert.Len(t, fake.tokens[collection], 1)
+	})
+
+	t.Run("group access token", func(t *testing.T) {
+		fake, baseURL := newFakeGitLab(t)
+		p := newTestProvider(t)
+		creds, err := p.GetCredentials(context.Background(), credentials.Request{
+			Project: "kargo-demo",
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/sub/repo",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte(testBootstrapToken),
+				groupKey:          []byte("org"),
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "glpat-minted-1", creds.Password)
+		tokens := fake.tokens["/api/v4/groups/org/access_tokens"]
+		require.Len(t, tokens, 1)
+		assert.Equal(t, []string{"read_repository", "write_repository"}, tokens[0].Scopes)
+		assert.Equal(t, 30, tokens[0].AccessLevel)
+	})
+
+	t.Run("repository outside of group", func(t *testing.T) {
+		_, baseURL := newFakeGitLab(t)
+		p := newTestProvider(t)
+		_, err := p.GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/other/repo",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte(testBootstrapToken),
+				groupKey:          []byte("org"),
+			},
+		})
+		require.ErrorContains(t, err, `does not belong to group "org"`)
+	})
+
+	t.Run("invalid access level", func(t *testing.T) {
+		_, baseURL := newFakeGitLab(t)
+		p := newTestProvider(t)
+		_, err := p.GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte(testBootstrapToken),
+				accessLevelKey:    []byte("developer"),
+			},
+		})
+		require.ErrorContains(t, err, "error parsing gitlabAccessLevel")
+	})
+
+	t.Run("bootstrap token rejected", func(t *testing.T) {
+		_, baseURL := newFakeGitLab(t)
+		p := newTestProvider(t)
+		_, err := p.GetCredentials(context.Background(), credentials.Request{
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte("wrong"),
+			},
+		})
+		require.ErrorContains(t, err, "error listing GitLab access tokens")
+	})
+
+	t.Run("superseded tokens are revoked", func(t *testing.T) {
+		fake, baseURL := newFakeGitLab(t)
+		req := credentials.Request{
+			Project: "kargo-demo",
+			Type:    credentials.TypeGit,
+			RepoURL: baseURL + "/org/repo",
+			Data: map[string][]byte{
+				bootstrapTokenKey: []byte(testBootstrapToken),
+			},
+		}
+		const collection = "/api/v4/projects/org%2Frepo/access_tokens"
+		// An unrelated token that must never be revoked.
+		fake.tokens[collection] = []*fakeToken{{
+			ID: 100, Name: "unrelated", Active: true, ExpiresAt: "2099-01-01",
+		}}
+		// Each new provider starts with an empty cache and so mints a new token,
+		// just as the controller would after its cached token expired or after
+		// it restarted.
+		for i := range 3 {
+			creds, err := newTestProvider(t).GetCredentials(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("glpat-minted-%d", i+1), creds.Password)
+		}
+		// The most recently minted token and the one it superseded remain active.
+		// The first was revoked when the third was minted.
+		assert.ElementsMatch(t, []int64{100, 2, 3}, fake.activeTokenIDs(collection))
+	})
+}
+
+func Test_parseRepoURL(t *testing.T) {
+	testCases := []struct {
+		url             string
+		expectedBaseURL string
+		expectedProject string
+		expectErr       bool
+	}{
+		{
+			url:             "https://gitlab.com/org/repo.git",
+			expectedBaseURL: "https://gitlab.com/api/v4",
+			expectedProject: "org/repo",
+		},
+		{
+			url:             "https://gitlab.example.com:8443/org/sub/repo",
+			expectedBaseURL: "https://gitlab.example.com:8443/api/v4",
+			expectedProject: "org/sub/repo",
+		},
+		{
+			url:       "https://gitlab.com/repo",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			baseURL, project, err := parseRepoURL(tc.url)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBaseURL, baseURL)
+			assert.Equal(t, tc.expectedProject, project)
+		})
+	}
+}
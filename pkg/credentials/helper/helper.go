@@ -0,0 +1,369 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/pattern"
+)
+
+const (
+	// APIVersion identifies the version of the protocol spoken between Kargo and
+	// a credential helper. It is included in every request so that helpers can
+	// detect, and reject, versions they do not understand.
+	APIVersion = "credentials.kargo.akuity.io/v1"
+
+	tokenCacheExpiryMargin = time.Minute
+
+	// maxOutputBytes bounds how much of a helper's stdout is retained. Anything
+	// larger than this cannot be a legitimate response.
+	maxOutputBytes = 1 << 20
+	// maxStderrBytes bounds how much of a helper's stderr is included in an
+	// error message.
+	maxStderrBytes = 1 << 10
+)
+
+func init() {
+	if !credentials.ProvidersEnabled() {
+		return
+	}
+	cfg := ProviderConfigFromEnv()
+	if provider := NewProvider(context.Background(), cfg); provider != nil {
+		credentials.DefaultProviderRegistry.MustRegister(
+			credentials.ProviderRegistration{
+				Predicate: provider.Supports,
+				Value:     provider,
+			},
+		)
+	}
+}
+
+// ProviderConfig represents configuration for the credential helper provider.
+type ProviderConfig struct {
+	// Command is the path to the credential helper executable. When empty, the
+	// provider is disabled.
+	Command string `envconfig:"CREDENTIAL_HELPER_COMMAND"`
+	// Args are any additional arguments to pass to the credential helper. They
+	// are read from a JSON array so that they may contain commas.
+	Args StringList `envconfig:"CREDENTIAL_HELPER_ARGS"`
+	// Types restricts the provider to requests for credentials of these types.
+	// When empty, credentials of any type may be requested from the helper.
+	Types []string `envconfig:"CREDENTIAL_HELPER_TYPES"`
+	// RepoURLs is a list of patterns (exact, "glob:" or "regex:"). Credentials
+	// are requested from the helper only for repositories whose URL matches at
+	// least one of these. At least one is required. They are read from a JSON
+	// array so that regular expressions may contain commas.
+	RepoURLs StringList `envconfig:"CREDENTIAL_HELPER_REPO_URLS"`
+	// Timeout bounds a single execution of the credential helper.
+	Timeout time.Duration `envconfig:"CREDENTIAL_HELPER_TIMEOUT" default:"30s"`
+	// CacheTTL is how long credentials returned without an expiry are cached
+	// for. Zero disables caching of such credentials.
+	CacheTTL time.Duration `envconfig:"CREDENTIAL_HELPER_CACHE_TTL" default:"5m"`
+}
+
+// StringList is a list of strings that is decoded from a JSON array rather
+// than from a comma-delimited string, so that its elements may contain commas.
+type StringList []string
+
+// Decode implements envconfig.Decoder.
+func (l *StringList) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*l = nil
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal([]byte(value), &list); err != nil {
+		return fmt.Errorf("error parsing JSON array of strings: %w", err)
+	}
+	*l = list
+	return nil
+}
+
+// ProviderConfigFromEnv returns a ProviderConfig populated from environment
+// variables.
+func ProviderConfigFromEnv() ProviderConfig {
+	cfg := ProviderConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// Request is the JSON document written to a credential helper's stdin.
+type Request struct {
+	// APIVersion is the version of the protocol. See APIVersion.
+	APIVersion string `json:"apiVersion"`
+	// Project is the name of the Project on whose behalf credentials are
+	// requested.
+	Project string `json:"project"`
+	// Type is the type of credentials requested.
+	Type credentials.Type `json:"type"`
+	// RepoURL is the URL of the repository for which credentials are requested.
+	RepoURL string `json:"repoURL"`
+	// Metadata holds the annotations of the Secret, if any, that delegated the
+	// request to the helper.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Response is the JSON document a credential helper writes to its stdout.
+// Empty output, or a Response with neither a password nor an SSH private key,
+// signals that the helper has no credentials for the request.
+type Response struct {
+	// Username identifies the principal the credentials belong to.
+	Username string `json:"username,omitempty"`
+	// Password is the password or token.
+	Password string `json:"password,omitempty"` // nolint: gosec
+	// SSHPrivateKey is a private key for use with Git repositories accessed over
+	// SSH.
+	SSHPrivateKey string `json:"sshPrivateKey,omitempty"`
+	// ExpiresAt, when set, is the time at which the credentials expire. They are
+	// cached until shortly before then. When not set, the credentials are cached
+	// for a configurable default duration.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Provider is an implementation of credentials.Provider that obtains
+// credentials by executing an external credential helper, in the manner of the
+// Docker and kubectl credential plugins.
+type Provider struct {
+	cfg      ProviderConfig
+	types    []credentials.Type
+	repoURLs pattern.Matchers
+
+	// credentialsCache holds credentials keyed by a hash of the request they
+	// were obtained for. It fills its own misses, coalescing concurrent
+	// executions of the helper for any given key.
+	credentialsCache coalescing.Cache[Request, *credentials.Credentials]
+
+	runFn func(ctx context.Context, stdin []byte) ([]byte, error)
+}
+
+// NewProvider returns an implementation of credentials.Provider backed by an
+// external credential helper. It returns nil if no helper is configured or the
+// configuration is invalid.
+func NewProvider(ctx context.Context, cfg ProviderConfig) credentials.Provider {
+	logger := logging.LoggerFromContext(ctx)
+	if cfg.Command == "" {
+		logger.Info(
+			"CREDENTIAL_HELPER_COMMAND is not set; credential helper integration will be disabled",
+		)
+		return nil
+	}
+	if len(cfg.RepoURLs) == 0 {
+		logger.Error(
+			nil, "CREDENTIAL_HELPER_REPO_URLS is not set; credential helper integration will be disabled",
+		)
+		return nil
+	}
+	if cfg.Timeout <= 0 {
+		logger.Error(
+			nil, "CREDENTIAL_HELPER_TIMEOUT must be positive; credential helper integration will be disabled",
+		)
+		return nil
+	}
+	p := &Provider{cfg: cfg}
+	for _, t := range cfg.Types {
+		p.types = append(p.types, credentials.Type(strings.TrimSpace(t)))
+	}
+	for _, u := range cfg.RepoURLs {
+		m, err := pattern.ParseNamePattern(strings.TrimSpace(u))
+		if err != nil {
+			logger.Error(
+				err, "error parsing credential helper repository URL pattern; "+
+					"credential helper integration will be disabled",
+				"pattern", u,
+			)
+			return nil
+		}
+		p.repoURLs = append(p.repoURLs, m)
+	}
+	p.runFn = p.run
+	credentialsCache, err := coalescing.NewCache(
+		p.loadCredentials,
+		&coalescing.CacheOptions{
+			LoadTimeout: new(cfg.Timeout),
+			// This is only consulted when the helper does not say when the
+			// credentials it returned expire.
+			DefaultTTL:      new(cfg.CacheTTL),
+			CleanupInterval: new(time.Hour),
+		},
+	)
+	if err != nil {
+		logger.Error(
+			err, "error creating credentials cache; credential helper integration will be disabled",
+		)
+		return nil
+	}
+	p.credentialsCache = credentialsCache
+	logger.Info("credential helper integration enabled", "command", cfg.Command)
+	return p
+}
+
+// Supports implements credentials.Provider. It returns true if the request is
+// for a type of credentials and a repository the helper is configured for and
+// there is either no Secret for the repository or the Secret that was found
+// identifies the repository without itself holding any credentials. The latter
+// is a means of passing metadata, in the form of the Secret's annotations, to
+// the helper.
+func (p *Provider) Supports(
+	_ context.Context,
+	req credentials.Request,
+) (bool, error) {
+	if len(p.types) > 0 && !slices.Contains(p.types, req.Type) {
+		return false, nil
+	}
+	for key := range req.Data {
+		if key != credentials.FieldRepoURL && key != credentials.FieldRepoURLIsRegex {
+			return false, nil
+		}
+	}
+	return p.repoURLs.Matches(req.RepoURL), nil
+}
+
+// GetCredentials implements credentials.Provider.
+func (p *Provider) GetCredentials(
+	ctx context.Context,
+	req credentials.Request,
+) (*credentials.Credentials, error) {
+	helperReq := Request{
+		APIVersion: APIVersion,
+		Project:    req.Project,
+		Type:       req.Type,
+		RepoURL:    req.RepoURL,
+		Metadata:   req.Metadata,
+	}
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"provider", "credentialHelper",
+		"repoURL", req.RepoURL,
+	)
+	ctx = logging.ContextWithLogger(ctx, logger)
+	creds, err := p.credentialsCache.Get(ctx, cacheKey(helperReq), helperReq)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, nil
+	}
+	// Hand the caller a copy so nothing it does can alter the cached value.
+	c := *creds
+	return &c, nil
+}
+
+// loadCredentials executes the helper for the given request. It is the Loader
+// for this provider's credentials cache.
+func (p *Provider) loadCredentials(
+	ctx context.Context,
+	req Request,
+) (*credentials.Credentials, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	stdin, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error marshaling credential helper request: %w", err)
+	}
+	stdout, err := p.runFn(ctx, stdin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res Response
+	if len(bytes.TrimSpace(stdout)) > 0 {
+		if err = json.Unmarshal(stdout, &res); err != nil {
+			return nil, nil, fmt.Errorf("error unmarshaling credential helper response: %w", err)
+		}
+	}
+	if res.Password == "" && res.SSHPrivateKey == "" {
+		// The helper has no credentials for this request. This is not cached so
+		// that credentials the helper later comes to have are picked up promptly.
+		logger.Debug("credential helper returned no credentials")
+		return nil, nil, nil
+	}
+	creds := &credentials.Credentials{
+		Username:      res.Username,
+		Password:      res.Password,
+		SSHPrivateKey: res.SSHPrivateKey,
+	}
+
+	var expiry time.Time
+	if res.ExpiresAt != nil {
+		expiry = *res.ExpiresAt
+	} else if p.cfg.CacheTTL <= 0 {
+		logger.Debug("caching of credentials without an expiry is disabled")
+		return creds, nil, nil
+	}
+	ttl := credentials.CalculateCacheTTL(expiry, tokenCacheExpiryMargin)
+	if ttl == nil {
+		logger.Debug("credentials expire too soon to be worth caching", "expiry", expiry)
+		return creds, nil, nil
+	}
+	logger.Debug("caching credentials", "expiry", expiry, "ttl", *ttl)
+	return creds, ttl, nil
+}
+
+// run executes the helper, writing the provided bytes to its stdin and
+// returning what it wrote to its stdout.
+func (p *Provider) run(ctx context.Context, stdin []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, p.cfg.Command, p.cfg.Args...) // nolint: gosec
+	cmd.Stdin = bytes.NewReader(stdin)
+	stdout := &limitedBuffer{limit: maxOutputBytes}
+	stderr := &limitedBuffer{limit: maxStderrBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Don't wait indefinitely on grandchildren holding the output pipes open
+	// after the helper itself has been killed.
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("credential helper timed out: %w", ctx.Err())
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("error executing credential helper: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("error executing credential helper: %w", err)
+	}
+	if stdout.truncated {
+		return nil, errors.New("credential helper output exceeded maximum size")
+	}
+	return stdout.Bytes(), nil
+}
+
+// limitedBuffer is an io.Writer that retains at most limit bytes, silently
+// discarding the rest so that the process writing to it is never blocked.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining < len(p) {
+		b.truncated = true
+		if remaining > 0 {
+			_, _ = b.Buffer.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// cacheKey returns a cache key in the form of a hash of the request.
+func cacheKey(req Request) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00%s\x00%s", req.Project, req.Type, req.RepoURL)
+	for _, k := range slices.Sorted(maps.Keys(req.Metadata)) {
+		_, _ = fmt.Fprintf(h, "\x00%s=%s", k, req.Metadata[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,331 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+// writeHelper writes an executable shell script to a temporary directory and
+// returns its path.
+func writeHelper(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700)) // nolint: gosec
+	return path
+}
+
+func newTestProvider(t *testing.T, cfg ProviderConfig) *Provider {
+	t.Helper()
+	if len(cfg.RepoURLs) == 0 {
+		cfg.RepoURLs = []string{"glob:**"}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	p := NewProvider(context.Background(), cfg)
+	require.NotNil(t, p)
+	return p.(*Provider) // nolint: forcetypeassert
+}
+
+func TestProviderConfigFromEnv(t *testing.T) {
+	t.Setenv("CREDENTIAL_HELPER_COMMAND", "/opt/helpers/broker")
+	t.Setenv("CREDENTIAL_HELPER_ARGS", `["--scopes","read,write"]`)
+	t.Setenv("CREDENTIAL_HELPER_TYPES", "git,image")
+	t.Setenv("CREDENTIAL_HELPER_REPO_URLS", `["regex:^https://git\\.example\\.com/[a-z]{1,8}/"]`)
+	cfg := ProviderConfigFromEnv()
+	assert.Equal(t, "/opt/helpers/broker", cfg.Command)
+	assert.Equal(t, StringList{"--scopes", "read,write"}, cfg.Args)
+	assert.Equal(t, []string{"git", "image"}, cfg.Types)
+	assert.Equal(t, StringList{`regex:^https://git\.example\.com/[a-z]{1,8}/`}, cfg.RepoURLs)
+}
+
+func TestStringList_Decode(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		l := StringList{"stale"}
+		require.NoError(t, l.Decode(""))
+		assert.Nil(t, l)
+	})
+	t.Run("JSON array", func(t *testing.T) {
+		var l StringList
+		require.NoError(t, l.Decode(`["a,b", "c"]`))
+		assert.Equal(t, StringList{"a,b", "c"}, l)
+	})
+	t.Run("not a JSON array", func(t *testing.T) {
+		var l StringList
+		require.ErrorContains(t, l.Decode("a,b"), "error parsing JSON array of strings")
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       ProviderConfig
+		expectNil bool
+	}{
+		{
+			name:      "no command",
+			cfg:       ProviderConfig{RepoURLs: []string{"glob:**"}, Timeout: time.Second},
+			expectNil: true,
+		},
+		{
+			name:      "no repo URL patterns",
+			cfg:       ProviderConfig{Command: "helper", Timeout: time.Second},
+			expectNil: true,
+		},
+		{
+			name: "invalid repo URL pattern",
+			cfg: ProviderConfig{
+				Command:  "helper",
+				RepoURLs: []string{"regex:("},
+				Timeout:  time.Second,
+			},
+			expectNil: true,
+		},
+		{
+			name: "non-positive timeout",
+			cfg: ProviderConfig{
+				Command:  "helper",
+				RepoURLs: []string{"glob:**"},
+			},
+			expectNil: true,
+		},
+		{
+			name: "valid configuration",
+			cfg: ProviderConfig{
+				Command:  "helper",
+				RepoURLs: []string{"glob:**"},
+				Timeout:  time.Second,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider(context.Background(), tc.cfg)
+			if tc.expectNil {
+				assert.Nil(t, p)
+			} else {
+				assert.NotNil(t, p)
+			}
+		})
+	}
+}
+
+func TestProvider_Supports(t *testing.T) {
+	p := newTestProvider(t, ProviderConfig{
+		Command:  "helper",
+		Types:    []string{"git", " image"},
+		RepoURLs: []string{"glob:https://git.example.com/**", "regex:^registry\\.example\\.com/"},
+	})
+	testCases := []struct {
+		name     string
+		req      credentials.Request
+		expected bool
+	}{
+		{
+			name: "no Secret",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			expected: true,
+		},
+		{
+			name: "Secret without credentials",
+			req: credentials.Request{
+				Type:    credentials.TypeImage,
+				RepoURL: "registry.example.com/image",
+				Data: map[string][]byte{
+					credentials.FieldRepoURL:        []byte("^registry\\.example\\.com/"),
+					credentials.FieldRepoURLIsRegex: []byte("true"),
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "Secret with credentials",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+				Data: map[string][]byte{
+					credentials.FieldRepoURL:  []byte("https://git.example.com/org/repo.git"),
+					credentials.FieldPassword: []byte("secret"),
+				},
+			},
+		},
+		{
+			name: "unsupported type",
+			req: credentials.Request{
+				Type:    credentials.TypeHelm,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+		},
+		{
+			name: "unmatched repo URL",
+			req: credentials.Request{
+				Type:    credentials.TypeGit,
+				RepoURL: "https://github.com/org/repo.git",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			supported, err := p.Supports(context.Background(), tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, supported)
+		})
+	}
+}
+
+func TestProvider_GetCredentials(t *testing.T) {
+	req := credentials.Request{
+		Project:  "my-project",
+		Type:     credentials.TypeGit,
+		RepoURL:  "https://git.example.com/org/repo.git",
+		Metadata: map[string]string{"vault-item": "git"},
+	}
+
+	t.Run("helper receives request on stdin", func(t *testing.T) {
+		stdinPath := filepath.Join(t.TempDir(), "stdin.json")
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, `cat > "$1"; echo '{"username":"u","password":"p"}'`),
+			Args:    []string{stdinPath},
+		})
+		creds, err := p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, &credentials.Credentials{Username: "u", Password: "p"}, creds)
+
+		stdin, err := os.ReadFile(stdinPath)
+		require.NoError(t, err)
+		var helperReq Request
+		require.NoError(t, json.Unmarshal(stdin, &helperReq))
+		assert.Equal(t, Request{
+			APIVersion: APIVersion,
+			Project:    "my-project",
+			Type:       credentials.TypeGit,
+			RepoURL:    "https://git.example.com/org/repo.git",
+			Metadata:   map[string]string{"vault-item": "git"},
+		}, helperReq)
+	})
+
+	t.Run("empty output means no credentials", func(t *testing.T) {
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, "cat > /dev/null"),
+		})
+		creds, err := p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Nil(t, creds)
+	})
+
+	t.Run("response without a password means no credentials", func(t *testing.T) {
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, `echo '{"username":"u"}'`),
+		})
+		creds, err := p.GetCredentials(context.Background(), req)
+		require.NoError(t, err)
+		assert.Nil(t, creds)
+	})
+
+	t.Run("helper fails", func(t *testing.T) {
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, "echo 'vault is sealed' >&2; exit 3"),
+		})
+		_, err := p.GetCredentials(context.Background(), req)
+		require.ErrorContains(t, err, "error executing credential helper")
+		require.ErrorContains(t, err, "exit status 3")
+		require.ErrorContains(t, err, "vault is sealed")
+	})
+
+	t.Run("helper returns invalid JSON", func(t *testing.T) {
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, "echo 'not json'"),
+		})
+		_, err := p.GetCredentials(context.Background(), req)
+		require.ErrorContains(t, err, "error unmarshaling credential helper response")
+	})
+
+	t.Run("helper times out", func(t *testing.T) {
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, "exec sleep 10"),
+			Timeout: 100 * time.Millisecond,
+		})
+		_, err := p.GetCredentials(context.Background(), req)
+		require.Error(t, err)
+	})
+
+	t.Run("credentials with a distant expiry are cached", func(t *testing.T) {
+		countPath := filepath.Join(t.TempDir(), "count")
+		expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(
+				t,
+				`echo x >> "$1"; echo '{"username":"u","password":"p","expiresAt":"`+expiresAt+`"}'`,
+			),
+			Args: []string{countPath},
+		})
+		for range 3 {
+			creds, err := p.GetCredentials(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, "p", creds.Password)
+		}
+		count, err := os.ReadFile(countPath)
+		require.NoError(t, err)
+		assert.Equal(t, "x\n", string(count))
+	})
+
+	t.Run("credentials expiring soon are not cached", func(t *testing.T) {
+		countPath := filepath.Join(t.TempDir(), "count")
+		expiresAt := time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(
+				t,
+				`echo x >> "$1"; echo '{"password":"p","expiresAt":"`+expiresAt+`"}'`,
+			),
+			Args: []string{countPath},
+		})
+		for range 2 {
+			_, err := p.GetCredentials(context.Background(), req)
+			require.NoError(t, err)
+		}
+		count, err := os.ReadFile(countPath)
+		require.NoError(t, err)
+		assert.Equal(t, "x\nx\n", string(count))
+	})
+
+	t.Run("caching without expiry can be disabled", func(t *testing.T) {
+		countPath := filepath.Join(t.TempDir(), "count")
+		p := newTestProvider(t, ProviderConfig{
+			Command: writeHelper(t, `echo x >> "$1"; echo '{"password":"p"}'`),
+			Args:    []string{countPath},
+		})
+		p.cfg.CacheTTL = 0
+		for range 2 {
+			_, err := p.GetCredentials(context.Background(), req)
+			require.NoError(t, err)
+		}
+		count, err := os.ReadFile(countPath)
+		require.NoError(t, err)
+		assert.Equal(t, "x\nx\n", string(count))
+	})
+}
+
+func Test_limitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 5}
+	n, err := b.Write([]byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.False(t, b.truncated)
+	n, err = b.Write([]byte("defg"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.True(t, b.truncated)
+	assert.Equal(t, "abcde", b.String())
+}
@@ -178,9 +178,24 @@ func (k *database) getCredentialsSecret(
 		return nil, err
 	}
 
+	return SelectSecret(ctx, secrets.Items, credType, repoURL), nil
+}
+
+// SelectSecret returns the credentials Secret, from among those provided, that
+// would be used for accessing the specified repository. If more than one
+// Secret matches, the first by name is selected. If none match, nil is
+// returned. All the provided Secrets are assumed to be from the same namespace
+// and labeled with the specified credential type.
+func SelectSecret(
+	ctx context.Context,
+	secrets []corev1.Secret,
+	credType credentials.Type,
+	repoURL string,
+) *corev1.Secret {
 	// Sort the secrets for consistent ordering every time this function is
 	// called.
-	slices.SortFunc(secrets.Items, func(lhs, rhs corev1.Secret) int {
+	secrets = slices.Clone(secrets)
+	slices.SortFunc(secrets, func(lhs, rhs corev1.Secret) int {
 		return strings.Compare(lhs.Name, rhs.Name)
 	})
 
@@ -189,7 +204,7 @@ func (k *database) getCredentialsSecret(
 	logger := logging.LoggerFromContext(ctx)
 
 	// Search for a matching Secret.
-	for _, secret := range secrets.Items {
+	for _, secret := range secrets {
 		if secret.Data == nil {
 			continue
 		}
@@ -205,7 +220,7 @@ func (k *database) getCredentialsSecret(
 			if err != nil {
 				logger.Error(
 					err, "failed to compile regex for credential secret",
-					"namespace", namespace,
+					"namespace", secret.Namespace,
 					"secret", secret.Name,
 				)
 				continue
@@ -214,17 +229,17 @@ func (k *database) getCredentialsSecret(
 			// the original repoURL and the normalized one.
 			// For more details see: https://github.com/akuity/kargo/issues/4833
 			if regex.MatchString(repoURL) || regex.MatchString(normalizedRepoURL) {
-				return &secret, nil
+				return &secret
 			}
 			continue
 		}
 
 		// Not a regex
 		if normalizeRepoURL(credType, string(urlBytes)) == normalizedRepoURL {
-			return &secret, nil
+			return &secret
 		}
 	}
-	return nil, nil
+	return nil
 }
 
 func normalizeRepoURL(credType credentials.Type, repoURL string) string {
@@ -0,0 +1,562 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/kelseyhightower/envconfig"
+
+	"github.com/akuity/kargo/pkg/cache/coalescing"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/pattern"
+)
+
+const (
+	// EngineKVv2 denotes a secret stored in a version 2 key/value secrets engine.
+	// Such a secret is read from the engine's "data/" endpoint and does not
+	// carry a lease.
+	EngineKVv2 Engine = "kv-v2"
+	// EngineDynamic denotes a secret generated on demand by a dynamic secrets
+	// engine (e.g. the database or AWS engines). Such a secret carries a lease
+	// that bounds how long it may be used for.
+	EngineDynamic Engine = "dynamic"
+
+	// projectPlaceholder, when it appears in the path of a Mapping, is replaced
+	// with the name of the Project on whose behalf credentials are requested.
+	projectPlaceholder = "{project}"
+
+	// namespaceHeader is the header used to select a Vault Enterprise or OpenBao
+	// namespace.
+	namespaceHeader = "X-Vault-Namespace"
+	// tokenHeader is the header used to present a Vault token.
+	tokenHeader = "X-Vault-Token"
+
+	defaultUsernameKey = "username"
+	defaultPasswordKey = "password"
+
+	// loginCacheKey is the key under which the token obtained by logging in to
+	// Vault is cached. There is only ever one such token.
+	loginCacheKey = "login"
+
+	tokenCacheExpiryMargin = 5 * time.Minute
+
+	// requestTimeout bounds a single round trip to Vault, including the login
+	// that may precede it. Because loads execute under a context detached from
+	// any caller's, this is the only thing bounding their duration.
+	requestTimeout = 30 * time.Second
+
+	// maxResponseBytes bounds how much of a response from Vault is read.
+	maxResponseBytes = 1 << 20
+)
+
+func init() {
+	if !credentials.ProvidersEnabled() {
+		return
+	}
+	cfg := ProviderConfigFromEnv()
+	if provider := NewProvider(context.Background(), cfg); provider != nil {
+		credentials.DefaultProviderRegistry.MustRegister(
+			credentials.ProviderRegistration{
+				Predicate: provider.Supports,
+				Value:     provider,
+			},
+		)
+	}
+}
+
+// Engine identifies the kind of Vault secrets engine a Mapping reads from.
+type Engine string
+
+// ProviderConfig represents configuration for the Vault credentials provider.
+// The names of the environment variables for the server address and namespace
+// are the same as those recognized by the Vault and OpenBao CLIs.
+type ProviderConfig struct {
+	// Address is the base URL of the Vault or OpenBao server. When empty, the
+	// provider is disabled.
+	Address string `envconfig:"VAULT_ADDR"`
+	// Namespace is the Vault Enterprise or OpenBao namespace in which all
+	// requests are made. It is optional.
+	Namespace string `envconfig:"VAULT_NAMESPACE"`
+	// AuthMount is the path at which the Kubernetes auth method is mounted.
+	AuthMount string `envconfig:"VAULT_AUTH_MOUNT" default:"kubernetes"`
+	// Role is the Kubernetes auth method role the controller logs in as.
+	Role string `envconfig:"VAULT_ROLE"`
+	// ServiceAccountTokenPath is the path to the controller's ServiceAccount
+	// token, which is presented to Vault when logging in.
+	ServiceAccountTokenPath string `envconfig:"VAULT_SERVICE_ACCOUNT_TOKEN_PATH" default:"/var/run/secrets/kubernetes.io/serviceaccount/token"` // nolint: lll
+	// Mappings is a JSON array of Mappings that determine which Vault secret,
+	// if any, supplies credentials for a given request.
+	Mappings string `envconfig:"VAULT_CREDENTIAL_MAPPINGS"`
+	// CacheTTL is how long credentials without a lease (e.g. those read from a
+	// key/value secrets engine) are cached for.
+	CacheTTL time.Duration `envconfig:"VAULT_CREDENTIAL_CACHE_TTL" default:"5m"`
+}
+
+// ProviderConfigFromEnv returns a ProviderConfig populated from environment
+// variables.
+func ProviderConfigFromEnv() ProviderConfig {
+	cfg := ProviderConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// Mapping associates credential requests with a Vault secret.
+type Mapping struct {
+	// Project is a pattern (exact, "glob:" or "regex:") matched against the name
+	// of the Project on whose behalf credentials are requested. When empty, all
+	// Projects are matched.
+	Project string `json:"project,omitempty"`
+	// Type, when non-empty, restricts the Mapping to requests for credentials of
+	// that type.
+	Type credentials.Type `json:"type,omitempty"`
+	// RepoURL is a pattern (exact, "glob:" or "regex:") matched against the URL
+	// of the repository for which credentials are requested.
+	RepoURL string `json:"repoURL"`
+	// Engine is the kind of secrets engine the secret is read from.
+	Engine Engine `json:"engine"`
+	// Path is the API path of the secret, relative to /v1/. For a key/value
+	// secrets engine, this includes the "data/" segment (e.g.
+	// "secret/data/kargo/{project}/git"). Any occurrence of "{project}" is
+	// replaced with the name of the Project.
+	Path string `json:"path"`
+	// Username, when non-empty, is used as the username regardless of the
+	// contents of the secret. This is useful when the secret contains a token
+	// only.
+	Username string `json:"username,omitempty"`
+	// UsernameKey is the key within the secret holding the username. It
+	// defaults to "username".
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// PasswordKey is the key within the secret holding the password. It
+	// defaults to "password".
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// mapping is a Mapping whose patterns have been parsed.
+type mapping struct {
+	Mapping
+	project pattern.Matcher
+	repoURL pattern.Matcher
+}
+
+func (m *mapping) matches(req credentials.Request) bool {
+	if m.Type != "" && m.Type != req.Type {
+		return false
+	}
+	if m.project != nil && !m.project.Matches(req.Project) {
+		return false
+	}
+	return m.repoURL.Matches(req.RepoURL)
+}
+
+// parseMappings parses and validates a JSON array of Mappings.
+func parseMappings(raw string) ([]mapping, error) {
+	var ms []Mapping
+	if err := json.Unmarshal([]byte(raw), &ms); err != nil {
+		return nil, fmt.Errorf("error unmarshaling mappings: %w", err)
+	}
+	mappings := make([]mapping, len(ms))
+	for i, m := range ms {
+		if m.RepoURL == "" {
+			return nil, fmt.Errorf("mapping %d: repoURL must not be empty", i)
+		}
+		if m.Path == "" {
+			return nil, fmt.Errorf("mapping %d: path must not be empty", i)
+		}
+		switch m.Engine {
+		case EngineKVv2, EngineDynamic:
+		default:
+			return nil, fmt.Errorf(
+				"mapping %d: unsupported engine %q; must be %q or %q",
+				i, m.Engine, EngineKVv2, EngineDynamic,
+			)
+		}
+		if m.UsernameKey == "" {
+			m.UsernameKey = defaultUsernameKey
+		}
+		if m.PasswordKey == "" {
+			m.PasswordKey = defaultPasswordKey
+		}
+		mappings[i] = mapping{Mapping: m}
+		var err error
+		if m.Project != "" {
+			if mappings[i].project, err = pattern.ParseNamePattern(m.Project); err != nil {
+				return nil, fmt.Errorf("mapping %d: error parsing project pattern: %w", i, err)
+			}
+		}
+		if mappings[i].repoURL, err = pattern.ParseNamePattern(m.RepoURL); err != nil {
+			return nil, fmt.Errorf("mapping %d: error parsing repoURL pattern: %w", i, err)
+		}
+	}
+	return mappings, nil
+}
+
+// secretInput identifies the secret a load should read. The cache key is a
+// hash of these values, so they cannot be recovered from it.
+type secretInput struct {
+	mapping *mapping
+	path    string
+}
+
+// Provider is an implementation of credentials.Provider that reads credentials
+// from HashiCorp Vault or OpenBao, authenticating using the Kubernetes auth
+// method.
+type Provider struct {
+	cfg      ProviderConfig
+	mappings []mapping
+
+	httpClient *http.Client
+
+	// loginCache holds the Vault token obtained by logging in. It fills its own
+	// misses and is refreshed as the token approaches expiry.
+	loginCache coalescing.Cache[struct{}, string]
+
+	// secretCache holds credentials keyed by a hash of the path of the secret
+	// they were read from and how they were extracted from it. It fills its own
+	// misses, coalescing concurrent reads for any given key.
+	secretCache coalescing.Cache[secretInput, *credentials.Credentials]
+}
+
+// NewProvider returns an implementation of credentials.Provider backed by
+// Vault. It returns nil if the provider is not configured or is configured
+// incorrectly.
+func NewProvider(ctx context.Context, cfg ProviderConfig) credentials.Provider {
+	logger := logging.LoggerFromContext(ctx)
+	if cfg.Address == "" {
+		logger.Info("VAULT_ADDR is not set; Vault credentials integration will be disabled")
+		return nil
+	}
+	if cfg.Role == "" {
+		logger.Error(
+			nil, "VAULT_ROLE is not set; Vault credentials integration will be disabled",
+		)
+		return nil
+	}
+	mappings, err := parseMappings(cfg.Mappings)
+	if err != nil {
+		logger.Error(
+			err, "error parsing Vault credential mappings; Vault credentials integration will be disabled",
+		)
+		return nil
+	}
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+	p := &Provider{
+		cfg:        cfg,
+		mappings:   mappings,
+		httpClient: cleanhttp.DefaultPooledClient(),
+	}
+	if p.loginCache, err = coalescing.NewCache(
+		p.loadLoginToken,
+		&coalescing.CacheOptions{
+			LoadTimeout:     new(requestTimeout),
+			DefaultTTL:      new(cfg.CacheTTL),
+			CleanupInterval: new(time.Hour),
+		},
+	); err != nil {
+		logger.Error(
+			err, "error creating login cache; Vault credentials integration will be disabled",
+		)
+		return nil
+	}
+	if p.secretCache, err = coalescing.NewCache(
+		p.loadCredentials,
+		&coalescing.CacheOptions{
+			// A load may have to log in before reading the secret.
+			LoadTimeout: new(2 * requestTimeout),
+			// Secrets from a key/value secrets engine carry no lease. They are
+			// cached for this long. When a lease is available, it is used (minus
+			// a safety margin) instead of this default.
+			DefaultTTL:      new(cfg.CacheTTL),
+			CleanupInterval: new(time.Hour),
+		},
+	); err != nil {
+		logger.Error(
+			err, "error creating credentials cache; Vault credentials integration will be disabled",
+		)
+		return nil
+	}
+	logger.Info(
+		"Vault credentials integration enabled",
+		"address", cfg.Address,
+		"mappings", len(mappings),
+	)
+	return p
+}
+
+// Supports implements credentials.Provider. It returns true if any Mapping
+// matches the request.
+func (p *Provider) Supports(
+	_ context.Context,
+	req credentials.Request,
+) (bool, error) {
+	return p.mappingFor(req) != nil, nil
+}
+
+// GetCredentials implements credentials.Provider. It reads credentials from
+// the secret identified by the first Mapping that matches the request.
+func (p *Provider) GetCredentials(
+	ctx context.Context,
+	req credentials.Request,
+) (*credentials.Credentials, error) {
+	m := p.mappingFor(req)
+	if m == nil {
+		return nil, nil
+	}
+	path := strings.Trim(strings.ReplaceAll(m.Path, projectPlaceholder, req.Project), "/")
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"provider", "vault",
+		"repoURL", req.RepoURL,
+		"path", path,
+	)
+	ctx = logging.ContextWithLogger(ctx, logger)
+	creds, err := p.secretCache.Get(
+		ctx,
+		cacheKey(string(m.Engine), path, m.Username, m.UsernameKey, m.PasswordKey),
+		secretInput{mapping: m, path: path},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, nil
+	}
+	// Hand the caller a copy so nothing it does can alter the cached value.
+	c := *creds
+	return &c, nil
+}
+
+func (p *Provider) mappingFor(req credentials.Request) *mapping {
+	for i := range p.mappings {
+		if p.mappings[i].matches(req) {
+			return &p.mappings[i]
+		}
+	}
+	return nil
+}
+
+// loadCredentials reads the secret identified by the input and extracts
+// credentials from it. It is the Loader for this provider's secret cache.
+func (p *Provider) loadCredentials(
+	ctx context.Context,
+	input secretInput,
+) (*credentials.Credentials, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	token, err := p.loginCache.Get(ctx, loginCacheKey, struct{}{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret, err := p.read(ctx, token, input.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if secret == nil {
+		// No secret at this path. We treat this as no credentials found.
+		logger.Debug("no secret found")
+		return nil, nil, nil
+	}
+
+	data := secret.Data
+	if input.mapping.Engine == EngineKVv2 {
+		var kv struct {
+			Data map[string]any `json:"data"`
+		}
+		if err = json.Unmarshal(secret.RawData, &kv); err != nil {
+			return nil, nil, fmt.Errorf("error unmarshaling key/value secret: %w", err)
+		}
+		data = kv.Data
+	}
+
+	creds := &credentials.Credentials{Username: input.mapping.Username}
+	if creds.Username == "" {
+		if creds.Username, err = stringValue(data, input.mapping.UsernameKey); err != nil {
+			return nil, nil, err
+		}
+	}
+	if creds.Password, err = stringValue(data, input.mapping.PasswordKey); err != nil {
+		return nil, nil, err
+	}
+
+	var expiry time.Time
+	if secret.LeaseDuration > 0 {
+		expiry = time.Now().Add(time.Duration(secret.LeaseDuration) * time.Second)
+	}
+	ttl := credentials.CalculateCacheTTL(expiry, tokenCacheExpiryMargin)
+	if ttl == nil {
+		logger.Debug("lease expires too soon to be worth caching", "expiry", expiry)
+		return creds, nil, nil
+	}
+	logger.Debug("caching credentials", "expiry", expiry, "ttl", *ttl)
+	return creds, ttl, nil
+}
+
+// loadLoginToken logs in to Vault using the Kubernetes auth method. It is the
+// Loader for this provider's login cache.
+func (p *Provider) loadLoginToken(
+	ctx context.Context,
+	_ struct{},
+) (string, *time.Duration, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	jwt, err := os.ReadFile(p.cfg.ServiceAccountTokenPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading ServiceAccount token: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{
+		"role": p.cfg.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("error marshaling login request: %w", err)
+	}
+
+	var res struct {
+		Auth *struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	found, err := p.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("auth/%s/login", strings.Trim(p.cfg.AuthMount, "/")),
+		"",
+		body,
+		&res,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("error logging in to Vault: %w", err)
+	}
+	if !found || res.Auth == nil || res.Auth.ClientToken == "" {
+		return "", nil, errors.New("error logging in to Vault: no token returned")
+	}
+	logger.Debug("logged in to Vault")
+
+	var expiry time.Time
+	if res.Auth.LeaseDuration > 0 {
+		expiry = time.Now().Add(time.Duration(res.Auth.LeaseDuration) * time.Second)
+	}
+	return res.Auth.ClientToken, credentials.CalculateCacheTTL(expiry, tokenCacheExpiryMargin), nil
+}
+
+// secret is the subset of a Vault secret response used by this provider.
+type secret struct {
+	LeaseDuration int64           `json:"lease_duration"`
+	RawData       json.RawMessage `json:"data"`
+	Data          map[string]any  `json:"-"`
+}
+
+// read reads the secret at the specified path. A nil secret and nil error are
+// returned if there is no secret at that path.
+func (p *Provider) read(ctx context.Context, token, path string) (*secret, error) {
+	s := &secret{}
+	found, err := p.do(ctx, http.MethodGet, path, token, nil, s)
+	if err != nil {
+		return nil, fmt.Errorf("error reading secret from Vault: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	if len(s.RawData) > 0 {
+		if err = json.Unmarshal(s.RawData, &s.Data); err != nil {
+			return nil, fmt.Errorf("error unmarshaling secret: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// do makes a request to the Vault API and unmarshals the response into res. It
+// returns false and a nil error if Vault responds with a 404.
+func (p *Provider) do(
+	ctx context.Context,
+	method string,
+	path string,
+	token string,
+	body []byte,
+	res any,
+) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		ctx,
+		method,
+		fmt.Sprintf("%s/v1/%s", p.cfg.Address, path),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return false, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(tokenHeader, token)
+	}
+	if p.cfg.Namespace != "" {
+		req.Header.Set(namespaceHeader, p.cfg.Namespace)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	resBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return false, fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errRes struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(resBody, &errRes)
+		if len(errRes.Errors) > 0 {
+			return false, fmt.Errorf(
+				"unexpected status code %d: %s",
+				resp.StatusCode, strings.Join(errRes.Errors, "; "),
+			)
+		}
+		return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if err = json.Unmarshal(resBody, res); err != nil {
+		return false, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	return true, nil
+}
+
+// stringValue returns the string value of the specified key in a secret's
+// data.
+func stringValue(data map[string]any, key string) (string, error) {
+	val, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", key)
+	}
+	str, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("value of key %q in secret is not a string", key)
+	}
+	return str, nil
+}
+
+// cacheKey returns a cache key in the form of a hash for the given parts.
+func cacheKey(parts ...string) string {
+	h := sha256.New()
+	for i := range parts {
+		if i > 0 {
+			_, _ = h.Write([]byte(":"))
+		}
+		_, _ = h.Write([]byte(parts[i]))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
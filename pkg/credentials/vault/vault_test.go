@@ -0,0 +1,484 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+const (
+	testRole      = "kargo"
+	testJWT       = "fake-service-account-token"
+	testToken     = "fake-vault-token"
+	testNamespace = "team-a"
+)
+
+// fakeVault is a minimal fake of the Vault HTTP API. It supports the
+// Kubernetes auth method login endpoint and reads of secrets registered in its
+// secrets map.
+type fakeVault struct {
+	t       *testing.T
+	secrets map[string]map[string]any
+	logins  atomic.Int32
+	reads   atomic.Int32
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(namespaceHeader) != testNamespace {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/auth/kubernetes/login" {
+		f.logins.Add(1)
+		var req map[string]string
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		if req["role"] != testRole || req["jwt"] != testJWT {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(
+			`{"auth":{"client_token":"` + testToken + `","lease_duration":3600}}`,
+		))
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get(tokenHeader) != testToken {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+	f.reads.Add(1)
+	s, ok := f.secrets[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+		return
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(s))
+}
+
+func newTestProvider(t *testing.T, role string, mappings []Mapping) (*Provider, *fakeVault) {
+	fake := &fakeVault{
+		t: t,
+		secrets: map[string]map[string]any{
+			"/v1/secret/data/kargo/team-a/git": {
+				"lease_duration": 0,
+				"data": map[string]any{
+					"data": map[string]any{
+						"username": "git-user",
+						"password": "git-pass",
+					},
+					"metadata": map[string]any{"version": 3},
+				},
+			},
+			"/v1/secret/data/kargo/team-a/registry": {
+				"data": map[string]any{
+					"data": map[string]any{"token": "registry-token"},
+				},
+			},
+			"/v1/secret/data/kargo/team-a/bad": {
+				"data": map[string]any{
+					"data": map[string]any{"username": "u", "password": 42},
+				},
+			},
+			"/v1/artifactory/token/kargo": {
+				"lease_id":       "artifactory/token/kargo/abc",
+				"lease_duration": 1800,
+				"renewable":      false,
+				"data": map[string]any{
+					"username":     "dynamic-user",
+					"access_token": "dynamic-token",
+				},
+			},
+			"/v1/artifactory/token/short": {
+				"lease_duration": 60,
+				"data": map[string]any{
+					"username": "short-user",
+					"password": "short-pass",
+				},
+			},
+		},
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte(testJWT+"\n"), 0o600))
+
+	rawMappings, err := json.Marshal(mappings)
+	require.NoError(t, err)
+
+	provider := NewProvider(context.Background(), ProviderConfig{
+		Address:                 srv.URL + "/",
+		Namespace:               testNamespace,
+		AuthMount:               "kubernetes",
+		Role:                    role,
+		ServiceAccountTokenPath: tokenPath,
+		Mappings:                string(rawMappings),
+		CacheTTL:                5 * time.Minute,
+	})
+	require.NotNil(t, provider)
+	return provider.(*Provider), fake // nolint: forcetypeassert
+}
+
+func TestNewProvider(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cfg       ProviderConfig
+		expectNil bool
+	}{
+		{
+			name:      "no address",
+			cfg:       ProviderConfig{Role: testRole, Mappings: "[]"},
+			expectNil: true,
+		},
+		{
+			name:      "no role",
+			cfg:       ProviderConfig{Address: "https://vault", Mappings: "[]"},
+			expectNil: true,
+		},
+		{
+			name: "invalid mappings",
+			cfg: ProviderConfig{
+				Address:  "https://vault",
+				Role:     testRole,
+				Mappings: "not json",
+			},
+			expectNil: true,
+		},
+		{
+			name: "mapping with unsupported engine",
+			cfg: ProviderConfig{
+				Address:  "https://vault",
+				Role:     testRole,
+				Mappings: `[{"repoURL":"x","engine":"kv-v1","path":"secret/x"}]`,
+			},
+			expectNil: true,
+		},
+		{
+			name: "mapping with no path",
+			cfg: ProviderConfig{
+				Address:  "https://vault",
+				Role:     testRole,
+				Mappings: `[{"repoURL":"x","engine":"kv-v2"}]`,
+			},
+			expectNil: true,
+		},
+		{
+			name: "mapping with invalid pattern",
+			cfg: ProviderConfig{
+				Address:  "https://vault",
+				Role:     testRole,
+				Mappings: `[{"repoURL":"regex:(","engine":"kv-v2","path":"secret/x"}]`,
+			},
+			expectNil: true,
+		},
+		{
+			name: "valid configuration",
+			cfg: ProviderConfig{
+				Address:  "https://vault",
+				Role:     testRole,
+				Mappings: `[{"repoURL":"glob:*","engine":"dynamic","path":"x/y"}]`,
+				CacheTTL: time.Minute,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider(context.Background(), tc.cfg)
+			if tc.expectNil {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			vp, ok := p.(*Provider)
+			require.True(t, ok)
+			require.Len(t, vp.mappings, 1)
+			assert.Equal(t, defaultUsernameKey, vp.mappings[0].UsernameKey)
+			assert.Equal(t, defaultPasswordKey, vp.mappings[0].PasswordKey)
+		})
+	}
+}
+
+func TestProvider_Supports(t *testing.T) {
+	p, _ := newTestProvider(t, testRole, []Mapping{
+		{
+			Project: "glob:team-*",
+			Type:    credentials.TypeGit,
+			RepoURL: "glob:https://git.example.com/**",
+			Engine:  EngineKVv2,
+			Path:    "secret/data/kargo/{project}/git",
+		},
+		{
+			RepoURL: "regex:^registry\\.example\\.com/",
+			Engine:  EngineKVv2,
+			Path:    "secret/data/kargo/{project}/registry",
+		},
+	})
+	testCases := []struct {
+		name     string
+		req      credentials.Request
+		expected bool
+	}{
+		{
+			name: "matches project, type and repo URL",
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			expected: true,
+		},
+		{
+			name: "project does not match",
+			req: credentials.Request{
+				Project: "other",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+		},
+		{
+			name: "type does not match",
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeHelm,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+		},
+		{
+			name: "mapping without project or type matches any",
+			req: credentials.Request{
+				Project: "anything",
+				Type:    credentials.TypeImage,
+				RepoURL: "registry.example.com/image",
+			},
+			expected: true,
+		},
+		{
+			name: "repo URL does not match",
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeImage,
+				RepoURL: "docker.io/library/nginx",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			supported, err := p.Supports(context.Background(), tc.req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, supported)
+		})
+	}
+}
+
+func TestProvider_GetCredentials(t *testing.T) {
+	testCases := []struct {
+		name       string
+		role       string
+		mapping    Mapping
+		req        credentials.Request
+		assertions func(*testing.T, *fakeVault, *credentials.Credentials, error)
+	}{
+		{
+			name: "no matching mapping",
+			mapping: Mapping{
+				RepoURL: "https://git.example.com/org/repo.git",
+				Engine:  EngineKVv2,
+				Path:    "secret/data/kargo/{project}/git",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/other.git",
+			},
+			assertions: func(t *testing.T, f *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Nil(t, creds)
+				assert.Zero(t, f.logins.Load())
+			},
+		},
+		{
+			name: "login denied",
+			role: "wrong-role",
+			mapping: Mapping{
+				RepoURL: "glob:**",
+				Engine:  EngineKVv2,
+				Path:    "secret/data/kargo/{project}/git",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			assertions: func(t *testing.T, _ *fakeVault, _ *credentials.Credentials, err error) {
+				require.ErrorContains(t, err, "error logging in to Vault")
+				require.ErrorContains(t, err, "permission denied")
+			},
+		},
+		{
+			name: "secret not found",
+			mapping: Mapping{
+				RepoURL: "glob:**",
+				Engine:  EngineKVv2,
+				Path:    "secret/data/kargo/{project}/missing",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			assertions: func(t *testing.T, _ *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Nil(t, creds)
+			},
+		},
+		{
+			name: "value is not a string",
+			mapping: Mapping{
+				RepoURL: "glob:**",
+				Engine:  EngineKVv2,
+				Path:    "secret/data/kargo/{project}/bad",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			assertions: func(t *testing.T, _ *fakeVault, _ *credentials.Credentials, err error) {
+				require.ErrorContains(t, err, `value of key "password" in secret is not a string`)
+			},
+		},
+		{
+			name: "key missing from secret",
+			mapping: Mapping{
+				RepoURL: "glob:**",
+				Engine:  EngineKVv2,
+				Path:    "secret/data/kargo/{project}/registry",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeImage,
+				RepoURL: "registry.example.com/image",
+			},
+			assertions: func(t *testing.T, _ *fakeVault, _ *credentials.Credentials, err error) {
+				require.ErrorContains(t, err, `secret has no key "username"`)
+			},
+		},
+		{
+			name: "key/value secret is cached",
+			mapping: Mapping{
+				Project: "team-a",
+				RepoURL: "glob:https://git.example.com/**",
+				Engine:  EngineKVv2,
+				Path:    "/secret/data/kargo/{project}/git",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeGit,
+				RepoURL: "https://git.example.com/org/repo.git",
+			},
+			assertions: func(t *testing.T, f *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &credentials.Credentials{
+					Username: "git-user",
+					Password: "git-pass",
+				}, creds)
+				assert.Equal(t, int32(1), f.logins.Load())
+				assert.Equal(t, int32(1), f.reads.Load())
+			},
+		},
+		{
+			name: "static username and custom password key",
+			mapping: Mapping{
+				RepoURL:     "glob:registry.example.com/*",
+				Engine:      EngineKVv2,
+				Path:        "secret/data/kargo/{project}/registry",
+				Username:    "kargo",
+				PasswordKey: "token",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypeImage,
+				RepoURL: "registry.example.com/image",
+			},
+			assertions: func(t *testing.T, _ *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &credentials.Credentials{
+					Username: "kargo",
+					Password: "registry-token",
+				}, creds)
+			},
+		},
+		{
+			name: "dynamic secret is cached until lease expiry",
+			mapping: Mapping{
+				RepoURL:     "glob:**",
+				Engine:      EngineDynamic,
+				Path:        "artifactory/token/kargo",
+				PasswordKey: "access_token",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypePackage,
+				RepoURL: "https://artifactory.example.com/npm",
+			},
+			assertions: func(t *testing.T, f *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &credentials.Credentials{
+					Username: "dynamic-user",
+					Password: "dynamic-token",
+				}, creds)
+				assert.Equal(t, int32(1), f.reads.Load())
+			},
+		},
+		{
+			name: "dynamic secret expiring soon is not cached",
+			mapping: Mapping{
+				RepoURL: "glob:**",
+				Engine:  EngineDynamic,
+				Path:    "artifactory/token/short",
+			},
+			req: credentials.Request{
+				Project: "team-a",
+				Type:    credentials.TypePackage,
+				RepoURL: "https://artifactory.example.com/npm",
+			},
+			assertions: func(t *testing.T, f *fakeVault, creds *credentials.Credentials, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "short-pass", creds.Password)
+				// Both reads reached Vault, but the login token was cached.
+				assert.Equal(t, int32(2), f.reads.Load())
+				assert.Equal(t, int32(1), f.logins.Load())
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			role := tc.role
+			if role == "" {
+				role = testRole
+			}
+			p, fake := newTestProvider(t, role, []Mapping{tc.mapping})
+			// Request twice to exercise caching.
+			_, _ = p.GetCredentials(context.Background(), tc.req)
+			creds, err := p.GetCredentials(context.Background(), tc.req)
+			tc.assertions(t, fake, creds, err)
+		})
+	}
+}
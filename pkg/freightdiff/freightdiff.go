@@ -0,0 +1,417 @@
+package freightdiff
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/git"
+)
+
+// ChangeType indicates how an artifact differs between two pieces of Freight.
+type ChangeType string // @name FreightDiffChangeType
+
+const (
+	// ChangeTypeAdded indicates an artifact that is referenced only by the
+	// newer piece of Freight.
+	ChangeTypeAdded ChangeType = "Added"
+	// ChangeTypeRemoved indicates an artifact that is referenced only by the
+	// older piece of Freight.
+	ChangeTypeRemoved ChangeType = "Removed"
+	// ChangeTypeChanged indicates an artifact that is referenced by both pieces
+	// of Freight, but at different versions.
+	ChangeTypeChanged ChangeType = "Changed"
+	// ChangeTypeUnchanged indicates an artifact that is referenced by both
+	// pieces of Freight at the same version.
+	ChangeTypeUnchanged ChangeType = "Unchanged"
+)
+
+// maxCommits is the maximum number of commits listed for any one direction of
+// a single CommitChange.
+const maxCommits = 100
+
+// commitIDRegex matches the abbreviated or full SHA-1 or SHA-256 ID of a Git
+// commit. IDs that do not match are never passed to Git.
+var commitIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+
+// Diff describes the differences between two pieces of Freight.
+type Diff struct {
+	// From is the name of the older piece of Freight.
+	From string `json:"from"`
+	// To is the name of the newer piece of Freight.
+	To string `json:"to"`
+	// Commits describes differences between the Git commits referenced by
+	// each piece of Freight.
+	Commits []CommitChange `json:"commits,omitempty"`
+	// Images describes differences between the container images referenced
+	// by each piece of Freight.
+	Images []ImageChange `json:"images,omitempty"`
+	// Charts describes differences between the Helm charts referenced by each
+	// piece of Freight.
+	Charts []ChartChange `json:"charts,omitempty"`
+	// Artifacts describes differences between the generic artifacts
+	// referenced by each piece of Freight.
+	Artifacts []ArtifactChange `json:"artifacts,omitempty"`
+} // @name FreightDiff
+
+// CommitChange describes the difference between the commits from a single Git
+// repository referenced by two pieces of Freight.
+type CommitChange struct {
+	// RepoURL is the URL of the Git repository.
+	RepoURL string `json:"repoURL"`
+	// Type indicates how the commits differ.
+	Type ChangeType `json:"type"`
+	// From is the commit referenced by the older piece of Freight.
+	From *Commit `json:"from,omitempty"`
+	// To is the commit referenced by the newer piece of Freight.
+	To *Commit `json:"to,omitempty"`
+	// Added are the commits in the history of To, but not From, ordered from
+	// newest to oldest.
+	Added []Commit `json:"added,omitempty"`
+	// Removed are the commits in the history of From, but not To, ordered from
+	// newest to oldest. These are only present when the newer piece of Freight
+	// rolls back, or diverges from, the older one.
+	Removed []Commit `json:"removed,omitempty"`
+	// Truncated indicates that Added or Removed were truncated.
+	Truncated bool `json:"truncated,omitempty"`
+	// Error, if non-empty, explains why the commits between From and To could
+	// not be listed.
+	Error string `json:"error,omitempty"`
+} // @name FreightDiffCommitChange
+
+// Commit describes a single Git commit.
+type Commit struct {
+	// ID is the ID (SHA) of the commit.
+	ID string `json:"id"`
+	// Tag is the tag through which the commit was selected, if any.
+	Tag string `json:"tag,omitempty"`
+	// Subject is the first line of the commit message.
+	Subject string `json:"subject,omitempty"`
+	// Author is the author of the commit, in the format "Name <email>".
+	Author string `json:"author,omitempty"`
+	// Date is the date of the commit.
+	Date *time.Time `json:"date,omitempty"`
+	// URL is a link to the commit in the Git hosting provider's UI, if one
+	// could be inferred.
+	URL string `json:"url,omitempty"`
+} // @name FreightDiffCommit
+
+// ImageChange describes the difference between the versions of a single
+// container image referenced by two pieces of Freight.
+type ImageChange struct {
+	// RepoURL is the URL of the image repository.
+	RepoURL string `json:"repoURL"`
+	// Type indicates how the images differ.
+	Type ChangeType `json:"type"`
+	// FromTag is the tag referenced by the older piece of Freight.
+	FromTag string `json:"fromTag,omitempty"`
+	// FromDigest is the digest referenced by the older piece of Freight.
+	FromDigest string `json:"fromDigest,omitempty"`
+	// ToTag is the tag referenced by the newer piece of Freight.
+	ToTag string `json:"toTag,omitempty"`
+	// ToDigest is the digest referenced by the newer piece of Freight.
+	ToDigest string `json:"toDigest,omitempty"`
+} // @name FreightDiffImageChange
+
+// ChartChange describes the difference between the versions of a single Helm
+// chart referenced by two pieces of Freight.
+type ChartChange struct {
+	// RepoURL is the URL of the chart repository.
+	RepoURL string `json:"repoURL"`
+	// Name is the name of the chart. It is empty for charts in OCI
+	// repositories.
+	Name string `json:"name,omitempty"`
+	// Type indicates how the charts differ.
+	Type ChangeType `json:"type"`
+	// FromVersion is the version referenced by the older piece of Freight.
+	FromVersion string `json:"fromVersion,omitempty"`
+	// ToVersion is the version referenced by the newer piece of Freight.
+	ToVersion string `json:"toVersion,omitempty"`
+} // @name FreightDiffChartChange
+
+// ArtifactChange describes the difference between the versions of a single
+// generic artifact referenced by two pieces of Freight.
+type ArtifactChange struct {
+	// SubscriptionName is the name of the subscription that discovered the
+	// artifact.
+	SubscriptionName string `json:"subscriptionName"`
+	// ArtifactType is the type of the artifact.
+	ArtifactType string `json:"artifactType,omitempty"`
+	// Type indicates how the artifacts differ.
+	Type ChangeType `json:"type"`
+	// FromVersion is the version referenced by the older piece of Freight.
+	FromVersion string `json:"fromVersion,omitempty"`
+	// ToVersion is the version referenced by the newer piece of Freight.
+	ToVersion string `json:"toVersion,omitempty"`
+} // @name FreightDiffArtifactChange
+
+// CommitListerFn lists the commits in the specified Git repository that are
+// in the history of the commit with ID to, but not in the history of the
+// commit with ID from.
+type CommitListerFn func(
+	ctx context.Context,
+	repoURL string,
+	from string,
+	to string,
+	limit uint,
+) ([]git.CommitMetadata, error)
+
+// CommitURLFn returns a link to the specified commit in the Git hosting
+// provider's UI.
+type CommitURLFn func(repoURL string, commitID string) (string, error)
+
+// Options represents options for computing a Diff.
+type Options struct {
+	// ListCommits, if non-nil, is used to list the commits added or removed
+	// between two commits from the same Git repository. If nil, commits are
+	// compared, but not listed.
+	ListCommits CommitListerFn
+	// CommitURL, if non-nil, is used to link to commits.
+	CommitURL CommitURLFn
+}
+
+// Compute returns a Diff describing the differences between the two provided
+// pieces of Freight.
+func Compute(
+	ctx context.Context,
+	from *kargoapi.Freight,
+	to *kargoapi.Freight,
+	opts *Options,
+) *Diff {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &Diff{
+		From:      from.Name,
+		To:        to.Name,
+		Commits:   diffCommits(ctx, from.Commits, to.Commits, opts),
+		Images:    diffImages(from.Images, to.Images),
+		Charts:    diffCharts(from.Charts, to.Charts),
+		Artifacts: diffArtifacts(from.Artifacts, to.Artifacts),
+	}
+}
+
+func diffCommits(
+	ctx context.Context,
+	from []kargoapi.GitCommit,
+	to []kargoapi.GitCommit,
+	opts *Options,
+) []CommitChange {
+	pairs := pair(from, to, func(c kargoapi.GitCommit) string { return c.RepoURL })
+	changes := make([]CommitChange, 0, len(pairs))
+	for _, p := range pairs {
+		change := CommitChange{RepoURL: p.key}
+		if p.from != nil {
+			change.From = newCommit(p.key, p.from.ID, opts)
+			change.From.Tag = p.from.Tag
+		}
+		if p.to != nil {
+			change.To = newCommit(p.key, p.to.ID, opts)
+			change.To.Tag = p.to.Tag
+		}
+		change.Type = changeType(p.from != nil, p.to != nil, func() bool {
+			return p.from.ID == p.to.ID
+		})
+		if change.Type == ChangeTypeChanged && opts.ListCommits != nil {
+			listCommits(ctx, &change, opts)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// listCommits populates the Added and Removed commits of the provided
+// CommitChange.
+func listCommits(ctx context.Context, change *CommitChange, opts *Options) {
+	if !commitIDRegex.MatchString(change.From.ID) || !commitIDRegex.MatchString(change.To.ID) {
+		change.Error = "cannot list commits between non-SHA commit IDs"
+		return
+	}
+	var err error
+	var added, removed []git.CommitMetadata
+	if added, err = opts.ListCommits(
+		ctx, change.RepoURL, change.From.ID, change.To.ID, maxCommits+1,
+	); err != nil {
+		change.Error = err.Error()
+		return
+	}
+	if removed, err = opts.ListCommits(
+		ctx, change.RepoURL, change.To.ID, change.From.ID, maxCommits+1,
+	); err != nil {
+		change.Error = err.Error()
+		return
+	}
+	change.Added, change.Truncated = toCommits(change.RepoURL, added, opts)
+	var truncated bool
+	change.Removed, truncated = toCommits(change.RepoURL, removed, opts)
+	change.Truncated = change.Truncated || truncated
+	// The commits at either end of the range are already known. Fill in any
+	// details about them that were learned from the Git history.
+	for _, c := range change.Added {
+		if c.ID == change.To.ID {
+			change.To = withTag(c, change.To.Tag)
+		}
+	}
+	for _, c := range change.Removed {
+		if c.ID == change.From.ID {
+			change.From = withTag(c, change.From.Tag)
+		}
+	}
+}
+
+func toCommits(
+	repoURL string,
+	metadata []git.CommitMetadata,
+	opts *Options,
+) ([]Commit, bool) {
+	truncated := len(metadata) > maxCommits
+	if truncated {
+		metadata = metadata[:maxCommits]
+	}
+	commits := make([]Commit, len(metadata))
+	for i, m := range metadata {
+		commits[i] = *newCommit(repoURL, m.ID, opts)
+		commits[i].Subject = m.Subject
+		commits[i].Author = m.Author
+		if !m.CommitDate.IsZero() {
+			commits[i].Date = &m.CommitDate
+		}
+	}
+	return commits, truncated
+}
+
+func newCommit(repoURL, id string, opts *Options) *Commit {
+	c := &Commit{ID: id}
+	if opts.CommitURL != nil && id != "" {
+		// Failure to infer a link is not important enough to report.
+		c.URL, _ = opts.CommitURL(repoURL, id)
+	}
+	return c
+}
+
+func withTag(c Commit, tag string) *Commit {
+	c.Tag = tag
+	return &c
+}
+
+func diffImages(from, to []kargoapi.Image) []ImageChange {
+	pairs := pair(from, to, func(i kargoapi.Image) string { return i.RepoURL })
+	changes := make([]ImageChange, 0, len(pairs))
+	for _, p := range pairs {
+		change := ImageChange{RepoURL: p.key}
+		if p.from != nil {
+			change.FromTag = p.from.Tag
+			change.FromDigest = p.from.Digest
+		}
+		if p.to != nil {
+			change.ToTag = p.to.Tag
+			change.ToDigest = p.to.Digest
+		}
+		change.Type = changeType(p.from != nil, p.to != nil, func() bool {
+			return p.from.Tag == p.to.Tag && p.from.Digest == p.to.Digest
+		})
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func diffCharts(from, to []kargoapi.Chart) []ChartChange {
+	pairs := pair(from, to, func(c kargoapi.Chart) string {
+		return c.RepoURL + "\x00" + c.Name
+	})
+	changes := make([]ChartChange, 0, len(pairs))
+	for _, p := range pairs {
+		repoURL, name, _ := strings.Cut(p.key, "\x00")
+		change := ChartChange{RepoURL: repoURL, Name: name}
+		if p.from != nil {
+			change.FromVersion = p.from.Version
+		}
+		if p.to != nil {
+			change.ToVersion = p.to.Version
+		}
+		change.Type = changeType(p.from != nil, p.to != nil, func() bool {
+			return p.from.Version == p.to.Version
+		})
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func diffArtifacts(from, to []kargoapi.ArtifactReference) []ArtifactChange {
+	pairs := pair(from, to, func(a kargoapi.ArtifactReference) string {
+		return a.SubscriptionName
+	})
+	changes := make([]ArtifactChange, 0, len(pairs))
+	for _, p := range pairs {
+		change := ArtifactChange{SubscriptionName: p.key}
+		if p.from != nil {
+			change.ArtifactType = p.from.ArtifactType
+			change.FromVersion = p.from.Version
+		}
+		if p.to != nil {
+			change.ArtifactType = p.to.ArtifactType
+			change.ToVersion = p.to.Version
+		}
+		change.Type = changeType(p.from != nil, p.to != nil, func() bool {
+			return p.from.Version == p.to.Version
+		})
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// changeType returns the ChangeType for an artifact that may be present in
+// either or both pieces of Freight. The equal function is only invoked when
+// the artifact is present in both.
+func changeType(inFrom, inTo bool, equal func() bool) ChangeType {
+	switch {
+	case inFrom && !inTo:
+		return ChangeTypeRemoved
+	case !inFrom && inTo:
+		return ChangeTypeAdded
+	case equal():
+		return ChangeTypeUnchanged
+	default:
+		return ChangeTypeChanged
+	}
+}
+
+// artifactPair holds the versions of the same artifact, as identified by key,
+// from each of two pieces of Freight. Either may be nil.
+type artifactPair[T any] struct {
+	key  string
+	from *T
+	to   *T
+}
+
+// pair matches up artifacts from two pieces of Freight using the provided
+// function to compute a key identifying each artifact. The result is sorted by
+// key.
+func pair[T any](from, to []T, key func(T) string) []artifactPair[T] {
+	byKey := map[string]*artifactPair[T]{}
+	get := func(k string) *artifactPair[T] {
+		p, ok := byKey[k]
+		if !ok {
+			p = &artifactPair[T]{key: k}
+			byKey[k] = p
+		}
+		return p
+	}
+	for i := range from {
+		get(key(from[i])).from = &from[i]
+	}
+	for i := range to {
+		get(key(to[i])).to = &to[i]
+	}
+	pairs := make([]artifactPair[T], 0, len(byKey))
+	for _, p := range byKey {
+		pairs = append(pairs, *p)
+	}
+	slices.SortFunc(pairs, func(a, b artifactPair[T]) int {
+		return strings.Compare(a.key, b.key)
+	})
+	return pairs
+}
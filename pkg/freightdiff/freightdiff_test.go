@@ -0,0 +1,191 @@
+package freightdiff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/git"
+)
+
+func TestCompute(t *testing.T) {
+	const repoURL = "https://github.com/example/repo"
+	const oldID = "1111111111111111111111111111111111111111"
+	const newID = "2222222222222222222222222222222222222222"
+	commitDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	from := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{Name: "old"},
+		Commits:    []kargoapi.GitCommit{{RepoURL: repoURL, ID: oldID}},
+		Images: []kargoapi.Image{
+			{RepoURL: "example/changed", Tag: "v1.0.0", Digest: "sha256:a"},
+			{RepoURL: "example/removed", Tag: "v1.0.0"},
+			{RepoURL: "example/same", Tag: "v1.0.0", Digest: "sha256:c"},
+		},
+		Charts: []kargoapi.Chart{
+			{RepoURL: "https://charts.example.com", Name: "app", Version: "1.0.0"},
+		},
+		Artifacts: []kargoapi.ArtifactReference{
+			{SubscriptionName: "bundle", ArtifactType: "tarball", Version: "1"},
+		},
+	}
+	to := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{Name: "new"},
+		Commits:    []kargoapi.GitCommit{{RepoURL: repoURL, ID: newID, Tag: "v2"}},
+		Images: []kargoapi.Image{
+			{RepoURL: "example/added", Tag: "v1.0.0"},
+			{RepoURL: "example/changed", Tag: "v1.0.0", Digest: "sha256:b"},
+			{RepoURL: "example/same", Tag: "v1.0.0", Digest: "sha256:c"},
+		},
+		Charts: []kargoapi.Chart{
+			{RepoURL: "https://charts.example.com", Name: "app", Version: "1.1.0"},
+		},
+		Artifacts: []kargoapi.ArtifactReference{
+			{SubscriptionName: "bundle", ArtifactType: "tarball", Version: "1"},
+		},
+	}
+
+	commitURL := func(repoURL, id string) (string, error) {
+		return repoURL + "/commit/" + id, nil
+	}
+
+	t.Run("without commit history", func(t *testing.T) {
+		diff := Compute(t.Context(), from, to, &Options{CommitURL: commitURL})
+		require.Equal(t, "old", diff.From)
+		require.Equal(t, "new", diff.To)
+		require.Equal(t, []CommitChange{{
+			RepoURL: repoURL,
+			Type:    ChangeTypeChanged,
+			From:    &Commit{ID: oldID, URL: repoURL + "/commit/" + oldID},
+			To:      &Commit{ID: newID, Tag: "v2", URL: repoURL + "/commit/" + newID},
+		}}, diff.Commits)
+		require.Equal(t, []ImageChange{
+			{RepoURL: "example/added", Type: ChangeTypeAdded, ToTag: "v1.0.0"},
+			{
+				RepoURL:    "example/changed",
+				Type:       ChangeTypeChanged,
+				FromTag:    "v1.0.0",
+				FromDigest: "sha256:a",
+				ToTag:      "v1.0.0",
+				ToDigest:   "sha256:b",
+			},
+			{RepoURL: "example/removed", Type: ChangeTypeRemoved, FromTag: "v1.0.0"},
+			{
+				RepoURL:    "example/same",
+				Type:       ChangeTypeUnchanged,
+				FromTag:    "v1.0.0",
+				FromDigest: "sha256:c",
+				ToTag:      "v1.0.0",
+				ToDigest:   "sha256:c",
+			},
+		}, diff.Images)
+		require.Equal(t, []ChartChange{{
+			RepoURL:     "https://charts.example.com",
+			Name:        "app",
+			Type:        ChangeTypeChanged,
+			FromVersion: "1.0.0",
+			ToVersion:   "1.1.0",
+		}}, diff.Charts)
+		require.Equal(t, []ArtifactChange{{
+			SubscriptionName: "bundle",
+			ArtifactType:     "tarball",
+			Type:             ChangeTypeUnchanged,
+			FromVersion:      "1",
+			ToVersion:        "1",
+		}}, diff.Artifacts)
+	})
+
+	t.Run("with commit history", func(t *testing.T) {
+		diff := Compute(t.Context(), from, to, &Options{
+			ListCommits: func(
+				_ context.Context,
+				_ string,
+				from string,
+				to string,
+				limit uint,
+			) ([]git.CommitMetadata, error) {
+				require.Equal(t, uint(maxCommits+1), limit)
+				if from == oldID && to == newID {
+					return []git.CommitMetadata{
+						{ID: newID, Subject: "Second", Author: "Jane <jane@example.com>", CommitDate: commitDate},
+						{ID: "3333333", Subject: "First", Author: "Joe <joe@example.com>", CommitDate: commitDate},
+					}, nil
+				}
+				return nil, nil
+			},
+		})
+		require.Len(t, diff.Commits, 1)
+		change := diff.Commits[0]
+		require.Empty(t, change.Error)
+		require.Empty(t, change.Removed)
+		require.False(t, change.Truncated)
+		require.Equal(t, []Commit{
+			{ID: newID, Subject: "Second", Author: "Jane <jane@example.com>", Date: &commitDate},
+			{ID: "3333333", Subject: "First", Author: "Joe <joe@example.com>", Date: &commitDate},
+		}, change.Added)
+		// Details about the newer commit should be filled in from the history
+		require.Equal(t, "Second", change.To.Subject)
+		require.Equal(t, "v2", change.To.Tag)
+	})
+
+	t.Run("error listing commits", func(t *testing.T) {
+		diff := Compute(t.Context(), from, to, &Options{
+			ListCommits: func(
+				context.Context,
+				string,
+				string,
+				string,
+				uint,
+			) ([]git.CommitMetadata, error) {
+				return nil, errors.New("something went wrong")
+			},
+		})
+		require.Len(t, diff.Commits, 1)
+		require.Equal(t, "something went wrong", diff.Commits[0].Error)
+		require.Empty(t, diff.Commits[0].Added)
+	})
+
+	t.Run("non-SHA commit IDs", func(t *testing.T) {
+		badTo := to.DeepCopy()
+		badTo.Commits[0].ID = "--upload-pack=evil"
+		diff := Compute(t.Context(), from, badTo, &Options{
+			ListCommits: func(
+				context.Context,
+				string,
+				string,
+				string,
+				uint,
+			) ([]git.CommitMetadata, error) {
+				require.Fail(t, "commits should not be listed")
+				return nil, nil
+			},
+		})
+		require.Len(t, diff.Commits, 1)
+		require.Contains(t, diff.Commits[0].Error, "non-SHA")
+	})
+
+	t.Run("truncated commit history", func(t *testing.T) {
+		diff := Compute(t.Context(), from, to, &Options{
+			ListCommits: func(
+				_ context.Context,
+				_ string,
+				from string,
+				_ string,
+				limit uint,
+			) ([]git.CommitMetadata, error) {
+				if from != oldID {
+					return nil, nil
+				}
+				return make([]git.CommitMetadata, limit), nil
+			},
+		})
+		require.Len(t, diff.Commits, 1)
+		require.True(t, diff.Commits[0].Truncated)
+		require.Len(t, diff.Commits[0].Added, maxCommits)
+	})
+}
@@ -0,0 +1,161 @@
+package freightdiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+
+	"github.com/akuity/kargo/pkg/controller/git"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/gitprovider"
+
+	_ "github.com/akuity/kargo/pkg/gitprovider/azure"           // Azure provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/bitbucket/cloud" // Bitbucket Cloud provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitea"           // Gitea provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/github"          // GitHub provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitlab"          // GitLab provider registration
+)
+
+const (
+	// maxConcurrentClones is the maximum number of repositories that all
+	// GitCommitListers combined will clone at the same time.
+	maxConcurrentClones = 4
+	// commitCacheTTL is how long the commits listed between two commits are
+	// cached for. The commits between two commits never change, so this only
+	// serves to bound the memory used by the cache.
+	commitCacheTTL = time.Hour
+)
+
+var (
+	// cloneSlots limits the number of repositories that are cloned
+	// concurrently.
+	cloneSlots = make(chan struct{}, maxConcurrentClones)
+	// commitCache caches listed commits across all GitCommitListers, keyed by
+	// Project, repository URL, range, and limit.
+	commitCache = gocache.New(commitCacheTTL, commitCacheTTL/2)
+)
+
+// GitCommitLister lists commits by cloning Git repositories using credentials
+// belonging to a specific Project. Each repository is cloned at most once and
+// the commits listed between any two commits are cached. Close must be called
+// to clean up the clones once they are no longer needed.
+type GitCommitLister struct {
+	credsDB credentials.Database
+	project string
+
+	mu    sync.Mutex
+	repos map[string]git.Repo
+}
+
+// NewGitCommitLister returns a GitCommitLister that clones Git repositories
+// using credentials belonging to the specified Project.
+func NewGitCommitLister(
+	credsDB credentials.Database,
+	project string,
+) *GitCommitLister {
+	return &GitCommitLister{
+		credsDB: credsDB,
+		project: project,
+		repos:   map[string]git.Repo{},
+	}
+}
+
+// ListCommits implements CommitListerFn.
+func (g *GitCommitLister) ListCommits(
+	ctx context.Context,
+	repoURL string,
+	from string,
+	to string,
+	limit uint,
+) ([]git.CommitMetadata, error) {
+	cacheKey := fmt.Sprintf("%s:%s:%s..%s:%d", g.project, repoURL, from, to, limit)
+	if commits, ok := commitCache.Get(cacheKey); ok {
+		return commits.([]git.CommitMetadata), nil // nolint: forcetypeassert
+	}
+	repo, err := g.getRepo(ctx, repoURL)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := repo.ListCommits(ctx, &git.ListCommitsOptions{
+		Ref:        to,
+		ExcludeRef: from,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing commits between %s and %s: %w", from, to, err)
+	}
+	commitCache.SetDefault(cacheKey, commits)
+	return commits, nil
+}
+
+func (g *GitCommitLister) getRepo(ctx context.Context, repoURL string) (git.Repo, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if repo, ok := g.repos[repoURL]; ok {
+		return repo, nil
+	}
+
+	var repoCreds *git.RepoCredentials
+	if g.credsDB != nil {
+		creds, err := g.credsDB.Get(ctx, g.project, credentials.TypeGit, repoURL)
+		if err != nil {
+			return nil, fmt.Errorf("error getting credentials for %s: %w", repoURL, err)
+		}
+		if creds != nil {
+			repoCreds = &git.RepoCredentials{
+				Username:      creds.Username,
+				Password:      creds.Password,
+				SSHPrivateKey: creds.SSHPrivateKey,
+			}
+		}
+	}
+
+	select {
+	case cloneSlots <- struct{}{}:
+		defer func() { <-cloneSlots }()
+	case <-ctx.Done():
+		return nil, fmt.Errorf("error waiting to clone %s: %w", repoURL, ctx.Err())
+	}
+
+	// Only commit history is needed, so there is no need to download any trees
+	// or file contents, or to check anything out.
+	repo, err := git.Clone(
+		ctx,
+		repoURL,
+		&git.ClientOptions{Credentials: repoCreds},
+		&git.CloneOptions{Treeless: true, NoCheckout: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error cloning %s: %w", repoURL, err)
+	}
+	g.repos[repoURL] = repo
+	return repo, nil
+}
+
+// Close cleans up all repositories cloned by the GitCommitLister.
+func (g *GitCommitLister) Close(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var errs []error
+	for repoURL, repo := range g.repos {
+		if err := repo.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error cleaning up clone of %s: %w", repoURL, err))
+		}
+		delete(g.repos, repoURL)
+	}
+	return errors.Join(errs...)
+}
+
+// GitProviderCommitURL implements CommitURLFn by inferring a commit URL from
+// the Git hosting provider associated with the repository URL.
+func GitProviderCommitURL(repoURL string, commitID string) (string, error) {
+	provider, err := gitprovider.New(repoURL, nil)
+	if err != nil {
+		return "", err
+	}
+	return provider.GetCommitURL(repoURL, commitID)
+}
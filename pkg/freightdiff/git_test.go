@@ -0,0 +1,78 @@
+package freightdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitCommitLister(t *testing.T) {
+	// Set up a local repository with a few commits
+	repoDir := t.TempDir()
+	gitCmd := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(
+			os.Environ(),
+			"HOME="+repoDir,
+			"GIT_AUTHOR_NAME=Jane",
+			"GIT_AUTHOR_EMAIL=jane@example.com",
+			"GIT_COMMITTER_NAME=Jane",
+			"GIT_COMMITTER_EMAIL=jane@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	gitCmd("init", "--initial-branch=main")
+	// Permit the partial clone performed by the lister
+	gitCmd("config", "uploadpack.allowFilter", "true")
+	var ids []string
+	for _, subject := range []string{"first", "second", "third"} {
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "file"), []byte(subject), 0o600))
+		gitCmd("add", ".")
+		gitCmd("commit", "-m", subject)
+		ids = append(ids, gitCmd("rev-parse", "HEAD"))
+	}
+
+	lister := NewGitCommitLister(nil, "fake-project")
+	defer func() {
+		require.NoError(t, lister.Close(t.Context()))
+	}()
+
+	commits, err := lister.ListCommits(t.Context(), "file://"+repoDir, ids[0], ids[2], 0)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, ids[2], commits[0].ID)
+	require.Equal(t, "third", commits[0].Subject)
+	require.Equal(t, "Jane <jane@example.com>", commits[0].Author)
+	require.Equal(t, ids[1], commits[1].ID)
+
+	// The reverse range is empty
+	commits, err = lister.ListCommits(t.Context(), "file://"+repoDir, ids[2], ids[0], 0)
+	require.NoError(t, err)
+	require.Empty(t, commits)
+
+	// The clone is reused
+	require.Len(t, lister.repos, 1)
+
+	// Listed commits are cached across listers
+	otherLister := NewGitCommitLister(nil, "fake-project")
+	commits, err = otherLister.ListCommits(t.Context(), "file://"+repoDir, ids[0], ids[2], 0)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Empty(t, otherLister.repos)
+}
+
+func TestGitProviderCommitURL(t *testing.T) {
+	url, err := GitProviderCommitURL("https://github.com/example/repo", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, "https://github.com/example/repo/commit/abc123", url)
+
+	_, err = GitProviderCommitURL("https://unknown.example.com/repo", "abc123")
+	require.Error(t, err)
+}
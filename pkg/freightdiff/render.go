@@ -0,0 +1,182 @@
+package freightdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a format in which a Diff can be rendered.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+// Formats returns all formats in which a Diff can be rendered.
+func Formats() []Format {
+	return []Format{FormatText, FormatJSON}
+}
+
+// Write renders the provided Diff to the provided io.Writer in the specified
+// Format.
+func Write(w io.Writer, d *Diff, format Format) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, d)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// WriteText renders the provided Diff to the provided io.Writer in a
+// human-readable format. Each change is prefixed with a marker indicating its
+// ChangeType: "+" for added, "-" for removed, "~" for changed, and "=" for
+// unchanged.
+func WriteText(w io.Writer, d *Diff) error {
+	p := &printer{w: w}
+	p.printf("Freight %s -> %s\n", d.From, d.To)
+
+	if len(d.Commits) > 0 {
+		p.printf("\nCommits:\n")
+		for _, c := range d.Commits {
+			p.printf(
+				"  %s %s  %s\n",
+				marker(c.Type), c.RepoURL, versions(commitRef(c.From), commitRef(c.To)),
+			)
+			for _, commit := range c.Added {
+				p.printCommit("+", commit)
+			}
+			for _, commit := range c.Removed {
+				p.printCommit("-", commit)
+			}
+			if c.Truncated {
+				p.printf("      ... (more commits not shown)\n")
+			}
+			if c.Error != "" {
+				p.printf("      ! %s\n", c.Error)
+			}
+		}
+	}
+
+	if len(d.Images) > 0 {
+		p.printf("\nImages:\n")
+		for _, i := range d.Images {
+			p.printf(
+				"  %s %s  %s\n",
+				marker(i.Type), i.RepoURL,
+				versions(imageRef(i.FromTag, i.FromDigest), imageRef(i.ToTag, i.ToDigest)),
+			)
+		}
+	}
+
+	if len(d.Charts) > 0 {
+		p.printf("\nCharts:\n")
+		for _, c := range d.Charts {
+			name := c.RepoURL
+			if c.Name != "" {
+				name = strings.TrimSuffix(c.RepoURL, "/") + "/" + c.Name
+			}
+			p.printf("  %s %s  %s\n", marker(c.Type), name, versions(c.FromVersion, c.ToVersion))
+		}
+	}
+
+	if len(d.Artifacts) > 0 {
+		p.printf("\nArtifacts:\n")
+		for _, a := range d.Artifacts {
+			name := a.SubscriptionName
+			if a.ArtifactType != "" {
+				name = fmt.Sprintf("%s (%s)", name, a.ArtifactType)
+			}
+			p.printf("  %s %s  %s\n", marker(a.Type), name, versions(a.FromVersion, a.ToVersion))
+		}
+	}
+
+	return p.err
+}
+
+// printer wraps an io.Writer and retains the first error encountered while
+// writing to it so that callers need only check for errors once.
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+func (p *printer) printCommit(prefix string, c Commit) {
+	line := prefix + " " + shortID(c.ID)
+	if c.Subject != "" {
+		line += " " + c.Subject
+	}
+	if c.Author != "" {
+		line += " (" + c.Author + ")"
+	}
+	p.printf("      %s\n", line)
+	if c.URL != "" {
+		p.printf("        %s\n", c.URL)
+	}
+}
+
+func marker(t ChangeType) string {
+	switch t {
+	case ChangeTypeAdded:
+		return "+"
+	case ChangeTypeRemoved:
+		return "-"
+	case ChangeTypeChanged:
+		return "~"
+	default:
+		return "="
+	}
+}
+
+// versions formats a pair of versions, either of which may be empty.
+func versions(from, to string) string {
+	switch {
+	case from == "":
+		return to
+	case to == "" || from == to:
+		return from
+	default:
+		return from + " -> " + to
+	}
+}
+
+func commitRef(c *Commit) string {
+	if c == nil {
+		return ""
+	}
+	if c.Tag != "" {
+		return fmt.Sprintf("%s (%s)", c.Tag, shortID(c.ID))
+	}
+	return shortID(c.ID)
+}
+
+func imageRef(tag, digest string) string {
+	switch {
+	case tag == "":
+		return digest
+	case digest == "":
+		return tag
+	default:
+		return tag + "@" + digest
+	}
+}
+
+func shortID(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
@@ -0,0 +1,99 @@
+package freightdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	diff := &Diff{
+		From: "old",
+		To:   "new",
+		Commits: []CommitChange{
+			{
+				RepoURL: "https://github.com/example/repo",
+				Type:    ChangeTypeChanged,
+				From:    &Commit{ID: "1111111111111111111111111111111111111111"},
+				To:      &Commit{ID: "2222222222222222222222222222222222222222", Tag: "v2"},
+				Added: []Commit{{
+					ID:      "2222222222222222222222222222222222222222",
+					Subject: "Fix bug",
+					Author:  "Jane <jane@example.com>",
+					URL:     "https://github.com/example/repo/commit/2222222",
+				}},
+				Truncated: true,
+			},
+			{
+				RepoURL: "https://github.com/example/other",
+				Type:    ChangeTypeChanged,
+				From:    &Commit{ID: "3333333"},
+				To:      &Commit{ID: "4444444"},
+				Error:   "error cloning",
+			},
+		},
+		Images: []ImageChange{
+			{RepoURL: "example/added", Type: ChangeTypeAdded, ToTag: "v1.0.0"},
+			{
+				RepoURL:    "example/changed",
+				Type:       ChangeTypeChanged,
+				FromTag:    "v1.0.0",
+				FromDigest: "sha256:a",
+				ToTag:      "v1.0.0",
+				ToDigest:   "sha256:b",
+			},
+		},
+		Charts: []ChartChange{{
+			RepoURL:     "https://charts.example.com",
+			Name:        "app",
+			Type:        ChangeTypeChanged,
+			FromVersion: "1.0.0",
+			ToVersion:   "1.1.0",
+		}},
+		Artifacts: []ArtifactChange{{
+			SubscriptionName: "bundle",
+			ArtifactType:     "tarball",
+			Type:             ChangeTypeRemoved,
+			FromVersion:      "1",
+		}},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, Write(buf, diff, FormatText))
+		require.Equal(t, `Freight old -> new
+
+Commits:
+  ~ https://github.com/example/repo  1111111 -> v2 (2222222)
+      + 2222222 Fix bug (Jane <jane@example.com>)
+        https://github.com/example/repo/commit/2222222
+      ... (more commits not shown)
+  ~ https://github.com/example/other  3333333 -> 4444444
+      ! error cloning
+
+Images:
+  + example/added  v1.0.0
+  ~ example/changed  v1.0.0@sha256:a -> v1.0.0@sha256:b
+
+Charts:
+  ~ https://charts.example.com/app  1.0.0 -> 1.1.0
+
+Artifacts:
+  - bundle (tarball)  1
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, Write(buf, diff, FormatJSON))
+		decoded := &Diff{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+		require.Equal(t, diff, decoded)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		require.Error(t, Write(&bytes.Buffer{}, diff, "yaml"))
+	})
+}
@@ -108,7 +108,7 @@ func NewProvider(
 		// background context is preferable here to refactoring all provider
 		// registrations to be context-aware.
 		context.Background(),
-		azuredevops.NewPatConnection(organizationUrl, opts.Token),
+		newConnection(organizationUrl, opts.Token),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Azure DevOps client: %w", err)
@@ -122,6 +122,26 @@ func NewProvider(
 	}, nil
 }
 
+// newConnection returns a connection to the specified organization that
+// authenticates using the provided token. Microsoft Entra ID access tokens, as
+// obtained using workload identity, are JWTs and must be presented as bearer
+// tokens. Any other token is assumed to be a personal access token.
+func newConnection(organizationURL, token string) *azuredevops.Connection {
+	if isEntraIDToken(token) {
+		conn := azuredevops.NewAnonymousConnection(organizationURL)
+		conn.AuthorizationString = "Bearer " + token
+		return conn
+	}
+	return azuredevops.NewPatConnection(organizationURL, token)
+}
+
+// isEntraIDToken returns true if the provided token appears to be a JWT and
+// therefore a Microsoft Entra ID access token. Personal access tokens are
+// opaque strings that never contain a period.
+func isEntraIDToken(token string) bool {
+	return strings.HasPrefix(token, "eyJ") && strings.Count(token, ".") == 2
+}
+
 // CreatePullRequest implements gitprovider.Interface.
 func (p *provider) CreatePullRequest(
 	ctx context.Context,
@@ -407,6 +427,12 @@ func convertADOPullRequest(pr *adogit.GitPullRequest) (*gitprovider.PullRequest,
 	if pr.Repository != nil {
 		webURL = ptr.Deref(pr.Repository.WebUrl, "")
 	}
+	var labels []string
+	for _, label := range ptr.Deref(pr.Labels, nil) {
+		if label.Name != nil {
+			labels = append(labels, *label.Name)
+		}
+	}
 	return &gitprovider.PullRequest{
 		Number: int64(ptr.Deref(pr.PullRequestId, 0)),
 		URL: fmt.Sprintf(
@@ -419,6 +445,11 @@ func convertADOPullRequest(pr *adogit.GitPullRequest) (*gitprovider.PullRequest,
 		MergeCommitSHA: ptr.Deref(mergeCommit.CommitId, ""),
 		Object:         pr,
 		HeadSHA:        ptr.Deref(pr.LastMergeSourceCommit.CommitId, ""),
+		Title:          ptr.Deref(pr.Title, ""),
+		HeadBranch:     strings.TrimPrefix(ptr.Deref(pr.SourceRefName, ""), "refs/heads/"),
+		BaseBranch:     strings.TrimPrefix(ptr.Deref(pr.TargetRefName, ""), "refs/heads/"),
+		Labels:         labels,
+		Draft:          ptr.Deref(pr.IsDraft, false),
 	}, nil
 }
 
@@ -439,11 +439,35 @@ func toProviderPR(pr *Pullrequest) *gitprovider.PullRequest {
 		mergeCommitSHA = *pr.MergeCommit.Hash
 	}
 
+	var headBranch string
+	if pr.Source != nil && pr.Source.Branch != nil && pr.Source.Branch.Name != nil {
+		headBranch = *pr.Source.Branch.Name
+	}
+
+	var baseBranch string
+	if pr.Destination != nil && pr.Destination.Branch != nil && pr.Destination.Branch.Name != nil {
+		baseBranch = *pr.Destination.Branch.Name
+	}
+
+	var title string
+	if pr.Title != nil {
+		title = *pr.Title
+	}
+
+	var draft bool
+	if pr.Draft != nil {
+		draft = *pr.Draft
+	}
+
 	return &gitprovider.PullRequest{
-		Number: id,
-		URL:    prURL,
-		Open:   state == PullrequestStateOPEN,
-		Merged: state == PullrequestStateMERGED,
+		Number:     id,
+		URL:        prURL,
+		Title:      title,
+		HeadBranch: headBranch,
+		BaseBranch: baseBranch,
+		Draft:      draft,
+		Open:       state == PullrequestStateOPEN,
+		Merged:     state == PullrequestStateMERGED,
 		// NB: As a sign of true craftsmanship, or lack thereof, the Bitbucket
 		// API returns a short commit SHA as merge commit hash. To get the full
 		// commit SHA, we need to fetch the commit details separately.
@@ -1087,7 +1087,10 @@ func Test_toProviderPR(t *testing.T) {
 			"id": 1,
 			"state": "OPEN",
 			"links": {"html": {"href": "https://bitbucket.org/owner/repo/pull-requests/1"}},
-			"source": {"commit": {"hash": "abcdef1234567890"}},
+			"title": "Add feature",
+			"draft": true,
+			"source": {"branch": {"name": "feature"}, "commit": {"hash": "abcdef1234567890"}},
+			"destination": {"branch": {"name": "main"}},
 			"created_on": "2023-01-01T12:00:00Z",
 			"type": "pullrequest"
 		}`)
@@ -1096,6 +1099,10 @@ func Test_toProviderPR(t *testing.T) {
 		assert.Equal(t, int64(1), pr.Number)
 		assert.Equal(t, "https://bitbucket.org/owner/repo/pull-requests/1", pr.URL)
 		assert.Equal(t, "abcdef1234567890", pr.HeadSHA)
+		assert.Equal(t, "Add feature", pr.Title)
+		assert.Equal(t, "feature", pr.HeadBranch)
+		assert.Equal(t, "main", pr.BaseBranch)
+		assert.True(t, pr.Draft)
 		assert.True(t, pr.Open)
 		assert.False(t, pr.Merged)
 		assert.NotNil(t, pr.CreatedAt)
@@ -80,6 +80,12 @@ type giteaClient interface {
 		number int64,
 		opts gitea.MergePullRequestOption,
 	) (bool, *gitea.Response, error)
+
+	ListReleases(
+		owner string,
+		repo string,
+		opts gitea.ListReleasesOptions,
+	) ([]*gitea.Release, *gitea.Response, error)
 }
 
 // provider is a Gitea implementation of gitprovider.Interface.
@@ -344,6 +350,37 @@ func (p *provider) MergePullRequest(
 	return &pr, true, nil
 }
 
+// ListReleases implements gitprovider.ReleaseLister.
+func (p *provider) ListReleases(
+	_ context.Context,
+	opts *gitprovider.ListReleasesOptions,
+) ([]gitprovider.Release, error) {
+	if opts == nil {
+		opts = &gitprovider.ListReleasesOptions{}
+	}
+	listOpts := gitea.ListReleasesOptions{
+		ListOptions: gitea.ListOptions{},
+	}
+	var releases []gitprovider.Release
+	for {
+		giteaReleases, res, err := p.client.ListReleases(p.owner, p.repo, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, giteaRelease := range giteaReleases {
+			releases = append(releases, convertGiteaRelease(*giteaRelease))
+			if opts.Limit > 0 && len(releases) == opts.Limit {
+				return releases, nil
+			}
+		}
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		listOpts.Page = res.NextPage
+	}
+	return releases, nil
+}
+
 // GetCommitURL implements gitprovider.Interface.
 func (p *provider) GetCommitURL(repoURL string, sha string) (string, error) {
 	normalizedURL := urls.NormalizeGit(repoURL)
@@ -366,6 +403,17 @@ func convertGiteaPR(giteaPR gitea.PullRequest) gitprovider.PullRequest {
 		Merged:  giteaPR.HasMerged,
 		Object:  giteaPR,
 		HeadSHA: giteaPR.Head.Sha,
+		Title:   giteaPR.Title,
+		Draft:   giteaPR.Draft,
+	}
+	pr.HeadBranch = giteaPR.Head.Ref
+	if giteaPR.Base != nil {
+		pr.BaseBranch = giteaPR.Base.Ref
+	}
+	for _, label := range giteaPR.Labels {
+		if label != nil {
+			pr.Labels = append(pr.Labels, label.Name)
+		}
 	}
 	if giteaPR.MergedCommitID != nil {
 		pr.MergeCommitSHA = *giteaPR.MergedCommitID
@@ -376,6 +424,28 @@ func convertGiteaPR(giteaPR gitea.PullRequest) gitprovider.PullRequest {
 	return pr
 }
 
+func convertGiteaRelease(giteaRelease gitea.Release) gitprovider.Release {
+	release := gitprovider.Release{
+		TagName:    giteaRelease.TagName,
+		Name:       giteaRelease.Title,
+		Notes:      giteaRelease.Note,
+		URL:        giteaRelease.HTMLURL,
+		Draft:      giteaRelease.IsDraft,
+		Prerelease: giteaRelease.IsPrerelease,
+	}
+	if !giteaRelease.PublishedAt.IsZero() {
+		release.PublishedAt = &giteaRelease.PublishedAt
+	}
+	for _, attachment := range giteaRelease.Attachments {
+		release.Assets = append(release.Assets, gitprovider.ReleaseAsset{
+			Name: attachment.Name,
+			URL:  attachment.DownloadURL,
+			Size: attachment.Size,
+		})
+	}
+	return release
+}
+
 func parseRepoURL(repoURL string) (string, string, string, string, error) {
 	u, err := url.Parse(urls.NormalizeGit(repoURL))
 	if err != nil {
@@ -109,6 +109,23 @@ func (m *mockGiteaClient) ListRepoPullRequests(
 	return prs, resp, args.Error(2)
 }
 
+func (m *mockGiteaClient) ListReleases(
+	owner string,
+	repo string,
+	opts gitea.ListReleasesOptions,
+) ([]*gitea.Release, *gitea.Response, error) {
+	args := m.Called(owner, repo, opts)
+	releases, ok := args.Get(0).([]*gitea.Release)
+	if !ok {
+		return nil, nil, args.Error(2)
+	}
+	resp, ok := args.Get(1).(*gitea.Response)
+	if !ok {
+		return releases, nil, args.Error(2)
+	}
+	return releases, resp, args.Error(2)
+}
+
 func (m *mockGiteaClient) GetPullRequest(
 	owner string,
 	repo string,
@@ -569,6 +586,65 @@ func TestListPullRequests(t *testing.T) {
 	require.True(t, prs[0].Open)
 }
 
+func TestListReleases(t *testing.T) {
+	publishedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &mockGiteaClient{}
+	mockClient.
+		On("ListReleases", testRepoOwner, testRepoName, gitea.ListReleasesOptions{}).
+		Return(
+			[]*gitea.Release{{
+				TagName:      "v1.1.0",
+				Title:        "Release 1.1.0",
+				Note:         "notes",
+				HTMLURL:      "https://gitea.com/akuity/kargo/releases/tag/v1.1.0",
+				IsPrerelease: true,
+				PublishedAt:  publishedAt,
+				Attachments: []*gitea.Attachment{{
+					Name:        "bundle.tar.gz",
+					Size:        2048,
+					DownloadURL: "https://gitea.com/akuity/kargo/releases/download/v1.1.0/bundle.tar.gz",
+				}},
+			}},
+			&gitea.Response{NextPage: 2},
+			nil,
+		)
+	mockClient.
+		On("ListReleases", testRepoOwner, testRepoName, gitea.ListReleasesOptions{
+			ListOptions: gitea.ListOptions{Page: 2},
+		}).
+		Return(
+			[]*gitea.Release{{TagName: "v1.0.0", IsDraft: true}},
+			&gitea.Response{},
+			nil,
+		)
+
+	g := provider{
+		owner:  testRepoOwner,
+		repo:   testRepoName,
+		client: mockClient,
+	}
+
+	releases, err := g.ListReleases(t.Context(), nil)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	require.Equal(t, []gitprovider.Release{
+		{
+			TagName:     "v1.1.0",
+			Name:        "Release 1.1.0",
+			Notes:       "notes",
+			URL:         "https://gitea.com/akuity/kargo/releases/tag/v1.1.0",
+			Prerelease:  true,
+			PublishedAt: &publishedAt,
+			Assets: []gitprovider.ReleaseAsset{{
+				Name: "bundle.tar.gz",
+				URL:  "https://gitea.com/akuity/kargo/releases/download/v1.1.0/bundle.tar.gz",
+				Size: 2048,
+			}},
+		},
+		{TagName: "v1.0.0", Draft: true},
+	}, releases)
+}
+
 func TestMergePullRequest(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -137,6 +137,13 @@ type githubClient interface {
 		number int,
 		labels []string,
 	) ([]*github.Label, *github.Response, error)
+
+	ListReleases(
+		ctx context.Context,
+		owner string,
+		repo string,
+		opts *github.ListOptions,
+	) ([]*github.RepositoryRelease, *github.Response, error)
 }
 
 // provider is a GitHub implementation of gitprovider.Interface.
@@ -227,6 +234,15 @@ func (g githubClientWrapper) AddLabelsToIssue(
 	return g.client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
 }
 
+func (g githubClientWrapper) ListReleases(
+	ctx context.Context,
+	owner string,
+	repo string,
+	opts *github.ListOptions,
+) ([]*github.RepositoryRelease, *github.Response, error) {
+	return g.client.Repositories.ListReleases(ctx, owner, repo, opts)
+}
+
 // CreatePullRequest implements gitprovider.Interface.
 func (p *provider) CreatePullRequest(
 	ctx context.Context,
@@ -484,6 +500,37 @@ func isTransientMerge405(msg string, policyBlocked bool) bool {
 	}
 }
 
+// ListReleases implements gitprovider.ReleaseLister.
+func (p *provider) ListReleases(
+	ctx context.Context,
+	opts *gitprovider.ListReleasesOptions,
+) ([]gitprovider.Release, error) {
+	if opts == nil {
+		opts = &gitprovider.ListReleasesOptions{}
+	}
+	listOpts := github.ListOptions{
+		PerPage: 100, // Max
+	}
+	var releases []gitprovider.Release
+	for {
+		ghReleases, res, err := p.client.ListReleases(ctx, p.owner, p.repo, &listOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, ghRelease := range ghReleases {
+			releases = append(releases, convertGithubRelease(*ghRelease))
+			if opts.Limit > 0 && len(releases) == opts.Limit {
+				return releases, nil
+			}
+		}
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		listOpts.Page = res.NextPage
+	}
+	return releases, nil
+}
+
 // GetCommitURL implements gitprovider.Interface.
 func (p *provider) GetCommitURL(
 	repoURL string,
@@ -505,9 +552,44 @@ func convertGithubPR(ghPR github.PullRequest) gitprovider.PullRequest {
 		MergeCommitSHA: ptr.Deref(ghPR.MergeCommitSHA, ""),
 		Object:         ghPR,
 		HeadSHA:        ptr.Deref(ghPR.Head.SHA, ""),
+		Title:          ptr.Deref(ghPR.Title, ""),
+		HeadBranch:     ptr.Deref(ghPR.Head.Ref, ""),
+		Draft:          ptr.Deref(ghPR.Draft, false),
+	}
+	if ghPR.Base != nil {
+		pr.BaseBranch = ptr.Deref(ghPR.Base.Ref, "")
+	}
+	for _, label := range ghPR.Labels {
+		if label != nil && label.Name != nil {
+			pr.Labels = append(pr.Labels, *label.Name)
+		}
 	}
 	if ghPR.CreatedAt != nil {
 		pr.CreatedAt = &ghPR.CreatedAt.Time
 	}
 	return pr
 }
+
+func convertGithubRelease(ghRelease github.RepositoryRelease) gitprovider.Release {
+	release := gitprovider.Release{
+		TagName:    ghRelease.GetTagName(),
+		Name:       ghRelease.GetName(),
+		Notes:      ghRelease.GetBody(),
+		URL:        ghRelease.GetHTMLURL(),
+		Draft:      ghRelease.GetDraft(),
+		Prerelease: ghRelease.GetPrerelease(),
+	}
+	if ghRelease.PublishedAt != nil {
+		release.PublishedAt = &ghRelease.PublishedAt.Time
+	}
+	for _, asset := range ghRelease.Assets {
+		release.Assets = append(release.Assets, gitprovider.ReleaseAsset{
+			Name:        asset.GetName(),
+			URL:         asset.GetBrowserDownloadURL(),
+			Size:        int64(asset.GetSize()),
+			ContentType: asset.GetContentType(),
+			Digest:      asset.GetDigest(),
+		})
+	}
+	return release
+}
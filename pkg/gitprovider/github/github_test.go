@@ -170,6 +170,24 @@ func (m *mockGithubClient) CreatePullRequest(
 	return pr, resp, args.Error(2)
 }
 
+func (m *mockGithubClient) ListReleases(
+	ctx context.Context,
+	owner string,
+	repo string,
+	opts *github.ListOptions,
+) ([]*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	releases, ok := args.Get(0).([]*github.RepositoryRelease)
+	if !ok {
+		return nil, nil, args.Error(2)
+	}
+	resp, ok := args.Get(1).(*github.Response)
+	if !ok {
+		return releases, nil, args.Error(2)
+	}
+	return releases, resp, args.Error(2)
+}
+
 func TestCreatePullRequestWithLabels(t *testing.T) {
 	opts := gitprovider.CreatePullRequestOpts{
 		Head:        "feature-branch",
@@ -326,9 +344,17 @@ func TestListPullRequests(t *testing.T) {
 		Return(
 			[]*github.PullRequest{{
 				Number: mockClient.pr.Number,
+				Title:  github.Ptr("title"),
 				Head: &github.PullRequestBranch{
 					Ref: github.Ptr("head"),
 				},
+				Base: &github.PullRequestBranch{
+					Ref: github.Ptr("base"),
+				},
+				Labels: []*github.Label{
+					{Name: github.Ptr("preview")},
+					{Name: github.Ptr("bug")},
+				},
 				MergeCommitSHA: mockClient.pr.MergeCommitSHA,
 				State:          mockClient.pr.State,
 				HTMLURL:        mockClient.pr.URL,
@@ -355,9 +381,82 @@ func TestListPullRequests(t *testing.T) {
 	require.Equal(t, int64(*mockClient.pr.Number), prs[0].Number)
 	require.Equal(t, *mockClient.pr.MergeCommitSHA, prs[0].MergeCommitSHA)
 	require.Equal(t, *mockClient.pr.URL, prs[0].URL)
+	require.Equal(t, "title", prs[0].Title)
+	require.Equal(t, "head", prs[0].HeadBranch)
+	require.Equal(t, "base", prs[0].BaseBranch)
+	require.Equal(t, []string{"preview", "bug"}, prs[0].Labels)
 	require.True(t, prs[0].Open)
 }
 
+func TestListReleases(t *testing.T) {
+	publishedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &mockGithubClient{}
+	mockClient.
+		On("ListReleases", t.Context(), testRepoOwner, testRepoName, &github.ListOptions{
+			PerPage: 100,
+		}).
+		Return(
+			[]*github.RepositoryRelease{{
+				TagName:     github.Ptr("v1.1.0"),
+				Name:        github.Ptr("Release 1.1.0"),
+				Body:        github.Ptr("notes"),
+				HTMLURL:     github.Ptr("https://github.com/akuity/kargo/releases/tag/v1.1.0"),
+				Prerelease:  github.Ptr(true),
+				PublishedAt: &github.Timestamp{Time: publishedAt},
+				Assets: []*github.ReleaseAsset{{
+					Name:               github.Ptr("kargo-linux-amd64"),
+					BrowserDownloadURL: github.Ptr("https://github.com/akuity/kargo/releases/download/v1.1.0/kargo-linux-amd64"),
+					Size:               github.Ptr(1024),
+					ContentType:        github.Ptr("application/octet-stream"),
+					Digest:             github.Ptr("sha256:abc123"),
+				}},
+			}},
+			&github.Response{NextPage: 2},
+			nil,
+		)
+	mockClient.
+		On("ListReleases", t.Context(), testRepoOwner, testRepoName, &github.ListOptions{
+			Page:    2,
+			PerPage: 100,
+		}).
+		Return(
+			[]*github.RepositoryRelease{
+				{TagName: github.Ptr("v1.0.0"), Draft: github.Ptr(true)},
+				{TagName: github.Ptr("v0.9.0")},
+			},
+			&github.Response{NextPage: 3},
+			nil,
+		)
+
+	g := provider{
+		owner:  testRepoOwner,
+		repo:   testRepoName,
+		client: mockClient,
+	}
+
+	releases, err := g.ListReleases(t.Context(), &gitprovider.ListReleasesOptions{Limit: 2})
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	require.Equal(t, []gitprovider.Release{
+		{
+			TagName:     "v1.1.0",
+			Name:        "Release 1.1.0",
+			Notes:       "notes",
+			URL:         "https://github.com/akuity/kargo/releases/tag/v1.1.0",
+			Prerelease:  true,
+			PublishedAt: &publishedAt,
+			Assets: []gitprovider.ReleaseAsset{{
+				Name:        "kargo-linux-amd64",
+				URL:         "https://github.com/akuity/kargo/releases/download/v1.1.0/kargo-linux-amd64",
+				Size:        1024,
+				ContentType: "application/octet-stream",
+				Digest:      "sha256:abc123",
+			}},
+		},
+		{TagName: "v1.0.0", Draft: true},
+	}, releases)
+}
+
 func TestMergePullRequest(t *testing.T) {
 	tests := []struct {
 		name               string
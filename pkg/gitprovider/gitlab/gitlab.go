@@ -69,10 +69,19 @@ type mergeRequestClient interface {
 	) (*gitlab.MergeRequest, *gitlab.Response, error)
 }
 
+type releaseClient interface {
+	ListReleases(
+		pid any,
+		opt *gitlab.ListReleasesOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Release, *gitlab.Response, error)
+}
+
 // provider is a GitLab-based implementation of gitprovider.Interface.
 type provider struct { // nolint: revive
-	projectName string
-	client      mergeRequestClient
+	projectName   string
+	client        mergeRequestClient
+	releaseClient releaseClient
 }
 
 // NewProvider returns a GitLab-based implementation of gitprovider.Interface.
@@ -114,8 +123,9 @@ func NewProvider(
 	}
 
 	return &provider{
-		projectName: projectName,
-		client:      client.MergeRequests,
+		projectName:   projectName,
+		client:        client.MergeRequests,
+		releaseClient: client.Releases,
 	}, nil
 }
 
@@ -262,6 +272,39 @@ func (p *provider) MergePullRequest(
 	return &pr, true, nil
 }
 
+// ListReleases implements gitprovider.ReleaseLister.
+func (p *provider) ListReleases(
+	_ context.Context,
+	opts *gitprovider.ListReleasesOptions,
+) ([]gitprovider.Release, error) {
+	if opts == nil {
+		opts = &gitprovider.ListReleasesOptions{}
+	}
+	listOpts := &gitlab.ListReleasesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100, // Max
+		},
+	}
+	var releases []gitprovider.Release
+	for {
+		glReleases, res, err := p.releaseClient.ListReleases(p.projectName, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, glRelease := range glReleases {
+			releases = append(releases, convertGitlabRelease(*glRelease))
+			if opts.Limit > 0 && len(releases) == opts.Limit {
+				return releases, nil
+			}
+		}
+		if res == nil || res.NextPage == 0 {
+			break
+		}
+		listOpts.Page = res.NextPage
+	}
+	return releases, nil
+}
+
 // GetCommitURL implements gitprovider.Interface.
 func (p *provider) GetCommitURL(repoURL string, sha string) (string, error) {
 	normalizedURL := urls.NormalizeGit(repoURL)
@@ -286,7 +329,37 @@ func convertGitlabMR(glMR gitlab.BasicMergeRequest) gitprovider.PullRequest {
 		Object:         glMR,
 		HeadSHA:        glMR.SHA,
 		CreatedAt:      glMR.CreatedAt,
+		Title:          glMR.Title,
+		HeadBranch:     glMR.SourceBranch,
+		BaseBranch:     glMR.TargetBranch,
+		Labels:         glMR.Labels,
+		Draft:          glMR.Draft,
+	}
+}
+
+func convertGitlabRelease(glRelease gitlab.Release) gitprovider.Release {
+	release := gitprovider.Release{
+		TagName: glRelease.TagName,
+		Name:    glRelease.Name,
+		Notes:   glRelease.Description,
+		URL:     glRelease.Links.Self,
+		// GitLab has no notion of drafts, but an upcoming release, i.e. one
+		// whose release date lies in the future, has not been published yet
+		// either.
+		Draft:       glRelease.UpcomingRelease,
+		PublishedAt: glRelease.ReleasedAt,
+	}
+	for _, link := range glRelease.Assets.Links {
+		assetURL := link.DirectAssetURL
+		if assetURL == "" {
+			assetURL = link.URL
+		}
+		release.Assets = append(release.Assets, gitprovider.ReleaseAsset{
+			Name: link.Name,
+			URL:  assetURL,
+		})
 	}
+	return release
 }
 
 func isMROpen(glMR gitlab.BasicMergeRequest) bool {
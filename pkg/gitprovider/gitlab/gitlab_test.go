@@ -3,6 +3,7 @@ package gitlab
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -72,6 +73,27 @@ func (m *mockGitLabClient) AcceptMergeRequest(
 	return m.mr, nil, nil
 }
 
+type mockReleaseClient struct {
+	pages    [][]*gitlab.Release
+	listOpts []gitlab.ListReleasesOptions
+	pid      any
+}
+
+func (m *mockReleaseClient) ListReleases(
+	pid any,
+	opt *gitlab.ListReleasesOptions,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.Release, *gitlab.Response, error) {
+	m.pid = pid
+	m.listOpts = append(m.listOpts, *opt)
+	page := len(m.listOpts)
+	res := &gitlab.Response{}
+	if page < len(m.pages) {
+		res.NextPage = int64(page + 1)
+	}
+	return m.pages[page-1], res, nil
+}
+
 func TestCreatePullRequest(t *testing.T) {
 	mockClient := &mockGitLabClient{
 		mr: &gitlab.MergeRequest{
@@ -169,6 +191,67 @@ func TestListPullRequests(t *testing.T) {
 	require.False(t, prs[0].Open)
 }
 
+func TestListReleases(t *testing.T) {
+	releasedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &mockReleaseClient{
+		pages: [][]*gitlab.Release{
+			{{
+				TagName:     "v1.1.0",
+				Name:        "Release 1.1.0",
+				Description: "notes",
+				ReleasedAt:  &releasedAt,
+				Links: gitlab.ReleaseLinks{
+					Self: "https://gitlab.com/group/project/-/releases/v1.1.0",
+				},
+				Assets: gitlab.ReleaseAssets{
+					Links: []*gitlab.ReleaseLink{
+						{
+							Name:           "bundle.tar.gz",
+							URL:            "https://example.com/bundle.tar.gz",
+							DirectAssetURL: "https://gitlab.com/group/project/-/releases/v1.1.0/downloads/bundle.tar.gz",
+						},
+						{
+							Name: "external.zip",
+							URL:  "https://example.com/external.zip",
+						},
+					},
+				},
+			}},
+			{{TagName: "v2.0.0", UpcomingRelease: true}},
+		},
+	}
+	g := provider{
+		projectName:   testProjectName,
+		releaseClient: mockClient,
+	}
+
+	releases, err := g.ListReleases(t.Context(), nil)
+	require.NoError(t, err)
+	require.Equal(t, testProjectName, mockClient.pid)
+	require.Len(t, mockClient.listOpts, 2)
+	require.Equal(t, int64(2), mockClient.listOpts[1].Page)
+	require.Equal(t, []gitprovider.Release{
+		{
+			TagName:     "v1.1.0",
+			Name:        "Release 1.1.0",
+			Notes:       "notes",
+			URL:         "https://gitlab.com/group/project/-/releases/v1.1.0",
+			PublishedAt: &releasedAt,
+			Assets: []gitprovider.ReleaseAsset{
+				{
+					Name: "bundle.tar.gz",
+					URL:  "https://gitlab.com/group/project/-/releases/v1.1.0/downloads/bundle.tar.gz",
+				},
+				{
+					Name: "external.zip",
+					URL:  "https://example.com/external.zip",
+				},
+			},
+		},
+		{TagName: "v2.0.0", Draft: true},
+	}, releases)
+}
+
 func TestMergePullRequest(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -64,6 +64,15 @@ type Interface interface {
 	GetCommitURL(repoURL string, commitID string) (string, error)
 }
 
+// ReleaseLister is an optional interface implemented by those implementations
+// of Interface whose underlying Git hosting provider has a notion of releases.
+// Callers should use a type assertion to determine whether a given
+// implementation of Interface supports it.
+type ReleaseLister interface {
+	// ListReleases lists the repository's releases, most recent first.
+	ListReleases(context.Context, *ListReleasesOptions) ([]Release, error)
+}
+
 // CreatePullRequestOpts encapsulates the options used when creating a pull
 // request.
 type CreatePullRequestOpts struct {
@@ -96,6 +105,14 @@ type ListPullRequestOptions struct {
 	BaseBranch string
 }
 
+// ListReleasesOptions encapsulates the options used when listing releases.
+type ListReleasesOptions struct {
+	// Limit is the maximum number of releases to list. Since releases are listed
+	// most recent first, this limits results to the most recent releases. A
+	// value of zero or less means no limit.
+	Limit int
+}
+
 // MergePullRequestOpts encapsulates the options used when merging a pull
 // request.
 type MergePullRequestOpts struct {
@@ -112,6 +129,18 @@ type PullRequest struct {
 	Number int64 `json:"id"`
 	// URL is the URL to the pull request.
 	URL string `json:"url"`
+	// Title is the title of the pull request.
+	Title string `json:"title,omitempty"`
+	// HeadBranch is the name of the source branch.
+	HeadBranch string `json:"headBranch,omitempty"`
+	// BaseBranch is the name of the target branch.
+	BaseBranch string `json:"baseBranch,omitempty"`
+	// Labels are the names of the labels applied to the pull request. Not every
+	// Git hosting provider supports labels.
+	Labels []string `json:"labels,omitempty"`
+	// Draft is true if the pull request is a draft that is not yet ready for
+	// review.
+	Draft bool `json:"draft,omitempty"`
 	// Open is true if the pull request is logically open. Depending on the
 	// underlying Git hosting provider, this may encompass pull requests in other
 	// states such as "draft" or "ready for review".
@@ -128,6 +157,44 @@ type PullRequest struct {
 	CreatedAt *time.Time `json:"createdAt"`
 }
 
+// Release is an abstracted representation of a Git hosting provider's release
+// object.
+type Release struct {
+	// TagName is the name of the tag the release was created from.
+	TagName string `json:"tagName"`
+	// Name is the title of the release.
+	Name string `json:"name,omitempty"`
+	// Notes are the release notes.
+	Notes string `json:"notes,omitempty"`
+	// URL is the URL to the release.
+	URL string `json:"url,omitempty"`
+	// Draft is true if the release is a draft that has not yet been published.
+	Draft bool `json:"draft,omitempty"`
+	// Prerelease is true if the Git hosting provider has the release flagged as
+	// a pre-release. Not every provider supports such a flag.
+	Prerelease bool `json:"prerelease,omitempty"`
+	// PublishedAt is the time the release was published.
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Assets are the files attached to the release.
+	Assets []ReleaseAsset `json:"assets,omitempty"`
+}
+
+// ReleaseAsset is an abstracted representation of a file attached to a
+// release.
+type ReleaseAsset struct {
+	// Name is the name of the asset.
+	Name string `json:"name"`
+	// URL is the URL from which the asset can be downloaded.
+	URL string `json:"url"`
+	// Size is the size of the asset in bytes, if known.
+	Size int64 `json:"size,omitempty"`
+	// ContentType is the media type of the asset, if known.
+	ContentType string `json:"contentType,omitempty"`
+	// Digest is the digest of the asset's content in the form
+	// <algorithm>:<hex>, if the Git hosting provider computes one.
+	Digest string `json:"digest,omitempty"`
+}
+
 // Fake is a fake implementation of the provider Interface used to facilitate
 // testing.
 type Fake struct {
@@ -149,6 +216,8 @@ type Fake struct {
 	MergePullRequestFn func(context.Context, int64, *MergePullRequestOpts) (*PullRequest, bool, error)
 	// GetCommitURLFn defines the functionality of the GetCommitURL method.
 	GetCommitURLFn func(repoURL string, commitID string) (string, error)
+	// ListReleasesFn defines the functionality of the ListReleases method.
+	ListReleasesFn func(context.Context, *ListReleasesOptions) ([]Release, error)
 }
 
 // CreatePullRequest implements gitprovider.Interface.
@@ -188,3 +257,11 @@ func (f *Fake) MergePullRequest(
 func (f *Fake) GetCommitURL(repoURL string, sha string) (string, error) {
 	return f.GetCommitURLFn(repoURL, sha)
 }
+
+// ListReleases implements gitprovider.ReleaseLister.
+func (f *Fake) ListReleases(
+	ctx context.Context,
+	opts *ListReleasesOptions,
+) ([]Release, error) {
+	return f.ListReleasesFn(ctx, opts)
+}
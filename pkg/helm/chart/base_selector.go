@@ -1,6 +1,7 @@
 package chart
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 // baseSelector is a base implementation of Selector that provides common
@@ -65,6 +67,46 @@ func (b *baseSelector) filterSemvers(
 	return slices.Clip(filtered)
 }
 
+// explainFilteredSemvers records, for every semantic version in candidates
+// that does not appear in filtered, that it was rejected for not satisfying
+// the selector's constraint. It is a no-op if the provided context does not
+// carry an explain.Recorder.
+func (b *baseSelector) explainFilteredSemvers(
+	ctx context.Context,
+	candidates semver.Collection,
+	filtered semver.Collection,
+) {
+	explain.Filtered(
+		ctx,
+		candidates,
+		filtered,
+		(*semver.Version).Original,
+		func(*semver.Version) string {
+			return fmt.Sprintf("version does not satisfy semverConstraint %q", b.constraint.String())
+		},
+	)
+}
+
+// explainDiscoveryLimit records, for every semantic version in the provided,
+// sorted collection that exceeds the selector's discovery limit, that it was
+// rejected for that reason. It is a no-op if the provided context does not
+// carry an explain.Recorder.
+func (b *baseSelector) explainDiscoveryLimit(
+	ctx context.Context,
+	semvers semver.Collection,
+) {
+	if b.discoveryLimit <= 0 || len(semvers) <= b.discoveryLimit {
+		return
+	}
+	for _, sv := range semvers[b.discoveryLimit:] {
+		explain.Reject(
+			ctx,
+			sv.Original(),
+			fmt.Sprintf("discovery limit of %d reached", b.discoveryLimit),
+		)
+	}
+}
+
 // sort sorts the provided semantic versions from greatest to least in place.
 func (b *baseSelector) sort(semvers semver.Collection) {
 	slices.SortFunc(semvers, func(lhs, rhs *semver.Version) int {
@@ -6,6 +6,8 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func Test_baseSelector_semversToVersionStrings(t *testing.T) {
@@ -131,3 +133,33 @@ func Test_baseSelector_filterSemvers(t *testing.T) {
 		})
 	}
 }
+
+func Test_baseSelector_explainRejections(t *testing.T) {
+	constraint, err := semver.NewConstraint("^1.0.0")
+	require.NoError(t, err)
+	s := &baseSelector{
+		constraint:     constraint,
+		discoveryLimit: 1,
+	}
+	recorder := explain.NewRecorder()
+	ctx := explain.ContextWithRecorder(t.Context(), recorder)
+
+	candidates := semver.Collection{
+		semver.MustParse("1.0.0"),
+		semver.MustParse("1.1.0"),
+		semver.MustParse("2.0.0"),
+	}
+	filtered := s.filterSemvers(candidates)
+	s.explainFilteredSemvers(ctx, candidates, filtered)
+	s.sort(filtered)
+	s.explainDiscoveryLimit(ctx, filtered)
+
+	require.Equal(
+		t,
+		[]explain.Rejection{
+			{Candidate: "2.0.0", Reason: `version does not satisfy semverConstraint "^1.0.0"`},
+			{Candidate: "1.0.0", Reason: "discovery limit of 1 reached"},
+		},
+		recorder.Rejections(),
+	)
+}
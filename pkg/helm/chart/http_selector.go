@@ -15,6 +15,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/helm"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 // responseHeaderTimeout bounds how long a request to a chart repository may
@@ -31,6 +32,7 @@ type httpSelector struct {
 	indexURL  string
 	chartName string
 	creds     *helm.Credentials
+	rateLimit int
 }
 
 func newHTTPSelector(
@@ -49,6 +51,7 @@ func newHTTPSelector(
 		),
 		chartName: sub.Name,
 		creds:     creds,
+		rateLimit: int(sub.RateLimit),
 	}, nil
 }
 
@@ -69,7 +72,9 @@ func (h *httpSelector) Select(ctx context.Context) ([]string, error) {
 			InsecureSkipVerify: true, // #nosec G402 -- explicitly allowed by insecureSkipTLSVerify
 		}
 	}
-	httpClient := &http.Client{Transport: httpTransport}
+	httpClient := &http.Client{
+		Transport: helm.NewThrottledTransport(httpTransport, h.rateLimit),
+	}
 	res, err := httpClient.Do(req) // #nosec G704 -- SSRF mitigated: no method/header control, no response access
 	if err != nil {
 		return nil,
@@ -106,11 +111,16 @@ func (h *httpSelector) Select(ctx context.Context) ([]string, error) {
 	semvers := make(semver.Collection, 0, len(entries))
 	for _, entry := range entries {
 		sv, err := semver.NewVersion(entry.Version)
-		if err == nil {
-			semvers = append(semvers, sv)
+		if err != nil {
+			explain.Reject(ctx, entry.Version, "version is not a semantic version")
+			continue
 		}
+		semvers = append(semvers, sv)
 	}
-	semvers = h.filterSemvers(semvers)
+	filtered := h.filterSemvers(semvers)
+	h.explainFilteredSemvers(ctx, semvers, filtered)
+	semvers = filtered
 	h.sort(semvers)
+	h.explainDiscoveryLimit(ctx, semvers)
 	return h.semversToVersionStrings(semvers), nil
 }
@@ -12,6 +12,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/helm"
+	"github.com/akuity/kargo/pkg/throttle"
 )
 
 func TestNewHTTPSelector(t *testing.T) {
@@ -231,3 +232,23 @@ func Test_httpSelector_Select_honorsContext(t *testing.T) {
 	_, err := s.Select(ctx)
 	require.ErrorIs(t, err, context.Canceled)
 }
+
+func Test_httpSelector_Select_throttled(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}),
+	)
+	t.Cleanup(testServer.Close)
+
+	s := &httpSelector{
+		baseSelector: &baseSelector{repoURL: testServer.URL},
+		indexURL:     fmt.Sprintf("%s/index.yaml", testServer.URL),
+		chartName:    "fake-chart",
+	}
+	_, err := s.Select(t.Context())
+	var throttledErr *throttle.ThrottledError
+	require.ErrorAs(t, err, &throttledErr)
+	require.Equal(t, strings.TrimPrefix(testServer.URL, "http://"), throttledErr.Host)
+}
@@ -11,6 +11,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/helm"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 // ociSelector is an implementation of Selector that interacts with OCI Helm
@@ -36,7 +37,7 @@ func newOCISelector(
 			fmt.Errorf("error parsing repository URL %q: %w", sub.RepoURL, err)
 	}
 
-	authorizer := helm.NewEphemeralAuthorizer(sub.InsecureSkipTLSVerify)
+	authorizer := helm.NewRateLimitedEphemeralAuthorizer(sub.InsecureSkipTLSVerify, int(sub.RateLimit))
 	if creds != nil {
 		if err = authorizer.Login(ctx, ref.Host(), creds.Username, creds.Password); err != nil {
 			return nil, fmt.Errorf(
@@ -65,9 +66,12 @@ func (o *ociSelector) Select(ctx context.Context) ([]string, error) {
 			// OCI artifact tags are not allowed to contain the "+" character, which is
 			// used by SemVer to separate the version from the build metadata. To work
 			// around this, Helm uses "_" instead of "+".
-			if sv, err := semver.StrictNewVersion(strings.ReplaceAll(tag, "_", "+")); err == nil {
-				semvers = append(semvers, sv)
+			sv, err := semver.StrictNewVersion(strings.ReplaceAll(tag, "_", "+"))
+			if err != nil {
+				explain.Reject(ctx, tag, "tag is not a strict semantic version")
+				continue
 			}
+			semvers = append(semvers, sv)
 		}
 		return nil
 	}); err != nil {
@@ -77,7 +81,10 @@ func (o *ociSelector) Select(ctx context.Context) ([]string, error) {
 			err,
 		)
 	}
-	semvers = o.filterSemvers(semvers)
+	filtered := o.filterSemvers(semvers)
+	o.explainFilteredSemvers(ctx, semvers, filtered)
+	semvers = filtered
 	o.sort(semvers)
+	o.explainDiscoveryLimit(ctx, semvers)
 	return o.semversToVersionStrings(semvers), nil
 }
@@ -1,11 +1,15 @@
 package chart
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/throttle"
 )
 
 func TestNewOCISelector(t *testing.T) {
@@ -83,3 +87,28 @@ func Test_ociSelector_Select(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, versions)
 }
+
+func Test_ociSelector_Select_throttled(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}),
+	)
+	t.Cleanup(testServer.Close)
+	host := strings.TrimPrefix(testServer.URL, "http://")
+
+	s, err := newOCISelector(
+		t.Context(),
+		kargoapi.ChartSubscription{
+			RepoURL:   "oci://" + host + "/fake-chart",
+			RateLimit: 5,
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	_, err = s.Select(t.Context())
+	var throttledErr *throttle.ThrottledError
+	require.ErrorAs(t, err, &throttledErr)
+	require.Equal(t, host, throttledErr.Host)
+}
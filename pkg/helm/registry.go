@@ -15,6 +15,9 @@ import (
 	"oras.land/oras-go/v2/registry/remote/credentials"
 	"oras.land/oras-go/v2/registry/remote/retry"
 
+	"github.com/akuity/kargo/pkg/os"
+	"github.com/akuity/kargo/pkg/throttle"
+	"github.com/akuity/kargo/pkg/types"
 	"github.com/akuity/kargo/pkg/x/version"
 )
 
@@ -25,6 +28,26 @@ import (
 // limit on the total duration of a response transfer.
 const responseHeaderTimeout = 30 * time.Second
 
+// registryLimits applies rate limits to all interactions with chart
+// repositories and OCI registries on a per-host basis. By default, requests
+// are limited to CHART_REGISTRY_RATE_LIMIT requests per second, with bursts of
+// up to CHART_REGISTRY_RATE_LIMIT_BURST requests. Subscriptions may lower,
+// but never raise, the rate.
+var registryLimits = throttle.NewRegistry(throttle.Limits{
+	RequestsPerSecond: types.MustParseInt(os.GetEnv("CHART_REGISTRY_RATE_LIMIT", "20")),
+	Burst:             types.MustParseInt(os.GetEnv("CHART_REGISTRY_RATE_LIMIT_BURST", "10")),
+})
+
+// NewThrottledTransport returns an http.RoundTripper that applies rate limits
+// to requests to chart repositories and OCI registries before delegating them
+// to the provided http.RoundTripper. If rateLimit is greater than zero and
+// lower than the default rate limit, in requests per second, it further limits
+// requests. Requests to a host that has asked for requests to be paused fail
+// with a *throttle.ThrottledError.
+func NewThrottledTransport(rt http.RoundTripper, rateLimit int) http.RoundTripper {
+	return registryLimits.Transport(rt, rateLimit)
+}
+
 // NewRegistryClient creates a new registry client using the provided authorizer.
 // This can be combined with an EphemeralAuthorizer to create a client that
 // does not persist credentials to disk. The authorizer is used to authenticate
@@ -68,8 +91,21 @@ type EphemeralAuthorizer struct {
 // credentials permanently. When insecure is true, TLS certificate verification
 // errors are ignored. This should be enabled only with great caution.
 func NewEphemeralAuthorizer(insecure bool) *EphemeralAuthorizer {
+	return NewRateLimitedEphemeralAuthorizer(insecure, 0)
+}
+
+// NewRateLimitedEphemeralAuthorizer is identical to NewEphemeralAuthorizer,
+// except that if rateLimit is greater than zero, it overrides the default rate
+// limit, in requests per second, applied to requests to the registry.
+func NewRateLimitedEphemeralAuthorizer(insecure bool, rateLimit int) *EphemeralAuthorizer {
+	// NB: Rate limits are applied beneath the retry transport so that every
+	// attempt counts against them. A registry asking for requests to be paused
+	// results in an error the retry transport does not retry, so the pause it
+	// asked for is honored instead of being retried with backoff.
 	httpClient := &http.Client{
-		Transport: retry.NewTransport(newRegistryTransport(insecure)),
+		Transport: retry.NewTransport(
+			NewThrottledTransport(newRegistryTransport(insecure), rateLimit),
+		),
 	}
 
 	store := credentials.NewMemoryStore()
@@ -72,12 +72,17 @@ func TestNewEphemeralAuthorizer(t *testing.T) {
 		assert.NotNil(t, authorizer)
 		assert.NotNil(t, authorizer.Client.Client)
 
-		// Unwrap the transport chain: retry.Transport -> *http.Transport to
-		// verify InsecureSkipVerify is set.
+		// Unwrap the transport chain: retry.Transport -> throttled transport ->
+		// *http.Transport to verify InsecureSkipVerify is set.
 		retryT, retryOK := authorizer.Client.Client.Transport.(*retry.Transport)
 		assert.True(t, retryOK, "expected outermost transport to be *retry.Transport")
 		if retryOK {
-			httpT, httpOK := retryT.Base.(*http.Transport)
+			throttledT, throttledOK := retryT.Base.(interface{ Unwrap() http.RoundTripper })
+			assert.True(t, throttledOK, "expected retry transport to wrap a throttled transport")
+			if !throttledOK {
+				return
+			}
+			httpT, httpOK := throttledT.Unwrap().(*http.Transport)
 			assert.True(t, httpOK, "expected base transport to be *http.Transport")
 			if httpOK {
 				assert.NotNil(t, httpT.TLSClientConfig)
@@ -38,6 +38,7 @@ func newBaseSelector(
 		sub.InsecureSkipTLSVerify,
 		creds,
 		cacheByTag,
+		int(sub.RateLimit),
 	); err != nil {
 		return nil, fmt.Errorf(
 			"error creating repository client for image %q: %w",
@@ -1,6 +1,9 @@
 package image
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/akuity/kargo/pkg/cache"
 	"github.com/akuity/kargo/pkg/os"
 	"github.com/akuity/kargo/pkg/types"
@@ -18,6 +21,22 @@ func init() {
 	}
 }
 
+// NewCacheFromEnv returns a cache for image metadata using the backend
+// selected by IMAGE_CACHE_-prefixed environment variables (see
+// cache.ConfigFromEnv). The returned cache is put to use by passing it to
+// SetCache. Until then, image metadata is cached in memory.
+func NewCacheFromEnv() (cache.Cache[image], error) {
+	cfg, err := cache.ConfigFromEnv("IMAGE")
+	if err != nil {
+		return nil, err
+	}
+	maxEntries, err := strconv.Atoi(os.GetEnv("MAX_IMAGE_CACHE_ENTRIES", "100000"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing MAX_IMAGE_CACHE_ENTRIES: %w", err)
+	}
+	return cache.New[image](cfg, "images", maxEntries)
+}
+
 func SetCache(c cache.Cache[image]) {
 	imageCache = c
 }
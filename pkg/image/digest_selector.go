@@ -10,6 +10,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -81,6 +82,7 @@ func (d *digestSelector) Select(
 
 	if img == nil {
 		logger.Trace("image with tag did not match platform constraints")
+		explain.Reject(ctx, d.mutableTag, platformRejectionReason)
 		return nil, nil
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -60,7 +61,9 @@ func (l *lexicalSelector) Select(
 	}
 	logger.Trace("got all tags")
 
-	tags = l.filterTags(tags)
+	filteredTags := l.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, l.tagRejectionReason)
+	tags = filteredTags
 	if len(tags) == 0 {
 		logger.Trace("no tags matched criteria")
 		return nil, nil
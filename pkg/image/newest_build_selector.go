@@ -9,6 +9,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -62,7 +63,9 @@ func (n *newestBuildSelector) Select(
 	}
 	logger.Trace("got all tags")
 
-	tags = n.filterTags(tags)
+	filteredTags := n.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, n.tagRejectionReason)
+	tags = filteredTags
 	if len(tags) == 0 {
 		logger.Trace("no tags matched criteria")
 		return nil, nil
@@ -90,6 +93,10 @@ func (n *newestBuildSelector) Select(
 		limit = len(images)
 	}
 
+	for _, image := range images[limit:] {
+		explain.Reject(ctx, image.Tag, limitRejectionReason(limit))
+	}
+
 	for _, image := range images[:limit] {
 		logger.Trace(
 			"discovered image",
@@ -153,6 +160,7 @@ func (n *newestBuildSelector) getImagesByTags(
 			}
 			if image == nil {
 				// This shouldn't happen
+				explain.Reject(ctx, tag, platformRejectionReason)
 				return
 			}
 			// imageCh is buffered and sized appropriately, so this will never block.
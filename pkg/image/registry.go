@@ -4,23 +4,28 @@ import (
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/name"
-	"go.uber.org/ratelimit"
 
 	"github.com/akuity/kargo/pkg/os"
+	"github.com/akuity/kargo/pkg/throttle"
 	"github.com/akuity/kargo/pkg/types"
 )
 
 var (
-	// rateLimit is the rate limit (in requests per second) to apply to all
-	// registry interactions on a per-registry basis.
-	rateLimit = types.MustParseInt(os.GetEnv("IMAGE_REGISTRY_RATE_LIMIT", "20"))
+	// registryLimits applies rate limits to all registry interactions on a
+	// per-registry basis. By default, requests are limited to
+	// IMAGE_REGISTRY_RATE_LIMIT requests per second, with bursts of up to
+	// IMAGE_REGISTRY_RATE_LIMIT_BURST requests. Subscriptions may lower, but
+	// never raise, the rate.
+	registryLimits = throttle.NewRegistry(throttle.Limits{
+		RequestsPerSecond: types.MustParseInt(os.GetEnv("IMAGE_REGISTRY_RATE_LIMIT", "20")),
+		Burst:             types.MustParseInt(os.GetEnv("IMAGE_REGISTRY_RATE_LIMIT_BURST", "10")),
+	})
 
 	// dockerRegistry is registry configuration for Docker Hub.
 	dockerRegistry = &registry{
 		name:             "Docker Hub",
 		imagePrefix:      name.DefaultRegistry,
 		defaultNamespace: "library",
-		rateLimiter:      ratelimit.New(rateLimit),
 	}
 
 	// registries is a map of Registries indexed by image prefix and is pre-loaded
@@ -40,7 +45,6 @@ type registry struct {
 	name             string
 	imagePrefix      string
 	defaultNamespace string
-	rateLimiter      ratelimit.Limiter
 }
 
 // newRegistry initializes and returns a new registry.
@@ -48,9 +52,6 @@ func newRegistry(imagePrefix string) *registry {
 	return &registry{
 		name:        imagePrefix,
 		imagePrefix: imagePrefix,
-		// TODO(krancour): We probably need to make this tunable per registry in
-		// the future.
-		rateLimiter: ratelimit.New(rateLimit),
 	}
 }
 
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"maps"
-	"net/http"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -15,7 +14,6 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/hashicorp/go-cleanhttp"
-	"go.uber.org/ratelimit"
 	"golang.org/x/sync/semaphore"
 
 	"github.com/akuity/kargo/pkg/cache"
@@ -93,12 +91,15 @@ type repositoryClient struct {
 
 // newRepositoryClient parses the provided repository URL to infer registry
 // information and image name. This information is used to initialize and
-// return a new repository client.
+// return a new repository client. If rateLimit is greater than zero and lower
+// than the default rate limit, in requests per second, applied to requests to
+// the registry, it further limits requests made by the client.
 func newRepositoryClient(
 	repoURL string,
 	insecureSkipTLSVerify bool,
 	creds *Credentials,
 	cacheByTag bool,
+	rateLimit int,
 ) (*repositoryClient, error) {
 	repoRef, err := name.ParseReference(repoURL)
 	if err != nil {
@@ -129,10 +130,7 @@ func newRepositoryClient(
 		repoURL:    repoURL,
 		repoRef:    repoRef,
 		remoteOptions: []remote.Option{
-			remote.WithTransport(&rateLimitedRoundTripper{
-				limiter:              reg.rateLimiter,
-				internalRoundTripper: httpTransport,
-			}),
+			remote.WithTransport(registryLimits.Transport(httpTransport, rateLimit)),
 			remote.WithAuth(auth),
 		},
 	}
@@ -495,18 +493,3 @@ func creationTimeFromMetadata(metadata map[string]string) *time.Time {
 	}
 	return nil
 }
-
-// rateLimitedRoundTripper is a rate limited implementation of
-// http.RoundTripper.
-type rateLimitedRoundTripper struct {
-	limiter              ratelimit.Limiter
-	internalRoundTripper http.RoundTripper
-}
-
-// RoundTrip implements the http.RoundTripper interface.
-func (r *rateLimitedRoundTripper) RoundTrip(
-	req *http.Request,
-) (*http.Response, error) {
-	r.limiter.Take()
-	return r.internalRoundTripper.RoundTrip(req)
-}
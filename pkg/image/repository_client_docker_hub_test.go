@@ -18,7 +18,7 @@ import (
 // - DOCKER_HUB_PASSWORD (personal access token)
 
 func TestGetTags(t *testing.T) {
-	client, err := newRepositoryClient("debian", false, getDockerHubCreds(), true)
+	client, err := newRepositoryClient("debian", false, getDockerHubCreds(), true, 0)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	tags, err := client.getTags(t.Context())
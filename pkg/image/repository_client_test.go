@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,10 +18,11 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/akuity/kargo/pkg/cache"
+	"github.com/akuity/kargo/pkg/throttle"
 )
 
 func TestNewRepositoryClient(t *testing.T) {
-	client, err := newRepositoryClient("debian", false, nil, true)
+	client, err := newRepositoryClient("debian", false, nil, true, 0)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 	require.NotNil(t, client.imageCache)
@@ -36,6 +40,24 @@ func TestNewRepositoryClient(t *testing.T) {
 	require.NotNil(t, client.remoteGetFn)
 }
 
+func Test_repositoryClient_getTags_throttled(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}),
+	)
+	t.Cleanup(testServer.Close)
+	host := strings.TrimPrefix(testServer.URL, "http://")
+
+	client, err := newRepositoryClient(host+"/fake-image", false, nil, false, 5)
+	require.NoError(t, err)
+	_, err = client.getTags(t.Context())
+	var throttledErr *throttle.ThrottledError
+	require.ErrorAs(t, err, &throttledErr)
+	require.Equal(t, host, throttledErr.Host)
+}
+
 func Test_repositoryClient_getImageByTag(t *testing.T) {
 	const testRepoURL = "fake-url"
 	const testTag = "fake-tag"
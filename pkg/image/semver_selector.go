@@ -11,6 +11,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	libSemver "github.com/akuity/kargo/pkg/controller/semver"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 func init() {
@@ -77,6 +78,22 @@ func (s *semverSelector) MatchesTag(tag string) bool {
 	return s.constraint == nil || s.constraint.Check(sv)
 }
 
+// tagRejectionReason returns a human-readable explanation of why the given tag
+// does not satisfy the constraints defined by the s.MatchesTag method. It is
+// only meaningful for tags that s.MatchesTag has already rejected.
+func (s *semverSelector) tagRejectionReason(tag string) string {
+	if !s.tagBasedSelector.MatchesTag(tag) {
+		return s.tagBasedSelector.tagRejectionReason(tag)
+	}
+	if libSemver.Parse(tag, s.strictSemvers) == nil {
+		if s.strictSemvers {
+			return "tag is not a strict semantic version"
+		}
+		return "tag is not a semantic version"
+	}
+	return fmt.Sprintf("tag does not satisfy semverConstraint %q", s.constraint.String())
+}
+
 // Select implements the Selector interface.
 func (s *semverSelector) Select(
 	ctx context.Context,
@@ -103,7 +120,9 @@ func (s *semverSelector) Select(
 
 	// Note: This is calling this type's own implementation of filterTags() and
 	// NOT directly calling tagBasedSelector's implementation.
-	tags = s.filterTags(tags)
+	filteredTags := s.filterTags(tags)
+	explain.Filtered(ctx, tags, filteredTags, tagName, s.tagRejectionReason)
+	tags = filteredTags
 	if len(tags) == 0 {
 		logger.Trace("no tags matched criteria")
 		return nil, nil
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/stretchr/testify/require"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
@@ -186,3 +187,26 @@ func Test_semVerSelector_sort(t *testing.T) {
 		sorted,
 	)
 }
+
+func Test_semverSelector_tagRejectionReason(t *testing.T) {
+	constraint, err := semver.NewConstraint("^1.0.0")
+	require.NoError(t, err)
+	s := &semverSelector{
+		tagBasedSelector: &tagBasedSelector{
+			ignoreTagsRegexes: []*regexp.Regexp{regexp.MustCompile(`-rc`)},
+		},
+		constraint:    constraint,
+		strictSemvers: true,
+	}
+	require.Equal(
+		t,
+		`tag matches ignoreTagsRegexes entry "-rc"`,
+		s.tagRejectionReason("v1.1.0-rc.1"),
+	)
+	require.Equal(t, "tag is not a strict semantic version", s.tagRejectionReason("v1.1"))
+	require.Equal(
+		t,
+		`tag does not satisfy semverConstraint "^1.0.0"`,
+		s.tagRejectionReason("v2.0.0"),
+	)
+}
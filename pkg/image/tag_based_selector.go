@@ -9,6 +9,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/subscription/explain"
 )
 
 // tagBasedSelector is a base implementation of Selector that provides common
@@ -99,6 +100,18 @@ func (t *tagBasedSelector) MatchesTag(tag string) bool {
 	return false
 }
 
+// tagRejectionReason returns a human-readable explanation of why the given tag
+// does not satisfy the constraints defined by the t.MatchesTag method. It is
+// only meaningful for tags that t.MatchesTag has already rejected.
+func (t *tagBasedSelector) tagRejectionReason(tag string) string {
+	for _, regex := range t.ignoreTagsRegexes {
+		if regex.MatchString(tag) {
+			return fmt.Sprintf("tag matches ignoreTagsRegexes entry %q", regex.String())
+		}
+	}
+	return "tag does not match any allowTagsRegexes entry"
+}
+
 // getLoggerContext returns key/value pairs that can be used by any selector
 // that images by retrieving, filtering, and sorting image tags to enrich
 // loggers with valuable context.
@@ -137,8 +150,11 @@ func (t *tagBasedSelector) getImagesByTags(
 		limit = len(tags)
 	}
 	images := make([]image, 0, limit)
-	for _, tag := range tags {
+	for i, tag := range tags {
 		if len(images) >= limit {
+			for _, skipped := range tags[i:] {
+				explain.Reject(ctx, skipped, limitRejectionReason(t.discoveryLimit))
+			}
 			break
 		}
 
@@ -151,6 +167,7 @@ func (t *tagBasedSelector) getImagesByTags(
 				"image was found, but did not match platform constraint",
 				"tag", tag,
 			)
+			explain.Reject(ctx, tag, platformRejectionReason)
 			continue
 		}
 
@@ -164,3 +181,19 @@ func (t *tagBasedSelector) getImagesByTags(
 
 	return slices.Clip(images), nil
 }
+
+// platformRejectionReason explains why an image was filtered out when it did
+// not match a selector's platform constraint.
+const platformRejectionReason = "image does not match platform constraint"
+
+// tagName returns the provided tag unchanged. It is used for identifying tags
+// when explaining why they were filtered out.
+func tagName(tag string) string {
+	return tag
+}
+
+// limitRejectionReason returns a human-readable explanation of why a candidate
+// was excluded after the specified discovery limit was reached.
+func limitRejectionReason(limit int) string {
+	return fmt.Sprintf("discovery limit of %d reached", limit)
+}
@@ -166,3 +166,20 @@ func Test_tagBasedSelector_filterTags(t *testing.T) {
 	})
 	require.Equal(t, []string{"v1.1.0"}, filtered)
 }
+
+func Test_tagBasedSelector_tagRejectionReason(t *testing.T) {
+	s := &tagBasedSelector{
+		allowTagsRegexes:  []*regexp.Regexp{regexp.MustCompile(`v1\.`)},
+		ignoreTagsRegexes: []*regexp.Regexp{regexp.MustCompile(`^v1\.0\.0$`)},
+	}
+	require.Equal(
+		t,
+		`tag matches ignoreTagsRegexes entry "^v1\\.0\\.0$"`,
+		s.tagRejectionReason("v1.0.0"),
+	)
+	require.Equal(
+		t,
+		"tag does not match any allowTagsRegexes entry",
+		s.tagRejectionReason("v2.0.0"),
+	)
+}
@@ -44,7 +44,8 @@ const (
 	StagesByUpstreamStagesField = "upstreamStages"
 	StagesByWarehouseField      = "warehouse"
 
-	ServiceAccountsByOIDCClaimsField = "claims"
+	ServiceAccountsByOIDCClaimsField  = "claims"
+	ServiceAccountsByRoleMappingField = "roleMapping"
 
 	WarehousesBySubscribedURLsField           = "subscribedURLs"
 	ProjectConfigsByWebhookReceiverPathsField = "receiverPaths"
@@ -620,6 +621,53 @@ func ServiceAccountsByOIDCClaims(obj client.Object) []string {
 	return refinedClaimValues
 }
 
+// RoleMappingExpressionsKey is the single value that the
+// ServiceAccountsByRoleMapping index yields for a ServiceAccount whose role
+// mapping criteria include CEL expressions. Expressions cannot be indexed, so
+// such ServiceAccounts must be retrieved and evaluated individually.
+const RoleMappingExpressionsKey = "expressions"
+
+// FormatRoleMappingGroup formats a group to be used by the
+// ServiceAccountsByRoleMapping index.
+func FormatRoleMappingGroup(group string) string {
+	return "group/" + group
+}
+
+// FormatRoleMappingEmailDomain formats an email domain to be used by the
+// ServiceAccountsByRoleMapping index. Domains are case-insensitive.
+func FormatRoleMappingEmailDomain(domain string) string {
+	return "emailDomain/" + strings.ToLower(domain)
+}
+
+// ServiceAccountsByRoleMapping is a client.IndexerFunc that indexes
+// ServiceAccounts by the role mapping criteria recorded in their
+// rbac.kargo.akuity.io/role-mapping annotation.
+func ServiceAccountsByRoleMapping(obj client.Object) []string {
+	sa, ok := obj.(*corev1.ServiceAccount)
+	if !ok {
+		return nil
+	}
+	criteria, err := rbacapi.RoleMappingCriteriaFromAnnotationValues(sa.GetAnnotations())
+	if err != nil || criteria.IsEmpty() {
+		return nil
+	}
+	keys := make(
+		[]string,
+		0,
+		len(criteria.Groups)+len(criteria.EmailDomains)+1,
+	)
+	for _, group := range criteria.Groups {
+		keys = append(keys, FormatRoleMappingGroup(group))
+	}
+	for _, domain := range criteria.EmailDomains {
+		keys = append(keys, FormatRoleMappingEmailDomain(domain))
+	}
+	if len(criteria.Expressions) > 0 {
+		keys = append(keys, RoleMappingExpressionsKey)
+	}
+	return keys
+}
+
 // WarehousesBySubscribedURLs is a client.IndexerFunc that indexes Warehouses by the
 // repositories they subscribe to.
 func WarehousesBySubscribedURLs(obj client.Object) []string {
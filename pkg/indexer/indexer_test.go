@@ -1338,6 +1338,66 @@ func TestServiceAccountsByOIDCClaims(t *testing.T) {
 	}
 }
 
+func TestServiceAccountsByRoleMapping(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sa       *corev1.ServiceAccount
+		expected []string
+	}{
+		{
+			name: "has no role mapping",
+			sa:   &corev1.ServiceAccount{},
+		},
+		{
+			name: "has invalid role mapping",
+			sa: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						rbacapi.AnnotationKeyRoleMapping: "invalid-input",
+					},
+				},
+			},
+		},
+		{
+			name: "has empty role mapping",
+			sa: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						rbacapi.AnnotationKeyRoleMapping: "{}",
+					},
+				},
+			},
+		},
+		{
+			name: "has role mapping",
+			sa: &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						rbacapi.AnnotationKeyRoleMapping: `{
+							"groups": ["devops", "admins"],
+							"emailDomains": ["Example.com"],
+							"expressions": ["claims.department == 'payments'", "claims.tier == 'gold'"]
+						}`,
+					},
+				},
+			},
+			expected: []string{
+				"emailDomain/example.com",
+				"expressions",
+				"group/admins",
+				"group/devops",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			keys := ServiceAccountsByRoleMapping(testCase.sa)
+			slices.Sort(keys)
+			require.Equal(t, testCase.expected, keys)
+		})
+	}
+}
+
 func TestWarehousesByRepoURL(t *testing.T) {
 	for _, test := range []struct {
 		name      string
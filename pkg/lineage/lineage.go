@@ -0,0 +1,345 @@
+package lineage
+
+import (
+	"slices"
+	"strings"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// NodeKind indicates the kind of resource represented by a Node.
+type NodeKind string // @name LineageNodeKind
+
+const (
+	NodeKindWarehouse NodeKind = "Warehouse"
+	NodeKindFreight   NodeKind = "Freight"
+	NodeKindStage     NodeKind = "Stage"
+)
+
+// EdgeType indicates the relationship between the two Nodes connected by an
+// Edge.
+type EdgeType string // @name LineageEdgeType
+
+const (
+	// EdgeTypeProduced connects the origin of a piece of Freight (e.g. a
+	// Warehouse) to the Freight it produced.
+	EdgeTypeProduced EdgeType = "Produced"
+	// EdgeTypeDelivered connects a piece of Freight to a Stage it has been
+	// approved for, promoted to, or used by.
+	EdgeTypeDelivered EdgeType = "Delivered"
+	// EdgeTypeUpstream connects a Stage to a downstream Stage that sources
+	// Freight from it.
+	EdgeTypeUpstream EdgeType = "Upstream"
+)
+
+// Graph is a directed graph describing where Freight came from and how it
+// made its way through a Project's Stages.
+type Graph struct {
+	// Nodes are the Warehouses, Freight, and Stages in the graph.
+	Nodes []Node `json:"nodes"`
+	// Edges are the relationships between Nodes.
+	Edges []Edge `json:"edges"`
+} // @name LineageGraph
+
+// Node is a single resource in a Graph.
+type Node struct {
+	// ID uniquely identifies the Node within the Graph.
+	ID string `json:"id"`
+	// Kind is the kind of resource represented by the Node.
+	Kind NodeKind `json:"kind"`
+	// Name is the name of the resource represented by the Node.
+	Name string `json:"name"`
+	// Alias is the alias of a piece of Freight. It is empty for other kinds of
+	// Nodes.
+	Alias string `json:"alias,omitempty"`
+	// Artifacts is a human-readable description of each artifact referenced by
+	// a piece of Freight. It is empty for other kinds of Nodes.
+	Artifacts []string `json:"artifacts,omitempty"`
+} // @name LineageNode
+
+// Edge is a directed relationship between two Nodes in a Graph.
+type Edge struct {
+	// From is the ID of the Node at which the Edge starts.
+	From string `json:"from"`
+	// To is the ID of the Node at which the Edge ends.
+	To string `json:"to"`
+	// Type is the type of relationship represented by the Edge.
+	Type EdgeType `json:"type"`
+	// CurrentlyIn indicates that the Freight is currently in use by the Stage.
+	CurrentlyIn bool `json:"currentlyIn,omitempty"`
+	// VerifiedIn indicates that the Freight has been verified in the Stage.
+	VerifiedIn bool `json:"verifiedIn,omitempty"`
+	// ApprovedFor indicates that the Freight has been manually approved for
+	// the Stage.
+	ApprovedFor bool `json:"approvedFor,omitempty"`
+	// InHistory indicates that the Freight appears in the Stage's Freight
+	// history.
+	InHistory bool `json:"inHistory,omitempty"`
+	// Promotions are the Promotions of the Freight to the Stage, ordered by
+	// name.
+	Promotions []Promotion `json:"promotions,omitempty"`
+} // @name LineageEdge
+
+// Promotion summarizes a Promotion of a piece of Freight to a Stage.
+type Promotion struct {
+	// Name is the name of the Promotion.
+	Name string `json:"name"`
+	// Phase is the current phase of the Promotion.
+	Phase kargoapi.PromotionPhase `json:"phase,omitempty"`
+} // @name LineagePromotion
+
+// Query selects the Freight around which a Graph is built. All non-empty
+// fields must match for a piece of Freight to be selected. An empty Query
+// selects all Freight.
+type Query struct {
+	// Commit is a Git commit SHA, or a prefix thereof.
+	Commit string
+	// Digest is a container image digest.
+	Digest string
+	// ChartVersion is a Helm chart version.
+	ChartVersion string
+}
+
+// Matches returns true if the provided Freight is selected by the Query.
+func (q Query) Matches(freight *kargoapi.Freight) bool {
+	if q.Commit != "" && !slices.ContainsFunc(
+		freight.Commits,
+		func(c kargoapi.GitCommit) bool {
+			return c.ID != "" && strings.HasPrefix(c.ID, strings.ToLower(q.Commit))
+		},
+	) {
+		return false
+	}
+	if q.Digest != "" && !slices.ContainsFunc(
+		freight.Images,
+		func(i kargoapi.Image) bool { return i.Digest == q.Digest },
+	) {
+		return false
+	}
+	if q.ChartVersion != "" && !slices.ContainsFunc(
+		freight.Charts,
+		func(c kargoapi.Chart) bool { return c.Version == q.ChartVersion },
+	) {
+		return false
+	}
+	return true
+}
+
+// Build builds a Graph of all Freight selected by the provided Query, the
+// origins of that Freight, and the Stages to which it has been delivered. The
+// relationships between Freight and Stages are derived from the Freight's
+// status, the Stages' Freight history, and Promotions.
+func Build(
+	query Query,
+	freight []kargoapi.Freight,
+	stages []kargoapi.Stage,
+	promos []kargoapi.Promotion,
+) *Graph {
+	b := &builder{
+		nodes: map[string]*Node{},
+		edges: map[edgeKey]*Edge{},
+	}
+
+	selected := map[string]struct{}{}
+	for i := range freight {
+		f := &freight[i]
+		if !query.Matches(f) {
+			continue
+		}
+		selected[f.Name] = struct{}{}
+		freightID := b.addFreight(f)
+		originID := b.addNode(NodeKind(f.Origin.Kind), f.Origin.Name)
+		b.edge(originID, freightID, EdgeTypeProduced)
+		for stage := range f.Status.CurrentlyIn {
+			b.delivered(f.Name, stage).CurrentlyIn = true
+		}
+		for stage := range f.Status.VerifiedIn {
+			b.delivered(f.Name, stage).VerifiedIn = true
+		}
+		for stage := range f.Status.ApprovedFor {
+			b.delivered(f.Name, stage).ApprovedFor = true
+		}
+	}
+
+	for _, stage := range stages {
+		for _, collection := range stage.Status.FreightHistory {
+			for _, ref := range collection.References() {
+				if _, ok := selected[ref.Name]; ok {
+					b.delivered(ref.Name, stage.Name).InHistory = true
+				}
+			}
+		}
+	}
+
+	for _, promo := range promos {
+		if _, ok := selected[promo.Spec.Freight]; !ok {
+			continue
+		}
+		e := b.delivered(promo.Spec.Freight, promo.Spec.Stage)
+		e.Promotions = append(e.Promotions, Promotion{
+			Name:  promo.Name,
+			Phase: promo.Status.Phase,
+		})
+	}
+
+	// Connect Stages already in the graph to any upstream Stages that are also
+	// in the graph. This shows the path the Freight took through the pipeline.
+	for _, stage := range stages {
+		stageID := nodeID(NodeKindStage, stage.Name)
+		if _, ok := b.nodes[stageID]; !ok {
+			continue
+		}
+		for _, req := range stage.Spec.RequestedFreight {
+			for _, upstream := range req.Sources.Stages {
+				upstreamID := nodeID(NodeKindStage, upstream)
+				if _, ok := b.nodes[upstreamID]; ok {
+					b.edge(upstreamID, stageID, EdgeTypeUpstream)
+				}
+			}
+		}
+	}
+
+	return b.graph()
+}
+
+// edgeKey uniquely identifies an Edge within a Graph.
+type edgeKey struct {
+	from string
+	to   string
+	typ  EdgeType
+}
+
+// builder accumulates the Nodes and Edges of a Graph, de-duplicating them as
+// it goes.
+type builder struct {
+	nodes map[string]*Node
+	edges map[edgeKey]*Edge
+}
+
+func (b *builder) addNode(kind NodeKind, name string) string {
+	id := nodeID(kind, name)
+	if _, ok := b.nodes[id]; !ok {
+		b.nodes[id] = &Node{ID: id, Kind: kind, Name: name}
+	}
+	return id
+}
+
+func (b *builder) addFreight(freight *kargoapi.Freight) string {
+	id := b.addNode(NodeKindFreight, freight.Name)
+	n := b.nodes[id]
+	n.Alias = freight.Alias
+	if n.Alias == "" {
+		n.Alias = freight.Labels[kargoapi.LabelKeyAlias]
+	}
+	n.Artifacts = describeArtifacts(freight)
+	return id
+}
+
+func (b *builder) edge(from, to string, typ EdgeType) *Edge {
+	key := edgeKey{from: from, to: to, typ: typ}
+	e, ok := b.edges[key]
+	if !ok {
+		e = &Edge{From: from, To: to, Type: typ}
+		b.edges[key] = e
+	}
+	return e
+}
+
+// delivered returns the Edge connecting the specified Freight to the specified
+// Stage, adding the Stage to the graph if necessary.
+func (b *builder) delivered(freight, stage string) *Edge {
+	return b.edge(
+		nodeID(NodeKindFreight, freight),
+		b.addNode(NodeKindStage, stage),
+		EdgeTypeDelivered,
+	)
+}
+
+// graph returns the accumulated Nodes and Edges as a Graph. Nodes are ordered
+// by kind, then name. Edges are ordered by type, then the IDs of the Nodes they
+// connect.
+func (b *builder) graph() *Graph {
+	g := &Graph{
+		Nodes: make([]Node, 0, len(b.nodes)),
+		Edges: make([]Edge, 0, len(b.edges)),
+	}
+	for _, n := range b.nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+	slices.SortFunc(g.Nodes, func(a, b Node) int {
+		if c := kindOrder(a.Kind) - kindOrder(b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	for _, e := range b.edges {
+		slices.SortFunc(e.Promotions, func(a, b Promotion) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		g.Edges = append(g.Edges, *e)
+	}
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		if c := strings.Compare(string(a.Type), string(b.Type)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return g
+}
+
+func nodeID(kind NodeKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+// kindOrder returns the relative position of Nodes of the provided kind in the
+// pipeline. Origins of Freight come first, followed by Freight, then Stages.
+func kindOrder(kind NodeKind) int {
+	switch kind {
+	case NodeKindFreight:
+		return 1
+	case NodeKindStage:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// describeArtifacts returns a short, human-readable description of each
+// artifact referenced by the provided Freight.
+func describeArtifacts(freight *kargoapi.Freight) []string {
+	var artifacts []string
+	for _, c := range freight.Commits {
+		desc := c.RepoURL
+		if c.Tag != "" {
+			desc += ":" + c.Tag
+		}
+		if c.ID != "" {
+			desc += "@" + c.ID
+		}
+		artifacts = append(artifacts, desc)
+	}
+	for _, i := range freight.Images {
+		desc := i.RepoURL
+		if i.Tag != "" {
+			desc += ":" + i.Tag
+		}
+		if i.Digest != "" {
+			desc += "@" + i.Digest
+		}
+		artifacts = append(artifacts, desc)
+	}
+	for _, c := range freight.Charts {
+		desc := c.RepoURL
+		if c.Name != "" {
+			desc = strings.TrimSuffix(desc, "/") + "/" + c.Name
+		}
+		artifacts = append(artifacts, desc+":"+c.Version)
+	}
+	for _, a := range freight.Artifacts {
+		artifacts = append(artifacts, a.SubscriptionName+":"+a.Version)
+	}
+	return artifacts
+}
@@ -0,0 +1,158 @@
+package lineage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestQuery_Matches(t *testing.T) {
+	freight := &kargoapi.Freight{
+		Commits: []kargoapi.GitCommit{{RepoURL: "https://github.com/example/repo", ID: "abc1234def"}},
+		Images:  []kargoapi.Image{{RepoURL: "example/image", Tag: "v1.0.0", Digest: "sha256:123"}},
+		Charts:  []kargoapi.Chart{{RepoURL: "oci://example/chart", Version: "1.2.3"}},
+	}
+	testCases := []struct {
+		name    string
+		query   Query
+		matches bool
+	}{
+		{name: "empty query", matches: true},
+		{name: "commit prefix", query: Query{Commit: "ABC1234"}, matches: true},
+		{name: "commit mismatch", query: Query{Commit: "def"}},
+		{name: "digest", query: Query{Digest: "sha256:123"}, matches: true},
+		{name: "digest mismatch", query: Query{Digest: "sha256:456"}},
+		{name: "chart version", query: Query{ChartVersion: "1.2.3"}, matches: true},
+		{name: "chart version mismatch", query: Query{ChartVersion: "1.2.4"}},
+		{
+			name:    "all fields must match",
+			query:   Query{Commit: "abc", Digest: "sha256:456"},
+			matches: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.matches, testCase.query.Matches(freight))
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	origin := kargoapi.FreightOrigin{Kind: kargoapi.FreightOriginKindWarehouse, Name: "wh"}
+	freight := []kargoapi.Freight{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "f1"},
+			Alias:      "wonky-wombat",
+			Origin:     origin,
+			Commits:    []kargoapi.GitCommit{{RepoURL: "https://github.com/example/repo", ID: "abc"}},
+			Status: kargoapi.FreightStatus{
+				CurrentlyIn: map[string]kargoapi.CurrentStage{"prod": {}},
+				VerifiedIn:  map[string]kargoapi.VerifiedStage{"test": {}, "prod": {}},
+				ApprovedFor: map[string]kargoapi.ApprovedStage{"prod": {}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "f2"},
+			Origin:     origin,
+			Commits:    []kargoapi.GitCommit{{RepoURL: "https://github.com/example/repo", ID: "def"}},
+			Status: kargoapi.FreightStatus{
+				CurrentlyIn: map[string]kargoapi.CurrentStage{"test": {}},
+			},
+		},
+	}
+	history := kargoapi.FreightCollection{}
+	history.UpdateOrPush(kargoapi.FreightReference{Name: "f1", Origin: origin})
+	stages := []kargoapi.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: kargoapi.StageSpec{
+				RequestedFreight: []kargoapi.FreightRequest{{
+					Origin:  origin,
+					Sources: kargoapi.FreightSources{Direct: true},
+				}},
+			},
+			Status: kargoapi.StageStatus{
+				FreightHistory: kargoapi.FreightHistory{&history},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: kargoapi.StageSpec{
+				RequestedFreight: []kargoapi.FreightRequest{{
+					Origin:  origin,
+					Sources: kargoapi.FreightSources{Stages: []string{"test"}},
+				}},
+			},
+		},
+	}
+	promos := []kargoapi.Promotion{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod.2"},
+			Spec:       kargoapi.PromotionSpec{Stage: "prod", Freight: "f1"},
+			Status:     kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseSucceeded},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod.1"},
+			Spec:       kargoapi.PromotionSpec{Stage: "prod", Freight: "f1"},
+			Status:     kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseFailed},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test.1"},
+			Spec:       kargoapi.PromotionSpec{Stage: "test", Freight: "f2"},
+		},
+	}
+
+	t.Run("query by commit", func(t *testing.T) {
+		g := Build(Query{Commit: "abc"}, freight, stages, promos)
+		require.Equal(t, []Node{
+			{ID: "Warehouse/wh", Kind: NodeKindWarehouse, Name: "wh"},
+			{
+				ID:        "Freight/f1",
+				Kind:      NodeKindFreight,
+				Name:      "f1",
+				Alias:     "wonky-wombat",
+				Artifacts: []string{"https://github.com/example/repo@abc"},
+			},
+			{ID: "Stage/prod", Kind: NodeKindStage, Name: "prod"},
+			{ID: "Stage/test", Kind: NodeKindStage, Name: "test"},
+		}, g.Nodes)
+		require.Equal(t, []Edge{
+			{
+				From:        "Freight/f1",
+				To:          "Stage/prod",
+				Type:        EdgeTypeDelivered,
+				CurrentlyIn: true,
+				VerifiedIn:  true,
+				ApprovedFor: true,
+				Promotions: []Promotion{
+					{Name: "prod.1", Phase: kargoapi.PromotionPhaseFailed},
+					{Name: "prod.2", Phase: kargoapi.PromotionPhaseSucceeded},
+				},
+			},
+			{
+				From:       "Freight/f1",
+				To:         "Stage/test",
+				Type:       EdgeTypeDelivered,
+				VerifiedIn: true,
+				InHistory:  true,
+			},
+			{From: "Warehouse/wh", To: "Freight/f1", Type: EdgeTypeProduced},
+			{From: "Stage/test", To: "Stage/prod", Type: EdgeTypeUpstream},
+		}, g.Edges)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		g := Build(Query{Digest: "sha256:nope"}, freight, stages, promos)
+		require.Empty(t, g.Nodes)
+		require.Empty(t, g.Edges)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		g := Build(Query{}, freight, stages, promos)
+		require.Len(t, g.Nodes, 5)
+		require.Len(t, g.Edges, 6)
+	})
+}
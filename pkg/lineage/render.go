@@ -0,0 +1,182 @@
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// Format is a format in which a Graph can be rendered.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+)
+
+// Formats returns all formats in which a Graph can be rendered.
+func Formats() []Format {
+	return []Format{FormatJSON, FormatDOT, FormatMermaid}
+}
+
+// Write renders the provided Graph to the provided io.Writer in the specified
+// Format.
+func Write(w io.Writer, g *Graph, format Format) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case FormatDOT:
+		return WriteDOT(w, g)
+	case FormatMermaid:
+		return WriteMermaid(w, g)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// WriteDOT renders the provided Graph to the provided io.Writer in the
+// Graphviz DOT language.
+func WriteDOT(w io.Writer, g *Graph) error {
+	var sb strings.Builder
+	sb.WriteString("digraph lineage {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(
+			&sb, "  %s [label=%s, shape=%s];\n",
+			dotQuote(n.ID), dotQuote(nodeLabel(n)), dotShape(n.Kind),
+		)
+	}
+	for _, e := range g.Edges {
+		attrs := []string{}
+		if label := edgeLabel(e); label != "" {
+			attrs = append(attrs, "label="+dotQuote(label))
+		}
+		switch {
+		case e.Type == EdgeTypeUpstream:
+			attrs = append(attrs, "style=dotted")
+		case e.Type == EdgeTypeDelivered && !e.CurrentlyIn:
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&sb, "  %s -> %s", dotQuote(e.From), dotQuote(e.To))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&sb, " [%s]", strings.Join(attrs, ", "))
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid renders the provided Graph to the provided io.Writer as a
+// Mermaid flowchart.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	// Mermaid is particular about which characters may appear in node IDs, so
+	// each Node is assigned a simple, positional ID instead.
+	ids := make(map[string]string, len(g.Nodes))
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[n.ID] = id
+		label := mermaidQuote(nodeLabel(n))
+		switch n.Kind {
+		case NodeKindWarehouse:
+			fmt.Fprintf(&sb, "  %s[(%s)]\n", id, label)
+		case NodeKindStage:
+			fmt.Fprintf(&sb, "  %s([%s])\n", id, label)
+		default:
+			fmt.Fprintf(&sb, "  %s[%s]\n", id, label)
+		}
+	}
+	for _, e := range g.Edges {
+		from, to := ids[e.From], ids[e.To]
+		if from == "" || to == "" {
+			continue
+		}
+		arrow := "-->"
+		if e.Type == EdgeTypeUpstream || (e.Type == EdgeTypeDelivered && !e.CurrentlyIn) {
+			arrow = "-.->"
+		}
+		if label := edgeLabel(e); label != "" {
+			fmt.Fprintf(&sb, "  %s %s|%s| %s\n", from, arrow, mermaidQuote(label), to)
+		} else {
+			fmt.Fprintf(&sb, "  %s %s %s\n", from, arrow, to)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// nodeLabel returns a multi-line label for the provided Node.
+func nodeLabel(n Node) string {
+	lines := []string{fmt.Sprintf("%s: %s", n.Kind, n.Name)}
+	if n.Alias != "" {
+		lines[0] = fmt.Sprintf("%s: %s (%s)", n.Kind, n.Alias, n.Name)
+	}
+	lines = append(lines, n.Artifacts...)
+	return strings.Join(lines, "\n")
+}
+
+// edgeLabel returns a short label summarizing the relationship represented by
+// the provided Edge.
+func edgeLabel(e Edge) string {
+	if e.Type != EdgeTypeDelivered {
+		return ""
+	}
+	var parts []string
+	if e.CurrentlyIn {
+		parts = append(parts, "current")
+	} else if e.InHistory {
+		parts = append(parts, "previous")
+	}
+	if e.VerifiedIn {
+		parts = append(parts, "verified")
+	}
+	if e.ApprovedFor {
+		parts = append(parts, "approved")
+	}
+	if len(e.Promotions) > 0 {
+		phase := e.Promotions[len(e.Promotions)-1].Phase
+		if phase == "" {
+			phase = kargoapi.PromotionPhasePending
+		}
+		parts = append(parts, fmt.Sprintf("promotion %s", strings.ToLower(string(phase))))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dotShape(kind NodeKind) string {
+	switch kind {
+	case NodeKindWarehouse:
+		return "cylinder"
+	case NodeKindStage:
+		return "ellipse"
+	default:
+		return "box"
+	}
+}
+
+// dotQuote returns the provided string as a double-quoted DOT ID.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+	).Replace(s) + `"`
+}
+
+// mermaidQuote returns the provided string as a double-quoted Mermaid label.
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(
+		`"`, "#quot;",
+		"\n", "<br/>",
+	).Replace(s) + `"`
+}
@@ -0,0 +1,84 @@
+package lineage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+var testGraph = &Graph{
+	Nodes: []Node{
+		{ID: "Warehouse/wh", Kind: NodeKindWarehouse, Name: "wh"},
+		{
+			ID:        "Freight/f1",
+			Kind:      NodeKindFreight,
+			Name:      "f1",
+			Alias:     "wonky-wombat",
+			Artifacts: []string{`example/"image":v1`},
+		},
+		{ID: "Stage/test", Kind: NodeKindStage, Name: "test"},
+		{ID: "Stage/prod", Kind: NodeKindStage, Name: "prod"},
+	},
+	Edges: []Edge{
+		{
+			From:        "Freight/f1",
+			To:          "Stage/prod",
+			Type:        EdgeTypeDelivered,
+			CurrentlyIn: true,
+			Promotions:  []Promotion{{Name: "prod.1", Phase: kargoapi.PromotionPhaseSucceeded}},
+		},
+		{From: "Freight/f1", To: "Stage/test", Type: EdgeTypeDelivered, InHistory: true, VerifiedIn: true},
+		{From: "Warehouse/wh", To: "Freight/f1", Type: EdgeTypeProduced},
+		{From: "Stage/test", To: "Stage/prod", Type: EdgeTypeUpstream},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, Write(buf, testGraph, FormatJSON))
+		g := &Graph{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), g))
+		require.Equal(t, testGraph, g)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		require.ErrorContains(t, Write(&bytes.Buffer{}, testGraph, "svg"), "unsupported format")
+	})
+}
+
+func TestWriteDOT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteDOT(buf, testGraph))
+	require.Equal(t, `digraph lineage {
+  rankdir=LR;
+  "Warehouse/wh" [label="Warehouse: wh", shape=cylinder];
+  "Freight/f1" [label="Freight: wonky-wombat (f1)\nexample/\"image\":v1", shape=box];
+  "Stage/test" [label="Stage: test", shape=ellipse];
+  "Stage/prod" [label="Stage: prod", shape=ellipse];
+  "Freight/f1" -> "Stage/prod" [label="current, promotion succeeded"];
+  "Freight/f1" -> "Stage/test" [label="previous, verified", style=dashed];
+  "Warehouse/wh" -> "Freight/f1";
+  "Stage/test" -> "Stage/prod" [style=dotted];
+}
+`, buf.String())
+}
+
+func TestWriteMermaid(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteMermaid(buf, testGraph))
+	require.Equal(t, `flowchart LR
+  n0[("Warehouse: wh")]
+  n1["Freight: wonky-wombat (f1)<br/>example/#quot;image#quot;:v1"]
+  n2(["Stage: test"])
+  n3(["Stage: prod"])
+  n1 -->|"current, promotion succeeded"| n3
+  n1 -.->|"previous, verified"| n2
+  n0 --> n1
+  n2 -.-> n3
+`, buf.String())
+}
@@ -0,0 +1,517 @@
+package promotion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	"github.com/akuity/kargo/pkg/controller/git"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+// MaxDryRunDiffBytes is the maximum size of the diff that will be reported for
+// any one working tree by a dry run.
+const MaxDryRunDiffBytes = 1 << 20
+
+// DryRunResult is the outcome of a dry run of a user-defined promotion process.
+type DryRunResult struct {
+	// Status is the phase the Promotion would have ended in.
+	Status kargoapi.PromotionPhase `json:"status"`
+	// Message is an optional message that provides additional context about
+	// the outcome of the dry run.
+	Message string `json:"message,omitempty"`
+	// Steps describes what happened to each step of the promotion process.
+	Steps []DryRunStep `json:"steps"`
+	// WorkTrees describes the changes the promotion process made to each Git
+	// working tree it checked out.
+	WorkTrees []DryRunWorkTree `json:"workTrees,omitempty"`
+} // @name PromotionDryRunResult
+
+// DryRunStep describes what happened to a single step of a user-defined
+// promotion process during a dry run.
+type DryRunStep struct {
+	// Alias is the alias of the step.
+	Alias string `json:"alias"`
+	// Kind is the kind of the step.
+	Kind string `json:"kind"`
+	// Status is the status the step finished with. It is empty if the step was
+	// never reached.
+	Status kargoapi.PromotionStepStatus `json:"status,omitempty"`
+	// Message is an optional message that provides additional context about
+	// the status of the step.
+	Message string `json:"message,omitempty"`
+	// Stubbed indicates that the step has side effects and was therefore not
+	// actually executed.
+	Stubbed bool `json:"stubbed,omitempty"`
+	// Config is the configuration of the step after all expressions in it were
+	// evaluated. It is empty if the step was skipped or never reached.
+	Config map[string]any `json:"config,omitempty" swaggertype:"object"`
+	// Output is the output of the step.
+	Output map[string]any `json:"output,omitempty" swaggertype:"object"`
+	// Log is any output the step wrote to its log.
+	Log string `json:"log,omitempty"`
+} // @name PromotionDryRunStep
+
+// DryRunWorkTree describes the changes made to a Git working tree during a
+// dry run.
+type DryRunWorkTree struct {
+	// Path is the path of the working tree, relative to the working directory
+	// of the promotion process.
+	Path string `json:"path"`
+	// RepoURL is the URL of the repository the working tree was checked out
+	// from.
+	RepoURL string `json:"repoURL,omitempty"`
+	// Diff is the unified diff of all changes made to the working tree, whether
+	// committed or not.
+	Diff string `json:"diff,omitempty"`
+	// Truncated indicates that Diff exceeded MaxDryRunDiffBytes and was
+	// truncated.
+	Truncated bool `json:"truncated,omitempty"`
+	// Error is set if the changes to the working tree could not be determined.
+	Error string `json:"error,omitempty"`
+} // @name PromotionDryRunWorkTree
+
+// DryRunEngine executes user-defined promotion processes without changing any
+// state outside of a working directory. Unless explicitly allowed, steps whose
+// runners are registered as having side effects are not executed, but are
+// reported as having succeeded. Steps that cannot be evaluated because they
+// reference the output of such a step are reported as skipped.
+type DryRunEngine struct {
+	registry        StepRunnerRegistry
+	kargoClient     client.Client
+	credsDB         credentials.Database
+	gitUserResolver GitUserResolver
+	cacheFunc       ExprDataCacheFn
+}
+
+// DryRunOptions represents options for a dry run.
+type DryRunOptions struct {
+	// WorkDir is the working directory in which to execute steps. If empty, a
+	// temporary directory is used and removed when the dry run is complete.
+	// Otherwise, the directory is created if it does not exist and is left in
+	// place.
+	WorkDir string
+	// AllowSideEffects permits steps of any kind to be executed, even if their
+	// runners are registered as having side effects.
+	AllowSideEffects bool
+	// AllowedStepKinds permits steps of the specified kinds to be executed,
+	// even if their runners are registered as having side effects.
+	AllowedStepKinds []string
+}
+
+// NewDryRunEngine returns a new DryRunEngine that uses built-in StepRunners.
+// Unless side effects are allowed, the provided Kubernetes client is used only
+// for reads; any attempt by a step to write through it fails.
+func NewDryRunEngine(
+	kargoClient client.Client,
+	credsDB credentials.Database,
+	gitUserResolver GitUserResolver,
+	cacheFunc ExprDataCacheFn,
+) *DryRunEngine {
+	return &DryRunEngine{
+		registry:        DefaultStepRunnerRegistry,
+		kargoClient:     kargoClient,
+		credsDB:         credsDB,
+		gitUserResolver: gitUserResolver,
+		cacheFunc:       cacheFunc,
+	}
+}
+
+// DryRun executes the provided steps in the provided Context and reports what
+// each step did.
+func (e *DryRunEngine) DryRun(
+	ctx context.Context,
+	promoCtx Context,
+	steps []Step,
+	opts *DryRunOptions,
+) (*DryRunResult, error) {
+	if opts == nil {
+		opts = &DryRunOptions{}
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		var err error
+		if workDir, err = os.MkdirTemp("", "dry-run-"); err != nil {
+			return nil, fmt.Errorf("temporary working directory creation failed: %w", err)
+		}
+		defer os.RemoveAll(workDir)
+	} else {
+		var err error
+		if workDir, err = filepath.Abs(workDir); err != nil {
+			return nil, fmt.Errorf("error resolving working directory: %w", err)
+		}
+		if err = os.MkdirAll(workDir, 0o700); err != nil {
+			return nil, fmt.Errorf("error creating working directory: %w", err)
+		}
+	}
+
+	promoCtx.WorkDir = workDir
+	promoCtx.DryRun = !opts.AllowSideEffects
+
+	roClient := &readOnlyClient{Client: e.kargoClient}
+	executor := &sandboxStepExecutor{
+		registry: e.registry,
+		executor: NewLocalStepExecutor(
+			e.registry,
+			roClient,
+			nil,
+			e.credsDB,
+			e.gitUserResolver,
+		),
+		sideEffectsExecutor: NewLocalStepExecutor(
+			e.registry,
+			e.kargoClient,
+			nil,
+			e.credsDB,
+			e.gitUserResolver,
+		),
+		allowSideEffects: opts.AllowSideEffects,
+		allowedKinds:     opts.AllowedStepKinds,
+		workDir:          workDir,
+		configs:          map[string]Config{},
+		stubbed:          map[string]bool{},
+		workTrees:        map[string]string{},
+	}
+	orchestrator := &LocalOrchestrator{
+		executor:             executor,
+		registry:             e.registry,
+		client:               roClient,
+		cacheFunc:            e.cacheFunc,
+		unexecutedDependency: executor.unexecutedDependency,
+	}
+
+	res, err := orchestrator.ExecuteSteps(ctx, promoCtx, steps)
+	result := &DryRunResult{
+		Status:  res.Status,
+		Message: res.Message,
+		Steps:   make([]DryRunStep, len(steps)),
+	}
+	if err != nil {
+		// Outside a dry run, the step would be retried. There is no point in
+		// doing that here.
+		result.Status = kargoapi.PromotionPhaseErrored
+		result.Message = err.Error()
+	}
+
+	for i, step := range steps {
+		s := DryRunStep{
+			Alias:   step.Alias,
+			Kind:    step.Kind,
+			Stubbed: executor.stubbed[step.Alias],
+			Config:  executor.configs[step.Alias],
+		}
+		for _, meta := range res.StepExecutionMetadata {
+			if meta.Alias == step.Alias {
+				s.Status = meta.Status
+				s.Message = meta.Message
+				break
+			}
+		}
+		if output, ok := res.State[step.Alias].(map[string]any); ok {
+			s.Output = output
+		}
+		for _, chunk := range res.StepLogs {
+			if chunk.Alias == step.Alias {
+				s.Log += string(chunk.Data)
+			}
+		}
+		result.Steps[i] = s
+	}
+
+	result.WorkTrees = executor.diffWorkTrees(ctx)
+	return result, nil
+}
+
+// sandboxStepExecutor is a StepExecutor that wraps other StepExecutors. It
+// does not pass on the execution of steps whose runners are registered as
+// having side effects unless they have been explicitly allowed, and records
+// information about all the steps it is asked to execute.
+type sandboxStepExecutor struct {
+	registry StepRunnerRegistry
+	// executor executes steps without side effects.
+	executor StepExecutor
+	// sideEffectsExecutor executes steps of kinds that have been allowed to
+	// have side effects.
+	sideEffectsExecutor StepExecutor
+	allowSideEffects    bool
+	allowedKinds        []string
+	workDir             string
+	// configs holds the rendered configuration of each step, keyed by alias.
+	configs map[string]Config
+	// stubbed holds the aliases of the steps that were not executed.
+	stubbed map[string]bool
+	// workTrees maps the path of each working tree found in workDir to the ID
+	// of the commit that was checked out when it was first found.
+	workTrees map[string]string
+}
+
+// ExecuteStep implements StepExecutor.
+func (e *sandboxStepExecutor) ExecuteStep(
+	ctx context.Context,
+	req StepExecutionRequest,
+) (StepResult, error) {
+	// Config.DeepCopy only copes with values as decoded from JSON, while
+	// expressions may evaluate to any type, so the rendered configuration is
+	// copied by round-tripping it through JSON instead.
+	var cfg Config
+	if err := json.Unmarshal(req.Context.Config.ToJSON(), &cfg); err == nil {
+		e.configs[req.Step.Alias] = cfg
+	}
+	executor := e.executor
+	if reg, err := e.registry.Get(req.Step.Kind); err == nil {
+		allowed := e.allowSideEffects || slices.Contains(e.allowedKinds, req.Step.Kind)
+		if reg.Metadata.SideEffects && !allowed {
+			e.stubbed[req.Step.Alias] = true
+			return StepResult{
+				Status: kargoapi.PromotionStepStatusSucceeded,
+				Message: fmt.Sprintf(
+					"step kind %q has side effects and was not executed", req.Step.Kind,
+				),
+			}, nil
+		}
+		if allowed {
+			executor = e.sideEffectsExecutor
+			req.Context.DryRun = false
+		}
+		if slices.Contains(reg.Metadata.RequiredCapabilities, StepCapabilityAccessArgoCD) {
+			return StepResult{
+				Status: kargoapi.PromotionStepStatusErrored,
+			}, &TerminalError{Err: fmt.Errorf(
+				"step kind %q requires access to Argo CD, which is not available", req.Step.Kind,
+			)}
+		}
+	}
+	result, err := executor.ExecuteStep(ctx, req)
+	e.discoverWorkTrees(ctx)
+	return result, err
+}
+
+// unexecutedDependency returns the alias of a step that was not executed and
+// whose output is referenced by the "if" condition, variables, or
+// configuration of the provided step.
+func (e *sandboxStepExecutor) unexecutedDependency(step Step) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString(step.If)
+	for _, v := range step.Vars {
+		sb.WriteString(v.Value)
+	}
+	sb.Write(step.Config)
+	refs := sb.String()
+
+	aliases := make([]string, 0, len(e.stubbed))
+	for alias := range e.stubbed {
+		aliases = append(aliases, alias)
+	}
+	slices.Sort(aliases)
+
+	for _, alias := range aliases {
+		if outputRefRegex(`(^|[^.\w])outputs`, alias).MatchString(refs) {
+			return alias, true
+		}
+		// Steps inflated from the same task may refer to each other's output
+		// by their alias within the task.
+		if ns := getAliasNamespace(alias); ns != "" && ns == getAliasNamespace(step.Alias) {
+			name := strings.TrimPrefix(alias, ns+api.PromotionAliasSeparator)
+			if outputRefRegex(`\btask\s*\.\s*outputs`, name).MatchString(refs) {
+				return alias, true
+			}
+		}
+	}
+	return "", false
+}
+
+// outputRefRegex returns a regular expression matching a reference to the
+// output of the step with the provided alias in the provided map of outputs,
+// using either dot or bracket notation. Quotes may be escaped, as they are in
+// JSON configuration.
+func outputRefRegex(outputs, alias string) *regexp.Regexp {
+	name := regexp.QuoteMeta(alias)
+	return regexp.MustCompile(
+		outputs + `\s*(\.\s*` + name + `\b|\[\s*\\?['"]` + name + `\\?['"]\s*\])`,
+	)
+}
+
+// discoverWorkTrees finds Git working trees in the working directory that
+// have not been seen before and records the commit each has checked out, so
+// that any changes made by subsequent steps can be diffed against it.
+func (e *sandboxStepExecutor) discoverWorkTrees(ctx context.Context) {
+	_ = filepath.WalkDir(e.workDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // nolint: nilerr
+		}
+		if _, seen := e.workTrees[path]; seen || d.Name() == ".git" || isBareRepo(path) {
+			return filepath.SkipDir
+		}
+		if _, err = os.Stat(filepath.Join(path, ".git")); err != nil {
+			return nil // nolint: nilerr
+		}
+		workTree, err := git.LoadWorkTree(ctx, path, nil)
+		if err != nil {
+			// Not a working tree checked out by a step.
+			return nil // nolint: nilerr
+		}
+		// A working tree on an orphaned branch has no commits yet, in which
+		// case the base is left empty and the tree is diffed against nothing.
+		e.workTrees[path], _ = workTree.LastCommitID(ctx)
+		return filepath.SkipDir
+	})
+}
+
+// diffWorkTrees returns the changes made to each working tree found in the
+// working directory, ordered by path.
+func (e *sandboxStepExecutor) diffWorkTrees(ctx context.Context) []DryRunWorkTree {
+	paths := make([]string, 0, len(e.workTrees))
+	for path := range e.workTrees {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	diffs := make([]DryRunWorkTree, 0, len(paths))
+	for _, path := range paths {
+		d := DryRunWorkTree{Path: path}
+		if rel, err := filepath.Rel(e.workDir, path); err == nil {
+			d.Path = rel
+		}
+		workTree, err := git.LoadWorkTree(ctx, path, nil)
+		if err != nil {
+			d.Error = err.Error()
+			diffs = append(diffs, d)
+			continue
+		}
+		d.RepoURL = workTree.URL()
+		diff, err := workTree.DiffFrom(ctx, e.workTrees[path])
+		if err != nil {
+			d.Error = err.Error()
+		}
+		if len(diff) > MaxDryRunDiffBytes {
+			diff = diff[:MaxDryRunDiffBytes]
+			d.Truncated = true
+		}
+		d.Diff = diff
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// isBareRepo returns true if the provided directory looks like a bare Git
+// repository.
+func isBareRepo(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil {
+		return false
+	}
+	fi, err := os.Stat(filepath.Join(dir, "objects"))
+	return err == nil && fi.IsDir()
+}
+
+// errDryRunWrite is returned by a readOnlyClient for every attempted write.
+var errDryRunWrite = errors.New("writes to the Kubernetes API are not permitted during a dry run")
+
+// readOnlyClient wraps a client.Client and refuses all writes.
+type readOnlyClient struct {
+	client.Client
+}
+
+func (c *readOnlyClient) Apply(
+	context.Context,
+	runtime.ApplyConfiguration,
+	...client.ApplyOption,
+) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) Patch(
+	context.Context,
+	client.Object,
+	client.Patch,
+	...client.PatchOption,
+) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) DeleteAllOf(
+	context.Context,
+	client.Object,
+	...client.DeleteAllOfOption,
+) error {
+	return errDryRunWrite
+}
+
+func (c *readOnlyClient) Status() client.SubResourceWriter {
+	return readOnlySubResourceWriter{}
+}
+
+func (c *readOnlyClient) SubResource(subResource string) client.SubResourceClient {
+	return readOnlySubResourceClient{
+		SubResourceReader: c.Client.SubResource(subResource),
+	}
+}
+
+// readOnlySubResourceWriter is a client.SubResourceWriter that refuses all
+// writes.
+type readOnlySubResourceWriter struct{}
+
+func (readOnlySubResourceWriter) Create(
+	context.Context,
+	client.Object,
+	client.Object,
+	...client.SubResourceCreateOption,
+) error {
+	return errDryRunWrite
+}
+
+func (readOnlySubResourceWriter) Update(
+	context.Context,
+	client.Object,
+	...client.SubResourceUpdateOption,
+) error {
+	return errDryRunWrite
+}
+
+func (readOnlySubResourceWriter) Patch(
+	context.Context,
+	client.Object,
+	client.Patch,
+	...client.SubResourcePatchOption,
+) error {
+	return errDryRunWrite
+}
+
+func (readOnlySubResourceWriter) Apply(
+	context.Context,
+	runtime.ApplyConfiguration,
+	...client.SubResourceApplyOption,
+) error {
+	return errDryRunWrite
+}
+
+// readOnlySubResourceClient is a client.SubResourceClient that permits reads
+// but refuses all writes.
+type readOnlySubResourceClient struct {
+	client.SubResourceReader
+	readOnlySubResourceWriter
+}
@@ -0,0 +1,80 @@
+package promotion
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// WriteDryRunResult renders the provided DryRunResult to the provided
+// io.Writer in a human-readable format.
+func WriteDryRunResult(w io.Writer, res *DryRunResult) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printIndented := func(indent string, text string) {
+		for line := range strings.SplitSeq(strings.TrimRight(text, "\n"), "\n") {
+			printf("%s%s\n", indent, line)
+		}
+	}
+
+	printf("Result: %s\n", res.Status)
+	if res.Message != "" {
+		printIndented("  ", res.Message)
+	}
+
+	if len(res.Steps) > 0 {
+		printf("\nSteps:\n")
+		for i, step := range res.Steps {
+			status := string(step.Status)
+			switch {
+			case status == "":
+				status = "NotReached"
+			case step.Stubbed:
+				status += " (not executed; has side effects)"
+			}
+			printf("  %d. %s (%s): %s\n", i+1, step.Alias, step.Kind, status)
+			if step.Message != "" && !step.Stubbed {
+				printIndented("       ", step.Message)
+			}
+			if len(step.Config) > 0 {
+				cfg, yamlErr := yaml.Marshal(step.Config)
+				if yamlErr != nil {
+					return fmt.Errorf("error marshaling config of step %q: %w", step.Alias, yamlErr)
+				}
+				printf("     Config:\n")
+				printIndented("       ", string(cfg))
+			}
+			if step.Log != "" {
+				printf("     Log:\n")
+				printIndented("       ", step.Log)
+			}
+		}
+	}
+
+	for _, wt := range res.WorkTrees {
+		printf("\nChanges to %s", wt.Path)
+		if wt.RepoURL != "" {
+			printf(" (%s)", wt.RepoURL)
+		}
+		printf(":\n")
+		switch {
+		case wt.Error != "":
+			printIndented("  ! ", wt.Error)
+		case wt.Diff == "":
+			printf("  (none)\n")
+		default:
+			printIndented("", wt.Diff)
+			if wt.Truncated {
+				printf("... (diff truncated)\n")
+			}
+		}
+	}
+
+	return err
+}
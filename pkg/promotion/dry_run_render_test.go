@@ -0,0 +1,84 @@
+package promotion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestWriteDryRunResult(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteDryRunResult(buf, &DryRunResult{
+		Status: kargoapi.PromotionPhaseErrored,
+		Message: "step \"update\" met error threshold of 1: " +
+			"something went wrong",
+		Steps: []DryRunStep{
+			{
+				Alias:  "clone",
+				Kind:   "git-clone",
+				Status: kargoapi.PromotionStepStatusSucceeded,
+				Config: map[string]any{"repoURL": "https://github.com/example/repo"},
+				Log:    "cloned\n",
+			},
+			{
+				Alias:  "skipped",
+				Kind:   "yaml-update",
+				Status: kargoapi.PromotionStepStatusSkipped,
+			},
+			{
+				Alias:   "push",
+				Kind:    "git-push",
+				Status:  kargoapi.PromotionStepStatusSucceeded,
+				Message: "step kind \"git-push\" has side effects and was not executed",
+				Stubbed: true,
+				Config:  map[string]any{"path": "./out"},
+			},
+			{
+				Alias:   "update",
+				Kind:    "argocd-update",
+				Status:  kargoapi.PromotionStepStatusErrored,
+				Message: "something went wrong",
+			},
+			{Alias: "never", Kind: "http"},
+		},
+		WorkTrees: []DryRunWorkTree{
+			{
+				Path:    "out",
+				RepoURL: "https://github.com/example/repo",
+				Diff:    "--- a/file.txt\n+++ b/file.txt\n",
+			},
+			{Path: "other"},
+			{Path: "broken", Error: "error loading working tree"},
+		},
+	}))
+	require.Equal(t, `Result: Errored
+  step "update" met error threshold of 1: something went wrong
+
+Steps:
+  1. clone (git-clone): Succeeded
+     Config:
+       repoURL: https://github.com/example/repo
+     Log:
+       cloned
+  2. skipped (yaml-update): Skipped
+  3. push (git-push): Succeeded (not executed; has side effects)
+     Config:
+       path: ./out
+  4. update (argocd-update): Errored
+       something went wrong
+  5. never (http): NotReached
+
+Changes to out (https://github.com/example/repo):
+--- a/file.txt
++++ b/file.txt
+
+Changes to other:
+  (none)
+
+Changes to broken:
+  ! error loading working tree
+`, buf.String())
+}
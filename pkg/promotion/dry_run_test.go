@@ -0,0 +1,335 @@
+package promotion
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/git"
+)
+
+func TestDryRunEngine_DryRun(t *testing.T) {
+	// Set up a local repository for a step to check out
+	repoDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--initial-branch=main"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"commit", "--allow-empty", "--message", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	registry := MustNewStepRunnerRegistry(
+		StepRunnerRegistration{
+			Name: "fake-clone",
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(ctx context.Context, stepCtx *StepContext) (StepResult, error) {
+						repo, err := git.CloneBare(
+							ctx,
+							"file://"+repoDir,
+							nil,
+							&git.BareCloneOptions{BaseDir: stepCtx.WorkDir},
+						)
+						if err != nil {
+							return StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
+						}
+						if _, err = repo.AddWorkTree(
+							ctx,
+							filepath.Join(stepCtx.WorkDir, "out"),
+							&git.AddWorkTreeOptions{Ref: "main"},
+						); err != nil {
+							return StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
+						}
+						return StepResult{Status: kargoapi.PromotionStepStatusSucceeded}, nil
+					},
+				}
+			},
+		},
+		StepRunnerRegistration{
+			Name: "fake-write",
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(_ context.Context, stepCtx *StepContext) (StepResult, error) {
+						content, _ := stepCtx.Config["content"].(string)
+						if err := os.WriteFile(
+							filepath.Join(stepCtx.WorkDir, "out", "file.txt"),
+							[]byte(content+"\n"),
+							0o600,
+						); err != nil {
+							return StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
+						}
+						stepCtx.Log.Write([]byte("wrote file.txt\n")) // nolint: errcheck
+						return StepResult{
+							Status: kargoapi.PromotionStepStatusSucceeded,
+							Output: map[string]any{"written": content},
+						}, nil
+					},
+				}
+			},
+		},
+		StepRunnerRegistration{
+			Name: "fake-create",
+			Metadata: StepRunnerMetadata{
+				RequiredCapabilities: []StepRunnerCapability{
+					StepCapabilityAccessControlPlane,
+				},
+			},
+			Value: func(caps StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(ctx context.Context, _ *StepContext) (StepResult, error) {
+						if err := caps.KargoClient.Create(ctx, &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "fake-project",
+								Name:      "fake-config-map",
+							},
+						}); err != nil {
+							return StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
+						}
+						return StepResult{Status: kargoapi.PromotionStepStatusSucceeded}, nil
+					},
+				}
+			},
+		},
+		StepRunnerRegistration{
+			Name:     "fake-push",
+			Metadata: StepRunnerMetadata{SideEffects: true},
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(context.Context, *StepContext) (StepResult, error) {
+						t.Error("step with side effects should not have been executed")
+						return StepResult{Status: kargoapi.PromotionStepStatusErrored}, nil
+					},
+				}
+			},
+		},
+	)
+
+	mustConfig := func(cfg map[string]any) []byte {
+		data, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		return data
+	}
+
+	engine := &DryRunEngine{
+		registry:    registry,
+		kargoClient: fake.NewClientBuilder().Build(),
+	}
+	res, err := engine.DryRun(
+		t.Context(),
+		Context{
+			Project: "fake-project",
+			Stage:   "fake-stage",
+			Vars: []kargoapi.ExpressionVariable{{
+				Name:  "greeting",
+				Value: "hello",
+			}},
+		},
+		[]Step{
+			{Kind: "fake-clone", Alias: "clone"},
+			{
+				Kind:   "fake-write",
+				Alias:  "write",
+				Config: mustConfig(map[string]any{"content": "${{ vars.greeting }}"}),
+			},
+			{Kind: "fake-write", Alias: "skipped", If: "${{ false }}"},
+			{Kind: "fake-create", Alias: "create", ContinueOnError: true},
+			{
+				Kind:  "fake-push",
+				Alias: "push",
+				Config: mustConfig(map[string]any{
+					"written": "${{ outputs.write.written }}",
+					"count":   "${{ 1 + 1 }}",
+				}),
+			},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, kargoapi.PromotionPhaseSucceeded, res.Status)
+	require.Len(t, res.Steps, 5)
+
+	clone := res.Steps[0]
+	require.Equal(t, kargoapi.PromotionStepStatusSucceeded, clone.Status)
+	require.False(t, clone.Stubbed)
+
+	write := res.Steps[1]
+	require.Equal(t, kargoapi.PromotionStepStatusSucceeded, write.Status)
+	require.Equal(t, map[string]any{"content": "hello"}, write.Config)
+	require.Equal(t, map[string]any{"written": "hello"}, write.Output)
+	require.Equal(t, "wrote file.txt\n", write.Log)
+
+	skipped := res.Steps[2]
+	require.Equal(t, kargoapi.PromotionStepStatusSkipped, skipped.Status)
+	require.Nil(t, skipped.Config)
+
+	create := res.Steps[3]
+	require.Equal(t, kargoapi.PromotionStepStatusErrored, create.Status)
+	require.Contains(t, create.Message, errDryRunWrite.Error())
+
+	push := res.Steps[4]
+	require.Equal(t, kargoapi.PromotionStepStatusSucceeded, push.Status)
+	require.True(t, push.Stubbed)
+	require.Equal(t, map[string]any{"written": "hello", "count": float64(2)}, push.Config)
+
+	require.Len(t, res.WorkTrees, 1)
+	require.Equal(t, "out", res.WorkTrees[0].Path)
+	require.Empty(t, res.WorkTrees[0].Error)
+	require.Contains(t, res.WorkTrees[0].Diff, "+++ b/file.txt")
+	require.Contains(t, res.WorkTrees[0].Diff, "+hello\n")
+}
+
+func TestDryRunEngine_DryRun_sideEffects(t *testing.T) {
+	// executed records whether each executed step kind was told it was part of
+	// a dry run
+	var executed map[string]bool
+	newRegistration := func(kind string, sideEffects bool) StepRunnerRegistration {
+		return StepRunnerRegistration{
+			Name:     kind,
+			Metadata: StepRunnerMetadata{SideEffects: sideEffects},
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(_ context.Context, stepCtx *StepContext) (StepResult, error) {
+						executed[kind] = stepCtx.DryRun
+						return StepResult{Status: kargoapi.PromotionStepStatusSucceeded}, nil
+					},
+				}
+			},
+		}
+	}
+	registry := MustNewStepRunnerRegistry(
+		newRegistration("fake-local", false),
+		newRegistration("fake-blocked", true),
+		newRegistration("fake-allowed", true),
+	)
+	steps := []Step{
+		{Kind: "fake-local", Alias: "local"},
+		{Kind: "fake-blocked", Alias: "blocked"},
+		{Kind: "fake-allowed", Alias: "allowed"},
+	}
+
+	t.Run("allowed kinds", func(t *testing.T) {
+		executed = map[string]bool{}
+		workDir := filepath.Join(t.TempDir(), "work")
+		engine := &DryRunEngine{
+			registry:    registry,
+			kargoClient: fake.NewClientBuilder().Build(),
+		}
+		res, err := engine.DryRun(t.Context(), Context{}, steps, &DryRunOptions{
+			WorkDir:          workDir,
+			AllowedStepKinds: []string{"fake-allowed"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, kargoapi.PromotionPhaseSucceeded, res.Status)
+		require.False(t, res.Steps[0].Stubbed)
+		require.True(t, res.Steps[1].Stubbed)
+		require.False(t, res.Steps[2].Stubbed)
+		require.Equal(t, map[string]bool{
+			"fake-local":   true,
+			"fake-allowed": false,
+		}, executed)
+		// The working directory is left in place
+		require.DirExists(t, workDir)
+	})
+
+	t.Run("all allowed", func(t *testing.T) {
+		executed = map[string]bool{}
+		engine := &DryRunEngine{
+			registry:    registry,
+			kargoClient: fake.NewClientBuilder().Build(),
+		}
+		res, err := engine.DryRun(t.Context(), Context{}, steps, &DryRunOptions{
+			AllowSideEffects: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, kargoapi.PromotionPhaseSucceeded, res.Status)
+		require.Equal(t, map[string]bool{
+			"fake-local":   false,
+			"fake-blocked": false,
+			"fake-allowed": false,
+		}, executed)
+	})
+}
+
+func TestDryRunEngine_DryRun_stubbedDependencies(t *testing.T) {
+	// executed records the aliases of the steps that were executed
+	var executed []string
+	registry := MustNewStepRunnerRegistry(
+		StepRunnerRegistration{
+			Name: "fake-local",
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{
+					RunFunc: func(_ context.Context, stepCtx *StepContext) (StepResult, error) {
+						executed = append(executed, stepCtx.Alias)
+						return StepResult{Status: kargoapi.PromotionStepStatusSucceeded}, nil
+					},
+				}
+			},
+		},
+		StepRunnerRegistration{
+			Name:     "fake-open-pr",
+			Metadata: StepRunnerMetadata{SideEffects: true},
+			Value: func(StepRunnerCapabilities) StepRunner {
+				return &MockStepRunner{}
+			},
+		},
+	)
+
+	engine := &DryRunEngine{
+		registry:    registry,
+		kargoClient: fake.NewClientBuilder().Build(),
+	}
+	res, err := engine.DryRun(
+		t.Context(),
+		Context{},
+		[]Step{
+			{Kind: "fake-open-pr", Alias: "open-pr"},
+			{
+				Kind:   "fake-local",
+				Alias:  "config",
+				Config: []byte(`{"url":"${{ outputs['open-pr'].pr.url }}"}`),
+			},
+			{
+				Kind:  "fake-local",
+				Alias: "condition",
+				If:    `${{ outputs["open-pr"].pr.merged }}`,
+			},
+			{Kind: "fake-open-pr", Alias: "task::open-pr"},
+			{
+				Kind:   "fake-local",
+				Alias:  "task::wait",
+				Config: []byte(`{"id":"${{ task.outputs['open-pr'].pr.id }}"}`),
+			},
+			{Kind: "fake-local", Alias: "unrelated"},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, kargoapi.PromotionPhaseSucceeded, res.Status)
+	require.Len(t, res.Steps, 6)
+	for _, s := range []DryRunStep{res.Steps[1], res.Steps[2]} {
+		require.Equal(t, kargoapi.PromotionStepStatusSkipped, s.Status)
+		require.Equal(t, `step depends on the output of step "open-pr", which was not executed`, s.Message)
+	}
+	require.Equal(t, kargoapi.PromotionStepStatusSkipped, res.Steps[4].Status)
+	require.Equal(
+		t,
+		`step depends on the output of step "task::open-pr", which was not executed`,
+		res.Steps[4].Message,
+	)
+	require.Equal(t, kargoapi.PromotionStepStatusSucceeded, res.Steps[5].Status)
+	require.Equal(t, []string{"unrelated"}, executed)
+}
@@ -377,6 +377,7 @@ func (p *StepEvaluator) BuildStepContext(
 		TargetFreightRef:   promoCtx.TargetFreightRef,
 		TargetFreightAlias: promoCtx.TargetFreightAlias,
 		Target:             promoCtx.Target.DeepCopy(),
+		DryRun:             promoCtx.DryRun,
 	}, nil
 }
 
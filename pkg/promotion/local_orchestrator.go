@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	gocache "github.com/patrickmn/go-cache"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,6 +13,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/credentials"
 	"github.com/akuity/kargo/pkg/health"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 )
 
 // LocalOrchestrator is an implementation of the Orchestrator interface that
@@ -22,6 +24,11 @@ type LocalOrchestrator struct {
 	registry  StepRunnerRegistry
 	client    client.Client
 	cacheFunc ExprDataCacheFn
+	// unexecutedDependency, if set, is consulted when the "if" condition or
+	// the configuration of a step cannot be evaluated. If it returns the alias
+	// of an earlier step that was not executed and whose output the step
+	// references, the step is skipped instead of being marked as errored.
+	unexecutedDependency func(step Step) (string, bool)
 }
 
 // NewLocalOrchestrator creates a new LocalOrchestrator instance with the
@@ -65,6 +72,7 @@ func (o *LocalOrchestrator) ExecuteSteps(
 	}
 
 	var healthChecks []health.Criteria
+	var stepLogs []steplog.Chunk
 
 	// Execute each step in sequence, starting from the step specified in
 	// the Context if provided.
@@ -90,6 +98,7 @@ func (o *LocalOrchestrator) ExecuteSteps(
 				StepExecutionMetadata: promoCtx.StepExecutionMetadata,
 				State:                 promoCtx.State,
 				HealthChecks:          healthChecks,
+				StepLogs:              stepLogs,
 			}, nil
 		default:
 			// Continue execution if the context is still active.
@@ -108,6 +117,9 @@ func (o *LocalOrchestrator) ExecuteSteps(
 			skip, err := processor.ShouldSkip(ctx, promoCtx, step)
 			switch {
 			case err != nil:
+				if o.skipForUnexecutedDependency(meta, step) {
+					continue
+				}
 				meta.WithStatus(kargoapi.PromotionStepStatusErrored).WithMessagef(
 					"error checking if step %q should be skipped: %s", step.Alias, err,
 				)
@@ -149,18 +161,36 @@ func (o *LocalOrchestrator) ExecuteSteps(
 		// Build step context for the step execution.
 		stepCtx, err := processor.BuildStepContext(ctx, promoCtx, step)
 		if err != nil {
+			if o.skipForUnexecutedDependency(meta, step) {
+				meta.Finished()
+				continue
+			}
 			meta.WithStatus(kargoapi.PromotionStepStatusErrored).WithMessagef(
 				"failed to build step context: %s", err,
 			)
 			continue
 		}
 
+		// Give the step somewhere to write its output.
+		stepCtx.Log = steplog.NewSink(steplog.DefaultMaxBytes)
+
 		// Execute the step.
 		result, err := o.executor.ExecuteStep(ctx, StepExecutionRequest{
 			Context: *stepCtx,
 			Step:    step,
 		})
 
+		// Collect any output the step wrote.
+		if data := stepCtx.Log.Bytes(); len(data) > 0 {
+			stepLogs = append(stepLogs, steplog.Chunk{
+				StepIndex:  i,
+				Alias:      step.Alias,
+				Data:       data,
+				Truncated:  stepCtx.Log.Truncated(),
+				CapturedAt: time.Now(),
+			})
+		}
+
 		// Propagate the step output to the state.
 		o.propagateStepOutput(promoCtx, step, reg.Metadata, result)
 
@@ -185,6 +215,7 @@ func (o *LocalOrchestrator) ExecuteSteps(
 				State:                 promoCtx.State,
 				HealthChecks:          healthChecks,
 				RetryAfter:            result.RetryAfter,
+				StepLogs:              stepLogs,
 			}, err
 		}
 
@@ -206,6 +237,7 @@ func (o *LocalOrchestrator) ExecuteSteps(
 		StepExecutionMetadata: promoCtx.StepExecutionMetadata,
 		State:                 promoCtx.State,
 		HealthChecks:          healthChecks,
+		StepLogs:              stepLogs,
 	}, nil
 }
 
@@ -269,6 +301,22 @@ func (o *LocalOrchestrator) reconcileResultWithMetadata(
 	return nil
 }
 
+// skipForUnexecutedDependency marks the provided step as skipped and returns
+// true if it references the output of an earlier step that was not executed.
+func (o *LocalOrchestrator) skipForUnexecutedDependency(meta *StepMetadata, step Step) bool {
+	if o.unexecutedDependency == nil {
+		return false
+	}
+	alias, ok := o.unexecutedDependency(step)
+	if !ok {
+		return false
+	}
+	meta.WithStatus(kargoapi.PromotionStepStatusSkipped).WithMessagef(
+		"step depends on the output of step %q, which was not executed", alias,
+	)
+	return true
+}
+
 func (o *LocalOrchestrator) determineStepCompletion(
 	promoCtx Context,
 	step Step,
@@ -492,6 +492,42 @@ func TestLocalOrchestrator_ExecuteSteps(t *testing.T) {
 				}, result.State)
 			},
 		},
+		{
+			name: "step log output is collected",
+			registrations: []StepRunnerRegistration{{
+				Name: "logging-step",
+				Value: func(_ StepRunnerCapabilities) StepRunner {
+					return &MockStepRunner{
+						RunFunc: func(_ context.Context, stepCtx *StepContext) (StepResult, error) {
+							stepCtx.Log.AddSecrets("hunter2")
+							stepCtx.Log.Printf("rendering failed with password hunter2")
+							return StepResult{
+								Status: kargoapi.PromotionStepStatusErrored,
+							}, &TerminalError{Err: errors.New("rendering failed")}
+						},
+					}
+				},
+			}},
+			steps: []Step{
+				{Kind: "success-step", Alias: "step1"},
+				{Kind: "logging-step", Alias: "step2"},
+			},
+			assertions: func(t *testing.T, result Result, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, kargoapi.PromotionPhaseErrored, result.Status)
+
+				// Only steps that wrote output should have produced a chunk.
+				require.Len(t, result.StepLogs, 1)
+				assert.Equal(t, int64(1), result.StepLogs[0].StepIndex)
+				assert.Equal(t, "step2", result.StepLogs[0].Alias)
+				assert.Equal(
+					t,
+					"rendering failed with password ********\n",
+					string(result.StepLogs[0].Data),
+				)
+				assert.False(t, result.StepLogs[0].Truncated)
+			},
+		},
 		{
 			name: "stand alone output composition step",
 			registrations: []StepRunnerRegistration{{
@@ -13,6 +13,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/health"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 )
 
 // TargetContext carries a resolved Target's data for exposure to promotion step
@@ -117,6 +118,9 @@ type Context struct {
 	// Rollback indicates whether this Promotion is a rollback to a previously
 	// verified piece of Freight.
 	Rollback bool
+	// DryRun indicates that the steps are being executed only to preview their
+	// outcome. Steps with side effects are not executed at all during a dry run.
+	DryRun bool
 
 	// currentStepMetadata is a pointer to the StepMetadata for the
 	// current step being executed. It is used to track the execution state of
@@ -151,6 +155,9 @@ type Result struct {
 	// reporting itself to be in a Running status should be retried. Note: This is
 	// unrelated to retrying upon non-terminal failures.
 	RetryAfter *time.Duration
+	// StepLogs collects any output written by StepRunners to their Log during
+	// this execution of the user-defined promotion process.
+	StepLogs []steplog.Chunk
 }
 
 // ContextOption is a function that configures a Context.
@@ -291,6 +298,7 @@ func (c *Context) DeepCopy() Context {
 		Vars:                  slices.Clone(c.Vars),
 		Actor:                 c.Actor,
 		Rollback:              c.Rollback,
+		DryRun:                c.DryRun,
 	}
 
 	if c.FreightRequests != nil {
@@ -481,6 +489,19 @@ type StepContext struct {
 	// and target.labels. It is nil for Promotions that promote to the Stage
 	// itself (classic behavior).
 	Target *TargetContext
+	// Log is a bounded sink to which the StepRunner may write human-readable
+	// output, such as the output of external tools it invokes, to help users
+	// understand the outcome of the step. Sensitive values are redacted from
+	// anything written to it. StepRunners that obtain credentials SHOULD
+	// register them with Log.AddSecrets before writing any output that might
+	// contain them. Log may be nil, but all its methods are nil-safe.
+	Log *steplog.Sink
+	// DryRun indicates that the step is being executed only to preview the
+	// outcome of the Promotion. Steps with side effects are never executed
+	// during a dry run, but StepRunners that are executed and that may
+	// incidentally change external state (e.g. by creating a branch that does
+	// not exist yet) MUST NOT do so when this is true.
+	DryRun bool
 }
 
 // StepResult represents the results of a single Step of a user-defined promotion
@@ -653,6 +653,7 @@ func TestContext_DeepCopy(t *testing.T) {
 				Promotion:     "test-promotion",
 				StartFromStep: 2,
 				Actor:         "test-actor",
+				DryRun:        true,
 				FreightRequests: []kargoapi.FreightRequest{
 					{
 						Origin: kargoapi.FreightOrigin{
@@ -718,6 +719,7 @@ func TestContext_DeepCopy(t *testing.T) {
 				assert.Equal(t, original.Stage, deepCopy.Stage)
 				assert.Equal(t, original.Promotion, deepCopy.Promotion)
 				assert.Equal(t, original.StartFromStep, deepCopy.StartFromStep)
+				assert.Equal(t, original.DryRun, deepCopy.DryRun)
 				assert.Equal(t, original.Actor, deepCopy.Actor)
 
 				// Verify FreightRequests is deep copied
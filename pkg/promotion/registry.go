@@ -92,6 +92,14 @@ type StepRunnerMetadata struct {
 	// factory function. By default, StepRunners are not granted any special
 	// capabilities.
 	RequiredCapabilities []StepRunnerCapability
+	// SideEffects indicates that the StepRunner changes state outside of the
+	// Promotion's working directory -- for instance, by pushing commits, opening
+	// pull requests, updating Argo CD Applications, or sending arbitrary HTTP
+	// requests -- or that it waits on such changes having been made. StepRunners
+	// that fetch content from arbitrary, user-specified locations should also
+	// be registered as having side effects. Steps of this kind are stubbed out
+	// rather than executed during a dry run.
+	SideEffects bool
 }
 
 // StepRunnerCapability is a type representing special capabilities that may be
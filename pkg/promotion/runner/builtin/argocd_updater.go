@@ -46,6 +46,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessArgoCD,
 				},
+				SideEffects: true,
 			},
 			Value: newArgocdUpdater,
 		},
@@ -47,6 +47,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessArgoCD,
 				},
+				SideEffects: true,
 			},
 			Value: newArgocdWaiter,
 		},
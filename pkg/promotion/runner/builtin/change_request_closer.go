@@ -0,0 +1,125 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
+)
+
+const (
+	stepKindChangeRequestClose = "change-request-close"
+
+	// changeRequestOutputResult is the key of the step output holding the result
+	// with which the change request was closed.
+	changeRequestOutputResult = "result"
+)
+
+func init() {
+	promotion.DefaultStepRunnerRegistry.MustRegister(
+		promotion.StepRunnerRegistration{
+			Name: stepKindChangeRequestClose,
+			Metadata: promotion.StepRunnerMetadata{
+				SideEffects: true,
+			},
+			Value: newChangeRequestCloser,
+		},
+	)
+}
+
+// changeRequestCloser is an implementation of the promotion.StepRunner
+// interface that closes a change request in an external change management
+// system with the result of the Promotion.
+type changeRequestCloser struct {
+	schemaLoader gojsonschema.JSONLoader
+}
+
+// newChangeRequestCloser returns an implementation of the promotion.StepRunner
+// interface that closes a change request in an external change management
+// system with the result of the Promotion.
+func newChangeRequestCloser(promotion.StepRunnerCapabilities) promotion.StepRunner {
+	return &changeRequestCloser{
+		schemaLoader: getConfigSchemaLoader(stepKindChangeRequestClose),
+	}
+}
+
+// Run implements the promotion.StepRunner interface.
+func (c *changeRequestCloser) Run(
+	ctx context.Context,
+	stepCtx *promotion.StepContext,
+) (promotion.StepResult, error) {
+	cfg, err := c.convert(stepCtx.Config)
+	if err != nil {
+		return promotion.StepResult{
+			Status: kargoapi.PromotionStepStatusFailed,
+		}, &promotion.TerminalError{Err: err}
+	}
+	return c.run(ctx, cfg)
+}
+
+// convert validates the configuration against a JSON schema and converts it
+// into a builtin.ChangeRequestCloseConfig struct.
+func (c *changeRequestCloser) convert(cfg promotion.Config) (builtin.ChangeRequestCloseConfig, error) {
+	return validateAndConvert[builtin.ChangeRequestCloseConfig](
+		c.schemaLoader, cfg, stepKindChangeRequestClose,
+	)
+}
+
+func (c *changeRequestCloser) run(
+	ctx context.Context,
+	cfg builtin.ChangeRequestCloseConfig,
+) (promotion.StepResult, error) {
+	// An empty change ID means no change request was ever opened, which is
+	// expected when the step that would have opened one failed before doing so.
+	if cfg.ChangeID == "" {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusSkipped}, nil
+	}
+
+	client, err := newChangeRequestClient(cfg.InsecureSkipTLSVerify, cfg.Timeout)
+	if err != nil {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
+			&promotion.TerminalError{Err: fmt.Errorf("error creating HTTP client: %w", err)}
+	}
+
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPatch
+	}
+	result := builtin.Succeeded
+	if cfg.Result != nil {
+		result = *cfg.Result
+	}
+	headers := make([]changeRequestHeader, len(cfg.Headers))
+	for i, header := range cfg.Headers {
+		headers[i] = changeRequestHeader{name: header.Name, value: header.Value}
+	}
+	if _, err = sendChangeRequestRequest(
+		ctx,
+		client,
+		strings.NewReplacer(
+			changeRequestIDPlaceholder, cfg.ChangeID,
+			changeRequestResultPlaceholder, string(result),
+		),
+		method,
+		cfg.URL,
+		headers,
+		cfg.Body,
+	); err != nil {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
+			fmt.Errorf("error closing change request %q: %w", cfg.ChangeID, err)
+	}
+
+	return promotion.StepResult{
+		Status: kargoapi.PromotionStepStatusSucceeded,
+		Output: map[string]any{
+			changeRequestOutputChangeID: cfg.ChangeID,
+			changeRequestOutputResult:   string(result),
+		},
+	}, nil
+}
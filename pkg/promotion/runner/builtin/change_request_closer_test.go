@@ -0,0 +1,177 @@
+package builtin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
+)
+
+func Test_changeRequestCloser_convert(t *testing.T) {
+	tests := []validationTestCase{
+		{
+			name:   "changeID and url not specified",
+			config: promotion.Config{},
+			expectedProblems: []string{
+				"(root): changeID is required",
+				"(root): url is required",
+			},
+		},
+		{
+			name: "invalid result",
+			config: promotion.Config{
+				"result": "Errored",
+			},
+			expectedProblems: []string{
+				"result: result must be one of the following",
+			},
+		},
+		{
+			name: "invalid method",
+			config: promotion.Config{
+				"method": "invalid",
+			},
+			expectedProblems: []string{
+				"method: Does not match pattern",
+			},
+		},
+		{
+			name: "empty changeID is valid",
+			config: promotion.Config{
+				"changeID": "",
+				"url":      "https://example.com/changes/{{changeID}}",
+			},
+		},
+		{
+			name: "valid kitchen sink",
+			config: promotion.Config{
+				"changeID": "42",
+				"url":      "https://example.com/changes/{{changeID}}",
+				"method":   "PUT",
+				"headers": []promotion.Config{{
+					"name":  "Authorization",
+					"value": "Bearer token",
+				}},
+				"body":                  `{"close_code":"{{result}}"}`,
+				"result":                "Failed",
+				"insecureSkipTLSVerify": true,
+				"timeout":               "30s",
+			},
+		},
+	}
+
+	r := newChangeRequestCloser(promotion.StepRunnerCapabilities{})
+	runner, ok := r.(*changeRequestCloser)
+	require.True(t, ok)
+
+	runValidationTests(t, runner.convert, tests)
+}
+
+func Test_changeRequestCloser_run(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handler    http.HandlerFunc
+		cfg        builtin.ChangeRequestCloseConfig
+		assertions func(*testing.T, promotion.StepResult, error)
+	}{
+		{
+			name: "no change ID",
+			handler: func(_ http.ResponseWriter, _ *http.Request) {
+				require.Fail(t, "no request should have been made")
+			},
+			cfg: builtin.ChangeRequestCloseConfig{
+				URL: "/changes/{{changeID}}",
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusSkipped, res.Status)
+			},
+		},
+		{
+			name: "closes change request with default result",
+			handler: func(_ http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPatch, r.Method)
+				require.Equal(t, "/changes/42", r.URL.Path)
+				require.Equal(t, "42-Succeeded", r.Header.Get("X-Change"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, `{"close_code":"Succeeded"}`, string(body))
+			},
+			cfg: builtin.ChangeRequestCloseConfig{
+				ChangeID: "42",
+				URL:      "/changes/{{changeID}}",
+				Headers: []builtin.ChangeRequestCloseConfigHeader{{
+					Name:  "X-Change",
+					Value: "{{changeID}}-{{result}}",
+				}},
+				Body: `{"close_code":"{{result}}"}`,
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusSucceeded, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "result": "Succeeded"},
+					res.Output,
+				)
+			},
+		},
+		{
+			name: "closes change request with failed result",
+			handler: func(_ http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/changes/42/Failed", r.URL.Path)
+			},
+			cfg: builtin.ChangeRequestCloseConfig{
+				ChangeID: "42",
+				URL:      "/changes/{{changeID}}/{{result}}",
+				Method:   http.MethodPost,
+				Result:   ptr.To(builtin.Failed),
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusSucceeded, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "result": "Failed"},
+					res.Output,
+				)
+			},
+		},
+		{
+			name: "close fails",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			cfg: builtin.ChangeRequestCloseConfig{
+				ChangeID: "42",
+				URL:      "/changes/{{changeID}}",
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, `error closing change request "42"`)
+				require.ErrorContains(t, err, "unexpected HTTP (503) response")
+				require.False(t, promotion.IsTerminal(err))
+				require.Equal(t, kargoapi.PromotionStepStatusErrored, res.Status)
+			},
+		},
+	}
+
+	r := &changeRequestCloser{}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			t.Cleanup(srv.Close)
+			testCase.cfg.URL = srv.URL + testCase.cfg.URL
+			res, err := r.run(t.Context(), testCase.cfg)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
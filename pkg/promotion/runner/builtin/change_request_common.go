@@ -0,0 +1,130 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
+)
+
+const (
+	// changeRequestIDPlaceholder is replaced with the ID of a change request
+	// wherever it appears in the URL, headers, or body of a request made by the
+	// change-request-open or change-request-close steps.
+	changeRequestIDPlaceholder = "{{changeID}}"
+	// changeRequestResultPlaceholder is replaced with the result of the
+	// Promotion wherever it appears in the URL, headers, or body of the request
+	// made by the change-request-close step.
+	changeRequestResultPlaceholder = "{{result}}"
+
+	// changeRequestOutputChangeID is the key of the step output holding the ID
+	// of the change request.
+	changeRequestOutputChangeID = "changeID"
+)
+
+// changeRequestHeader is a header to include in a request made to a change
+// management system.
+type changeRequestHeader struct {
+	name  string
+	value string
+}
+
+// sendChangeRequestRequest sends a request to a change management system and
+// returns an expression environment built from the response. Occurrences of
+// placeholders in the URL, header values, and body are replaced using the
+// provided replacer before the request is sent. Responses with a status code
+// outside the 2xx range result in an error. Errors that may be transient, such
+// as failures to reach the change management system or responses with a 429 or
+// 5xx status code, are returned as a *retryableError.
+func sendChangeRequestRequest(
+	ctx context.Context,
+	client *http.Client,
+	replacer *strings.Replacer,
+	method string,
+	url string,
+	headers []changeRequestHeader,
+	body string,
+) (map[string]any, error) {
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(replacer.Replace(body))
+	}
+	req, err := http.NewRequestWithContext(ctx, method, replacer.Replace(url), bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	for _, header := range headers {
+		req.Header.Add(header.name, replacer.Replace(header.value))
+	}
+	// #nosec G704 -- The client is using a custom dialer that mitigates the worst
+	// practical risks of SSRF by refusing to dial link-local addresses.
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &retryableError{err: fmt.Errorf("error sending HTTP request: %w", err)}
+	}
+	defer resp.Body.Close()
+	env, err := (&httpRequester{}).buildExprEnv(ctx, resp, "")
+	if err != nil {
+		return nil, fmt.Errorf("error building expression context from HTTP response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = fmt.Errorf("unexpected HTTP (%d) response", resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return nil, &retryableError{err: err}
+		}
+		return nil, err
+	}
+	return env, nil
+}
+
+// newChangeRequestClient returns an HTTP client for making requests to a
+// change management system.
+func newChangeRequestClient(insecureSkipTLSVerify bool, timeout string) (*http.Client, error) {
+	return (&httpRequester{}).getClient(builtin.HTTPConfig{
+		InsecureSkipTLSVerify: insecureSkipTLSVerify,
+		Timeout:               timeout,
+	})
+}
+
+// evaluateChangeRequestExpression evaluates the provided expression against
+// the provided expression environment. An expression that cannot be compiled
+// results in a terminal error.
+func evaluateChangeRequestExpression(expression string, env map[string]any) (any, error) {
+	program, err := expr.Compile(expression)
+	if err != nil {
+		return nil, &promotion.TerminalError{
+			Err: fmt.Errorf("error compiling expression %q: %w", expression, err),
+		}
+	}
+	res, err := expr.Run(program, env)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating expression %q: %w", expression, err)
+	}
+	return res, nil
+}
+
+// evaluateChangeRequestCondition evaluates the provided expression against the
+// provided expression environment, requiring it to evaluate to a boolean. An
+// empty expression evaluates to false.
+func evaluateChangeRequestCondition(expression string, env map[string]any) (bool, error) {
+	if expression == "" {
+		return false, nil
+	}
+	res, err := evaluateChangeRequestExpression(expression, env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := res.(bool)
+	if !ok {
+		return false, fmt.Errorf(
+			"expression %q did not evaluate to a boolean (got %T)", expression, res,
+		)
+	}
+	return b, nil
+}
@@ -0,0 +1,286 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
+)
+
+const (
+	stepKindChangeRequestOpen = "change-request-open"
+
+	// changeRequestPollIntervalDefault is the suggested interval at which the
+	// change-request-open step re-polls a change request while waiting for it to
+	// be approved or rejected, absent an explicitly configured pollInterval.
+	changeRequestPollIntervalDefault = time.Minute
+
+	// changeRequestOutputState is the key of the step output holding the state
+	// of the change request.
+	changeRequestOutputState = "state"
+
+	changeRequestStatePending  = "Pending"
+	changeRequestStateApproved = "Approved"
+	changeRequestStateRejected = "Rejected"
+)
+
+func init() {
+	promotion.DefaultStepRunnerRegistry.MustRegister(
+		promotion.StepRunnerRegistration{
+			Name: stepKindChangeRequestOpen,
+			Metadata: promotion.StepRunnerMetadata{
+				// Change requests are typically reviewed by humans, so allow them
+				// considerably longer than most steps to be approved.
+				DefaultTimeout: 24 * time.Hour,
+				SideEffects:    true,
+			},
+			Value: newChangeRequestOpener,
+		},
+	)
+}
+
+// changeRequestOpener is an implementation of the promotion.StepRunner
+// interface that opens a change request in an external change management
+// system and waits for it to be approved or rejected.
+type changeRequestOpener struct {
+	schemaLoader gojsonschema.JSONLoader
+}
+
+// newChangeRequestOpener returns an implementation of the promotion.StepRunner
+// interface that opens a change request in an external change management
+// system and waits for it to be approved or rejected.
+func newChangeRequestOpener(promotion.StepRunnerCapabilities) promotion.StepRunner {
+	return &changeRequestOpener{
+		schemaLoader: getConfigSchemaLoader(stepKindChangeRequestOpen),
+	}
+}
+
+// Run implements the promotion.StepRunner interface.
+func (c *changeRequestOpener) Run(
+	ctx context.Context,
+	stepCtx *promotion.StepContext,
+) (promotion.StepResult, error) {
+	cfg, err := c.convert(stepCtx.Config)
+	if err != nil {
+		return promotion.StepResult{
+			Status: kargoapi.PromotionStepStatusFailed,
+		}, &promotion.TerminalError{Err: err}
+	}
+	return c.run(ctx, stepCtx, cfg)
+}
+
+// convert validates the configuration against a JSON schema and converts it
+// into a builtin.ChangeRequestOpenConfig struct.
+func (c *changeRequestOpener) convert(cfg promotion.Config) (builtin.ChangeRequestOpenConfig, error) {
+	return validateAndConvert[builtin.ChangeRequestOpenConfig](
+		c.schemaLoader, cfg, stepKindChangeRequestOpen,
+	)
+}
+
+func (c *changeRequestOpener) run(
+	ctx context.Context,
+	stepCtx *promotion.StepContext,
+	cfg builtin.ChangeRequestOpenConfig,
+) (promotion.StepResult, error) {
+	pollInterval, err := resolvePollInterval(cfg.PollInterval, changeRequestPollIntervalDefault)
+	if err != nil {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
+	}
+	client, err := newChangeRequestClient(cfg.InsecureSkipTLSVerify, cfg.Timeout)
+	if err != nil {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
+			&promotion.TerminalError{Err: fmt.Errorf("error creating HTTP client: %w", err)}
+	}
+
+	// Short-circuit the creation of the change request if shared state has
+	// output from a previous execution of this step that contains its ID.
+	changeID, err := c.getChangeID(stepCtx)
+	if err != nil {
+		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
+			&promotion.TerminalError{Err: fmt.Errorf("error getting change ID from shared state: %w", err)}
+	}
+	if changeID == "" {
+		if changeID, err = c.create(ctx, client, cfg.Create); err != nil {
+			return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
+				fmt.Errorf("error creating change request: %w", err)
+		}
+		// Return right away so the ID is recorded in the Promotion's state before
+		// anything else can go wrong. Were polling to fail now, a retry would
+		// otherwise create a second change request.
+		return promotion.StepResult{
+			Status:     kargoapi.PromotionStepStatusRunning,
+			Output:     changeRequestOpenOutput(changeID, changeRequestStatePending),
+			RetryAfter: &pollInterval,
+		}, nil
+	}
+
+	state, err := c.poll(ctx, client, cfg.Poll, changeID)
+	if err != nil {
+		err = fmt.Errorf("error polling change request %q: %w", changeID, err)
+		// Retain the ID, since the output of this execution replaces that of the
+		// previous one.
+		output := changeRequestOpenOutput(changeID, changeRequestStatePending)
+		// A change request may be pending for a long time, during which the
+		// change management system may well be briefly unavailable. Rather than
+		// count such errors against the step's error threshold, poll again later
+		// as if the change request were still pending.
+		var re *retryableError
+		if errors.As(err, &re) {
+			return promotion.StepResult{
+				Status:     kargoapi.PromotionStepStatusRunning,
+				Message:    fmt.Sprintf("%s; will retry", err),
+				Output:     output,
+				RetryAfter: &pollInterval,
+			}, nil
+		}
+		return promotion.StepResult{
+			Status: kargoapi.PromotionStepStatusErrored,
+			Output: output,
+		}, err
+	}
+	output := changeRequestOpenOutput(changeID, state)
+	switch state {
+	case changeRequestStateApproved:
+		return promotion.StepResult{
+			Status: kargoapi.PromotionStepStatusSucceeded,
+			Output: output,
+		}, nil
+	case changeRequestStateRejected:
+		return promotion.StepResult{
+			Status: kargoapi.PromotionStepStatusFailed,
+			Output: output,
+		}, &promotion.TerminalError{
+			Err: fmt.Errorf("change request %q was rejected", changeID),
+		}
+	default:
+		return promotion.StepResult{
+			Status:     kargoapi.PromotionStepStatusRunning,
+			Output:     output,
+			RetryAfter: &pollInterval,
+		}, nil
+	}
+}
+
+// create creates a change request and returns its ID.
+func (c *changeRequestOpener) create(
+	ctx context.Context,
+	client *http.Client,
+	cfg builtin.ChangeRequestCreate,
+) (string, error) {
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	headers := make([]changeRequestHeader, len(cfg.Headers))
+	for i, header := range cfg.Headers {
+		headers[i] = changeRequestHeader{name: header.Name, value: header.Value}
+	}
+	// There is no ID to substitute for placeholders yet.
+	env, err := sendChangeRequestRequest(
+		ctx, client, strings.NewReplacer(), method, cfg.URL, headers, cfg.Body,
+	)
+	if err != nil {
+		return "", err
+	}
+	idAny, err := evaluateChangeRequestExpression(cfg.IDExpression, env)
+	if err != nil {
+		return "", err
+	}
+	if idAny == nil {
+		return "", fmt.Errorf("ID expression %q evaluated to nil", cfg.IDExpression)
+	}
+	changeID := fmt.Sprint(idAny)
+	if changeID == "" {
+		return "", fmt.Errorf("ID expression %q evaluated to an empty string", cfg.IDExpression)
+	}
+	return changeID, nil
+}
+
+// poll retrieves the change request with the provided ID and returns its
+// state.
+func (c *changeRequestOpener) poll(
+	ctx context.Context,
+	client *http.Client,
+	cfg builtin.ChangeRequestPoll,
+	changeID string,
+) (string, error) {
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers := make([]changeRequestHeader, len(cfg.Headers))
+	for i, header := range cfg.Headers {
+		headers[i] = changeRequestHeader{name: header.Name, value: header.Value}
+	}
+	env, err := sendChangeRequestRequest(
+		ctx,
+		client,
+		strings.NewReplacer(changeRequestIDPlaceholder, changeID),
+		method,
+		cfg.URL,
+		headers,
+		"",
+	)
+	if err != nil {
+		return "", err
+	}
+	// Rejection is checked first so that a change request that somehow
+	// satisfies both expressions is never treated as approved.
+	rejected, err := evaluateChangeRequestCondition(cfg.RejectedExpression, env)
+	if err != nil {
+		return "", err
+	}
+	if rejected {
+		return changeRequestStateRejected, nil
+	}
+	approved, err := evaluateChangeRequestCondition(cfg.ApprovedExpression, env)
+	if err != nil {
+		return "", err
+	}
+	if approved {
+		return changeRequestStateApproved, nil
+	}
+	return changeRequestStatePending, nil
+}
+
+// getChangeID returns the ID of the change request recorded in shared state by
+// a previous execution of this step. It returns an empty string if there is
+// none.
+func (c *changeRequestOpener) getChangeID(stepCtx *promotion.StepContext) (string, error) {
+	stepOutput, exists := stepCtx.SharedState.Get(stepCtx.Alias)
+	if !exists || stepOutput == nil {
+		return "", nil
+	}
+	stepOutputMap, ok := stepOutput.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf(
+			"output from step with alias %q is not a map[string]any",
+			stepCtx.Alias,
+		)
+	}
+	changeIDAny, exists := stepOutputMap[changeRequestOutputChangeID]
+	if !exists {
+		return "", nil
+	}
+	changeID, ok := changeIDAny.(string)
+	if !ok {
+		return "", errors.New("change ID from shared state is not a string")
+	}
+	return changeID, nil
+}
+
+// changeRequestOpenOutput returns the output of the change-request-open step.
+func changeRequestOpenOutput(changeID, state string) map[string]any {
+	return map[string]any{
+		changeRequestOutputChangeID: changeID,
+		changeRequestOutputState:    state,
+	}
+}
@@ -0,0 +1,357 @@
+package builtin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
+)
+
+func Test_changeRequestOpener_convert(t *testing.T) {
+	tests := []validationTestCase{
+		{
+			name:   "create and poll not specified",
+			config: promotion.Config{},
+			expectedProblems: []string{
+				"(root): create is required",
+				"(root): poll is required",
+			},
+		},
+		{
+			name: "create url and idExpression not specified",
+			config: promotion.Config{
+				"create": promotion.Config{},
+			},
+			expectedProblems: []string{
+				"create: url is required",
+				"create: idExpression is required",
+			},
+		},
+		{
+			name: "poll url and approvedExpression not specified",
+			config: promotion.Config{
+				"poll": promotion.Config{},
+			},
+			expectedProblems: []string{
+				"poll: url is required",
+				"poll: approvedExpression is required",
+			},
+		},
+		{
+			name: "invalid create method",
+			config: promotion.Config{
+				"create": promotion.Config{
+					"method": "invalid",
+				},
+			},
+			expectedProblems: []string{
+				"create.method: Does not match pattern",
+			},
+		},
+		{
+			name: "invalid pollInterval",
+			config: promotion.Config{
+				"pollInterval": "invalid",
+			},
+			expectedProblems: []string{
+				"pollInterval: Does not match pattern",
+			},
+		},
+		{
+			name: "valid kitchen sink",
+			config: promotion.Config{
+				"create": promotion.Config{
+					"method": "POST",
+					"url":    "https://example.com/api/now/table/change_request",
+					"headers": []promotion.Config{{
+						"name":  "Authorization",
+						"value": "Bearer token",
+					}},
+					"body":         `{"short_description":"Promote"}`,
+					"idExpression": "response.body.result.sys_id",
+				},
+				"poll": promotion.Config{
+					"url":                "https://example.com/api/now/table/change_request/{{changeID}}",
+					"approvedExpression": "response.body.result.approval == 'approved'",
+					"rejectedExpression": "response.body.result.approval == 'rejected'",
+				},
+				"insecureSkipTLSVerify": true,
+				"pollInterval":          "30s",
+				"timeout":               "30s",
+			},
+		},
+	}
+
+	r := newChangeRequestOpener(promotion.StepRunnerCapabilities{})
+	runner, ok := r.(*changeRequestOpener)
+	require.True(t, ok)
+
+	runValidationTests(t, runner.convert, tests)
+}
+
+func Test_changeRequestOpener_run(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handler    http.HandlerFunc
+		state      promotion.State
+		cfg        builtin.ChangeRequestOpenConfig
+		assertions func(*testing.T, promotion.StepResult, error)
+	}{
+		{
+			name: "creates change request",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/create", r.URL.Path)
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, `{"description":"promote"}`, string(body))
+				w.Header().Set("Content-Type", "application/json")
+				_, err = w.Write([]byte(`{"result":{"number":42}}`))
+				require.NoError(t, err)
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Create: builtin.ChangeRequestCreate{
+					URL:          "/create",
+					Body:         `{"description":"promote"}`,
+					IDExpression: "response.body.result.number",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusRunning, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Pending"},
+					res.Output,
+				)
+				require.NotNil(t, res.RetryAfter)
+				require.Equal(t, time.Minute, *res.RetryAfter)
+			},
+		},
+		{
+			name: "create fails",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Create: builtin.ChangeRequestCreate{
+					URL:          "/create",
+					IDExpression: "response.body.id",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, "error creating change request")
+				require.ErrorContains(t, err, "unexpected HTTP (500) response")
+				require.Equal(t, kargoapi.PromotionStepStatusErrored, res.Status)
+			},
+		},
+		{
+			name: "ID expression evaluates to nil",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{}`))
+				require.NoError(t, err)
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Create: builtin.ChangeRequestCreate{
+					URL:          "/create",
+					IDExpression: "response.body.id",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, "evaluated to nil")
+				require.Equal(t, kargoapi.PromotionStepStatusErrored, res.Status)
+			},
+		},
+		{
+			name: "change request pending",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/changes/42", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"approval":"requested"}`))
+				require.NoError(t, err)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42", "state": "Pending"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "response.body.approval == 'approved'",
+					RejectedExpression: "response.body.approval == 'rejected'",
+				},
+				PollInterval: "5m",
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusRunning, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Pending"},
+					res.Output,
+				)
+				require.NotNil(t, res.RetryAfter)
+				require.Equal(t, 5*time.Minute, *res.RetryAfter)
+			},
+		},
+		{
+			name: "change request approved",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"approval":"approved"}`))
+				require.NoError(t, err)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42", "state": "Pending"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "response.body.approval == 'approved'",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusSucceeded, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Approved"},
+					res.Output,
+				)
+			},
+		},
+		{
+			name: "change request rejected",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"approval":"rejected"}`))
+				require.NoError(t, err)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42", "state": "Pending"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "response.body.approval == 'approved'",
+					RejectedExpression: "response.body.approval == 'rejected'",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, `change request "42" was rejected`)
+				require.True(t, promotion.IsTerminal(err))
+				require.Equal(t, kargoapi.PromotionStepStatusFailed, res.Status)
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Rejected"},
+					res.Output,
+				)
+			},
+		},
+		{
+			name: "poll fails transiently",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42", "state": "Pending"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "true",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionStepStatusRunning, res.Status)
+				require.Contains(t, res.Message, `error polling change request "42"`)
+				require.Contains(t, res.Message, "unexpected HTTP (502) response")
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Pending"},
+					res.Output,
+				)
+				require.NotNil(t, res.RetryAfter)
+				require.Equal(t, time.Minute, *res.RetryAfter)
+			},
+		},
+		{
+			name: "poll fails",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42", "state": "Pending"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "true",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, `error polling change request "42"`)
+				require.ErrorContains(t, err, "unexpected HTTP (401) response")
+				require.False(t, promotion.IsTerminal(err))
+				require.Equal(t, kargoapi.PromotionStepStatusErrored, res.Status)
+				// The ID must survive so that a retry does not open a new change
+				// request.
+				require.Equal(
+					t,
+					map[string]any{"changeID": "42", "state": "Pending"},
+					res.Output,
+				)
+			},
+		},
+		{
+			name: "approved expression does not evaluate to a boolean",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"approval":"approved"}`))
+				require.NoError(t, err)
+			},
+			state: promotion.State{
+				"open": map[string]any{"changeID": "42"},
+			},
+			cfg: builtin.ChangeRequestOpenConfig{
+				Poll: builtin.ChangeRequestPoll{
+					URL:                "/changes/{{changeID}}",
+					ApprovedExpression: "response.body.approval",
+				},
+			},
+			assertions: func(t *testing.T, res promotion.StepResult, err error) {
+				require.ErrorContains(t, err, "did not evaluate to a boolean")
+				require.Equal(t, kargoapi.PromotionStepStatusErrored, res.Status)
+			},
+		},
+	}
+
+	r := &changeRequestOpener{}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			t.Cleanup(srv.Close)
+			testCase.cfg.Create.URL = srv.URL + testCase.cfg.Create.URL
+			testCase.cfg.Poll.URL = srv.URL + testCase.cfg.Poll.URL
+			stepCtx := &promotion.StepContext{
+				Alias:       "open",
+				SharedState: testCase.state,
+			}
+			if stepCtx.SharedState == nil {
+				stepCtx.SharedState = promotion.State{}
+			}
+			res, err := r.run(t.Context(), stepCtx, testCase.cfg)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
+)
+
+// secretRegisteringCredsDB is a credentials.Database that registers the
+// secret parts of all credentials it obtains from the wrapped Database with a
+// step's log, so that they are redacted from anything written to it. It is
+// useful for steps that hand a credentials.Database to another component
+// instead of obtaining credentials themselves.
+type secretRegisteringCredsDB struct {
+	credentials.Database
+	log *steplog.Sink
+}
+
+// newSecretRegisteringCredsDB returns a credentials.Database that registers
+// the secret parts of all credentials obtained from the provided Database with
+// the provided Sink.
+func newSecretRegisteringCredsDB(
+	db credentials.Database,
+	log *steplog.Sink,
+) credentials.Database {
+	return &secretRegisteringCredsDB{
+		Database: db,
+		log:      log,
+	}
+}
+
+// Get implements credentials.Database.
+func (s *secretRegisteringCredsDB) Get(
+	ctx context.Context,
+	namespace string,
+	credType credentials.Type,
+	repo string,
+) (*credentials.Credentials, error) {
+	creds, err := s.Database.Get(ctx, namespace, credType, repo)
+	if creds != nil {
+		s.log.AddSecrets(creds.Password, creds.SSHPrivateKey)
+	}
+	return creds, err
+}
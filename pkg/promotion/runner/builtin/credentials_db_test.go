@@ -0,0 +1,58 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
+)
+
+func Test_secretRegisteringCredsDB_Get(t *testing.T) {
+	t.Run("credentials found", func(t *testing.T) {
+		log := steplog.NewSink(0)
+		db := newSecretRegisteringCredsDB(
+			&credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (*credentials.Credentials, error) {
+					return &credentials.Credentials{
+						Username: "fake-user",
+						Password: "fake-password",
+					}, nil
+				},
+			},
+			log,
+		)
+		creds, err := db.Get(t.Context(), "fake-project", credentials.TypeHelm, "https://example.com")
+		require.NoError(t, err)
+		require.Equal(t, "fake-password", creds.Password)
+
+		log.Printf("pulling https://example.com with fake-password failed")
+		require.Equal(t, "pulling https://example.com with ******** failed\n", string(log.Bytes()))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db := newSecretRegisteringCredsDB(
+			&credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (*credentials.Credentials, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			steplog.NewSink(0),
+		)
+		_, err := db.Get(t.Context(), "fake-project", credentials.TypeHelm, "https://example.com")
+		require.ErrorContains(t, err, "something went wrong")
+	})
+}
@@ -117,6 +117,7 @@ func (g *gitCloner) run(
 			fmt.Errorf("error getting credentials for %s: %w", cfg.RepoURL, err)
 	}
 	if creds != nil {
+		stepCtx.Log.AddSecrets(creds.Password, creds.SSHPrivateKey)
 		repoCreds = &git.RepoCredentials{
 			Username:      creds.Username,
 			Password:      creds.Password,
@@ -162,7 +163,9 @@ func (g *gitCloner) run(
 		switch {
 		case checkout.Branch != "":
 			ref = checkout.Branch
-			if err = ensureRemoteBranch(ctx, repo, ref, checkout.Create); err != nil {
+			if err = ensureRemoteBranch(
+				ctx, repo, ref, checkout.Create, stepCtx.DryRun,
+			); err != nil {
 				return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
 					fmt.Errorf("error ensuring existence of remote branch %s: %w", ref, err)
 			}
@@ -220,13 +223,15 @@ func (g *gitCloner) run(
 // ensureRemoteBranch checks for the existence of a remote branch. If the remote
 // branch exists, no action is taken and nil is returned. If the branch does not
 // exist and create == true, an empty orphaned branch is created and pushed to
-// the remote. If the branch does not exist and create == false, an error is
+// the remote. If dryRun == true, the branch is created only locally and is not
+// pushed. If the branch does not exist and create == false, an error is
 // returned.
 func ensureRemoteBranch(
 	ctx context.Context,
 	repo git.BareRepo,
 	branch string,
 	create bool,
+	dryRun bool,
 ) error {
 	exists, err := repo.RemoteBranchExists(ctx, branch)
 	if err != nil {
@@ -278,6 +283,9 @@ func ensureRemoteBranch(
 			branch, repo.URL(), err,
 		)
 	}
+	if dryRun {
+		return nil
+	}
 	if err = workTree.Push(
 		ctx,
 		&git.PushOptions{TargetBranch: branch},
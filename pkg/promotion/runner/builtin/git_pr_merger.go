@@ -38,6 +38,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			Value: newGitPRMerger,
 		},
@@ -33,6 +33,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			Value: newGitPROpener,
 		},
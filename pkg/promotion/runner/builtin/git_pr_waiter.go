@@ -36,6 +36,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			Value: newGitPRWaiter,
 		},
@@ -45,6 +45,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			// This factory function closes over a single instance of gitPushPusher
 			// so that that its mutexes are shared across all executions of this step
@@ -148,6 +149,7 @@ func (g *gitPushPusher) run(
 			fmt.Errorf("error getting credentials for %s: %w", workTree.URL(), err)
 	}
 	if creds != nil {
+		stepCtx.Log.AddSecrets(creds.Password, creds.SSHPrivateKey)
 		loadOpts.Credentials = &git.RepoCredentials{
 			Username:      creds.Username,
 			Password:      creds.Password,
@@ -46,6 +46,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			// This factory function closes over a single instance of githubPusher
 			// so that that its mutexes are shared across all executions of this step
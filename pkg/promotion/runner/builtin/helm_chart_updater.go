@@ -24,6 +24,9 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				// This step pulls dependencies from arbitrary repositories using the Project's
+				// credentials, so it must not be executed by a dry run.
+				SideEffects: true,
 			},
 			Value: newHelmChartUpdater,
 		},
@@ -71,7 +74,11 @@ func (h *helmChartUpdater) run(
 	stepCtx *promotion.StepContext,
 	cfg builtin.HelmUpdateChartConfig,
 ) (promotion.StepResult, error) {
-	manager, err := helm.NewEphemeralDependencyManager(h.credsDB, stepCtx.Project, stepCtx.WorkDir)
+	manager, err := helm.NewEphemeralDependencyManager(
+		newSecretRegisteringCredsDB(h.credsDB, stepCtx.Log),
+		stepCtx.Project,
+		stepCtx.WorkDir,
+	)
 	if err != nil {
 		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
 			fmt.Errorf("failed to create Helm dependency manager: %w", err)
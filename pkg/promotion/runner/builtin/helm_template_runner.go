@@ -41,6 +41,10 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				// This step builds chart dependencies from arbitrary repositories
+				// using the Project's credentials, so it must not be executed by a
+				// dry run.
+				SideEffects: true,
 			},
 			Value: newHelmTemplateRunner,
 		},
@@ -147,6 +151,21 @@ func (h *helmTemplateRunner) run(
 
 	rls, err := install.RunWithContext(ctx, chartRequested, composedValues)
 	if err != nil {
+		// Helm may return whatever it managed to render before it failed. Which
+		// resources those were is often a useful clue as to what went wrong, so
+		// preserve it in the step's log. The rendered resources themselves are
+		// never logged, since they may contain sensitive data (e.g. Secrets)
+		// and step logs are persisted and readable by anyone who can view the
+		// Promotion.
+		stepCtx.Log.Printf("failed to render chart %q: %s", cfg.Path, err)
+		if rls != nil {
+			if resources := summarizeManifest(rls.Manifest); len(resources) > 0 {
+				stepCtx.Log.Printf(
+					"resources rendered before failure:\n%s",
+					strings.Join(resources, "\n"),
+				)
+			}
+		}
 		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
 			fmt.Errorf("failed to render chart: %w", err)
 	}
@@ -155,6 +174,9 @@ func (h *helmTemplateRunner) run(
 		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored},
 			fmt.Errorf("failed to write rendered chart: %w", err)
 	}
+	stepCtx.Log.Printf(
+		"rendered chart %q to %q", cfg.Path, cfg.OutPath,
+	)
 	return promotion.StepResult{Status: kargoapi.PromotionStepStatusSucceeded}, nil
 
 }
@@ -165,7 +187,11 @@ func (h *helmTemplateRunner) buildDependencies(
 	stepCtx *promotion.StepContext,
 	relPath string,
 ) error {
-	manager, err := helm.NewEphemeralDependencyManager(h.credsDB, stepCtx.Project, stepCtx.WorkDir)
+	manager, err := helm.NewEphemeralDependencyManager(
+		newSecretRegisteringCredsDB(h.credsDB, stepCtx.Log),
+		stepCtx.Project,
+		stepCtx.WorkDir,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create Helm dependency manager: %w", err)
 	}
@@ -438,6 +464,32 @@ func (h *helmTemplateRunner) generateResourceFilename(resource []byte) string {
 	return fmt.Sprintf("%s.yaml", strings.ToLower(fileName))
 }
 
+// summarizeManifest returns a description of the kind, namespace, and name of
+// each resource in the provided manifest, for use in logs. Secrets are omitted
+// entirely and nothing beyond this metadata is ever included. Summarizing
+// stops at the first document that cannot be read.
+func summarizeManifest(manifest string) []string {
+	if strings.TrimSpace(manifest) == "" {
+		return nil
+	}
+	reader := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+	var resources []string
+	for {
+		document, err := reader.Read()
+		if err != nil {
+			return resources
+		}
+		group, kind, namespace, name := extractObjectMetadata(document)
+		if kind == "" || (group == "" && kind == "Secret") {
+			continue
+		}
+		if namespace != "" {
+			name = namespace + "/" + name
+		}
+		resources = append(resources, fmt.Sprintf("- %s %s", kind, name))
+	}
+}
+
 // extractObjectMetadata extracts the group, kind, namespace, and name from the
 // metadata of a Kubernetes YAML resource.
 func extractObjectMetadata(resource []byte) (group, kind, namespace, name string) {
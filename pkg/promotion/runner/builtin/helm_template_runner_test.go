@@ -16,6 +16,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/credentials"
 	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
 )
 
@@ -755,6 +756,40 @@ metadata:
 	}
 }
 
+func Test_helmTemplateRunner_run_log(t *testing.T) {
+	workDir := t.TempDir()
+	files := map[string]string{
+		"charts/test-chart/Chart.yaml": `apiVersion: v1
+name: test-chart
+version: 0.1.0`,
+		"charts/test-chart/templates/test.yaml": `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-configmap
+data:
+  value: {{ required "value is required" .Values.value }}
+`,
+	}
+	for p, c := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(workDir, p)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(workDir, p), []byte(c), 0o600))
+	}
+
+	runner := &helmTemplateRunner{credsDB: &credentials.FakeDB{}}
+	stepCtx := &promotion.StepContext{
+		WorkDir: workDir,
+		Project: "test-project",
+		Log:     steplog.NewSink(0),
+	}
+	_, err := runner.run(t.Context(), stepCtx, builtin.HelmTemplateConfig{
+		Path:    "charts/test-chart",
+		OutPath: "output.yaml",
+	})
+	require.ErrorContains(t, err, "failed to render chart")
+	require.Contains(t, string(stepCtx.Log.Bytes()), "value is required")
+}
+
 func Test_helmTemplateRunner_composeValues(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1531,6 +1566,47 @@ metadata:
 	}
 }
 
+func Test_summarizeManifest(t *testing.T) {
+	require.Nil(t, summarizeManifest(""))
+	require.Equal(
+		t,
+		[]string{
+			"- ConfigMap default/test-config",
+			"- ClusterRole test-cluster-role",
+			"- SealedSecret default/not-a-core-secret",
+		},
+		summarizeManifest(`---
+# Source: test-chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-config
+  namespace: default
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test-secret
+  namespace: default
+stringData:
+  password: hunter2
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: test-cluster-role
+---
+apiVersion: bitnami.com/v1alpha1
+kind: SealedSecret
+metadata:
+  name: not-a-core-secret
+  namespace: default
+`),
+	)
+}
+
 func Test_extractObjectMetadata(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -34,7 +34,12 @@ const (
 func init() {
 	promotion.DefaultStepRunnerRegistry.MustRegister(
 		promotion.StepRunnerRegistration{
-			Name:  stepKindHTTPDownload,
+			Name: stepKindHTTPDownload,
+			Metadata: promotion.StepRunnerMetadata{
+				// This step fetches arbitrary URLs, so it must not be executed by
+				// a dry run.
+				SideEffects: true,
+			},
 			Value: newHTTPDownloader,
 		},
 	)
@@ -89,6 +94,15 @@ func (d *httpDownloader) run(
 	stepCtx *promotion.StepContext,
 	cfg builtin.HTTPDownloadConfig,
 ) (promotion.StepResult, error) {
+	// Credentials obtained from Secrets are passed to the remote endpoint as
+	// header or query parameter values.
+	for _, header := range cfg.Headers {
+		stepCtx.Log.AddSecrets(header.Value)
+	}
+	for _, queryParam := range cfg.QueryParams {
+		stepCtx.Log.AddSecrets(queryParam.Value)
+	}
+
 	absOutPath, err := d.prepareOutputPath(stepCtx.WorkDir, cfg.OutPath, cfg.AllowOverwrite)
 	if err != nil {
 		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
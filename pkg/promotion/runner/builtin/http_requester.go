@@ -51,7 +51,10 @@ const (
 func init() {
 	promotion.DefaultStepRunnerRegistry.MustRegister(
 		promotion.StepRunnerRegistration{
-			Name:  stepKindHTTP,
+			Name: stepKindHTTP,
+			Metadata: promotion.StepRunnerMetadata{
+				SideEffects: true,
+			},
 			Value: newHTTPRequester,
 		},
 	)
@@ -347,6 +350,16 @@ func (h *httpRequester) buildRequest(
 		}
 		req.URL.RawQuery = q.Encode()
 	}
+	if stepCtx != nil {
+		// Credentials obtained from Secrets are passed to the remote endpoint
+		// as header or query parameter values.
+		for _, header := range cfg.Headers {
+			stepCtx.Log.AddSecrets(header.Value)
+		}
+		for _, queryParam := range cfg.QueryParams {
+			stepCtx.Log.AddSecrets(queryParam.Value)
+		}
+	}
 	return req, nil
 }
 
@@ -29,7 +29,13 @@ const stepKindKustomizeBuild = "kustomize-build"
 func init() {
 	promotion.DefaultStepRunnerRegistry.MustRegister(
 		promotion.StepRunnerRegistration{
-			Name:  stepKindKustomizeBuild,
+			Name: stepKindKustomizeBuild,
+			Metadata: promotion.StepRunnerMetadata{
+				// This step may inflate Helm charts and load remote resources
+				// from arbitrary locations, so it must not be executed by a dry
+				// run.
+				SideEffects: true,
+			},
 			Value: newKustomizeBuilder,
 		},
 	)
@@ -89,8 +95,15 @@ func (k *kustomizeBuilder) run(
 	// Build the manifests.
 	rm, err := kustomizeBuild(diskFS, filepath.Join(stepCtx.WorkDir, cfg.Path), cfg.Plugin)
 	if err != nil {
+		stepCtx.Log.Printf(
+			"failed to build %q: %s", cfg.Path, fs.SanitizePathError(err, stepCtx.WorkDir),
+		)
 		return promotion.StepResult{Status: kargoapi.PromotionStepStatusErrored}, err
 	}
+	stepCtx.Log.Printf("built %d resources from %q:", rm.Size(), cfg.Path)
+	for _, id := range rm.AllIds() {
+		stepCtx.Log.Printf("  %s", id)
+	}
 
 	// Prepare the output path.
 	outPath, err := securejoin.SecureJoin(stepCtx.WorkDir, cfg.OutPath)
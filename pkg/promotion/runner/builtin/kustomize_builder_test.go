@@ -16,6 +16,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/io/fs"
 	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
 	"github.com/akuity/kargo/pkg/x/promotion/runner/builtin"
 )
 
@@ -475,3 +476,43 @@ metadata:
 		})
 	}
 }
+
+func Test_kustomizeBuilder_run_log(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "kustomization.yaml"), []byte(`
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- deployment.yaml
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "deployment.yaml"), []byte(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+`), 0o600))
+
+	stepCtx := &promotion.StepContext{
+		WorkDir: tempDir,
+		Log:     steplog.NewSink(0),
+	}
+	_, err := (&kustomizeBuilder{}).run(stepCtx, builtin.KustomizeBuildConfig{
+		Path:    ".",
+		OutPath: "output.yaml",
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"built 1 resources from \".\":\n  Deployment.v1.apps/test-deployment.[noNs]\n",
+		string(stepCtx.Log.Bytes()),
+	)
+
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "deployment.yaml")))
+	stepCtx.Log = steplog.NewSink(0)
+	_, err = (&kustomizeBuilder{}).run(stepCtx, builtin.KustomizeBuildConfig{
+		Path:    ".",
+		OutPath: "output.yaml",
+	})
+	require.Error(t, err)
+	require.Contains(t, string(stepCtx.Log.Bytes()), "failed to build \".\"")
+}
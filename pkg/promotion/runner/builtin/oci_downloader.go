@@ -34,6 +34,9 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				// This step pulls artifacts from arbitrary registries using the Project's
+				// credentials, so it must not be executed by a dry run.
+				SideEffects: true,
 			},
 			Value: newOCIDownloader,
 		},
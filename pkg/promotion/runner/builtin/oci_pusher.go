@@ -37,6 +37,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessCredentials,
 				},
+				SideEffects: true,
 			},
 			Value: func(
 				caps promotion.StepRunnerCapabilities,
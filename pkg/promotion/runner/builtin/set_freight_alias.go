@@ -24,6 +24,7 @@ func init() {
 				RequiredCapabilities: []promotion.StepRunnerCapability{
 					promotion.StepCapabilityAccessControlPlane,
 				},
+				SideEffects: true,
 			},
 			Value: newSetFreightAlias,
 		},
@@ -0,0 +1,207 @@
+package steplog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const (
+	// labelKeyPromotionUID is the key of the label identifying the Promotion a
+	// ConfigMap holding a Chunk belongs to. The UID is used instead of the name
+	// because Promotion names may exceed the maximum length of a label value.
+	labelKeyPromotionUID = "kargo.akuity.io/step-log-promotion-uid"
+	// labelKeyStepIndex is the key of the label identifying the index of the
+	// step that produced the Chunk held by a ConfigMap.
+	labelKeyStepIndex = "kargo.akuity.io/step-log-step-index"
+	// annotationKeyAlias is the key of the annotation recording the alias of
+	// the step that produced the Chunk held by a ConfigMap.
+	annotationKeyAlias = "kargo.akuity.io/step-log-alias"
+	// annotationKeyCapturedAt is the key of the annotation recording the time
+	// at which the Chunk held by a ConfigMap was captured.
+	annotationKeyCapturedAt = "kargo.akuity.io/step-log-captured-at"
+	// annotationKeyTruncated is the key of the annotation recording whether the
+	// Chunk held by a ConfigMap was truncated.
+	annotationKeyTruncated = "kargo.akuity.io/step-log-truncated"
+
+	// dataKeyLog is the key in a ConfigMap's binary data holding a Chunk's data.
+	dataKeyLog = "log"
+
+	// DefaultMaxChunksPerStep is the default maximum number of Chunks a
+	// ConfigMapStore retains for a single step of a single Promotion.
+	DefaultMaxChunksPerStep = 5
+
+	// maxNamePrefixLength is the maximum length of the Promotion name used as
+	// a prefix for the names of ConfigMaps holding its Chunks. This leaves room
+	// for a suffix and a random suffix added by the API server while remaining
+	// within the maximum length of an object name.
+	maxNamePrefixLength = 200
+)
+
+// ConfigMapStore is an implementation of Store that persists each Chunk in its
+// own ConfigMap in the Promotion's namespace. Each ConfigMap is owned by the
+// Promotion it belongs to, so it is garbage collected along with it. To bound
+// the space used by any one Promotion, only the most recent Chunks for each
+// step are retained.
+type ConfigMapStore struct {
+	client           client.Client
+	reader           client.Reader
+	maxChunksPerStep int
+}
+
+// NewConfigMapStore returns a ConfigMapStore that uses the provided client to
+// create and delete ConfigMaps and the provided reader to list them. The
+// reader should ideally NOT be backed by a cache, since ConfigMaps holding
+// Chunks are not otherwise of interest to Kargo's components.
+func NewConfigMapStore(c client.Client, r client.Reader) *ConfigMapStore {
+	return &ConfigMapStore{
+		client:           c,
+		reader:           r,
+		maxChunksPerStep: DefaultMaxChunksPerStep,
+	}
+}
+
+// Append implements Store.
+func (s *ConfigMapStore) Append(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	chunk Chunk,
+) error {
+	capturedAt := chunk.CapturedAt
+	if capturedAt.IsZero() {
+		capturedAt = time.Now()
+	}
+	namePrefix := promo.Name
+	if len(namePrefix) > maxNamePrefixLength {
+		namePrefix = namePrefix[:maxNamePrefixLength]
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    promo.Namespace,
+			GenerateName: fmt.Sprintf("%s-log-", namePrefix),
+			Labels: map[string]string{
+				labelKeyPromotionUID: string(promo.UID),
+				labelKeyStepIndex:    strconv.FormatInt(chunk.StepIndex, 10),
+			},
+			Annotations: map[string]string{
+				kargoapi.AnnotationKeyPromotion: promo.Name,
+				annotationKeyAlias:              chunk.Alias,
+				annotationKeyCapturedAt:         capturedAt.UTC().Format(time.RFC3339Nano),
+				annotationKeyTruncated:          strconv.FormatBool(chunk.Truncated),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kargoapi.GroupVersion.String(),
+				Kind:       "Promotion",
+				Name:       promo.Name,
+				UID:        promo.UID,
+			}},
+		},
+		BinaryData: map[string][]byte{dataKeyLog: chunk.Data},
+	}
+	if err := s.client.Create(ctx, cm); err != nil {
+		return fmt.Errorf(
+			"error creating ConfigMap for output of step %q of Promotion %q in namespace %q: %w",
+			chunk.Alias, promo.Name, promo.Namespace, err,
+		)
+	}
+	return s.prune(ctx, promo, chunk.StepIndex)
+}
+
+// List implements Store.
+func (s *ConfigMapStore) List(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) ([]Chunk, error) {
+	cms, err := s.list(ctx, promo, client.MatchingLabels{
+		labelKeyPromotionUID: string(promo.UID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]Chunk, 0, len(cms))
+	for _, cm := range cms {
+		chunks = append(chunks, chunkFromConfigMap(cm))
+	}
+	return chunks, nil
+}
+
+// prune deletes the oldest ConfigMaps holding Chunks for the specified step of
+// the provided Promotion until no more than the maximum number remain.
+func (s *ConfigMapStore) prune(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	stepIndex int64,
+) error {
+	cms, err := s.list(ctx, promo, client.MatchingLabels{
+		labelKeyPromotionUID: string(promo.UID),
+		labelKeyStepIndex:    strconv.FormatInt(stepIndex, 10),
+	})
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(cms)-s.maxChunksPerStep; i++ {
+		if err = s.client.Delete(ctx, &cms[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf(
+				"error deleting ConfigMap %q in namespace %q: %w",
+				cms[i].Name, cms[i].Namespace, err,
+			)
+		}
+	}
+	return nil
+}
+
+// list returns the ConfigMaps in the provided Promotion's namespace that match
+// the provided labels, ordered by step index and then by capture time.
+func (s *ConfigMapStore) list(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	labels client.MatchingLabels,
+) ([]corev1.ConfigMap, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := s.reader.List(
+		ctx,
+		cmList,
+		client.InNamespace(promo.Namespace),
+		labels,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"error listing ConfigMaps for output of Promotion %q in namespace %q: %w",
+			promo.Name, promo.Namespace, err,
+		)
+	}
+	cms := cmList.Items
+	slices.SortStableFunc(cms, func(a, b corev1.ConfigMap) int {
+		ac, bc := chunkFromConfigMap(a), chunkFromConfigMap(b)
+		if ac.StepIndex != bc.StepIndex {
+			if ac.StepIndex < bc.StepIndex {
+				return -1
+			}
+			return 1
+		}
+		return ac.CapturedAt.Compare(bc.CapturedAt)
+	})
+	return cms, nil
+}
+
+// chunkFromConfigMap returns the Chunk held by the provided ConfigMap.
+// Malformed metadata is tolerated and results in zero values.
+func chunkFromConfigMap(cm corev1.ConfigMap) Chunk {
+	stepIndex, _ := strconv.ParseInt(cm.Labels[labelKeyStepIndex], 10, 64)
+	capturedAt, _ := time.Parse(time.RFC3339Nano, cm.Annotations[annotationKeyCapturedAt])
+	truncated, _ := strconv.ParseBool(cm.Annotations[annotationKeyTruncated])
+	return Chunk{
+		StepIndex:  stepIndex,
+		Alias:      cm.Annotations[annotationKeyAlias],
+		Data:       cm.BinaryData[dataKeyLog],
+		Truncated:  truncated,
+		CapturedAt: capturedAt,
+	}
+}
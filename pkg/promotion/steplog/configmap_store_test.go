@@ -0,0 +1,79 @@
+package steplog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	s := NewConfigMapStore(c, c)
+	s.maxChunksPerStep = 2
+
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-promotion",
+			UID:       "fake-uid",
+		},
+	}
+	otherPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "other-promotion",
+			UID:       "other-uid",
+		},
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	appends := []struct {
+		promo *kargoapi.Promotion
+		chunk Chunk
+	}{
+		{promo, Chunk{StepIndex: 1, Alias: "render", Data: []byte("attempt 1"), CapturedAt: start}},
+		{promo, Chunk{StepIndex: 0, Alias: "clone", Data: []byte("cloned"), CapturedAt: start.Add(time.Second)}},
+		{promo, Chunk{StepIndex: 1, Alias: "render", Data: []byte("attempt 2"), CapturedAt: start.Add(2 * time.Second)}},
+		{promo, Chunk{
+			StepIndex:  1,
+			Alias:      "render",
+			Data:       []byte("attempt 3"),
+			Truncated:  true,
+			CapturedAt: start.Add(3 * time.Second),
+		}},
+		{otherPromo, Chunk{StepIndex: 0, Alias: "clone", Data: []byte("other"), CapturedAt: start}},
+	}
+	for _, a := range appends {
+		require.NoError(t, s.Append(ctx, a.promo, a.chunk))
+	}
+
+	chunks, err := s.List(ctx, promo)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	require.Equal(t, "cloned", string(chunks[0].Data))
+	require.Equal(t, "clone", chunks[0].Alias)
+	// The oldest Chunk for the "render" step should have been pruned.
+	require.Equal(t, "attempt 2", string(chunks[1].Data))
+	require.False(t, chunks[1].Truncated)
+	require.Equal(t, "attempt 3", string(chunks[2].Data))
+	require.True(t, chunks[2].Truncated)
+	require.Equal(t, int64(1), chunks[2].StepIndex)
+	require.True(t, start.Add(3*time.Second).Equal(chunks[2].CapturedAt))
+
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, c.List(ctx, cms, client.InNamespace("fake-project")))
+	require.Len(t, cms.Items, 4)
+	for _, cm := range cms.Items {
+		require.Len(t, cm.OwnerReferences, 1)
+		require.Equal(t, "Promotion", cm.OwnerReferences[0].Kind)
+	}
+}
@@ -0,0 +1,142 @@
+package steplog
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxBytes is the default maximum number of bytes a Sink will retain
+// for a single execution of a step.
+const DefaultMaxBytes = 64 * 1024
+
+// truncationMarker is appended to the captured output of a Sink once its
+// limit has been reached.
+const truncationMarker = "\n... output truncated ...\n"
+
+// Sink is a bounded, redacting io.Writer to which a StepRunner may write
+// human-readable output (e.g. the partial output of a failed render) for the
+// benefit of users inspecting a Promotion after the fact. Output is redacted
+// one line at a time, so a secret is never split across a write boundary, and
+// output beyond the Sink's limit is discarded.
+//
+// All methods of a Sink are safe for concurrent use and are no-ops on a nil
+// *Sink, so StepRunners need not check whether a Sink was provided.
+type Sink struct {
+	mu        sync.Mutex
+	maxBytes  int
+	redactor  *Redactor
+	buf       bytes.Buffer
+	pending   []byte
+	truncated bool
+}
+
+// NewSink returns a Sink that retains at most maxBytes of redacted output. A
+// non-positive maxBytes is replaced with DefaultMaxBytes.
+func NewSink(maxBytes int) *Sink {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &Sink{
+		maxBytes: maxBytes,
+		redactor: NewRedactor(),
+	}
+}
+
+// AddSecrets registers additional literal values (e.g. passwords or tokens
+// obtained from the credentials database) that must never appear in the
+// Sink's output.
+func (s *Sink) AddSecrets(secrets ...string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.redactor.AddSecrets(secrets...)
+}
+
+// Write implements io.Writer. It always reports having consumed all of p, even
+// when some or all of it was discarded because the Sink's limit was reached, so
+// that callers such as external tools writing to the Sink are not interrupted.
+func (s *Sink) Write(p []byte) (int, error) {
+	if s == nil {
+		return len(p), nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.truncated {
+		return len(p), nil
+	}
+	s.pending = append(s.pending, p...)
+	for !s.truncated {
+		i := bytes.IndexByte(s.pending, '\n')
+		if i < 0 {
+			break
+		}
+		line := s.pending[:i+1]
+		s.pending = s.pending[i+1:]
+		s.appendLine(line)
+	}
+	// Guard against a writer that never emits a newline.
+	if !s.truncated && len(s.pending) > s.maxBytes {
+		s.appendLine(s.pending)
+		s.pending = nil
+	}
+	return len(p), nil
+}
+
+// Printf formats according to a format specifier and writes the result,
+// followed by a newline if it does not already end with one, to the Sink.
+func (s *Sink) Printf(format string, args ...any) {
+	if s == nil {
+		return
+	}
+	line := fmt.Sprintf(format, args...)
+	if len(line) == 0 || line[len(line)-1] != '\n' {
+		line += "\n"
+	}
+	_, _ = s.Write([]byte(line))
+}
+
+// Bytes returns the redacted output captured by the Sink so far, including any
+// incomplete trailing line.
+func (s *Sink) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) > 0 && !s.truncated {
+		s.appendLine(s.pending)
+		s.pending = nil
+	}
+	return bytes.Clone(s.buf.Bytes())
+}
+
+// Truncated returns true if any output written to the Sink was discarded
+// because the Sink's limit was reached.
+func (s *Sink) Truncated() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.truncated
+}
+
+// appendLine redacts the provided line and appends it to the buffer, truncating
+// it and marking the Sink as truncated if doing so would exceed the limit. The
+// caller must hold the lock.
+func (s *Sink) appendLine(line []byte) {
+	redacted := s.redactor.Redact(line)
+	if remaining := s.maxBytes - s.buf.Len(); len(redacted) > remaining {
+		if remaining > 0 {
+			s.buf.Write(redacted[:remaining])
+		}
+		s.buf.WriteString(truncationMarker)
+		s.truncated = true
+		s.pending = nil
+		return
+	}
+	s.buf.Write(redacted)
+}
@@ -0,0 +1,83 @@
+package steplog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	testCases := []struct {
+		name       string
+		maxBytes   int
+		secrets    []string
+		writes     []string
+		assertions func(*testing.T, *Sink)
+	}{
+		{
+			name:   "output within limit",
+			writes: []string{"hello\n", "world"},
+			assertions: func(t *testing.T, s *Sink) {
+				require.Equal(t, "hello\nworld", string(s.Bytes()))
+				require.False(t, s.Truncated())
+			},
+		},
+		{
+			name:    "secret split across writes is redacted",
+			secrets: []string{"hunter2!"},
+			writes:  []string{"password is hun", "ter2! ok\n"},
+			assertions: func(t *testing.T, s *Sink) {
+				require.Equal(t, "password is "+redacted+" ok\n", string(s.Bytes()))
+			},
+		},
+		{
+			name:     "output exceeding limit is truncated",
+			maxBytes: 10,
+			writes:   []string{"0123456\n", "789abcdef\n", "more\n"},
+			assertions: func(t *testing.T, s *Sink) {
+				require.Equal(t, "0123456\n78"+truncationMarker, string(s.Bytes()))
+				require.True(t, s.Truncated())
+			},
+		},
+		{
+			name:     "long output without newlines is bounded",
+			maxBytes: 10,
+			writes:   []string{strings.Repeat("x", 100)},
+			assertions: func(t *testing.T, s *Sink) {
+				require.Equal(t, strings.Repeat("x", 10)+truncationMarker, string(s.Bytes()))
+				require.True(t, s.Truncated())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := NewSink(testCase.maxBytes)
+			s.AddSecrets(testCase.secrets...)
+			for _, w := range testCase.writes {
+				n, err := s.Write([]byte(w))
+				require.NoError(t, err)
+				require.Equal(t, len(w), n)
+			}
+			testCase.assertions(t, s)
+		})
+	}
+}
+
+func TestSink_Printf(t *testing.T) {
+	s := NewSink(0)
+	s.Printf("rendered %d manifests", 3)
+	s.Printf("done\n")
+	require.Equal(t, "rendered 3 manifests\ndone\n", string(s.Bytes()))
+}
+
+func TestSink_Nil(t *testing.T) {
+	var s *Sink
+	n, err := s.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	s.Printf("foo")
+	s.AddSecrets("foo")
+	require.Nil(t, s.Bytes())
+	require.False(t, s.Truncated())
+}
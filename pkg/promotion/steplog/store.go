@@ -0,0 +1,36 @@
+package steplog
+
+import (
+	"context"
+	"time"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// Chunk is the output captured from a single execution of a single step of a
+// Promotion. A step that is executed more than once (e.g. because it is retried
+// or because it remains Running across several reconciliations) may produce
+// multiple Chunks.
+type Chunk struct {
+	// StepIndex is the index of the step that produced the output.
+	StepIndex int64
+	// Alias is the alias of the step that produced the output.
+	Alias string
+	// Data is the redacted output of the step.
+	Data []byte
+	// Truncated indicates whether some of the output of the step was discarded
+	// because it exceeded the limit of the Sink it was written to.
+	Truncated bool
+	// CapturedAt is the time at which the output was captured.
+	CapturedAt time.Time
+}
+
+// Store is an interface for components that persist the Chunks of output
+// captured from the execution of a Promotion's steps.
+type Store interface {
+	// Append persists the provided Chunk for the provided Promotion.
+	Append(context.Context, *kargoapi.Promotion, Chunk) error
+	// List returns all Chunks persisted for the provided Promotion, ordered by
+	// step index and then by the time at which they were captured.
+	List(context.Context, *kargoapi.Promotion) ([]Chunk, error)
+}
@@ -0,0 +1,202 @@
+package rolemapping
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+)
+
+const (
+	// GroupsClaim is the name of the claim that RoleMapping groups are matched
+	// against.
+	GroupsClaim = "groups"
+	// EmailClaim is the name of the claim that RoleMapping email domains are
+	// matched against.
+	EmailClaim = "email"
+	// EmailVerifiedClaim is the name of the claim that, when false, prevents a
+	// user from being matched on the basis of their email address.
+	EmailVerifiedClaim = "email_verified"
+
+	// MaxExpressionLength is the maximum length, in bytes, of a claim
+	// expression.
+	MaxExpressionLength = 1024
+
+	// expressionVar is the name of the variable by which a CEL expression
+	// refers to a user's claims.
+	expressionVar = "claims"
+
+	// expressionCostLimit bounds the cost of evaluating a single claim
+	// expression. Expressions are evaluated on every authenticated API request,
+	// potentially for many ServiceAccounts, so a Project must not be able to
+	// author one that is expensive to evaluate. The limit comfortably admits
+	// expressions that compare or search claims of any realistic size.
+	expressionCostLimit = 10000
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// getEnv returns the CEL environment in which all claim expressions are
+// compiled. It is initialized once, on first use.
+func getEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(expressionVar, cel.MapType(cel.StringType, cel.DynType)),
+		)
+	})
+	return env, envErr
+}
+
+// CompileExpression compiles the provided CEL expression for evaluation
+// against a user's claims. An error is returned if the expression is too long,
+// is invalid, or does not evaluate to a bool. Evaluation of the returned
+// program fails if its cost exceeds a fixed limit.
+func CompileExpression(expression string) (cel.Program, error) {
+	if len(expression) > MaxExpressionLength {
+		return nil, fmt.Errorf(
+			"expression exceeds maximum length of %d bytes", MaxExpressionLength,
+		)
+	}
+	e, err := getEnv()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing CEL environment: %w", err)
+	}
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("error compiling expression %q: %w", expression, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf(
+			"expression %q must evaluate to a bool; got %s",
+			expression, ast.OutputType(),
+		)
+	}
+	prg, err := e.Program(
+		ast,
+		cel.CostLimit(expressionCostLimit),
+		cel.EvalOptions(cel.OptTrackCost),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building program for expression %q: %w", expression, err)
+	}
+	return prg, nil
+}
+
+// Matcher determines whether a user's claims satisfy RoleMappingCriteria.
+// Compiled CEL expressions are cached, so a single Matcher should be reused.
+// It is safe for concurrent use.
+type Matcher struct {
+	programs sync.Map // map[string]cel.Program
+}
+
+// Matches returns true if the provided claims satisfy ANY of the provided
+// criteria. Errors evaluating individual expressions do not prevent other
+// criteria from being evaluated. They are joined and returned alongside the
+// result.
+func (m *Matcher) Matches(
+	criteria *rbacapi.RoleMappingCriteria,
+	claims map[string]any,
+) (bool, error) {
+	if criteria.IsEmpty() {
+		return false, nil
+	}
+	if groups := StringValues(claims[GroupsClaim]); len(groups) > 0 {
+		for _, group := range criteria.Groups {
+			if slices.Contains(groups, group) {
+				return true, nil
+			}
+		}
+	}
+	if domain, ok := EmailDomain(claims); ok {
+		for _, d := range criteria.EmailDomains {
+			if strings.EqualFold(d, domain) {
+				return true, nil
+			}
+		}
+	}
+	var errs []error
+	for _, expression := range criteria.Expressions {
+		matched, err := m.evaluate(expression, claims)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+// evaluate evaluates the provided CEL expression against the provided claims.
+func (m *Matcher) evaluate(expression string, claims map[string]any) (bool, error) {
+	var prg cel.Program
+	if cached, ok := m.programs.Load(expression); ok {
+		prg = cached.(cel.Program) // nolint: forcetypeassert
+	} else {
+		var err error
+		if prg, err = CompileExpression(expression); err != nil {
+			return false, err
+		}
+		m.programs.Store(expression, prg)
+	}
+	out, _, err := prg.Eval(map[string]any{expressionVar: claims})
+	if err != nil {
+		// Most commonly, the expression referenced a claim the user does not
+		// have. This is not a match, but is worth reporting.
+		return false, fmt.Errorf("error evaluating expression %q: %w", expression, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q did not evaluate to a bool", expression)
+	}
+	return matched, nil
+}
+
+// EmailDomain returns the lowercased domain of the address in the provided
+// claims' "email" claim and true, or an empty string and false if the claims
+// contain no email address or if the "email_verified" claim is false.
+func EmailDomain(claims map[string]any) (string, bool) {
+	if verified, ok := claims[EmailVerifiedClaim].(bool); ok && !verified {
+		return "", false
+	}
+	email, ok := claims[EmailClaim].(string)
+	if !ok {
+		return "", false
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return "", false
+	}
+	return strings.ToLower(email[at+1:]), true
+}
+
+// StringValues returns the string value(s) of a claim whose value may be
+// either a scalar string or a list.
+func StringValues(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,190 @@
+package rolemapping
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+)
+
+func TestCompileExpression(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expression  string
+		errContains string
+	}{
+		{
+			name:       "valid",
+			expression: `claims.department == "payments"`,
+		},
+		{
+			name:       "valid using a list claim",
+			expression: `"admins" in claims.groups`,
+		},
+		{
+			name:        "syntax error",
+			expression:  `claims.department ==`,
+			errContains: "error compiling expression",
+		},
+		{
+			name:        "undeclared variable",
+			expression:  `user.department == "payments"`,
+			errContains: "error compiling expression",
+		},
+		{
+			name:        "not a bool",
+			expression:  `"payments"`,
+			errContains: "must evaluate to a bool",
+		},
+		{
+			name:        "too long",
+			expression:  `claims.department == "` + strings.Repeat("x", MaxExpressionLength) + `"`,
+			errContains: "exceeds maximum length",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			prg, err := CompileExpression(testCase.expression)
+			if testCase.errContains != "" {
+				require.ErrorContains(t, err, testCase.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, prg)
+		})
+	}
+}
+
+func TestMatcher_Matches(t *testing.T) {
+	testCases := []struct {
+		name        string
+		criteria    *rbacapi.RoleMappingCriteria
+		claims      map[string]any
+		matches     bool
+		errContains string
+	}{
+		{
+			name:   "no criteria",
+			claims: map[string]any{GroupsClaim: []any{"devops"}},
+		},
+		{
+			name:     "group matches",
+			criteria: &rbacapi.RoleMappingCriteria{Groups: []string{"devops"}},
+			claims:   map[string]any{GroupsClaim: []any{"eng", "devops"}},
+			matches:  true,
+		},
+		{
+			name:     "group does not match",
+			criteria: &rbacapi.RoleMappingCriteria{Groups: []string{"devops"}},
+			claims:   map[string]any{GroupsClaim: []any{"eng"}},
+		},
+		{
+			name:     "email domain matches",
+			criteria: &rbacapi.RoleMappingCriteria{EmailDomains: []string{"Example.com"}},
+			claims:   map[string]any{EmailClaim: "kilgore@EXAMPLE.com"},
+			matches:  true,
+		},
+		{
+			name:     "email domain matches but email is unverified",
+			criteria: &rbacapi.RoleMappingCriteria{EmailDomains: []string{"example.com"}},
+			claims: map[string]any{
+				EmailClaim:         "kilgore@example.com",
+				EmailVerifiedClaim: false,
+			},
+		},
+		{
+			name:     "email subdomain does not match",
+			criteria: &rbacapi.RoleMappingCriteria{EmailDomains: []string{"example.com"}},
+			claims:   map[string]any{EmailClaim: "kilgore@evil.example.com"},
+		},
+		{
+			name: "expression matches",
+			criteria: &rbacapi.RoleMappingCriteria{
+				Expressions: []string{`claims.department == "payments"`},
+			},
+			claims:  map[string]any{"department": "payments"},
+			matches: true,
+		},
+		{
+			name: "one expression errors but another matches",
+			criteria: &rbacapi.RoleMappingCriteria{
+				Expressions: []string{
+					`claims.department == "payments"`,
+					`"admins" in claims.groups`,
+				},
+			},
+			claims:  map[string]any{GroupsClaim: []any{"admins"}},
+			matches: true,
+		},
+		{
+			name: "expression referencing a missing claim",
+			criteria: &rbacapi.RoleMappingCriteria{
+				Expressions: []string{`claims.department == "payments"`},
+			},
+			claims:      map[string]any{},
+			errContains: "error evaluating expression",
+		},
+		{
+			name: "expression exceeding cost limit",
+			criteria: &rbacapi.RoleMappingCriteria{
+				Expressions: []string{
+					`claims.groups.all(g, claims.groups.all(h, g == h || g != h))`,
+				},
+			},
+			claims:      map[string]any{GroupsClaim: manyGroups(1000)},
+			errContains: "cost limit exceeded",
+		},
+	}
+	m := &Matcher{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matches, err := m.Matches(testCase.criteria, testCase.claims)
+			if testCase.errContains != "" {
+				require.ErrorContains(t, err, testCase.errContains)
+			} else if testCase.matches {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.matches, matches)
+		})
+	}
+}
+
+// manyGroups returns a groups claim with the specified number of groups.
+func manyGroups(n int) []any {
+	groups := make([]any, n)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("group-%d", i)
+	}
+	return groups
+}
+
+func TestEmailDomain(t *testing.T) {
+	domain, ok := EmailDomain(map[string]any{EmailClaim: "kilgore@Example.com"})
+	require.True(t, ok)
+	require.Equal(t, "example.com", domain)
+
+	_, ok = EmailDomain(map[string]any{EmailClaim: "kilgore"})
+	require.False(t, ok)
+
+	_, ok = EmailDomain(map[string]any{EmailClaim: "kilgore@"})
+	require.False(t, ok)
+
+	_, ok = EmailDomain(map[string]any{})
+	require.False(t, ok)
+
+	_, ok = EmailDomain(map[string]any{
+		EmailClaim:         "kilgore@example.com",
+		EmailVerifiedClaim: false,
+	})
+	require.False(t, ok)
+}
+
+func TestStringValues(t *testing.T) {
+	require.Equal(t, []string{"a"}, StringValues("a"))
+	require.Equal(t, []string{"a", "b"}, StringValues([]string{"a", "b"}))
+	require.Equal(t, []string{"a", "b"}, StringValues([]any{"a", 1, "b"}))
+	require.Nil(t, StringValues(1))
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
@@ -17,6 +19,7 @@ import (
 	libhttp "github.com/akuity/kargo/pkg/http"
 	"github.com/akuity/kargo/pkg/kubeclient"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/rolemapping"
 	"github.com/akuity/kargo/pkg/server/user"
 )
 
@@ -43,16 +46,32 @@ func (s *server) patchFreightStatus(
 	return nil
 }
 
+type approveFreightResponse struct {
+	// Approved indicates whether the Freight is approved for promotion to the
+	// Stage.
+	Approved bool `json:"approved"`
+	// Approvers lists the users who have approved the Freight for the Stage.
+	Approvers []kargoapi.Approver `json:"approvers,omitempty"`
+	// RemainingApprovals is the number of additional approvals required before
+	// the Freight is approved for promotion to the Stage.
+	RemainingApprovals int32 `json:"remainingApprovals,omitempty"`
+	// MissingGroups lists the groups required by the Stage's ApprovalPolicy
+	// that are not yet represented among the approvers.
+	MissingGroups []string `json:"missingGroups,omitempty"`
+} // @name ApproveFreightResponse
+
 // @id ApproveFreight
 // @Summary Approve Freight for promotion to a Stage
-// @Description Approve Freight for promotion to a Stage.
+// @Description Approve Freight for promotion to a Stage. If the Stage's
+// @Description PromotionPolicy requires approval by multiple users, the
+// @Description approval is recorded as pending until the policy is satisfied.
 // @Tags Core, Project-Level
 // @Security BearerAuth
 // @Produce json
 // @Param project path string true "Project name"
 // @Param freight-name-or-alias path string true "Freight name or alias"
 // @Param stage query string true "Stage name"
-// @Success 200 "Success"
+// @Success 200 {object} approveFreightResponse
 // @Router /v1beta1/projects/{project}/freight/{freight-name-or-alias}/approve [post]
 func (s *server) approveFreight(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -113,42 +132,126 @@ func (s *server) approveFreight(c *gin.Context) {
 	}
 
 	if freight.IsApprovedFor(stageName) {
-		c.Status(http.StatusOK)
+		c.JSON(http.StatusOK, newApproveFreightResponse(freight, stageName, nil))
 		return
 	}
 
-	newStatus := *freight.Status.DeepCopy()
-	if newStatus.ApprovedFor == nil {
-		newStatus.ApprovedFor = make(map[string]kargoapi.ApprovedStage)
+	policy, err := api.GetApprovalPolicy(ctx, s.client, stage.ObjectMeta)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
-	newStatus.AddApprovedStage(stageName, time.Now())
 
-	if err := kubeclient.PatchStatus(
-		ctx,
-		s.client,
-		freight,
-		func(status *kargoapi.FreightStatus) {
-			*status = newStatus
-		},
-	); err != nil {
+	approver := kargoapi.Approver{ApprovedAt: &metav1.Time{Time: time.Now()}}
+	u, ok := user.InfoFromContext(ctx)
+	if ok {
+		approver = approverFromUserInfo(u, approver.ApprovedAt.Time)
+	}
+
+	if policy != nil && !policy.AllowCommitAuthorApproval && len(freight.Commits) > 0 {
+		email, _ := u.Claims[rolemapping.EmailClaim].(string)
+		// Without an email address, there is no telling whether the approver
+		// authored any of the commits, so the approval cannot be accepted.
+		if email == "" {
+			_ = c.Error(libhttp.ErrorStr(
+				fmt.Sprintf(
+					"authors of commits referenced by Freight %q may not approve it for "+
+						"Stage %q and the approver's email address could not be determined",
+					freight.Name, stageName,
+				),
+				http.StatusForbidden,
+			))
+			return
+		}
+		if api.IsFreightCommitAuthor(freight, email) {
+			_ = c.Error(libhttp.ErrorStr(
+				fmt.Sprintf(
+					"authors of commits referenced by Freight %q may not approve it for Stage %q",
+					freight.Name, stageName,
+				),
+				http.StatusForbidden,
+			))
+			return
+		}
+	}
+
+	// Approvals are accumulated across requests, so the status is patched
+	// using an optimistic lock to avoid losing concurrent approvals.
+	var approved bool
+	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err = s.client.Get(ctx, client.ObjectKeyFromObject(freight), freight); err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(
+			freight.DeepCopy(),
+			client.MergeFromWithOptimisticLock{},
+		)
+		approved = freight.Status.AddApprover(stageName, approver, policy)
+		return s.client.Status().Patch(ctx, freight, patch)
+	}); err != nil {
 		_ = c.Error(fmt.Errorf("patch freight status: %w", err))
 		return
 	}
 
-	var actor string
 	eventMsg := fmt.Sprintf("Freight approved for Stage %q", stageName)
-	if u, ok := user.InfoFromContext(ctx); ok {
-		actor = api.FormatEventUserActor(u)
-		eventMsg += fmt.Sprintf(" by %q", actor)
+	if !approved {
+		eventMsg = fmt.Sprintf("Freight partially approved for Stage %q", stageName)
+	}
+	if approver.Name != "" {
+		eventMsg += fmt.Sprintf(" by %q", approver.Name)
 	}
 
-	if s.sender != nil {
-		evt := event.NewFreightApproved(eventMsg, actor, stageName, freight)
-		if err := s.sender.Send(ctx, evt); err != nil {
+	// Only approvals that satisfy the policy are announced, since consumers of
+	// the event treat it as the Freight having become promotable.
+	if approved && s.sender != nil {
+		evt := event.NewFreightApproved(eventMsg, approver.Name, stageName, freight)
+		if err = s.sender.Send(ctx, evt); err != nil {
 			logging.LoggerFromContext(ctx).Error(err,
 				"error sending Freight approved event")
 		}
+	} else {
+		logging.LoggerFromContext(ctx).Debug(eventMsg)
 	}
 
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, newApproveFreightResponse(freight, stageName, policy))
+}
+
+// approverFromUserInfo returns an Approver describing the provided user. Users
+// are identified in the same manner as in events. Groups are taken from the
+// groups claim of users authenticated via OIDC and from the Kubernetes user
+// info of users authenticated by Kubernetes.
+func approverFromUserInfo(u user.Info, approvedAt time.Time) kargoapi.Approver {
+	approver := kargoapi.Approver{
+		Name:       api.FormatEventUserActor(u),
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+	}
+	if u.KubernetesUserInfo != nil {
+		approver.Groups = u.KubernetesUserInfo.Groups
+	} else {
+		approver.Groups = rolemapping.StringValues(u.Claims[rolemapping.GroupsClaim])
+	}
+	return approver
+}
+
+// newApproveFreightResponse describes the approval state of the provided
+// Freight for the specified Stage, evaluated against the provided
+// ApprovalPolicy.
+func newApproveFreightResponse(
+	freight *kargoapi.Freight,
+	stage string,
+	policy *kargoapi.ApprovalPolicy,
+) approveFreightResponse {
+	if approvedStage, ok := freight.Status.ApprovedFor[stage]; ok {
+		return approveFreightResponse{
+			Approved:  true,
+			Approvers: approvedStage.Approvers,
+		}
+	}
+	approvers := freight.Status.PendingApprovals[stage].Approvers
+	remaining, missingGroups := policy.Outstanding(approvers)
+	return approveFreightResponse{
+		Approvers:          approvers,
+		RemainingApprovals: remaining,
+		MissingGroups:      missingGroups,
+	}
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +17,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/server/config"
+	"github.com/akuity/kargo/pkg/server/user"
 )
 
 func Test_server_approveFreight(t *testing.T) {
@@ -52,6 +54,55 @@ func Test_server_approveFreight(t *testing.T) {
 			Namespace: testProject.Name,
 		},
 	}
+	testProjectConfig := &kargoapi.ProjectConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testProject.Name,
+			Namespace: testProject.Name,
+		},
+		Spec: kargoapi.ProjectConfigSpec{
+			PromotionPolicies: []kargoapi.PromotionPolicy{{
+				StageSelector: &kargoapi.PromotionPolicySelector{Name: testStageName},
+				Approval: &kargoapi.ApprovalPolicy{
+					RequiredApprovals: 2,
+					RequiredGroups:    []string{"sre"},
+				},
+			}},
+		},
+	}
+	// Authored a commit referenced by testFreightWithPendingApproval.
+	testAuthor := user.Info{
+		Claims: map[string]any{
+			"email":  "Kilgore@Example.com",
+			"groups": []any{"sre"},
+		},
+		UsernameClaim: "email",
+		Username:      "kilgore@example.com",
+	}
+	testFreightWithPendingApproval := testFreight.DeepCopy()
+	testFreightWithPendingApproval.Commits = []kargoapi.GitCommit{{
+		Author: "Kilgore Trout <kilgore@example.com>",
+	}}
+	testFreightWithPendingApproval.Status.PendingApprovals = map[string]kargoapi.PendingApproval{
+		testStageName: {
+			Approvers: []kargoapi.Approver{{Name: "email:eliot@example.com"}},
+		},
+	}
+	allowAll := func(_ *testing.T, s *server) {
+		s.authorizeFn = func(
+			context.Context,
+			string,
+			schema.GroupVersionResource,
+			string,
+			client.ObjectKey,
+		) error {
+			return nil
+		}
+	}
+	withUser := func(u user.Info) func(context.Context) context.Context {
+		return func(ctx context.Context) context.Context {
+			return user.ContextWithInfo(ctx, u)
+		}
+	}
 	testRESTEndpoint(
 		t, &config.ServerConfig{},
 		http.MethodPost, "/v1beta1/projects/"+testProject.Name+"/freight/"+testFreight.Name+"/approve?stage="+testStageName,
@@ -167,6 +218,136 @@ func Test_server_approveFreight(t *testing.T) {
 					require.NoError(t, err)
 					require.True(t, freight.IsApprovedFor(testStage.Name))
 					require.Contains(t, freight.Status.ApprovedFor, testStage.Name)
+
+					res := approveFreightResponse{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+					require.True(t, res.Approved)
+				},
+			},
+			{
+				name: "records pending approval when approval policy is not satisfied",
+				clientBuilder: fake.NewClientBuilder().
+					WithObjects(testProject, testProjectConfig, testFreight, testStage).
+					WithStatusSubresource(testFreight),
+				serverSetup: allowAll,
+				ctxSetup: withUser(user.Info{
+					Claims: map[string]any{
+						"email":  "eliot@example.com",
+						"groups": []any{"developers"},
+					},
+					UsernameClaim: "email",
+					Username:      "eliot@example.com",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, c client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+
+					res := approveFreightResponse{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+					require.False(t, res.Approved)
+					require.Equal(t, int32(1), res.RemainingApprovals)
+					require.Equal(t, []string{"sre"}, res.MissingGroups)
+					require.Len(t, res.Approvers, 1)
+					require.Equal(t, "email:eliot@example.com", res.Approvers[0].Name)
+
+					freight := &kargoapi.Freight{}
+					require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(testFreight), freight))
+					require.False(t, freight.IsApprovedFor(testStageName))
+					require.Len(t, freight.Status.PendingApprovals[testStageName].Approvers, 1)
+					require.Equal(
+						t,
+						[]string{"developers"},
+						freight.Status.PendingApprovals[testStageName].Approvers[0].Groups,
+					)
+				},
+			},
+			{
+				name: "approves Freight when approval policy is satisfied",
+				clientBuilder: fake.NewClientBuilder().
+					WithObjects(testProject, testProjectConfig, testFreightWithPendingApproval, testStage).
+					WithStatusSubresource(testFreight),
+				serverSetup: allowAll,
+				ctxSetup: withUser(user.Info{
+					Claims: map[string]any{
+						"email":  "rosewater@example.com",
+						"groups": []any{"sre"},
+					},
+					UsernameClaim: "email",
+					Username:      "rosewater@example.com",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, c client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+
+					res := approveFreightResponse{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+					require.True(t, res.Approved)
+					require.Len(t, res.Approvers, 2)
+
+					freight := &kargoapi.Freight{}
+					require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(testFreight), freight))
+					require.True(t, freight.IsApprovedFor(testStageName))
+					require.Len(t, freight.Status.ApprovedFor[testStageName].Approvers, 2)
+					require.Empty(t, freight.Status.PendingApprovals)
+				},
+			},
+			{
+				name: "repeated approval does not count toward approval policy",
+				clientBuilder: fake.NewClientBuilder().
+					WithObjects(testProject, testProjectConfig, testFreightWithPendingApproval, testStage).
+					WithStatusSubresource(testFreight),
+				serverSetup: allowAll,
+				ctxSetup: withUser(user.Info{
+					Claims:        map[string]any{"email": "eliot@example.com"},
+					UsernameClaim: "email",
+					Username:      "eliot@example.com",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, c client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+
+					res := approveFreightResponse{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+					require.False(t, res.Approved)
+					require.Equal(t, int32(1), res.RemainingApprovals)
+
+					freight := &kargoapi.Freight{}
+					require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(testFreight), freight))
+					require.Len(t, freight.Status.PendingApprovals[testStageName].Approvers, 1)
+				},
+			},
+			{
+				name: "commit author may not approve Freight",
+				clientBuilder: fake.NewClientBuilder().
+					WithObjects(testProject, testProjectConfig, testFreightWithPendingApproval, testStage).
+					WithStatusSubresource(testFreight),
+				serverSetup: allowAll,
+				ctxSetup:    withUser(testAuthor),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, c client.Client) {
+					require.Equal(t, http.StatusForbidden, w.Code)
+					require.Contains(t, w.Body.String(), "authors of commits")
+
+					freight := &kargoapi.Freight{}
+					require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(testFreight), freight))
+					require.False(t, freight.IsApprovedFor(testStageName))
+				},
+			},
+			{
+				name: "approver without email may not approve Freight referencing commits",
+				clientBuilder: fake.NewClientBuilder().
+					WithObjects(testProject, testProjectConfig, testFreightWithPendingApproval, testStage).
+					WithStatusSubresource(testFreight),
+				serverSetup: allowAll,
+				ctxSetup: withUser(user.Info{
+					Claims:        map[string]any{"groups": []any{"sre"}},
+					UsernameClaim: "sub",
+					Username:      "kilgore",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, c client.Client) {
+					require.Equal(t, http.StatusForbidden, w.Code)
+					require.Contains(t, w.Body.String(), "email address could not be determined")
+
+					freight := &kargoapi.Freight{}
+					require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(testFreight), freight))
+					require.False(t, freight.IsApprovedFor(testStageName))
+					require.Len(t, freight.Status.PendingApprovals[testStageName].Approvers, 1)
 				},
 			},
 		},
@@ -14,6 +14,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
@@ -21,19 +22,28 @@ import (
 	"github.com/hashicorp/go-cleanhttp"
 	authnv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	libClient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	libhttp "github.com/akuity/kargo/pkg/http"
 	"github.com/akuity/kargo/pkg/indexer"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/rolemapping"
 	"github.com/akuity/kargo/pkg/server/config"
 	"github.com/akuity/kargo/pkg/server/user"
 )
 
 const authHeaderKey = "Authorization"
 
+// apiTokenLastUsedUpdateInterval is the minimum interval between updates to
+// the time at which a Kargo API token was last used. It keeps a token that is
+// used frequently from causing a write to its Secret on every request.
+const apiTokenLastUsedUpdateInterval = time.Minute
+
 // errNoToken and errInvalidToken are the only authentication failures reported
 // to clients. Both carry a 401 and disclose nothing about which check rejected
 // the credential. Where the underlying reason is useful, sites wrap
@@ -66,6 +76,9 @@ type authMiddleware struct {
 	// endpoints to be accessed without a token, such as the public server config
 	// endpoint.
 	exemptPaths map[string]struct{}
+	// roleMappingMatcher evaluates the role mapping criteria of
+	// ServiceAccounts that cannot be selected using an index alone.
+	roleMappingMatcher *rolemapping.Matcher
 
 	parseUnverifiedJWTFn func(
 		rawToken string,
@@ -80,6 +93,11 @@ type authMiddleware struct {
 		ctx context.Context,
 		rawToken string,
 	) (*authnv1.UserInfo, error)
+	verifyAPITokenFn func(
+		ctx context.Context,
+		rawToken string,
+		userInfo *authnv1.UserInfo,
+	) ([]rbacv1.PolicyRule, error)
 	oidcTokenVerifyFn     goOIDCIDTokenVerifyFn
 	oidcExtractClaimsFn   func(*oidc.IDToken) (claims, error)
 	listServiceAccountsFn func(
@@ -115,9 +133,10 @@ func NewAuthMiddleware(
 	opts ...AuthMiddlewareOpt,
 ) gin.HandlerFunc {
 	a := &authMiddleware{
-		cfg:            cfg,
-		internalClient: client,
-		exemptPaths:    maps.Clone(exemptPaths),
+		cfg:                cfg,
+		internalClient:     client,
+		exemptPaths:        maps.Clone(exemptPaths),
+		roleMappingMatcher: &rolemapping.Matcher{},
 	}
 	if cfg.OIDCConfig != nil {
 		a.oidcTokenVerifyFn = newMultiClientVerifier(ctx, cfg)
@@ -126,6 +145,7 @@ func NewAuthMiddleware(
 	a.verifyKargoIssuedTokenFn = a.verifyKargoIssuedToken
 	a.verifyIDPIssuedTokenFn = a.verifyIDPIssuedToken
 	a.verifyKubernetesTokenFn = a.verifyKubernetesToken
+	a.verifyAPITokenFn = a.verifyAPIToken
 	a.oidcExtractClaimsFn = oidcExtractClaims
 	a.listServiceAccountsFn = a.listServiceAccounts
 
@@ -279,9 +299,19 @@ func (a *authMiddleware) authenticate(
 	}
 	logger.Debug("token recognized by Kubernetes", "username", k8sUserInfo.Username)
 
+	// If the token is a Kargo API token, it may have expired or have been
+	// scoped more narrowly than the role it is associated with.
+	scopes, err := a.verifyAPITokenFn(ctx, rawToken, k8sUserInfo)
+	if err != nil {
+		return ctx, err
+	}
+
 	return user.ContextWithInfo(
 		ctx,
-		user.Info{KubernetesUserInfo: k8sUserInfo},
+		user.Info{
+			KubernetesUserInfo: k8sUserInfo,
+			APITokenScopes:     scopes,
+		},
 	), nil
 }
 
@@ -308,6 +338,18 @@ func (a *authMiddleware) listServiceAccounts(
 			}
 		}
 	}
+	// Users may also be mapped to ServiceAccounts by the role mappings that
+	// the management controller records on them.
+	for _, group := range rolemapping.StringValues(c[rolemapping.GroupsClaim]) {
+		queries = append(queries, libClient.MatchingFields{
+			indexer.ServiceAccountsByRoleMappingField: indexer.FormatRoleMappingGroup(group),
+		})
+	}
+	if domain, ok := rolemapping.EmailDomain(c); ok {
+		queries = append(queries, libClient.MatchingFields{
+			indexer.ServiceAccountsByRoleMappingField: indexer.FormatRoleMappingEmailDomain(domain),
+		})
+	}
 	// allowedNamespaces is a set of all namespaces in which to search for
 	// ServiceAccounts the user may be mapped to. These will includes all project
 	// namespaces and any additional namespaces that the Kargo admin has
@@ -354,6 +396,54 @@ func (a *authMiddleware) listServiceAccounts(
 			accounts[key.Namespace][key] = struct{}{}
 		}
 	}
+	// ServiceAccounts mapped to by CEL expressions cannot be selected using the
+	// index alone. Retrieve all of them and evaluate their criteria.
+	list := &corev1.ServiceAccountList{}
+	if err := a.internalClient.List(ctx, list, libClient.MatchingFields{
+		indexer.ServiceAccountsByRoleMappingField: indexer.RoleMappingExpressionsKey,
+	}); err != nil {
+		return nil, fmt.Errorf("list service accounts: %w", err)
+	}
+	logger := logging.LoggerFromContext(ctx)
+	for _, sa := range list.Items {
+		if _, ok := allowedNamespaces[sa.GetNamespace()]; !ok {
+			continue
+		}
+		key := types.NamespacedName{
+			Namespace: sa.GetNamespace(),
+			Name:      sa.GetName(),
+		}
+		if _, ok := accounts[key.Namespace][key]; ok {
+			continue
+		}
+		criteria, err := rbacapi.RoleMappingCriteriaFromAnnotationValues(sa.GetAnnotations())
+		if err != nil {
+			// The index only contains ServiceAccounts with valid criteria, so
+			// this should never happen.
+			continue
+		}
+		matched, err := a.roleMappingMatcher.Matches(
+			&rbacapi.RoleMappingCriteria{Expressions: criteria.Expressions},
+			c,
+		)
+		if err != nil {
+			// Expressions referencing claims the user does not have are expected
+			// to fail, so this is not worth more than a debug message.
+			logger.Debug(
+				"error evaluating role mapping expressions",
+				"namespace", key.Namespace,
+				"serviceAccount", key.Name,
+				"error", err.Error(),
+			)
+		}
+		if !matched {
+			continue
+		}
+		if _, ok := accounts[key.Namespace]; !ok {
+			accounts[key.Namespace] = make(map[types.NamespacedName]struct{})
+		}
+		accounts[key.Namespace][key] = struct{}{}
+	}
 	return accounts, nil
 }
 
@@ -442,6 +532,112 @@ func (a *authMiddleware) verifyKubernetesToken(
 	return &review.Status.User, nil
 }
 
+// serviceAccountTokenClaims are the claims of a legacy ServiceAccount token,
+// which is what a Kargo API token is.
+type serviceAccountTokenClaims struct {
+	jwt.RegisteredClaims
+	Namespace          string `json:"kubernetes.io/serviceaccount/namespace"`
+	SecretName         string `json:"kubernetes.io/serviceaccount/secret.name"`
+	ServiceAccountName string `json:"kubernetes.io/serviceaccount/service-account.name"`
+}
+
+// verifyAPIToken determines whether a token already verified by Kubernetes is
+// a Kargo API token and, if so, rejects it if it has expired, records that it
+// has been used, and returns any scopes that narrow its permissions. A nil
+// slice of rules is returned for tokens that are not scoped or are not Kargo
+// API tokens at all.
+func (a *authMiddleware) verifyAPIToken(
+	ctx context.Context,
+	rawToken string,
+	userInfo *authnv1.UserInfo,
+) ([]rbacv1.PolicyRule, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	// Kubernetes has already verified this token, so its claims can be trusted
+	// as long as they agree with the identity Kubernetes reported for it.
+	tokenClaims := &serviceAccountTokenClaims{}
+	if _, _, err := a.parseUnverifiedJWTFn(rawToken, tokenClaims); err != nil {
+		return nil, errInvalidToken
+	}
+	if tokenClaims.SecretName == "" ||
+		userInfo.Username != fmt.Sprintf(
+			"system:serviceaccount:%s:%s",
+			tokenClaims.Namespace, tokenClaims.ServiceAccountName,
+		) {
+		// Not a token backed by a Secret, so not a Kargo API token.
+		return nil, nil
+	}
+
+	tokenSecret := &corev1.Secret{}
+	if err := a.internalClient.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: tokenClaims.Namespace,
+			Name:      tokenClaims.SecretName,
+		},
+		tokenSecret,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Kubernetes would not have authenticated a token whose Secret no
+			// longer exists, so this can only be a race with its deletion.
+			return nil, fmt.Errorf("%w: token Secret not found", errInvalidToken)
+		}
+		return nil, fmt.Errorf("get token Secret: %w", err)
+	}
+	if tokenSecret.Type != corev1.SecretTypeServiceAccountToken ||
+		tokenSecret.Labels[rbacapi.LabelKeyAPIToken] != rbacapi.LabelValueTrue {
+		return nil, nil
+	}
+
+	expiresAt, err := rbacapi.APITokenExpiryFromAnnotationValues(tokenSecret.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidToken, err)
+	}
+	now := time.Now()
+	if expiresAt != nil && !now.Before(*expiresAt) {
+		return nil, fmt.Errorf("%w: token expired at %s", errInvalidToken, expiresAt)
+	}
+
+	scopes, err := rbacapi.APITokenScopesFromAnnotationValues(tokenSecret.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidToken, err)
+	}
+
+	// Failure to record the time the token was last used is not a reason to
+	// reject a request.
+	if err = a.recordAPITokenUse(ctx, tokenSecret, now); err != nil {
+		logger.Error(
+			err, "error recording API token use",
+			"namespace", tokenSecret.Namespace,
+			"secret", tokenSecret.Name,
+		)
+	}
+
+	return scopes, nil
+}
+
+// recordAPITokenUse updates the time at which the given API token Secret was
+// last used, unless it was updated less than apiTokenLastUsedUpdateInterval
+// ago.
+func (a *authMiddleware) recordAPITokenUse(
+	ctx context.Context,
+	tokenSecret *corev1.Secret,
+	now time.Time,
+) error {
+	if lastUsedStr, ok := tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenLastUsed]; ok {
+		if lastUsed, err := time.Parse(time.RFC3339, lastUsedStr); err == nil &&
+			now.Sub(lastUsed) < apiTokenLastUsedUpdateInterval {
+			return nil
+		}
+	}
+	patch := libClient.MergeFrom(tokenSecret.DeepCopy())
+	if tokenSecret.Annotations == nil {
+		tokenSecret.Annotations = map[string]string{}
+	}
+	tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenLastUsed] = now.UTC().Format(time.RFC3339)
+	return a.internalClient.Patch(ctx, tokenSecret, patch)
+}
+
 func oidcExtractClaims(token *oidc.IDToken) (claims, error) {
 	c := claims{}
 	err := token.Claims(&c)
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -15,13 +16,20 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/require"
 	authnv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	libClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/indexer"
+	"github.com/akuity/kargo/pkg/rolemapping"
 	"github.com/akuity/kargo/pkg/server/config"
 	"github.com/akuity/kargo/pkg/server/dex"
 	libOIDC "github.com/akuity/kargo/pkg/server/oidc"
@@ -459,16 +467,59 @@ func TestAuthenticate(t *testing.T) {
 						Username: "system:serviceaccount:kargo-demo:ci-bot",
 					}, nil
 				},
+				verifyAPITokenFn: func(
+					context.Context,
+					string,
+					*authnv1.UserInfo,
+				) ([]rbacv1.PolicyRule, error) {
+					return []rbacv1.PolicyRule{{
+						APIGroups: []string{kargoapi.GroupVersion.Group},
+						Resources: []string{"stages"},
+						Verbs:     []string{"promote"},
+					}}, nil
+				},
 			},
 			token: testToken,
 			// Kubernetes recognizes the token, so we expect the raw token and the
-			// Kubernetes-verified identity to be bound to the context.
+			// Kubernetes-verified identity to be bound to the context along with
+			// any scopes of the API token.
 			assertions: func(ctx context.Context, err error) {
 				require.NoError(t, err)
 				u, ok := user.InfoFromContext(ctx)
 				require.True(t, ok)
 				require.NotNil(t, u.KubernetesUserInfo)
 				require.Equal(t, "system:serviceaccount:kargo-demo:ci-bot", u.KubernetesUserInfo.Username)
+				require.Len(t, u.APITokenScopes, 1)
+			},
+		},
+		"JWT recognized by Kubernetes is an expired API token": {
+			path: testPath,
+			authMiddleware: &authMiddleware{
+				parseUnverifiedJWTFn: func(_ string, claims jwt.Claims) (*jwt.Token, []string, error) {
+					rc, ok := claims.(*jwt.RegisteredClaims)
+					require.True(t, ok)
+					rc.Issuer = "unrecognized-issuer"
+					return nil, nil, nil
+				},
+				verifyKubernetesTokenFn: func(context.Context, string) (*authnv1.UserInfo, error) {
+					return &authnv1.UserInfo{
+						Username: "system:serviceaccount:kargo-demo:ci-bot",
+					}, nil
+				},
+				verifyAPITokenFn: func(
+					context.Context,
+					string,
+					*authnv1.UserInfo,
+				) ([]rbacv1.PolicyRule, error) {
+					return nil, fmt.Errorf("%w: token expired", errInvalidToken)
+				},
+			},
+			token: testToken,
+			assertions: func(ctx context.Context, err error) {
+				require.Error(t, err)
+				requireErrorStatus(t, err, http.StatusUnauthorized)
+				_, ok := user.InfoFromContext(ctx)
+				require.False(t, ok)
 			},
 		},
 		"unrecognized JWT not recognized by Kubernetes": {
@@ -935,3 +986,266 @@ func TestVerifyKubernetesToken(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyAPIToken(t *testing.T) {
+	const (
+		testNamespace = "kargo-demo"
+		testSAName    = "ci-bot"
+		testTokenName = "ci-token"
+	)
+	testUserInfo := &authnv1.UserInfo{
+		Username: "system:serviceaccount:" + testNamespace + ":" + testSAName,
+	}
+	newRawToken := func(t *testing.T, c jwt.Claims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("key"))
+		require.NoError(t, err)
+		return token
+	}
+	testRawToken := newRawToken(t, serviceAccountTokenClaims{
+		Namespace:          testNamespace,
+		SecretName:         testTokenName,
+		ServiceAccountName: testSAName,
+	})
+	newTokenSecret := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testNamespace,
+				Name:        testTokenName,
+				Labels:      map[string]string{rbacapi.LabelKeyAPIToken: rbacapi.LabelValueTrue},
+				Annotations: annotations,
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+	}
+	recentlyUsed := time.Now().Add(-10 * time.Second).UTC().Format(time.RFC3339)
+
+	testCases := []struct {
+		name       string
+		rawToken   string
+		objects    []libClient.Object
+		assertions func(*testing.T, libClient.Client, []rbacv1.PolicyRule, error)
+	}{
+		{
+			name: "not a Secret-backed token",
+			rawToken: newRawToken(t, jwt.RegisteredClaims{
+				Subject: testUserInfo.Username,
+			}),
+			assertions: func(t *testing.T, _ libClient.Client, scopes []rbacv1.PolicyRule, err error) {
+				require.NoError(t, err)
+				require.Nil(t, scopes)
+			},
+		},
+		{
+			name: "claims do not match verified identity",
+			rawToken: newRawToken(t, serviceAccountTokenClaims{
+				Namespace:          testNamespace,
+				SecretName:         testTokenName,
+				ServiceAccountName: "someone-else",
+			}),
+			objects: []libClient.Object{
+				newTokenSecret(map[string]string{
+					rbacapi.AnnotationKeyAPITokenExpiresAt: "2000-01-01T00:00:00Z",
+				}),
+			},
+			assertions: func(t *testing.T, _ libClient.Client, scopes []rbacv1.PolicyRule, err error) {
+				require.NoError(t, err)
+				require.Nil(t, scopes)
+			},
+		},
+		{
+			name:     "token Secret not found",
+			rawToken: testRawToken,
+			assertions: func(t *testing.T, _ libClient.Client, _ []rbacv1.PolicyRule, err error) {
+				require.ErrorIs(t, err, errInvalidToken)
+			},
+		},
+		{
+			name:     "not a Kargo API token",
+			rawToken: testRawToken,
+			objects: []libClient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      testTokenName,
+					},
+					Type: corev1.SecretTypeServiceAccountToken,
+				},
+			},
+			assertions: func(t *testing.T, c libClient.Client, scopes []rbacv1.PolicyRule, err error) {
+				require.NoError(t, err)
+				require.Nil(t, scopes)
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					t.Context(),
+					types.NamespacedName{Namespace: testNamespace, Name: testTokenName},
+					secret,
+				))
+				require.NotContains(t, secret.Annotations, rbacapi.AnnotationKeyAPITokenLastUsed)
+			},
+		},
+		{
+			name:     "token expired",
+			rawToken: testRawToken,
+			objects: []libClient.Object{
+				newTokenSecret(map[string]string{
+					rbacapi.AnnotationKeyAPITokenExpiresAt: "2000-01-01T00:00:00Z",
+				}),
+			},
+			assertions: func(t *testing.T, _ libClient.Client, _ []rbacv1.PolicyRule, err error) {
+				requireErrorStatus(t, err, http.StatusUnauthorized)
+				require.ErrorIs(t, err, errInvalidToken)
+				require.ErrorContains(t, err, "token expired")
+			},
+		},
+		{
+			name:     "unexpired scoped token",
+			rawToken: testRawToken,
+			objects: []libClient.Object{
+				newTokenSecret(map[string]string{
+					rbacapi.AnnotationKeyAPITokenExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+					rbacapi.AnnotationKeyAPITokenScopes: `[{"apiGroups":["kargo.akuity.io"],` +
+						`"resources":["stages"],"resourceNames":["uat"],"verbs":["promote"]}]`,
+				}),
+			},
+			assertions: func(t *testing.T, c libClient.Client, scopes []rbacv1.PolicyRule, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []rbacv1.PolicyRule{{
+					APIGroups:     []string{"kargo.akuity.io"},
+					Resources:     []string{"stages"},
+					ResourceNames: []string{"uat"},
+					Verbs:         []string{"promote"},
+				}}, scopes)
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					t.Context(),
+					types.NamespacedName{Namespace: testNamespace, Name: testTokenName},
+					secret,
+				))
+				lastUsed, err := time.Parse(
+					time.RFC3339,
+					secret.Annotations[rbacapi.AnnotationKeyAPITokenLastUsed],
+				)
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now(), lastUsed, time.Minute)
+			},
+		},
+		{
+			name:     "recently used token",
+			rawToken: testRawToken,
+			objects: []libClient.Object{
+				newTokenSecret(map[string]string{
+					rbacapi.AnnotationKeyAPITokenLastUsed: recentlyUsed,
+				}),
+			},
+			assertions: func(t *testing.T, c libClient.Client, scopes []rbacv1.PolicyRule, err error) {
+				require.NoError(t, err)
+				require.Nil(t, scopes)
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					t.Context(),
+					types.NamespacedName{Namespace: testNamespace, Name: testTokenName},
+					secret,
+				))
+				require.Equal(t, recentlyUsed, secret.Annotations[rbacapi.AnnotationKeyAPITokenLastUsed])
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(testCase.objects...).Build()
+			a := &authMiddleware{
+				internalClient:       c,
+				parseUnverifiedJWTFn: jwt.NewParser().ParseUnverified,
+			}
+			scopes, err := a.verifyAPIToken(t.Context(), testCase.rawToken, testUserInfo)
+			testCase.assertions(t, c, scopes, err)
+		})
+	}
+}
+
+func TestListServiceAccounts(t *testing.T) {
+	projectNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "fake-project",
+			Labels: map[string]string{kargoapi.LabelKeyProject: kargoapi.LabelValueTrue},
+		},
+	}
+	newSA := func(namespace, name string, annotations map[string]string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithObjects(
+			projectNamespace,
+			newSA("fake-project", "by-claim", map[string]string{
+				rbacapi.AnnotationKeyOIDCClaims: `{"sub":["kilgore"]}`,
+			}),
+			newSA("fake-project", "by-group", map[string]string{
+				rbacapi.AnnotationKeyRoleMapping: `{"groups":["devops"]}`,
+			}),
+			newSA("fake-project", "by-email-domain", map[string]string{
+				rbacapi.AnnotationKeyRoleMapping: `{"emailDomains":["Example.com"]}`,
+			}),
+			newSA("fake-project", "by-expression", map[string]string{
+				rbacapi.AnnotationKeyRoleMapping: `{"expressions":["claims.department == 'payments'"]}`,
+			}),
+			newSA("fake-project", "by-other-expression", map[string]string{
+				rbacapi.AnnotationKeyRoleMapping: `{"expressions":["claims.department == 'sales'"]}`,
+			}),
+			// Not in a Project namespace.
+			newSA("not-a-project", "by-group", map[string]string{
+				rbacapi.AnnotationKeyRoleMapping: `{"groups":["devops"]}`,
+			}),
+		).
+		WithIndex(
+			&corev1.ServiceAccount{},
+			indexer.ServiceAccountsByOIDCClaimsField,
+			indexer.ServiceAccountsByOIDCClaims,
+		).
+		WithIndex(
+			&corev1.ServiceAccount{},
+			indexer.ServiceAccountsByRoleMappingField,
+			indexer.ServiceAccountsByRoleMapping,
+		).
+		Build()
+	a := &authMiddleware{
+		internalClient:     c,
+		roleMappingMatcher: &rolemapping.Matcher{},
+	}
+
+	t.Run("mapped by all means", func(t *testing.T) {
+		accounts, err := a.listServiceAccounts(t.Context(), claims{
+			"sub":        "kilgore",
+			"groups":     []any{"devops"},
+			"email":      "kilgore@example.com",
+			"department": "payments",
+		})
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			map[string]map[types.NamespacedName]struct{}{
+				"fake-project": {
+					{Namespace: "fake-project", Name: "by-claim"}:        {},
+					{Namespace: "fake-project", Name: "by-group"}:        {},
+					{Namespace: "fake-project", Name: "by-email-domain"}: {},
+					{Namespace: "fake-project", Name: "by-expression"}:   {},
+				},
+			},
+			accounts,
+		)
+	})
+
+	t.Run("unverified email and missing claims", func(t *testing.T) {
+		accounts, err := a.listServiceAccounts(t.Context(), claims{
+			"email":          "kilgore@example.com",
+			"email_verified": false,
+		})
+		require.NoError(t, err)
+		require.Empty(t, accounts)
+	})
+}
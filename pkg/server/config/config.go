@@ -33,6 +33,7 @@ type ServerConfig struct {
 	TLSConfig                   *TLSConfig
 	OIDCConfig                  *oidc.Config
 	AdminConfig                 *AdminConfig
+	RateLimitConfig             *RateLimitConfig
 	DexProxyConfig              *dex.ProxyConfig
 	ArgoCDConfig                ArgoCDConfig
 	PermissiveCORSPolicyEnabled bool
@@ -78,6 +79,10 @@ func ServerConfigFromEnv() ServerConfig {
 		adminCfg := AdminConfigFromEnv()
 		cfg.AdminConfig = &adminCfg
 	}
+	if types.MustParseBool(os.GetEnv("RATE_LIMIT_ENABLED", "false")) {
+		rateLimitCfg := RateLimitConfigFromEnv()
+		cfg.RateLimitConfig = &rateLimitCfg
+	}
 	if types.MustParseBool(os.GetEnv("DEX_ENABLED", "false")) {
 		dexProxyCfg := dex.ProxyConfigFromEnv()
 		cfg.DexProxyConfig = &dexProxyCfg
@@ -167,6 +172,38 @@ func AdminConfigFromEnv() AdminConfig {
 	return cfg
 }
 
+// RateLimitConfig represents configuration for limiting the rate at which
+// each authenticated principal may make API requests. Every principal has two
+// token buckets: one for mutating requests (any method other than GET, HEAD,
+// or OPTIONS) and one for all other requests.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the rate at which each principal's bucket for
+	// non-mutating requests is refilled.
+	RequestsPerSecond float64 `envconfig:"RATE_LIMIT_REQUESTS_PER_SECOND" default:"20"`
+	// Burst is the capacity of each principal's bucket for non-mutating
+	// requests. i.e. The number of such requests a principal may make in quick
+	// succession before being throttled.
+	Burst int `envconfig:"RATE_LIMIT_BURST" default:"100"`
+	// MutatingRequestsPerSecond is the rate at which each principal's bucket
+	// for mutating requests, such as refreshes and promotions, is refilled.
+	MutatingRequestsPerSecond float64 `envconfig:"RATE_LIMIT_MUTATING_REQUESTS_PER_SECOND" default:"2"`
+	// MutatingBurst is the capacity of each principal's bucket for mutating
+	// requests.
+	MutatingBurst int `envconfig:"RATE_LIMIT_MUTATING_BURST" default:"20"`
+	// MaxPrincipals is the maximum number of principals whose buckets are
+	// tracked at once. When exceeded, the buckets of the least recently active
+	// principals are discarded.
+	MaxPrincipals int `envconfig:"RATE_LIMIT_MAX_PRINCIPALS" default:"10000"`
+}
+
+// RateLimitConfigFromEnv returns a RateLimitConfig populated from environment
+// variables.
+func RateLimitConfigFromEnv() RateLimitConfig {
+	var cfg RateLimitConfig
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
 type ArgoCDURLMap map[string]string
 
 func (a *ArgoCDURLMap) Decode(value string) error {
@@ -2,23 +2,59 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	corev1 "k8s.io/api/core/v1"
 
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
 	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/server/rbac"
 )
 
 // createAPITokenRequest is the request body for creating an API token.
 type createAPITokenRequest struct {
 	Name string `json:"name"`
+	// TTL is the length of time, expressed as a Go duration string (e.g. "24h"),
+	// for which the token remains valid. If unspecified, the token never
+	// expires.
+	TTL string `json:"ttl,omitempty"`
+	// Scopes, if specified, narrow the permissions conferred by the token to
+	// those that are both held by the role and described by at least one scope.
+	Scopes []rbacapi.ResourceDetails `json:"scopes,omitempty"`
 } // @name CreateAPITokenRequest
 
+// apiTokenOptions validates the optional fields of the request and returns
+// them as rbac.APITokenOptions.
+func (r createAPITokenRequest) apiTokenOptions() (*rbac.APITokenOptions, error) {
+	opts := &rbac.APITokenOptions{Scopes: r.Scopes}
+	if r.TTL != "" {
+		ttl, err := time.ParseDuration(r.TTL)
+		if err != nil {
+			return nil, libhttp.Error(
+				fmt.Errorf("invalid ttl %q: %w", r.TTL, err),
+				http.StatusBadRequest,
+			)
+		}
+		if ttl <= 0 {
+			return nil, libhttp.Error(
+				errors.New("ttl must be positive"),
+				http.StatusBadRequest,
+			)
+		}
+		opts.TTL = ttl
+	}
+	return opts, nil
+}
+
 // @id CreateProjectAPIToken
 // @Summary Create a project-level API token
 // @Description Create a project-level API token associated with a Kargo Role
-// @Description virtual resource. Returns a Kubernetes Secret resource
+// @Description virtual resource. The token may optionally be given a TTL and
+// @Description scopes that narrow its permissions to a subset of the Role's.
+// @Description Returns a Kubernetes Secret resource
 // @Description representing the token. Store it securely. The token is not
 // @Description retrievable via the Kargo API after creation except in a
 // @Description redacted form.
@@ -48,9 +84,15 @@ func (s *server) createProjectAPIToken(c *gin.Context) {
 		return
 	}
 
+	opts, err := req.apiTokenOptions()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	var tokenSecret *corev1.Secret
-	tokenSecret, err := s.rolesDB.CreateAPIToken(
-		ctx, false, project, role, req.Name,
+	tokenSecret, err = s.rolesDB.CreateAPIToken(
+		ctx, false, project, role, req.Name, opts,
 	)
 	if err != nil {
 		_ = c.Error(err)
@@ -63,7 +105,9 @@ func (s *server) createProjectAPIToken(c *gin.Context) {
 // @id CreateSystemAPIToken
 // @Summary Create a system-level API token
 // @Description Create a system-level API token associated with a system-level
-// @Description Kargo Role virtual resource. Returns a Kubernetes Secret
+// @Description Kargo Role virtual resource. The token may optionally be given
+// @Description a TTL and scopes that narrow its permissions to a subset of the
+// @Description Role's. Returns a Kubernetes Secret
 // @Description resource representing the token. Store it securely. The token
 // @Description is not retrievable via the Kargo API after creation except in
 // @Description a redacted form.
@@ -91,8 +135,14 @@ func (s *server) createSystemAPIToken(c *gin.Context) {
 		return
 	}
 
+	opts, err := req.apiTokenOptions()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	tokenSecret, err := s.rolesDB.CreateAPIToken(
-		ctx, true, "", role, req.Name,
+		ctx, true, "", role, req.Name, opts,
 	)
 	if err != nil {
 		_ = c.Error(err)
@@ -69,6 +69,28 @@ func Test_server_createProjectAPIToken(t *testing.T) {
 					require.Equal(t, http.StatusBadRequest, w.Code)
 				},
 			},
+			{
+				name: "invalid ttl in request body",
+				body: mustJSONBody(createAPITokenRequest{
+					Name: testToken.Name,
+					TTL:  "forever",
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "non-positive ttl in request body",
+				body: mustJSONBody(createAPITokenRequest{
+					Name: testToken.Name,
+					TTL:  "-1h",
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
 			{
 				name: "ServiceAccount does not exist",
 				body: mustJSONBody(createAPITokenRequest{
@@ -191,6 +213,26 @@ func Test_server_createSystemAPIToken(t *testing.T) {
 					require.Equal(t, http.StatusBadRequest, w.Code)
 				},
 			},
+			{
+				name: "invalid ttl in request body",
+				body: mustJSONBody(createAPITokenRequest{
+					Name: testToken.Name,
+					TTL:  "forever",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "non-positive ttl in request body",
+				body: mustJSONBody(createAPITokenRequest{
+					Name: testToken.Name,
+					TTL:  "-1h",
+				}),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
 			{
 				name: "ServiceAccount does not exist",
 				body: mustJSONBody(createAPITokenRequest{
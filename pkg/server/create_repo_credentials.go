@@ -147,9 +147,11 @@ func (s *server) validateRESTCreateRepoCredentialsRequest(
 	switch req.Type {
 	case kargoapi.LabelValueCredentialTypeGit,
 		kargoapi.LabelValueCredentialTypeHelm,
-		kargoapi.LabelValueCredentialTypeImage:
+		kargoapi.LabelValueCredentialTypeImage,
+		kargoapi.LabelValueCredentialTypeHTTP,
+		kargoapi.LabelValueCredentialTypePackage:
 	default:
-		return errors.New("type should be one of git, helm, or image")
+		return errors.New("type should be one of git, helm, image, http, or package")
 	}
 	if req.RepoURL == "" {
 		return errors.New("repoURL should not be empty")
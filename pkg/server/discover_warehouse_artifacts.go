@@ -0,0 +1,95 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/types"
+	sigyaml "sigs.k8s.io/yaml"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/warehouses"
+	libhttp "github.com/akuity/kargo/pkg/http"
+)
+
+// @id DiscoverWarehouseArtifacts
+// @Summary Dry run artifact discovery for a Warehouse
+// @Description Discover artifacts using the subscriptions of the Warehouse
+// @Description described by the provided YAML or JSON manifest without creating
+// @Description or updating the Warehouse and without creating any Freight. The
+// @Description Warehouse need not exist. The result includes the artifacts
+// @Description that would be discovered, why each filtered-out candidate was
+// @Description filtered out, and the Freight that would be created.
+// @Tags Core, Project-Level
+// @Security BearerAuth
+// @Accept text/plain
+// @Produce json
+// @Param project path string true "Project name"
+// @Param manifest body string true "YAML or JSON Warehouse manifest"
+// @Success 200 {object} warehouses.DryRunResult
+// @Router /v1beta1/projects/{project}/warehouses/discover [post]
+func (s *server) discoverWarehouseArtifacts(c *gin.Context) {
+	ctx := c.Request.Context()
+	project := c.Param("project")
+
+	// Note that there's middleware in place that limits the body size, which is
+	// why we're not defensive about that here.
+	manifest, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(libhttp.Error(err, http.StatusBadRequest))
+		return
+	}
+
+	warehouse := &kargoapi.Warehouse{}
+	if err = sigyaml.Unmarshal(manifest, warehouse); err != nil {
+		_ = c.Error(libhttp.Error(
+			fmt.Errorf("error parsing Warehouse manifest: %w", err),
+			http.StatusBadRequest,
+		))
+		return
+	}
+	if warehouse.Kind != "" && warehouse.Kind != "Warehouse" {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf("expected a Warehouse manifest; got a %s manifest", warehouse.Kind),
+			http.StatusBadRequest,
+		))
+		return
+	}
+	if warehouse.Namespace != "" && warehouse.Namespace != project {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf(
+				"Warehouse namespace %q does not match project %q",
+				warehouse.Namespace, project,
+			),
+			http.StatusBadRequest,
+		))
+		return
+	}
+	warehouse.Namespace = project
+
+	// Discovery makes use of the Project's credentials, so it requires the same
+	// permission as creating the Warehouse would.
+	if err = s.authorizeFn(
+		ctx,
+		"create",
+		kargoapi.GroupVersion.WithResource("warehouses"),
+		"",
+		types.NamespacedName{
+			Namespace: project,
+			Name:      warehouse.Name,
+		},
+	); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var res *warehouses.DryRunResult
+	if res, err = s.discoverWarehouseArtifactsFn(ctx, warehouse); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
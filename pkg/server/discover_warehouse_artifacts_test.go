@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/warehouses"
+	"github.com/akuity/kargo/pkg/server/config"
+)
+
+func Test_server_discoverWarehouseArtifacts(t *testing.T) {
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	const manifest = `apiVersion: kargo.akuity.io/v1alpha1
+kind: Warehouse
+metadata:
+  name: fake-warehouse
+spec:
+  subscriptions:
+  - image:
+      repoURL: example/image
+      semverConstraint: ^1.0.0
+`
+	testRESTEndpoint(
+		t, &config.ServerConfig{},
+		http.MethodPost,
+		"/v1beta1/projects/"+testProject.Name+"/warehouses/discover",
+		[]restTestCase{
+			{
+				name:          "invalid manifest",
+				body:          strings.NewReader("{"),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name:          "not a Warehouse",
+				body:          strings.NewReader("kind: Stage\nmetadata:\n  name: fake-stage\n"),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "namespace mismatch",
+				body: strings.NewReader(
+					strings.Replace(manifest, "  name: fake-warehouse\n", "  name: x\n  namespace: other\n", 1),
+				),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name:          "invalid Warehouse",
+				body:          strings.NewReader(manifest),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				serverSetup: func(_ *testing.T, s *server) {
+					s.discoverWarehouseArtifactsFn = func(
+						context.Context,
+						*kargoapi.Warehouse,
+					) (*warehouses.DryRunResult, error) {
+						return nil, apierrors.NewInvalid(
+							schema.GroupKind{Group: kargoapi.GroupVersion.Group, Kind: "Warehouse"},
+							"fake-warehouse",
+							field.ErrorList{field.Invalid(field.NewPath("spec"), "", "bogus")},
+						)
+					}
+				},
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+				},
+			},
+			{
+				name:          "success",
+				body:          strings.NewReader(manifest),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				serverSetup: func(t *testing.T, s *server) {
+					s.discoverWarehouseArtifactsFn = func(
+						_ context.Context,
+						warehouse *kargoapi.Warehouse,
+					) (*warehouses.DryRunResult, error) {
+						require.Equal(t, testProject.Name, warehouse.Namespace)
+						require.Equal(t, "fake-warehouse", warehouse.Name)
+						require.Len(t, warehouse.Spec.InternalSubscriptions, 1)
+						require.Equal(
+							t,
+							"example/image",
+							warehouse.Spec.InternalSubscriptions[0].Image.RepoURL,
+						)
+						return &warehouses.DryRunResult{
+							Subscriptions: []warehouses.DryRunSubscription{{
+								Type:       "image",
+								RepoURL:    "example/image",
+								Discovered: 1,
+							}},
+							FreightCreationCriteriaSatisfied: true,
+						}, nil
+					}
+				},
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					res := &warehouses.DryRunResult{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+					require.True(t, res.FreightCreationCriteriaSatisfied)
+					require.Len(t, res.Subscriptions, 1)
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/freightdiff"
+	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+// freightDiffCommitListingTimeout bounds the time spent cloning repositories
+// and listing commits while comparing two pieces of Freight. If it elapses,
+// the diff is still returned, but without the commits that could not be
+// listed.
+const freightDiffCommitListingTimeout = time.Minute
+
+// @id GetFreightDiff
+// @Summary Compare a Freight resource to another
+// @Description Compare a Freight resource to either another Freight resource or
+// @Description the Freight currently in a Stage. The comparison includes the
+// @Description Git commits added and removed between the referenced commits,
+// @Description changed image tags and digests, chart versions, and artifact
+// @Description versions. Exactly one of the from and stage query parameters
+// @Description must be specified.
+// @Tags Core, Project-Level
+// @Security BearerAuth
+// @Produce json
+// @Param project path string true "Project name"
+// @Param freight-name-or-alias path string true "Freight name or alias"
+// @Param from query string false "Name or alias of the Freight to compare against"
+// @Param stage query string false "Name of the Stage whose current Freight should be compared against"
+// @Success 200 {object} freightdiff.Diff
+// @Router /v1beta1/projects/{project}/freight/{freight-name-or-alias}/diff [get]
+func (s *server) getFreightDiff(c *gin.Context) {
+	ctx := c.Request.Context()
+	project := c.Param("project")
+	nameOrAlias := c.Param("freight-name-or-alias")
+	fromNameOrAlias := c.Query("from")
+	stageName := c.Query("stage")
+
+	if (fromNameOrAlias == "") == (stageName == "") {
+		_ = c.Error(libhttp.ErrorStr(
+			"exactly one of from or stage must be specified",
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	to := s.getFreightByNameOrAliasForGin(c, project, nameOrAlias)
+	if to == nil {
+		return
+	}
+
+	if stageName != "" {
+		stage := &kargoapi.Stage{}
+		if err := s.client.Get(
+			ctx,
+			client.ObjectKey{Name: stageName, Namespace: project},
+			stage,
+		); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		// Compare against whatever Freight from the same origin the Stage
+		// currently has
+		var current kargoapi.FreightReference
+		if col := stage.Status.FreightHistory.Current(); col != nil {
+			current = col.Freight[to.Origin.String()]
+		}
+		if fromNameOrAlias = current.Name; fromNameOrAlias == "" {
+			_ = c.Error(libhttp.ErrorStr(
+				fmt.Sprintf(
+					"Stage %q does not currently have any Freight from %s",
+					stageName, to.Origin.String(),
+				),
+				http.StatusNotFound,
+			))
+			return
+		}
+	}
+
+	from := s.getFreightByNameOrAliasForGin(c, project, fromNameOrAlias)
+	if from == nil {
+		return
+	}
+
+	opts := &freightdiff.Options{CommitURL: freightdiff.GitProviderCommitURL}
+	computeCtx := ctx
+	if s.newCommitListerFn != nil && len(to.Commits) > 0 {
+		var cancel context.CancelFunc
+		computeCtx, cancel = context.WithTimeout(ctx, freightDiffCommitListingTimeout)
+		defer cancel()
+		lister := s.newCommitListerFn(project)
+		defer func() {
+			if err := lister.Close(ctx); err != nil {
+				logging.LoggerFromContext(ctx).Error(
+					err, "error cleaning up after listing commits",
+				)
+			}
+		}()
+		opts.ListCommits = lister.ListCommits
+	}
+
+	c.JSON(http.StatusOK, freightdiff.Compute(computeCtx, from, to, opts))
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/controller/git"
+	"github.com/akuity/kargo/pkg/freightdiff"
+	"github.com/akuity/kargo/pkg/server/config"
+)
+
+type mockCommitLister struct {
+	commits []git.CommitMetadata
+	closed  bool
+}
+
+func (m *mockCommitLister) ListCommits(
+	context.Context,
+	string,
+	string,
+	string,
+	uint,
+) ([]git.CommitMetadata, error) {
+	return m.commits, nil
+}
+
+func (m *mockCommitLister) Close(context.Context) error {
+	m.closed = true
+	return nil
+}
+
+func Test_server_getFreightDiff(t *testing.T) {
+	const repoURL = "https://github.com/example/repo"
+	const oldID = "1111111111111111111111111111111111111111"
+	const newID = "2222222222222222222222222222222222222222"
+
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	origin := kargoapi.FreightOrigin{
+		Kind: kargoapi.FreightOriginKindWarehouse,
+		Name: "fake-warehouse",
+	}
+	oldFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "old-freight",
+		},
+		Origin:  origin,
+		Commits: []kargoapi.GitCommit{{RepoURL: repoURL, ID: oldID}},
+		Images:  []kargoapi.Image{{RepoURL: "example/app", Tag: "v1.0.0"}},
+	}
+	newFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "new-freight",
+			Labels: map[string]string{
+				kargoapi.LabelKeyAlias: "new-alias",
+			},
+		},
+		Alias:   "new-alias",
+		Origin:  origin,
+		Commits: []kargoapi.GitCommit{{RepoURL: repoURL, ID: newID}},
+		Images:  []kargoapi.Image{{RepoURL: "example/app", Tag: "v1.1.0"}},
+	}
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "fake-stage",
+		},
+		Status: kargoapi.StageStatus{
+			FreightHistory: kargoapi.FreightHistory{{
+				Freight: map[string]kargoapi.FreightReference{
+					origin.String(): {Name: oldFreight.Name, Origin: origin},
+				},
+			}},
+		},
+	}
+
+	basePath := "/v1beta1/projects/" + testProject.Name + "/freight/" + newFreight.Name + "/diff"
+
+	testRESTEndpoint(
+		t, &config.ServerConfig{},
+		http.MethodGet,
+		basePath+"?from="+oldFreight.Name,
+		[]restTestCase{
+			{
+				name: "project does not exist",
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name:          "neither from nor stage specified",
+				url:           basePath,
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name:          "both from and stage specified",
+				url:           basePath + "?from=" + oldFreight.Name + "&stage=" + testStage.Name,
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name:          "freight does not exist",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, oldFreight),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name:          "from freight does not exist",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, newFreight),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name:          "stage does not exist",
+				url:           basePath + "?stage=" + testStage.Name,
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, newFreight),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name: "stage has no freight from the same origin",
+				url:  basePath + "?stage=" + testStage.Name,
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					newFreight,
+					&kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: testProject.Name,
+							Name:      testStage.Name,
+						},
+					},
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name: "compares against another freight",
+				url: "/v1beta1/projects/" + testProject.Name + "/freight/" + newFreight.Alias +
+					"/diff?from=" + oldFreight.Name,
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					oldFreight,
+					newFreight,
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					diff := &freightdiff.Diff{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), diff))
+					require.Equal(t, oldFreight.Name, diff.From)
+					require.Equal(t, newFreight.Name, diff.To)
+					require.Len(t, diff.Commits, 1)
+					require.Equal(t, repoURL+"/commit/"+newID, diff.Commits[0].To.URL)
+					require.Equal(t, []freightdiff.ImageChange{{
+						RepoURL: "example/app",
+						Type:    freightdiff.ChangeTypeChanged,
+						FromTag: "v1.0.0",
+						ToTag:   "v1.1.0",
+					}}, diff.Images)
+				},
+			},
+			{
+				name: "compares against the freight currently in a stage",
+				url:  basePath + "?stage=" + testStage.Name,
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					oldFreight,
+					newFreight,
+					testStage,
+				),
+				serverSetup: func(t *testing.T, s *server) {
+					lister := &mockCommitLister{
+						commits: []git.CommitMetadata{{ID: newID, Subject: "Fix bug"}},
+					}
+					s.newCommitListerFn = func(project string) commitLister {
+						require.Equal(t, testProject.Name, project)
+						return lister
+					}
+					t.Cleanup(func() {
+						require.True(t, lister.closed)
+					})
+				},
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					diff := &freightdiff.Diff{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), diff))
+					require.Equal(t, oldFreight.Name, diff.From)
+					require.Len(t, diff.Commits, 1)
+					require.Len(t, diff.Commits[0].Added, 1)
+					require.Equal(t, "Fix bug", diff.Commits[0].Added[0].Subject)
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/lineage"
+)
+
+// @id GetLineage
+// @Summary Retrieve Freight lineage
+// @Description Retrieve a graph describing which Warehouses produced the
+// @Description selected Freight and which Stages that Freight has since been
+// @Description approved for, promoted to, verified in, or used by. Freight may
+// @Description be selected by Git commit SHA, container image digest, or Helm
+// @Description chart version. If no criteria are specified, all Freight in the
+// @Description project is selected.
+// @Tags Core, Project-Level
+// @Security BearerAuth
+// @Produce json
+// @Param project path string true "Project name"
+// @Param commit query string false "Git commit SHA or prefix thereof"
+// @Param digest query string false "Container image digest"
+// @Param chartVersion query string false "Helm chart version"
+// @Success 200 {object} lineage.Graph
+// @Router /v1beta1/projects/{project}/lineage [get]
+func (s *server) getLineage(c *gin.Context) {
+	ctx := c.Request.Context()
+	project := c.Param("project")
+
+	query := lineage.Query{
+		Commit:       c.Query("commit"),
+		Digest:       c.Query("digest"),
+		ChartVersion: c.Query("chartVersion"),
+	}
+
+	freight := &kargoapi.FreightList{}
+	if err := s.client.List(ctx, freight, client.InNamespace(project)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	stages := &kargoapi.StageList{}
+	if err := s.client.List(ctx, stages, client.InNamespace(project)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	promos := &kargoapi.PromotionList{}
+	if err := s.client.List(ctx, promos, client.InNamespace(project)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(
+		http.StatusOK,
+		lineage.Build(query, freight.Items, stages.Items, promos.Items),
+	)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/lineage"
+	"github.com/akuity/kargo/pkg/server/config"
+)
+
+func Test_server_getLineage(t *testing.T) {
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	origin := kargoapi.FreightOrigin{
+		Kind: kargoapi.FreightOriginKindWarehouse,
+		Name: "fake-warehouse",
+	}
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "fake-freight",
+		},
+		Origin: origin,
+		Images: []kargoapi.Image{{RepoURL: "example/image", Digest: "sha256:abc"}},
+		Status: kargoapi.FreightStatus{
+			CurrentlyIn: map[string]kargoapi.CurrentStage{"fake-stage": {}},
+		},
+	}
+	otherFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "other-freight",
+		},
+		Origin: origin,
+		Images: []kargoapi.Image{{RepoURL: "example/image", Digest: "sha256:def"}},
+	}
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "fake-stage",
+		},
+	}
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "fake-promotion",
+		},
+		Spec: kargoapi.PromotionSpec{
+			Stage:   testStage.Name,
+			Freight: testFreight.Name,
+		},
+		Status: kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseSucceeded},
+	}
+
+	testRESTEndpoint(
+		t, &config.ServerConfig{},
+		http.MethodGet, "/v1beta1/projects/"+testProject.Name+"/lineage",
+		[]restTestCase{
+			{
+				name: "Project does not exist",
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name:          "no Freight",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					graph := &lineage.Graph{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), graph))
+					require.Empty(t, graph.Nodes)
+					require.Empty(t, graph.Edges)
+				},
+			},
+			{
+				name: "query by digest",
+				url:  "/v1beta1/projects/" + testProject.Name + "/lineage?digest=sha256:abc",
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testFreight,
+					otherFreight,
+					testStage,
+					testPromo,
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					graph := &lineage.Graph{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), graph))
+					require.Len(t, graph.Nodes, 3)
+					require.Equal(t, "fake-warehouse", graph.Nodes[0].Name)
+					require.Equal(t, "fake-freight", graph.Nodes[1].Name)
+					require.Equal(t, "fake-stage", graph.Nodes[2].Name)
+					require.Len(t, graph.Edges, 2)
+					require.Equal(t, lineage.EdgeTypeDelivered, graph.Edges[0].Type)
+					require.True(t, graph.Edges[0].CurrentlyIn)
+					require.Equal(
+						t,
+						[]lineage.Promotion{{
+							Name:  testPromo.Name,
+							Phase: kargoapi.PromotionPhaseSucceeded,
+						}},
+						graph.Edges[0].Promotions,
+					)
+				},
+			},
+		},
+	)
+}
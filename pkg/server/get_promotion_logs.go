@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
+)
+
+// promotionLogPollInterval is how often the output captured from a Promotion's
+// steps is checked for new Chunks when following a Promotion's logs.
+const promotionLogPollInterval = 2 * time.Second
+
+// promotionStepLog is a chunk of output captured from a single execution of a
+// single step of a Promotion, as sent to clients streaming a Promotion's logs.
+type promotionStepLog struct {
+	StepIndex  int64     `json:"stepIndex"`
+	Alias      string    `json:"alias"`
+	Log        string    `json:"log"`
+	Truncated  bool      `json:"truncated,omitempty"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
+
+// @id GetPromotionLogs
+// @Summary Stream Promotion step logs
+// @Description Stream output captured from the steps of a Promotion as
+// @Description Server-Sent Events (SSE). Each event is a JSON object holding
+// @Description the output of a single execution of a single step. When
+// @Description following, the stream remains open until the Promotion reaches
+// @Description a terminal phase.
+// @Tags Core, Project-Level
+// @Security BearerAuth
+// @Param project path string true "Project name"
+// @Param promotion path string true "Promotion name"
+// @Param step query string false "Step alias"
+// @Param follow query boolean false "Follow the logs until the Promotion completes"
+// @Produce text/event-stream
+// @Success 200 {string} string "Log stream (SSE)"
+// @Router /v1beta1/projects/{project}/promotions/{promotion}/logs [get]
+func (s *server) getPromotionLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := logging.LoggerFromContext(ctx)
+
+	project := c.Param("project")
+	name := c.Param("promotion")
+	stepAlias := c.Query("step")
+	follow := c.Query("follow") == trueStr
+
+	// Retrieving the Promotion through the authorizing client confirms the
+	// user is permitted to view it, and by extension, its logs.
+	promo := &kargoapi.Promotion{}
+	if err := s.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: project, Name: name},
+		promo,
+	); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if stepAlias != "" && !promotionHasStep(promo, stepAlias) {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf(
+				"Promotion %q in namespace %q has no step with alias %q",
+				name, project, stepAlias,
+			),
+			http.StatusNotFound,
+		))
+		return
+	}
+
+	// The ConfigMaps holding step output are not subject to Kargo's RBAC, so
+	// they are read using the internal, uncached reader.
+	store := steplog.NewConfigMapStore(s.client.InternalClient(), s.client.APIReader())
+
+	SetSSEHeaders(c)
+
+	sent := map[promotionStepLogKey]struct{}{}
+	if !s.sendPromotionLogs(c, store, promo, stepAlias, sent) || !follow {
+		return
+	}
+
+	pollTicker := time.NewTicker(promotionLogPollInterval)
+	defer pollTicker.Stop()
+	keepaliveTicker := time.NewTicker(30 * time.Second)
+	defer keepaliveTicker.Stop()
+
+	for !promo.Status.Phase.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			logger.Debug("context done", "error", ctx.Err())
+			return
+
+		case <-keepaliveTicker.C:
+			if !WriteSSEKeepalive(c) {
+				return
+			}
+
+		case <-pollTicker.C:
+			if err := s.client.Get(
+				ctx,
+				client.ObjectKey{Namespace: project, Name: name},
+				promo,
+			); err != nil {
+				SendSSEWatchError(c, err)
+				return
+			}
+			if !s.sendPromotionLogs(c, store, promo, stepAlias, sent) {
+				return
+			}
+		}
+	}
+}
+
+// promotionStepLogKey uniquely identifies a Chunk of output captured from a
+// Promotion's steps.
+type promotionStepLogKey struct {
+	stepIndex  int64
+	capturedAt time.Time
+}
+
+// sendPromotionLogs sends, as SSE events, all Chunks of output captured from the
+// provided Promotion's steps that have not already been sent. If stepAlias is
+// non-empty, only Chunks captured from the step with that alias are sent. It
+// returns false if streaming should be terminated.
+func (s *server) sendPromotionLogs(
+	c *gin.Context,
+	store steplog.Store,
+	promo *kargoapi.Promotion,
+	stepAlias string,
+	sent map[promotionStepLogKey]struct{},
+) bool {
+	ctx := c.Request.Context()
+	logger := logging.LoggerFromContext(ctx)
+
+	chunks, err := store.List(ctx, promo)
+	if err != nil {
+		logger.Error(err, "error listing Promotion step logs")
+		SendSSEWatchError(c, err)
+		return false
+	}
+	for _, chunk := range chunks {
+		if stepAlias != "" && chunk.Alias != stepAlias {
+			continue
+		}
+		key := promotionStepLogKey{
+			stepIndex:  chunk.StepIndex,
+			capturedAt: chunk.CapturedAt,
+		}
+		if _, ok := sent[key]; ok {
+			continue
+		}
+		sent[key] = struct{}{}
+		if !sendPromotionStepLog(ctx, c, chunk) {
+			return false
+		}
+	}
+	return true
+}
+
+// sendPromotionStepLog sends the provided Chunk as an SSE event. It returns
+// false if the write failed.
+func sendPromotionStepLog(ctx context.Context, c *gin.Context, chunk steplog.Chunk) bool {
+	logger := logging.LoggerFromContext(ctx)
+	data, err := json.Marshal(promotionStepLog{
+		StepIndex:  chunk.StepIndex,
+		Alias:      chunk.Alias,
+		Log:        string(chunk.Data),
+		Truncated:  chunk.Truncated,
+		CapturedAt: chunk.CapturedAt,
+	})
+	if err != nil {
+		logger.Error(err, "failed to marshal step log")
+		return true
+	}
+	if _, err = fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+		logger.Debug("failed to write step log", "error", err)
+		return false
+	}
+	c.Writer.Flush()
+	return true
+}
+
+// promotionHasStep returns true if the provided Promotion has a step with the
+// provided alias.
+func promotionHasStep(promo *kargoapi.Promotion, alias string) bool {
+	for i := range promo.Spec.Steps {
+		if promo.Spec.Steps[i].GetAlias(i) == alias {
+			return true
+		}
+	}
+	return false
+}
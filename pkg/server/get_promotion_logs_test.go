@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion/steplog"
+	"github.com/akuity/kargo/pkg/server/config"
+)
+
+func Test_server_getPromotionLogs(t *testing.T) {
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject.Name,
+			Name:      "fake-promotion",
+			UID:       "fake-uid",
+		},
+		Spec: kargoapi.PromotionSpec{
+			Steps: []kargoapi.PromotionStep{
+				{Uses: "git-clone", As: "clone"},
+				{Uses: "helm-template", As: "render"},
+			},
+		},
+		Status: kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhaseErrored,
+		},
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	appendLogs := func(t *testing.T, s *server) {
+		store := steplog.NewConfigMapStore(s.client.InternalClient(), s.client.APIReader())
+		for _, chunk := range []steplog.Chunk{
+			{StepIndex: 0, Alias: "clone", Data: []byte("cloned\n"), CapturedAt: start},
+			{
+				StepIndex:  1,
+				Alias:      "render",
+				Data:       []byte("Error: value is required\n"),
+				CapturedAt: start.Add(time.Second),
+			},
+		} {
+			require.NoError(t, store.Append(t.Context(), testPromo, chunk))
+		}
+	}
+	parseEvents := func(t *testing.T, body string) []promotionStepLog {
+		var logs []promotionStepLog
+		for _, event := range strings.Split(body, "\n\n") {
+			data, ok := strings.CutPrefix(event, "data: ")
+			if !ok {
+				continue
+			}
+			var log promotionStepLog
+			require.NoError(t, json.Unmarshal([]byte(data), &log))
+			logs = append(logs, log)
+		}
+		return logs
+	}
+
+	testRESTEndpoint(
+		t, &config.ServerConfig{},
+		http.MethodGet, "/v1beta1/projects/"+testProject.Name+"/promotions/"+testPromo.Name+"/logs",
+		[]restTestCase{
+			{
+				name:          "Promotion does not exist",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name: "step does not exist",
+				url: "/v1beta1/projects/" + testProject.Name + "/promotions/" +
+					testPromo.Name + "/logs?step=bogus",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, testPromo),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name:          "streams logs for all steps",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, testPromo),
+				serverSetup:   appendLogs,
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+					logs := parseEvents(t, w.Body.String())
+					require.Len(t, logs, 2)
+					require.Equal(t, "clone", logs[0].Alias)
+					require.Equal(t, "cloned\n", logs[0].Log)
+					require.Equal(t, "render", logs[1].Alias)
+					require.Equal(t, int64(1), logs[1].StepIndex)
+					require.Equal(t, "Error: value is required\n", logs[1].Log)
+				},
+			},
+			{
+				name: "streams logs for a single step",
+				url: "/v1beta1/projects/" + testProject.Name + "/promotions/" +
+					testPromo.Name + "/logs?step=render&follow=true",
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject, testPromo),
+				serverSetup:   appendLogs,
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					// The Promotion is already in a terminal phase, so following
+					// should not block.
+					require.Equal(t, http.StatusOK, w.Code)
+					logs := parseEvents(t, w.Body.String())
+					require.Len(t, logs, 1)
+					require.Equal(t, "render", logs[0].Alias)
+				},
+			},
+		},
+	)
+}
@@ -91,15 +91,16 @@ func (s *server) getSharedRepoCredentials(c *gin.Context) {
 // redacted because AT LEAST "last-applied-configuration" is a known vector for
 // leaking sensitive information and unknown configuration management tools may
 // use other annotations in a manner similar to "last-applied-configuration".
-// There is no concern over labels because the constraints on label values rule
-// out use in a manner similar to that of the "last-applied-configuration"
-// annotation.
+// Exceptions are made only for annotations known to be written by Kargo itself
+// and to contain no sensitive information. There is no concern over labels
+// because the constraints on label values rule out use in a manner similar to
+// that of the "last-applied-configuration" annotation.
 func sanitizeCredentialSecret(secret corev1.Secret) *corev1.Secret {
 	s := secret.DeepCopy()
 	s.StringData = make(map[string]string, len(s.Data))
 	for k, v := range s.Annotations {
 		switch k {
-		case kargoapi.AnnotationKeyDescription:
+		case kargoapi.AnnotationKeyDescription, kargoapi.AnnotationKeyCredentialHealth:
 			s.Annotations[k] = v
 		default:
 			s.Annotations[k] = redacted
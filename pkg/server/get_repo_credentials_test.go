@@ -169,7 +169,8 @@ func TestSanitizeCredentialSecret(t *testing.T) {
 	creds := corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Annotations: map[string]string{
-				"last-applied-configuration": "fake-configuration",
+				"last-applied-configuration":           "fake-configuration",
+				kargoapi.AnnotationKeyCredentialHealth: "fake-health",
 			},
 		},
 		Data: map[string][]byte{
@@ -183,7 +184,8 @@ func TestSanitizeCredentialSecret(t *testing.T) {
 	require.Equal(
 		t,
 		map[string]string{
-			"last-applied-configuration": redacted,
+			"last-applied-configuration":           redacted,
+			kargoapi.AnnotationKeyCredentialHealth: "fake-health",
 		},
 		sanitizedCreds.Annotations,
 	)
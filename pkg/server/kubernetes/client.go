@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	authnv1 "k8s.io/api/authentication/v1"
@@ -334,6 +335,14 @@ func newDefaultCluster(
 	); err != nil {
 		return nil, fmt.Errorf("index ServiceAccounts by OIDC claims: %w", err)
 	}
+	if err = cluster.GetFieldIndexer().IndexField(
+		ctx,
+		&corev1.ServiceAccount{},
+		indexer.ServiceAccountsByRoleMappingField,
+		indexer.ServiceAccountsByRoleMapping,
+	); err != nil {
+		return nil, fmt.Errorf("index ServiceAccounts by role mapping: %w", err)
+	}
 	if err = cluster.GetFieldIndexer().IndexField(
 		ctx,
 		&corev1.Event{},
@@ -931,7 +940,13 @@ func getAuthorizedClient(globalServiceAccountNamespaces []string) func(
 		}
 
 		// If we get to here, we're dealing with a user whose token was recognized
-		// by the Kubernetes API server, which told us who they are.
+		// by the Kubernetes API server, which told us who they are. If that token
+		// is a Kargo API token that was scoped more narrowly than the role it is
+		// associated with, the operation must fall within those scopes before the
+		// role's own permissions are even considered.
+		if userInfo.APITokenScopes != nil && !scopesPermit(userInfo.APITokenScopes, ra) {
+			return nil, newForbiddenError(ra)
+		}
 		if err := reviewSubjectAccess(
 			ctx,
 			internalClient,
@@ -944,6 +959,31 @@ func getAuthorizedClient(globalServiceAccountNamespaces []string) func(
 	}
 }
 
+// scopesPermit returns true if at least one of the provided rules permits the
+// operation described by the provided ResourceAttributes. Rules are matched
+// the same way Kubernetes RBAC matches them.
+func scopesPermit(rules []rbacv1.PolicyRule, ra authv1.ResourceAttributes) bool {
+	resource := ra.Resource
+	if ra.Subresource != "" {
+		resource = ra.Resource + "/" + ra.Subresource
+	}
+	for _, rule := range rules {
+		if ruleMatches(rule.Verbs, ra.Verb) &&
+			ruleMatches(rule.APIGroups, ra.Group) &&
+			ruleMatches(rule.Resources, resource) &&
+			(len(rule.ResourceNames) == 0 || slices.Contains(rule.ResourceNames, ra.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleMatches returns true if the provided values include the provided value
+// or the wildcard.
+func ruleMatches(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, rbacv1.ResourceAll)
+}
+
 // reviewSubject is the identity whose access is reviewed by
 // reviewSubjectAccess.
 type reviewSubject struct {
@@ -9,6 +9,7 @@ import (
 	authnv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/server/user"
 )
 
@@ -493,6 +495,22 @@ func TestGetAuthorizedClient(t *testing.T) {
 				require.True(t, apierrors.IsForbidden(err))
 			},
 		},
+		{
+			// The role permits the operation, but the API token's scopes do not.
+			name:           "Kubernetes-verified user, outside API token scopes",
+			internalClient: reviewingClient(t, true),
+			userInfo: &user.Info{
+				KubernetesUserInfo: &testKubernetesUser,
+				APITokenScopes: []rbacv1.PolicyRule{{
+					APIGroups: []string{kargoapi.GroupVersion.Group},
+					Resources: []string{"stages"},
+					Verbs:     []string{"promote"},
+				}},
+			},
+			assert: func(t *testing.T, _ libClient.Client, err error) {
+				require.True(t, apierrors.IsForbidden(err))
+			},
+		},
 		{
 			// Neither an admin, nor mapped to any ServiceAccount, nor bearing an
 			// identity from Kubernetes. There is no subject to authorize.
@@ -526,3 +544,102 @@ func TestGetAuthorizedClient(t *testing.T) {
 		})
 	}
 }
+
+func Test_scopesPermit(t *testing.T) {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{kargoapi.GroupVersion.Group},
+			Resources:     []string{"stages"},
+			ResourceNames: []string{"uat"},
+			Verbs:         []string{"get", "promote"},
+		},
+		{
+			APIGroups: []string{kargoapi.GroupVersion.Group},
+			Resources: []string{"freights", "freights/status"},
+			Verbs:     []string{"*"},
+		},
+	}
+	testCases := []struct {
+		name     string
+		ra       authv1.ResourceAttributes
+		expected bool
+	}{
+		{
+			name: "permitted verb on named resource",
+			ra: authv1.ResourceAttributes{
+				Verb:     "promote",
+				Group:    kargoapi.GroupVersion.Group,
+				Resource: "stages",
+				Name:     "uat",
+			},
+			expected: true,
+		},
+		{
+			name: "permitted verb on other named resource",
+			ra: authv1.ResourceAttributes{
+				Verb:     "promote",
+				Group:    kargoapi.GroupVersion.Group,
+				Resource: "stages",
+				Name:     "prod",
+			},
+		},
+		{
+			name: "unnamed resource when rule names resources",
+			ra: authv1.ResourceAttributes{
+				Verb:     "get",
+				Group:    kargoapi.GroupVersion.Group,
+				Resource: "stages",
+			},
+		},
+		{
+			name: "verb not permitted",
+			ra: authv1.ResourceAttributes{
+				Verb:     "delete",
+				Group:    kargoapi.GroupVersion.Group,
+				Resource: "stages",
+				Name:     "uat",
+			},
+		},
+		{
+			name: "wildcard verb",
+			ra: authv1.ResourceAttributes{
+				Verb:     "list",
+				Group:    kargoapi.GroupVersion.Group,
+				Resource: "freights",
+			},
+			expected: true,
+		},
+		{
+			name: "permitted subresource",
+			ra: authv1.ResourceAttributes{
+				Verb:        "patch",
+				Group:       kargoapi.GroupVersion.Group,
+				Resource:    "freights",
+				Subresource: "status",
+			},
+			expected: true,
+		},
+		{
+			name: "subresource not permitted",
+			ra: authv1.ResourceAttributes{
+				Verb:        "patch",
+				Group:       kargoapi.GroupVersion.Group,
+				Resource:    "stages",
+				Subresource: "status",
+				Name:        "uat",
+			},
+		},
+		{
+			name: "group not permitted",
+			ra: authv1.ResourceAttributes{
+				Verb:     "get",
+				Resource: "freights",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, scopesPermit(rules, testCase.ra))
+		})
+	}
+}
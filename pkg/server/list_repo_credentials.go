@@ -37,6 +37,8 @@ func (s *server) listProjectRepoCredentials(c *gin.Context) {
 			kargoapi.LabelValueCredentialTypeGit,
 			kargoapi.LabelValueCredentialTypeHelm,
 			kargoapi.LabelValueCredentialTypeImage,
+			kargoapi.LabelValueCredentialTypeHTTP,
+			kargoapi.LabelValueCredentialTypePackage,
 		})
 	if err != nil {
 		_ = c.Error(err)
@@ -88,6 +90,8 @@ func (s *server) listSharedRepoCredentials(c *gin.Context) {
 			kargoapi.LabelValueCredentialTypeGit,
 			kargoapi.LabelValueCredentialTypeHelm,
 			kargoapi.LabelValueCredentialTypeImage,
+			kargoapi.LabelValueCredentialTypeHTTP,
+			kargoapi.LabelValueCredentialTypePackage,
 		})
 	if err != nil {
 		_ = c.Error(err)
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/server/user"
+)
+
+// promoteToStageDryRunTimeout bounds how long a dry run may execute steps on
+// behalf of a single request.
+const promoteToStageDryRunTimeout = 5 * time.Minute
+
+// promoteToStageDryRunRequest represents the request body for the
+// PromoteToStageDryRun REST endpoint.
+type promoteToStageDryRunRequest struct {
+	Freight      string `json:"freight,omitempty"`
+	FreightAlias string `json:"freightAlias,omitempty"`
+} // @name PromoteToStageDryRunRequest
+
+// @id PromoteToStageDryRun
+// @Summary Dry run a promotion to a Stage
+// @Description Execute the promotion steps of a Stage's PromotionTemplate for
+// @Description the specified Freight without creating a Promotion. Steps that
+// @Description would change state outside of a temporary working directory,
+// @Description such as pushing commits or updating Argo CD Applications, are
+// @Description not executed. The result includes the rendered configuration of
+// @Description each step, which steps were skipped, and the changes made to
+// @Description each Git working tree.
+// @Tags Core, Project-Level
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param project path string true "Project name"
+// @Param stage path string true "Stage name"
+// @Param body body promoteToStageDryRunRequest true "Dry run request"
+// @Success 200 {object} promotion.DryRunResult
+// @Router /v1beta1/projects/{project}/stages/{stage}/promotions/dry-run [post]
+func (s *server) promoteToStageDryRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	project := c.Param("project")
+	stageName := c.Param("stage")
+
+	var req promoteToStageDryRunRequest
+	if !bindJSONOrError(c, &req) {
+		return
+	}
+
+	if (req.Freight == "") == (req.FreightAlias == "") {
+		_ = c.Error(libhttp.ErrorStr(
+			"exactly one of freight or freightAlias must be provided",
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	stage, err := s.getStageFn(
+		ctx,
+		s.client,
+		types.NamespacedName{
+			Namespace: project,
+			Name:      stageName,
+		},
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if stage == nil {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf("Stage %q not found in project %q", stageName, project),
+			http.StatusNotFound,
+		))
+		return
+	}
+
+	// A dry run exposes the rendered configuration of every step, so it
+	// requires the same permission as an actual promotion.
+	if err = s.authorizeFn(
+		ctx,
+		"promote",
+		kargoapi.GroupVersion.WithResource("stages"),
+		"",
+		types.NamespacedName{
+			Namespace: project,
+			Name:      stageName,
+		},
+	); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if api.IsTargetAware(stage) {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf(
+				"dry runs are not supported for Stage %q, which selects Targets",
+				stageName,
+			),
+			http.StatusBadRequest,
+		))
+		return
+	}
+	if stage.Spec.PromotionTemplate == nil || len(stage.Spec.PromotionTemplate.Spec.Steps) == 0 {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf("Stage %q defines no promotion steps", stageName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	freight, err := api.GetFreightByNameOrAlias(
+		ctx,
+		s.client,
+		project,
+		req.Freight,
+		req.FreightAlias,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if freight == nil {
+		freightName := req.Freight
+		if freightName == "" {
+			freightName = req.FreightAlias
+		}
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf("Freight %q not found in project %q", freightName, project),
+			http.StatusNotFound,
+		))
+		return
+	}
+
+	if !stage.IsFreightAvailable(freight) {
+		_ = c.Error(libhttp.ErrorStr(
+			fmt.Sprintf("Freight %q is not available to Stage %q", freight.Name, stageName),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	// Build the Promotion the same way the Promotion defaulting webhook would.
+	promo := api.NewMinimalPromotion(stage, freight.Name)
+	promo.Name = api.GeneratePromotionName(stage.Name, freight.Name)
+	promo.Spec.Steps = stage.Spec.PromotionTemplate.Spec.Steps
+	promo.Spec.Vars = append(
+		append([]kargoapi.ExpressionVariable{}, stage.Spec.Vars...),
+		stage.Spec.PromotionTemplate.Spec.Vars...,
+	)
+	// PromotionTasks are resolved with the internal client, exactly as they
+	// would be by the webhook.
+	if err = api.InflateSteps(ctx, s.client.InternalClient(), promo); err != nil {
+		_ = c.Error(fmt.Errorf("failed to inflate Promotion steps: %w", err))
+		return
+	}
+
+	targetFreightRef := kargoapi.FreightReference{
+		Name:      freight.Name,
+		Commits:   freight.Commits,
+		Images:    freight.Images,
+		Charts:    freight.Charts,
+		Artifacts: freight.Artifacts,
+		Origin:    freight.Origin,
+	}
+	promo.Status.Freight = &targetFreightRef
+	promo.Status.FreightCollection = buildDryRunFreightCollection(targetFreightRef, stage)
+
+	var actor string
+	if u, ok := user.InfoFromContext(ctx); ok {
+		actor = api.FormatEventUserActor(u)
+	}
+
+	// Steps are executed with the user's own client so that expressions can
+	// only read what the user could read anyway.
+	dryRunCtx, cancel := context.WithTimeout(ctx, promoteToStageDryRunTimeout)
+	defer cancel()
+	res, err := s.dryRunPromotionFn(
+		dryRunCtx,
+		promotion.NewContext(
+			promo,
+			stage,
+			promotion.WithActor(actor),
+			promotion.WithTargetFreightAlias(freight.Alias),
+		),
+		promotion.NewSteps(promo),
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// buildDryRunFreightCollection builds the FreightCollection a Promotion of the
+// provided Freight to the provided Stage would work with. As the Promotion
+// controller does, it carries over any Freight from other origins that the
+// Stage's last Promotion left in place.
+func buildDryRunFreightCollection(
+	targetFreight kargoapi.FreightReference,
+	stage *kargoapi.Stage,
+) *kargoapi.FreightCollection {
+	freightCol := &kargoapi.FreightCollection{}
+	if len(stage.Spec.RequestedFreight) > 1 {
+		lastPromo := stage.Status.LastPromotion
+		if lastPromo != nil && lastPromo.Status != nil && lastPromo.Status.FreightCollection != nil {
+			for _, req := range stage.Spec.RequestedFreight {
+				if freight, ok := lastPromo.Status.FreightCollection.Freight[req.Origin.String()]; ok {
+					freightCol.UpdateOrPush(freight)
+				}
+			}
+		}
+	}
+	freightCol.UpdateOrPush(targetFreight)
+	return freightCol
+}
+
+// dryRunPromotion executes the provided steps with a promotion.DryRunEngine.
+func (s *server) dryRunPromotion(
+	ctx context.Context,
+	promoCtx promotion.Context,
+	steps []promotion.Step,
+) (*promotion.DryRunResult, error) {
+	return promotion.NewDryRunEngine(
+		s.client,
+		s.credsDB,
+		promotion.NewGitUserResolver(
+			s.client.InternalClient(),
+			s.cfg.SystemResourcesNamespace,
+			promotion.GitUserFromEnv(),
+		),
+		promotion.DefaultExprDataCacheFn,
+	).DryRun(ctx, promoCtx, steps, nil)
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/promotion"
+	"github.com/akuity/kargo/pkg/server/config"
+)
+
+func Test_server_promoteToStageDryRun(t *testing.T) {
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	origin := kargoapi.FreightOrigin{
+		Kind: kargoapi.FreightOriginKindWarehouse,
+		Name: "fake-warehouse",
+	}
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-freight",
+			Namespace: testProject.Name,
+			Labels: map[string]string{
+				kargoapi.LabelKeyAlias: "fake-alias",
+			},
+		},
+		Alias:  "fake-alias",
+		Origin: origin,
+	}
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: testProject.Name,
+		},
+		Spec: kargoapi.StageSpec{
+			RequestedFreight: []kargoapi.FreightRequest{{
+				Origin:  origin,
+				Sources: kargoapi.FreightSources{Direct: true},
+			}},
+			Vars: []kargoapi.ExpressionVariable{{Name: "stage-var", Value: "a"}},
+			PromotionTemplate: &kargoapi.PromotionTemplate{
+				Spec: kargoapi.PromotionTemplateSpec{
+					Vars:  []kargoapi.ExpressionVariable{{Name: "template-var", Value: "b"}},
+					Steps: []kargoapi.PromotionStep{{Uses: "fake-step"}},
+				},
+			},
+		},
+	}
+	testStageWithoutSteps := testStage.DeepCopy()
+	testStageWithoutSteps.Spec.PromotionTemplate = nil
+
+	testRESTEndpoint(
+		t, &config.ServerConfig{},
+		http.MethodPost,
+		"/v1beta1/projects/"+testProject.Name+"/stages/"+testStage.Name+"/promotions/dry-run",
+		[]restTestCase{
+			{
+				name: "neither freight nor alias specified",
+				body: mustJSONBody(promoteToStageDryRunRequest{}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testStage,
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "Stage does not exist",
+				body: mustJSONBody(promoteToStageDryRunRequest{
+					Freight: testFreight.Name,
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(testProject),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name: "Stage has no promotion steps",
+				body: mustJSONBody(promoteToStageDryRunRequest{
+					Freight: testFreight.Name,
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testStageWithoutSteps,
+					testFreight,
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "Freight does not exist",
+				body: mustJSONBody(promoteToStageDryRunRequest{
+					Freight: testFreight.Name,
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testStage,
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusNotFound, w.Code)
+				},
+			},
+			{
+				name: "Freight not available to Stage",
+				body: mustJSONBody(promoteToStageDryRunRequest{
+					Freight: testFreight.Name,
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testStage,
+					&kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testFreight.Name,
+							Namespace: testProject.Name,
+						},
+						Origin: kargoapi.FreightOrigin{
+							Kind: kargoapi.FreightOriginKindWarehouse,
+							Name: "other-warehouse",
+						},
+					},
+				),
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusBadRequest, w.Code)
+				},
+			},
+			{
+				name: "success",
+				body: mustJSONBody(promoteToStageDryRunRequest{
+					FreightAlias: testFreight.Alias,
+				}),
+				clientBuilder: fake.NewClientBuilder().WithObjects(
+					testProject,
+					testStage,
+					testFreight,
+				),
+				serverSetup: func(t *testing.T, s *server) {
+					s.dryRunPromotionFn = func(
+						ctx context.Context,
+						promoCtx promotion.Context,
+						steps []promotion.Step,
+					) (*promotion.DryRunResult, error) {
+						deadline, ok := ctx.Deadline()
+						require.True(t, ok)
+						require.WithinDuration(
+							t,
+							time.Now().Add(promoteToStageDryRunTimeout),
+							deadline,
+							time.Minute,
+						)
+						require.Equal(t, testProject.Name, promoCtx.Project)
+						require.Equal(t, testStage.Name, promoCtx.Stage)
+						require.Equal(t, testFreight.Name, promoCtx.TargetFreightRef.Name)
+						require.Equal(t, testFreight.Alias, promoCtx.TargetFreightAlias)
+						require.NotNil(t, promoCtx.Freight.Freight[origin.String()])
+						require.Equal(t, []kargoapi.ExpressionVariable{
+							{Name: "stage-var", Value: "a"},
+							{Name: "template-var", Value: "b"},
+						}, promoCtx.Vars)
+						require.Len(t, steps, 1)
+						require.Equal(t, "fake-step", steps[0].Kind)
+						require.NotEmpty(t, steps[0].Alias)
+						return &promotion.DryRunResult{
+							Status: kargoapi.PromotionPhaseSucceeded,
+							Steps: []promotion.DryRunStep{{
+								Alias:  steps[0].Alias,
+								Kind:   steps[0].Kind,
+								Status: kargoapi.PromotionStepStatusSucceeded,
+							}},
+						}, nil
+					}
+				},
+				assertions: func(t *testing.T, w *httptest.ResponseRecorder, _ client.Client) {
+					require.Equal(t, http.StatusOK, w.Code)
+					res := &promotion.DryRunResult{}
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), res))
+					require.Equal(t, kargoapi.PromotionPhaseSucceeded, res.Status)
+					require.Len(t, res.Steps, 1)
+				},
+			},
+		},
+	)
+}
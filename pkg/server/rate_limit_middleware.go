@@ -0,0 +1,138 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+
+	libhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/server/config"
+	"github.com/akuity/kargo/pkg/server/user"
+)
+
+// defaultRateLimitMaxPrincipals is the number of principals whose buckets are
+// tracked when the configured maximum is not positive.
+const defaultRateLimitMaxPrincipals = 10000
+
+// principalLimiters holds a single principal's token buckets.
+type principalLimiters struct {
+	read     *rate.Limiter
+	mutating *rate.Limiter
+}
+
+// rateLimitMiddleware returns Gin middleware that limits the rate at which each
+// principal may make requests, using one token bucket for the principal's
+// mutating requests and another for all of its other requests. A request that
+// would exceed its principal's budget is rejected with a 429 and a Retry-After
+// header indicating how many seconds to wait before retrying.
+//
+// Principals are identified using the user.Info bound to the request context,
+// so the middleware must be registered after the authentication middleware.
+// Requests that have not been authenticated are limited per client IP.
+func rateLimitMiddleware(cfg config.RateLimitConfig) gin.HandlerFunc {
+	size := cfg.MaxPrincipals
+	if size <= 0 {
+		size = defaultRateLimitMaxPrincipals
+	}
+	// This only fails when the size is not positive.
+	cache, _ := lru.New[string, *principalLimiters](size)
+	return func(c *gin.Context) {
+		key := rateLimitKey(c)
+		limiters, ok := cache.Get(key)
+		if !ok {
+			limiters = &principalLimiters{
+				read:     rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+				mutating: rate.NewLimiter(rate.Limit(cfg.MutatingRequestsPerSecond), cfg.MutatingBurst),
+			}
+			// Another request from the same principal may have beaten us to it.
+			if existing, found, _ := cache.PeekOrAdd(key, limiters); found {
+				limiters = existing
+			}
+		}
+		limiter := limiters.read
+		if isMutatingMethod(c.Request.Method) {
+			limiter = limiters.mutating
+		}
+		if retryAfter, allowed := reserve(limiter, time.Now()); !allowed {
+			logging.LoggerFromContext(c.Request.Context()).Debug(
+				"request rate limit exceeded",
+				"path", c.Request.URL.Path,
+				"method", c.Request.Method,
+				"retryAfter", retryAfter,
+			)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			_ = c.Error(libhttp.ErrorStr(
+				"rate limit exceeded; retry after "+strconv.Itoa(retryAfter)+"s",
+				http.StatusTooManyRequests,
+			))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// reserve takes a token from the provided limiter if one is available at the
+// provided time. If none is, it returns false along with the number of whole
+// seconds, never less than one, until one will be.
+func reserve(limiter *rate.Limiter, now time.Time) (int, bool) {
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		// The bucket has no capacity at all, so no amount of waiting helps.
+		// Suggest waiting a full minute rather than hammering the server.
+		return 60, false
+	}
+	delay := r.DelayFrom(now)
+	if delay <= 0 {
+		return 0, true
+	}
+	// Return the token we will not be using.
+	r.CancelAt(now)
+	return max(1, int(math.Ceil(delay.Seconds()))), false
+}
+
+// isMutatingMethod returns true if a request using the provided HTTP method
+// may modify resources.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// rateLimitKey returns a key identifying the principal that made the provided
+// request. Users authenticated via OIDC are identified by username, so all of
+// a user's sessions share their budget. Bearers of tokens authenticated by
+// Kubernetes, such as Kargo API tokens, are identified by token, so each token
+// has its own budget.
+func rateLimitKey(c *gin.Context) string {
+	u, ok := user.InfoFromContext(c.Request.Context())
+	switch {
+	case !ok:
+	case u.IsAdmin:
+		return "admin"
+	case u.KubernetesUserInfo != nil:
+		// Avoid retaining the token itself.
+		rawToken := strings.TrimPrefix(c.GetHeader(authHeaderKey), "Bearer ")
+		sum := sha256.Sum256([]byte(rawToken))
+		return "token:" + hex.EncodeToString(sum[:])
+	case u.Username != "":
+		return "user:" + u.Username
+	default:
+		if sub, ok := u.Claims["sub"].(string); ok && sub != "" {
+			return "sub:" + sub
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
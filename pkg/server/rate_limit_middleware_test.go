@@ -0,0 +1,165 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	authnv1 "k8s.io/api/authentication/v1"
+
+	"github.com/akuity/kargo/pkg/server/config"
+	"github.com/akuity/kargo/pkg/server/user"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func() *gin.Engine {
+		router := gin.New()
+		router.Use((&server{}).handleError)
+		// Mimics the authentication middleware by binding user.Info derived
+		// from a test header to the request context.
+		router.Use(func(c *gin.Context) {
+			if username := c.GetHeader("X-Test-User"); username != "" {
+				c.Request = c.Request.WithContext(
+					user.ContextWithInfo(c.Request.Context(), user.Info{Username: username}),
+				)
+			}
+			c.Next()
+		})
+		router.Use(rateLimitMiddleware(config.RateLimitConfig{
+			// Slow enough that no tokens are replenished during the test.
+			RequestsPerSecond:         0.001,
+			Burst:                     2,
+			MutatingRequestsPerSecond: 0.001,
+			MutatingBurst:             1,
+		}))
+		router.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.POST("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+		return router
+	}
+
+	do := func(router *gin.Engine, method, username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/test", nil)
+		if username != "" {
+			req.Header.Set("X-Test-User", username)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("non-mutating requests exhaust their own budget", func(t *testing.T) {
+		router := newRouter()
+		require.Equal(t, http.StatusOK, do(router, http.MethodGet, "alice").Code)
+		require.Equal(t, http.StatusOK, do(router, http.MethodGet, "alice").Code)
+		w := do(router, http.MethodGet, "alice")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.NotEmpty(t, w.Header().Get("Retry-After"))
+		require.Contains(t, w.Body.String(), "rate limit exceeded")
+		// The mutating budget is unaffected.
+		require.Equal(t, http.StatusOK, do(router, http.MethodPost, "alice").Code)
+	})
+
+	t.Run("mutating requests exhaust their own budget", func(t *testing.T) {
+		router := newRouter()
+		require.Equal(t, http.StatusOK, do(router, http.MethodPost, "alice").Code)
+		require.Equal(t, http.StatusTooManyRequests, do(router, http.MethodPost, "alice").Code)
+		// The non-mutating budget is unaffected.
+		require.Equal(t, http.StatusOK, do(router, http.MethodGet, "alice").Code)
+	})
+
+	t.Run("principals have separate budgets", func(t *testing.T) {
+		router := newRouter()
+		require.Equal(t, http.StatusOK, do(router, http.MethodPost, "alice").Code)
+		require.Equal(t, http.StatusTooManyRequests, do(router, http.MethodPost, "alice").Code)
+		require.Equal(t, http.StatusOK, do(router, http.MethodPost, "bob").Code)
+		// Unauthenticated requests are budgeted by client IP.
+		require.Equal(t, http.StatusOK, do(router, http.MethodPost, "").Code)
+		require.Equal(t, http.StatusTooManyRequests, do(router, http.MethodPost, "").Code)
+	})
+}
+
+func TestReserve(t *testing.T) {
+	now := time.Now()
+
+	limiter := rate.NewLimiter(rate.Limit(0.5), 1)
+	retryAfter, allowed := reserve(limiter, now)
+	require.True(t, allowed)
+	require.Zero(t, retryAfter)
+	retryAfter, allowed = reserve(limiter, now)
+	require.False(t, allowed)
+	require.Equal(t, 2, retryAfter)
+	// The rejected reservation did not consume a token.
+	_, allowed = reserve(limiter, now.Add(2*time.Second))
+	require.True(t, allowed)
+
+	// Sub-second delays are rounded up.
+	limiter = rate.NewLimiter(rate.Limit(10), 1)
+	_, _ = reserve(limiter, now)
+	retryAfter, allowed = reserve(limiter, now)
+	require.False(t, allowed)
+	require.Equal(t, 1, retryAfter)
+
+	// A bucket with no capacity never allows anything.
+	limiter = rate.NewLimiter(rate.Limit(10), 0)
+	_, allowed = reserve(limiter, now)
+	require.False(t, allowed)
+}
+
+func TestRateLimitKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     *user.Info
+		token    string
+		expected string
+	}{
+		{
+			name:     "unauthenticated",
+			expected: "ip:192.0.2.1",
+		},
+		{
+			name:     "admin",
+			info:     &user.Info{IsAdmin: true},
+			expected: "admin",
+		},
+		{
+			name:     "OIDC user",
+			info:     &user.Info{Username: "alice", Claims: map[string]any{"sub": "1234"}},
+			expected: "user:alice",
+		},
+		{
+			name:     "OIDC user without username",
+			info:     &user.Info{Claims: map[string]any{"sub": "1234"}},
+			expected: "sub:1234",
+		},
+		{
+			name: "Kubernetes-authenticated token",
+			info: &user.Info{
+				KubernetesUserInfo: &authnv1.UserInfo{Username: "system:serviceaccount:p:t"},
+			},
+			token: "fake-token",
+			// sha256("fake-token")
+			expected: "token:e1466187c844c921b622aff2197444cfdc2c87489f7a6e71cef47b31a1602ced",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if testCase.token != "" {
+				req.Header.Set(authHeaderKey, "Bearer "+testCase.token)
+			}
+			if testCase.info != nil {
+				req = req.WithContext(user.ContextWithInfo(req.Context(), *testCase.info))
+			}
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = req
+			require.Equal(t, testCase.expected, rateLimitKey(c))
+		})
+	}
+}
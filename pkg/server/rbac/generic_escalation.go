@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/server/kubernetes"
 )
 
@@ -21,6 +22,7 @@ var (
 	clusterRoleGVK        = rbacv1.SchemeGroupVersion.WithKind("ClusterRole")
 	clusterRoleBindingGVK = rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding")
 	serviceAccountGVK     = corev1.SchemeGroupVersion.WithKind("ServiceAccount")
+	projectConfigGVK      = kargoapi.GroupVersion.WithKind("ProjectConfig")
 )
 
 // VerifyResourceNotEscalating guards the generic resource endpoints against
@@ -41,7 +43,11 @@ var (
 //     they reference.
 //   - ServiceAccount annotated for OIDC claim mapping: the rules of every Role
 //     and ClusterRole bound to it, because mapping a claim onto it hands those
-//     permissions to every user bearing that claim.
+//     permissions to every user bearing that claim. The same applies to a
+//     ServiceAccount annotated with role mapping criteria.
+//   - ProjectConfig declaring RoleMappings: the rules of every Role and
+//     ClusterRole bound to each mapped Role's ServiceAccount, because the
+//     management controller will record the mapping on that ServiceAccount.
 //
 // Each permission is verified at the scope where it applies: namespaced kinds
 // in obj's namespace, cluster-scoped kinds cluster-wide. (A RoleBinding is
@@ -51,7 +57,8 @@ var (
 // disables verification (tests, non-authorizing local mode). resolver reads the
 // Roles and bindings needed to decide and must always be able to read them,
 // e.g. the server's internal client rather than one scoped to the requester.
-// globalNamespaces matters only for the ServiceAccount case; see
+// globalNamespaces matters only for the ServiceAccount and ProjectConfig cases;
+// see
 // verifyServiceAccountBindingsNotEscalating.
 func VerifyResourceNotEscalating(
 	ctx context.Context,
@@ -127,12 +134,36 @@ func VerifyResourceNotEscalating(
 			// Malformed claim annotations: fail closed rather than skip the check.
 			return fmt.Errorf("error reading ServiceAccount claim annotations: %w", err)
 		}
-		if len(claims) == 0 {
+		criteria, err := rbacapi.RoleMappingCriteriaFromAnnotationValues(obj.GetAnnotations())
+		if err != nil {
+			return fmt.Errorf("error reading ServiceAccount role mapping annotation: %w", err)
+		}
+		if len(claims) == 0 && criteria.IsEmpty() {
 			return nil
 		}
 		return verifyServiceAccountBindingsNotEscalating(
 			ctx, authz, resolver, globalNamespaces, obj.GetNamespace(), obj.GetName(),
 		)
+	case projectConfigGVK:
+		projectCfg := &kargoapi.ProjectConfig{}
+		if err := fromUnstructured(obj, projectCfg); err != nil {
+			return err
+		}
+		// A Kargo Role's ServiceAccount shares the Role's name. Each mapped Role
+		// is checked once, however many mappings reference it.
+		roles := make(map[string]struct{}, len(projectCfg.Spec.RoleMappings))
+		for _, mapping := range projectCfg.Spec.RoleMappings {
+			if _, ok := roles[mapping.Role]; ok {
+				continue
+			}
+			roles[mapping.Role] = struct{}{}
+			if err := verifyServiceAccountBindingsNotEscalating(
+				ctx, authz, resolver, globalNamespaces, obj.GetNamespace(), mapping.Role,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -15,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/server/kubernetes"
 )
 
@@ -154,6 +155,36 @@ func TestVerifyResourceNotEscalating(t *testing.T) {
 		})
 	}
 
+	saWithRoleMapping := func(name string) *unstructured.Unstructured {
+		return toUnstructured(t, &corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Annotations: map[string]string{
+					rbacapi.AnnotationKeyRoleMapping: `{"emailDomains":["example.com"]}`,
+				},
+			},
+		})
+	}
+
+	projectConfigMapping := func(roles ...string) *unstructured.Unstructured {
+		projectCfg := &kargoapi.ProjectConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: kargoapi.GroupVersion.String(),
+				Kind:       "ProjectConfig",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: ns, Namespace: ns},
+		}
+		for _, role := range roles {
+			projectCfg.Spec.RoleMappings = append(
+				projectCfg.Spec.RoleMappings,
+				kargoapi.RoleMapping{Role: role, Groups: []string{"everyone"}},
+			)
+		}
+		return toUnstructured(t, projectCfg)
+	}
+
 	// Resolver client holds the referenced Roles and the SA bindings so the
 	// binding and ServiceAccount checks can resolve rules.
 	testScheme := runtime.NewScheme()
@@ -233,6 +264,33 @@ func TestVerifyResourceNotEscalating(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "bound-sa", Namespace: ns},
 			}),
 		},
+		{
+			name:    "ServiceAccount with role mapping bound to a Role the requester lacks is rejected",
+			authz:   &fakeAuthorizer{allow: holdsNothing},
+			obj:     saWithRoleMapping("bound-sa"),
+			wantErr: true,
+		},
+		{
+			name:  "ServiceAccount with role mapping bound to a Role the requester holds is allowed",
+			authz: &fakeAuthorizer{allow: holdsSecretsGet},
+			obj:   saWithRoleMapping("bound-sa"),
+		},
+		{
+			name:    "ProjectConfig mapping a Role the requester lacks is rejected",
+			authz:   &fakeAuthorizer{allow: holdsNothing},
+			obj:     projectConfigMapping("unbound-sa", "bound-sa"),
+			wantErr: true,
+		},
+		{
+			name:  "ProjectConfig mapping a Role the requester holds is allowed",
+			authz: &fakeAuthorizer{allow: holdsSecretsGet},
+			obj:   projectConfigMapping("bound-sa", "bound-sa"),
+		},
+		{
+			name:  "ProjectConfig without role mappings is ignored",
+			authz: &fakeAuthorizer{allow: holdsNothing},
+			obj:   projectConfigMapping(),
+		},
 		{
 			name:    "ClusterRole granting rules the requester lacks is rejected",
 			authz:   &fakeAuthorizer{allow: holdsNothing},
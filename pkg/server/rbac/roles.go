@@ -2,9 +2,11 @@ package rbac
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	corev1 "k8s.io/api/core/v1"
@@ -116,13 +118,16 @@ type RolesDatabase interface {
 	// underlying Role and RoleBinding resources if they do not exist.
 	Update(context.Context, *rbacapi.Role) (*rbacapi.Role, error)
 	// CreateAPIToken generates and returns a new bearer token associated with a
-	// Kargo Role in the form of a Kubernetes Secret.
+	// Kargo Role in the form of a Kubernetes Secret. The token may optionally
+	// be made to expire or have its permissions narrowed to a subset of the
+	// Role's.
 	CreateAPIToken(
 		ctx context.Context,
 		systemLevel bool,
 		project string,
 		roleName string,
 		tokenName string,
+		opts *APITokenOptions,
 	) (*corev1.Secret, error)
 	// DeleteAPIToken deletes a bearer token associated with a Kargo Role.
 	DeleteAPIToken(
@@ -148,6 +153,17 @@ type RolesDatabase interface {
 	) ([]corev1.Secret, error)
 }
 
+// APITokenOptions describes optional restrictions on a new API token.
+type APITokenOptions struct {
+	// TTL is the length of time for which the token remains valid. A zero value
+	// means the token never expires.
+	TTL time.Duration
+	// Scopes, when non-empty, narrow the permissions the token confers. A
+	// request made using the token is permitted only if it is permitted both by
+	// the token's Kargo Role and by at least one of these.
+	Scopes []rbacapi.ResourceDetails
+}
+
 // Resources is a struct that encapsulates the Kubernetes resources underlying a
 // Kargo Role.
 type Resources struct {
@@ -1155,7 +1171,14 @@ func (c *rolesDatabase) CreateAPIToken(
 	project string,
 	roleName string,
 	tokenName string,
+	opts *APITokenOptions,
 ) (*corev1.Secret, error) {
+	if opts == nil {
+		opts = &APITokenOptions{}
+	}
+	if opts.TTL < 0 {
+		return nil, apierrors.NewBadRequest("token TTL must not be negative")
+	}
 	namespace := project
 	if systemLevel {
 		namespace = c.cfg.KargoNamespace
@@ -1193,6 +1216,21 @@ func (c *rolesDatabase) CreateAPIToken(
 		},
 		Type: corev1.SecretTypeServiceAccountToken,
 	}
+	if opts.TTL > 0 {
+		tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenExpiresAt] =
+			time.Now().Add(opts.TTL).UTC().Format(time.RFC3339)
+	}
+	if len(opts.Scopes) > 0 {
+		scopes, err := c.buildAPITokenScopes(opts.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		scopesJSON, err := json.Marshal(scopes)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling token scopes: %w", err)
+		}
+		tokenSecret.Annotations[rbacapi.AnnotationKeyAPITokenScopes] = string(scopesJSON)
+	}
 	fmt.Println(tokenSecret.OwnerReferences)
 	if err = c.client.Create(ctx, tokenSecret); err != nil {
 		return nil, fmt.Errorf(
@@ -1217,6 +1255,47 @@ func (c *rolesDatabase) CreateAPIToken(
 	return tokenSecret, nil
 }
 
+// buildAPITokenScopes converts the provided ResourceDetails into normalized
+// PolicyRules suitable for recording on an API token Secret.
+func (c *rolesDatabase) buildAPITokenScopes(
+	details []rbacapi.ResourceDetails,
+) ([]rbacv1.PolicyRule, error) {
+	rules := make([]rbacv1.PolicyRule, 0, len(details))
+	for _, d := range details {
+		if len(d.Verbs) == 0 {
+			return nil, apierrors.NewBadRequest(
+				fmt.Sprintf("token scope for resource type %q specifies no verbs", d.ResourceType),
+			)
+		}
+		if err := validateResourceTypeName(d.ResourceType); err != nil {
+			return nil, err
+		}
+		group, err := c.resolveGroup(d.ResourceType)
+		if err != nil {
+			return nil, err
+		}
+		rule := rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: []string{d.ResourceType},
+			Verbs:     d.Verbs,
+		}
+		if d.ResourceName != "" {
+			rule.ResourceNames = []string{d.ResourceName}
+		}
+		rules = append(rules, rule)
+	}
+	// A wildcard verb is expanded to include custom verbs such as "promote",
+	// exactly as it would be in a Role.
+	scopes, err := NormalizePolicyRules(
+		rules,
+		&PolicyRuleNormalizationOptions{IncludeCustomVerbsInExpansion: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error normalizing token scopes: %w", err)
+	}
+	return scopes, nil
+}
+
 // waitForTokenData retrieves a token Secret with retry logic. It retries when:
 //
 //  1. The Secret exists but token data hasn't been populated yet
@@ -1655,7 +1655,7 @@ func Test_rolesDatabase_CreateAPIToken(t *testing.T) {
 	t.Run("ServiceAccount not found", func(t *testing.T) {
 		c := fake.NewClientBuilder().WithScheme(scheme).Build()
 		_, err := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv()).
-			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName)
+			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName, nil)
 		require.Error(t, err)
 		require.True(t, apierrors.IsNotFound(err))
 	})
@@ -1675,7 +1675,7 @@ func Test_rolesDatabase_CreateAPIToken(t *testing.T) {
 			}},
 		).Build()
 		_, err := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv()).
-			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName)
+			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName, nil)
 		require.Error(t, err)
 		require.True(t, apierrors.IsAlreadyExists(err))
 	})
@@ -1717,7 +1717,7 @@ func Test_rolesDatabase_CreateAPIToken(t *testing.T) {
 				},
 			}).Build()
 		tokenSecret, err := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv()).
-			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName)
+			CreateAPIToken(t.Context(), false, testProject, testRoleName, testTokenName, nil)
 		require.NoError(t, err)
 		require.NotNil(t, tokenSecret)
 		tokenSecret = &corev1.Secret{}
@@ -1736,6 +1736,91 @@ func Test_rolesDatabase_CreateAPIToken(t *testing.T) {
 			testRoleName,
 			tokenSecret.Annotations["kubernetes.io/service-account.name"],
 		)
+		require.NotContains(t, tokenSecret.Annotations, rbacapi.AnnotationKeyAPITokenExpiresAt)
+		require.NotContains(t, tokenSecret.Annotations, rbacapi.AnnotationKeyAPITokenScopes)
+	})
+
+	t.Run("negative TTL", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		_, err := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv()).
+			CreateAPIToken(
+				t.Context(), false, testProject, testRoleName, testTokenName,
+				&APITokenOptions{TTL: -time.Hour},
+			)
+		require.True(t, apierrors.IsBadRequest(err))
+	})
+
+	t.Run("scope without verbs", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(managedServiceAccount(nil)).
+			Build()
+		_, err := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv()).
+			CreateAPIToken(
+				t.Context(), false, testProject, testKargoRoleName, testTokenName,
+				&APITokenOptions{
+					Scopes: []rbacapi.ResourceDetails{{ResourceType: "stages"}},
+				},
+			)
+		require.True(t, apierrors.IsBadRequest(err))
+		require.ErrorContains(t, err, "specifies no verbs")
+	})
+
+	t.Run("success with TTL and scopes", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(managedServiceAccount(nil)).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(
+					ctx context.Context,
+					client client.WithWatch,
+					key client.ObjectKey,
+					obj client.Object,
+					opts ...client.GetOption,
+				) error {
+					if err := client.Get(ctx, key, obj, opts...); err != nil {
+						return err
+					}
+					if s, ok := obj.(*corev1.Secret); ok {
+						s.Data = map[string][]byte{"token": []byte("fake-token-value")}
+					}
+					return nil
+				},
+			}).Build()
+		db := NewKubernetesRolesDatabase(c, c, RolesDatabaseConfigFromEnv())
+		rdb, ok := db.(*rolesDatabase)
+		require.True(t, ok)
+		rdb.resolveGroup = fakeGroupResolver
+		tokenSecret, err := db.CreateAPIToken(
+			t.Context(), false, testProject, testKargoRoleName, testTokenName,
+			&APITokenOptions{
+				TTL: time.Hour,
+				Scopes: []rbacapi.ResourceDetails{
+					{ResourceType: "stages", ResourceName: "uat", Verbs: []string{"promote"}},
+					{ResourceType: "freights", Verbs: []string{"list", "get"}},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		expiry, err := rbacapi.APITokenExpiryFromAnnotationValues(tokenSecret.Annotations)
+		require.NoError(t, err)
+		require.NotNil(t, expiry)
+		require.WithinDuration(t, time.Now().Add(time.Hour), *expiry, time.Minute)
+
+		scopes, err := rbacapi.APITokenScopesFromAnnotationValues(tokenSecret.Annotations)
+		require.NoError(t, err)
+		require.Equal(t, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{kargoapi.GroupVersion.Group},
+				Resources: []string{"freights"},
+				Verbs:     []string{"get", "list"},
+			},
+			{
+				APIGroups:     []string{kargoapi.GroupVersion.Group},
+				Resources:     []string{"stages"},
+				ResourceNames: []string{"uat"},
+				Verbs:         []string{"promote"},
+			},
+		}, scopes)
 	})
 }
 
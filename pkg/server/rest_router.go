@@ -88,12 +88,18 @@ func (s *server) setupRESTRouter(ctx context.Context) *gin.Engine {
 	// have to write its own response. Authentication is innermost of the four so
 	// that its rejections are answered by the error handling middleware, and so
 	// that a panic within it is recovered too.
+	//
+	// Rate limiting, when enabled, follows authentication because it budgets
+	// requests per authenticated principal.
 	router.Use(loggingMiddleware())
 	router.Use(s.handleError)
 	router.Use(recoveryMiddleware())
 	if s.cfg.AdminConfig != nil || s.cfg.OIDCConfig != nil {
 		router.Use(NewAuthMiddleware(ctx, s.cfg, s.client.InternalClient()))
 	}
+	if s.cfg.RateLimitConfig != nil {
+		router.Use(rateLimitMiddleware(*s.cfg.RateLimitConfig))
+	}
 
 	v1beta1 := router.Group("/v1beta1")
 	{
@@ -231,6 +237,7 @@ func (s *server) setupRESTRouter(ctx context.Context) *gin.Engine {
 			// Stage Promotions
 			project.POST("/stages/:stage/promotions", s.promoteToStage)
 			project.POST("/stages/:stage/promotions/downstream", s.promoteDownstream)
+			project.POST("/stages/:stage/promotions/dry-run", s.promoteToStageDryRun)
 			// Stage Verification
 			project.POST("/stages/:stage/verification", s.reverify)
 			project.POST("/stages/:stage/verification/abort", s.abortVerification)
@@ -238,6 +245,7 @@ func (s *server) setupRESTRouter(ctx context.Context) *gin.Engine {
 			// Warehouses
 			project.GET("/warehouses", s.listWarehouses)
 			project.GET("/warehouses/:warehouse", s.getWarehouse)
+			project.POST("/warehouses/discover", s.discoverWarehouseArtifacts)
 			project.POST("/warehouses/:warehouse/refresh", s.refreshWarehouse)
 			project.DELETE("/warehouses/:warehouse", s.deleteWarehouse)
 
@@ -245,15 +253,18 @@ func (s *server) setupRESTRouter(ctx context.Context) *gin.Engine {
 			project.GET("/freight", s.queryFreight)
 			project.GET("/freight/:freight-name-or-alias", s.getFreight)
 			project.GET("/freight/:freight-name-or-alias/links", s.getFreightLinks)
+			project.GET("/freight/:freight-name-or-alias/diff", s.getFreightDiff)
 			project.POST("/freight/:freight-name-or-alias/approve", s.approveFreight)
 			project.PATCH("/freight/:freight-name-or-alias/alias", s.patchFreightAliasHandler)
 			project.DELETE("/freight/:freight-name-or-alias", s.deleteFreight)
+			project.GET("/lineage", s.getLineage)
 
 			// Promotions
 			project.GET("/promotions", s.listPromotions)
 			project.GET("/promotions/:promotion", s.getPromotion)
 			project.POST("/promotions/:promotion/refresh", s.refreshPromotion)
 			project.POST("/promotions/:promotion/abort", s.abortPromotion)
+			project.GET("/promotions/:promotion/logs", s.getPromotionLogs)
 
 			// Promotion Tasks
 			project.GET("/promotion-tasks", s.listPromotionTasks)
@@ -24,14 +24,21 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/api"
 	rollouts "github.com/akuity/kargo/pkg/api/stubs/rollouts"
+	"github.com/akuity/kargo/pkg/controller/git"
+	"github.com/akuity/kargo/pkg/controller/warehouses"
+	"github.com/akuity/kargo/pkg/credentials"
+	credsdb "github.com/akuity/kargo/pkg/credentials/kubernetes"
 	"github.com/akuity/kargo/pkg/event"
+	"github.com/akuity/kargo/pkg/freightdiff"
 	httputil "github.com/akuity/kargo/pkg/http"
 	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/promotion"
 	"github.com/akuity/kargo/pkg/server/config"
 	"github.com/akuity/kargo/pkg/server/dex"
 	"github.com/akuity/kargo/pkg/server/kubernetes"
 	"github.com/akuity/kargo/pkg/server/rbac"
 	"github.com/akuity/kargo/pkg/server/validation"
+	"github.com/akuity/kargo/pkg/subscription"
 )
 
 //go:embed all:ui
@@ -42,6 +49,7 @@ type server struct {
 	client  kubernetes.Client
 	rolesDB rbac.RolesDatabase
 	sender  event.Sender
+	credsDB credentials.Database
 
 	// The following behaviors are overridable for testing purposes:
 
@@ -140,6 +148,22 @@ type server struct {
 		types.NamespacedName,
 	) (*rolloutsapi.AnalysisRun, error)
 
+	// Freight diffs:
+	newCommitListerFn func(project string) commitLister
+
+	// Promotion dry runs:
+	dryRunPromotionFn func(
+		context.Context,
+		promotion.Context,
+		[]promotion.Step,
+	) (*promotion.DryRunResult, error)
+
+	// Warehouse discovery dry runs:
+	discoverWarehouseArtifactsFn func(
+		context.Context,
+		*kargoapi.Warehouse,
+	) (*warehouses.DryRunResult, error)
+
 	// Special authorizations:
 	authorizeFn func(
 		ctx context.Context,
@@ -150,6 +174,18 @@ type server struct {
 	) error
 }
 
+// commitLister lists the commits between two commits in a Git repository.
+type commitLister interface {
+	ListCommits(
+		ctx context.Context,
+		repoURL string,
+		from string,
+		to string,
+		limit uint,
+	) ([]git.CommitMetadata, error)
+	Close(ctx context.Context) error
+}
+
 type Server interface {
 	Serve(ctx context.Context, l net.Listener) error
 }
@@ -185,6 +221,30 @@ func NewServer(
 	s.getClusterAnalysisTemplateFn = rollouts.GetClusterAnalysisTemplate
 	s.getAnalysisRunFn = rollouts.GetAnalysisRun
 
+	s.credsDB = credsdb.NewDatabase(
+		kubeClient.InternalClient(),
+		nil,
+		credentials.DefaultProviderRegistry,
+		credsdb.DatabaseConfig{
+			SharedResourcesNamespace: cfg.SharedResourcesNamespace,
+		},
+	)
+	s.newCommitListerFn = func(project string) commitLister {
+		return freightdiff.NewGitCommitLister(s.credsDB, project)
+	}
+	s.dryRunPromotionFn = s.dryRunPromotion
+	s.discoverWarehouseArtifactsFn = func(
+		ctx context.Context,
+		warehouse *kargoapi.Warehouse,
+	) (*warehouses.DryRunResult, error) {
+		return warehouses.DryRun(
+			ctx,
+			s.credsDB,
+			subscription.DefaultSubscriberRegistry,
+			warehouse,
+		)
+	}
+
 	return s
 }
 
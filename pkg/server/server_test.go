@@ -71,6 +71,9 @@ func TestNewServer(t *testing.T) {
 	require.NotNil(t, s.patchFreightStatusFn)
 	require.NotNil(t, s.authorizeFn)
 	require.NotNil(t, s.getAnalysisRunFn)
+	require.NotNil(t, s.newCommitListerFn)
+	require.NotNil(t, s.dryRunPromotionFn)
+	require.NotNil(t, s.discoverWarehouseArtifactsFn)
 }
 
 func TestWrapWithBasePath(t *testing.T) {
@@ -145,9 +145,11 @@ func validateUpdateRepoCredentialsRequest(req updateRepoCredentialsRequest) erro
 	switch req.Type {
 	case kargoapi.LabelValueCredentialTypeGit,
 		kargoapi.LabelValueCredentialTypeHelm,
-		kargoapi.LabelValueCredentialTypeImage:
+		kargoapi.LabelValueCredentialTypeImage,
+		kargoapi.LabelValueCredentialTypeHTTP,
+		kargoapi.LabelValueCredentialTypePackage:
 	default:
-		return errors.New("type should be one of git, helm, or image")
+		return errors.New("type should be one of git, helm, image, http, or package")
 	}
 	if req.RepoURL == "" {
 		return errors.New("repoUrl should not be empty")
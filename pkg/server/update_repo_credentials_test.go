@@ -126,7 +126,7 @@ func TestValidateUpdateRepoCredentialsRequest(t *testing.T) {
 				Username: "user",
 				Password: "pass",
 			},
-			wantErr: "type should be one of git, helm, or image",
+			wantErr: "type should be one of git, helm, image, http, or package",
 		},
 		{
 			name: "missing repoUrl",
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	authnv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -36,6 +37,10 @@ type Info struct {
 	// when Kubernetes authenticated the token directly (e.g. a Kargo API
 	// token), rather than Kargo's own token issuer or OIDC provider.
 	KubernetesUserInfo *authnv1.UserInfo
+	// APITokenScopes, when non-nil, further restricts the permissions of a
+	// Kargo API token holder to requests permitted by at least one of these
+	// rules. A nil value means the token is not scoped.
+	APITokenScopes []rbacv1.PolicyRule
 }
 
 // ContextWithInfo returns a context.Context that has been augmented with
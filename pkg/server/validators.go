@@ -97,7 +97,9 @@ func validateRepoCredentialSecret(secret *corev1.Secret) error {
 	}
 	if credType != kargoapi.LabelValueCredentialTypeGit &&
 		credType != kargoapi.LabelValueCredentialTypeHelm &&
-		credType != kargoapi.LabelValueCredentialTypeImage {
+		credType != kargoapi.LabelValueCredentialTypeImage &&
+		credType != kargoapi.LabelValueCredentialTypeHTTP &&
+		credType != kargoapi.LabelValueCredentialTypePackage {
 		return libhttp.ErrorStr(
 			fmt.Sprintf(
 				"Kubernetes Secret %s/%s exists, but is labeled as unrecognized credential type %q",
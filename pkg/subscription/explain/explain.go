@@ -0,0 +1,113 @@
+// Package explain provides a mechanism by which artifact selectors can record
+// why candidate artifacts (tags, commits, chart versions, etc.) were filtered
+// out during discovery. Recording is opt-in: callers that wish to collect
+// explanations attach a Recorder to the context.Context passed to a selector.
+// When no Recorder is present, recording is a no-op, so selectors can record
+// rejections unconditionally without affecting normal discovery.
+package explain
+
+import (
+	"context"
+	"sync"
+)
+
+// Rejection describes a single candidate artifact that was filtered out during
+// discovery and the reason it was filtered out.
+type Rejection struct {
+	// Candidate identifies the candidate that was rejected. Depending on the
+	// type of subscription, this may be a tag, a commit ID, a version, etc.
+	Candidate string `json:"candidate"`
+	// Reason is a human-readable explanation of why the candidate was
+	// rejected.
+	Reason string `json:"reason"`
+} // @name DiscoveryRejection
+
+// Recorder collects Rejections. It is safe for concurrent use.
+type Recorder struct {
+	mu         sync.Mutex
+	rejections []Rejection
+}
+
+// NewRecorder returns a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Reject records the rejection of the specified candidate for the specified
+// reason.
+func (r *Recorder) Reject(candidate, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rejections = append(r.rejections, Rejection{
+		Candidate: candidate,
+		Reason:    reason,
+	})
+}
+
+// Rejections returns all Rejections recorded so far, in the order in which
+// they were recorded.
+func (r *Recorder) Rejections() []Rejection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rejections := make([]Rejection, len(r.rejections))
+	copy(rejections, r.rejections)
+	return rejections
+}
+
+type recorderContextKey struct{}
+
+// ContextWithRecorder returns a context.Context that has been augmented with
+// the provided *Recorder.
+func ContextWithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderContextKey{}, r)
+}
+
+// RecorderFromContext extracts a *Recorder from the provided context.Context
+// and returns it. If no *Recorder is found, nil is returned.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderContextKey{}).(*Recorder)
+	return r
+}
+
+// Enabled returns a boolean indicating whether the provided context.Context
+// carries a *Recorder. Selectors can use this to avoid computing expensive
+// explanations that would be discarded anyway.
+func Enabled(ctx context.Context) bool {
+	return RecorderFromContext(ctx) != nil
+}
+
+// Reject records the rejection of the specified candidate for the specified
+// reason using the *Recorder carried by the provided context.Context. If the
+// context does not carry a *Recorder, this is a no-op.
+func Reject(ctx context.Context, candidate, reason string) {
+	if r := RecorderFromContext(ctx); r != nil {
+		r.Reject(candidate, reason)
+	}
+}
+
+// Filtered records a rejection for every element of candidates that does not
+// also appear in selected. Elements are compared using the provided key
+// function, which is also used to identify the rejected candidate. The reason
+// for each rejection is obtained from the provided reason function. If the
+// context does not carry a *Recorder, this is a no-op.
+func Filtered[T any](
+	ctx context.Context,
+	candidates []T,
+	selected []T,
+	key func(T) string,
+	reason func(T) string,
+) {
+	r := RecorderFromContext(ctx)
+	if r == nil || len(candidates) == len(selected) {
+		return
+	}
+	kept := make(map[string]struct{}, len(selected))
+	for _, s := range selected {
+		kept[key(s)] = struct{}{}
+	}
+	for _, c := range candidates {
+		if _, ok := kept[key(c)]; !ok {
+			r.Reject(key(c), reason(c))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package explain
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReject(t *testing.T) {
+	// No recorder; should not panic
+	Reject(t.Context(), "v1.0.0", "some reason")
+	require.False(t, Enabled(t.Context()))
+
+	r := NewRecorder()
+	ctx := ContextWithRecorder(t.Context(), r)
+	require.True(t, Enabled(ctx))
+	require.Same(t, r, RecorderFromContext(ctx))
+	Reject(ctx, "v1.0.0", "some reason")
+	Reject(ctx, "v2.0.0", "another reason")
+	require.Equal(
+		t,
+		[]Rejection{
+			{Candidate: "v1.0.0", Reason: "some reason"},
+			{Candidate: "v2.0.0", Reason: "another reason"},
+		},
+		r.Rejections(),
+	)
+}
+
+func TestFiltered(t *testing.T) {
+	candidates := []int{1, 2, 3, 4}
+	selected := []int{2, 4}
+	key := strconv.Itoa
+	reason := func(i int) string { return "odd number " + strconv.Itoa(i) }
+
+	t.Run("without recorder", func(t *testing.T) {
+		Filtered(context.Background(), candidates, selected, key, reason)
+	})
+
+	t.Run("with recorder", func(t *testing.T) {
+		r := NewRecorder()
+		Filtered(ContextWithRecorder(t.Context(), r), candidates, selected, key, reason)
+		require.Equal(
+			t,
+			[]Rejection{
+				{Candidate: "1", Reason: "odd number 1"},
+				{Candidate: "3", Reason: "odd number 3"},
+			},
+			r.Rejections(),
+		)
+	})
+
+	t.Run("nothing filtered", func(t *testing.T) {
+		r := NewRecorder()
+		Filtered(ContextWithRecorder(t.Context(), r), candidates, candidates, key, reason)
+		require.Empty(t, r.Rejections())
+	})
+}
@@ -0,0 +1,888 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsv4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/hashicorp/go-cleanhttp"
+	"golang.org/x/net/html"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+	kargonet "github.com/akuity/kargo/pkg/net"
+	"github.com/akuity/kargo/pkg/validation"
+)
+
+const (
+	// httpSubscriptionType is the SubscriptionType of generic subscriptions
+	// handled by the httpSubscriber.
+	httpSubscriptionType = "http"
+	// httpArtifactType is the ArtifactType of the ArtifactReferences discovered
+	// by the httpSubscriber.
+	httpArtifactType = "http"
+
+	// httpIndexFormatHTML is the format of an index that is an HTML document
+	// linking to artifacts, such as a web server's directory listing.
+	httpIndexFormatHTML = "html"
+	// httpIndexFormatJSON is the format of an index that is a JSON manifest
+	// listing artifacts.
+	httpIndexFormatJSON = "json"
+	// httpIndexFormatS3 is the format of an index that is the listing of the
+	// objects in an S3-compatible bucket.
+	httpIndexFormatS3 = "s3"
+
+	// httpSelectionStrategySemVer selects artifacts with the highest semantic
+	// versions.
+	httpSelectionStrategySemVer = "SemVer"
+	// httpSelectionStrategyLexical selects artifacts with the lexically highest
+	// versions.
+	httpSelectionStrategyLexical = "Lexical"
+	// httpSelectionStrategyNewestBuild selects the most recently modified
+	// artifacts.
+	httpSelectionStrategyNewestBuild = "NewestBuild"
+
+	// defaultHTTPVersionPattern is the regular expression used to extract
+	// versions from artifact names when a subscription does not specify one.
+	defaultHTTPVersionPattern = `v?[0-9]+\.[0-9]+\.[0-9]+`
+	// defaultS3Region is the region used to sign requests to S3-compatible
+	// storage when a subscription does not specify one.
+	defaultS3Region = "us-east-1"
+
+	// maxHTTPObjectsListed bounds the number of objects that are considered
+	// when discovering artifacts.
+	maxHTTPObjectsListed = 10000
+	// maxHTTPIndexSize bounds the size, in bytes, of an index (or of a single
+	// page of an S3 bucket listing).
+	maxHTTPIndexSize = 16 << 20
+	// httpRequestTimeout bounds the duration of each request made while
+	// discovering artifacts.
+	httpRequestTimeout = 30 * time.Second
+
+	// emptyPayloadHash is the hex encoded SHA-256 hash of an empty request
+	// body, which is required for signing requests to S3-compatible storage.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func init() {
+	DefaultSubscriberRegistry.MustRegister(SubscriberRegistration{
+		Predicate: func(
+			_ context.Context,
+			sub kargoapi.RepoSubscription,
+		) (bool, error) {
+			return sub.Subscription != nil &&
+				sub.Subscription.SubscriptionType == httpSubscriptionType, nil
+		},
+		Value: newHTTPSubscriber,
+	})
+}
+
+// httpSubscriptionConfig is the configuration of a generic subscription of
+// type "http".
+type httpSubscriptionConfig struct {
+	// URL is the URL of the index listing artifacts. For the "s3" format, it is
+	// the URL of the bucket, either path-style (https://endpoint/bucket) or
+	// virtual-hosted-style (https://bucket.endpoint).
+	URL string `json:"url"`
+	// Format is the format of the index. One of "html", "json" or "s3".
+	Format string `json:"format"`
+	// Prefix limits discovery to artifacts whose names begin with it. For the
+	// "s3" format, names are object keys.
+	Prefix string `json:"prefix,omitempty"`
+	// VersionPattern is a regular expression used to extract a version from
+	// each artifact's name. The submatch named "version" is used if there is
+	// one, the first submatch otherwise, and the entire match if there are no
+	// submatches. Artifacts whose names do not match are not discovered. For the
+	// "json" format, versions listed in the manifest take precedence.
+	VersionPattern string `json:"versionPattern,omitempty"`
+	// SemverConstraint limits discovery to artifacts whose versions are
+	// semantic versions satisfying the constraint.
+	SemverConstraint string `json:"semverConstraint,omitempty"`
+	// SelectionStrategy is the strategy used to order discovered artifacts. One
+	// of "SemVer" (the default), "Lexical" or "NewestBuild". "NewestBuild"
+	// relies on modification times, which "html" indices do not provide.
+	SelectionStrategy string `json:"selectionStrategy,omitempty"`
+	// Region is the region used to sign requests to S3-compatible storage. It
+	// is only applicable to the "s3" format and defaults to "us-east-1".
+	Region string `json:"region,omitempty"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when connecting to the server.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// httpObject is an artifact listed in an index. It is also the schema of the
+// entries of an index in the "json" format.
+type httpObject struct {
+	// Name is the name of the artifact. For the "s3" format, it is the object
+	// key.
+	Name string `json:"name"`
+	// URL is the URL the artifact can be downloaded from. In a JSON manifest, a
+	// relative URL is resolved against the URL of the manifest and, when
+	// absent, the URL is derived from Name.
+	URL string `json:"url,omitempty"`
+	// Version is the version of the artifact. It is only read from JSON
+	// manifests.
+	Version string `json:"version,omitempty"`
+	// ETag is the entity tag of the artifact.
+	ETag string `json:"etag,omitempty"`
+	// Checksum is a checksum of the artifact's content in the form
+	// <algorithm>:<hex digest>.
+	Checksum string `json:"checksum,omitempty"`
+	// Size is the size of the artifact in bytes.
+	Size int64 `json:"size,omitempty"`
+	// LastModified is the time the artifact was last modified.
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// httpArtifactMetadata is the metadata recorded on each ArtifactReference
+// discovered by the httpSubscriber.
+type httpArtifactMetadata struct {
+	// URL is the URL the artifact can be downloaded from, for instance using
+	// the http-download promotion step.
+	URL string `json:"url"`
+	// Name is the name of the artifact.
+	Name string `json:"name"`
+	// ETag is the entity tag of the artifact, if the server provides one.
+	ETag string `json:"etag,omitempty"`
+	// Checksum is a checksum of the artifact's content in the form
+	// <algorithm>:<hex digest>, if one is known.
+	Checksum string `json:"checksum,omitempty"`
+	// Size is the size of the artifact in bytes, if known.
+	Size int64 `json:"size,omitempty"`
+	// LastModified is the time the artifact was last modified, if known.
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// s3ChecksumHeaders maps the headers S3-compatible storage returns checksums
+// of an object's content in to the names of the checksum algorithms, in order
+// of preference.
+var s3ChecksumHeaders = []struct {
+	header    string
+	algorithm string
+}{
+	{header: "X-Amz-Checksum-Sha256", algorithm: "sha256"},
+	{header: "X-Amz-Checksum-Sha1", algorithm: "sha1"},
+	{header: "X-Amz-Checksum-Crc64nvme", algorithm: "crc64nvme"},
+	{header: "X-Amz-Checksum-Crc32c", algorithm: "crc32c"},
+	{header: "X-Amz-Checksum-Crc32", algorithm: "crc32"},
+}
+
+var (
+	supportedHTTPIndexFormats = []string{
+		httpIndexFormatHTML,
+		httpIndexFormatJSON,
+		httpIndexFormatS3,
+	}
+	supportedHTTPSelectionStrategies = []string{
+		httpSelectionStrategySemVer,
+		httpSelectionStrategyLexical,
+		httpSelectionStrategyNewestBuild,
+	}
+)
+
+// httpSubscriber is an implementation of the Subscriber interface that
+// discovers artifacts listed by an HTTP index or stored in an S3-compatible
+// bucket.
+type httpSubscriber struct {
+	credentialsDB credentials.Database
+}
+
+// newHTTPSubscriber returns an implementation of the Subscriber interface that
+// discovers artifacts listed by an HTTP index or stored in an S3-compatible
+// bucket.
+func newHTTPSubscriber(
+	_ context.Context,
+	credentialsDB credentials.Database,
+) (Subscriber, error) {
+	return &httpSubscriber{credentialsDB: credentialsDB}, nil
+}
+
+// ApplySubscriptionDefaults implements Subscriber.
+func (h *httpSubscriber) ApplySubscriptionDefaults(
+	context.Context,
+	*kargoapi.RepoSubscription,
+) error {
+	// Defaults for optional configuration are applied when the configuration is
+	// parsed.
+	return nil
+}
+
+// ValidateSubscription implements Subscriber.
+func (h *httpSubscriber) ValidateSubscription(
+	_ context.Context,
+	f *field.Path,
+	s kargoapi.RepoSubscription,
+) field.ErrorList {
+	f = f.Child("config")
+	cfg, err := parseHTTPSubscriptionConfig(s.Subscription)
+	if err != nil {
+		return field.ErrorList{field.Invalid(f, "", err.Error())}
+	}
+
+	var errs field.ErrorList
+
+	// Validate URL: MinLength=1, HTTP/S URL
+	if err := validation.MinLength(f.Child("url"), cfg.URL, 1); err != nil {
+		errs = append(errs, err)
+	} else if u, err := url.Parse(cfg.URL); err != nil ||
+		(u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(
+			f.Child("url"),
+			cfg.URL,
+			"must be a valid HTTP/S URL",
+		))
+	}
+
+	// Validate Format: Enum
+	if !slices.Contains(supportedHTTPIndexFormats, cfg.Format) {
+		errs = append(errs, field.NotSupported(
+			f.Child("format"),
+			cfg.Format,
+			supportedHTTPIndexFormats,
+		))
+	}
+
+	// Validate VersionPattern
+	if _, err := regexp.Compile(cfg.VersionPattern); err != nil {
+		errs = append(errs, field.Invalid(
+			f.Child("versionPattern"),
+			cfg.VersionPattern,
+			fmt.Sprintf("must be a valid regular expression: %v", err),
+		))
+	}
+
+	// Validate SemverConstraint
+	if err := validation.SemverConstraint(
+		f.Child("semverConstraint"),
+		cfg.SemverConstraint,
+	); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate SelectionStrategy: Enum
+	if !slices.Contains(supportedHTTPSelectionStrategies, cfg.SelectionStrategy) {
+		errs = append(errs, field.NotSupported(
+			f.Child("selectionStrategy"),
+			cfg.SelectionStrategy,
+			supportedHTTPSelectionStrategies,
+		))
+	} else if cfg.SelectionStrategy == httpSelectionStrategyNewestBuild &&
+		cfg.Format == httpIndexFormatHTML {
+		errs = append(errs, field.Invalid(
+			f.Child("selectionStrategy"),
+			cfg.SelectionStrategy,
+			`cannot be used with the "html" format, which does not provide `+
+				"modification times",
+		))
+	}
+
+	return errs
+}
+
+// DiscoverArtifacts implements Subscriber.
+func (h *httpSubscriber) DiscoverArtifacts(
+	ctx context.Context,
+	project string,
+	sub kargoapi.RepoSubscription,
+	_ any,
+) (any, error) {
+	if sub.Subscription == nil {
+		return nil, nil
+	}
+
+	cfg, err := parseHTTPSubscriptionConfig(sub.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithValues("url", cfg.URL)
+
+	creds, err := h.credentialsDB.Get(ctx, project, credentials.TypeHTTP, cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for %q: %w",
+			cfg.URL, err,
+		)
+	}
+	if creds != nil {
+		logger.Debug("obtained credentials")
+	} else {
+		logger.Debug("found no credentials")
+	}
+
+	client := newHTTPIndexClient(cfg, creds)
+
+	var objects []httpObject
+	switch cfg.Format {
+	case httpIndexFormatHTML:
+		objects, err = client.listHTML(ctx)
+	case httpIndexFormatJSON:
+		objects, err = client.listJSON(ctx)
+	case httpIndexFormatS3:
+		objects, err = client.listS3(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported index format %q", cfg.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing artifacts at %q: %w", cfg.URL, err)
+	}
+
+	selected, err := selectHTTPObjects(cfg, objects)
+	if err != nil {
+		return nil, err
+	}
+	selected = trimSlice(selected, int(sub.Subscription.DiscoveryLimit))
+	if len(selected) == 0 {
+		logger.Debug("discovered no artifacts")
+	} else {
+		logger.Debug("discovered artifacts", "count", len(selected))
+	}
+
+	refs := make([]kargoapi.ArtifactReference, len(selected))
+	for i, s := range selected {
+		obj := s.object
+		// Indices rarely list everything worth recording about an artifact, so
+		// the server is asked for the rest. Failing to obtain it is not fatal.
+		if obj.ETag == "" || (cfg.Format == httpIndexFormatS3 && obj.Checksum == "") {
+			if err = client.head(ctx, &obj); err != nil {
+				logger.Debug(
+					"error obtaining artifact details",
+					"artifact", obj.Name,
+					"error", err.Error(),
+				)
+			}
+		}
+		metadata, err := json.Marshal(httpArtifactMetadata{
+			URL:          obj.URL,
+			Name:         obj.Name,
+			ETag:         obj.ETag,
+			Checksum:     obj.Checksum,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error marshaling metadata of artifact %q: %w",
+				obj.Name, err,
+			)
+		}
+		refs[i] = kargoapi.ArtifactReference{
+			ArtifactType:     httpArtifactType,
+			SubscriptionName: sub.Name,
+			Version:          s.version,
+			Metadata:         &apiextensionsv1.JSON{Raw: metadata},
+		}
+	}
+	return kargoapi.DiscoveryResult{
+		SubscriptionName:   sub.Name,
+		ArtifactReferences: refs,
+	}, nil
+}
+
+// selectedHTTPObject is an httpObject selected for discovery along with its
+// version.
+type selectedHTTPObject struct {
+	object  httpObject
+	version string
+	semver  *semver.Version
+}
+
+// selectHTTPObjects filters the provided objects according to the provided
+// configuration and returns those remaining along with their versions, ordered
+// according to the configured selection strategy. When more than one object
+// has the same version, only the first of them in that order is returned.
+func selectHTTPObjects(
+	cfg httpSubscriptionConfig,
+	objects []httpObject,
+) ([]selectedHTTPObject, error) {
+	versionRegex, err := regexp.Compile(cfg.VersionPattern)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing version pattern %q: %w",
+			cfg.VersionPattern, err,
+		)
+	}
+	var constraint *semver.Constraints
+	if cfg.SemverConstraint != "" {
+		if constraint, err = semver.NewConstraint(cfg.SemverConstraint); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing semver constraint %q: %w",
+				cfg.SemverConstraint, err,
+			)
+		}
+	}
+	requireSemver := cfg.SelectionStrategy == httpSelectionStrategySemVer ||
+		constraint != nil
+
+	selected := make([]selectedHTTPObject, 0, len(objects))
+	for _, obj := range objects {
+		if !strings.HasPrefix(obj.Name, cfg.Prefix) {
+			continue
+		}
+		version := obj.Version
+		if version == "" {
+			if version = extractVersion(versionRegex, obj.Name); version == "" {
+				continue
+			}
+		}
+		s := selectedHTTPObject{object: obj, version: version}
+		if requireSemver {
+			if s.semver, err = semver.NewVersion(version); err != nil {
+				continue
+			}
+			if constraint != nil && !constraint.Check(s.semver) {
+				continue
+			}
+		}
+		selected = append(selected, s)
+	}
+
+	slices.SortStableFunc(selected, func(lhs, rhs selectedHTTPObject) int {
+		switch cfg.SelectionStrategy {
+		case httpSelectionStrategyLexical:
+			return strings.Compare(rhs.version, lhs.version)
+		case httpSelectionStrategyNewestBuild:
+			switch {
+			case lhs.object.LastModified == nil && rhs.object.LastModified == nil:
+				return 0
+			case lhs.object.LastModified == nil:
+				return 1
+			case rhs.object.LastModified == nil:
+				return -1
+			}
+			return rhs.object.LastModified.Compare(*lhs.object.LastModified)
+		default:
+			return rhs.semver.Compare(lhs.semver)
+		}
+	})
+
+	versions := make(map[string]struct{}, len(selected))
+	return slices.DeleteFunc(selected, func(s selectedHTTPObject) bool {
+		if _, seen := versions[s.version]; seen {
+			return true
+		}
+		versions[s.version] = struct{}{}
+		return false
+	}), nil
+}
+
+// extractVersion extracts a version from the provided artifact name using the
+// provided regular expression. It returns an empty string if the name does not
+// match.
+func extractVersion(versionRegex *regexp.Regexp, name string) string {
+	matches := versionRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return ""
+	}
+	if i := versionRegex.SubexpIndex("version"); i > 0 {
+		return matches[i]
+	}
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return matches[0]
+}
+
+// httpIndexClient makes the requests needed to list and inspect the artifacts
+// of an index.
+type httpIndexClient struct {
+	cfg    httpSubscriptionConfig
+	creds  *credentials.Credentials
+	client *http.Client
+}
+
+// newHTTPIndexClient returns an httpIndexClient for the index described by the
+// provided configuration. The provided credentials are optional.
+func newHTTPIndexClient(
+	cfg httpSubscriptionConfig,
+	creds *credentials.Credentials,
+) *httpIndexClient {
+	httpTransport := kargonet.SafeTransport(cleanhttp.DefaultTransport())
+	if cfg.InsecureSkipTLSVerify {
+		httpTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, // nolint: gosec
+		}
+	}
+	return &httpIndexClient{
+		cfg:   cfg,
+		creds: creds,
+		client: &http.Client{
+			Transport: httpTransport,
+			Timeout:   httpRequestTimeout,
+		},
+	}
+}
+
+// listHTML lists the artifacts linked to by an HTML index. Links to
+// directories, to the page itself and to other pages of the server's UI (for
+// instance, links that only change the sort order) are ignored.
+func (c *httpIndexClient) listHTML(ctx context.Context) ([]httpObject, error) {
+	body, err := c.get(ctx, c.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL %q: %w", c.cfg.URL, err)
+	}
+
+	var objects []httpObject
+	seen := map[string]struct{}{}
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for len(objects) < maxHTTPObjectsListed {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err = tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("error parsing HTML index: %w", err)
+			}
+			return objects, nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tag, hasAttrs := tokenizer.TagName()
+			if string(tag) != "a" || !hasAttrs {
+				continue
+			}
+			var href string
+			for {
+				key, val, more := tokenizer.TagAttr()
+				if string(key) == "href" {
+					href = string(val)
+				}
+				if !more {
+					break
+				}
+			}
+			if href == "" || strings.HasPrefix(href, "?") ||
+				strings.HasPrefix(href, "#") || strings.HasSuffix(href, "/") {
+				continue
+			}
+			ref, err := url.Parse(href)
+			if err != nil {
+				continue
+			}
+			u := baseURL.ResolveReference(ref)
+			u.Fragment = ""
+			if u.RawQuery != "" || (u.Scheme != "http" && u.Scheme != "https") {
+				continue
+			}
+			if _, ok := seen[u.String()]; ok {
+				continue
+			}
+			seen[u.String()] = struct{}{}
+			objects = append(objects, httpObject{
+				Name: path.Base(u.Path),
+				URL:  u.String(),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// listJSON lists the artifacts in a JSON manifest. The manifest is either an
+// array of httpObjects or an object with such an array as its "artifacts"
+// field.
+func (c *httpIndexClient) listJSON(ctx context.Context) ([]httpObject, error) {
+	body, err := c.get(ctx, c.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	var objects []httpObject
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		err = json.Unmarshal(body, &objects)
+	} else {
+		manifest := struct {
+			Artifacts []httpObject `json:"artifacts"`
+		}{}
+		err = json.Unmarshal(body, &manifest)
+		objects = manifest.Artifacts
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON index: %w", err)
+	}
+
+	baseURL, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL %q: %w", c.cfg.URL, err)
+	}
+	objects = trimSlice(objects, maxHTTPObjectsListed)
+	for i := range objects {
+		ref := objects[i].URL
+		if ref == "" {
+			ref = (&url.URL{Path: objects[i].Name}).String()
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing URL of artifact %q: %w",
+				objects[i].Name, err,
+			)
+		}
+		objects[i].URL = baseURL.ResolveReference(u).String()
+		objects[i].ETag = normalizeETag(objects[i].ETag)
+	}
+	return objects, nil
+}
+
+// s3ListBucketResult is the response to an S3 ListObjectsV2 request.
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// listS3 lists the objects in an S3-compatible bucket whose keys begin with
+// the configured prefix.
+func (c *httpIndexClient) listS3(ctx context.Context) ([]httpObject, error) {
+	bucketURL := strings.TrimSuffix(c.cfg.URL, "/")
+	var objects []httpObject
+	var continuationToken string
+	for len(objects) < maxHTTPObjectsListed {
+		query := url.Values{"list-type": []string{"2"}}
+		if c.cfg.Prefix != "" {
+			query.Set("prefix", c.cfg.Prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		body, err := c.get(ctx, bucketURL+"/?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListBucketResult
+		if err = xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("error parsing bucket listing: %w", err)
+		}
+		for _, obj := range result.Contents {
+			if strings.HasSuffix(obj.Key, "/") {
+				continue
+			}
+			lastModified := obj.LastModified
+			objects = append(objects, httpObject{
+				Name:         obj.Key,
+				URL:          bucketURL + "/" + escapeS3Key(obj.Key),
+				ETag:         normalizeETag(obj.ETag),
+				Size:         obj.Size,
+				LastModified: &lastModified,
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+	}
+	return trimSlice(objects, maxHTTPObjectsListed), nil
+}
+
+// head completes the provided object's details using the headers of the
+// response to a HEAD request for it. Details that are already known are left
+// untouched.
+func (c *httpIndexClient) head(ctx context.Context, obj *httpObject) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, obj.URL, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request for %q: %w", obj.URL, err)
+	}
+	if c.cfg.Format == httpIndexFormatS3 {
+		req.Header.Set("X-Amz-Checksum-Mode", "ENABLED")
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if obj.ETag == "" {
+		obj.ETag = normalizeETag(resp.Header.Get("ETag"))
+	}
+	if obj.Checksum == "" {
+		obj.Checksum = s3Checksum(resp.Header)
+	}
+	if obj.Size == 0 && resp.ContentLength > 0 {
+		obj.Size = resp.ContentLength
+	}
+	if obj.LastModified == nil {
+		if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			obj.LastModified = &t
+		}
+	}
+	return nil
+}
+
+// get returns the body of the response to a GET request for the provided URL.
+func (c *httpIndexClient) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %q: %w", u, err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPIndexSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %q: %w", u, err)
+	}
+	if len(body) > maxHTTPIndexSize {
+		return nil, fmt.Errorf(
+			"response from %q exceeds limit of %d bytes",
+			u, maxHTTPIndexSize,
+		)
+	}
+	return body, nil
+}
+
+// do authenticates and sends the provided request, returning an error if the
+// response does not have a successful status. Credentials are only attached to
+// requests for the same scheme and host as the index itself, since an index may
+// link to artifacts hosted anywhere and must not be able to harvest them.
+func (c *httpIndexClient) do(req *http.Request) (*http.Response, error) {
+	if c.creds != nil && c.isIndexOrigin(req.URL) {
+		if c.cfg.Format == httpIndexFormatS3 {
+			// S3-compatible storage expects the access key ID and secret access
+			// key to be used to sign requests.
+			req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+			region := c.cfg.Region
+			if region == "" {
+				region = defaultS3Region
+			}
+			if err := awsv4.NewSigner().SignHTTP(
+				req.Context(),
+				aws.Credentials{
+					AccessKeyID:     c.creds.Username,
+					SecretAccessKey: c.creds.Password,
+				},
+				req,
+				emptyPayloadHash,
+				"s3",
+				region,
+				time.Now(),
+			); err != nil {
+				return nil, fmt.Errorf("error signing request for %q: %w", req.URL, err)
+			}
+		} else {
+			req.SetBasicAuth(c.creds.Username, c.creds.Password)
+		}
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting %q: %w", req.URL, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf(
+			"request for %q failed with status %d",
+			req.URL, resp.StatusCode,
+		)
+	}
+	return resp, nil
+}
+
+// isIndexOrigin returns true if the provided URL has the same scheme and host
+// as the index.
+func (c *httpIndexClient) isIndexOrigin(u *url.URL) bool {
+	indexURL, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, indexURL.Scheme) &&
+		strings.EqualFold(u.Hostname(), indexURL.Hostname()) &&
+		urlPort(u) == urlPort(indexURL)
+}
+
+// urlPort returns the port of the provided URL, falling back to the default
+// port for its scheme if none is explicitly specified.
+func urlPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
+
+// s3Checksum returns the checksum of an object's content found in the provided
+// response headers in the form <algorithm>:<hex digest>. It returns an empty
+// string if there is none. Checksums of multipart uploads, which are checksums
+// of the parts' checksums rather than of the content, are ignored.
+func s3Checksum(header http.Header) string {
+	for _, h := range s3ChecksumHeaders {
+		val := header.Get(h.header)
+		if val == "" || strings.Contains(val, "-") {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			continue
+		}
+		return h.algorithm + ":" + hex.EncodeToString(sum)
+	}
+	return ""
+}
+
+// normalizeETag strips the quotes and weakness indicator from the provided
+// entity tag.
+func normalizeETag(etag string) string {
+	return strings.Trim(strings.TrimPrefix(etag, "W/"), `"`)
+}
+
+// escapeS3Key escapes each segment of the provided object key for use in the
+// path of a URL.
+func escapeS3Key(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// parseHTTPSubscriptionConfig parses the configuration of the provided generic
+// subscription as an httpSubscriptionConfig and applies defaults to optional
+// fields.
+func parseHTTPSubscriptionConfig(
+	sub *kargoapi.Subscription,
+) (httpSubscriptionConfig, error) {
+	var cfg httpSubscriptionConfig
+	if sub == nil || sub.Config == nil || len(sub.Config.Raw) == 0 {
+		return cfg, errors.New("configuration is required")
+	}
+	dec := json.NewDecoder(bytes.NewReader(sub.Config.Raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	if cfg.VersionPattern == "" {
+		cfg.VersionPattern = defaultHTTPVersionPattern
+	}
+	if cfg.SelectionStrategy == "" {
+		cfg.SelectionStrategy = httpSelectionStrategySemVer
+	}
+	return cfg, nil
+}
@@ -0,0 +1,555 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func newHTTPSubscription(t *testing.T, cfg map[string]any) kargoapi.RepoSubscription {
+	t.Helper()
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return kargoapi.RepoSubscription{
+		Name: "fake-sub",
+		Subscription: &kargoapi.Subscription{
+			SubscriptionType: httpSubscriptionType,
+			Config:           &apiextensionsv1.JSON{Raw: raw},
+			DiscoveryLimit:   20,
+		},
+	}
+}
+
+func Test_httpSubscriber_registration(t *testing.T) {
+	reg, err := DefaultSubscriberRegistry.Get(
+		t.Context(),
+		newHTTPSubscription(t, map[string]any{
+			"url":    "https://example.com/artifacts/",
+			"format": "html",
+		}),
+	)
+	require.NoError(t, err)
+	subscriber, err := reg.Value(t.Context(), nil)
+	require.NoError(t, err)
+	require.IsType(t, &httpSubscriber{}, subscriber)
+}
+
+func Test_httpSubscriber_ValidateSubscription(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.RepoSubscription
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "valid",
+			sub: newHTTPSubscription(t, map[string]any{
+				"url":               "https://minio.example.com/builds",
+				"format":            "s3",
+				"prefix":            "tool/",
+				"versionPattern":    `tool-(?P<version>[0-9.]+)\.tar\.gz`,
+				"semverConstraint":  "^1.0.0",
+				"selectionStrategy": "NewestBuild",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "missing config",
+			sub: kargoapi.RepoSubscription{
+				Subscription: &kargoapi.Subscription{
+					SubscriptionType: httpSubscriptionType,
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].http.config", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "configuration is required")
+			},
+		},
+		{
+			name: "unknown field",
+			sub: newHTTPSubscription(t, map[string]any{
+				"url":    "https://example.com/artifacts/",
+				"format": "html",
+				"bogus":  true,
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Contains(t, errs[0].Detail, `unknown field "bogus"`)
+			},
+		},
+		{
+			name: "invalid fields",
+			sub: newHTTPSubscription(t, map[string]any{
+				"url":               "s3://builds",
+				"format":            "xml",
+				"versionPattern":    "(",
+				"semverConstraint":  "not a constraint",
+				"selectionStrategy": "Digest",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(t, []string{
+					"spec.subscriptions[0].http.config.url",
+					"spec.subscriptions[0].http.config.format",
+					"spec.subscriptions[0].http.config.versionPattern",
+					"spec.subscriptions[0].http.config.semverConstraint",
+					"spec.subscriptions[0].http.config.selectionStrategy",
+				}, fields)
+			},
+		},
+		{
+			name: "missing url and format",
+			sub:  newHTTPSubscription(t, map[string]any{}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 2)
+				require.Equal(t, "spec.subscriptions[0].http.config.url", errs[0].Field)
+				require.Equal(t, "spec.subscriptions[0].http.config.format", errs[1].Field)
+			},
+		},
+		{
+			name: "NewestBuild with html index",
+			sub: newHTTPSubscription(t, map[string]any{
+				"url":               "https://example.com/artifacts/",
+				"format":            "html",
+				"selectionStrategy": "NewestBuild",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(
+					t,
+					"spec.subscriptions[0].http.config.selectionStrategy",
+					errs[0].Field,
+				)
+				require.Contains(t, errs[0].Detail, "does not provide modification times")
+			},
+		},
+	}
+	s := &httpSubscriber{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				s.ValidateSubscription(
+					t.Context(),
+					field.NewPath("spec").Child("subscriptions").Index(0).Child("http"),
+					testCase.sub,
+				),
+			)
+		})
+	}
+}
+
+func Test_httpSubscriber_DiscoverArtifacts(t *testing.T) {
+	noCredsDB := &credentials.FakeDB{
+		GetFn: func(
+			context.Context,
+			string,
+			credentials.Type,
+			string,
+		) (*credentials.Credentials, error) {
+			return nil, nil
+		},
+	}
+
+	discover := func(
+		t *testing.T,
+		credsDB credentials.Database,
+		sub kargoapi.RepoSubscription,
+	) ([]kargoapi.ArtifactReference, []httpArtifactMetadata) {
+		t.Helper()
+		res, err := (&httpSubscriber{credentialsDB: credsDB}).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			sub,
+			nil,
+		)
+		require.NoError(t, err)
+		result, ok := res.(kargoapi.DiscoveryResult)
+		require.True(t, ok)
+		require.Equal(t, "fake-sub", result.SubscriptionName)
+		metadata := make([]httpArtifactMetadata, len(result.ArtifactReferences))
+		for i, ref := range result.ArtifactReferences {
+			require.Equal(t, httpArtifactType, ref.ArtifactType)
+			require.Equal(t, "fake-sub", ref.SubscriptionName)
+			require.NoError(t, json.Unmarshal(ref.Metadata.Raw, &metadata[i]))
+		}
+		return result.ArtifactReferences, metadata
+	}
+	versions := func(refs []kargoapi.ArtifactReference) []string {
+		v := make([]string, len(refs))
+		for i, ref := range refs {
+			v[i] = ref.Version
+		}
+		return v
+	}
+
+	t.Run("html index", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /artifacts/", func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "fake-user", username)
+			require.Equal(t, "fake-pass", password)
+			_, _ = fmt.Fprint(w, `<html><body>
+<a href="../">../</a>
+<a href="?C=M;O=A">Last modified</a>
+<a href="old/">old/</a>
+<a href="tool-1.0.0.tar.gz">tool-1.0.0.tar.gz</a>
+<a href="tool-1.10.0.tar.gz">tool-1.10.0.tar.gz</a>
+<a href="/artifacts/tool-1.2.0.tar.gz">tool-1.2.0.tar.gz</a>
+<a href="tool-1.2.0.tar.gz">tool-1.2.0.tar.gz</a>
+<a href="other-2.0.0.tar.gz">other-2.0.0.tar.gz</a>
+<a href="tool-latest.tar.gz">tool-latest.tar.gz</a>
+</body></html>`)
+		})
+		mux.HandleFunc("HEAD /artifacts/{name}", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", fmt.Sprintf(`"etag-%s"`, r.PathValue("name")))
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		sub := newHTTPSubscription(t, map[string]any{
+			"url":              srv.URL + "/artifacts/",
+			"format":           "html",
+			"prefix":           "tool-",
+			"semverConstraint": ">=1.1.0",
+		})
+		refs, metadata := discover(t, &credentials.FakeDB{
+			GetFn: func(
+				_ context.Context,
+				project string,
+				credType credentials.Type,
+				repoURL string,
+			) (*credentials.Credentials, error) {
+				require.Equal(t, "fake-project", project)
+				require.Equal(t, credentials.TypeHTTP, credType)
+				require.Equal(t, srv.URL+"/artifacts/", repoURL)
+				return &credentials.Credentials{
+					Username: "fake-user",
+					Password: "fake-pass",
+				}, nil
+			},
+		}, sub)
+		require.Equal(t, []string{"1.10.0", "1.2.0"}, versions(refs))
+		lastModified := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+		require.Equal(t, httpArtifactMetadata{
+			URL:          srv.URL + "/artifacts/tool-1.10.0.tar.gz",
+			Name:         "tool-1.10.0.tar.gz",
+			ETag:         "etag-tool-1.10.0.tar.gz",
+			LastModified: &lastModified,
+		}, metadata[0])
+	})
+
+	t.Run("html index linking to another host", func(t *testing.T) {
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Empty(
+				t,
+				r.Header.Get("Authorization"),
+				"credentials must not be sent to other hosts",
+			)
+			w.Header().Set("ETag", `"etag-other"`)
+		}))
+		t.Cleanup(other.Close)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "fake-user", username)
+			require.Equal(t, "fake-pass", password)
+			_, _ = fmt.Fprintf(
+				w,
+				`<html><body><a href="%s/tool-1.0.0.tar.gz">tool-1.0.0.tar.gz</a></body></html>`,
+				other.URL,
+			)
+		}))
+		t.Cleanup(srv.Close)
+
+		refs, metadata := discover(t, &credentials.FakeDB{
+			GetFn: func(
+				context.Context,
+				string,
+				credentials.Type,
+				string,
+			) (*credentials.Credentials, error) {
+				return &credentials.Credentials{
+					Username: "fake-user",
+					Password: "fake-pass",
+				}, nil
+			},
+		}, newHTTPSubscription(t, map[string]any{
+			"url":    srv.URL + "/",
+			"format": "html",
+		}))
+		require.Equal(t, []string{"1.0.0"}, versions(refs))
+		require.Equal(t, other.URL+"/tool-1.0.0.tar.gz", metadata[0].URL)
+		require.Equal(t, "etag-other", metadata[0].ETag)
+	})
+
+	t.Run("json index", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method, "no HEAD requests are expected")
+			_, _ = fmt.Fprint(w, `{"artifacts": [
+  {"name": "build-a.zip", "version": "2024.01.1", "etag": "a", "lastModified": "2024-01-01T00:00:00Z"},
+  {
+    "name": "build-b.zip",
+    "url": "https://cdn.example.com/b.zip",
+    "version": "2024.03.1",
+    "etag": "b",
+    "checksum": "sha256:abc",
+    "size": 42,
+    "lastModified": "2024-03-01T00:00:00Z"
+  },
+  {
+    "name": "build-c.zip",
+    "url": "nested/c.zip",
+    "version": "2024.02.1",
+    "etag": "\"c\"",
+    "lastModified": "2024-02-01T00:00:00Z"
+  }
+]}`)
+		}))
+		t.Cleanup(srv.Close)
+
+		sub := newHTTPSubscription(t, map[string]any{
+			"url":               srv.URL + "/builds/index.json",
+			"format":            "json",
+			"selectionStrategy": "NewestBuild",
+		})
+		sub.Subscription.DiscoveryLimit = 2
+		refs, metadata := discover(t, noCredsDB, sub)
+		require.Equal(t, []string{"2024.03.1", "2024.02.1"}, versions(refs))
+		lastModified := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, httpArtifactMetadata{
+			URL:          "https://cdn.example.com/b.zip",
+			Name:         "build-b.zip",
+			ETag:         "b",
+			Checksum:     "sha256:abc",
+			Size:         42,
+			LastModified: &lastModified,
+		}, metadata[0])
+		require.Equal(t, srv.URL+"/builds/nested/c.zip", metadata[1].URL)
+		require.Equal(t, "c", metadata[1].ETag)
+	})
+
+	t.Run("json array index with lexical ordering", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("ETag", `"fallback"`)
+			_, _ = fmt.Fprint(w, `[
+  {"name": "build-20240101.zip"},
+  {"name": "build-20240301.zip"},
+  {"name": "readme.txt"}
+]`)
+		}))
+		t.Cleanup(srv.Close)
+
+		refs, metadata := discover(t, noCredsDB, newHTTPSubscription(t, map[string]any{
+			"url":               srv.URL + "/index.json",
+			"format":            "json",
+			"versionPattern":    `build-([0-9]+)\.zip`,
+			"selectionStrategy": "Lexical",
+		}))
+		require.Equal(t, []string{"20240301", "20240101"}, versions(refs))
+		require.Equal(t, srv.URL+"/build-20240301.zip", metadata[0].URL)
+		require.Equal(t, "fallback", metadata[0].ETag)
+	})
+
+	t.Run("s3 bucket", func(t *testing.T) {
+		var listRequests int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.True(t, strings.HasPrefix(
+				r.Header.Get("Authorization"),
+				"AWS4-HMAC-SHA256 Credential=fake-access-key/",
+			))
+			require.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request")
+			require.Equal(t, emptyPayloadHash, r.Header.Get("X-Amz-Content-Sha256"))
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/builds/":
+				listRequests++
+				require.Equal(t, "2", r.URL.Query().Get("list-type"))
+				require.Equal(t, "tool/", r.URL.Query().Get("prefix"))
+				w.Header().Set("Content-Type", "application/xml")
+				if r.URL.Query().Get("continuation-token") == "" {
+					_, _ = fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Name>builds</Name>
+  <Contents>
+    <Key>tool/</Key>
+    <LastModified>2024-01-01T00:00:00.000Z</LastModified>
+    <ETag>&quot;dir&quot;</ETag>
+    <Size>0</Size>
+  </Contents>
+  <Contents>
+    <Key>tool/tool-1.0.0.tar.gz</Key>
+    <LastModified>2024-01-01T00:00:00.000Z</LastModified>
+    <ETag>&quot;etag-1.0.0&quot;</ETag>
+    <Size>100</Size>
+  </Contents>
+  <IsTruncated>true</IsTruncated>
+  <NextContinuationToken>next</NextContinuationToken>
+</ListBucketResult>`)
+					return
+				}
+				require.Equal(t, "next", r.URL.Query().Get("continuation-token"))
+				_, _ = fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Name>builds</Name>
+  <Contents>
+    <Key>tool/tool 1.1.0.tar.gz</Key>
+    <LastModified>2024-02-01T00:00:00.000Z</LastModified>
+    <ETag>&quot;etag-1.1.0&quot;</ETag>
+    <Size>200</Size>
+  </Contents>
+  <IsTruncated>false</IsTruncated>
+</ListBucketResult>`)
+			case r.Method == http.MethodHead && r.URL.Path == "/builds/tool/tool 1.1.0.tar.gz":
+				require.Equal(t, "ENABLED", r.Header.Get("X-Amz-Checksum-Mode"))
+				// "hello" hashed with SHA-256, base64 encoded
+				w.Header().Set(
+					"X-Amz-Checksum-Sha256",
+					"LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=",
+				)
+			case r.Method == http.MethodHead && r.URL.Path == "/builds/tool/tool-1.0.0.tar.gz":
+				// A multipart upload
+				w.Header().Set("X-Amz-Checksum-Sha256", "abc=-2")
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(srv.Close)
+
+		refs, metadata := discover(t, &credentials.FakeDB{
+			GetFn: func(
+				context.Context,
+				string,
+				credentials.Type,
+				string,
+			) (*credentials.Credentials, error) {
+				return &credentials.Credentials{
+					Username: "fake-access-key",
+					Password: "fake-secret-key",
+				}, nil
+			},
+		}, newHTTPSubscription(t, map[string]any{
+			"url":            srv.URL + "/builds",
+			"format":         "s3",
+			"prefix":         "tool/",
+			"region":         "eu-west-1",
+			"versionPattern": `tool[- ](?P<version>[0-9.]+)\.tar\.gz$`,
+		}))
+		require.Equal(t, 2, listRequests)
+		require.Equal(t, []string{"1.1.0", "1.0.0"}, versions(refs))
+		lastModified := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, httpArtifactMetadata{
+			URL:          srv.URL + "/builds/tool/tool%201.1.0.tar.gz",
+			Name:         "tool/tool 1.1.0.tar.gz",
+			ETag:         "etag-1.1.0",
+			Checksum:     "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+			Size:         200,
+			LastModified: &lastModified,
+		}, metadata[0])
+		require.Empty(t, metadata[1].Checksum)
+	})
+
+	t.Run("error listing artifacts", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		_, err := (&httpSubscriber{credentialsDB: noCredsDB}).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newHTTPSubscription(t, map[string]any{
+				"url":    srv.URL,
+				"format": "json",
+			}),
+			nil,
+		)
+		require.ErrorContains(t, err, "error listing artifacts")
+		require.ErrorContains(t, err, "failed with status 403")
+	})
+}
+
+func Test_selectHTTPObjects(t *testing.T) {
+	objects := []httpObject{
+		{Name: "tool-1.0.0-linux.tar.gz"},
+		{Name: "tool-1.0.0-darwin.tar.gz"},
+		{Name: "tool-2.0.0-rc.1-linux.tar.gz"},
+		{Name: "tool-nightly-linux.tar.gz"},
+		{Name: "tool-1.1.0-linux.tar.gz", Version: "1.1.0-manifest"},
+	}
+
+	selected, err := selectHTTPObjects(httpSubscriptionConfig{
+		VersionPattern:    defaultHTTPVersionPattern,
+		SelectionStrategy: httpSelectionStrategySemVer,
+	}, objects)
+	require.NoError(t, err)
+	names := make([]string, len(selected))
+	for i, s := range selected {
+		names[i] = s.object.Name
+	}
+	// Versions from manifests take precedence and objects sharing a version
+	// are only selected once.
+	require.Equal(t, []string{
+		"tool-2.0.0-rc.1-linux.tar.gz",
+		"tool-1.1.0-linux.tar.gz",
+		"tool-1.0.0-linux.tar.gz",
+	}, names)
+
+	_, err = selectHTTPObjects(httpSubscriptionConfig{VersionPattern: "("}, objects)
+	require.ErrorContains(t, err, "error parsing version pattern")
+}
+
+func Test_extractVersion(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		name     string
+		expected string
+	}{
+		{defaultHTTPVersionPattern, "tool-v1.2.3.tar.gz", "v1.2.3"},
+		{`tool-([0-9]+)-(?P<version>[0-9.]+)\.zip`, "tool-7-1.2.zip", "1.2"},
+		{`tool-([0-9]+)\.zip`, "tool-42.zip", "42"},
+		{`[0-9]+`, "build-42.zip", "42"},
+		{`tool-([0-9]+)\.zip`, "other.zip", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				extractVersion(regexp.MustCompile(testCase.pattern), testCase.name),
+			)
+		})
+	}
+}
+
+func Test_s3Checksum(t *testing.T) {
+	header := http.Header{}
+	require.Empty(t, s3Checksum(header))
+
+	header.Set("X-Amz-Checksum-Crc32", "DUoRhQ==")
+	require.Equal(t, "crc32:0d4a1185", s3Checksum(header))
+
+	header.Set("X-Amz-Checksum-Sha1", "qvTGHdzF6KLavt4PO0gs2a6pQ00=")
+	require.Equal(t, "sha1:aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", s3Checksum(header))
+}
@@ -0,0 +1,457 @@
+package subscription
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// mavenSubscriptionType is the SubscriptionType of generic subscriptions to
+	// Maven repositories. It is also the ArtifactType of the ArtifactReferences
+	// discovered by them.
+	mavenSubscriptionType = "maven"
+
+	// defaultMavenRegistryURL is the URL of Maven Central.
+	defaultMavenRegistryURL = "https://repo.maven.apache.org/maven2"
+	// defaultMavenPackaging is the packaging of Maven artifacts when a
+	// subscription does not specify one.
+	defaultMavenPackaging = "jar"
+)
+
+var (
+	// mavenCoordinateRegex matches Maven coordinates of the form
+	// groupId:artifactId.
+	mavenCoordinateRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+:[A-Za-z0-9_.-]+$`)
+	// mavenPackagingRegex matches valid Maven packaging values.
+	mavenPackagingRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+func init() {
+	registerPackageSubscriber[mavenVersion](mavenSubscriptionType, mavenRegistry{})
+}
+
+// mavenMetadata is the subset of a Maven repository's maven-metadata.xml
+// document for an artifact that is needed to discover its versions.
+type mavenMetadata struct {
+	Versioning struct {
+		Versions []string `xml:"versions>version"`
+	} `xml:"versioning"`
+}
+
+// mavenRegistry is the packageRegistry implementation for Maven repositories.
+// Versions of Maven artifacts are ordered the way Maven itself orders them.
+type mavenRegistry struct{}
+
+func (mavenRegistry) defaultRegistryURL() string {
+	return defaultMavenRegistryURL
+}
+
+func (mavenRegistry) validateConfig(
+	f *field.Path,
+	cfg packageSubscriptionConfig,
+) field.ErrorList {
+	var errs field.ErrorList
+	if cfg.Package != "" && !mavenCoordinateRegex.MatchString(cfg.Package) {
+		errs = append(errs, field.Invalid(
+			f.Child("package"),
+			cfg.Package,
+			"must be of the form groupId:artifactId",
+		))
+	}
+	if cfg.Packaging != "" && !mavenPackagingRegex.MatchString(cfg.Packaging) {
+		errs = append(errs, field.Invalid(
+			f.Child("packaging"),
+			cfg.Packaging,
+			"must be a valid Maven packaging",
+		))
+	}
+	return errs
+}
+
+func (mavenRegistry) parseVersion(version string) (mavenVersion, error) {
+	return parseMavenVersion(version)
+}
+
+func (mavenRegistry) compareVersions(lhs, rhs mavenVersion) int {
+	return lhs.compare(rhs)
+}
+
+func (mavenRegistry) isPrerelease(version mavenVersion) bool {
+	return version.isPrerelease()
+}
+
+func (mavenRegistry) parseConstraint(constraint string) (func(mavenVersion) bool, error) {
+	r, err := parseMavenVersionRange(constraint)
+	if err != nil {
+		return nil, err
+	}
+	return r.contains, nil
+}
+
+func (mavenRegistry) listVersions(
+	ctx context.Context,
+	client *packageRegistryClient,
+	cfg packageSubscriptionConfig,
+) ([]packageVersion, error) {
+	artifactURL := mavenArtifactURL(cfg.RegistryURL, cfg.Package)
+	body, _, err := client.get(ctx, artifactURL+"/maven-metadata.xml", "")
+	if err != nil {
+		return nil, err
+	}
+	var metadata mavenMetadata
+	if err = xml.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("error parsing maven-metadata.xml: %w", err)
+	}
+
+	packaging := cfg.Packaging
+	if packaging == "" {
+		packaging = defaultMavenPackaging
+	}
+	_, artifactID, _ := strings.Cut(cfg.Package, ":")
+	versions := make([]packageVersion, len(metadata.Versioning.Versions))
+	for i, version := range metadata.Versioning.Versions {
+		versions[i] = packageVersion{
+			version: version,
+			metadata: packageMetadata{
+				RegistryURL: cfg.RegistryURL,
+				Package:     cfg.Package,
+				URL: fmt.Sprintf(
+					"%s/%s/%s-%s.%s",
+					artifactURL, version, artifactID, version, packaging,
+				),
+			},
+		}
+	}
+	return versions, nil
+}
+
+// completeMetadata obtains the SHA-1 checksum Maven repositories publish next
+// to each file, since maven-metadata.xml only lists versions.
+func (mavenRegistry) completeMetadata(
+	ctx context.Context,
+	client *packageRegistryClient,
+	version *packageVersion,
+) error {
+	body, _, err := client.get(ctx, version.metadata.URL+".sha1", "")
+	if err != nil {
+		return err
+	}
+	// Checksum files sometimes also contain the name of the file.
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return errors.New("checksum file is empty")
+	}
+	sum, err := hex.DecodeString(fields[0])
+	if err != nil || len(sum) != 20 {
+		return errors.New("checksum file does not contain a SHA-1 checksum")
+	}
+	version.metadata.Checksum = "sha1:" + hex.EncodeToString(sum)
+	return nil
+}
+
+// mavenArtifactURL returns the URL of the directory of a Maven repository
+// containing all versions of the artifact with the provided coordinates.
+func mavenArtifactURL(registryURL string, coordinates string) string {
+	groupID, artifactID, _ := strings.Cut(coordinates, ":")
+	return fmt.Sprintf(
+		"%s/%s/%s",
+		strings.TrimSuffix(registryURL, "/"),
+		strings.ReplaceAll(groupID, ".", "/"),
+		artifactID,
+	)
+}
+
+// mavenQualifiers are the well-known qualifiers of Maven versions, in order.
+// The empty qualifier is that of releases. Unknown qualifiers are ordered
+// after all of these.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenQualifierAliases maps alternative spellings of qualifiers to the
+// qualifiers in mavenQualifiers.
+var mavenQualifierAliases = map[string]string{
+	"a":       "alpha",
+	"b":       "beta",
+	"m":       "milestone",
+	"cr":      "rc",
+	"ga":      "",
+	"final":   "",
+	"release": "",
+}
+
+// mavenPrereleaseQualifiers are the qualifiers that make a version a
+// pre-release.
+var mavenPrereleaseQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot"}
+
+// mavenVersionItem is a component of a Maven version: either a number or a
+// qualifier.
+type mavenVersionItem struct {
+	// number is the number, without leading zeros, if the item is numeric.
+	number string
+	// qualifier is the normalized qualifier if the item is not numeric.
+	qualifier string
+	// numeric is true if the item is a number.
+	numeric bool
+}
+
+// mavenVersion is a parsed Maven version.
+type mavenVersion struct {
+	items []mavenVersionItem
+}
+
+// parseMavenVersion parses a Maven version. Any string is a valid Maven
+// version, but blank ones are rejected.
+//
+// Like Maven, it splits versions into numbers and qualifiers on dots, hyphens
+// and transitions between digits and letters, normalizes qualifiers, and drops
+// trailing items that are equivalent to a release (such as ".0" or "-final")
+// so that, for instance, 1.0, 1 and 1.0.0-ga are equal. Unlike Maven, it does
+// not distinguish between items separated by dots and by hyphens.
+func parseMavenVersion(version string) (mavenVersion, error) {
+	if strings.TrimSpace(version) == "" {
+		return mavenVersion{}, errors.New("version is empty")
+	}
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	runes := []rune(strings.ToLower(version))
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			flush()
+			continue
+		case i > 0 && current.Len() > 0 &&
+			unicode.IsDigit(r) != unicode.IsDigit(runes[i-1]):
+			flush()
+		}
+		current.WriteRune(r)
+	}
+	flush()
+
+	items := make([]mavenVersionItem, len(tokens))
+	for i, token := range tokens {
+		if unicode.IsDigit([]rune(token)[0]) {
+			number := strings.TrimLeft(token, "0")
+			items[i] = mavenVersionItem{number: number, numeric: true}
+			continue
+		}
+		qualifier := token
+		if alias, ok := mavenQualifierAliases[qualifier]; ok {
+			// The single letter aliases only apply when immediately followed by a
+			// number, as in 1.0a1.
+			if len(qualifier) > 1 || (i+1 < len(tokens) && unicode.IsDigit([]rune(tokens[i+1])[0])) {
+				qualifier = alias
+			}
+		}
+		items[i] = mavenVersionItem{qualifier: qualifier}
+	}
+
+	// Drop trailing items equivalent to a release.
+	for len(items) > 0 && items[len(items)-1].isRelease() {
+		items = items[:len(items)-1]
+	}
+	return mavenVersion{items: items}, nil
+}
+
+// isRelease returns true if the item is equivalent to the absence of an item.
+func (i mavenVersionItem) isRelease() bool {
+	if i.numeric {
+		return i.number == ""
+	}
+	return i.qualifier == ""
+}
+
+// compare compares two items. Numbers are ordered after qualifiers. A nil item
+// represents the absence of an item.
+func (i *mavenVersionItem) compare(other *mavenVersionItem) int {
+	switch {
+	case i == nil && other == nil:
+		return 0
+	case i == nil:
+		return -other.compare(nil)
+	case other == nil:
+		if i.numeric {
+			if i.number == "" {
+				return 0
+			}
+			return 1
+		}
+		return compareMavenQualifiers(i.qualifier, "")
+	case i.numeric && other.numeric:
+		if len(i.number) != len(other.number) {
+			return len(i.number) - len(other.number)
+		}
+		return strings.Compare(i.number, other.number)
+	case i.numeric:
+		return 1
+	case other.numeric:
+		return -1
+	default:
+		return compareMavenQualifiers(i.qualifier, other.qualifier)
+	}
+}
+
+// compareMavenQualifiers compares two normalized qualifiers.
+func compareMavenQualifiers(lhs, rhs string) int {
+	lhsIndex := slices.Index(mavenQualifiers, lhs)
+	rhsIndex := slices.Index(mavenQualifiers, rhs)
+	switch {
+	case lhsIndex >= 0 && rhsIndex >= 0:
+		return lhsIndex - rhsIndex
+	case lhsIndex >= 0:
+		return -1
+	case rhsIndex >= 0:
+		return 1
+	default:
+		return strings.Compare(lhs, rhs)
+	}
+}
+
+// compare returns a negative number if the version is lower than the other
+// version, a positive number if it is higher and zero if they are equal.
+func (v mavenVersion) compare(other mavenVersion) int {
+	for i := range max(len(v.items), len(other.items)) {
+		var lhs, rhs *mavenVersionItem
+		if i < len(v.items) {
+			lhs = &v.items[i]
+		}
+		if i < len(other.items) {
+			rhs = &other.items[i]
+		}
+		if c := lhs.compare(rhs); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// isPrerelease returns true if the version has a pre-release qualifier, such
+// as alpha or SNAPSHOT.
+func (v mavenVersion) isPrerelease() bool {
+	return slices.ContainsFunc(v.items, func(item mavenVersionItem) bool {
+		return !item.numeric && slices.Contains(mavenPrereleaseQualifiers, item.qualifier)
+	})
+}
+
+// mavenVersionRange is a parsed Maven version range, such as [1.0,2.0) or
+// (,1.0],[1.2,). It is a union of intervals.
+type mavenVersionRange []mavenVersionInterval
+
+// mavenVersionInterval is an interval of Maven versions. A nil bound is
+// unbounded.
+type mavenVersionInterval struct {
+	lower, upper                   *mavenVersion
+	lowerInclusive, upperInclusive bool
+}
+
+// parseMavenVersionRange parses a Maven version range. Unlike Maven, which
+// treats a bare version as a soft requirement allowing any version, it only
+// accepts versions enclosed in brackets or parentheses.
+func parseMavenVersionRange(constraint string) (mavenVersionRange, error) {
+	var r mavenVersionRange
+	remaining := strings.TrimSpace(constraint)
+	for remaining != "" {
+		if remaining[0] != '[' && remaining[0] != '(' {
+			return nil, fmt.Errorf(
+				"invalid version range %q: ranges must begin with [ or (",
+				constraint,
+			)
+		}
+		end := strings.IndexAny(remaining, "])")
+		if end < 0 {
+			return nil, fmt.Errorf(
+				"invalid version range %q: ranges must end with ] or )",
+				constraint,
+			)
+		}
+		interval, err := parseMavenVersionInterval(remaining[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", constraint, err)
+		}
+		r = append(r, interval)
+		remaining = strings.TrimSpace(remaining[end+1:])
+		if remaining != "" {
+			if remaining[0] != ',' {
+				return nil, fmt.Errorf(
+					"invalid version range %q: ranges must be separated by commas",
+					constraint,
+				)
+			}
+			remaining = strings.TrimSpace(remaining[1:])
+		}
+	}
+	if len(r) == 0 {
+		return nil, errors.New("version range is empty")
+	}
+	return r, nil
+}
+
+// parseMavenVersionInterval parses a single interval of a Maven version range,
+// such as [1.0,2.0) or [1.5].
+func parseMavenVersionInterval(s string) (mavenVersionInterval, error) {
+	interval := mavenVersionInterval{
+		lowerInclusive: s[0] == '[',
+		upperInclusive: s[len(s)-1] == ']',
+	}
+	lowerStr, upperStr, isRange := strings.Cut(s[1:len(s)-1], ",")
+	lowerStr = strings.TrimSpace(lowerStr)
+	upperStr = strings.TrimSpace(upperStr)
+	if !isRange {
+		// A single version, which must be enclosed in brackets.
+		if !interval.lowerInclusive || !interval.upperInclusive || lowerStr == "" {
+			return interval, fmt.Errorf("%q is not a valid exact version", s)
+		}
+		upperStr = lowerStr
+	}
+	if lowerStr != "" {
+		lower, err := parseMavenVersion(lowerStr)
+		if err != nil {
+			return interval, err
+		}
+		interval.lower = &lower
+	}
+	if upperStr != "" {
+		upper, err := parseMavenVersion(upperStr)
+		if err != nil {
+			return interval, err
+		}
+		interval.upper = &upper
+	}
+	if interval.lower != nil && interval.upper != nil &&
+		interval.lower.compare(*interval.upper) > 0 {
+		return interval, fmt.Errorf("lower bound of %q exceeds its upper bound", s)
+	}
+	return interval, nil
+}
+
+// contains returns true if the provided version is within the range.
+func (r mavenVersionRange) contains(version mavenVersion) bool {
+	return slices.ContainsFunc(r, func(interval mavenVersionInterval) bool {
+		if interval.lower != nil {
+			c := version.compare(*interval.lower)
+			if c < 0 || (c == 0 && !interval.lowerInclusive) {
+				return false
+			}
+		}
+		if interval.upper != nil {
+			c := version.compare(*interval.upper)
+			if c > 0 || (c == 0 && !interval.upperInclusive) {
+				return false
+			}
+		}
+		return true
+	})
+}
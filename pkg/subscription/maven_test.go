@@ -0,0 +1,187 @@
+package subscription
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func Test_mavenRegistry_discovery(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /repo/com/example/service/maven-metadata.xml",
+		func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "fake-user", username)
+			require.Equal(t, "fake-pass", password)
+			_, _ = fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>com.example</groupId>
+  <artifactId>service</artifactId>
+  <versioning>
+    <latest>2.0.0-SNAPSHOT</latest>
+    <release>1.10.0</release>
+    <versions>
+      <version>1.0.0</version>
+      <version>1.9.0</version>
+      <version>1.10.0</version>
+      <version>2.0.0-SNAPSHOT</version>
+    </versions>
+    <lastUpdated>20240101000000</lastUpdated>
+  </versioning>
+</metadata>`)
+		},
+	)
+	mux.HandleFunc(
+		"GET /repo/com/example/service/1.10.0/service-1.10.0.war.sha1",
+		func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = fmt.Fprint(w, "da39a3ee5e6b4b0d3255bfef95601890afd80709  service-1.10.0.war\n")
+		},
+	)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	versions, metadata := discoverPackageVersions(
+		t,
+		&credentials.Credentials{Username: "fake-user", Password: "fake-pass"},
+		newPackageSubscription(t, mavenSubscriptionType, map[string]any{
+			"registryURL": srv.URL + "/repo",
+			"package":     "com.example:service",
+			"packaging":   "war",
+		}),
+	)
+	require.Equal(t, []string{"1.10.0", "1.9.0", "1.0.0"}, versions)
+	require.Equal(t, packageMetadata{
+		RegistryURL: srv.URL + "/repo",
+		Package:     "com.example:service",
+		URL:         srv.URL + "/repo/com/example/service/1.10.0/service-1.10.0.war",
+		Checksum:    "sha1:da39a3ee5e6b4b0d3255bfef95601890afd80709",
+	}, metadata[0])
+	// Checksums that cannot be obtained are simply not recorded.
+	require.Empty(t, metadata[1].Checksum)
+}
+
+func Test_mavenVersion_compare(t *testing.T) {
+	// Each version is lower than the next one.
+	ordered := []string{
+		"1-alpha",
+		"1.0a1",
+		"1.0-alpha-2",
+		"1.0-beta1",
+		"1.0-m1",
+		"1.0-rc1",
+		"1.0-cr2",
+		"1.0-SNAPSHOT",
+		"1.0",
+		"1.0-sp1",
+		"1.0-jre",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"1.10.0.1",
+		"2",
+	}
+	for i := 1; i < len(ordered); i++ {
+		lhs, err := parseMavenVersion(ordered[i-1])
+		require.NoError(t, err)
+		rhs, err := parseMavenVersion(ordered[i])
+		require.NoError(t, err)
+		require.Negative(t, lhs.compare(rhs), "%s < %s", ordered[i-1], ordered[i])
+		require.Positive(t, rhs.compare(lhs), "%s > %s", ordered[i], ordered[i-1])
+	}
+
+	for _, equal := range [][]string{
+		{"1", "1.0", "1.0.0", "1-ga", "1.0-final", "1.0.0-release"},
+		{"1.0-rc1", "1.0-cr1", "1.0-RC-1"},
+		{"1.01", "1.1"},
+	} {
+		first, err := parseMavenVersion(equal[0])
+		require.NoError(t, err)
+		for _, v := range equal[1:] {
+			other, err := parseMavenVersion(v)
+			require.NoError(t, err)
+			require.Zero(t, first.compare(other), "%s == %s", equal[0], v)
+		}
+	}
+
+	_, err := parseMavenVersion(" ")
+	require.Error(t, err)
+}
+
+func Test_mavenVersion_isPrerelease(t *testing.T) {
+	for version, expected := range map[string]bool{
+		"1.0":          false,
+		"33.0.0-jre":   false,
+		"1.0-sp1":      false,
+		"1.0-SNAPSHOT": true,
+		"1.0a1":        true,
+		"1.0-M2":       true,
+		"1.0-rc.1":     true,
+	} {
+		v, err := parseMavenVersion(version)
+		require.NoError(t, err)
+		require.Equal(t, expected, v.isPrerelease(), version)
+	}
+}
+
+func Test_parseMavenVersionRange(t *testing.T) {
+	testCases := []struct {
+		rangeStr string
+		contains []string
+		excludes []string
+		err      string
+	}{
+		{
+			rangeStr: "[1.0,2.0)",
+			contains: []string{"1.0", "1.5", "2.0-SNAPSHOT"},
+			excludes: []string{"0.9", "2.0", "2.1"},
+		},
+		{
+			rangeStr: "(1.0,]",
+			contains: []string{"1.0.1", "99"},
+			excludes: []string{"1.0", "0.1"},
+		},
+		{
+			rangeStr: "[1.5]",
+			contains: []string{"1.5", "1.5.0"},
+			excludes: []string{"1.5.1", "1.4"},
+		},
+		{
+			rangeStr: "(,1.0], [1.2,)",
+			contains: []string{"0.5", "1.0", "1.2", "3"},
+			excludes: []string{"1.1"},
+		},
+		{rangeStr: "1.0", err: "ranges must begin with [ or ("},
+		{rangeStr: "[1.0,2.0", err: "ranges must end with ] or )"},
+		{rangeStr: "[1.0,2.0)[3.0,)", err: "ranges must be separated by commas"},
+		{rangeStr: "(1.0)", err: "is not a valid exact version"},
+		{rangeStr: "[2.0,1.0]", err: "exceeds its upper bound"},
+		{rangeStr: "", err: "version range is empty"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.rangeStr, func(t *testing.T) {
+			r, err := parseMavenVersionRange(testCase.rangeStr)
+			if testCase.err != "" {
+				require.ErrorContains(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			for _, version := range testCase.contains {
+				v, err := parseMavenVersion(version)
+				require.NoError(t, err)
+				require.True(t, r.contains(v), version)
+			}
+			for _, version := range testCase.excludes {
+				v, err := parseMavenVersion(version)
+				require.NoError(t, err)
+				require.False(t, r.contains(v), version)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ type MockSubscriber struct {
 		sub kargoapi.RepoSubscription,
 		last any,
 	) (any, error)
+	FreightPerArtifactFn func(sub kargoapi.RepoSubscription) bool
 }
 
 func (m *MockSubscriber) ApplySubscriptionDefaults(
@@ -52,3 +53,10 @@ func (m *MockSubscriber) DiscoverArtifacts(
 	}
 	return nil, nil
 }
+
+func (m *MockSubscriber) FreightPerArtifact(sub kargoapi.RepoSubscription) bool {
+	if m.FreightPerArtifactFn != nil {
+		return m.FreightPerArtifactFn(sub)
+	}
+	return false
+}
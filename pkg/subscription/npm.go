@@ -0,0 +1,182 @@
+package subscription
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// npmSubscriptionType is the SubscriptionType of generic subscriptions to
+	// npm registries. It is also the ArtifactType of the ArtifactReferences
+	// discovered by them.
+	npmSubscriptionType = "npm"
+
+	// defaultNPMRegistryURL is the URL of the public npm registry.
+	defaultNPMRegistryURL = "https://registry.npmjs.org"
+)
+
+// npmPackageNameRegex matches valid npm package names, which may be scoped.
+var npmPackageNameRegex = regexp.MustCompile(
+	`^(?:@[a-z0-9][a-z0-9._~-]*/)?[a-z0-9][a-z0-9._~-]*$`,
+)
+
+func init() {
+	registerPackageSubscriber[*semver.Version](npmSubscriptionType, npmRegistry{})
+}
+
+// npmPackument is the subset of an npm registry's document describing a
+// package (a "packument") that is needed to discover its versions.
+type npmPackument struct {
+	Versions map[string]struct {
+		Dist struct {
+			Tarball   string `json:"tarball"`
+			Integrity string `json:"integrity"`
+			Shasum    string `json:"shasum"`
+		} `json:"dist"`
+		// Deprecated is normally a deprecation message, but some old packages
+		// have booleans here.
+		Deprecated any `json:"deprecated"`
+	} `json:"versions"`
+	Time map[string]string `json:"time"`
+}
+
+// npmRegistry is the packageRegistry implementation for npm registries.
+// Versions of npm packages are semantic versions.
+type npmRegistry struct{}
+
+func (npmRegistry) defaultRegistryURL() string {
+	return defaultNPMRegistryURL
+}
+
+func (npmRegistry) validateConfig(
+	f *field.Path,
+	cfg packageSubscriptionConfig,
+) field.ErrorList {
+	var errs field.ErrorList
+	if cfg.Package != "" && !npmPackageNameRegex.MatchString(cfg.Package) {
+		errs = append(errs, field.Invalid(
+			f.Child("package"),
+			cfg.Package,
+			"must be a valid npm package name",
+		))
+	}
+	if cfg.Packaging != "" {
+		errs = append(errs, field.Forbidden(
+			f.Child("packaging"),
+			"is only applicable to Maven",
+		))
+	}
+	return errs
+}
+
+func (npmRegistry) parseVersion(version string) (*semver.Version, error) {
+	return semver.StrictNewVersion(version)
+}
+
+func (npmRegistry) compareVersions(lhs, rhs *semver.Version) int {
+	return lhs.Compare(rhs)
+}
+
+func (npmRegistry) isPrerelease(version *semver.Version) bool {
+	return version.Prerelease() != ""
+}
+
+func (npmRegistry) parseConstraint(constraint string) (func(*semver.Version) bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+	return c.Check, nil
+}
+
+func (npmRegistry) listVersions(
+	ctx context.Context,
+	client *packageRegistryClient,
+	cfg packageSubscriptionConfig,
+) ([]packageVersion, error) {
+	// Scoped package names are requested with their slash escaped.
+	docURL := strings.TrimSuffix(cfg.RegistryURL, "/") + "/" +
+		url.PathEscape(cfg.Package)
+	body, _, err := client.get(ctx, docURL, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var packument npmPackument
+	if err = json.Unmarshal(body, &packument); err != nil {
+		return nil, fmt.Errorf("error parsing package document: %w", err)
+	}
+
+	versions := make([]packageVersion, 0, len(packument.Versions))
+	for version, details := range packument.Versions {
+		metadata := packageMetadata{
+			RegistryURL: cfg.RegistryURL,
+			Package:     cfg.Package,
+			URL:         details.Dist.Tarball,
+			Checksum:    npmChecksum(details.Dist.Integrity, details.Dist.Shasum),
+		}
+		if published, err := time.Parse(time.RFC3339, packument.Time[version]); err == nil {
+			metadata.PublishedAt = &published
+		}
+		switch deprecated := details.Deprecated.(type) {
+		case string:
+			metadata.Deprecated = deprecated
+		case bool:
+			if deprecated {
+				metadata.Deprecated = "deprecated"
+			}
+		}
+		versions = append(versions, packageVersion{
+			version:  version,
+			metadata: metadata,
+		})
+	}
+	return versions, nil
+}
+
+func (npmRegistry) completeMetadata(
+	context.Context,
+	*packageRegistryClient,
+	*packageVersion,
+) error {
+	// Packuments include everything that is recorded.
+	return nil
+}
+
+// npmIntegrityAlgorithms are the hash algorithms that may appear in
+// subresource integrity strings, from the strongest to the weakest.
+var npmIntegrityAlgorithms = []string{"sha512", "sha384", "sha256", "sha1"}
+
+// npmChecksum returns the checksum of a tarball in the form
+// <algorithm>:<hex digest> given its subresource integrity string and its
+// legacy SHA-1 checksum. The strongest checksum available is returned.
+func npmChecksum(integrity string, shasum string) string {
+	digests := map[string]string{}
+	for _, hash := range strings.Fields(integrity) {
+		algorithm, digest, ok := strings.Cut(hash, "-")
+		if !ok {
+			continue
+		}
+		if sum, err := base64.StdEncoding.DecodeString(digest); err == nil {
+			digests[algorithm] = hex.EncodeToString(sum)
+		}
+	}
+	if _, ok := digests["sha1"]; !ok && shasum != "" {
+		digests["sha1"] = shasum
+	}
+	for _, algorithm := range npmIntegrityAlgorithms {
+		if digest, ok := digests[algorithm]; ok {
+			return algorithm + ":" + digest
+		}
+	}
+	return ""
+}
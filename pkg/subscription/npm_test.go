@@ -0,0 +1,73 @@
+package subscription
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func Test_npmRegistry_discovery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/@example%2Flib", r.URL.RawPath)
+		require.Equal(t, "Bearer fake-token", r.Header.Get("Authorization"))
+		_, _ = fmt.Fprint(w, `{
+  "name": "@example/lib",
+  "versions": {
+    "1.0.0": {"dist": {"tarball": "https://registry.example.com/lib-1.0.0.tgz", "shasum": "abc"}},
+    "1.1.0": {
+      "dist": {
+        "tarball": "https://registry.example.com/lib-1.1.0.tgz",
+        "integrity": "sha512-3q2+7w==",
+        "shasum": "abc"
+      },
+      "deprecated": "use 1.2.0"
+    },
+    "1.2.0-beta.1": {"dist": {"tarball": "https://registry.example.com/lib-1.2.0-beta.1.tgz"}},
+    "v2": {"dist": {}}
+  },
+  "time": {
+    "created": "2024-01-01T00:00:00.000Z",
+    "1.0.0": "2024-01-01T00:00:00.000Z",
+    "1.1.0": "2024-02-01T00:00:00.000Z"
+  }
+}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	versions, metadata := discoverPackageVersions(
+		t,
+		&credentials.Credentials{Password: "fake-token"},
+		newPackageSubscription(t, npmSubscriptionType, map[string]any{
+			"registryURL": srv.URL + "/",
+			"package":     "@example/lib",
+		}),
+	)
+	require.Equal(t, []string{"1.1.0", "1.0.0"}, versions)
+	published := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, packageMetadata{
+		RegistryURL: srv.URL + "/",
+		Package:     "@example/lib",
+		URL:         "https://registry.example.com/lib-1.1.0.tgz",
+		Checksum:    "sha512:deadbeef",
+		PublishedAt: &published,
+		Deprecated:  "use 1.2.0",
+	}, metadata[0])
+	require.Equal(t, "sha1:abc", metadata[1].Checksum)
+}
+
+func Test_npmChecksum(t *testing.T) {
+	require.Empty(t, npmChecksum("", ""))
+	require.Equal(t, "sha1:abc", npmChecksum("", "abc"))
+	require.Equal(t, "sha1:abc", npmChecksum("bogus", "abc"))
+	require.Equal(
+		t,
+		"sha512:deadbeef",
+		npmChecksum("sha1-3q2+7w== sha512-3q2+7w== sha256-AAAA", "abc"),
+	)
+}
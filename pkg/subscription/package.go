@@ -0,0 +1,529 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/logging"
+	kargonet "github.com/akuity/kargo/pkg/net"
+	"github.com/akuity/kargo/pkg/validation"
+)
+
+const (
+	// maxPackageDocumentSize bounds the size, in bytes, of a document describing
+	// a package that is retrieved from a registry. Documents describing popular
+	// npm packages are very large.
+	maxPackageDocumentSize = 128 << 20
+	// packageRequestTimeout bounds the duration of each request made to a
+	// registry.
+	packageRequestTimeout = time.Minute
+)
+
+// packageSubscriptionConfig is the configuration of generic subscriptions to
+// language package registries.
+type packageSubscriptionConfig struct {
+	// RegistryURL is the URL of the registry. Each type of registry has its own
+	// default, which is the URL of the public registry.
+	RegistryURL string `json:"registryURL,omitempty"`
+	// Package identifies the package. For npm, it is the (optionally scoped)
+	// package name, for Maven it is of the form groupId:artifactId and for PyPI
+	// it is the project name.
+	Package string `json:"package"`
+	// VersionConstraint limits discovery to versions satisfying it. Its syntax
+	// is that of the registry's ecosystem: a semver range for npm, a version
+	// range such as [1.0,2.0) for Maven and a version specifier such as
+	// >=1.0,<2.0 for PyPI.
+	VersionConstraint string `json:"versionConstraint,omitempty"`
+	// AllowVersionsRegexes is a list of regular expressions. When specified,
+	// only versions matching at least one of them are discovered.
+	AllowVersionsRegexes []string `json:"allowVersionsRegexes,omitempty"`
+	// IgnoreVersionsRegexes is a list of regular expressions. Versions matching
+	// any of them are not discovered.
+	IgnoreVersionsRegexes []string `json:"ignoreVersionsRegexes,omitempty"`
+	// AllowPrereleases specifies whether pre-release versions may be
+	// discovered.
+	AllowPrereleases bool `json:"allowPrereleases,omitempty"`
+	// Packaging is the packaging of a Maven artifact, which determines the
+	// extension of the file recorded as the artifact's URL. It defaults to
+	// "jar" and is only applicable to Maven.
+	Packaging string `json:"packaging,omitempty"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when connecting to the registry.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// packageMetadata is the metadata recorded on each ArtifactReference
+// discovered by a packageSubscriber.
+type packageMetadata struct {
+	// RegistryURL is the URL of the registry the package version was discovered
+	// in.
+	RegistryURL string `json:"registryURL"`
+	// Package identifies the package.
+	Package string `json:"package"`
+	// URL is the URL of the package version's main file (the npm tarball, the
+	// Maven artifact or the Python source distribution), if known.
+	URL string `json:"url,omitempty"`
+	// Checksum is a checksum of the main file's content in the form
+	// <algorithm>:<hex digest>, if known.
+	Checksum string `json:"checksum,omitempty"`
+	// PublishedAt is the time the package version was published, if known.
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Deprecated is the deprecation message of the package version, if it is
+	// deprecated.
+	Deprecated string `json:"deprecated,omitempty"`
+	// Files are all of the package version's files, for registries that publish
+	// more than one per version.
+	Files []packageFile `json:"files,omitempty"`
+}
+
+// packageFile is a file of a package version.
+type packageFile struct {
+	// Name is the name of the file.
+	Name string `json:"name"`
+	// URL is the URL the file can be downloaded from.
+	URL string `json:"url"`
+	// Checksum is a checksum of the file's content in the form
+	// <algorithm>:<hex digest>, if known.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// packageVersion is a version of a package listed by a registry.
+type packageVersion struct {
+	// version is the version as listed by the registry.
+	version string
+	// metadata is the metadata recorded for the version if it is discovered.
+	metadata packageMetadata
+}
+
+// packageRegistry abstracts the differences between language package
+// registries, including how their versions are ordered. V is the type of a
+// parsed version.
+type packageRegistry[V any] interface {
+	// defaultRegistryURL returns the URL of the public registry.
+	defaultRegistryURL() string
+	// validateConfig validates the parts of the configuration specific to the
+	// registry, including the package name.
+	validateConfig(f *field.Path, cfg packageSubscriptionConfig) field.ErrorList
+	// parseVersion parses a version listed by the registry.
+	parseVersion(version string) (V, error)
+	// compareVersions returns a negative number if lhs is lower than rhs, a
+	// positive number if it is higher and zero if they are equal.
+	compareVersions(lhs, rhs V) int
+	// isPrerelease returns true if the provided version is a pre-release.
+	isPrerelease(V) bool
+	// parseConstraint parses a version constraint and returns a function
+	// reporting whether versions satisfy it.
+	parseConstraint(constraint string) (func(V) bool, error)
+	// listVersions lists the versions of the configured package.
+	listVersions(
+		ctx context.Context,
+		client *packageRegistryClient,
+		cfg packageSubscriptionConfig,
+	) ([]packageVersion, error)
+	// completeMetadata completes the metadata of a version that was selected
+	// for discovery, for registries where obtaining all of it for every
+	// version would be too costly. It is best-effort.
+	completeMetadata(
+		ctx context.Context,
+		client *packageRegistryClient,
+		version *packageVersion,
+	) error
+}
+
+// registerPackageSubscriber registers a packageSubscriber for the provided
+// registry with the DefaultSubscriberRegistry. The subscriber handles generic
+// subscriptions of the provided type.
+func registerPackageSubscriber[V any](
+	subscriptionType string,
+	registry packageRegistry[V],
+) {
+	DefaultSubscriberRegistry.MustRegister(SubscriberRegistration{
+		Predicate: func(
+			_ context.Context,
+			sub kargoapi.RepoSubscription,
+		) (bool, error) {
+			return sub.Subscription != nil &&
+				sub.Subscription.SubscriptionType == subscriptionType, nil
+		},
+		Value: func(
+			_ context.Context,
+			credentialsDB credentials.Database,
+		) (Subscriber, error) {
+			return &packageSubscriber[V]{
+				artifactType:  subscriptionType,
+				registry:      registry,
+				credentialsDB: credentialsDB,
+			}, nil
+		},
+	})
+}
+
+// packageSubscriber is an implementation of the Subscriber interface that
+// discovers versions of a package published to a language package registry.
+type packageSubscriber[V any] struct {
+	artifactType  string
+	registry      packageRegistry[V]
+	credentialsDB credentials.Database
+}
+
+// ApplySubscriptionDefaults implements Subscriber.
+func (p *packageSubscriber[V]) ApplySubscriptionDefaults(
+	context.Context,
+	*kargoapi.RepoSubscription,
+) error {
+	// Defaults for optional configuration are applied when the configuration is
+	// parsed.
+	return nil
+}
+
+// ValidateSubscription implements Subscriber.
+func (p *packageSubscriber[V]) ValidateSubscription(
+	_ context.Context,
+	f *field.Path,
+	s kargoapi.RepoSubscription,
+) field.ErrorList {
+	f = f.Child("config")
+	cfg, err := p.parseConfig(s.Subscription)
+	if err != nil {
+		return field.ErrorList{field.Invalid(f, "", err.Error())}
+	}
+
+	var errs field.ErrorList
+
+	// Validate RegistryURL: HTTP/S URL
+	if u, err := url.Parse(cfg.RegistryURL); err != nil ||
+		(u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(
+			f.Child("registryURL"),
+			cfg.RegistryURL,
+			"must be a valid HTTP/S URL",
+		))
+	}
+
+	// Validate Package: MinLength=1
+	if err := validation.MinLength(f.Child("package"), cfg.Package, 1); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate registry-specific configuration
+	errs = append(errs, p.registry.validateConfig(f, cfg)...)
+
+	// Validate VersionConstraint
+	if cfg.VersionConstraint != "" {
+		if _, err := p.registry.parseConstraint(cfg.VersionConstraint); err != nil {
+			errs = append(errs, field.Invalid(
+				f.Child("versionConstraint"),
+				cfg.VersionConstraint,
+				err.Error(),
+			))
+		}
+	}
+
+	// Validate AllowVersionsRegexes and IgnoreVersionsRegexes
+	for i, regex := range cfg.AllowVersionsRegexes {
+		if _, err := regexp.Compile(regex); err != nil {
+			errs = append(errs, field.Invalid(
+				f.Child("allowVersionsRegexes").Index(i),
+				regex,
+				fmt.Sprintf("must be a valid regular expression: %v", err),
+			))
+		}
+	}
+	for i, regex := range cfg.IgnoreVersionsRegexes {
+		if _, err := regexp.Compile(regex); err != nil {
+			errs = append(errs, field.Invalid(
+				f.Child("ignoreVersionsRegexes").Index(i),
+				regex,
+				fmt.Sprintf("must be a valid regular expression: %v", err),
+			))
+		}
+	}
+
+	return errs
+}
+
+// DiscoverArtifacts implements Subscriber.
+func (p *packageSubscriber[V]) DiscoverArtifacts(
+	ctx context.Context,
+	project string,
+	sub kargoapi.RepoSubscription,
+	_ any,
+) (any, error) {
+	if sub.Subscription == nil {
+		return nil, nil
+	}
+
+	cfg, err := p.parseConfig(sub.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithValues(
+		"registry", cfg.RegistryURL,
+		"package", cfg.Package,
+	)
+
+	creds, err := p.credentialsDB.Get(
+		ctx,
+		project,
+		credentials.TypePackage,
+		cfg.RegistryURL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for registry %q: %w",
+			cfg.RegistryURL, err,
+		)
+	}
+	if creds != nil {
+		logger.Debug("obtained credentials for registry")
+	} else {
+		logger.Debug("found no credentials for registry")
+	}
+
+	client := newPackageRegistryClient(cfg, creds)
+	versions, err := p.registry.listVersions(ctx, client, cfg)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing versions of package %q in registry %q: %w",
+			cfg.Package, cfg.RegistryURL, err,
+		)
+	}
+
+	selected, err := p.selectVersions(cfg, versions)
+	if err != nil {
+		return nil, err
+	}
+	selected = trimSlice(selected, int(sub.Subscription.DiscoveryLimit))
+	if len(selected) == 0 {
+		logger.Debug("discovered no package versions")
+	} else {
+		logger.Debug("discovered package versions", "count", len(selected))
+	}
+
+	refs := make([]kargoapi.ArtifactReference, len(selected))
+	for i := range selected {
+		version := &selected[i]
+		if err = p.registry.completeMetadata(ctx, client, version); err != nil {
+			logger.Debug(
+				"error completing package version metadata",
+				"version", version.version,
+				"error", err.Error(),
+			)
+		}
+		metadata, err := json.Marshal(version.metadata)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error marshaling metadata of package version %q: %w",
+				version.version, err,
+			)
+		}
+		refs[i] = kargoapi.ArtifactReference{
+			ArtifactType:     p.artifactType,
+			SubscriptionName: sub.Name,
+			Version:          version.version,
+			Metadata:         &apiextensionsv1.JSON{Raw: metadata},
+		}
+	}
+	return kargoapi.DiscoveryResult{
+		SubscriptionName:   sub.Name,
+		ArtifactReferences: refs,
+	}, nil
+}
+
+// selectVersions filters the provided versions according to the provided
+// configuration and returns those remaining, ordered from the highest version
+// to the lowest. Versions the registry cannot parse are never selected.
+func (p *packageSubscriber[V]) selectVersions(
+	cfg packageSubscriptionConfig,
+	versions []packageVersion,
+) ([]packageVersion, error) {
+	allowRegexes, err := compileRegexes(cfg.AllowVersionsRegexes)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling allow versions regex: %w", err)
+	}
+	ignoreRegexes, err := compileRegexes(cfg.IgnoreVersionsRegexes)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling ignore versions regex: %w", err)
+	}
+	var constraint func(V) bool
+	if cfg.VersionConstraint != "" {
+		if constraint, err = p.registry.parseConstraint(cfg.VersionConstraint); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing version constraint %q: %w",
+				cfg.VersionConstraint, err,
+			)
+		}
+	}
+
+	type parsedVersion struct {
+		parsed  V
+		version packageVersion
+	}
+	parsedVersions := make([]parsedVersion, 0, len(versions))
+	for _, version := range versions {
+		if slices.ContainsFunc(ignoreRegexes, matchesString(version.version)) {
+			continue
+		}
+		if len(allowRegexes) > 0 &&
+			!slices.ContainsFunc(allowRegexes, matchesString(version.version)) {
+			continue
+		}
+		parsed, err := p.registry.parseVersion(version.version)
+		if err != nil {
+			continue
+		}
+		if !cfg.AllowPrereleases && p.registry.isPrerelease(parsed) {
+			continue
+		}
+		if constraint != nil && !constraint(parsed) {
+			continue
+		}
+		parsedVersions = append(parsedVersions, parsedVersion{
+			parsed:  parsed,
+			version: version,
+		})
+	}
+
+	slices.SortStableFunc(parsedVersions, func(lhs, rhs parsedVersion) int {
+		return p.registry.compareVersions(rhs.parsed, lhs.parsed)
+	})
+	selected := make([]packageVersion, len(parsedVersions))
+	for i, v := range parsedVersions {
+		selected[i] = v.version
+	}
+	return selected, nil
+}
+
+// parseConfig parses the configuration of the provided generic subscription as
+// a packageSubscriptionConfig and applies defaults to optional fields.
+func (p *packageSubscriber[V]) parseConfig(
+	sub *kargoapi.Subscription,
+) (packageSubscriptionConfig, error) {
+	var cfg packageSubscriptionConfig
+	if sub == nil || sub.Config == nil || len(sub.Config.Raw) == 0 {
+		return cfg, errors.New("configuration is required")
+	}
+	dec := json.NewDecoder(bytes.NewReader(sub.Config.Raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	if cfg.RegistryURL == "" {
+		cfg.RegistryURL = p.registry.defaultRegistryURL()
+	}
+	return cfg, nil
+}
+
+// compileRegexes returns a slice of compiled regular expressions.
+func compileRegexes(regexStrs []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, len(regexStrs))
+	var err error
+	for i, regexStr := range regexStrs {
+		if regexes[i], err = regexp.Compile(regexStr); err != nil {
+			return nil, fmt.Errorf(
+				"error compiling regular expression %q: %w",
+				regexStr, err,
+			)
+		}
+	}
+	return regexes, nil
+}
+
+// matchesString returns a function reporting whether a regular expression
+// matches the provided string.
+func matchesString(s string) func(*regexp.Regexp) bool {
+	return func(regex *regexp.Regexp) bool {
+		return regex.MatchString(s)
+	}
+}
+
+// packageRegistryClient makes requests to a language package registry.
+type packageRegistryClient struct {
+	creds  *credentials.Credentials
+	client *http.Client
+}
+
+// newPackageRegistryClient returns a packageRegistryClient for the registry
+// described by the provided configuration. The provided credentials are
+// optional.
+func newPackageRegistryClient(
+	cfg packageSubscriptionConfig,
+	creds *credentials.Credentials,
+) *packageRegistryClient {
+	httpTransport := kargonet.SafeTransport(cleanhttp.DefaultTransport())
+	if cfg.InsecureSkipTLSVerify {
+		httpTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, // nolint: gosec
+		}
+	}
+	return &packageRegistryClient{
+		creds: creds,
+		client: &http.Client{
+			Transport: httpTransport,
+			Timeout:   packageRequestTimeout,
+		},
+	}
+}
+
+// get returns the body and content type of the response to a GET request for
+// the provided URL. When credentials are available, they are used for basic
+// authentication or, if there is no username, as a bearer token.
+func (c *packageRegistryClient) get(
+	ctx context.Context,
+	u string,
+	accept string,
+) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating request for %q: %w", u, err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.creds != nil {
+		if c.creds.Username != "" {
+			req.SetBasicAuth(c.creds.Username, c.creds.Password)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.creds.Password)
+		}
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("error requesting %q: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf(
+			"request for %q failed with status %d",
+			u, resp.StatusCode,
+		)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPackageDocumentSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading response from %q: %w", u, err)
+	}
+	if len(body) > maxPackageDocumentSize {
+		return nil, "", fmt.Errorf(
+			"response from %q exceeds limit of %d bytes",
+			u, maxPackageDocumentSize,
+		)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,265 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+)
+
+func newPackageSubscription(
+	t *testing.T,
+	subscriptionType string,
+	cfg map[string]any,
+) kargoapi.RepoSubscription {
+	t.Helper()
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return kargoapi.RepoSubscription{
+		Name: "fake-sub",
+		Subscription: &kargoapi.Subscription{
+			SubscriptionType: subscriptionType,
+			Config:           &apiextensionsv1.JSON{Raw: raw},
+			DiscoveryLimit:   20,
+		},
+	}
+}
+
+// discoverPackageVersions runs discovery for the provided subscription using
+// the subscriber registered for its type and returns the discovered versions
+// and their metadata.
+func discoverPackageVersions(
+	t *testing.T,
+	creds *credentials.Credentials,
+	sub kargoapi.RepoSubscription,
+) ([]string, []packageMetadata) {
+	t.Helper()
+	reg, err := DefaultSubscriberRegistry.Get(t.Context(), sub)
+	require.NoError(t, err)
+	subscriber, err := reg.Value(t.Context(), &credentials.FakeDB{
+		GetFn: func(
+			_ context.Context,
+			project string,
+			credType credentials.Type,
+			_ string,
+		) (*credentials.Credentials, error) {
+			require.Equal(t, "fake-project", project)
+			require.Equal(t, credentials.TypePackage, credType)
+			return creds, nil
+		},
+	})
+	require.NoError(t, err)
+	res, err := subscriber.DiscoverArtifacts(t.Context(), "fake-project", sub, nil)
+	require.NoError(t, err)
+	result, ok := res.(kargoapi.DiscoveryResult)
+	require.True(t, ok)
+	require.Equal(t, "fake-sub", result.SubscriptionName)
+	versions := make([]string, len(result.ArtifactReferences))
+	metadata := make([]packageMetadata, len(result.ArtifactReferences))
+	for i, ref := range result.ArtifactReferences {
+		require.Equal(t, sub.Subscription.SubscriptionType, ref.ArtifactType)
+		require.Equal(t, "fake-sub", ref.SubscriptionName)
+		versions[i] = ref.Version
+		require.NoError(t, json.Unmarshal(ref.Metadata.Raw, &metadata[i]))
+	}
+	return versions, metadata
+}
+
+func Test_packageSubscriber_registration(t *testing.T) {
+	for _, subscriptionType := range []string{
+		npmSubscriptionType,
+		mavenSubscriptionType,
+		pypiSubscriptionType,
+	} {
+		t.Run(subscriptionType, func(t *testing.T) {
+			reg, err := DefaultSubscriberRegistry.Get(
+				t.Context(),
+				newPackageSubscription(t, subscriptionType, map[string]any{
+					"package": "fake",
+				}),
+			)
+			require.NoError(t, err)
+			_, err = reg.Value(t.Context(), nil)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_packageSubscriber_ValidateSubscription(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.RepoSubscription
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "valid npm",
+			sub: newPackageSubscription(t, npmSubscriptionType, map[string]any{
+				"package":               "@example/lib",
+				"versionConstraint":     "^1.2.0",
+				"allowVersionsRegexes":  []string{`^1\.`},
+				"ignoreVersionsRegexes": []string{`-beta`},
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "valid maven",
+			sub: newPackageSubscription(t, mavenSubscriptionType, map[string]any{
+				"registryURL":       "https://nexus.example.com/repository/maven-releases",
+				"package":           "com.example:service",
+				"versionConstraint": "[1.0,2.0)",
+				"packaging":         "war",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "valid pypi",
+			sub: newPackageSubscription(t, pypiSubscriptionType, map[string]any{
+				"package":           "Example_Lib",
+				"versionConstraint": "~=1.4,!=1.4.2",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "missing config",
+			sub: kargoapi.RepoSubscription{
+				Subscription: &kargoapi.Subscription{
+					SubscriptionType: npmSubscriptionType,
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].pkg.config", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "configuration is required")
+			},
+		},
+		{
+			name: "invalid npm",
+			sub: newPackageSubscription(t, npmSubscriptionType, map[string]any{
+				"registryURL":           "ftp://registry.example.com",
+				"package":               "Not Valid",
+				"packaging":             "jar",
+				"versionConstraint":     "not a constraint",
+				"allowVersionsRegexes":  []string{"("},
+				"ignoreVersionsRegexes": []string{"", "("},
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(t, []string{
+					"spec.subscriptions[0].pkg.config.registryURL",
+					"spec.subscriptions[0].pkg.config.package",
+					"spec.subscriptions[0].pkg.config.packaging",
+					"spec.subscriptions[0].pkg.config.versionConstraint",
+					"spec.subscriptions[0].pkg.config.allowVersionsRegexes[0]",
+					"spec.subscriptions[0].pkg.config.ignoreVersionsRegexes[1]",
+				}, fields)
+			},
+		},
+		{
+			name: "invalid maven",
+			sub: newPackageSubscription(t, mavenSubscriptionType, map[string]any{
+				"package":           "service",
+				"versionConstraint": "1.0",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(t, []string{
+					"spec.subscriptions[0].pkg.config.package",
+					"spec.subscriptions[0].pkg.config.versionConstraint",
+				}, fields)
+			},
+		},
+		{
+			name: "missing package",
+			sub:  newPackageSubscription(t, pypiSubscriptionType, map[string]any{}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].pkg.config.package", errs[0].Field)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reg, err := DefaultSubscriberRegistry.Get(t.Context(), testCase.sub)
+			require.NoError(t, err)
+			subscriber, err := reg.Value(t.Context(), nil)
+			require.NoError(t, err)
+			testCase.assertions(
+				t,
+				subscriber.ValidateSubscription(
+					t.Context(),
+					field.NewPath("spec").Child("subscriptions").Index(0).Child("pkg"),
+					testCase.sub,
+				),
+			)
+		})
+	}
+}
+
+func Test_packageSubscriber_selectVersions(t *testing.T) {
+	s := &packageSubscriber[mavenVersion]{registry: mavenRegistry{}}
+	versions := []packageVersion{
+		{version: "1.0"},
+		{version: "1.10"},
+		{version: "1.2"},
+		{version: "2.0-SNAPSHOT"},
+		{version: "1.9-rc1"},
+		{version: "1.5"},
+		{version: "33.0.0-jre"},
+	}
+	selectedVersions := func(cfg packageSubscriptionConfig) []string {
+		selected, err := s.selectVersions(cfg, versions)
+		require.NoError(t, err)
+		v := make([]string, len(selected))
+		for i, version := range selected {
+			v[i] = version.version
+		}
+		return v
+	}
+
+	require.Equal(
+		t,
+		[]string{"33.0.0-jre", "1.10", "1.5", "1.2", "1.0"},
+		selectedVersions(packageSubscriptionConfig{}),
+	)
+	require.Equal(
+		t,
+		[]string{"2.0-SNAPSHOT", "1.10", "1.9-rc1", "1.5", "1.2"},
+		selectedVersions(packageSubscriptionConfig{
+			AllowPrereleases:      true,
+			VersionConstraint:     "(1.0,3.0)",
+			IgnoreVersionsRegexes: []string{`^33\.`},
+		}),
+	)
+	require.Equal(
+		t,
+		[]string{"1.5", "1.2"},
+		selectedVersions(packageSubscriptionConfig{
+			AllowVersionsRegexes:  []string{`^1\.[0-9]$`},
+			IgnoreVersionsRegexes: []string{`^1\.0$`},
+		}),
+	)
+
+	_, err := s.selectVersions(
+		packageSubscriptionConfig{AllowVersionsRegexes: []string{"("}},
+		versions,
+	)
+	require.ErrorContains(t, err, "error compiling allow versions regex")
+}
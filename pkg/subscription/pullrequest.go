@@ -0,0 +1,373 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/gitprovider"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/validation"
+
+	_ "github.com/akuity/kargo/pkg/gitprovider/azure"           // Azure provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/bitbucket/cloud" // Bitbucket Cloud provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitea"           // Gitea provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/github"          // GitHub provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitlab"          // GitLab provider registration
+)
+
+const (
+	// pullRequestSubscriptionType is the SubscriptionType of generic
+	// subscriptions handled by the pullRequestSubscriber.
+	pullRequestSubscriptionType = "pullrequest"
+	// pullRequestArtifactType is the ArtifactType of the ArtifactReferences
+	// discovered by the pullRequestSubscriber.
+	pullRequestArtifactType = "pullrequest"
+)
+
+func init() {
+	DefaultSubscriberRegistry.MustRegister(SubscriberRegistration{
+		Predicate: func(
+			_ context.Context,
+			sub kargoapi.RepoSubscription,
+		) (bool, error) {
+			return sub.Subscription != nil &&
+				sub.Subscription.SubscriptionType == pullRequestSubscriptionType, nil
+		},
+		Value: newPullRequestSubscriber,
+	})
+}
+
+// pullRequestSubscriptionConfig is the configuration of a generic subscription
+// of type "pullrequest".
+type pullRequestSubscriptionConfig struct {
+	// RepoURL is the URL of the Git repository whose pull requests are
+	// subscribed to.
+	RepoURL string `json:"repoURL"`
+	// Provider is the name of the Git hosting provider hosting the repository.
+	// It only needs to be specified when it cannot be inferred from RepoURL.
+	Provider string `json:"provider,omitempty"`
+	// BaseBranch limits discovery to pull requests targeting the specified
+	// branch.
+	BaseBranch string `json:"baseBranch,omitempty"`
+	// Labels limits discovery to pull requests having all the specified labels.
+	// Git hosting providers that do not support labels never have any pull
+	// requests matching a non-empty list of labels.
+	Labels []string `json:"labels,omitempty"`
+	// IgnoreDrafts specifies whether draft pull requests should be ignored.
+	IgnoreDrafts bool `json:"ignoreDrafts,omitempty"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when connecting to the Git hosting provider's API.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// pullRequestMetadata is the metadata recorded on each ArtifactReference
+// discovered by the pullRequestSubscriber.
+type pullRequestMetadata struct {
+	// RepoURL is the URL of the repository the pull request belongs to.
+	RepoURL string `json:"repoURL"`
+	// Number is the pull request number.
+	Number int64 `json:"number"`
+	// Title is the title of the pull request.
+	Title string `json:"title,omitempty"`
+	// URL is the URL to the pull request.
+	URL string `json:"url,omitempty"`
+	// HeadSHA is the SHA of the commit at the head of the pull request's source
+	// branch.
+	HeadSHA string `json:"headSHA"`
+	// HeadBranch is the name of the pull request's source branch.
+	HeadBranch string `json:"headBranch,omitempty"`
+	// BaseBranch is the name of the pull request's target branch.
+	BaseBranch string `json:"baseBranch,omitempty"`
+	// Labels are the labels applied to the pull request.
+	Labels []string `json:"labels,omitempty"`
+	// CreatedAt is the time the pull request was created.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// supportedPullRequestProviders are the names of the Git hosting providers
+// that the pullRequestSubscriber can discover pull requests from.
+var supportedPullRequestProviders = []string{
+	"azure", "bitbucket", "gitea", "github", "gitlab",
+}
+
+// pullRequestSubscriber is an implementation of the Subscriber interface that
+// discovers the head commits of a Git repository's open pull requests from
+// its Git hosting provider. Since each open pull request is independent of
+// all others, it is also a FreightPerArtifactSubscriber.
+type pullRequestSubscriber struct {
+	credentialsDB credentials.Database
+
+	// newGitProviderFn constructs the gitprovider.Interface for a repository.
+	// It is a field so tests can substitute a fake one for the real one.
+	newGitProviderFn func(
+		repoURL string,
+		opts *gitprovider.Options,
+	) (gitprovider.Interface, error)
+}
+
+// newPullRequestSubscriber returns an implementation of the Subscriber
+// interface that discovers the head commits of a Git repository's open pull
+// requests from its Git hosting provider.
+func newPullRequestSubscriber(
+	_ context.Context,
+	credentialsDB credentials.Database,
+) (Subscriber, error) {
+	return &pullRequestSubscriber{
+		credentialsDB:    credentialsDB,
+		newGitProviderFn: gitprovider.New,
+	}, nil
+}
+
+// ApplySubscriptionDefaults implements Subscriber.
+func (p *pullRequestSubscriber) ApplySubscriptionDefaults(
+	context.Context,
+	*kargoapi.RepoSubscription,
+) error {
+	// Pull request subscriptions have no defaults beyond those common to all
+	// generic subscriptions.
+	return nil
+}
+
+// ValidateSubscription implements Subscriber.
+func (p *pullRequestSubscriber) ValidateSubscription(
+	_ context.Context,
+	f *field.Path,
+	s kargoapi.RepoSubscription,
+) field.ErrorList {
+	f = f.Child("config")
+	cfg, err := parsePullRequestSubscriptionConfig(s.Subscription)
+	if err != nil {
+		return field.ErrorList{field.Invalid(f, "", err.Error())}
+	}
+
+	var errs field.ErrorList
+
+	// Validate RepoURL: MinLength=1, Pattern (HTTP/S Git URL)
+	if err := validation.MinLength(f.Child("repoURL"), cfg.RepoURL, 1); err != nil {
+		errs = append(errs, err)
+	} else if !gitURLRegex.MatchString(cfg.RepoURL) ||
+		!(strings.HasPrefix(cfg.RepoURL, "https://") ||
+			strings.HasPrefix(cfg.RepoURL, "http://")) {
+		errs = append(errs, field.Invalid(
+			f.Child("repoURL"),
+			cfg.RepoURL,
+			"must be a valid HTTP/S Git repository URL",
+		))
+	}
+
+	// Validate Provider: Enum
+	if cfg.Provider != "" && !slices.Contains(supportedPullRequestProviders, cfg.Provider) {
+		errs = append(errs, field.NotSupported(
+			f.Child("provider"),
+			cfg.Provider,
+			supportedPullRequestProviders,
+		))
+	}
+
+	// Validate Labels: no empty values
+	for i, label := range cfg.Labels {
+		if strings.TrimSpace(label) == "" {
+			errs = append(errs, field.Invalid(
+				f.Child("labels").Index(i),
+				label,
+				"must not be empty",
+			))
+		}
+	}
+
+	return errs
+}
+
+// DiscoverArtifacts implements Subscriber.
+func (p *pullRequestSubscriber) DiscoverArtifacts(
+	ctx context.Context,
+	project string,
+	sub kargoapi.RepoSubscription,
+	_ any,
+) (any, error) {
+	if sub.Subscription == nil {
+		return nil, nil
+	}
+
+	cfg, err := parsePullRequestSubscriptionConfig(sub.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithValues("repo", cfg.RepoURL)
+
+	// Obtain credentials for the Git hosting provider's API.
+	creds, err := p.credentialsDB.Get(ctx, project, credentials.TypeGit, cfg.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+	gpOpts := &gitprovider.Options{
+		Name:                  cfg.Provider,
+		InsecureSkipTLSVerify: cfg.InsecureSkipTLSVerify,
+	}
+	if creds != nil {
+		gpOpts.Token = creds.Password
+		logger.Debug("obtained credentials for git repo")
+	} else {
+		logger.Debug("found no credentials for git repo")
+	}
+
+	provider, err := p.newGitProviderFn(cfg.RepoURL, gpOpts)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining git provider for git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+	prs, err := provider.ListPullRequests(
+		ctx,
+		&gitprovider.ListPullRequestOptions{
+			State:      gitprovider.PullRequestStateOpen,
+			BaseBranch: cfg.BaseBranch,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing pull requests of git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+
+	refs, err := selectPullRequests(cfg, prs)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		logger.Debug("discovered no pull requests")
+	} else {
+		logger.Debug("discovered pull requests", "count", len(refs))
+	}
+
+	refs = trimSlice(refs, int(sub.Subscription.DiscoveryLimit))
+	for i := range refs {
+		refs[i].SubscriptionName = sub.Name
+	}
+	return kargoapi.DiscoveryResult{
+		SubscriptionName:   sub.Name,
+		ArtifactReferences: refs,
+	}, nil
+}
+
+// FreightPerArtifact implements FreightPerArtifactSubscriber.
+func (p *pullRequestSubscriber) FreightPerArtifact(kargoapi.RepoSubscription) bool {
+	return true
+}
+
+// selectPullRequests filters the provided pull requests according to the
+// provided configuration and returns references to the head commits of those
+// remaining, ordered from the most recently opened pull request to the least
+// recently opened one.
+func selectPullRequests(
+	cfg pullRequestSubscriptionConfig,
+	prs []gitprovider.PullRequest,
+) ([]kargoapi.ArtifactReference, error) {
+	selected := make([]gitprovider.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		// Not every provider filters by state and base branch server-side, so we
+		// re-apply those filters here.
+		if !pr.Open || pr.HeadSHA == "" {
+			continue
+		}
+		if cfg.BaseBranch != "" && pr.BaseBranch != "" && pr.BaseBranch != cfg.BaseBranch {
+			continue
+		}
+		if cfg.IgnoreDrafts && pr.Draft {
+			continue
+		}
+		if !hasAllLabels(pr.Labels, cfg.Labels) {
+			continue
+		}
+		selected = append(selected, pr)
+	}
+
+	// Pull request numbers increase monotonically within a repository, so
+	// sorting by number puts the most recently opened pull requests first.
+	slices.SortStableFunc(selected, func(lhs, rhs gitprovider.PullRequest) int {
+		switch {
+		case lhs.Number > rhs.Number:
+			return -1
+		case lhs.Number < rhs.Number:
+			return 1
+		}
+		return 0
+	})
+
+	refs := make([]kargoapi.ArtifactReference, len(selected))
+	for i, pr := range selected {
+		metadata, err := json.Marshal(pullRequestMetadata{
+			RepoURL:    cfg.RepoURL,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			URL:        pr.URL,
+			HeadSHA:    pr.HeadSHA,
+			HeadBranch: pr.HeadBranch,
+			BaseBranch: pr.BaseBranch,
+			Labels:     pr.Labels,
+			CreatedAt:  pr.CreatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error marshaling metadata of pull request %d: %w",
+				pr.Number, err,
+			)
+		}
+		refs[i] = kargoapi.ArtifactReference{
+			ArtifactType: pullRequestArtifactType,
+			// The version incorporates the pull request number because distinct
+			// pull requests may share a head commit, but must not share Freight.
+			Version:  fmt.Sprintf("pr-%d@%s", pr.Number, pr.HeadSHA),
+			Metadata: &apiextensionsv1.JSON{Raw: metadata},
+		}
+	}
+	return refs, nil
+}
+
+// hasAllLabels returns true if the provided labels include all the required
+// ones. Labels are compared case-insensitively, as most Git hosting providers
+// treat them that way.
+func hasAllLabels(labels []string, required []string) bool {
+	for _, r := range required {
+		if !slices.ContainsFunc(labels, func(l string) bool {
+			return strings.EqualFold(l, r)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePullRequestSubscriptionConfig parses the configuration of the provided
+// generic subscription as a pullRequestSubscriptionConfig.
+func parsePullRequestSubscriptionConfig(
+	sub *kargoapi.Subscription,
+) (pullRequestSubscriptionConfig, error) {
+	var cfg pullRequestSubscriptionConfig
+	if sub == nil || sub.Config == nil || len(sub.Config.Raw) == 0 {
+		return cfg, errors.New("configuration is required")
+	}
+	dec := json.NewDecoder(bytes.NewReader(sub.Config.Raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	return cfg, nil
+}
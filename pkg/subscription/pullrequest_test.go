@@ -0,0 +1,293 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/gitprovider"
+)
+
+func newPullRequestSubscription(t *testing.T, cfg map[string]any) kargoapi.RepoSubscription {
+	t.Helper()
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return kargoapi.RepoSubscription{
+		Name: "fake-sub",
+		Subscription: &kargoapi.Subscription{
+			SubscriptionType: pullRequestSubscriptionType,
+			Config:           &apiextensionsv1.JSON{Raw: raw},
+			DiscoveryLimit:   20,
+		},
+	}
+}
+
+func Test_pullRequestSubscriber_registration(t *testing.T) {
+	sub := newPullRequestSubscription(t, map[string]any{"repoURL": "https://github.com/example/repo"})
+	reg, err := DefaultSubscriberRegistry.Get(t.Context(), sub)
+	require.NoError(t, err)
+	subscriber, err := reg.Value(t.Context(), nil)
+	require.NoError(t, err)
+	require.IsType(t, &pullRequestSubscriber{}, subscriber)
+
+	s, ok := subscriber.(FreightPerArtifactSubscriber)
+	require.True(t, ok)
+	require.True(t, s.FreightPerArtifact(sub))
+}
+
+func Test_pullRequestSubscriber_ValidateSubscription(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.RepoSubscription
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "valid",
+			sub: newPullRequestSubscription(t, map[string]any{
+				"repoURL":      "https://gitlab.example.com/group/repo",
+				"provider":     "gitlab",
+				"baseBranch":   "main",
+				"labels":       []string{"preview"},
+				"ignoreDrafts": true,
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "missing config",
+			sub: kargoapi.RepoSubscription{
+				Subscription: &kargoapi.Subscription{
+					SubscriptionType: pullRequestSubscriptionType,
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].pr.config", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "configuration is required")
+			},
+		},
+		{
+			name: "unknown field",
+			sub: newPullRequestSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+				"bogus":   true,
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Contains(t, errs[0].Detail, `unknown field "bogus"`)
+			},
+		},
+		{
+			name: "invalid fields",
+			sub: newPullRequestSubscription(t, map[string]any{
+				"repoURL":  "git@github.com:example/repo.git",
+				"provider": "sourcehut",
+				"labels":   []string{"preview", " "},
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(t, []string{
+					"spec.subscriptions[0].pr.config.repoURL",
+					"spec.subscriptions[0].pr.config.provider",
+					"spec.subscriptions[0].pr.config.labels[1]",
+				}, fields)
+			},
+		},
+		{
+			name: "missing repoURL",
+			sub:  newPullRequestSubscription(t, map[string]any{}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].pr.config.repoURL", errs[0].Field)
+			},
+		},
+	}
+	s := &pullRequestSubscriber{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				s.ValidateSubscription(
+					t.Context(),
+					field.NewPath("spec").Child("subscriptions").Index(0).Child("pr"),
+					testCase.sub,
+				),
+			)
+		})
+	}
+}
+
+func Test_pullRequestSubscriber_DiscoverArtifacts(t *testing.T) {
+	prs := []gitprovider.PullRequest{
+		{
+			Number:     1,
+			Title:      "Old feature",
+			URL:        "https://github.com/example/repo/pull/1",
+			Open:       true,
+			HeadSHA:    "sha-1",
+			HeadBranch: "old-feature",
+			BaseBranch: "main",
+			Labels:     []string{"Preview"},
+		},
+		{Number: 2, Open: true, HeadSHA: "sha-2", BaseBranch: "release-1.0", Labels: []string{"preview"}},
+		{Number: 3, Open: false, Merged: true, HeadSHA: "sha-3", BaseBranch: "main"},
+		{Number: 4, Open: true, HeadSHA: "sha-4", BaseBranch: "main", Draft: true},
+		{Number: 5, Open: true, HeadSHA: "sha-5", BaseBranch: "main", Labels: []string{"bug"}},
+		{Number: 6, Open: true, BaseBranch: "main"},
+	}
+	newSubscriber := func(t *testing.T) *pullRequestSubscriber {
+		return &pullRequestSubscriber{
+			credentialsDB: &credentials.FakeDB{
+				GetFn: func(
+					_ context.Context,
+					project string,
+					credType credentials.Type,
+					repoURL string,
+				) (*credentials.Credentials, error) {
+					require.Equal(t, "fake-project", project)
+					require.Equal(t, credentials.TypeGit, credType)
+					require.Equal(t, "https://github.com/example/repo", repoURL)
+					return &credentials.Credentials{Password: "fake-token"}, nil
+				},
+			},
+			newGitProviderFn: func(
+				repoURL string,
+				opts *gitprovider.Options,
+			) (gitprovider.Interface, error) {
+				require.Equal(t, "https://github.com/example/repo", repoURL)
+				require.Equal(t, "fake-token", opts.Token)
+				return &gitprovider.Fake{
+					ListPullRequestsFn: func(
+						_ context.Context,
+						opts *gitprovider.ListPullRequestOptions,
+					) ([]gitprovider.PullRequest, error) {
+						require.Equal(t, gitprovider.PullRequestStateOpen, opts.State)
+						return prs, nil
+					},
+				}, nil
+			},
+		}
+	}
+	versions := func(res any) []string {
+		result, ok := res.(kargoapi.DiscoveryResult)
+		require.True(t, ok)
+		require.Equal(t, "fake-sub", result.SubscriptionName)
+		v := make([]string, len(result.ArtifactReferences))
+		for i, ref := range result.ArtifactReferences {
+			require.Equal(t, pullRequestArtifactType, ref.ArtifactType)
+			require.Equal(t, "fake-sub", ref.SubscriptionName)
+			v[i] = ref.Version
+		}
+		return v
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		res, err := newSubscriber(t).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newPullRequestSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+			}),
+			nil,
+		)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]string{"pr-5@sha-5", "pr-4@sha-4", "pr-2@sha-2", "pr-1@sha-1"},
+			versions(res),
+		)
+
+		var metadata pullRequestMetadata
+		require.NoError(t, json.Unmarshal(
+			res.(kargoapi.DiscoveryResult).ArtifactReferences[3].Metadata.Raw, // nolint: forcetypeassert
+			&metadata,
+		))
+		require.Equal(t, pullRequestMetadata{
+			RepoURL:    "https://github.com/example/repo",
+			Number:     1,
+			Title:      "Old feature",
+			URL:        "https://github.com/example/repo/pull/1",
+			HeadSHA:    "sha-1",
+			HeadBranch: "old-feature",
+			BaseBranch: "main",
+			Labels:     []string{"Preview"},
+		}, metadata)
+	})
+
+	t.Run("filters and limit", func(t *testing.T) {
+		s := newSubscriber(t)
+		sub := newPullRequestSubscription(t, map[string]any{
+			"repoURL":      "https://github.com/example/repo",
+			"baseBranch":   "main",
+			"ignoreDrafts": true,
+		})
+		res, err := s.DiscoverArtifacts(t.Context(), "fake-project", sub, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-5@sha-5", "pr-1@sha-1"}, versions(res))
+
+		sub.Subscription.DiscoveryLimit = 1
+		res, err = s.DiscoverArtifacts(t.Context(), "fake-project", sub, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-5@sha-5"}, versions(res))
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		res, err := newSubscriber(t).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newPullRequestSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+				"labels":  []string{"preview"},
+			}),
+			nil,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-2@sha-2", "pr-1@sha-1"}, versions(res))
+	})
+
+	t.Run("error listing pull requests", func(t *testing.T) {
+		s := newSubscriber(t)
+		s.newGitProviderFn = func(
+			string,
+			*gitprovider.Options,
+		) (gitprovider.Interface, error) {
+			return &gitprovider.Fake{
+				ListPullRequestsFn: func(
+					context.Context,
+					*gitprovider.ListPullRequestOptions,
+				) ([]gitprovider.PullRequest, error) {
+					return nil, errors.New("something went wrong")
+				},
+			}, nil
+		}
+		_, err := s.DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newPullRequestSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+			}),
+			nil,
+		)
+		require.ErrorContains(t, err, "error listing pull requests")
+		require.ErrorContains(t, err, "something went wrong")
+	})
+}
+
+func Test_hasAllLabels(t *testing.T) {
+	require.True(t, hasAllLabels(nil, nil))
+	require.True(t, hasAllLabels([]string{"a", "B"}, []string{"b"}))
+	require.True(t, hasAllLabels([]string{"a", "b"}, []string{"a", "b"}))
+	require.False(t, hasAllLabels([]string{"a"}, []string{"a", "b"}))
+	require.False(t, hasAllLabels(nil, []string{"a"}))
+}
@@ -0,0 +1,647 @@
+package subscription
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// pypiSubscriptionType is the SubscriptionType of generic subscriptions to
+	// Python package indices. It is also the ArtifactType of the
+	// ArtifactReferences discovered by them.
+	pypiSubscriptionType = "pypi"
+
+	// defaultPyPIRegistryURL is the URL of PyPI's simple repository API.
+	defaultPyPIRegistryURL = "https://pypi.org/simple"
+
+	// pypiSimpleJSONContentType is the content type of the JSON flavor of the
+	// simple repository API.
+	pypiSimpleJSONContentType = "application/vnd.pypi.simple.v1+json"
+)
+
+var (
+	// pypiProjectNameRegex matches valid Python project names.
+	pypiProjectNameRegex = regexp.MustCompile(
+		`^(?i:[a-z0-9]|[a-z0-9][a-z0-9._-]*[a-z0-9])$`,
+	)
+	// pypiNameSeparatorsRegex matches the runs of characters that are replaced
+	// by a single hyphen when normalizing a Python project name.
+	pypiNameSeparatorsRegex = regexp.MustCompile(`[-_.]+`)
+	// pep440VersionRegex matches versions as defined by PEP 440.
+	pep440VersionRegex = regexp.MustCompile(
+		`^(?i)v?(?:(?P<epoch>[0-9]+)!)?(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+			`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+			`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+			`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+			`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`,
+	)
+	// pypiSdistExtensions are the extensions of source distributions.
+	pypiSdistExtensions = []string{".tar.gz", ".zip", ".tar.bz2", ".tar.xz", ".tgz"}
+)
+
+func init() {
+	registerPackageSubscriber[pep440Version](pypiSubscriptionType, pypiRegistry{})
+}
+
+// pypiSimpleProject is the subset of the JSON flavor of the simple repository
+// API's project document (PEP 691) that is needed to discover versions.
+type pypiSimpleProject struct {
+	Files []struct {
+		Filename   string            `json:"filename"`
+		URL        string            `json:"url"`
+		Hashes     map[string]string `json:"hashes"`
+		UploadTime string            `json:"upload-time"`
+		// Yanked is either a boolean or the reason the file was yanked.
+		Yanked any `json:"yanked"`
+	} `json:"files"`
+}
+
+// pypiFile is a file listed in a Python package index.
+type pypiFile struct {
+	packageFile
+	uploadedAt *time.Time
+	yanked     bool
+}
+
+// pypiRegistry is the packageRegistry implementation for Python package
+// indices implementing the simple repository API, in either its HTML or JSON
+// flavor. Versions of Python packages are ordered as specified by PEP 440.
+type pypiRegistry struct{}
+
+func (pypiRegistry) defaultRegistryURL() string {
+	return defaultPyPIRegistryURL
+}
+
+func (pypiRegistry) validateConfig(
+	f *field.Path,
+	cfg packageSubscriptionConfig,
+) field.ErrorList {
+	var errs field.ErrorList
+	if cfg.Package != "" && !pypiProjectNameRegex.MatchString(cfg.Package) {
+		errs = append(errs, field.Invalid(
+			f.Child("package"),
+			cfg.Package,
+			"must be a valid Python project name",
+		))
+	}
+	if cfg.Packaging != "" {
+		errs = append(errs, field.Forbidden(
+			f.Child("packaging"),
+			"is only applicable to Maven",
+		))
+	}
+	return errs
+}
+
+func (pypiRegistry) parseVersion(version string) (pep440Version, error) {
+	return parsePEP440Version(version)
+}
+
+func (pypiRegistry) compareVersions(lhs, rhs pep440Version) int {
+	return lhs.compare(rhs)
+}
+
+func (pypiRegistry) isPrerelease(version pep440Version) bool {
+	return version.isPrerelease()
+}
+
+func (pypiRegistry) parseConstraint(constraint string) (func(pep440Version) bool, error) {
+	s, err := parsePEP440Specifiers(constraint)
+	if err != nil {
+		return nil, err
+	}
+	return s.contains, nil
+}
+
+func (pypiRegistry) listVersions(
+	ctx context.Context,
+	client *packageRegistryClient,
+	cfg packageSubscriptionConfig,
+) ([]packageVersion, error) {
+	project := normalizePyPIProjectName(cfg.Package)
+	projectURL := strings.TrimSuffix(cfg.RegistryURL, "/") + "/" + project + "/"
+	body, contentType, err := client.get(
+		ctx,
+		projectURL,
+		pypiSimpleJSONContentType+", text/html;q=0.1",
+	)
+	if err != nil {
+		return nil, err
+	}
+	var files []pypiFile
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == pypiSimpleJSONContentType {
+		files, err = parsePyPISimpleJSON(body)
+	} else {
+		files, err = parsePyPISimpleHTML(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(projectURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL %q: %w", projectURL, err)
+	}
+	filesByVersion := map[string][]pypiFile{}
+	for _, file := range files {
+		if file.yanked {
+			continue
+		}
+		version := pypiFileVersion(project, file.Name)
+		if version == "" {
+			continue
+		}
+		ref, err := url.Parse(file.URL)
+		if err != nil {
+			continue
+		}
+		file.URL = baseURL.ResolveReference(ref).String()
+		filesByVersion[version] = append(filesByVersion[version], file)
+	}
+
+	versions := make([]packageVersion, 0, len(filesByVersion))
+	for version, files := range filesByVersion {
+		slices.SortFunc(files, func(lhs, rhs pypiFile) int {
+			return cmp.Compare(lhs.Name, rhs.Name)
+		})
+		metadata := packageMetadata{
+			RegistryURL: cfg.RegistryURL,
+			Package:     cfg.Package,
+			Files:       make([]packageFile, len(files)),
+		}
+		for i, file := range files {
+			metadata.Files[i] = file.packageFile
+			if metadata.PublishedAt == nil ||
+				(file.uploadedAt != nil && file.uploadedAt.Before(*metadata.PublishedAt)) {
+				metadata.PublishedAt = file.uploadedAt
+			}
+		}
+		// The source distribution, if there is one, is the main file.
+		main := files[0]
+		for _, file := range files {
+			if isPyPISdist(file.Name) {
+				main = file
+				break
+			}
+		}
+		metadata.URL = main.URL
+		metadata.Checksum = main.Checksum
+		versions = append(versions, packageVersion{
+			version:  version,
+			metadata: metadata,
+		})
+	}
+	return versions, nil
+}
+
+func (pypiRegistry) completeMetadata(
+	context.Context,
+	*packageRegistryClient,
+	*packageVersion,
+) error {
+	// Project documents include everything that is recorded.
+	return nil
+}
+
+// parsePyPISimpleJSON parses a project document of the JSON flavor of the
+// simple repository API.
+func parsePyPISimpleJSON(body []byte) ([]pypiFile, error) {
+	var project pypiSimpleProject
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("error parsing project document: %w", err)
+	}
+	files := make([]pypiFile, len(project.Files))
+	for i, f := range project.Files {
+		files[i] = pypiFile{
+			packageFile: packageFile{
+				Name:     f.Filename,
+				URL:      f.URL,
+				Checksum: pypiChecksum(f.Hashes),
+			},
+		}
+		if uploadedAt, err := time.Parse(time.RFC3339, f.UploadTime); err == nil {
+			files[i].uploadedAt = &uploadedAt
+		}
+		switch yanked := f.Yanked.(type) {
+		case bool:
+			files[i].yanked = yanked
+		case string:
+			files[i].yanked = true
+		}
+	}
+	return files, nil
+}
+
+// parsePyPISimpleHTML parses a project page of the HTML flavor of the simple
+// repository API (PEP 503), where each file is a link whose text is the
+// file's name and whose URL's fragment may carry a hash of its content.
+func parsePyPISimpleHTML(body []byte) ([]pypiFile, error) {
+	var files []pypiFile
+	var current *pypiFile
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("error parsing project page: %w", err)
+			}
+			return files, nil
+		case html.StartTagToken:
+			tag, hasAttrs := tokenizer.TagName()
+			if string(tag) != "a" || !hasAttrs {
+				continue
+			}
+			file := pypiFile{}
+			for {
+				key, val, more := tokenizer.TagAttr()
+				switch string(key) {
+				case "href":
+					file.URL = string(val)
+				case "data-yanked":
+					file.yanked = true
+				}
+				if !more {
+					break
+				}
+			}
+			if file.URL == "" {
+				continue
+			}
+			if u, err := url.Parse(file.URL); err == nil {
+				if algorithm, digest, ok := strings.Cut(u.Fragment, "="); ok {
+					file.Checksum = pypiChecksum(map[string]string{algorithm: digest})
+				}
+				u.Fragment = ""
+				file.URL = u.String()
+				file.Name = path.Base(u.Path)
+			}
+			files = append(files, file)
+			current = &files[len(files)-1]
+		case html.TextToken:
+			if current != nil {
+				if name := strings.TrimSpace(string(tokenizer.Text())); name != "" {
+					current.Name = name
+				}
+			}
+		case html.EndTagToken:
+			current = nil
+		}
+	}
+}
+
+// pypiHashAlgorithms are the hash algorithms a Python package index may report
+// hashes of files with, from the strongest to the weakest.
+var pypiHashAlgorithms = []string{"sha512", "sha384", "sha256", "sha224", "sha1", "md5"}
+
+// pypiChecksum returns the strongest of the provided hashes, keyed by hash
+// algorithm, in the form <algorithm>:<hex digest>.
+func pypiChecksum(hashes map[string]string) string {
+	for _, algorithm := range pypiHashAlgorithms {
+		if digest, ok := hashes[algorithm]; ok && digest != "" {
+			return algorithm + ":" + strings.ToLower(digest)
+		}
+	}
+	return ""
+}
+
+// normalizePyPIProjectName normalizes a Python project name as specified by
+// PEP 503.
+func normalizePyPIProjectName(name string) string {
+	return strings.ToLower(pypiNameSeparatorsRegex.ReplaceAllString(name, "-"))
+}
+
+// isPyPISdist returns true if the file with the provided name is a source
+// distribution.
+func isPyPISdist(filename string) bool {
+	return slices.ContainsFunc(pypiSdistExtensions, func(ext string) bool {
+		return strings.HasSuffix(filename, ext)
+	})
+}
+
+// pypiFileVersion returns the version of the provided (normalized) project
+// that the wheel or source distribution with the provided name belongs to. It
+// returns an empty string for other types of files and files that belong to
+// other projects.
+func pypiFileVersion(project string, filename string) string {
+	var name, version string
+	switch {
+	case strings.HasSuffix(filename, ".whl"):
+		// {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl
+		parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		name, version = parts[0], parts[1]
+	case isPyPISdist(filename):
+		// {name}-{version}.{extension}, where older source distributions may
+		// have hyphens in the name but never in the version.
+		for _, ext := range pypiSdistExtensions {
+			if base, ok := strings.CutSuffix(filename, ext); ok {
+				i := strings.LastIndex(base, "-")
+				if i < 0 {
+					return ""
+				}
+				name, version = base[:i], base[i+1:]
+				break
+			}
+		}
+	default:
+		return ""
+	}
+	if normalizePyPIProjectName(name) != project {
+		return ""
+	}
+	return version
+}
+
+// pep440Version is a parsed PEP 440 version.
+type pep440Version struct {
+	epoch   int
+	release []int
+	// preKind is the kind of pre-release ("a", "b" or "rc"), if the version is
+	// a pre-release.
+	preKind string
+	preNum  int
+	post    *int
+	dev     *int
+}
+
+// parsePEP440Version parses a PEP 440 version, accepting the alternative
+// spellings PEP 440 normalizes.
+func parsePEP440Version(version string) (pep440Version, error) {
+	matches := pep440VersionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return pep440Version{}, fmt.Errorf("%q is not a valid PEP 440 version", version)
+	}
+	group := func(name string) string {
+		return matches[pep440VersionRegex.SubexpIndex(name)]
+	}
+	atoi := func(s string) int {
+		// The regular expression guarantees s is a number. Absurdly large
+		// numbers saturate rather than fail.
+		n, err := strconv.Atoi(s)
+		if err != nil && s != "" {
+			return int(^uint(0) >> 1)
+		}
+		return n
+	}
+
+	var v pep440Version
+	v.epoch = atoi(group("epoch"))
+	for _, part := range strings.Split(group("release"), ".") {
+		v.release = append(v.release, atoi(part))
+	}
+	if preL := strings.ToLower(group("pre_l")); preL != "" {
+		switch preL {
+		case "a", "alpha":
+			v.preKind = "a"
+		case "b", "beta":
+			v.preKind = "b"
+		default:
+			v.preKind = "rc"
+		}
+		v.preNum = atoi(group("pre_n"))
+	}
+	if postN := group("post_n1"); postN != "" {
+		n := atoi(postN)
+		v.post = &n
+	} else if group("post_l") != "" {
+		n := atoi(group("post_n2"))
+		v.post = &n
+	}
+	if group("dev_l") != "" {
+		n := atoi(group("dev_n"))
+		v.dev = &n
+	}
+	return v, nil
+}
+
+// isPrerelease returns true if the version is a pre-release or a development
+// release.
+func (v pep440Version) isPrerelease() bool {
+	return v.preKind != "" || v.dev != nil
+}
+
+// compare returns a negative number if the version is lower than the other
+// version, a positive number if it is higher and zero if they are equal. Local
+// version labels are ignored.
+func (v pep440Version) compare(other pep440Version) int {
+	if c := cmp.Compare(v.epoch, other.epoch); c != 0 {
+		return c
+	}
+	if c := compareReleases(v.release, other.release); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.preRank(), other.preRank()); c != 0 {
+		return c
+	}
+	if v.preKind != "" && other.preKind != "" {
+		if c := cmp.Compare(v.preNum, other.preNum); c != 0 {
+			return c
+		}
+	}
+	// No post-release sorts before any post-release.
+	if c := comparePEP440Optional(v.post, other.post, -1); c != 0 {
+		return c
+	}
+	// No development release sorts after any development release.
+	return comparePEP440Optional(v.dev, other.dev, 1)
+}
+
+// preRank ranks the pre-release segment of the version: development releases
+// of a final release, then alpha, beta and release candidates, then final and
+// post releases.
+func (v pep440Version) preRank() int {
+	switch {
+	case v.preKind == "" && v.post == nil && v.dev != nil:
+		return 0
+	case v.preKind == "a":
+		return 1
+	case v.preKind == "b":
+		return 2
+	case v.preKind == "rc":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// comparePEP440Optional compares two optional numbers. missing is the result
+// of comparing a missing number to a present one.
+func comparePEP440Optional(lhs, rhs *int, missing int) int {
+	switch {
+	case lhs == nil && rhs == nil:
+		return 0
+	case lhs == nil:
+		return missing
+	case rhs == nil:
+		return -missing
+	default:
+		return cmp.Compare(*lhs, *rhs)
+	}
+}
+
+// compareReleases compares two release segments, padding the shorter one with
+// zeros.
+func compareReleases(lhs, rhs []int) int {
+	for i := range max(len(lhs), len(rhs)) {
+		var l, r int
+		if i < len(lhs) {
+			l = lhs[i]
+		}
+		if i < len(rhs) {
+			r = rhs[i]
+		}
+		if c := cmp.Compare(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// pep440Specifiers is a parsed PEP 440 version specifier, such as
+// >=1.0,!=1.3.*,<2.0. A version satisfies it if it satisfies all of its
+// clauses.
+type pep440Specifiers []func(pep440Version) bool
+
+// pep440SpecifierRegex matches a clause of a PEP 440 version specifier.
+var pep440SpecifierRegex = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*(\S+)$`)
+
+// parsePEP440Specifiers parses a PEP 440 version specifier. Arbitrary equality
+// (===) compares versions as strings.
+func parsePEP440Specifiers(specifier string) (pep440Specifiers, error) {
+	var s pep440Specifiers
+	for _, clause := range strings.Split(specifier, ",") {
+		clause = strings.TrimSpace(clause)
+		matches := pep440SpecifierRegex.FindStringSubmatch(clause)
+		if matches == nil {
+			return nil, fmt.Errorf("%q is not a valid version specifier clause", clause)
+		}
+		op, operand := matches[1], matches[2]
+		if op == "===" {
+			s = append(s, func(v pep440Version) bool {
+				return v.String() == operand
+			})
+			continue
+		}
+		prefix, isPrefix := strings.CutSuffix(operand, ".*")
+		if isPrefix && op != "==" && op != "!=" {
+			return nil, fmt.Errorf("%q may not be used with a wildcard", op)
+		}
+		version, err := parsePEP440Version(prefix)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case isPrefix:
+			matchesPrefix := func(v pep440Version) bool {
+				return v.epoch == version.epoch &&
+					len(v.release) >= len(version.release) &&
+					compareReleases(v.release[:len(version.release)], version.release) == 0
+			}
+			if op == "==" {
+				s = append(s, matchesPrefix)
+			} else {
+				s = append(s, func(v pep440Version) bool { return !matchesPrefix(v) })
+			}
+		case op == "~=":
+			// ~=X.Y is equivalent to >=X.Y,==X.*
+			if len(version.release) < 2 {
+				return nil, fmt.Errorf(
+					"%q requires a version with at least two release segments",
+					op,
+				)
+			}
+			compatible := pep440Version{
+				epoch:   version.epoch,
+				release: version.release[:len(version.release)-1],
+			}
+			s = append(s, func(v pep440Version) bool {
+				return v.compare(version) >= 0 &&
+					len(v.release) >= len(compatible.release) &&
+					compareReleases(
+						v.release[:len(compatible.release)],
+						compatible.release,
+					) == 0
+			})
+		default:
+			s = append(s, func(v pep440Version) bool {
+				c := v.compare(version)
+				switch op {
+				case "==":
+					return c == 0
+				case "!=":
+					return c != 0
+				case "<=":
+					return c <= 0
+				case ">=":
+					return c >= 0
+				case "<":
+					// <V excludes pre-releases of V unless V is itself one.
+					return c < 0 && (version.isPrerelease() || !v.isPrerelease() ||
+						v.epoch != version.epoch ||
+						compareReleases(v.release, version.release) != 0)
+				default: // ">"
+					// >V excludes post-releases of V unless V is itself one.
+					return c > 0 && (version.post != nil || v.post == nil ||
+						v.epoch != version.epoch ||
+						compareReleases(v.release, version.release) != 0 ||
+						v.preKind != version.preKind || v.preNum != version.preNum)
+				}
+			})
+		}
+	}
+	return s, nil
+}
+
+// contains returns true if the provided version satisfies all of the
+// specifier's clauses.
+func (s pep440Specifiers) contains(version pep440Version) bool {
+	for _, clause := range s {
+		if !clause(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the normalized form of the version, without its local version
+// label.
+func (v pep440Version) String() string {
+	var sb strings.Builder
+	if v.epoch != 0 {
+		fmt.Fprintf(&sb, "%d!", v.epoch)
+	}
+	for i, part := range v.release {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strconv.Itoa(part))
+	}
+	if v.preKind != "" {
+		fmt.Fprintf(&sb, "%s%d", v.preKind, v.preNum)
+	}
+	if v.post != nil {
+		fmt.Fprintf(&sb, ".post%d", *v.post)
+	}
+	if v.dev != nil {
+		fmt.Fprintf(&sb, ".dev%d", *v.dev)
+	}
+	return sb.String()
+}
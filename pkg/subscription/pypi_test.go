@@ -0,0 +1,252 @@
+package subscription
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pypiRegistry_discovery(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/simple/example-lib/", r.URL.Path)
+			require.Contains(t, r.Header.Get("Accept"), pypiSimpleJSONContentType)
+			w.Header().Set("Content-Type", pypiSimpleJSONContentType)
+			_, _ = fmt.Fprint(w, `{
+  "meta": {"api-version": "1.1"},
+  "name": "example-lib",
+  "files": [
+    {
+      "filename": "example_lib-1.0.0-py3-none-any.whl",
+      "url": "../../files/example_lib-1.0.0-py3-none-any.whl",
+      "hashes": {"sha256": "AA"},
+      "upload-time": "2024-01-02T00:00:00Z"
+    },
+    {
+      "filename": "example-lib-1.0.0.tar.gz",
+      "url": "https://files.example.com/example-lib-1.0.0.tar.gz",
+      "hashes": {"md5": "bb", "sha256": "cc"},
+      "upload-time": "2024-01-01T00:00:00Z"
+    },
+    {
+      "filename": "example_lib-1.1.0rc1.tar.gz",
+      "url": "https://files.example.com/example_lib-1.1.0rc1.tar.gz",
+      "hashes": {}
+    },
+    {
+      "filename": "example_lib-1.0.1.tar.gz",
+      "url": "https://files.example.com/example_lib-1.0.1.tar.gz",
+      "hashes": {},
+      "yanked": "broken"
+    },
+    {
+      "filename": "other-2.0.0.tar.gz",
+      "url": "https://files.example.com/other-2.0.0.tar.gz",
+      "hashes": {}
+    },
+    {
+      "filename": "example_lib-0.9.egg",
+      "url": "https://files.example.com/example_lib-0.9.egg",
+      "hashes": {}
+    },
+    {
+      "filename": "example_lib-0.1.0-py2-none-any.whl",
+      "url": "https://files.example.com/example_lib-0.1.0-py2-none-any.whl",
+      "hashes": {},
+      "yanked": false
+    }
+  ]
+}`)
+		}))
+		t.Cleanup(srv.Close)
+
+		versions, metadata := discoverPackageVersions(
+			t,
+			nil,
+			newPackageSubscription(t, pypiSubscriptionType, map[string]any{
+				"registryURL": srv.URL + "/simple",
+				"package":     "Example.Lib",
+			}),
+		)
+		require.Equal(t, []string{"1.0.0", "0.1.0"}, versions)
+		published := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, packageMetadata{
+			RegistryURL: srv.URL + "/simple",
+			Package:     "Example.Lib",
+			URL:         "https://files.example.com/example-lib-1.0.0.tar.gz",
+			Checksum:    "sha256:cc",
+			PublishedAt: &published,
+			Files: []packageFile{
+				{
+					Name:     "example-lib-1.0.0.tar.gz",
+					URL:      "https://files.example.com/example-lib-1.0.0.tar.gz",
+					Checksum: "sha256:cc",
+				},
+				{
+					Name:     "example_lib-1.0.0-py3-none-any.whl",
+					URL:      srv.URL + "/files/example_lib-1.0.0-py3-none-any.whl",
+					Checksum: "sha256:aa",
+				},
+			},
+		}, metadata[0])
+		require.Equal(
+			t,
+			"https://files.example.com/example_lib-0.1.0-py2-none-any.whl",
+			metadata[1].URL,
+		)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprint(w, `<!DOCTYPE html>
+<html><body>
+<h1>Links for example-lib</h1>
+<a href="/packages/example_lib-1.0.0.tar.gz#sha256=ABC">example_lib-1.0.0.tar.gz</a><br/>
+<a href="/packages/example_lib-2.0.0.tar.gz#sha256=def" data-yanked="">example_lib-2.0.0.tar.gz</a><br/>
+<a href="/packages/example_lib-1.1.0.post1.zip">example_lib-1.1.0.post1.zip</a><br/>
+<a href="/packages/example_lib-1.2.0.dev1.zip">example_lib-1.2.0.dev1.zip</a><br/>
+</body></html>`)
+		}))
+		t.Cleanup(srv.Close)
+
+		versions, metadata := discoverPackageVersions(
+			t,
+			nil,
+			newPackageSubscription(t, pypiSubscriptionType, map[string]any{
+				"registryURL":       srv.URL,
+				"package":           "example-lib",
+				"versionConstraint": ">=1.0",
+			}),
+		)
+		require.Equal(t, []string{"1.1.0.post1", "1.0.0"}, versions)
+		require.Equal(t, srv.URL+"/packages/example_lib-1.0.0.tar.gz", metadata[1].URL)
+		require.Equal(t, "sha256:abc", metadata[1].Checksum)
+	})
+}
+
+func Test_pypiFileVersion(t *testing.T) {
+	for filename, expected := range map[string]string{
+		"example_lib-1.0.0-py3-none-any.whl":         "1.0.0",
+		"example_lib-1.0.0-1-cp312-abi3-linux.whl":   "1.0.0",
+		"Example.Lib-1.0.0.tar.gz":                   "1.0.0",
+		"example-lib-2.0.zip":                        "2.0",
+		"example_lib-1.0.0-py3.whl":                  "",
+		"example_lib.tar.gz":                         "",
+		"example_lib-1.0.0.egg":                      "",
+		"example_lib_extra-1.0.0.tar.gz":             "",
+		"example_lib_extra-1.0.0-py3-none-any.whl":   "",
+		"example-lib-1.0.0.dev1+local.build.tar.bz2": "1.0.0.dev1+local.build",
+	} {
+		require.Equal(t, expected, pypiFileVersion("example-lib", filename), filename)
+	}
+}
+
+func Test_pep440Version_compare(t *testing.T) {
+	// Each version is lower than the next one.
+	ordered := []string{
+		"0.9",
+		"1.0.dev0",
+		"1.0.dev1",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0a2",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0.post1.dev1",
+		"1.0.post1",
+		"1.0.1",
+		"1.10",
+		"1!0.1",
+	}
+	for i := 1; i < len(ordered); i++ {
+		lhs, err := parsePEP440Version(ordered[i-1])
+		require.NoError(t, err)
+		rhs, err := parsePEP440Version(ordered[i])
+		require.NoError(t, err)
+		require.Negative(t, lhs.compare(rhs), "%s < %s", ordered[i-1], ordered[i])
+		require.Positive(t, rhs.compare(lhs), "%s > %s", ordered[i], ordered[i-1])
+	}
+
+	for _, equal := range [][]string{
+		{"1.0", "1", "1.0.0", "v1.0", "1.0+local"},
+		{"1.0a1", "1.0-alpha-1", "1.0.A1"},
+		{"1.0rc1", "1.0c1", "1.0-pre1", "1.0preview1"},
+		{"1.0.post1", "1.0-1", "1.0-r1", "1.0rev1"},
+	} {
+		first, err := parsePEP440Version(equal[0])
+		require.NoError(t, err)
+		for _, v := range equal[1:] {
+			other, err := parsePEP440Version(v)
+			require.NoError(t, err)
+			require.Zero(t, first.compare(other), "%s == %s", equal[0], v)
+		}
+	}
+
+	_, err := parsePEP440Version("latest")
+	require.ErrorContains(t, err, "is not a valid PEP 440 version")
+}
+
+func Test_parsePEP440Specifiers(t *testing.T) {
+	testCases := []struct {
+		specifier string
+		contains  []string
+		excludes  []string
+		err       string
+	}{
+		{
+			specifier: ">=1.0,<2.0",
+			contains:  []string{"1.0", "1.9.9", "1.5.post1"},
+			excludes:  []string{"0.9", "2.0", "2.0rc1"},
+		},
+		{
+			specifier: "~=1.4.2",
+			contains:  []string{"1.4.2", "1.4.10"},
+			excludes:  []string{"1.4.1", "1.5.0"},
+		},
+		{
+			specifier: "==1.4.*, != 1.4.3",
+			contains:  []string{"1.4", "1.4.2", "1.4.2.1"},
+			excludes:  []string{"1.4.3", "1.5", "1.3"},
+		},
+		{
+			specifier: ">1.0",
+			contains:  []string{"1.0.1", "1.1"},
+			excludes:  []string{"1.0", "1.0.post1"},
+		},
+		{
+			specifier: "===1.0",
+			contains:  []string{"1.0"},
+			excludes:  []string{"1.0.0"},
+		},
+		{specifier: ">=1.*", err: "may not be used with a wildcard"},
+		{specifier: "~=1", err: "at least two release segments"},
+		{specifier: "1.0", err: "is not a valid version specifier clause"},
+		{specifier: ">=latest", err: "is not a valid PEP 440 version"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.specifier, func(t *testing.T) {
+			s, err := parsePEP440Specifiers(testCase.specifier)
+			if testCase.err != "" {
+				require.ErrorContains(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			for _, version := range testCase.contains {
+				v, err := parsePEP440Version(version)
+				require.NoError(t, err)
+				require.True(t, s.contains(v), version)
+			}
+			for _, version := range testCase.excludes {
+				v, err := parsePEP440Version(version)
+				require.NoError(t, err)
+				require.False(t, s.contains(v), version)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Masterminds/semver/v3"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/gitprovider"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/validation"
+
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitea"  // Gitea provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/github" // GitHub provider registration
+	_ "github.com/akuity/kargo/pkg/gitprovider/gitlab" // GitLab provider registration
+)
+
+const (
+	// releaseSubscriptionType is the SubscriptionType of generic subscriptions
+	// handled by the releaseSubscriber.
+	releaseSubscriptionType = "release"
+	// releaseArtifactType is the ArtifactType of the ArtifactReferences
+	// discovered by the releaseSubscriber.
+	releaseArtifactType = "release"
+
+	// maxReleasesListed bounds the number of a repository's most recent
+	// releases that are considered when discovering releases.
+	maxReleasesListed = 500
+	// maxReleaseNotesLength bounds the length, in bytes, of the release notes
+	// recorded in an artifact's metadata, since that metadata ends up in the
+	// status of the Warehouse and in Freight.
+	maxReleaseNotesLength = 4096
+)
+
+func init() {
+	DefaultSubscriberRegistry.MustRegister(SubscriberRegistration{
+		Predicate: func(
+			_ context.Context,
+			sub kargoapi.RepoSubscription,
+		) (bool, error) {
+			return sub.Subscription != nil &&
+				sub.Subscription.SubscriptionType == releaseSubscriptionType, nil
+		},
+		Value: newReleaseSubscriber,
+	})
+}
+
+// releaseSubscriptionConfig is the configuration of a generic subscription of
+// type "release".
+type releaseSubscriptionConfig struct {
+	// RepoURL is the URL of the Git repository whose releases are subscribed
+	// to.
+	RepoURL string `json:"repoURL"`
+	// Provider is the name of the Git hosting provider hosting the repository.
+	// It only needs to be specified when it cannot be inferred from RepoURL.
+	Provider string `json:"provider,omitempty"`
+	// SemverConstraint limits discovery to releases whose tag is a semantic
+	// version satisfying the constraint.
+	SemverConstraint string `json:"semverConstraint,omitempty"`
+	// AllowPrereleases specifies whether pre-releases may be discovered. A
+	// release is a pre-release if it is flagged as one by the Git hosting
+	// provider or if its tag is a semantic pre-release version.
+	AllowPrereleases bool `json:"allowPrereleases,omitempty"`
+	// AssetPattern is a regular expression. When specified, only assets whose
+	// names match it are recorded and releases without any such asset are not
+	// discovered.
+	AssetPattern string `json:"assetPattern,omitempty"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when connecting to the Git hosting provider's API.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// releaseMetadata is the metadata recorded on each ArtifactReference
+// discovered by the releaseSubscriber.
+type releaseMetadata struct {
+	// RepoURL is the URL of the repository the release belongs to.
+	RepoURL string `json:"repoURL"`
+	// Name is the title of the release.
+	Name string `json:"name,omitempty"`
+	// URL is the URL to the release.
+	URL string `json:"url,omitempty"`
+	// Notes are the release notes, truncated if they are very long.
+	Notes string `json:"notes,omitempty"`
+	// Prerelease is true if the release is a pre-release.
+	Prerelease bool `json:"prerelease,omitempty"`
+	// PublishedAt is the time the release was published.
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Assets are the release's assets, including their download URLs and,
+	// where the Git hosting provider computes them, the digests of their
+	// content.
+	Assets []gitprovider.ReleaseAsset `json:"assets,omitempty"`
+}
+
+// supportedReleaseProviders are the names of the Git hosting providers that
+// the releaseSubscriber can discover releases from.
+var supportedReleaseProviders = []string{"gitea", "github", "gitlab"}
+
+// releaseSubscriber is an implementation of the Subscriber interface that
+// discovers releases of a Git repository from its Git hosting provider.
+type releaseSubscriber struct {
+	credentialsDB credentials.Database
+
+	// newReleaseListerFn constructs the gitprovider.ReleaseLister for a
+	// repository. It is a field so tests can substitute a fake one for the real
+	// one.
+	newReleaseListerFn func(
+		repoURL string,
+		opts *gitprovider.Options,
+	) (gitprovider.ReleaseLister, error)
+}
+
+// newReleaseSubscriber returns an implementation of the Subscriber interface
+// that discovers releases of a Git repository from its Git hosting provider.
+func newReleaseSubscriber(
+	_ context.Context,
+	credentialsDB credentials.Database,
+) (Subscriber, error) {
+	return &releaseSubscriber{
+		credentialsDB:      credentialsDB,
+		newReleaseListerFn: newReleaseLister,
+	}, nil
+}
+
+// newReleaseLister returns the gitprovider.ReleaseLister for the provided
+// repository, if its Git hosting provider supports releases.
+func newReleaseLister(
+	repoURL string,
+	opts *gitprovider.Options,
+) (gitprovider.ReleaseLister, error) {
+	provider, err := gitprovider.New(repoURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := provider.(gitprovider.ReleaseLister)
+	if !ok {
+		return nil, fmt.Errorf(
+			"the Git hosting provider of repository %q does not support releases",
+			repoURL,
+		)
+	}
+	return lister, nil
+}
+
+// ApplySubscriptionDefaults implements Subscriber.
+func (r *releaseSubscriber) ApplySubscriptionDefaults(
+	context.Context,
+	*kargoapi.RepoSubscription,
+) error {
+	// Release subscriptions have no defaults beyond those common to all
+	// generic subscriptions.
+	return nil
+}
+
+// ValidateSubscription implements Subscriber.
+func (r *releaseSubscriber) ValidateSubscription(
+	_ context.Context,
+	f *field.Path,
+	s kargoapi.RepoSubscription,
+) field.ErrorList {
+	f = f.Child("config")
+	cfg, err := parseReleaseSubscriptionConfig(s.Subscription)
+	if err != nil {
+		return field.ErrorList{field.Invalid(f, "", err.Error())}
+	}
+
+	var errs field.ErrorList
+
+	// Validate RepoURL: MinLength=1, Pattern (HTTP/S Git URL)
+	if err := validation.MinLength(f.Child("repoURL"), cfg.RepoURL, 1); err != nil {
+		errs = append(errs, err)
+	} else if !gitURLRegex.MatchString(cfg.RepoURL) ||
+		!(strings.HasPrefix(cfg.RepoURL, "https://") ||
+			strings.HasPrefix(cfg.RepoURL, "http://")) {
+		errs = append(errs, field.Invalid(
+			f.Child("repoURL"),
+			cfg.RepoURL,
+			"must be a valid HTTP/S Git repository URL",
+		))
+	}
+
+	// Validate Provider: Enum
+	if cfg.Provider != "" && !slices.Contains(supportedReleaseProviders, cfg.Provider) {
+		errs = append(errs, field.NotSupported(
+			f.Child("provider"),
+			cfg.Provider,
+			supportedReleaseProviders,
+		))
+	}
+
+	// Validate SemverConstraint
+	if err := validation.SemverConstraint(
+		f.Child("semverConstraint"),
+		cfg.SemverConstraint,
+	); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate AssetPattern
+	if _, err := regexp.Compile(cfg.AssetPattern); err != nil {
+		errs = append(errs, field.Invalid(
+			f.Child("assetPattern"),
+			cfg.AssetPattern,
+			fmt.Sprintf("must be a valid regular expression: %v", err),
+		))
+	}
+
+	return errs
+}
+
+// DiscoverArtifacts implements Subscriber.
+func (r *releaseSubscriber) DiscoverArtifacts(
+	ctx context.Context,
+	project string,
+	sub kargoapi.RepoSubscription,
+	_ any,
+) (any, error) {
+	if sub.Subscription == nil {
+		return nil, nil
+	}
+
+	cfg, err := parseReleaseSubscriptionConfig(sub.Subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithValues("repo", cfg.RepoURL)
+
+	// Obtain credentials for the Git hosting provider's API.
+	creds, err := r.credentialsDB.Get(ctx, project, credentials.TypeGit, cfg.RepoURL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+	gpOpts := &gitprovider.Options{
+		Name:                  cfg.Provider,
+		InsecureSkipTLSVerify: cfg.InsecureSkipTLSVerify,
+	}
+	if creds != nil {
+		gpOpts.Token = creds.Password
+		logger.Debug("obtained credentials for git repo")
+	} else {
+		logger.Debug("found no credentials for git repo")
+	}
+
+	lister, err := r.newReleaseListerFn(cfg.RepoURL, gpOpts)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining release lister for git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+	releases, err := lister.ListReleases(
+		ctx,
+		&gitprovider.ListReleasesOptions{Limit: maxReleasesListed},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing releases of git repo %q: %w",
+			cfg.RepoURL, err,
+		)
+	}
+
+	refs, err := selectReleases(cfg, releases)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		logger.Debug("discovered no releases")
+	} else {
+		logger.Debug("discovered releases", "count", len(refs))
+	}
+
+	refs = trimSlice(refs, int(sub.Subscription.DiscoveryLimit))
+	for i := range refs {
+		refs[i].SubscriptionName = sub.Name
+	}
+	return kargoapi.DiscoveryResult{
+		SubscriptionName:   sub.Name,
+		ArtifactReferences: refs,
+	}, nil
+}
+
+// selectReleases filters the provided releases according to the provided
+// configuration and returns references to those remaining, ordered from the
+// highest semantic version to the lowest. Releases whose tags are not
+// semantic versions are never selected.
+func selectReleases(
+	cfg releaseSubscriptionConfig,
+	releases []gitprovider.Release,
+) ([]kargoapi.ArtifactReference, error) {
+	var constraint *semver.Constraints
+	if cfg.SemverConstraint != "" {
+		var err error
+		if constraint, err = semver.NewConstraint(cfg.SemverConstraint); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing semver constraint %q: %w",
+				cfg.SemverConstraint, err,
+			)
+		}
+	}
+	var assetRegex *regexp.Regexp
+	if cfg.AssetPattern != "" {
+		var err error
+		if assetRegex, err = regexp.Compile(cfg.AssetPattern); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing asset pattern %q: %w",
+				cfg.AssetPattern, err,
+			)
+		}
+	}
+
+	type selectedRelease struct {
+		version *semver.Version
+		ref     kargoapi.ArtifactReference
+	}
+	selected := make([]selectedRelease, 0, len(releases))
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		version, err := semver.NewVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		prerelease := release.Prerelease || version.Prerelease() != ""
+		if prerelease && !cfg.AllowPrereleases {
+			continue
+		}
+		if constraint != nil && !constraint.Check(version) {
+			continue
+		}
+		assets := release.Assets
+		if assetRegex != nil {
+			assets = slices.DeleteFunc(
+				slices.Clone(assets),
+				func(asset gitprovider.ReleaseAsset) bool {
+					return !assetRegex.MatchString(asset.Name)
+				},
+			)
+			if len(assets) == 0 {
+				continue
+			}
+		}
+		metadata, err := json.Marshal(releaseMetadata{
+			RepoURL:     cfg.RepoURL,
+			Name:        release.Name,
+			URL:         release.URL,
+			Notes:       truncateReleaseNotes(release.Notes),
+			Prerelease:  prerelease,
+			PublishedAt: release.PublishedAt,
+			Assets:      assets,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error marshaling metadata of release %q: %w",
+				release.TagName, err,
+			)
+		}
+		selected = append(selected, selectedRelease{
+			version: version,
+			ref: kargoapi.ArtifactReference{
+				ArtifactType: releaseArtifactType,
+				Version:      release.TagName,
+				Metadata:     &apiextensionsv1.JSON{Raw: metadata},
+			},
+		})
+	}
+
+	slices.SortStableFunc(selected, func(lhs, rhs selectedRelease) int {
+		return rhs.version.Compare(lhs.version)
+	})
+	refs := make([]kargoapi.ArtifactReference, len(selected))
+	for i, s := range selected {
+		refs[i] = s.ref
+	}
+	return refs, nil
+}
+
+// parseReleaseSubscriptionConfig parses the configuration of the provided
+// generic subscription as a releaseSubscriptionConfig.
+func parseReleaseSubscriptionConfig(
+	sub *kargoapi.Subscription,
+) (releaseSubscriptionConfig, error) {
+	var cfg releaseSubscriptionConfig
+	if sub == nil || sub.Config == nil || len(sub.Config.Raw) == 0 {
+		return cfg, errors.New("configuration is required")
+	}
+	dec := json.NewDecoder(bytes.NewReader(sub.Config.Raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// truncateReleaseNotes truncates the provided release notes to at most
+// maxReleaseNotesLength bytes without splitting a multi-byte character.
+func truncateReleaseNotes(notes string) string {
+	if len(notes) <= maxReleaseNotesLength {
+		return notes
+	}
+	n := maxReleaseNotesLength
+	for n > 0 && !utf8.RuneStart(notes[n]) {
+		n--
+	}
+	return notes[:n]
+}
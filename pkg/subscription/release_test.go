@@ -0,0 +1,309 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/credentials"
+	"github.com/akuity/kargo/pkg/gitprovider"
+)
+
+func newReleaseSubscription(t *testing.T, cfg map[string]any) kargoapi.RepoSubscription {
+	t.Helper()
+	raw, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	return kargoapi.RepoSubscription{
+		Name: "fake-sub",
+		Subscription: &kargoapi.Subscription{
+			SubscriptionType: releaseSubscriptionType,
+			Config:           &apiextensionsv1.JSON{Raw: raw},
+			DiscoveryLimit:   20,
+		},
+	}
+}
+
+func Test_releaseSubscriber_registration(t *testing.T) {
+	reg, err := DefaultSubscriberRegistry.Get(
+		t.Context(),
+		newReleaseSubscription(t, map[string]any{"repoURL": "https://github.com/example/repo"}),
+	)
+	require.NoError(t, err)
+	subscriber, err := reg.Value(t.Context(), nil)
+	require.NoError(t, err)
+	require.IsType(t, &releaseSubscriber{}, subscriber)
+}
+
+func Test_releaseSubscriber_ValidateSubscription(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.RepoSubscription
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "valid",
+			sub: newReleaseSubscription(t, map[string]any{
+				"repoURL":          "https://gitlab.example.com/group/repo",
+				"provider":         "gitlab",
+				"semverConstraint": "^1.0.0",
+				"assetPattern":     `\.tar\.gz$`,
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "missing config",
+			sub: kargoapi.RepoSubscription{
+				Subscription: &kargoapi.Subscription{
+					SubscriptionType: releaseSubscriptionType,
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].release.config", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "configuration is required")
+			},
+		},
+		{
+			name: "unknown field",
+			sub: newReleaseSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+				"bogus":   true,
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Contains(t, errs[0].Detail, `unknown field "bogus"`)
+			},
+		},
+		{
+			name: "invalid fields",
+			sub: newReleaseSubscription(t, map[string]any{
+				"repoURL":          "git@github.com:example/repo.git",
+				"provider":         "azure",
+				"semverConstraint": "not a constraint",
+				"assetPattern":     "(",
+			}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(t, []string{
+					"spec.subscriptions[0].release.config.repoURL",
+					"spec.subscriptions[0].release.config.provider",
+					"spec.subscriptions[0].release.config.semverConstraint",
+					"spec.subscriptions[0].release.config.assetPattern",
+				}, fields)
+			},
+		},
+		{
+			name: "missing repoURL",
+			sub:  newReleaseSubscription(t, map[string]any{}),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, "spec.subscriptions[0].release.config.repoURL", errs[0].Field)
+			},
+		},
+	}
+	s := &releaseSubscriber{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				s.ValidateSubscription(
+					t.Context(),
+					field.NewPath("spec").Child("subscriptions").Index(0).Child("release"),
+					testCase.sub,
+				),
+			)
+		})
+	}
+}
+
+func Test_releaseSubscriber_DiscoverArtifacts(t *testing.T) {
+	releases := []gitprovider.Release{
+		{TagName: "v1.0.0", Name: "One", Assets: []gitprovider.ReleaseAsset{
+			{Name: "tool-linux.tar.gz", URL: "https://example.com/1.0.0/tool-linux.tar.gz"},
+			{Name: "checksums.txt", URL: "https://example.com/1.0.0/checksums.txt"},
+		}},
+		{TagName: "v2.0.0-rc.1"},
+		{TagName: "v1.2.0", Prerelease: true},
+		{TagName: "v3.0.0", Draft: true},
+		{TagName: "nightly"},
+		{TagName: "v1.1.0", Notes: "notes", Assets: []gitprovider.ReleaseAsset{{
+			Name:   "tool-linux.tar.gz",
+			URL:    "https://example.com/1.1.0/tool-linux.tar.gz",
+			Digest: "sha256:abc123",
+		}}},
+		{TagName: "v0.9.0", Assets: []gitprovider.ReleaseAsset{
+			{Name: "tool.zip", URL: "https://example.com/0.9.0/tool.zip"},
+		}},
+	}
+	newSubscriber := func(t *testing.T) *releaseSubscriber {
+		return &releaseSubscriber{
+			credentialsDB: &credentials.FakeDB{
+				GetFn: func(
+					_ context.Context,
+					project string,
+					credType credentials.Type,
+					repoURL string,
+				) (*credentials.Credentials, error) {
+					require.Equal(t, "fake-project", project)
+					require.Equal(t, credentials.TypeGit, credType)
+					require.Equal(t, "https://github.com/example/repo", repoURL)
+					return &credentials.Credentials{Password: "fake-token"}, nil
+				},
+			},
+			newReleaseListerFn: func(
+				repoURL string,
+				opts *gitprovider.Options,
+			) (gitprovider.ReleaseLister, error) {
+				require.Equal(t, "https://github.com/example/repo", repoURL)
+				require.Equal(t, "fake-token", opts.Token)
+				return &gitprovider.Fake{
+					ListReleasesFn: func(
+						context.Context,
+						*gitprovider.ListReleasesOptions,
+					) ([]gitprovider.Release, error) {
+						return releases, nil
+					},
+				}, nil
+			},
+		}
+	}
+	versions := func(res any) []string {
+		result, ok := res.(kargoapi.DiscoveryResult)
+		require.True(t, ok)
+		require.Equal(t, "fake-sub", result.SubscriptionName)
+		v := make([]string, len(result.ArtifactReferences))
+		for i, ref := range result.ArtifactReferences {
+			require.Equal(t, releaseArtifactType, ref.ArtifactType)
+			require.Equal(t, "fake-sub", ref.SubscriptionName)
+			v[i] = ref.Version
+		}
+		return v
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		res, err := newSubscriber(t).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newReleaseSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+			}),
+			nil,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1.1.0", "v1.0.0", "v0.9.0"}, versions(res))
+
+		var metadata releaseMetadata
+		require.NoError(t, json.Unmarshal(
+			res.(kargoapi.DiscoveryResult).ArtifactReferences[0].Metadata.Raw, // nolint: forcetypeassert
+			&metadata,
+		))
+		require.Equal(t, releaseMetadata{
+			RepoURL: "https://github.com/example/repo",
+			Notes:   "notes",
+			Assets: []gitprovider.ReleaseAsset{{
+				Name:   "tool-linux.tar.gz",
+				URL:    "https://example.com/1.1.0/tool-linux.tar.gz",
+				Digest: "sha256:abc123",
+			}},
+		}, metadata)
+	})
+
+	t.Run("prereleases, constraint and limit", func(t *testing.T) {
+		sub := newReleaseSubscription(t, map[string]any{
+			"repoURL":          "https://github.com/example/repo",
+			"semverConstraint": ">=1.0.0-0",
+			"allowPrereleases": true,
+		})
+		sub.Subscription.DiscoveryLimit = 3
+		res, err := newSubscriber(t).DiscoverArtifacts(t.Context(), "fake-project", sub, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"v2.0.0-rc.1", "v1.2.0", "v1.1.0"}, versions(res))
+	})
+
+	t.Run("asset pattern", func(t *testing.T) {
+		res, err := newSubscriber(t).DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newReleaseSubscription(t, map[string]any{
+				"repoURL":      "https://github.com/example/repo",
+				"assetPattern": `\.tar\.gz$`,
+			}),
+			nil,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1.1.0", "v1.0.0"}, versions(res))
+
+		var metadata releaseMetadata
+		require.NoError(t, json.Unmarshal(
+			res.(kargoapi.DiscoveryResult).ArtifactReferences[1].Metadata.Raw, // nolint: forcetypeassert
+			&metadata,
+		))
+		require.Equal(t, "One", metadata.Name)
+		require.Len(t, metadata.Assets, 1)
+		require.Equal(t, "tool-linux.tar.gz", metadata.Assets[0].Name)
+	})
+
+	t.Run("error listing releases", func(t *testing.T) {
+		s := newSubscriber(t)
+		s.newReleaseListerFn = func(
+			string,
+			*gitprovider.Options,
+		) (gitprovider.ReleaseLister, error) {
+			return &gitprovider.Fake{
+				ListReleasesFn: func(
+					context.Context,
+					*gitprovider.ListReleasesOptions,
+				) ([]gitprovider.Release, error) {
+					return nil, errors.New("something went wrong")
+				},
+			}, nil
+		}
+		_, err := s.DiscoverArtifacts(
+			t.Context(),
+			"fake-project",
+			newReleaseSubscription(t, map[string]any{
+				"repoURL": "https://github.com/example/repo",
+			}),
+			nil,
+		)
+		require.ErrorContains(t, err, "error listing releases")
+		require.ErrorContains(t, err, "something went wrong")
+	})
+}
+
+func Test_newReleaseLister(t *testing.T) {
+	_, err := newReleaseLister("https://github.com/example/repo", nil)
+	require.NoError(t, err)
+
+	// A provider without support for releases
+	gitprovider.Register("fake-no-releases", gitprovider.Registration{
+		NewProvider: func(string, *gitprovider.Options) (gitprovider.Interface, error) {
+			return struct{ gitprovider.Interface }{&gitprovider.Fake{}}, nil
+		},
+	})
+	_, err = newReleaseLister(
+		"https://git.example.com/example/repo",
+		&gitprovider.Options{Name: "fake-no-releases"},
+	)
+	require.ErrorContains(t, err, "does not support releases")
+}
+
+func Test_truncateReleaseNotes(t *testing.T) {
+	require.Equal(t, "short", truncateReleaseNotes("short"))
+
+	long := strings.Repeat("a", maxReleaseNotesLength-1) + "é"
+	truncated := truncateReleaseNotes(long)
+	require.Equal(t, strings.Repeat("a", maxReleaseNotesLength-1), truncated)
+}
@@ -44,3 +44,16 @@ type Subscriber interface {
 		last any,
 	) (any, error)
 }
+
+// FreightPerArtifactSubscriber is an optional interface implemented by those
+// implementations of Subscriber whose discovered artifacts are independent of
+// one another (e.g. the head commits of distinct open pull requests), such that
+// each of them, and not only the latest, warrants Freight of its own. Callers
+// should use a type assertion to determine whether a given implementation of
+// Subscriber supports it.
+type FreightPerArtifactSubscriber interface {
+	// FreightPerArtifact returns a bool indicating whether each artifact
+	// discovered for the provided kargoapi.RepoSubscription should be surfaced
+	// as Freight of its own.
+	FreightPerArtifact(sub kargoapi.RepoSubscription) bool
+}
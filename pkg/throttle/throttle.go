@@ -0,0 +1,266 @@
+package throttle
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultPause is how long requests to a host are paused after it responds
+	// with HTTP 429 without indicating when requests may resume.
+	defaultPause = time.Minute
+	// maxPause is the longest requests to a host are ever paused for,
+	// regardless of how long the host asks for.
+	maxPause = time.Hour
+	// maxDrainBytes is the maximum number of bytes read from the body of a
+	// throttled response before it is discarded, permitting the connection to
+	// be reused.
+	maxDrainBytes = 4 << 10
+
+	// dockerHubLimitHeader and dockerHubRemainingHeader are the headers used by
+	// Docker Hub to report the number of requests permitted within a window of
+	// time and how many of those remain. Values take the form "100;w=21600",
+	// where w is the length of the window in seconds.
+	dockerHubLimitHeader     = "ratelimit-limit"
+	dockerHubRemainingHeader = "ratelimit-remaining"
+)
+
+// Limits describes the rate at which requests may be made to a host.
+type Limits struct {
+	// RequestsPerSecond is the rate at which tokens are added to the bucket. A
+	// value of zero or less means requests are not limited.
+	RequestsPerSecond int
+	// Burst is the size of the bucket, i.e. the number of requests that may be
+	// made at once after a period of inactivity.
+	Burst int
+}
+
+// ThrottledError is returned for requests to a host that has asked for
+// requests to be paused, either by responding with HTTP 429 or by reporting
+// that its rate limit has been exhausted.
+type ThrottledError struct {
+	// Host is the host requests to which are paused.
+	Host string
+	// Until is the time at which requests to the host may resume.
+	Until time.Time
+}
+
+// Error implements error.
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf(
+		"requests to %s are rate limited until %s",
+		e.Host, e.Until.UTC().Format(time.RFC3339),
+	)
+}
+
+// Registry tracks the rate limits of, and any pauses requested by, hosts.
+// Requests to the same host with the same Limits share a token bucket. All
+// requests to a host are subject to the Registry's default Limits, regardless
+// of any lower Limits they are additionally subject to. Pauses apply to all
+// requests to a host, regardless of their Limits.
+type Registry struct {
+	defaults Limits
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	paused   map[string]time.Time
+
+	// nowFn returns the current time. It is overridable for testing purposes.
+	nowFn func() time.Time
+}
+
+// NewRegistry returns a Registry that applies the provided Limits to any
+// request for which no other limit is specified.
+func NewRegistry(defaults Limits) *Registry {
+	return &Registry{
+		defaults: defaults,
+		limiters: map[string]*rate.Limiter{},
+		paused:   map[string]time.Time{},
+		nowFn:    time.Now,
+	}
+}
+
+// Transport returns an http.RoundTripper that applies rate limits to requests
+// before delegating them to the provided http.RoundTripper. If
+// requestsPerSecond is greater than zero and lower than the rate of the
+// Registry's default Limits, requests are additionally limited to that rate.
+// It can never be used to exceed the Registry's default Limits.
+func (r *Registry) Transport(rt http.RoundTripper, requestsPerSecond int) http.RoundTripper {
+	t := &transport{
+		registry:  r,
+		transport: rt,
+	}
+	if requestsPerSecond > 0 &&
+		(r.defaults.RequestsPerSecond <= 0 || requestsPerSecond < r.defaults.RequestsPerSecond) {
+		t.override = &Limits{
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             r.defaults.Burst,
+		}
+	}
+	return t
+}
+
+// limiter returns the token bucket shared by all requests to the provided host
+// with the provided Limits. It returns nil if requests are not limited.
+func (r *Registry) limiter(host string, limits Limits) *rate.Limiter {
+	if limits.RequestsPerSecond <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s/%d/%d", host, limits.RequestsPerSecond, limits.Burst)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.limiters[key]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), max(limits.Burst, 1))
+		r.limiters[key] = l
+	}
+	return l
+}
+
+// pausedUntil returns the time until which requests to the provided host are
+// paused. It returns the zero time if they are not.
+func (r *Registry) pausedUntil(host string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	until, ok := r.paused[host]
+	if ok && !r.nowFn().Before(until) {
+		delete(r.paused, host)
+		return time.Time{}
+	}
+	return until
+}
+
+// pause pauses requests to the provided host for the provided duration, capped
+// at maxPause, unless they are already paused for longer. It returns the time
+// until which requests are paused.
+func (r *Registry) pause(host string, d time.Duration) time.Time {
+	until := r.nowFn().Add(min(d, maxPause))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing := r.paused[host]; existing.After(until) {
+		return existing
+	}
+	r.paused[host] = until
+	return until
+}
+
+// transport is an http.RoundTripper that applies rate limits to requests.
+type transport struct {
+	registry *Registry
+	// override, if non-nil, holds Limits that apply to requests in addition to
+	// the Registry's default Limits.
+	override  *Limits
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if until := t.registry.pausedUntil(host); !until.IsZero() {
+		return nil, &ThrottledError{Host: host, Until: until}
+	}
+	if t.override != nil {
+		if err := t.registry.limiter(host, *t.override).Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	if l := t.registry.limiter(host, t.registry.defaults); l != nil {
+		if err := l.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode == http.StatusServiceUnavailable && res.Header.Get("Retry-After") != "") {
+		pause, ok := retryAfter(res.Header, t.registry.nowFn())
+		if !ok {
+			if pause, ok = dockerHubPause(res.Header); !ok {
+				pause = defaultPause
+			}
+		}
+		until := t.registry.pause(host, pause)
+		_, _ = io.CopyN(io.Discard, res.Body, maxDrainBytes)
+		_ = res.Body.Close()
+		return nil, &ThrottledError{Host: host, Until: until}
+	}
+
+	// Docker Hub reports when its limit has been exhausted before it begins
+	// rejecting requests. Pause preemptively rather than wait to be rejected.
+	if remaining, _, ok := parseDockerHubHeader(res.Header.Get(dockerHubRemainingHeader)); ok && remaining == 0 {
+		if pause, ok := dockerHubPause(res.Header); ok {
+			t.registry.pause(host, pause)
+		}
+	}
+	return res, nil
+}
+
+// Unwrap returns the http.RoundTripper to which requests are delegated.
+func (t *transport) Unwrap() http.RoundTripper {
+	return t.transport
+}
+
+// retryAfter returns the duration indicated by the Retry-After header, which
+// may be expressed either as a number of seconds or as an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	val := strings.TrimSpace(header.Get("Retry-After"))
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(val); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// dockerHubPause estimates, from Docker Hub's rate limit headers, how long it
+// will be before another request is permitted. Docker Hub's limits apply to a
+// sliding window, so on average, one request is permitted again every window
+// divided by limit.
+func dockerHubPause(header http.Header) (time.Duration, bool) {
+	limit, window, ok := parseDockerHubHeader(header.Get(dockerHubLimitHeader))
+	if !ok || limit <= 0 || window <= 0 {
+		return 0, false
+	}
+	return window / time.Duration(limit), true
+}
+
+// parseDockerHubHeader parses a Docker Hub rate limit header value of the form
+// "100;w=21600" into a count and a window.
+func parseDockerHubHeader(val string) (int, time.Duration, bool) {
+	if val == "" {
+		return 0, 0, false
+	}
+	countStr, params, _ := strings.Cut(val, ";")
+	count, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil {
+		return 0, 0, false
+	}
+	var window time.Duration
+	for param := range strings.SplitSeq(params, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if k != "w" {
+			continue
+		}
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, false
+		}
+		window = time.Duration(seconds) * time.Second
+	}
+	return count, window, true
+}
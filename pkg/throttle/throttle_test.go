@@ -0,0 +1,301 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Transport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		handler    http.HandlerFunc
+		assertions func(*testing.T, *Registry, string, *http.Response, error)
+	}{
+		{
+			name: "success",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			assertions: func(t *testing.T, r *Registry, host string, res *http.Response, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.True(t, r.pausedUntil(host).IsZero())
+			},
+		},
+		{
+			name: "429 with Retry-After in seconds",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "120")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			assertions: func(t *testing.T, r *Registry, host string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, host, throttledErr.Host)
+				require.Equal(t, now.Add(2*time.Minute), throttledErr.Until)
+				require.Equal(t, now.Add(2*time.Minute), r.pausedUntil(host))
+			},
+		},
+		{
+			name: "429 with Retry-After as a date",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", now.Add(30*time.Second).Format(http.TimeFormat))
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			assertions: func(t *testing.T, _ *Registry, _ string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, now.Add(30*time.Second), throttledErr.Until)
+			},
+		},
+		{
+			name: "429 with excessive Retry-After",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "86400")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			assertions: func(t *testing.T, _ *Registry, _ string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, now.Add(maxPause), throttledErr.Until)
+			},
+		},
+		{
+			name: "429 with Docker Hub headers",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(dockerHubLimitHeader, "100;w=21600")
+				w.Header().Set(dockerHubRemainingHeader, "0;w=21600")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			assertions: func(t *testing.T, _ *Registry, _ string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, now.Add(216*time.Second), throttledErr.Until)
+			},
+		},
+		{
+			name: "429 without any indication of when to retry",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			assertions: func(t *testing.T, _ *Registry, _ string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, now.Add(defaultPause), throttledErr.Until)
+			},
+		},
+		{
+			name: "503 with Retry-After",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "10")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			assertions: func(t *testing.T, _ *Registry, _ string, _ *http.Response, err error) {
+				throttledErr := &ThrottledError{}
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, now.Add(10*time.Second), throttledErr.Until)
+			},
+		},
+		{
+			name: "503 without Retry-After",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			assertions: func(t *testing.T, r *Registry, host string, res *http.Response, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+				require.True(t, r.pausedUntil(host).IsZero())
+			},
+		},
+		{
+			name: "Docker Hub limit exhausted",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(dockerHubLimitHeader, "100;w=21600")
+				w.Header().Set(dockerHubRemainingHeader, "0;w=21600")
+				w.WriteHeader(http.StatusOK)
+			},
+			assertions: func(t *testing.T, r *Registry, host string, res *http.Response, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.Equal(t, now.Add(216*time.Second), r.pausedUntil(host))
+			},
+		},
+		{
+			name: "Docker Hub limit not exhausted",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set(dockerHubLimitHeader, "100;w=21600")
+				w.Header().Set(dockerHubRemainingHeader, "42;w=21600")
+				w.WriteHeader(http.StatusOK)
+			},
+			assertions: func(t *testing.T, r *Registry, host string, _ *http.Response, err error) {
+				require.NoError(t, err)
+				require.True(t, r.pausedUntil(host).IsZero())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			t.Cleanup(srv.Close)
+			srvURL, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			r := NewRegistry(Limits{})
+			r.nowFn = func() time.Time { return now }
+			client := &http.Client{Transport: r.Transport(http.DefaultTransport, 0)}
+			res, err := client.Get(srv.URL)
+			if res != nil {
+				t.Cleanup(func() { _ = res.Body.Close() })
+			}
+			testCase.assertions(t, r, srvURL.Host, res, err)
+		})
+	}
+}
+
+func TestRegistry_Transport_paused(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	now := time.Now()
+	r := NewRegistry(Limits{})
+	r.nowFn = func() time.Time { return now }
+
+	// Requests from different subscriptions with different limits share the
+	// pause.
+	first := &http.Client{Transport: r.Transport(http.DefaultTransport, 0)}
+	second := &http.Client{Transport: r.Transport(http.DefaultTransport, 5)}
+
+	_, err := first.Get(srv.URL) // nolint: bodyclose
+	require.ErrorAs(t, err, new(*ThrottledError))
+	_, err = second.Get(srv.URL) // nolint: bodyclose
+	require.ErrorAs(t, err, new(*ThrottledError))
+	require.Equal(t, int32(1), requests.Load())
+
+	// Once the pause has elapsed, requests resume.
+	r.nowFn = func() time.Time { return now.Add(time.Minute) }
+	_, err = second.Get(srv.URL) // nolint: bodyclose
+	require.ErrorAs(t, err, new(*ThrottledError))
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestRegistry_Transport_override(t *testing.T) {
+	testCases := []struct {
+		name              string
+		defaults          Limits
+		requestsPerSecond int
+		expected          *Limits
+	}{
+		{
+			name:     "no override",
+			defaults: Limits{RequestsPerSecond: 20, Burst: 10},
+		},
+		{
+			name:              "override lowers the default",
+			defaults:          Limits{RequestsPerSecond: 20, Burst: 10},
+			requestsPerSecond: 5,
+			expected:          &Limits{RequestsPerSecond: 5, Burst: 10},
+		},
+		{
+			name:              "override equals the default",
+			defaults:          Limits{RequestsPerSecond: 20, Burst: 10},
+			requestsPerSecond: 20,
+		},
+		{
+			name:              "override exceeds the default",
+			defaults:          Limits{RequestsPerSecond: 20, Burst: 10},
+			requestsPerSecond: 100,
+		},
+		{
+			name:              "default is unlimited",
+			defaults:          Limits{},
+			requestsPerSecond: 100,
+			expected:          &Limits{RequestsPerSecond: 100},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := NewRegistry(testCase.defaults)
+			rt, ok := r.Transport(http.DefaultTransport, testCase.requestsPerSecond).(*transport)
+			require.True(t, ok)
+			require.Equal(t, testCase.expected, rt.override)
+		})
+	}
+}
+
+func TestRegistry_Transport_sharedDefaultLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	r := NewRegistry(Limits{RequestsPerSecond: 1, Burst: 1})
+
+	// A request made with an overridden limit consumes a token from the
+	// default bucket, so a subsequent request made without one must wait.
+	overridden := &http.Client{Transport: r.Transport(http.DefaultTransport, 1000)}
+	res, err := overridden.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: r.Transport(http.DefaultTransport, 0)}).Do(req) // nolint: bodyclose
+	require.Error(t, err)
+}
+
+func TestRegistry_limiter(t *testing.T) {
+	r := NewRegistry(Limits{RequestsPerSecond: 10, Burst: 5})
+
+	require.Nil(t, r.limiter("example.com", Limits{}))
+
+	l := r.limiter("example.com", Limits{RequestsPerSecond: 10, Burst: 5})
+	require.NotNil(t, l)
+	require.Equal(t, 5, l.Burst())
+	// The same host and limits share a token bucket.
+	require.Same(t, l, r.limiter("example.com", Limits{RequestsPerSecond: 10, Burst: 5}))
+	// Different limits or hosts do not.
+	require.NotSame(t, l, r.limiter("example.com", Limits{RequestsPerSecond: 1, Burst: 5}))
+	require.NotSame(t, l, r.limiter("other.example.com", Limits{RequestsPerSecond: 10, Burst: 5}))
+	// A burst of at least one is always permitted.
+	require.Equal(t, 1, r.limiter("example.com", Limits{RequestsPerSecond: 1}).Burst())
+}
+
+func Test_parseDockerHubHeader(t *testing.T) {
+	testCases := []struct {
+		val            string
+		expectedCount  int
+		expectedWindow time.Duration
+		expectedOK     bool
+	}{
+		{val: ""},
+		{val: "bogus"},
+		{val: "100;w=bogus"},
+		{val: "100", expectedCount: 100, expectedOK: true},
+		{val: "100;w=21600", expectedCount: 100, expectedWindow: 6 * time.Hour, expectedOK: true},
+		{val: " 0 ; w=60", expectedWindow: time.Minute, expectedOK: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.val, func(t *testing.T) {
+			count, window, ok := parseDockerHubHeader(testCase.val)
+			require.Equal(t, testCase.expectedOK, ok)
+			require.Equal(t, testCase.expectedCount, count)
+			require.Equal(t, testCase.expectedWindow, window)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	xhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/urls"
+)
+
+const (
+	artifactRegistry              = "artifactregistry"
+	artifactRegistrySecretDataKey = "secret"
+
+	artifactRegistryInsertAction = "INSERT"
+)
+
+func init() {
+	defaultWebhookReceiverRegistry.MustRegister(
+		webhookReceiverRegistration{
+			Predicate: func(_ context.Context, cfg kargoapi.WebhookReceiverConfig) (bool, error) {
+				return cfg.ArtifactRegistry != nil, nil
+			},
+			Value: newArtifactRegistryWebhookReceiver,
+		},
+	)
+}
+
+// artifactRegistryWebhookReceiver is an implementation of WebhookReceiver that
+// handles inbound webhooks from Google Cloud Pub/Sub push subscriptions
+// carrying Google Artifact Registry notifications.
+type artifactRegistryWebhookReceiver struct {
+	*baseWebhookReceiver
+}
+
+// newArtifactRegistryWebhookReceiver returns a new instance of
+// artifactRegistryWebhookReceiver.
+func newArtifactRegistryWebhookReceiver(
+	c client.Client,
+	project string,
+	cfg kargoapi.WebhookReceiverConfig,
+) WebhookReceiver {
+	return &artifactRegistryWebhookReceiver{
+		baseWebhookReceiver: &baseWebhookReceiver{
+			client:     c,
+			project:    project,
+			secretName: cfg.ArtifactRegistry.SecretRef.Name,
+		},
+	}
+}
+
+// getReceiverType implements WebhookReceiver.
+func (a *artifactRegistryWebhookReceiver) getReceiverType() string {
+	return artifactRegistry
+}
+
+// getSecretValues implements WebhookReceiver.
+func (a *artifactRegistryWebhookReceiver) getSecretValues(
+	secretData map[string][]byte,
+) ([]string, error) {
+	secretValue, ok := secretData[artifactRegistrySecretDataKey]
+	if !ok {
+		return nil, fmt.Errorf(
+			"missing data key %q for Artifact Registry WebhookReceiver",
+			artifactRegistrySecretDataKey,
+		)
+	}
+	return []string{string(secretValue)}, nil
+}
+
+// getHandler implements WebhookReceiver.
+func (a *artifactRegistryWebhookReceiver) getHandler(requestBody []byte) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.LoggerFromContext(ctx)
+
+		notification, err := getArtifactRegistryNotification(requestBody)
+		if err != nil {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(errors.New("invalid request body"), http.StatusBadRequest),
+			)
+			return
+		}
+
+		// Artifact Registry also publishes notifications for deletions. There is
+		// nothing to discover in that case, but Pub/Sub must still see a success
+		// response or it will redeliver the message.
+		if notification.Action != artifactRegistryInsertAction {
+			xhttp.WriteResponseJSON(
+				w,
+				http.StatusOK,
+				map[string]string{
+					"msg": fmt.Sprintf("ignored %q notification", notification.Action),
+				},
+			)
+			return
+		}
+
+		repo, tag := notification.repoAndTag()
+		if repo == "" {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(
+					errors.New("notification does not identify a repository"),
+					http.StatusBadRequest,
+				),
+			)
+			return
+		}
+
+		// Notifications from Artifact Registry contain no information about
+		// media type, so we normalize the URL BOTH as if it were an image repo
+		// URL and as if it were a chart repository URL. Note: The refresh logic
+		// will dedupe the URLs, so this does not create the possibility of a
+		// double refresh.
+		repoURLs := []string{
+			urls.NormalizeImage(repo),
+			urls.NormalizeChart(repo),
+		}
+		logger = logger.WithValues(
+			"repoURLs", repoURLs,
+			"tag", tag,
+		)
+		ctx = logging.ContextWithLogger(ctx, logger)
+		refreshWarehouses(ctx, w, a.client, a.project, repoURLs, nil, tag)
+	})
+}
+
+// artifactRegistryNotification represents a notification published by
+// Artifact Registry. For more information on the notification schema, see:
+//
+//	https://cloud.google.com/artifact-registry/docs/configure-notifications
+type artifactRegistryNotification struct {
+	// Action is the action that was performed; either "INSERT" or "DELETE".
+	Action string `json:"action"`
+	// Digest is the digest of the affected artifact, in the form
+	// "<host>/<project>/<repo>/<image>@sha256:<digest>".
+	Digest string `json:"digest"`
+	// Tag is the affected tag, in the form
+	// "<host>/<project>/<repo>/<image>:<tag>". It is empty if no tag was
+	// affected.
+	Tag string `json:"tag"`
+}
+
+// repoAndTag returns the repository and tag identified by the notification.
+// The tag is empty if the notification does not identify one.
+func (n artifactRegistryNotification) repoAndTag() (string, string) {
+	if n.Tag != "" {
+		// The last colon separates the tag from the repository, unless it is
+		// part of the host (i.e. a port number).
+		if i := strings.LastIndex(n.Tag, ":"); i > strings.LastIndex(n.Tag, "/") {
+			return n.Tag[:i], n.Tag[i+1:]
+		}
+		return n.Tag, ""
+	}
+	repo, _, _ := strings.Cut(n.Digest, "@")
+	return repo, ""
+}
+
+// getArtifactRegistryNotification extracts an Artifact Registry notification
+// from the body of a request made by a Pub/Sub push subscription. Messages are
+// wrapped in an envelope with the notification base64-encoded in its
+// message.data field, unless the subscription was configured to deliver
+// unwrapped payloads, in which case the body is the notification itself.
+func getArtifactRegistryNotification(
+	requestBody []byte,
+) (artifactRegistryNotification, error) {
+	envelope := struct {
+		Message *struct {
+			// Data is base64-encoded in the envelope and is decoded
+			// automatically by virtue of being a []byte.
+			Data []byte `json:"data"`
+		} `json:"message"`
+	}{}
+	if err := json.Unmarshal(requestBody, &envelope); err != nil {
+		return artifactRegistryNotification{}, err
+	}
+	data := requestBody
+	if envelope.Message != nil {
+		data = envelope.Message.Data
+	}
+	var notification artifactRegistryNotification
+	err := json.Unmarshal(data, &notification)
+	return notification, err
+}
@@ -0,0 +1,212 @@
+package external
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/indexer"
+)
+
+const artifactRegistryNotificationInsert = `{
+	"action": "INSERT",
+	"digest": "us-east1-docker.pkg.dev/my-project/my-repo/hello-world@sha256:6ec128e26cd5",
+	"tag": "us-east1-docker.pkg.dev/my-project/my-repo/hello-world:v1.0.0"
+}`
+
+const artifactRegistryNotificationDelete = `{
+	"action": "DELETE",
+	"digest": "us-east1-docker.pkg.dev/my-project/my-repo/hello-world@sha256:6ec128e26cd5"
+}`
+
+// wrapPubSubMessage wraps the provided data in the envelope used by Pub/Sub
+// push subscriptions.
+func wrapPubSubMessage(data string) string {
+	return fmt.Sprintf(
+		`{
+	"message": {
+		"attributes": {},
+		"data": %q,
+		"messageId": "2070443601311540",
+		"publishTime": "2021-02-26T19:13:55.749Z"
+	},
+	"subscription": "projects/my-project/subscriptions/kargo"
+}`,
+		base64.StdEncoding.EncodeToString([]byte(data)),
+	)
+}
+
+func TestArtifactRegistryHandler(t *testing.T) {
+	const testURL = "https://webhooks.kargo.example.com/nonsense"
+
+	const testProjectName = "fake-project"
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	newTestClient := func(constraint string) client.Client {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "fake-warehouse",
+				},
+				Spec: kargoapi.WarehouseSpec{
+					InternalSubscriptions: []kargoapi.RepoSubscription{{
+						Image: &kargoapi.ImageSubscription{
+							RepoURL:    "us-east1-docker.pkg.dev/my-project/my-repo/hello-world",
+							Constraint: constraint,
+						},
+					}},
+				},
+			},
+		).WithIndex(
+			&kargoapi.Warehouse{},
+			indexer.WarehousesBySubscribedURLsField,
+			indexer.WarehousesBySubscribedURLs,
+		).Build()
+	}
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		body       string
+		assertions func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "malformed request body",
+			body: "invalid json",
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(t, `{"error":"invalid request body"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "malformed message data",
+			body: wrapPubSubMessage("invalid json"),
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(t, `{"error":"invalid request body"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "deletion ignored",
+			body: wrapPubSubMessage(artifactRegistryNotificationDelete),
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"ignored \"DELETE\" notification"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "no repository",
+			body: wrapPubSubMessage(`{"action":"INSERT"}`),
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(
+					t,
+					`{"error":"notification does not identify a repository"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name:   "no tag match",
+			client: newTestClient("^v2.0.0"),
+			body:   wrapPubSubMessage(artifactRegistryNotificationInsert),
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 0 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+		{
+			name:   "warehouse refreshed",
+			client: newTestClient("^v1.0.0"),
+			body:   wrapPubSubMessage(artifactRegistryNotificationInsert),
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 1 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+		{
+			name:   "warehouse refreshed (unwrapped payload)",
+			client: newTestClient("^v1.0.0"),
+			body:   artifactRegistryNotificationInsert,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 1 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(testCase.body))
+			requestBody, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			(&artifactRegistryWebhookReceiver{
+				baseWebhookReceiver: &baseWebhookReceiver{
+					client:  testCase.client,
+					project: testProjectName,
+				},
+			}).getHandler(requestBody)(w, req)
+
+			testCase.assertions(t, w)
+		})
+	}
+}
+
+func Test_artifactRegistryNotification_repoAndTag(t *testing.T) {
+	testCases := []struct {
+		name         string
+		notification artifactRegistryNotification
+		expectedRepo string
+		expectedTag  string
+	}{
+		{
+			name:         "empty",
+			notification: artifactRegistryNotification{},
+		},
+		{
+			name: "tag",
+			notification: artifactRegistryNotification{
+				Digest: "us-docker.pkg.dev/proj/repo/img@sha256:abc",
+				Tag:    "us-docker.pkg.dev/proj/repo/img:v1.0.0",
+			},
+			expectedRepo: "us-docker.pkg.dev/proj/repo/img",
+			expectedTag:  "v1.0.0",
+		},
+		{
+			name: "host with port and no tag",
+			notification: artifactRegistryNotification{
+				Tag: "localhost:5000/proj/repo/img",
+			},
+			expectedRepo: "localhost:5000/proj/repo/img",
+		},
+		{
+			name: "digest only",
+			notification: artifactRegistryNotification{
+				Digest: "us-docker.pkg.dev/proj/repo/img@sha256:abc",
+			},
+			expectedRepo: "us-docker.pkg.dev/proj/repo/img",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			repo, tag := testCase.notification.repoAndTag()
+			require.Equal(t, testCase.expectedRepo, repo)
+			require.Equal(t, testCase.expectedTag, tag)
+		})
+	}
+}
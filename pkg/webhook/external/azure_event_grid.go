@@ -0,0 +1,224 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	xhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	azureEventGrid              = "azureeventgrid"
+	azureEventGridSecretDataKey = "secret"
+
+	azureEventGridValidationEvent = "Microsoft.EventGrid.SubscriptionValidationEvent"
+	azureEventGridImagePushed     = "Microsoft.ContainerRegistry.ImagePushed"
+
+	// azureEventGridRequestOriginHeader and azureEventGridAllowedOriginHeader
+	// are the headers used by the abuse protection handshake performed by
+	// Event Grid before delivering events using the CloudEvents schema.
+	azureEventGridRequestOriginHeader = "WebHook-Request-Origin"
+	azureEventGridAllowedOriginHeader = "WebHook-Allowed-Origin"
+)
+
+func init() {
+	defaultWebhookReceiverRegistry.MustRegister(
+		webhookReceiverRegistration{
+			Predicate: func(_ context.Context, cfg kargoapi.WebhookReceiverConfig) (bool, error) {
+				return cfg.AzureEventGrid != nil, nil
+			},
+			Value: newAzureEventGridWebhookReceiver,
+		},
+	)
+}
+
+// azureEventGridWebhookReceiver is an implementation of WebhookReceiver that
+// handles inbound webhooks from Azure Event Grid carrying Azure Container
+// Registry (ACR) events.
+type azureEventGridWebhookReceiver struct {
+	*baseWebhookReceiver
+}
+
+// newAzureEventGridWebhookReceiver returns a new instance of
+// azureEventGridWebhookReceiver.
+func newAzureEventGridWebhookReceiver(
+	c client.Client,
+	project string,
+	cfg kargoapi.WebhookReceiverConfig,
+) WebhookReceiver {
+	return &azureEventGridWebhookReceiver{
+		baseWebhookReceiver: &baseWebhookReceiver{
+			client:     c,
+			project:    project,
+			secretName: cfg.AzureEventGrid.SecretRef.Name,
+		},
+	}
+}
+
+// getReceiverType implements WebhookReceiver.
+func (a *azureEventGridWebhookReceiver) getReceiverType() string {
+	return azureEventGrid
+}
+
+// getSecretValues implements WebhookReceiver.
+func (a *azureEventGridWebhookReceiver) getSecretValues(
+	secretData map[string][]byte,
+) ([]string, error) {
+	secretValue, ok := secretData[azureEventGridSecretDataKey]
+	if !ok {
+		return nil, fmt.Errorf(
+			"missing data key %q for Azure Event Grid WebhookReceiver",
+			azureEventGridSecretDataKey,
+		)
+	}
+	return []string{string(secretValue)}, nil
+}
+
+// getHandler implements WebhookReceiver.
+func (a *azureEventGridWebhookReceiver) getHandler(requestBody []byte) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.LoggerFromContext(ctx)
+
+		// Before delivering events using the CloudEvents schema, Event Grid
+		// validates the endpoint by sending an OPTIONS request and expects the
+		// origin it specified to be echoed back.
+		if r.Method == http.MethodOptions {
+			origin := r.Header.Get(azureEventGridRequestOriginHeader)
+			if origin == "" {
+				xhttp.WriteErrorJSON(
+					w,
+					xhttp.Error(
+						fmt.Errorf("missing %s header", azureEventGridRequestOriginHeader),
+						http.StatusBadRequest,
+					),
+				)
+				return
+			}
+			logger.Debug("completing Event Grid abuse protection handshake", "origin", origin)
+			w.Header().Set(azureEventGridAllowedOriginHeader, origin)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		events, err := getAzureEventGridEvents(requestBody)
+		if err != nil {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(errors.New("invalid request body"), http.StatusBadRequest),
+			)
+			return
+		}
+
+		var repoURLs []string
+		var tags []string
+		for _, event := range events {
+			switch event.getType() {
+			case azureEventGridValidationEvent:
+				// Before delivering events using the Event Grid schema, Event
+				// Grid validates the endpoint by sending a validation event and
+				// expects the validation code it contains to be echoed back.
+				var data struct {
+					ValidationCode string `json:"validationCode"`
+				}
+				if err = json.Unmarshal(event.Data, &data); err != nil || data.ValidationCode == "" {
+					xhttp.WriteErrorJSON(
+						w,
+						xhttp.Error(errors.New("invalid validation event"), http.StatusBadRequest),
+					)
+					return
+				}
+				logger.Debug("completing Event Grid subscription validation handshake")
+				xhttp.WriteResponseJSON(
+					w,
+					http.StatusOK,
+					map[string]string{"validationResponse": data.ValidationCode},
+				)
+				return
+			case azureEventGridImagePushed:
+				var data acrEvent
+				if err = json.Unmarshal(event.Data, &data); err != nil {
+					xhttp.WriteErrorJSON(
+						w,
+						xhttp.Error(errors.New("invalid request body"), http.StatusBadRequest),
+					)
+					return
+				}
+				repoURLs = append(
+					repoURLs,
+					getNormalizedImageRepoURLs(
+						fmt.Sprintf("%s/%s", data.Request.Host, data.Target.Repository),
+						data.Target.MediaType,
+					)...,
+				)
+				tags = append(tags, data.Target.Tag)
+			}
+		}
+
+		// Event Grid subscriptions may be filtered by event type, but might not
+		// be. Events other than pushes are not an error.
+		if len(repoURLs) == 0 {
+			xhttp.WriteResponseJSON(
+				w,
+				http.StatusOK,
+				map[string]string{"msg": "no supported events received"},
+			)
+			return
+		}
+
+		logger = logger.WithValues(
+			"repoURLs", repoURLs,
+			"tags", tags,
+		)
+		ctx = logging.ContextWithLogger(ctx, logger)
+		refreshWarehouses(ctx, w, a.client, a.project, repoURLs, nil, tags...)
+	})
+}
+
+// azureEventGridEvent represents a single event delivered by Event Grid using
+// either the Event Grid schema or the CloudEvents schema. The two differ only
+// in the name of the field indicating the type of the event. For more
+// information on these schemas, see:
+//
+//	https://learn.microsoft.com/en-us/azure/event-grid/event-schema
+//	https://learn.microsoft.com/en-us/azure/event-grid/cloud-event-schema
+type azureEventGridEvent struct {
+	// EventType is the type of the event when using the Event Grid schema.
+	EventType string `json:"eventType"`
+	// Type is the type of the event when using the CloudEvents schema.
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// getType returns the type of the event, regardless of schema.
+func (e azureEventGridEvent) getType() string {
+	if e.EventType != "" {
+		return e.EventType
+	}
+	return e.Type
+}
+
+// getAzureEventGridEvents extracts events from the body of a request made by
+// Event Grid. Events using the Event Grid schema are always delivered in
+// batches (arrays), while events using the CloudEvents schema may be delivered
+// either individually or in batches.
+func getAzureEventGridEvents(requestBody []byte) ([]azureEventGridEvent, error) {
+	if trimmed := bytes.TrimSpace(requestBody); len(trimmed) > 0 && trimmed[0] == '[' {
+		var events []azureEventGridEvent
+		err := json.Unmarshal(trimmed, &events)
+		return events, err
+	}
+	var event azureEventGridEvent
+	if err := json.Unmarshal(requestBody, &event); err != nil {
+		return nil, err
+	}
+	return []azureEventGridEvent{event}, nil
+}
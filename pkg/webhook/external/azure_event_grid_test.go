@@ -0,0 +1,270 @@
+package external
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/indexer"
+)
+
+const azureEventGridRequestBodyValidation = `
+[{
+	"id": "2d1781af-3a4c-4d7c-bd0c-e34b19da4e66",
+	"topic": "/subscriptions/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx",
+	"subject": "",
+	"data": {
+		"validationCode": "512d38b6-c7b8-40c8-89fe-f46f9e9622b6",
+		"validationUrl": "https://rp-eastus2.eventgrid.azure.net:553/eventsubscriptions/myeventsub/validate"
+	},
+	"eventType": "Microsoft.EventGrid.SubscriptionValidationEvent",
+	"eventTime": "2022-10-28T04:23:35.1981776Z",
+	"metadataVersion": "1",
+	"dataVersion": "1"
+}]`
+
+const azureEventGridRequestBodyImagePushed = `
+[{
+	"id": "831e1650-001e-001b-66ab-eeb76e069631",
+	"topic": "/subscriptions/xxx/resourceGroups/myrg/providers/Microsoft.ContainerRegistry/registries/myregistry",
+	"subject": "aci-helloworld:v1.0.0",
+	"eventType": "Microsoft.ContainerRegistry.ImagePushed",
+	"eventTime": "2018-04-25T21:39:47.6549614Z",
+	"data": {
+		"id": "31c51664-e5bd-416a-a5df-e5206bc47ed0",
+		"timestamp": "2018-04-25T21:39:47.276585742Z",
+		"action": "push",
+		"target": {
+			"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+			"size": 3023,
+			"digest": "sha256:213bbc182920ab41e18edc2001e06abcca6735d87782d9cef68abd83941cf0e5",
+			"length": 3023,
+			"repository": "aci-helloworld",
+			"tag": "v1.0.0"
+		},
+		"request": {
+			"id": "7c66f28b-de19-40a4-821c-6f5f6c0003a4",
+			"host": "myregistry.azurecr.io",
+			"method": "PUT",
+			"useragent": "docker/18.03.0-ce"
+		}
+	},
+	"dataVersion": "1.0",
+	"metadataVersion": "1"
+}]`
+
+const azureEventGridRequestBodyImagePushedCloudEvent = `
+{
+	"specversion": "1.0",
+	"type": "Microsoft.ContainerRegistry.ImagePushed",
+	"source": "/subscriptions/xxx/resourceGroups/myrg/providers/Microsoft.ContainerRegistry/registries/myregistry",
+	"subject": "aci-helloworld:v1.0.0",
+	"id": "831e1650-001e-001b-66ab-eeb76e069631",
+	"time": "2018-04-25T21:39:47.6549614Z",
+	"data": {
+		"action": "push",
+		"target": {
+			"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+			"repository": "aci-helloworld",
+			"tag": "v1.0.0"
+		},
+		"request": {
+			"host": "myregistry.azurecr.io"
+		}
+	}
+}`
+
+const azureEventGridRequestBodyImageDeleted = `
+[{
+	"eventType": "Microsoft.ContainerRegistry.ImageDeleted",
+	"data": {
+		"action": "delete",
+		"target": {
+			"repository": "aci-helloworld"
+		},
+		"request": {
+			"host": "myregistry.azurecr.io"
+		}
+	}
+}]`
+
+func TestAzureEventGridHandler(t *testing.T) {
+	const testURL = "https://webhooks.kargo.example.com/nonsense"
+
+	const testProjectName = "fake-project"
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	newTestClient := func(constraint string) client.Client {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "fake-warehouse",
+				},
+				Spec: kargoapi.WarehouseSpec{
+					InternalSubscriptions: []kargoapi.RepoSubscription{{
+						Image: &kargoapi.ImageSubscription{
+							RepoURL:    "myregistry.azurecr.io/aci-helloworld",
+							Constraint: constraint,
+						},
+					}},
+				},
+			},
+		).WithIndex(
+			&kargoapi.Warehouse{},
+			indexer.WarehousesBySubscribedURLsField,
+			indexer.WarehousesBySubscribedURLs,
+		).Build()
+	}
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		req        func() *http.Request
+		assertions func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "CloudEvents abuse protection handshake",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodOptions, testURL, nil)
+				req.Header.Set(azureEventGridRequestOriginHeader, "eventgrid.azure.net")
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Equal(
+					t,
+					"eventgrid.azure.net",
+					rr.Header().Get(azureEventGridAllowedOriginHeader),
+				)
+			},
+		},
+		{
+			name: "CloudEvents abuse protection handshake without origin",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodOptions, testURL, nil)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(
+					t,
+					`{"error":"missing WebHook-Request-Origin header"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "malformed request body",
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString("invalid json")
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(t, `{"error":"invalid request body"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "subscription validation handshake",
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(azureEventGridRequestBodyValidation)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(
+					t,
+					`{"validationResponse":"512d38b6-c7b8-40c8-89fe-f46f9e9622b6"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "invalid subscription validation event",
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(
+					`[{"eventType":"Microsoft.EventGrid.SubscriptionValidationEvent","data":{}}]`,
+				)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(t, `{"error":"invalid validation event"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "unsupported events ignored",
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(azureEventGridRequestBodyImageDeleted)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"no supported events received"}`, rr.Body.String())
+			},
+		},
+		{
+			name:   "no tag match",
+			client: newTestClient("^v2.0.0"),
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(azureEventGridRequestBodyImagePushed)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 0 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+		{
+			name:   "warehouse refreshed",
+			client: newTestClient("^v1.0.0"),
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(azureEventGridRequestBodyImagePushed)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 1 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+		{
+			name:   "warehouse refreshed (CloudEvents schema)",
+			client: newTestClient("^v1.0.0"),
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(azureEventGridRequestBodyImagePushedCloudEvent)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 1 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requestBody, err := io.ReadAll(testCase.req().Body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			(&azureEventGridWebhookReceiver{
+				baseWebhookReceiver: &baseWebhookReceiver{
+					client:  testCase.client,
+					project: testProjectName,
+				},
+			}).getHandler(requestBody)(w, testCase.req())
+
+			testCase.assertions(t, w)
+		})
+	}
+}
@@ -0,0 +1,518 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	xhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+	"github.com/akuity/kargo/pkg/pattern"
+	"github.com/akuity/kargo/pkg/urls"
+)
+
+const (
+	cloudEvents              = "cloudevents"
+	cloudEventsSecretDataKey = "secret"
+
+	cloudEventsSignatureHeader = "X-Signature-256"
+	cloudEventsSignaturePrefix = "sha256="
+	// cloudEventsTimestampHeader carries the time, in seconds since the Unix
+	// epoch, at which a signed request was signed.
+	cloudEventsTimestampHeader = "X-Signature-Timestamp"
+	// cloudEventsSignatureTolerance is how far the timestamp of a signed request
+	// may deviate from the current time before the request is rejected as a
+	// possible replay.
+	cloudEventsSignatureTolerance = 5 * time.Minute
+	cloudEventsBearerPrefix    = "Bearer "
+
+	// cloudEventsHeaderPrefix is the prefix of the headers carrying event
+	// attributes in binary content mode.
+	cloudEventsHeaderPrefix = "Ce-"
+
+	cloudEventsStructuredMediaType = "application/cloudevents+json"
+	cloudEventsBatchMediaType      = "application/cloudevents-batch+json"
+
+	cloudEventsSpecVersion = "1.0"
+
+	summaryNoRoutesMatched = "Event did not match any routes"
+)
+
+// cloudEventContextAttributes are the names of the context attributes defined
+// by the CloudEvents specification. Any other attributes are extensions.
+var cloudEventContextAttributes = []string{
+	"specversion",
+	"id",
+	"source",
+	"type",
+	"subject",
+	"time",
+	"datacontenttype",
+	"dataschema",
+	"data",
+	"data_base64",
+}
+
+func init() {
+	defaultWebhookReceiverRegistry.MustRegister(
+		webhookReceiverRegistration{
+			Predicate: func(_ context.Context, cfg kargoapi.WebhookReceiverConfig) (bool, error) {
+				return cfg.CloudEvents != nil, nil
+			},
+			Value: newCloudEventsWebhookReceiver,
+		},
+	)
+}
+
+// cloudEventsWebhookReceiver is an implementation of WebhookReceiver that
+// handles inbound CloudEvents delivered using the HTTP protocol binding in
+// either binary, structured or batched content mode.
+type cloudEventsWebhookReceiver struct {
+	*baseWebhookReceiver
+	config *kargoapi.CloudEventsWebhookReceiverConfig
+}
+
+// newCloudEventsWebhookReceiver returns a new instance of
+// cloudEventsWebhookReceiver.
+func newCloudEventsWebhookReceiver(
+	c client.Client,
+	project string,
+	cfg kargoapi.WebhookReceiverConfig,
+) WebhookReceiver {
+	return &cloudEventsWebhookReceiver{
+		baseWebhookReceiver: &baseWebhookReceiver{
+			client:     c,
+			project:    project,
+			secretName: cfg.CloudEvents.SecretRef.Name,
+		},
+		config: cfg.CloudEvents,
+	}
+}
+
+// getReceiverType implements WebhookReceiver.
+func (c *cloudEventsWebhookReceiver) getReceiverType() string {
+	return cloudEvents
+}
+
+// getSecretValues implements WebhookReceiver.
+func (c *cloudEventsWebhookReceiver) getSecretValues(
+	secretData map[string][]byte,
+) ([]string, error) {
+	secretValue, ok := secretData[cloudEventsSecretDataKey]
+	if !ok {
+		return nil, fmt.Errorf(
+			"missing data key %q for CloudEvents WebhookReceiver",
+			cloudEventsSecretDataKey,
+		)
+	}
+	return []string{string(secretValue)}, nil
+}
+
+// getHandler implements WebhookReceiver.
+func (c *cloudEventsWebhookReceiver) getHandler(requestBody []byte) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.LoggerFromContext(ctx)
+
+		if err := c.authenticate(r, requestBody); err != nil {
+			xhttp.WriteErrorJSON(w, xhttp.Error(err, http.StatusUnauthorized))
+			return
+		}
+
+		events, err := getCloudEvents(r, requestBody)
+		if err != nil {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(fmt.Errorf("invalid CloudEvent: %w", err), http.StatusBadRequest),
+			)
+			return
+		}
+
+		var objects []client.Object
+		var matched bool
+		for _, event := range events {
+			eventLogger := logger.WithValues(
+				"id", event.ID,
+				"type", event.Type,
+				"source", event.Source,
+				"subject", event.Subject,
+			)
+			env := event.newEnv()
+			for i, route := range c.config.Routes {
+				var routeMatched bool
+				if routeMatched, err = event.matches(route); err != nil {
+					eventLogger.Error(err, "error matching route", "route", i)
+					xhttp.WriteErrorJSON(w, err)
+					return
+				}
+				if !routeMatched {
+					continue
+				}
+				matched = true
+				eventLogger.Debug("event matched route", "route", i)
+				var routeObjects []client.Object
+				if routeObjects, err = c.listUniqueObjects(
+					ctx,
+					route.TargetSelectionCriteria,
+					env,
+				); err != nil {
+					eventLogger.Error(err, "error selecting targets", "route", i)
+					xhttp.WriteErrorJSON(w, err)
+					return
+				}
+				objects = append(objects, routeObjects...)
+			}
+		}
+
+		if !matched {
+			xhttp.WriteResponseJSON(
+				w,
+				http.StatusOK,
+				map[string]any{
+					"result":  resultNotApplicable,
+					"summary": summaryNoRoutesMatched,
+				},
+			)
+			return
+		}
+
+		// Multiple events or routes may have selected the same resources. Each
+		// should be refreshed only once.
+		objects = uniqueObjects(objects)
+		selectedTargets, result, summary := refreshObjects(ctx, c.client, objects)
+		statusCode := http.StatusOK
+		if result == resultFailure || result == resultPartialSuccess {
+			statusCode = http.StatusInternalServerError
+		}
+		xhttp.WriteResponseJSON(
+			w,
+			statusCode,
+			map[string]any{
+				"selectedTargets": selectedTargets,
+				"result":          result,
+				"summary":         summary,
+			},
+		)
+	})
+}
+
+// authenticate verifies that the request was sent by a party in possession of
+// the receiver's secret, either because it presented the secret as a bearer
+// token or because it signed the request using the secret. Signatures cover a
+// recent timestamp, the Content-Type header and all headers carrying event
+// attributes in addition to the request body, so a signed request can neither
+// be replayed later nor be re-routed by altering its unsigned headers.
+func (c *cloudEventsWebhookReceiver) authenticate(r *http.Request, requestBody []byte) error {
+	secret := c.secretData[cloudEventsSecretDataKey]
+	if sig := r.Header.Get(cloudEventsSignatureHeader); sig != "" {
+		if err := verifyCloudEventsTimestamp(
+			r.Header.Get(cloudEventsTimestampHeader),
+			time.Now(),
+		); err != nil {
+			return err
+		}
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write(cloudEventsSigningPayload(r.Header, requestBody))
+		expected := cloudEventsSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+		if !hmac.Equal([]byte(expected), []byte(sig)) {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), cloudEventsBearerPrefix)
+	if !ok {
+		return errors.New("missing authorization")
+	}
+	if subtle.ConstantTimeCompare(secret, []byte(token)) != 1 {
+		return errors.New("unauthorized")
+	}
+	return nil
+}
+
+// verifyCloudEventsTimestamp checks that the provided signature timestamp is
+// within cloudEventsSignatureTolerance of the provided time.
+func verifyCloudEventsTimestamp(timestamp string, now time.Time) error {
+	if timestamp == "" {
+		return fmt.Errorf("missing %s header", cloudEventsTimestampHeader)
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header", cloudEventsTimestampHeader)
+	}
+	if delta := now.Sub(time.Unix(seconds, 0)).Abs(); delta > cloudEventsSignatureTolerance {
+		return errors.New("signature timestamp is outside of the allowed tolerance")
+	}
+	return nil
+}
+
+// cloudEventsSigningPayload returns the data a sender must sign. It consists
+// of the following, each line terminated by a newline:
+//
+//   - The value of the X-Signature-Timestamp header.
+//   - "content-type:" followed by the value of the Content-Type header.
+//   - For every header beginning with "Ce-", in lexical order of lower-cased
+//     header names, the lower-cased header name, a colon and the header's
+//     values joined by commas.
+//   - An empty line.
+//
+// The request body follows.
+func cloudEventsSigningPayload(header http.Header, requestBody []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header.Get(cloudEventsTimestampHeader))
+	buf.WriteByte('\n')
+	buf.WriteString("content-type:")
+	buf.WriteString(header.Get("Content-Type"))
+	buf.WriteByte('\n')
+	attrHeaders := map[string]string{}
+	for key, values := range header {
+		key = http.CanonicalHeaderKey(key)
+		if strings.HasPrefix(key, cloudEventsHeaderPrefix) {
+			attrHeaders[strings.ToLower(key)] = strings.Join(values, ",")
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(attrHeaders)) {
+		buf.WriteString(key)
+		buf.WriteByte(':')
+		buf.WriteString(attrHeaders[key])
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	buf.Write(requestBody)
+	return buf.Bytes()
+}
+
+// cloudEvent represents a single CloudEvent. For more information, see:
+//
+//	https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time,omitempty"`
+	DataContentType string `json:"datacontenttype,omitempty"`
+	DataSchema      string `json:"dataschema,omitempty"`
+	// Data is the event payload, decoded if it is JSON.
+	Data any `json:"-"`
+	// Extensions contains any extension attributes of the event.
+	Extensions map[string]any `json:"-"`
+}
+
+// validate checks that the event carries all attributes required by the
+// CloudEvents specification.
+func (e *cloudEvent) validate() error {
+	if e.SpecVersion != cloudEventsSpecVersion {
+		return fmt.Errorf("unsupported specversion %q", e.SpecVersion)
+	}
+	var missing []string
+	if e.ID == "" {
+		missing = append(missing, "id")
+	}
+	if e.Source == "" {
+		missing = append(missing, "source")
+	}
+	if e.Type == "" {
+		missing = append(missing, "type")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required attributes %v", missing)
+	}
+	return nil
+}
+
+// matches returns whether the event matches the provided route.
+func (e *cloudEvent) matches(route kargoapi.CloudEventsWebhookRoute) (bool, error) {
+	for _, attr := range []struct {
+		pattern string
+		value   string
+	}{
+		{pattern: route.Type, value: e.Type},
+		{pattern: route.Source, value: e.Source},
+		{pattern: route.Subject, value: e.Subject},
+	} {
+		if attr.pattern == "" {
+			continue
+		}
+		matcher, err := pattern.ParseNamePattern(attr.pattern)
+		if err != nil {
+			return false, fmt.Errorf("error parsing pattern %q: %w", attr.pattern, err)
+		}
+		if !matcher.Matches(attr.value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// newEnv returns the environment used for evaluating expressions in the
+// target selection criteria of routes matching the event.
+func (e *cloudEvent) newEnv() map[string]any {
+	extensions := e.Extensions
+	if extensions == nil {
+		extensions = map[string]any{}
+	}
+	return map[string]any{
+		"normalizeGit":   urls.NormalizeGit,
+		"normalizeImage": urls.NormalizeImage,
+		"normalizeChart": urls.NormalizeChart,
+		"event": map[string]any{
+			"specversion":     e.SpecVersion,
+			"id":              e.ID,
+			"source":          e.Source,
+			"type":            e.Type,
+			"subject":         e.Subject,
+			"time":            e.Time,
+			"datacontenttype": e.DataContentType,
+			"dataschema":      e.DataSchema,
+			"data":            e.Data,
+			"extensions":      extensions,
+		},
+	}
+}
+
+// getCloudEvents extracts CloudEvents from a request using whichever content
+// mode of the CloudEvents HTTP protocol binding the request was made in. For
+// more information, see:
+//
+//	https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+func getCloudEvents(r *http.Request, requestBody []byte) ([]cloudEvent, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var events []cloudEvent
+	switch mediaType {
+	case cloudEventsStructuredMediaType:
+		event, err := parseStructuredCloudEvent(requestBody)
+		if err != nil {
+			return nil, err
+		}
+		events = []cloudEvent{event}
+	case cloudEventsBatchMediaType:
+		var rawEvents []json.RawMessage
+		if err := json.Unmarshal(requestBody, &rawEvents); err != nil {
+			return nil, fmt.Errorf("error parsing batch: %w", err)
+		}
+		events = make([]cloudEvent, len(rawEvents))
+		for i, rawEvent := range rawEvents {
+			event, err := parseStructuredCloudEvent(rawEvent)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing event at index %d: %w", i, err)
+			}
+			events[i] = event
+		}
+	default:
+		events = []cloudEvent{parseBinaryCloudEvent(r, requestBody)}
+	}
+	for i := range events {
+		if err := events[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// parseBinaryCloudEvent extracts a CloudEvent from a request made in binary
+// content mode, wherein attributes are carried in headers and the request body
+// is the event's data.
+func parseBinaryCloudEvent(r *http.Request, requestBody []byte) cloudEvent {
+	event := cloudEvent{
+		DataContentType: r.Header.Get("Content-Type"),
+		Extensions:      map[string]any{},
+	}
+	for key, values := range r.Header {
+		name, ok := strings.CutPrefix(http.CanonicalHeaderKey(key), cloudEventsHeaderPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch name = strings.ToLower(name); name {
+		case "specversion":
+			event.SpecVersion = value
+		case "id":
+			event.ID = value
+		case "source":
+			event.Source = value
+		case "type":
+			event.Type = value
+		case "subject":
+			event.Subject = value
+		case "time":
+			event.Time = value
+		case "dataschema":
+			event.DataSchema = value
+		default:
+			event.Extensions[name] = value
+		}
+	}
+	event.Data = decodeCloudEventData(event.DataContentType, requestBody)
+	return event
+}
+
+// parseStructuredCloudEvent extracts a CloudEvent from its JSON
+// representation, as found in the body of a request made in structured or
+// batched content mode.
+func parseStructuredCloudEvent(raw []byte) (cloudEvent, error) {
+	var event cloudEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return event, err
+	}
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return event, err
+	}
+	event.Extensions = map[string]any{}
+	for name, value := range attrs {
+		if slices.Contains(cloudEventContextAttributes, name) {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(value, &v); err != nil {
+			return event, err
+		}
+		event.Extensions[name] = v
+	}
+	if data, ok := attrs["data_base64"]; ok {
+		var decoded []byte
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return event, fmt.Errorf("error decoding data_base64: %w", err)
+		}
+		event.Data = decodeCloudEventData(event.DataContentType, decoded)
+	} else if data, ok = attrs["data"]; ok {
+		if err := json.Unmarshal(data, &event.Data); err != nil {
+			return event, err
+		}
+	}
+	return event, nil
+}
+
+// decodeCloudEventData decodes event data of the specified content type. Data
+// that is JSON (which is assumed if the content type is unspecified) is
+// decoded. Anything else is returned as a string.
+func decodeCloudEventData(contentType string, data []byte) any {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err == nil {
+			return decoded
+		}
+	}
+	return string(data)
+}
@@ -0,0 +1,435 @@
+package external
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const cloudEventStructured = `{
+	"specversion": "1.0",
+	"id": "b3d8f4b4-4c8e-4d4e-9b0a-8d6f1d2e3c4b",
+	"source": "tekton/pipelines",
+	"type": "dev.tekton.event.pipelinerun.successful.v1",
+	"subject": "build-my-app",
+	"datacontenttype": "application/json",
+	"team": "platform",
+	"data": {"warehouse": "my-warehouse"}
+}`
+
+func TestCloudEventsHandler(t *testing.T) {
+	const testURL = "https://webhooks.kargo.example.com/nonsense"
+
+	const testProjectName = "fake-project"
+
+	const testToken = "mysupersecrettoken"
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	// Computed once so that both requests built by each test case bear the same
+	// timestamp.
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// sign signs the provided request with the provided timestamp. It must be
+	// called after all other headers have been set.
+	sign := func(req *http.Request, body string, timestamp string) {
+		req.Header.Set(cloudEventsTimestampHeader, timestamp)
+		mac := hmac.New(sha256.New, []byte(testToken))
+		_, _ = mac.Write(cloudEventsSigningPayload(req.Header, []byte(body)))
+		req.Header.Set(cloudEventsSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	newTestClient := func() client.Client {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "my-warehouse",
+				},
+			},
+			&kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "my-stage",
+					Labels:    map[string]string{"team": "platform"},
+				},
+			},
+		).Build()
+	}
+
+	testConfig := &kargoapi.CloudEventsWebhookReceiverConfig{
+		Routes: []kargoapi.CloudEventsWebhookRoute{
+			{
+				Type:   "glob:dev.tekton.event.pipelinerun.*",
+				Source: "tekton/pipelines",
+				TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{{
+					Kind: kargoapi.GenericWebhookTargetKindWarehouse,
+					Name: "${{ event.data.warehouse }}",
+				}},
+			},
+			{
+				Subject: "regex:^build-",
+				TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{
+					{
+						Kind: kargoapi.GenericWebhookTargetKindStage,
+						LabelSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"team": "${{ event.extensions.team }}",
+							},
+						},
+					},
+					{
+						// Selects the same Warehouse as the first route
+						Kind: kargoapi.GenericWebhookTargetKindWarehouse,
+						Name: "my-warehouse",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		req        func() *http.Request
+		assertions func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "missing authorization",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"missing authorization"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "wrong token",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType)
+				req.Header.Set("Authorization", "Bearer wrong")
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "bad signature",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType)
+				sign(req, "something else", now)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "signature without timestamp",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType)
+				sign(req, cloudEventStructured, now)
+				req.Header.Del(cloudEventsTimestampHeader)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"missing X-Signature-Timestamp header"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "stale signature",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType)
+				sign(req, cloudEventStructured, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(
+					t,
+					`{"error":"signature timestamp is outside of the allowed tolerance"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "binary mode with signature and altered attribute",
+			req: func() *http.Request {
+				const body = `{"warehouse":"my-warehouse"}`
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Ce-Specversion", "1.0")
+				req.Header.Set("Ce-Id", "1")
+				req.Header.Set("Ce-Source", "argo-events")
+				req.Header.Set("Ce-Type", "push")
+				sign(req, body, now)
+				// Re-route the signed event
+				req.Header.Set("Ce-Source", "tekton/pipelines")
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			},
+		},
+		{
+			name: "binary mode with signature",
+			req: func() *http.Request {
+				const body = `{"warehouse":"my-warehouse"}`
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Ce-Specversion", "1.0")
+				req.Header.Set("Ce-Id", "1")
+				req.Header.Set("Ce-Source", "tekton/pipelines")
+				req.Header.Set("Ce-Type", "dev.tekton.event.pipelinerun.successful.v1")
+				sign(req, body, now)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(
+					t,
+					`{
+						"result": "Success",
+						"summary": "Refreshed 1 of 1 selected resources",
+						"selectedTargets": [
+							{"namespace": "fake-project", "name": "my-warehouse", "success": true}
+						]
+					}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "missing required attributes",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(`{}`))
+				req.Header.Set("Ce-Specversion", "1.0")
+				req.Header.Set("Authorization", "Bearer "+testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(
+					t,
+					`{"error":"invalid CloudEvent: missing required attributes [id source type]"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "no routes matched",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(`{}`))
+				req.Header.Set("Ce-Specversion", "1.0")
+				req.Header.Set("Ce-Id", "1")
+				req.Header.Set("Ce-Source", "argo-events")
+				req.Header.Set("Ce-Type", "push")
+				req.Header.Set("Authorization", "Bearer "+testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(
+					t,
+					`{"result":"NotApplicable","summary":"Event did not match any routes"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "structured mode with signature",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(cloudEventStructured))
+				req.Header.Set("Content-Type", cloudEventsStructuredMediaType+"; charset=utf-8")
+				sign(req, cloudEventStructured, now)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(
+					t,
+					`{
+						"result": "Success",
+						"summary": "Refreshed 2 of 2 selected resources",
+						"selectedTargets": [
+							{"namespace": "fake-project", "name": "my-stage", "success": true},
+							{"namespace": "fake-project", "name": "my-warehouse", "success": true}
+						]
+					}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "binary mode with token",
+			req: func() *http.Request {
+				req := httptest.NewRequest(
+					http.MethodPost,
+					testURL,
+					bytes.NewBufferString(`{"warehouse":"my-warehouse"}`),
+				)
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Ce-Specversion", "1.0")
+				req.Header.Set("Ce-Id", "1")
+				req.Header.Set("Ce-Source", "tekton/pipelines")
+				req.Header.Set("Ce-Type", "dev.tekton.event.pipelinerun.successful.v1")
+				req.Header.Set("Authorization", "Bearer "+testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(
+					t,
+					`{
+						"result": "Success",
+						"summary": "Refreshed 1 of 1 selected resources",
+						"selectedTargets": [
+							{"namespace": "fake-project", "name": "my-warehouse", "success": true}
+						]
+					}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name: "batched mode",
+			req: func() *http.Request {
+				body := "[" + cloudEventStructured + "," + cloudEventStructured + "]"
+				req := httptest.NewRequest(http.MethodPost, testURL, bytes.NewBufferString(body))
+				req.Header.Set("Content-Type", cloudEventsBatchMediaType)
+				req.Header.Set("Authorization", "Bearer "+testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Contains(t, rr.Body.String(), "Refreshed 2 of 2 selected resources")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requestBody, err := io.ReadAll(testCase.req().Body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			(&cloudEventsWebhookReceiver{
+				baseWebhookReceiver: &baseWebhookReceiver{
+					client:     newTestClient(),
+					project:    testProjectName,
+					secretData: map[string][]byte{cloudEventsSecretDataKey: []byte(testToken)},
+				},
+				config: testConfig,
+			}).getHandler(requestBody)(w, testCase.req())
+
+			testCase.assertions(t, w)
+		})
+	}
+}
+
+func Test_cloudEventsSigningPayload(t *testing.T) {
+	header := http.Header{}
+	header.Set(cloudEventsTimestampHeader, "1700000000")
+	header.Set("Content-Type", "application/json")
+	header.Set("Ce-Type", "push")
+	header.Set("Ce-Id", "1")
+	header.Add("Ce-Source", "a")
+	header.Add("Ce-Source", "b")
+	header.Set("X-Unrelated", "ignored")
+	require.Equal(
+		t,
+		"1700000000\n"+
+			"content-type:application/json\n"+
+			"ce-id:1\n"+
+			"ce-source:a,b\n"+
+			"ce-type:push\n"+
+			"\n"+
+			`{"foo":"bar"}`,
+		string(cloudEventsSigningPayload(header, []byte(`{"foo":"bar"}`))),
+	)
+}
+
+func Test_verifyCloudEventsTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, verifyCloudEventsTimestamp("1700000000", now))
+	require.NoError(t, verifyCloudEventsTimestamp("1699999760", now))
+	require.NoError(t, verifyCloudEventsTimestamp("1700000240", now))
+	require.ErrorContains(t, verifyCloudEventsTimestamp("", now), "missing")
+	require.ErrorContains(t, verifyCloudEventsTimestamp("yesterday", now), "invalid")
+	require.ErrorContains(t, verifyCloudEventsTimestamp("1699999000", now), "tolerance")
+	require.ErrorContains(t, verifyCloudEventsTimestamp("1700001000", now), "tolerance")
+}
+
+func Test_parseStructuredCloudEvent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		raw        string
+		assertions func(*testing.T, cloudEvent, error)
+	}{
+		{
+			name: "invalid JSON",
+			raw:  "invalid",
+			assertions: func(t *testing.T, _ cloudEvent, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "JSON data and extensions",
+			raw:  cloudEventStructured,
+			assertions: func(t *testing.T, event cloudEvent, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "build-my-app", event.Subject)
+				require.Equal(t, map[string]any{"warehouse": "my-warehouse"}, event.Data)
+				require.Equal(t, map[string]any{"team": "platform"}, event.Extensions)
+			},
+		},
+		{
+			name: "base64-encoded data",
+			raw: `{
+				"specversion": "1.0",
+				"id": "1",
+				"source": "s",
+				"type": "t",
+				"datacontenttype": "text/plain",
+				"data_base64": "aGVsbG8="
+			}`,
+			assertions: func(t *testing.T, event cloudEvent, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "hello", event.Data)
+				require.Empty(t, event.Extensions)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			event, err := parseStructuredCloudEvent([]byte(testCase.raw))
+			testCase.assertions(t, event, err)
+		})
+	}
+}
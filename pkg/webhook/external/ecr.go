@@ -0,0 +1,181 @@
+package external
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	xhttp "github.com/akuity/kargo/pkg/http"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	ecr              = "ecr"
+	ecrSecretDataKey = "secret"
+
+	// ecrAuthHeader is the header in which EventBridge sends the API key
+	// configured on the connection used by the API destination.
+	ecrAuthHeader = "Authorization"
+
+	ecrEventSource     = "aws.ecr"
+	ecrEventDetailType = "ECR Image Action"
+	ecrActionTypePush  = "PUSH"
+	ecrResultSuccess   = "SUCCESS"
+)
+
+func init() {
+	defaultWebhookReceiverRegistry.MustRegister(
+		webhookReceiverRegistration{
+			Predicate: func(_ context.Context, cfg kargoapi.WebhookReceiverConfig) (bool, error) {
+				return cfg.ECR != nil, nil
+			},
+			Value: newECRWebhookReceiver,
+		},
+	)
+}
+
+// ecrWebhookReceiver is an implementation of WebhookReceiver that handles
+// inbound webhooks from Amazon EventBridge carrying Amazon Elastic Container
+// Registry (ECR) events.
+type ecrWebhookReceiver struct {
+	*baseWebhookReceiver
+}
+
+// newECRWebhookReceiver returns a new instance of ecrWebhookReceiver.
+func newECRWebhookReceiver(
+	c client.Client,
+	project string,
+	cfg kargoapi.WebhookReceiverConfig,
+) WebhookReceiver {
+	return &ecrWebhookReceiver{
+		baseWebhookReceiver: &baseWebhookReceiver{
+			client:     c,
+			project:    project,
+			secretName: cfg.ECR.SecretRef.Name,
+		},
+	}
+}
+
+// getReceiverType implements WebhookReceiver.
+func (e *ecrWebhookReceiver) getReceiverType() string {
+	return ecr
+}
+
+// getSecretValues implements WebhookReceiver.
+func (e *ecrWebhookReceiver) getSecretValues(
+	secretData map[string][]byte,
+) ([]string, error) {
+	secretValue, ok := secretData[ecrSecretDataKey]
+	if !ok {
+		return nil, fmt.Errorf("missing data key %q for ECR WebhookReceiver", ecrSecretDataKey)
+	}
+	return []string{string(secretValue)}, nil
+}
+
+// getHandler implements WebhookReceiver.
+func (e *ecrWebhookReceiver) getHandler(requestBody []byte) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logging.LoggerFromContext(ctx)
+
+		token, ok := e.secretData[ecrSecretDataKey]
+		if !ok {
+			xhttp.WriteErrorJSON(w, nil)
+			return
+		}
+
+		authHeader := r.Header.Get(ecrAuthHeader)
+		if authHeader == "" {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(errors.New("missing authorization"), http.StatusUnauthorized),
+			)
+			return
+		}
+
+		// EventBridge API destinations send the API key configured on their
+		// connection verbatim, so this is a simple comparison.
+		if subtle.ConstantTimeCompare([]byte(authHeader), token) != 1 {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(errors.New("unauthorized"), http.StatusUnauthorized),
+			)
+			return
+		}
+
+		var event ecrEvent
+		if err := json.Unmarshal(requestBody, &event); err != nil {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(errors.New("invalid request body"), http.StatusBadRequest),
+			)
+			return
+		}
+
+		if event.Source != ecrEventSource || event.DetailType != ecrEventDetailType ||
+			event.Detail.ActionType != ecrActionTypePush {
+			xhttp.WriteErrorJSON(
+				w,
+				xhttp.Error(
+					fmt.Errorf(
+						"event type %q from source %q is not supported",
+						event.DetailType, event.Source,
+					),
+					http.StatusBadRequest,
+				),
+			)
+			return
+		}
+
+		// EventBridge also delivers events for pushes that failed. There is
+		// nothing to discover in that case, but the event was still handled
+		// successfully.
+		if event.Detail.Result != ecrResultSuccess {
+			xhttp.WriteResponseJSON(
+				w,
+				http.StatusOK,
+				map[string]string{"msg": "ignored unsuccessful push"},
+			)
+			return
+		}
+
+		repoURLs := getNormalizedImageRepoURLs(
+			fmt.Sprintf(
+				"%s.dkr.ecr.%s.amazonaws.com/%s",
+				event.Account, event.Region, event.Detail.RepositoryName,
+			),
+			event.Detail.ArtifactMediaType,
+		)
+		logger = logger.WithValues(
+			"repoURLs", repoURLs,
+			"tag", event.Detail.ImageTag,
+			"mediaType", event.Detail.ArtifactMediaType,
+		)
+		ctx = logging.ContextWithLogger(ctx, logger)
+		refreshWarehouses(ctx, w, e.client, e.project, repoURLs, nil, event.Detail.ImageTag)
+	})
+}
+
+// ecrEvent represents an ECR image action event as delivered by EventBridge.
+// For more information on the event schema, see:
+//
+//	https://docs.aws.amazon.com/AmazonECR/latest/userguide/ecr-eventbridge.html
+type ecrEvent struct {
+	Source     string `json:"source"`
+	DetailType string `json:"detail-type"`
+	Account    string `json:"account"`
+	Region     string `json:"region"`
+	Detail     struct {
+		Result            string `json:"result"`
+		ActionType        string `json:"action-type"`
+		RepositoryName    string `json:"repository-name"`
+		ImageTag          string `json:"image-tag"`
+		ArtifactMediaType string `json:"artifact-media-type"`
+	} `json:"detail"`
+}
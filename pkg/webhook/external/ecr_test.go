@@ -0,0 +1,231 @@
+package external
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/indexer"
+)
+
+const ecrWebhookRequestBodyPush = `
+{
+	"version": "0",
+	"id": "13cde686-328b-6117-af20-0e5566167482",
+	"detail-type": "ECR Image Action",
+	"source": "aws.ecr",
+	"account": "123456789012",
+	"time": "2019-11-16T01:54:34Z",
+	"region": "us-west-2",
+	"resources": [],
+	"detail": {
+		"result": "SUCCESS",
+		"repository-name": "my-repository-name",
+		"image-digest": "sha256:7f5b2640fe6fb4f46592dfd3410c4a79dac4f89e4782432e0378abcd1234",
+		"action-type": "PUSH",
+		"image-tag": "v1.0.0",
+		"artifact-media-type": "` + dockerImageConfigBlobMediaType + `"
+	}
+}`
+
+const ecrWebhookRequestBodyFailedPush = `
+{
+	"detail-type": "ECR Image Action",
+	"source": "aws.ecr",
+	"account": "123456789012",
+	"region": "us-west-2",
+	"detail": {
+		"result": "FAILURE",
+		"repository-name": "my-repository-name",
+		"action-type": "PUSH",
+		"image-tag": "v1.0.0"
+	}
+}`
+
+const ecrWebhookRequestBodyDelete = `
+{
+	"detail-type": "ECR Image Action",
+	"source": "aws.ecr",
+	"account": "123456789012",
+	"region": "us-west-2",
+	"detail": {
+		"result": "SUCCESS",
+		"repository-name": "my-repository-name",
+		"action-type": "DELETE",
+		"image-tag": "v1.0.0"
+	}
+}`
+
+func TestECRHandler(t *testing.T) {
+	const testURL = "https://webhooks.kargo.example.com/nonsense"
+
+	const testProjectName = "fake-project"
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	const testToken = "mysupersecrettoken"
+	testSecretData := map[string][]byte{
+		ecrSecretDataKey: []byte(testToken),
+	}
+
+	newTestClient := func(constraint string) client.Client {
+		return fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&kargoapi.Warehouse{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testProjectName,
+					Name:      "fake-warehouse",
+				},
+				Spec: kargoapi.WarehouseSpec{
+					InternalSubscriptions: []kargoapi.RepoSubscription{{
+						Image: &kargoapi.ImageSubscription{
+							RepoURL:    "123456789012.dkr.ecr.us-west-2.amazonaws.com/my-repository-name",
+							Constraint: constraint,
+						},
+					}},
+				},
+			},
+		).WithIndex(
+			&kargoapi.Warehouse{},
+			indexer.WarehousesBySubscribedURLsField,
+			indexer.WarehousesBySubscribedURLs,
+		).Build()
+	}
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		secretData map[string][]byte
+		req        func() *http.Request
+		assertions func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "missing authorization",
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyPush)
+				return httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"missing authorization"}`, rr.Body.String())
+			},
+		},
+		{
+			name:       "unauthorized",
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyPush)
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, "wrong")
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				require.JSONEq(t, `{"error":"unauthorized"}`, rr.Body.String())
+			},
+		},
+		{
+			name:       "malformed request body",
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString("invalid json")
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(t, `{"error":"invalid request body"}`, rr.Body.String())
+			},
+		},
+		{
+			name:       "unsupported event",
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyDelete)
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.JSONEq(
+					t,
+					`{"error":"event type \"ECR Image Action\" from source \"aws.ecr\" is not supported"}`,
+					rr.Body.String(),
+				)
+			},
+		},
+		{
+			name:       "unsuccessful push",
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyFailedPush)
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"ignored unsuccessful push"}`, rr.Body.String())
+			},
+		},
+		{
+			name:       "no tag match",
+			client:     newTestClient("^v2.0.0"),
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyPush)
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 0 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+		{
+			name:       "warehouse refreshed",
+			client:     newTestClient("^v1.0.0"),
+			secretData: testSecretData,
+			req: func() *http.Request {
+				bodyBuf := bytes.NewBufferString(ecrWebhookRequestBodyPush)
+				req := httptest.NewRequest(http.MethodPost, testURL, bodyBuf)
+				req.Header.Set(ecrAuthHeader, testToken)
+				return req
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.JSONEq(t, `{"msg":"refreshed 1 warehouse(s)"}`, rr.Body.String())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requestBody, err := io.ReadAll(testCase.req().Body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			(&ecrWebhookReceiver{
+				baseWebhookReceiver: &baseWebhookReceiver{
+					client:     testCase.client,
+					project:    testProjectName,
+					secretData: testCase.secretData,
+				},
+			}).getHandler(requestBody)(w, testCase.req())
+
+			testCase.assertions(t, w)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/expr-lang/expr"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
 	"github.com/akuity/kargo/pkg/logging"
 )
 
@@ -23,6 +24,7 @@ const (
 	summaryRequestNotMatched      = "Request did not match whenExpression"
 	summaryRequestMatchingError   = "Error evaluating whenExpression"
 	summaryResourceSelectionError = "Error evaluating targetSelectionCriteria"
+	summaryParameterEvalError     = "Error evaluating parameters"
 )
 
 type actionResult struct {
@@ -37,6 +39,7 @@ type selectedTarget struct {
 	Namespace string `json:"namespace"`
 	Name      string `json:"name"`
 	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
 }
 
 func newActionEnv(params map[string]string, baseEnv map[string]any) map[string]any {
@@ -75,7 +78,7 @@ func (g *genericWebhookReceiver) handleAction(
 		ar.Summary = summaryRequestNotMatched
 		return ar
 	}
-	objects, err := g.listUniqueObjects(ctx, action, env)
+	objects, err := g.listUniqueObjects(ctx, action.TargetSelectionCriteria, env)
 	if err != nil {
 		aLogger.Error(err, "failed to list unique objects")
 		ar.Result = resultError
@@ -85,10 +88,62 @@ func (g *genericWebhookReceiver) handleAction(
 	switch action.ActionType {
 	case kargoapi.GenericWebhookActionTypeRefresh:
 		ar.SelectedTargets, ar.Result, ar.Summary = refreshObjects(ctx, g.client, objects)
+	case kargoapi.GenericWebhookActionTypePromote:
+		var freight, origin string
+		if freight, err = evalParam(action.Parameters, promoteParamFreight, env); err == nil {
+			origin, err = evalParam(action.Parameters, promoteParamOrigin, env)
+		}
+		if err != nil {
+			aLogger.Error(err, "failed to evaluate parameters")
+			ar.Result = resultError
+			ar.Summary = summaryParameterEvalError
+			return ar
+		}
+		ar.SelectedTargets, ar.Result, ar.Summary = promoteStages(
+			ctx,
+			g.client,
+			api.FormatEventWebhookReceiverActor(g.details.Name),
+			objects,
+			freight,
+			origin,
+		)
+	case kargoapi.GenericWebhookActionTypeApprove:
+		freight, err := evalParam(action.Parameters, promoteParamFreight, env)
+		if err != nil {
+			aLogger.Error(err, "failed to evaluate parameters")
+			ar.Result = resultError
+			ar.Summary = summaryParameterEvalError
+			return ar
+		}
+		ar.SelectedTargets, ar.Result, ar.Summary = approveFreight(ctx, g.client, objects, freight)
+	case kargoapi.GenericWebhookActionTypeSetMetadata:
+		metadata, err := evalMetadata(action.Parameters, env)
+		if err != nil {
+			aLogger.Error(err, "failed to evaluate parameters")
+			ar.Result = resultError
+			ar.Summary = summaryParameterEvalError
+			return ar
+		}
+		ar.SelectedTargets, ar.Result, ar.Summary = setMetadata(ctx, g.client, objects, metadata)
 	}
 	return ar
 }
 
+// evalParam evaluates the action parameter with the specified key as an
+// expression that must yield a string. An empty string is returned if no such
+// parameter exists.
+func evalParam(params map[string]string, key string, env map[string]any) (string, error) {
+	expr, ok := params[key]
+	if !ok {
+		return "", nil
+	}
+	value, err := evalAsString(expr, env)
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate parameter %q: %w", key, err)
+	}
+	return value, nil
+}
+
 func whenExpressionMet(expression string, env map[string]any) (bool, error) {
 	if expression == "" {
 		return true, nil
@@ -565,6 +565,81 @@ func TestHandleAction(t *testing.T) {
 				require.Equal(t, ar.Summary, summaryResourceSelectionError)
 			},
 		},
+		{
+			name:   "error evaluating parameters",
+			client: fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			action: kargoapi.GenericWebhookAction{
+				ActionType: kargoapi.GenericWebhookActionTypePromote,
+				Parameters: map[string]string{
+					"freight": "${{ 42 }}", // Not a string
+				},
+				TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{{
+					Kind: kargoapi.GenericWebhookTargetKindStage,
+					Name: "test-stage",
+				}},
+			},
+			assertions: func(t *testing.T, ar actionResult) {
+				require.True(t, ar.MatchedWhenExpression)
+				require.Empty(t, ar.SelectedTargets)
+				require.Equal(t, resultError, ar.Result)
+				require.Equal(t, summaryParameterEvalError, ar.Summary)
+			},
+		},
+		{
+			name: "Promote action",
+			client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+				&kargoapi.Stage{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test-namespace",
+						Name:      "test-stage",
+					},
+					Spec: kargoapi.StageSpec{
+						RequestedFreight: []kargoapi.FreightRequest{{
+							Origin: kargoapi.FreightOrigin{
+								Kind: kargoapi.FreightOriginKindWarehouse,
+								Name: "test-warehouse",
+							},
+							Sources: kargoapi.FreightSources{Direct: true},
+						}},
+					},
+				},
+				&kargoapi.Freight{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test-namespace",
+						Name:      "test-freight",
+					},
+					Origin: kargoapi.FreightOrigin{
+						Kind: kargoapi.FreightOriginKindWarehouse,
+						Name: "test-warehouse",
+					},
+				},
+			).Build(),
+			project: "test-namespace",
+			action: kargoapi.GenericWebhookAction{
+				ActionType: kargoapi.GenericWebhookActionTypePromote,
+				Parameters: map[string]string{
+					"freight": "test-freight",
+				},
+				TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{{
+					Kind: kargoapi.GenericWebhookTargetKindStage,
+					Name: "test-stage",
+				}},
+			},
+			assertions: func(t *testing.T, ar actionResult) {
+				require.True(t, ar.MatchedWhenExpression)
+				require.Equal(
+					t,
+					[]selectedTarget{{
+						Namespace: "test-namespace",
+						Name:      "test-stage",
+						Success:   true,
+					}},
+					ar.SelectedTargets,
+				)
+				require.Equal(t, resultSuccess, ar.Result)
+				require.Equal(t, "Promoted to 1 of 1 selected Stages", ar.Summary)
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -14,37 +14,49 @@ import (
 	"github.com/akuity/kargo/pkg/expressions"
 )
 
-func (g *genericWebhookReceiver) listUniqueObjects(
+// listUniqueObjects lists the objects selected by each of the provided
+// selection criteria, returning them sorted and without duplicates.
+func (b *baseWebhookReceiver) listUniqueObjects(
 	ctx context.Context,
-	action kargoapi.GenericWebhookAction,
+	criteria []kargoapi.GenericWebhookTargetSelectionCriteria,
 	actionEnv map[string]any,
 ) ([]client.Object, error) {
 	var resources []client.Object
-	for i, tsc := range action.TargetSelectionCriteria {
-		objects, err := g.listTargetObjects(ctx, tsc, actionEnv)
+	for i, tsc := range criteria {
+		objects, err := b.listTargetObjects(ctx, tsc, actionEnv)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list objects for targetSelectionCriteria at index %d: %w", i, err)
 		}
 		resources = append(resources, objects...)
 	}
-	slices.SortFunc(resources, func(a, b client.Object) int {
+	return uniqueObjects(resources), nil
+}
+
+// uniqueObjects sorts the provided objects by kind, namespace and name and
+// removes any duplicates.
+func uniqueObjects(objects []client.Object) []client.Object {
+	slices.SortFunc(objects, func(a, b client.Object) int {
+		if comp := strings.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); comp != 0 {
+			return comp
+		}
 		if comp := strings.Compare(a.GetNamespace(), b.GetNamespace()); comp != 0 {
 			return comp
 		}
 		return strings.Compare(a.GetName(), b.GetName())
 	})
-	resources = slices.CompactFunc(resources, func(a, b client.Object) bool {
-		return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+	return slices.CompactFunc(objects, func(a, b client.Object) bool {
+		return fmt.Sprintf("%T", a) == fmt.Sprintf("%T", b) &&
+			a.GetNamespace() == b.GetNamespace() &&
+			a.GetName() == b.GetName()
 	})
-	return resources, nil
 }
 
-func (g *genericWebhookReceiver) listTargetObjects(
+func (b *baseWebhookReceiver) listTargetObjects(
 	ctx context.Context,
 	targetSelectionCriteria kargoapi.GenericWebhookTargetSelectionCriteria,
 	actionEnv map[string]any,
 ) ([]client.Object, error) {
-	listOpts, err := g.buildListOptionsForTarget(targetSelectionCriteria, actionEnv)
+	listOpts, err := b.buildListOptionsForTarget(targetSelectionCriteria, actionEnv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build list options: %w", err)
 	}
@@ -52,16 +64,28 @@ func (g *genericWebhookReceiver) listTargetObjects(
 	switch targetSelectionCriteria.Kind {
 	case kargoapi.GenericWebhookTargetKindWarehouse:
 		warehouses := new(kargoapi.WarehouseList)
-		if err = g.client.List(ctx, warehouses, listOpts...); err != nil {
+		if err = b.client.List(ctx, warehouses, listOpts...); err != nil {
 			return nil, fmt.Errorf("error listing %s targets: %w", targetSelectionCriteria.Kind, err)
 		}
 		objects = itemsToObjects(warehouses.Items)
 	case kargoapi.GenericWebhookTargetKindPromotion:
 		promotions := new(kargoapi.PromotionList)
-		if err = g.client.List(ctx, promotions, listOpts...); err != nil {
+		if err = b.client.List(ctx, promotions, listOpts...); err != nil {
 			return nil, fmt.Errorf("error listing %s targets: %w", targetSelectionCriteria.Kind, err)
 		}
 		objects = itemsToObjects(promotions.Items)
+	case kargoapi.GenericWebhookTargetKindStage:
+		stages := new(kargoapi.StageList)
+		if err = b.client.List(ctx, stages, listOpts...); err != nil {
+			return nil, fmt.Errorf("error listing %s targets: %w", targetSelectionCriteria.Kind, err)
+		}
+		objects = itemsToObjects(stages.Items)
+	case kargoapi.GenericWebhookTargetKindFreight:
+		freight := new(kargoapi.FreightList)
+		if err = b.client.List(ctx, freight, listOpts...); err != nil {
+			return nil, fmt.Errorf("error listing %s targets: %w", targetSelectionCriteria.Kind, err)
+		}
+		objects = itemsToObjects(freight.Items)
 	default:
 		return nil, fmt.Errorf("unsupported target kind: %q", targetSelectionCriteria.Kind)
 	}
@@ -84,13 +108,13 @@ func (g *genericWebhookReceiver) listTargetObjects(
 // buildListOptionsForTarget builds a list of client.ListOption based on the
 // provided GenericWebhookTarget's selectors. The returned ListOptions can be
 // used to list Kubernetes resources that match the target's selection criteria.
-func (g *genericWebhookReceiver) buildListOptionsForTarget(
+func (b *baseWebhookReceiver) buildListOptionsForTarget(
 	t kargoapi.GenericWebhookTargetSelectionCriteria,
 	env map[string]any,
 ) ([]client.ListOption, error) {
 	var listOpts []client.ListOption
-	if g.project != "" {
-		listOpts = append(listOpts, client.InNamespace(g.project))
+	if b.project != "" {
+		listOpts = append(listOpts, client.InNamespace(b.project))
 	}
 	indexSelectorListOpts, err := newListOptionsForIndexSelector(t.IndexSelector, env)
 	if err != nil {
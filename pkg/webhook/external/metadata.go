@@ -0,0 +1,136 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/expressions"
+	"github.com/akuity/kargo/pkg/kubeclient"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+// evalMetadata evaluates each of the provided parameters as an expression,
+// returning a map of the results keyed by parameter name. Unlike other
+// parameters, results are not required to be strings. They may be of any type
+// that can be serialized as JSON.
+func evalMetadata(params map[string]string, env map[string]any) (map[string]any, error) {
+	metadata := make(map[string]any, len(params))
+	for key, expr := range params {
+		value, err := expressions.EvaluateTemplate(expr, env)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate expression for metadata key %q: %w", key, err)
+		}
+		if _, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("result of expression for metadata key %q is not serializable: %w", key, err)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// setMetadata upserts the provided metadata into the status of each of the
+// Stages and/or Freight in the provided list.
+func setMetadata(
+	ctx context.Context,
+	c client.Client,
+	objList []client.Object,
+	metadata map[string]any,
+) ([]selectedTarget, string, string) {
+	logger := logging.LoggerFromContext(ctx)
+	selectedTargets := make([]selectedTarget, len(objList))
+	var successCount, failureCount int
+	for i, obj := range objList {
+		objKey := client.ObjectKeyFromObject(obj)
+		objLogger := logger.WithValues(
+			"namespace", objKey.Namespace,
+			"name", objKey.Name,
+			"kind", metadataTargetKind(obj),
+		)
+		selectedTargets[i] = selectedTarget{
+			Namespace: objKey.Namespace,
+			Name:      objKey.Name,
+		}
+		if err := setObjectMetadata(ctx, c, obj, metadata); err != nil {
+			objLogger.Error(err, "error setting metadata")
+			failureCount++
+			selectedTargets[i].Message = err.Error()
+		} else {
+			objLogger.Debug("successfully set metadata")
+			successCount++
+			selectedTargets[i].Success = true
+		}
+	}
+	result := getResult(len(objList), successCount, failureCount)
+	summary := fmt.Sprintf("Set metadata on %d of %d selected resources",
+		successCount,
+		len(objList),
+	)
+	return selectedTargets, result, summary
+}
+
+// metadataTargetKind returns the kind of the provided object for logging
+// purposes. Typed objects retrieved through a client do not have their kind
+// populated, so it is inferred from the object's type.
+func metadataTargetKind(obj client.Object) string {
+	switch obj.(type) {
+	case *kargoapi.Stage:
+		return "Stage"
+	case *kargoapi.Freight:
+		return "Freight"
+	default:
+		return fmt.Sprintf("%T", obj)
+	}
+}
+
+// setObjectMetadata upserts the provided metadata into the status of the
+// provided Stage or Freight. All keys are validated against a copy of the
+// status before anything is patched, so the metadata is either set in its
+// entirety or not at all.
+func setObjectMetadata(
+	ctx context.Context,
+	c client.Client,
+	obj client.Object,
+	metadata map[string]any,
+) error {
+	switch o := obj.(type) {
+	case *kargoapi.Stage:
+		if err := upsertMetadata(o.Status.DeepCopy().UpsertMetadata, metadata); err != nil {
+			return err
+		}
+		if err := kubeclient.PatchStatus(ctx, c, o, func(status *kargoapi.StageStatus) {
+			_ = upsertMetadata(status.UpsertMetadata, metadata)
+		}); err != nil {
+			return fmt.Errorf("error patching Stage status: %w", err)
+		}
+	case *kargoapi.Freight:
+		if err := upsertMetadata(o.Status.DeepCopy().UpsertMetadata, metadata); err != nil {
+			return err
+		}
+		if err := kubeclient.PatchStatus(ctx, c, o, func(status *kargoapi.FreightStatus) {
+			_ = upsertMetadata(status.UpsertMetadata, metadata)
+		}); err != nil {
+			return fmt.Errorf("error patching Freight status: %w", err)
+		}
+	default:
+		return fmt.Errorf("metadata cannot be set on %T", obj)
+	}
+	return nil
+}
+
+// upsertMetadata upserts each of the provided metadata entries using the
+// provided function. Keys are upserted in a deterministic order so that, should
+// one fail, the error returned is predictable.
+func upsertMetadata(upsert func(string, any) error, metadata map[string]any) error {
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		if err := upsert(key, metadata[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
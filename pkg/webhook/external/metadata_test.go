@@ -0,0 +1,145 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestEvalMetadata(t *testing.T) {
+	env := map[string]any{
+		"request": map[string]any{
+			"body": map[string]any{
+				"ticket": "CHG-123",
+				"count":  float64(3),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		params     map[string]string
+		assertions func(*testing.T, map[string]any, error)
+	}{
+		{
+			name:   "invalid expression",
+			params: map[string]string{"ticket": "${{ request.body.ticket + }}"},
+			assertions: func(t *testing.T, _ map[string]any, err error) {
+				require.ErrorContains(t, err, `failed to evaluate expression for metadata key "ticket"`)
+			},
+		},
+		{
+			name: "success",
+			params: map[string]string{
+				"ticket":   "${{ request.body.ticket }}",
+				"count":    "${{ request.body.count }}",
+				"approved": "${{ true }}",
+			},
+			assertions: func(t *testing.T, metadata map[string]any, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]any{
+						"ticket":   "CHG-123",
+						"count":    float64(3),
+						"approved": true,
+					},
+					metadata,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metadata, err := evalMetadata(testCase.params, env)
+			testCase.assertions(t, metadata, err)
+		})
+	}
+}
+
+func TestSetMetadata(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	const testProject = "fake-project"
+
+	stage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject,
+			Name:      "fake-stage",
+		},
+	}
+	freight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject,
+			Name:      "fake-freight",
+		},
+	}
+	warehouse := &kargoapi.Warehouse{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject,
+			Name:      "fake-warehouse",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).
+		WithObjects(stage, freight, warehouse).
+		WithStatusSubresource(&kargoapi.Stage{}, &kargoapi.Freight{}).
+		Build()
+
+	targets, result, summary := setMetadata(
+		t.Context(),
+		c,
+		[]client.Object{freight, stage, warehouse},
+		map[string]any{"ticket": "CHG-123", "count": 3},
+	)
+	require.Equal(t, resultPartialSuccess, result)
+	require.Equal(t, "Set metadata on 2 of 3 selected resources", summary)
+	require.True(t, targets[0].Success)
+	require.True(t, targets[1].Success)
+	require.False(t, targets[2].Success)
+	require.Equal(t, "metadata cannot be set on *v1alpha1.Warehouse", targets[2].Message)
+
+	updatedStage := &kargoapi.Stage{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(stage), updatedStage))
+	var ticket string
+	ok, err := updatedStage.Status.GetMetadata("ticket", &ticket)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "CHG-123", ticket)
+
+	updatedFreight := &kargoapi.Freight{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(freight), updatedFreight))
+	var count int
+	ok, err = updatedFreight.Status.GetMetadata("count", &count)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 3, count)
+
+	// Metadata that cannot be set in its entirety is not set at all
+	targets, result, summary = setMetadata(
+		t.Context(),
+		c,
+		[]client.Object{updatedStage},
+		map[string]any{"approved": true, "unserializable": make(chan int)},
+	)
+	require.Equal(t, resultFailure, result)
+	require.Equal(t, "Set metadata on 0 of 1 selected resources", summary)
+	require.False(t, targets[0].Success)
+	require.NotEmpty(t, targets[0].Message)
+
+	unchangedStage := &kargoapi.Stage{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(stage), unchangedStage))
+	ok, err = unchangedStage.Status.GetMetadata("approved", new(bool))
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = unchangedStage.Status.GetMetadata("ticket", &ticket)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
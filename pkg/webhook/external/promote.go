@@ -0,0 +1,259 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/api"
+	"github.com/akuity/kargo/pkg/kubeclient"
+	"github.com/akuity/kargo/pkg/logging"
+)
+
+const (
+	// promoteParamFreight is the name of the action parameter specifying the
+	// name or alias of the Freight to promote or approve.
+	promoteParamFreight = "freight"
+	// promoteParamOrigin is the name of the action parameter specifying the
+	// origin (e.g. "Warehouse/my-warehouse") from which the auto-promotion
+	// candidate Freight should be selected and promoted.
+	promoteParamOrigin = "origin"
+)
+
+// promoteStages promotes Freight to each of the Stages in the provided list.
+// Exactly one of freight (a Freight name or alias) or origin must be
+// non-empty. When origin is specified, the Freight promoted to each Stage is
+// whichever Freight from that origin is that Stage's auto-promotion candidate
+// at the time the Promotion is created. Stages that select Targets have
+// Freight fanned out to them via a PromotionRequest instead of a Promotion.
+func promoteStages(
+	ctx context.Context,
+	c client.Client,
+	actor string,
+	objList []client.Object,
+	freight string,
+	origin string,
+) ([]selectedTarget, string, string) {
+	logger := logging.LoggerFromContext(ctx)
+	freights := newFreightResolver(c)
+	selectedTargets := make([]selectedTarget, len(objList))
+	var successCount, failureCount int
+	for i, obj := range objList {
+		objKey := client.ObjectKeyFromObject(obj)
+		objLogger := logger.WithValues(
+			"namespace", objKey.Namespace,
+			"name", objKey.Name,
+		)
+		selectedTargets[i] = selectedTarget{
+			Namespace: objKey.Namespace,
+			Name:      objKey.Name,
+		}
+		if err := promoteStage(ctx, c, freights, actor, obj, freight, origin); err != nil {
+			objLogger.Error(err, "error promoting")
+			failureCount++
+			selectedTargets[i].Message = err.Error()
+		} else {
+			objLogger.Debug("successfully promoted to Stage")
+			successCount++
+			selectedTargets[i].Success = true
+		}
+	}
+	result := getResult(len(objList), successCount, failureCount)
+	summary := fmt.Sprintf("Promoted to %d of %d selected Stages",
+		successCount,
+		len(objList),
+	)
+	return selectedTargets, result, summary
+}
+
+func promoteStage(
+	ctx context.Context,
+	c client.Client,
+	freights *freightResolver,
+	actor string,
+	obj client.Object,
+	freightNameOrAlias string,
+	originStr string,
+) error {
+	stage, ok := obj.(*kargoapi.Stage)
+	if !ok {
+		return fmt.Errorf("%T is not a Stage", obj)
+	}
+
+	if originStr != "" {
+		// Promotion by origin relies on the Promotion defaulting webhook to
+		// resolve the origin to a candidate Freight at admission time.
+		// PromotionRequests have no such webhook, so there is nothing to resolve
+		// the origin.
+		if api.IsTargetAware(stage) {
+			return errors.New("promotion by origin is not supported for a Stage that selects Targets")
+		}
+		origin, err := kargoapi.ParseFreightOrigin(originStr)
+		if err != nil {
+			return err
+		}
+		promotion := api.NewMinimalPromotionForOrigin(stage, origin)
+		api.SetCreateActorAnnotation(promotion, actor)
+		return c.Create(ctx, promotion)
+	}
+
+	freight, err := freights.get(ctx, stage.Namespace, freightNameOrAlias)
+	if err != nil {
+		return err
+	}
+	if !stage.IsFreightAvailable(freight) {
+		return fmt.Errorf("Freight %q is not available to Stage", freight.Name)
+	}
+
+	// A Stage that selects Targets fans Freight out to them via a
+	// PromotionRequest rather than promoting to itself with a single Promotion.
+	if api.IsTargetAware(stage) {
+		promotionRequest, err := api.NewPromotionRequest(ctx, c, stage, freight.Name)
+		if err != nil {
+			return err
+		}
+		api.SetCreateActorAnnotation(promotionRequest, actor)
+		return c.Create(ctx, promotionRequest)
+	}
+
+	promotion := api.NewMinimalPromotion(stage, freight.Name)
+	api.SetCreateActorAnnotation(promotion, actor)
+	return c.Create(ctx, promotion)
+}
+
+// approveFreight approves the Freight specified by name or alias for each of
+// the Stages in the provided list. Freight that is already approved for a
+// Stage is left untouched and counted as a success.
+func approveFreight(
+	ctx context.Context,
+	c client.Client,
+	objList []client.Object,
+	freightNameOrAlias string,
+) ([]selectedTarget, string, string) {
+	logger := logging.LoggerFromContext(ctx)
+	freights := newFreightResolver(c)
+	selectedTargets := make([]selectedTarget, len(objList))
+	var successCount, failureCount int
+	for i, obj := range objList {
+		objKey := client.ObjectKeyFromObject(obj)
+		objLogger := logger.WithValues(
+			"namespace", objKey.Namespace,
+			"name", objKey.Name,
+		)
+		selectedTargets[i] = selectedTarget{
+			Namespace: objKey.Namespace,
+			Name:      objKey.Name,
+		}
+		if err := approveFreightForStage(ctx, c, freights, obj, freightNameOrAlias); err != nil {
+			objLogger.Error(err, "error approving Freight")
+			failureCount++
+			selectedTargets[i].Message = err.Error()
+		} else {
+			objLogger.Debug("successfully approved Freight for Stage")
+			successCount++
+			selectedTargets[i].Success = true
+		}
+	}
+	result := getResult(len(objList), successCount, failureCount)
+	summary := fmt.Sprintf("Approved Freight for %d of %d selected Stages",
+		successCount,
+		len(objList),
+	)
+	return selectedTargets, result, summary
+}
+
+func approveFreightForStage(
+	ctx context.Context,
+	c client.Client,
+	freights *freightResolver,
+	obj client.Object,
+	freightNameOrAlias string,
+) error {
+	stage, ok := obj.(*kargoapi.Stage)
+	if !ok {
+		return fmt.Errorf("%T is not a Stage", obj)
+	}
+	freight, err := freights.get(ctx, stage.Namespace, freightNameOrAlias)
+	if err != nil {
+		return err
+	}
+	if !stage.RequestsFreightFromOrigin(freight.Origin) {
+		return fmt.Errorf(
+			"Stage does not request Freight from origin %q",
+			freight.Origin.String(),
+		)
+	}
+	if freight.IsApprovedFor(stage.Name) {
+		return nil
+	}
+	// Approvals made on behalf of a webhook cannot be attributed to a user, so
+	// they can neither count toward a quorum nor be checked against commit
+	// authors.
+	policy, err := api.GetApprovalPolicy(ctx, c, stage.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		return errors.New(
+			"Stage has an approval policy; Freight must be approved for it by users",
+		)
+	}
+	approvedAt := time.Now()
+	if err = kubeclient.PatchStatus(ctx, c, freight, func(status *kargoapi.FreightStatus) {
+		status.AddApprovedStage(stage.Name, approvedAt)
+	}); err != nil {
+		return fmt.Errorf("error patching Freight %q status: %w", freight.Name, err)
+	}
+	// Keep the cached copy current so that subsequent Stages selected by the
+	// same action observe this approval.
+	freight.Status.AddApprovedStage(stage.Name, approvedAt)
+	return nil
+}
+
+// freightResolver looks up Freight by name or alias, caching the results so
+// that an action targeting many Stages in the same namespace looks each piece
+// of Freight up only once.
+type freightResolver struct {
+	client client.Client
+	cache  map[types.NamespacedName]*kargoapi.Freight
+}
+
+func newFreightResolver(c client.Client) *freightResolver {
+	return &freightResolver{
+		client: c,
+		cache:  map[types.NamespacedName]*kargoapi.Freight{},
+	}
+}
+
+// get returns the Freight in the specified namespace whose name or, failing
+// that, whose alias matches nameOrAlias. An error is returned if no such
+// Freight exists.
+func (f *freightResolver) get(
+	ctx context.Context,
+	namespace string,
+	nameOrAlias string,
+) (*kargoapi.Freight, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: nameOrAlias}
+	if freight, ok := f.cache[key]; ok {
+		return freight, nil
+	}
+	freight, err := api.GetFreight(ctx, f.client, key)
+	if err != nil {
+		return nil, err
+	}
+	if freight == nil {
+		if freight, err = api.GetFreightByAlias(ctx, f.client, namespace, nameOrAlias); err != nil {
+			return nil, err
+		}
+	}
+	if freight == nil {
+		return nil, fmt.Errorf("Freight %q not found", nameOrAlias)
+	}
+	f.cache[key] = freight
+	return freight, nil
+}
@@ -0,0 +1,298 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const (
+	testPromoteProject = "fake-project"
+	testPromoteActor   = "webhook-receiver:fake-receiver"
+)
+
+var testPromoteOrigin = kargoapi.FreightOrigin{
+	Kind: kargoapi.FreightOriginKindWarehouse,
+	Name: "fake-warehouse",
+}
+
+func newTestPromoteStage(name string, direct bool) *kargoapi.Stage {
+	return &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testPromoteProject,
+			Name:      name,
+		},
+		Spec: kargoapi.StageSpec{
+			RequestedFreight: []kargoapi.FreightRequest{{
+				Origin:  testPromoteOrigin,
+				Sources: kargoapi.FreightSources{Direct: direct},
+			}},
+		},
+	}
+}
+
+func newTestPromoteFreight() *kargoapi.Freight {
+	return &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testPromoteProject,
+			Name:      "fake-freight",
+			Labels:    map[string]string{kargoapi.LabelKeyAlias: "fake-alias"},
+		},
+		Origin: testPromoteOrigin,
+	}
+}
+
+func TestPromoteStages(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		objects    []client.Object
+		freight    string
+		origin     string
+		assertions func(*testing.T, client.Client, []selectedTarget, string, string)
+	}{
+		{
+			name: "Freight not found",
+			client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(newTestPromoteStage("fake-stage", true)).Build(),
+			objects: []client.Object{newTestPromoteStage("fake-stage", true)},
+			freight: "nonexistent",
+			assertions: func(t *testing.T, _ client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultFailure, result)
+				require.Equal(t, "Promoted to 0 of 1 selected Stages", summary)
+				require.Len(t, targets, 1)
+				require.False(t, targets[0].Success)
+				require.Contains(t, targets[0].Message, `Freight "nonexistent" not found`)
+			},
+		},
+		{
+			name: "partial success",
+			client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+				newTestPromoteFreight(),
+				newTestPromoteStage("direct-stage", true),
+				newTestPromoteStage("indirect-stage", false),
+			).Build(),
+			objects: []client.Object{
+				newTestPromoteStage("direct-stage", true),
+				newTestPromoteStage("indirect-stage", false),
+			},
+			// Freight may be referenced by alias
+			freight: "fake-alias",
+			assertions: func(t *testing.T, c client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultPartialSuccess, result)
+				require.Equal(t, "Promoted to 1 of 2 selected Stages", summary)
+				require.Equal(
+					t,
+					[]selectedTarget{
+						{Namespace: testPromoteProject, Name: "direct-stage", Success: true},
+						{
+							Namespace: testPromoteProject,
+							Name:      "indirect-stage",
+							Message:   `Freight "fake-freight" is not available to Stage`,
+						},
+					},
+					targets,
+				)
+				promos := &kargoapi.PromotionList{}
+				require.NoError(t, c.List(t.Context(), promos))
+				require.Len(t, promos.Items, 1)
+				require.Equal(t, "direct-stage", promos.Items[0].Spec.Stage)
+				require.Equal(t, "fake-freight", promos.Items[0].Spec.Freight)
+				require.Equal(
+					t,
+					testPromoteActor,
+					promos.Items[0].Annotations[kargoapi.AnnotationKeyCreateActor],
+				)
+			},
+		},
+		{
+			name: "promote by origin",
+			client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(newTestPromoteStage("fake-stage", false)).Build(),
+			objects: []client.Object{newTestPromoteStage("fake-stage", false)},
+			origin:  "Warehouse/fake-warehouse",
+			assertions: func(t *testing.T, c client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultSuccess, result)
+				require.Equal(t, "Promoted to 1 of 1 selected Stages", summary)
+				require.True(t, targets[0].Success)
+				promos := &kargoapi.PromotionList{}
+				require.NoError(t, c.List(t.Context(), promos))
+				require.Len(t, promos.Items, 1)
+				require.Equal(t, &testPromoteOrigin, promos.Items[0].Spec.Origin)
+				require.Empty(t, promos.Items[0].Spec.Freight)
+			},
+		},
+		{
+			name:    "invalid origin",
+			client:  fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			objects: []client.Object{newTestPromoteStage("fake-stage", false)},
+			origin:  "bogus",
+			assertions: func(t *testing.T, _ client.Client, targets []selectedTarget, result, _ string) {
+				require.Equal(t, resultFailure, result)
+				require.Contains(t, targets[0].Message, "invalid Freight origin string")
+			},
+		},
+		{
+			name: "error creating Promotion",
+			client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(newTestPromoteFreight()).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+						return errors.New("something went wrong")
+					},
+				}).Build(),
+			objects: []client.Object{newTestPromoteStage("fake-stage", true)},
+			freight: "fake-freight",
+			assertions: func(t *testing.T, _ client.Client, targets []selectedTarget, result, _ string) {
+				require.Equal(t, resultFailure, result)
+				require.Equal(t, "something went wrong", targets[0].Message)
+			},
+		},
+		{
+			name:    "target is not a Stage",
+			client:  fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			objects: []client.Object{newTestPromoteFreight()},
+			freight: "fake-freight",
+			assertions: func(t *testing.T, _ client.Client, targets []selectedTarget, result, _ string) {
+				require.Equal(t, resultFailure, result)
+				require.Equal(t, "*v1alpha1.Freight is not a Stage", targets[0].Message)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			targets, result, summary := promoteStages(
+				t.Context(),
+				testCase.client,
+				testPromoteActor,
+				testCase.objects,
+				testCase.freight,
+				testCase.origin,
+			)
+			testCase.assertions(t, testCase.client, targets, result, summary)
+		})
+	}
+}
+
+func TestApproveFreight(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(testScheme))
+
+	otherOriginStage := newTestPromoteStage("other-stage", false)
+	otherOriginStage.Spec.RequestedFreight[0].Origin.Name = "other-warehouse"
+
+	approvedFreight := newTestPromoteFreight()
+	approvedFreight.Status.AddApprovedStage("approved-stage", metav1.Now().Time)
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		objects    []client.Object
+		assertions func(*testing.T, client.Client, []selectedTarget, string, string)
+	}{
+		{
+			name:    "Freight not found",
+			client:  fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			objects: []client.Object{newTestPromoteStage("fake-stage", false)},
+			assertions: func(t *testing.T, _ client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultFailure, result)
+				require.Equal(t, "Approved Freight for 0 of 1 selected Stages", summary)
+				require.Contains(t, targets[0].Message, `Freight "fake-freight" not found`)
+			},
+		},
+		{
+			name: "partial success",
+			client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(approvedFreight).
+				WithStatusSubresource(&kargoapi.Freight{}).
+				Build(),
+			objects: []client.Object{
+				newTestPromoteStage("approved-stage", false),
+				newTestPromoteStage("fake-stage", false),
+				otherOriginStage,
+			},
+			assertions: func(t *testing.T, c client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultPartialSuccess, result)
+				require.Equal(t, "Approved Freight for 2 of 3 selected Stages", summary)
+				require.True(t, targets[0].Success)
+				require.True(t, targets[1].Success)
+				require.False(t, targets[2].Success)
+				require.Equal(
+					t,
+					`Stage does not request Freight from origin "Warehouse/fake-warehouse"`,
+					targets[2].Message,
+				)
+				freight := &kargoapi.Freight{}
+				require.NoError(
+					t,
+					c.Get(t.Context(), client.ObjectKeyFromObject(approvedFreight), freight),
+				)
+				require.True(t, freight.IsApprovedFor("approved-stage"))
+				require.True(t, freight.IsApprovedFor("fake-stage"))
+				require.False(t, freight.IsApprovedFor("other-stage"))
+			},
+		},
+		{
+			name: "Stage with approval policy",
+			client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithObjects(
+					newTestPromoteFreight(),
+					&kargoapi.ProjectConfig{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: testPromoteProject,
+							Name:      testPromoteProject,
+						},
+						Spec: kargoapi.ProjectConfigSpec{
+							PromotionPolicies: []kargoapi.PromotionPolicy{{
+								StageSelector: &kargoapi.PromotionPolicySelector{Name: "prod"},
+								Approval:      &kargoapi.ApprovalPolicy{RequiredApprovals: 2},
+							}},
+						},
+					},
+				).
+				WithStatusSubresource(&kargoapi.Freight{}).
+				Build(),
+			objects: []client.Object{
+				newTestPromoteStage("prod", false),
+				newTestPromoteStage("fake-stage", false),
+			},
+			assertions: func(t *testing.T, c client.Client, targets []selectedTarget, result, summary string) {
+				require.Equal(t, resultPartialSuccess, result)
+				require.Equal(t, "Approved Freight for 1 of 2 selected Stages", summary)
+				require.False(t, targets[0].Success)
+				require.Contains(t, targets[0].Message, "Stage has an approval policy")
+				require.True(t, targets[1].Success)
+				freight := &kargoapi.Freight{}
+				require.NoError(
+					t,
+					c.Get(t.Context(), client.ObjectKeyFromObject(newTestPromoteFreight()), freight),
+				)
+				require.False(t, freight.IsApprovedFor("prod"))
+				require.True(t, freight.IsApprovedFor("fake-stage"))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			targets, result, summary := approveFreight(
+				t.Context(),
+				testCase.client,
+				testCase.objects,
+				"fake-freight",
+			)
+			testCase.assertions(t, testCase.client, targets, result, summary)
+		})
+	}
+}
@@ -2,10 +2,12 @@ package external
 
 import (
 	"fmt"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/pattern"
 )
 
 func ValidateWebhookReceivers(
@@ -24,6 +26,11 @@ func ValidateWebhookReceivers(
 				validateGenericConfig(i, r.Generic)...,
 			)
 		}
+		if r.CloudEvents != nil {
+			errs = append(errs,
+				validateCloudEventsConfig(i, r.CloudEvents)...,
+			)
+		}
 	}
 	return errs
 }
@@ -59,6 +66,18 @@ func validateMutuallyExclusive(
 		if r.Gitea != nil {
 			receivers = append(receivers, "Gitea")
 		}
+		if r.ECR != nil {
+			receivers = append(receivers, "ECR")
+		}
+		if r.ArtifactRegistry != nil {
+			receivers = append(receivers, "ArtifactRegistry")
+		}
+		if r.AzureEventGrid != nil {
+			receivers = append(receivers, "AzureEventGrid")
+		}
+		if r.CloudEvents != nil {
+			receivers = append(receivers, "CloudEvents")
+		}
 		if r.Generic != nil {
 			receivers = append(receivers, "Generic")
 		}
@@ -106,6 +125,117 @@ func validateGenericConfig(
 	var errs field.ErrorList
 	for i, action := range cfg.Actions {
 		errs = append(errs, validateGenericTargets(cfgIndex, i, action.TargetSelectionCriteria)...)
+		errs = append(errs, validateGenericAction(cfgIndex, i, action)...)
+	}
+	return errs
+}
+
+func validateCloudEventsConfig(
+	cfgIndex int,
+	cfg *kargoapi.CloudEventsWebhookReceiverConfig,
+) field.ErrorList {
+	var errs field.ErrorList
+	supportedKinds := []kargoapi.GenericWebhookTargetKind{
+		kargoapi.GenericWebhookTargetKindWarehouse,
+		kargoapi.GenericWebhookTargetKindStage,
+	}
+	for i, route := range cfg.Routes {
+		routePath := field.NewPath(fmt.Sprintf(
+			"spec.webhookReceivers[%d].cloudEvents.routes[%d]",
+			cfgIndex, i,
+		))
+		for _, p := range []struct {
+			name    string
+			pattern string
+		}{
+			{name: "type", pattern: route.Type},
+			{name: "source", pattern: route.Source},
+			{name: "subject", pattern: route.Subject},
+		} {
+			if _, err := pattern.ParseNamePattern(p.pattern); err != nil {
+				errs = append(errs, field.Invalid(routePath.Child(p.name), p.pattern, err.Error()))
+			}
+		}
+		for j, target := range route.TargetSelectionCriteria {
+			targetPath := routePath.Child("targetSelectionCriteria").Index(j)
+			if selectionTargetCriteriaIsEmpty(&target) {
+				errs = append(errs, field.Invalid(
+					targetPath,
+					target,
+					"at least one of name, labelSelector, or indexSelector must be specified for target",
+				))
+			}
+			if !slices.Contains(supportedKinds, target.Kind) {
+				errs = append(errs, field.NotSupported(targetPath.Child("kind"), target.Kind, supportedKinds))
+			}
+		}
+	}
+	return errs
+}
+
+// validateGenericAction validates that the targets and parameters of an action
+// are appropriate for its type.
+func validateGenericAction(
+	cfgIndex, actionIndex int,
+	action kargoapi.GenericWebhookAction,
+) []*field.Error {
+	actionPath := field.NewPath(fmt.Sprintf(
+		"spec.webhookReceivers[%d].generic.actions[%d]",
+		cfgIndex, actionIndex,
+	))
+	var supportedKinds []kargoapi.GenericWebhookTargetKind
+	var errs field.ErrorList
+	switch action.ActionType {
+	case kargoapi.GenericWebhookActionTypePromote:
+		supportedKinds = []kargoapi.GenericWebhookTargetKind{kargoapi.GenericWebhookTargetKindStage}
+		_, hasFreight := action.Parameters["freight"]
+		_, hasOrigin := action.Parameters["origin"]
+		if hasFreight == hasOrigin {
+			errs = append(errs, field.Invalid(
+				actionPath.Child("parameters"),
+				action.Parameters,
+				"exactly one of freight or origin must be specified for Promote action",
+			))
+		}
+	case kargoapi.GenericWebhookActionTypeApprove:
+		supportedKinds = []kargoapi.GenericWebhookTargetKind{kargoapi.GenericWebhookTargetKindStage}
+		if _, ok := action.Parameters["freight"]; !ok {
+			errs = append(errs, field.Required(
+				actionPath.Child("parameters").Key("freight"),
+				"freight must be specified for Approve action",
+			))
+		}
+	case kargoapi.GenericWebhookActionTypeSetMetadata:
+		supportedKinds = []kargoapi.GenericWebhookTargetKind{
+			kargoapi.GenericWebhookTargetKindStage,
+			kargoapi.GenericWebhookTargetKindFreight,
+		}
+		if len(action.Parameters) == 0 {
+			errs = append(errs, field.Required(
+				actionPath.Child("parameters"),
+				"at least one parameter must be specified for SetMetadata action",
+			))
+		}
+		for key := range action.Parameters {
+			if key == "" {
+				errs = append(errs, field.Invalid(
+					actionPath.Child("parameters"),
+					key,
+					"metadata keys must not be empty",
+				))
+			}
+		}
+	default:
+		return nil
+	}
+	for i, target := range action.TargetSelectionCriteria {
+		if !slices.Contains(supportedKinds, target.Kind) {
+			errs = append(errs, field.NotSupported(
+				actionPath.Child("targetSelectionCriteria").Index(i).Child("kind"),
+				target.Kind,
+				supportedKinds,
+			))
+		}
 	}
 	return errs
 }
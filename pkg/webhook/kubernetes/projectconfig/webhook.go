@@ -18,6 +18,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/pkg/pattern"
+	"github.com/akuity/kargo/pkg/rolemapping"
 	"github.com/akuity/kargo/pkg/webhook/kubernetes/external"
 )
 
@@ -140,9 +141,49 @@ func (w *webhook) validateSpec(
 	); errs != nil {
 		fieldErrs = append(fieldErrs, errs...)
 	}
+
+	if errs := w.validateRoleMappings(
+		f.Child("roleMappings"),
+		spec.RoleMappings,
+	); errs != nil {
+		fieldErrs = append(fieldErrs, errs...)
+	}
 	return fieldErrs
 }
 
+func (w *webhook) validateRoleMappings(
+	f *field.Path,
+	roleMappings []kargoapi.RoleMapping,
+) field.ErrorList {
+	var errs field.ErrorList
+	for i, mapping := range roleMappings {
+		if len(mapping.Groups) == 0 && len(mapping.EmailDomains) == 0 && len(mapping.Expressions) == 0 {
+			errs = append(errs, field.Required(
+				f.Index(i),
+				"at least one of groups, emailDomains, or expressions must be specified",
+			))
+		}
+		for j, expression := range mapping.Expressions {
+			if len(expression) > rolemapping.MaxExpressionLength {
+				errs = append(errs, field.TooLong(
+					f.Index(i).Child("expressions").Index(j),
+					expression,
+					rolemapping.MaxExpressionLength,
+				))
+				continue
+			}
+			if _, err := rolemapping.CompileExpression(expression); err != nil {
+				errs = append(errs, field.Invalid(
+					f.Index(i).Child("expressions").Index(j),
+					expression,
+					err.Error(),
+				))
+			}
+		}
+	}
+	return errs
+}
+
 func (w *webhook) validatePromotionPolicies(
 	f *field.Path,
 	promotionPolicies []kargoapi.PromotionPolicy,
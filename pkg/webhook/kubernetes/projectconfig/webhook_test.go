@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,11 +14,13 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/pkg/rolemapping"
 )
 
 func Test_webhook_ValidateCreate(t *testing.T) {
@@ -79,6 +82,59 @@ func Test_webhook_ValidateCreate(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			name: "invalid spec: invalid role mappings",
+			projectConfig: &kargoapi.ProjectConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testProjectName,
+					Namespace: testProjectName,
+				},
+				Spec: kargoapi.ProjectConfigSpec{
+					RoleMappings: []kargoapi.RoleMapping{
+						{
+							Role:        "developer",
+							Groups:      []string{"devops"},
+							Expressions: []string{`claims.department == "payments"`},
+						},
+						{Role: "viewer"},
+						{
+							Role:        "admin",
+							Expressions: []string{`claims.department ==`},
+						},
+						{
+							Role: "promoter",
+							Expressions: []string{
+								`claims.department == "` + strings.Repeat("x", rolemapping.MaxExpressionLength) + `"`,
+							},
+						},
+					},
+				},
+			},
+			objects: []client.Object{testNs},
+			assertions: func(t *testing.T, warnings admission.Warnings, err error) {
+				assert.Empty(t, warnings)
+				require.Error(t, err)
+
+				var statusErr *apierrors.StatusError
+				require.True(t, errors.As(err, &statusErr))
+				require.Len(t, statusErr.ErrStatus.Details.Causes, 3)
+
+				assert.Equal(t, "spec.roleMappings[1]", statusErr.ErrStatus.Details.Causes[0].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueRequired, statusErr.ErrStatus.Details.Causes[0].Type)
+				assert.Equal(
+					t,
+					"spec.roleMappings[2].expressions[0]",
+					statusErr.ErrStatus.Details.Causes[1].Field,
+				)
+				assert.Equal(t, metav1.CauseTypeFieldValueInvalid, statusErr.ErrStatus.Details.Causes[1].Type)
+				assert.Equal(
+					t,
+					"spec.roleMappings[3].expressions[0]",
+					statusErr.ErrStatus.Details.Causes[2].Field,
+				)
+				assert.Equal(t, metav1.CauseType(field.ErrorTypeTooLong), statusErr.ErrStatus.Details.Causes[2].Type)
+			},
+		},
 		{
 			name: "invalid metadata: name does not match namespace",
 			projectConfig: &kargoapi.ProjectConfig{
@@ -637,6 +693,144 @@ func Test_webhook_ValidateCreate(t *testing.T) {
 					"at least one of name, labelSelector, or indexSelector must be specified for target")
 			},
 		},
+		{
+			name: "generic webhook receiver action misconfiguration",
+			projectConfig: &kargoapi.ProjectConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testProjectName,
+					Namespace: testProjectName,
+				},
+				Spec: kargoapi.ProjectConfigSpec{
+					WebhookReceivers: []kargoapi.WebhookReceiverConfig{
+						{
+							Name: "my-generic-webhook-receiver",
+							Generic: &kargoapi.GenericWebhookReceiverConfig{
+								Actions: []kargoapi.GenericWebhookAction{
+									{
+										ActionType: kargoapi.GenericWebhookActionTypePromote,
+										// Promote requires exactly one of freight or origin.
+										Parameters: map[string]string{
+											"freight": "${{ request.body.freight }}",
+											"origin":  "Warehouse/my-warehouse",
+										},
+										TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{
+											{
+												// Promote only applies to Stages.
+												Kind: kargoapi.GenericWebhookTargetKindWarehouse,
+												Name: "my-warehouse",
+											},
+										},
+									},
+									{
+										ActionType: kargoapi.GenericWebhookActionTypeApprove,
+										// Approve requires freight.
+										TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{
+											{
+												Kind: kargoapi.GenericWebhookTargetKindStage,
+												Name: "my-stage",
+											},
+										},
+									},
+									{
+										ActionType: kargoapi.GenericWebhookActionTypeSetMetadata,
+										// SetMetadata requires at least one parameter.
+										TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{
+											{
+												Kind: kargoapi.GenericWebhookTargetKindFreight,
+												Name: "my-freight",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			objects: []client.Object{testNs},
+			assertions: func(t *testing.T, warnings admission.Warnings, err error) {
+				assert.Empty(t, warnings)
+				require.Error(t, err)
+
+				var statusErr *apierrors.StatusError
+				require.True(t, errors.As(err, &statusErr))
+
+				assert.Equal(t, metav1.StatusReasonInvalid, statusErr.ErrStatus.Reason)
+				assert.Equal(t, 4, len(statusErr.ErrStatus.Details.Causes))
+
+				// Sort errors for consistent testing
+				sort.Slice(statusErr.ErrStatus.Details.Causes, func(i, j int) bool {
+					return statusErr.ErrStatus.Details.Causes[i].Field < statusErr.ErrStatus.Details.Causes[j].Field
+				})
+
+				assert.Equal(t, "spec.webhookReceivers[0].generic.actions[0].parameters",
+					statusErr.ErrStatus.Details.Causes[0].Field)
+				assert.Contains(t, statusErr.ErrStatus.Details.Causes[0].Message,
+					"exactly one of freight or origin must be specified for Promote action")
+				assert.Equal(t, "spec.webhookReceivers[0].generic.actions[0].targetSelectionCriteria[0].kind",
+					statusErr.ErrStatus.Details.Causes[1].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, statusErr.ErrStatus.Details.Causes[1].Type)
+				assert.Equal(t, "spec.webhookReceivers[0].generic.actions[1].parameters[freight]",
+					statusErr.ErrStatus.Details.Causes[2].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueRequired, statusErr.ErrStatus.Details.Causes[2].Type)
+				assert.Equal(t, "spec.webhookReceivers[0].generic.actions[2].parameters",
+					statusErr.ErrStatus.Details.Causes[3].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueRequired, statusErr.ErrStatus.Details.Causes[3].Type)
+			},
+		},
+		{
+			name: "cloudevents webhook receiver misconfiguration",
+			projectConfig: &kargoapi.ProjectConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testProjectName,
+					Namespace: testProjectName,
+				},
+				Spec: kargoapi.ProjectConfigSpec{
+					WebhookReceivers: []kargoapi.WebhookReceiverConfig{
+						{
+							Name: "my-cloudevents-webhook-receiver",
+							CloudEvents: &kargoapi.CloudEventsWebhookReceiverConfig{
+								Routes: []kargoapi.CloudEventsWebhookRoute{
+									{
+										Type: "regex:[",
+										TargetSelectionCriteria: []kargoapi.GenericWebhookTargetSelectionCriteria{
+											{
+												// Only Warehouses and Stages are supported.
+												Kind: kargoapi.GenericWebhookTargetKindPromotion,
+												Name: "my-promotion",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			objects: []client.Object{testNs},
+			assertions: func(t *testing.T, warnings admission.Warnings, err error) {
+				assert.Empty(t, warnings)
+				require.Error(t, err)
+
+				var statusErr *apierrors.StatusError
+				require.True(t, errors.As(err, &statusErr))
+
+				assert.Equal(t, metav1.StatusReasonInvalid, statusErr.ErrStatus.Reason)
+				assert.Equal(t, 2, len(statusErr.ErrStatus.Details.Causes))
+
+				// Sort errors for consistent testing
+				sort.Slice(statusErr.ErrStatus.Details.Causes, func(i, j int) bool {
+					return statusErr.ErrStatus.Details.Causes[i].Field < statusErr.ErrStatus.Details.Causes[j].Field
+				})
+
+				assert.Equal(t, "spec.webhookReceivers[0].cloudEvents.routes[0].targetSelectionCriteria[0].kind",
+					statusErr.ErrStatus.Details.Causes[0].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, statusErr.ErrStatus.Details.Causes[0].Type)
+				assert.Equal(t, "spec.webhookReceivers[0].cloudEvents.routes[0].type",
+					statusErr.ErrStatus.Details.Causes[1].Field)
+				assert.Equal(t, metav1.CauseTypeFieldValueInvalid, statusErr.ErrStatus.Details.Causes[1].Type)
+			},
+		},
 	}
 
 	for _, tt := range tests {
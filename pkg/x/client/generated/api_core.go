@@ -147,14 +147,16 @@ func (r ApiApproveFreightRequest) Stage(stage string) ApiApproveFreightRequest {
 	return r
 }
 
-func (r ApiApproveFreightRequest) Execute() (*http.Response, error) {
+func (r ApiApproveFreightRequest) Execute() (*ApproveFreightResponse, *http.Response, error) {
 	return r.ApiService.ApproveFreightExecute(r)
 }
 
 /*
 ApproveFreight Approve Freight for promotion to a Stage
 
-Approve Freight for promotion to a Stage.
+Approve Freight for promotion to a Stage. If the Stage's
+PromotionPolicy requires approval by multiple users, the
+approval is recorded as pending until the policy is satisfied.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
  @param project Project name
@@ -171,16 +173,18 @@ func (a *CoreAPIService) ApproveFreight(ctx context.Context, project string, fre
 }
 
 // Execute executes the request
-func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*http.Response, error) {
+//  @return ApproveFreightResponse
+func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*ApproveFreightResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodPost
 		localVarPostBody     interface{}
 		formFiles            []formFile
+		localVarReturnValue  *ApproveFreightResponse
 	)
 
 	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.ApproveFreight")
 	if err != nil {
-		return nil, &GenericOpenAPIError{error: err.Error()}
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
 	localVarPath := localBasePath + "/v1beta1/projects/{project}/freight/{freight-name-or-alias}/approve"
@@ -191,7 +195,7 @@ func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*htt
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 	if r.stage == nil {
-		return nil, reportError("stage is required and must be specified")
+		return localVarReturnValue, nil, reportError("stage is required and must be specified")
 	}
 
 	parameterAddToHeaderOrQuery(localVarQueryParams, "stage", r.stage, "", "")
@@ -205,7 +209,7 @@ func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*htt
 	}
 
 	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{}
+	localVarHTTPHeaderAccepts := []string{"application/json"}
 
 	// set Accept header
 	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
@@ -228,19 +232,19 @@ func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*htt
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return nil, err
+		return localVarReturnValue, nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarHTTPResponse, err
+		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -248,10 +252,19 @@ func (a *CoreAPIService) ApproveFreightExecute(r ApiApproveFreightRequest) (*htt
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		return localVarHTTPResponse, newErr
+		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarHTTPResponse, nil
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
 type ApiCreateProjectConfigMapRequest struct {
@@ -1502,6 +1515,140 @@ func (a *CoreAPIService) DeleteWarehouseExecute(r ApiDeleteWarehouseRequest) (*h
 	return localVarHTTPResponse, nil
 }
 
+type ApiDiscoverWarehouseArtifactsRequest struct {
+	ctx context.Context
+	ApiService *CoreAPIService
+	project string
+	manifest *string
+}
+
+// YAML or JSON Warehouse manifest
+func (r ApiDiscoverWarehouseArtifactsRequest) Manifest(manifest string) ApiDiscoverWarehouseArtifactsRequest {
+	r.manifest = &manifest
+	return r
+}
+
+func (r ApiDiscoverWarehouseArtifactsRequest) Execute() (*WarehouseDryRunResult, *http.Response, error) {
+	return r.ApiService.DiscoverWarehouseArtifactsExecute(r)
+}
+
+/*
+DiscoverWarehouseArtifacts Dry run artifact discovery for a Warehouse
+
+Discover artifacts using the subscriptions of the Warehouse
+described by the provided YAML or JSON manifest without creating
+or updating the Warehouse and without creating any Freight. The
+Warehouse need not exist. The result includes the artifacts
+that would be discovered, why each filtered-out candidate was
+filtered out, and the Freight that would be created.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param project Project name
+ @return ApiDiscoverWarehouseArtifactsRequest
+*/
+func (a *CoreAPIService) DiscoverWarehouseArtifacts(ctx context.Context, project string) ApiDiscoverWarehouseArtifactsRequest {
+	return ApiDiscoverWarehouseArtifactsRequest{
+		ApiService: a,
+		ctx: ctx,
+		project: project,
+	}
+}
+
+// Execute executes the request
+//  @return WarehouseDryRunResult
+func (a *CoreAPIService) DiscoverWarehouseArtifactsExecute(r ApiDiscoverWarehouseArtifactsRequest) (*WarehouseDryRunResult, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *WarehouseDryRunResult
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.DiscoverWarehouseArtifacts")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/warehouses/discover"
+	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.manifest == nil {
+		return localVarReturnValue, nil, reportError("manifest is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"text/plain"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.manifest
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["BearerAuth"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGetClusterPromotionTaskRequest struct {
 	ctx context.Context
 	ApiService *CoreAPIService
@@ -1741,6 +1888,152 @@ func (a *CoreAPIService) GetFreightExecute(r ApiGetFreightRequest) (*Freight, *h
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiGetFreightDiffRequest struct {
+	ctx context.Context
+	ApiService *CoreAPIService
+	project string
+	freightNameOrAlias string
+	from *string
+	stage *string
+}
+
+// Name or alias of the Freight to compare against
+func (r ApiGetFreightDiffRequest) From(from string) ApiGetFreightDiffRequest {
+	r.from = &from
+	return r
+}
+
+// Name of the Stage whose current Freight should be compared against
+func (r ApiGetFreightDiffRequest) Stage(stage string) ApiGetFreightDiffRequest {
+	r.stage = &stage
+	return r
+}
+
+func (r ApiGetFreightDiffRequest) Execute() (*FreightDiff, *http.Response, error) {
+	return r.ApiService.GetFreightDiffExecute(r)
+}
+
+/*
+GetFreightDiff Compare a Freight resource to another
+
+Compare a Freight resource to either another Freight resource or
+the Freight currently in a Stage. The comparison includes the
+Git commits added and removed between the referenced commits,
+changed image tags and digests, chart versions, and artifact
+versions. Exactly one of the from and stage query parameters
+must be specified.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param project Project name
+ @param freightNameOrAlias Freight name or alias
+ @return ApiGetFreightDiffRequest
+*/
+func (a *CoreAPIService) GetFreightDiff(ctx context.Context, project string, freightNameOrAlias string) ApiGetFreightDiffRequest {
+	return ApiGetFreightDiffRequest{
+		ApiService: a,
+		ctx: ctx,
+		project: project,
+		freightNameOrAlias: freightNameOrAlias,
+	}
+}
+
+// Execute executes the request
+//  @return FreightDiff
+func (a *CoreAPIService) GetFreightDiffExecute(r ApiGetFreightDiffRequest) (*FreightDiff, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *FreightDiff
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.GetFreightDiff")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/freight/{freight-name-or-alias}/diff"
+	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"freight-name-or-alias"+"}", url.PathEscape(parameterValueToString(r.freightNameOrAlias, "freightNameOrAlias")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.from != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "from", r.from, "", "")
+	}
+	if r.stage != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "stage", r.stage, "", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["BearerAuth"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGetFreightLinksRequest struct {
 	ctx context.Context
 	ApiService *CoreAPIService
@@ -1748,54 +2041,206 @@ type ApiGetFreightLinksRequest struct {
 	freightNameOrAlias string
 }
 
-func (r ApiGetFreightLinksRequest) Execute() (*GetFreightLinksResponse, *http.Response, error) {
-	return r.ApiService.GetFreightLinksExecute(r)
+func (r ApiGetFreightLinksRequest) Execute() (*GetFreightLinksResponse, *http.Response, error) {
+	return r.ApiService.GetFreightLinksExecute(r)
+}
+
+/*
+GetFreightLinks Retrieve deep links for a Freight resource
+
+Retrieve evaluated deep links for a Freight resource, combining
+cluster-level links from ClusterConfig and project-level links
+from ProjectConfig.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param project Project name
+ @param freightNameOrAlias Freight name or alias
+ @return ApiGetFreightLinksRequest
+*/
+func (a *CoreAPIService) GetFreightLinks(ctx context.Context, project string, freightNameOrAlias string) ApiGetFreightLinksRequest {
+	return ApiGetFreightLinksRequest{
+		ApiService: a,
+		ctx: ctx,
+		project: project,
+		freightNameOrAlias: freightNameOrAlias,
+	}
+}
+
+// Execute executes the request
+//  @return GetFreightLinksResponse
+func (a *CoreAPIService) GetFreightLinksExecute(r ApiGetFreightLinksRequest) (*GetFreightLinksResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *GetFreightLinksResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.GetFreightLinks")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/freight/{freight-name-or-alias}/links"
+	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"freight-name-or-alias"+"}", url.PathEscape(parameterValueToString(r.freightNameOrAlias, "freightNameOrAlias")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["BearerAuth"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiGetLineageRequest struct {
+	ctx context.Context
+	ApiService *CoreAPIService
+	project string
+	commit *string
+	digest *string
+	chartVersion *string
+}
+
+// Git commit SHA or prefix thereof
+func (r ApiGetLineageRequest) Commit(commit string) ApiGetLineageRequest {
+	r.commit = &commit
+	return r
+}
+
+// Container image digest
+func (r ApiGetLineageRequest) Digest(digest string) ApiGetLineageRequest {
+	r.digest = &digest
+	return r
+}
+
+// Helm chart version
+func (r ApiGetLineageRequest) ChartVersion(chartVersion string) ApiGetLineageRequest {
+	r.chartVersion = &chartVersion
+	return r
+}
+
+func (r ApiGetLineageRequest) Execute() (*LineageGraph, *http.Response, error) {
+	return r.ApiService.GetLineageExecute(r)
 }
 
 /*
-GetFreightLinks Retrieve deep links for a Freight resource
+GetLineage Retrieve Freight lineage
 
-Retrieve evaluated deep links for a Freight resource, combining
-cluster-level links from ClusterConfig and project-level links
-from ProjectConfig.
+Retrieve a graph describing which Warehouses produced the
+selected Freight and which Stages that Freight has since been
+approved for, promoted to, verified in, or used by. Freight may
+be selected by Git commit SHA, container image digest, or Helm
+chart version. If no criteria are specified, all Freight in the
+project is selected.
 
  @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
  @param project Project name
- @param freightNameOrAlias Freight name or alias
- @return ApiGetFreightLinksRequest
+ @return ApiGetLineageRequest
 */
-func (a *CoreAPIService) GetFreightLinks(ctx context.Context, project string, freightNameOrAlias string) ApiGetFreightLinksRequest {
-	return ApiGetFreightLinksRequest{
+func (a *CoreAPIService) GetLineage(ctx context.Context, project string) ApiGetLineageRequest {
+	return ApiGetLineageRequest{
 		ApiService: a,
 		ctx: ctx,
 		project: project,
-		freightNameOrAlias: freightNameOrAlias,
 	}
 }
 
 // Execute executes the request
-//  @return GetFreightLinksResponse
-func (a *CoreAPIService) GetFreightLinksExecute(r ApiGetFreightLinksRequest) (*GetFreightLinksResponse, *http.Response, error) {
+//  @return LineageGraph
+func (a *CoreAPIService) GetLineageExecute(r ApiGetLineageRequest) (*LineageGraph, *http.Response, error) {
 	var (
 		localVarHTTPMethod   = http.MethodGet
 		localVarPostBody     interface{}
 		formFiles            []formFile
-		localVarReturnValue  *GetFreightLinksResponse
+		localVarReturnValue  *LineageGraph
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.GetFreightLinks")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.GetLineage")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/v1beta1/projects/{project}/freight/{freight-name-or-alias}/links"
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/lineage"
 	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
-	localVarPath = strings.Replace(localVarPath, "{"+"freight-name-or-alias"+"}", url.PathEscape(parameterValueToString(r.freightNameOrAlias, "freightNameOrAlias")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.commit != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "commit", r.commit, "", "")
+	}
+	if r.digest != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "digest", r.digest, "", "")
+	}
+	if r.chartVersion != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "chartVersion", r.chartVersion, "", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -2341,6 +2786,151 @@ func (a *CoreAPIService) GetPromotionExecute(r ApiGetPromotionRequest) (*Promoti
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiGetPromotionLogsRequest struct {
+	ctx context.Context
+	ApiService *CoreAPIService
+	project string
+	promotion string
+	step *string
+	follow *bool
+}
+
+// Step alias
+func (r ApiGetPromotionLogsRequest) Step(step string) ApiGetPromotionLogsRequest {
+	r.step = &step
+	return r
+}
+
+// Follow the logs until the Promotion completes
+func (r ApiGetPromotionLogsRequest) Follow(follow bool) ApiGetPromotionLogsRequest {
+	r.follow = &follow
+	return r
+}
+
+func (r ApiGetPromotionLogsRequest) Execute() (string, *http.Response, error) {
+	return r.ApiService.GetPromotionLogsExecute(r)
+}
+
+/*
+GetPromotionLogs Stream Promotion step logs
+
+Stream output captured from the steps of a Promotion as
+Server-Sent Events (SSE). Each event is a JSON object holding
+the output of a single execution of a single step. When
+following, the stream remains open until the Promotion reaches
+a terminal phase.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param project Project name
+ @param promotion Promotion name
+ @return ApiGetPromotionLogsRequest
+*/
+func (a *CoreAPIService) GetPromotionLogs(ctx context.Context, project string, promotion string) ApiGetPromotionLogsRequest {
+	return ApiGetPromotionLogsRequest{
+		ApiService: a,
+		ctx: ctx,
+		project: project,
+		promotion: promotion,
+	}
+}
+
+// Execute executes the request
+//  @return string
+func (a *CoreAPIService) GetPromotionLogsExecute(r ApiGetPromotionLogsRequest) (string, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  string
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.GetPromotionLogs")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/promotions/{promotion}/logs"
+	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"promotion"+"}", url.PathEscape(parameterValueToString(r.promotion, "promotion")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.step != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "step", r.step, "", "")
+	}
+	if r.follow != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "follow", r.follow, "", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"text/event-stream"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["BearerAuth"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGetPromotionTaskRequest struct {
 	ctx context.Context
 	ApiService *CoreAPIService
@@ -5086,6 +5676,145 @@ func (a *CoreAPIService) PromoteToStageExecute(r ApiPromoteToStageRequest) (*Pro
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiPromoteToStageDryRunRequest struct {
+	ctx context.Context
+	ApiService *CoreAPIService
+	project string
+	stage string
+	body *PromoteToStageDryRunRequest
+}
+
+// Dry run request
+func (r ApiPromoteToStageDryRunRequest) Body(body PromoteToStageDryRunRequest) ApiPromoteToStageDryRunRequest {
+	r.body = &body
+	return r
+}
+
+func (r ApiPromoteToStageDryRunRequest) Execute() (*PromotionDryRunResult, *http.Response, error) {
+	return r.ApiService.PromoteToStageDryRunExecute(r)
+}
+
+/*
+PromoteToStageDryRun Dry run a promotion to a Stage
+
+Execute the promotion steps of a Stage's PromotionTemplate for
+the specified Freight without creating a Promotion. Steps that
+would change state outside of a temporary working directory,
+such as pushing commits or updating Argo CD Applications, are
+not executed. The result includes the rendered configuration of
+each step, which steps were skipped, and the changes made to
+each Git working tree.
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param project Project name
+ @param stage Stage name
+ @return ApiPromoteToStageDryRunRequest
+*/
+func (a *CoreAPIService) PromoteToStageDryRun(ctx context.Context, project string, stage string) ApiPromoteToStageDryRunRequest {
+	return ApiPromoteToStageDryRunRequest{
+		ApiService: a,
+		ctx: ctx,
+		project: project,
+		stage: stage,
+	}
+}
+
+// Execute executes the request
+//  @return PromotionDryRunResult
+func (a *CoreAPIService) PromoteToStageDryRunExecute(r ApiPromoteToStageDryRunRequest) (*PromotionDryRunResult, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodPost
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *PromotionDryRunResult
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "CoreAPIService.PromoteToStageDryRun")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1beta1/projects/{project}/stages/{stage}/promotions/dry-run"
+	localVarPath = strings.Replace(localVarPath, "{"+"project"+"}", url.PathEscape(parameterValueToString(r.project, "project")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"stage"+"}", url.PathEscape(parameterValueToString(r.stage, "stage")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.body == nil {
+		return localVarReturnValue, nil, reportError("body is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.body
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["BearerAuth"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiQueryFreightsRestRequest struct {
 	ctx context.Context
 	ApiService *CoreAPIService
@@ -45,7 +45,9 @@ func (r ApiCreateProjectAPITokenRequest) Execute() (*V1Secret, *http.Response, e
 CreateProjectAPIToken Create a project-level API token
 
 Create a project-level API token associated with a Kargo Role
-virtual resource. Returns a Kubernetes Secret resource
+virtual resource. The token may optionally be given a TTL and
+scopes that narrow its permissions to a subset of the Role's.
+Returns a Kubernetes Secret resource
 representing the token. Store it securely. The token is not
 retrievable via the Kargo API after creation except in a
 redacted form.
@@ -312,7 +314,9 @@ func (r ApiCreateSystemAPITokenRequest) Execute() (*V1Secret, *http.Response, er
 CreateSystemAPIToken Create a system-level API token
 
 Create a system-level API token associated with a system-level
-Kargo Role virtual resource. Returns a Kubernetes Secret
+Kargo Role virtual resource. The token may optionally be given
+a TTL and scopes that narrow its permissions to a subset of the
+Role's. Returns a Kubernetes Secret
 resource representing the token. Store it securely. The token
 is not retrievable via the Kargo API after creation except in
 a redacted form.
@@ -21,6 +21,8 @@ var _ MappedNullable = &ApprovedStage{}
 type ApprovedStage struct {
 	// ApprovedAt is the time at which the Freight was approved for the Stage.
 	ApprovedAt *string `json:"approvedAt,omitempty"`
+	// Approvers lists the users whose approvals satisfied the Stage's ApprovalPolicy. It is empty for approvals that predate approval policies or that were not made by a user.
+	Approvers []Approver `json:"approvers,omitempty"`
 }
 
 // NewApprovedStage instantiates a new ApprovedStage object
@@ -72,6 +74,38 @@ func (o *ApprovedStage) SetApprovedAt(v string) {
 	o.ApprovedAt = &v
 }
 
+// GetApprovers returns the Approvers field value if set, zero value otherwise.
+func (o *ApprovedStage) GetApprovers() []Approver {
+	if o == nil || IsNil(o.Approvers) {
+		var ret []Approver
+		return ret
+	}
+	return o.Approvers
+}
+
+// GetApproversOk returns a tuple with the Approvers field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ApprovedStage) GetApproversOk() ([]Approver, bool) {
+	if o == nil || IsNil(o.Approvers) {
+		return nil, false
+	}
+	return o.Approvers, true
+}
+
+// HasApprovers returns a boolean if a field has been set.
+func (o *ApprovedStage) HasApprovers() bool {
+	if o != nil && !IsNil(o.Approvers) {
+		return true
+	}
+
+	return false
+}
+
+// SetApprovers gets a reference to the given []Approver and assigns it to the Approvers field.
+func (o *ApprovedStage) SetApprovers(v []Approver) {
+	o.Approvers = v
+}
+
 func (o ApprovedStage) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -85,6 +119,9 @@ func (o ApprovedStage) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ApprovedAt) {
 		toSerialize["approvedAt"] = o.ApprovedAt
 	}
+	if !IsNil(o.Approvers) {
+		toSerialize["approvers"] = o.Approvers
+	}
 	return toSerialize, nil
 }
 
@@ -20,6 +20,10 @@ var _ MappedNullable = &CreateAPITokenRequest{}
 // CreateAPITokenRequest struct for CreateAPITokenRequest
 type CreateAPITokenRequest struct {
 	Name *string `json:"name,omitempty"`
+	// Scopes, if specified, narrow the permissions conferred by the token to those that are both held by the role and described by at least one scope.
+	Scopes []ResourceDetails `json:"scopes,omitempty"`
+	// TTL is the length of time, expressed as a Go duration string (e.g. \"24h\"), for which the token remains valid. If unspecified, the token never expires.
+	Ttl *string `json:"ttl,omitempty"`
 }
 
 // NewCreateAPITokenRequest instantiates a new CreateAPITokenRequest object
@@ -71,6 +75,70 @@ func (o *CreateAPITokenRequest) SetName(v string) {
 	o.Name = &v
 }
 
+// GetScopes returns the Scopes field value if set, zero value otherwise.
+func (o *CreateAPITokenRequest) GetScopes() []ResourceDetails {
+	if o == nil || IsNil(o.Scopes) {
+		var ret []ResourceDetails
+		return ret
+	}
+	return o.Scopes
+}
+
+// GetScopesOk returns a tuple with the Scopes field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateAPITokenRequest) GetScopesOk() ([]ResourceDetails, bool) {
+	if o == nil || IsNil(o.Scopes) {
+		return nil, false
+	}
+	return o.Scopes, true
+}
+
+// HasScopes returns a boolean if a field has been set.
+func (o *CreateAPITokenRequest) HasScopes() bool {
+	if o != nil && !IsNil(o.Scopes) {
+		return true
+	}
+
+	return false
+}
+
+// SetScopes gets a reference to the given []ResourceDetails and assigns it to the Scopes field.
+func (o *CreateAPITokenRequest) SetScopes(v []ResourceDetails) {
+	o.Scopes = v
+}
+
+// GetTtl returns the Ttl field value if set, zero value otherwise.
+func (o *CreateAPITokenRequest) GetTtl() string {
+	if o == nil || IsNil(o.Ttl) {
+		var ret string
+		return ret
+	}
+	return *o.Ttl
+}
+
+// GetTtlOk returns a tuple with the Ttl field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *CreateAPITokenRequest) GetTtlOk() (*string, bool) {
+	if o == nil || IsNil(o.Ttl) {
+		return nil, false
+	}
+	return o.Ttl, true
+}
+
+// HasTtl returns a boolean if a field has been set.
+func (o *CreateAPITokenRequest) HasTtl() bool {
+	if o != nil && !IsNil(o.Ttl) {
+		return true
+	}
+
+	return false
+}
+
+// SetTtl gets a reference to the given string and assigns it to the Ttl field.
+func (o *CreateAPITokenRequest) SetTtl(v string) {
+	o.Ttl = &v
+}
+
 func (o CreateAPITokenRequest) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -84,6 +152,12 @@ func (o CreateAPITokenRequest) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Name) {
 		toSerialize["name"] = o.Name
 	}
+	if !IsNil(o.Scopes) {
+		toSerialize["scopes"] = o.Scopes
+	}
+	if !IsNil(o.Ttl) {
+		toSerialize["ttl"] = o.Ttl
+	}
 	return toSerialize, nil
 }
 
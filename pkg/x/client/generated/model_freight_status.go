@@ -25,6 +25,8 @@ type FreightStatus struct {
 	CurrentlyIn *map[string]CurrentStage `json:"currentlyIn,omitempty"`
 	// Metadata is a map of arbitrary metadata associated with the Freight. This is useful for storing additional information about the Freight or Promotion that can be shared across steps or stages.
 	Metadata map[string]any `json:"metadata,omitempty"`
+	// PendingApprovals describes the Stages for which this Freight has been approved by some, but not yet enough, users to satisfy the Stage's ApprovalPolicy. Once the policy is satisfied, the Stage's entry is moved to ApprovedFor.
+	PendingApprovals *map[string]PendingApproval `json:"pendingApprovals,omitempty"`
 	// VerifiedIn describes the Stages in which this Freight has been verified through promotion and subsequent health checks.
 	VerifiedIn *map[string]VerifiedStage `json:"verifiedIn,omitempty"`
 }
@@ -142,6 +144,38 @@ func (o *FreightStatus) SetMetadata(v map[string]any) {
 	o.Metadata = v
 }
 
+// GetPendingApprovals returns the PendingApprovals field value if set, zero value otherwise.
+func (o *FreightStatus) GetPendingApprovals() map[string]PendingApproval {
+	if o == nil || IsNil(o.PendingApprovals) {
+		var ret map[string]PendingApproval
+		return ret
+	}
+	return *o.PendingApprovals
+}
+
+// GetPendingApprovalsOk returns a tuple with the PendingApprovals field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *FreightStatus) GetPendingApprovalsOk() (*map[string]PendingApproval, bool) {
+	if o == nil || IsNil(o.PendingApprovals) {
+		return nil, false
+	}
+	return o.PendingApprovals, true
+}
+
+// HasPendingApprovals returns a boolean if a field has been set.
+func (o *FreightStatus) HasPendingApprovals() bool {
+	if o != nil && !IsNil(o.PendingApprovals) {
+		return true
+	}
+
+	return false
+}
+
+// SetPendingApprovals gets a reference to the given map[string]PendingApproval and assigns it to the PendingApprovals field.
+func (o *FreightStatus) SetPendingApprovals(v map[string]PendingApproval) {
+	o.PendingApprovals = &v
+}
+
 // GetVerifiedIn returns the VerifiedIn field value if set, zero value otherwise.
 func (o *FreightStatus) GetVerifiedIn() map[string]VerifiedStage {
 	if o == nil || IsNil(o.VerifiedIn) {
@@ -193,6 +227,9 @@ func (o FreightStatus) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Metadata) {
 		toSerialize["metadata"] = o.Metadata
 	}
+	if !IsNil(o.PendingApprovals) {
+		toSerialize["pendingApprovals"] = o.PendingApprovals
+	}
 	if !IsNil(o.VerifiedIn) {
 		toSerialize["verifiedIn"] = o.VerifiedIn
 	}
@@ -19,9 +19,9 @@ var _ MappedNullable = &GenericWebhookAction{}
 
 // GenericWebhookAction struct for GenericWebhookAction
 type GenericWebhookAction struct {
-	// ActionType indicates the type of action to be performed. `Refresh` is the only currently supported action.  +kubebuilder:validation:Enum=Refresh;
+	// ActionType indicates the type of action to be performed.  `Refresh` enqueues target resources of any kind for immediate reconciliation.  `Promote` creates a Promotion of the Freight specified by the `freight` (name or alias) or `origin` (e.g. `Warehouse/my-warehouse`) parameter for each target Stage.  `Approve` approves the Freight specified by the `freight` (name or alias) parameter for each target Stage.  `SetMetadata` sets a metadata entry on each target Stage or Freight for every parameter, using the parameter's key as the metadata key and the result of evaluating the parameter's value as the metadata value.  +kubebuilder:validation:Enum=Refresh;Promote;Approve;SetMetadata;
 	Action *string `json:"action,omitempty"`
-	// Parameters contains additional, action-specific parameters. Values may be static or extracted from the request using expressions.  +optional
+	// Parameters contains additional, action-specific parameters. Values may be static or extracted from the request using expressions. Refer to ActionType for the parameters supported by each action.  +optional
 	Parameters *map[string]string `json:"parameters,omitempty"`
 	// TargetSelectionCriteria is a list of selection criteria for the resources on which the action should be performed.  +kubebuilder:validation:MinItems=1
 	TargetSelectionCriteria []GenericWebhookTargetSelectionCriteria `json:"targetSelectionCriteria,omitempty"`
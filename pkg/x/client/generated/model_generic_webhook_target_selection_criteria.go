@@ -21,7 +21,7 @@ var _ MappedNullable = &GenericWebhookTargetSelectionCriteria{}
 type GenericWebhookTargetSelectionCriteria struct {
 	// IndexSelector is a selector used to identify cached target resources by cache key. If used with LabelSelector and/or Name, the results are the combined (logical AND) of all the criteria.  +optional
 	IndexSelector *IndexSelector `json:"indexSelector,omitempty"`
-	// Kind is the kind of the target resource.  +kubebuilder:validation:Enum=Warehouse;
+	// Kind is the kind of the target resource.  +kubebuilder:validation:Enum=Warehouse;Promotion;Stage;Freight;
 	Kind *string `json:"kind,omitempty"`
 	// LabelSelector is a label selector to identify the target resources. If used with IndexSelector and/or Name, the results are the combined (logical AND) of all the criteria.  +optional
 	LabelSelector *V1LabelSelector `json:"labelSelector,omitempty"`
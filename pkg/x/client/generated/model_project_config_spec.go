@@ -25,6 +25,8 @@ type ProjectConfigSpec struct {
 	PromotionPolicies []PromotionPolicy `json:"promotionPolicies,omitempty"`
 	// PromotionWindows defines time windows that gate promotions for Stages in this Project. A Stage's effective schedule is the union of matching windows defined here and any cluster-level windows in ClusterConfig.  Kargo Enterprise only: This field is ignored in Kargo OSS.  +optional +listType=map +listMapKey=name
 	PromotionWindows []PromotionWindow `json:"promotionWindows,omitempty"`
+	// RoleMappings declaratively map users authenticated via OIDC to Kargo Roles in this Project on the basis of their claims. Mappings are applied in addition to, and never replace, any claims associated with a Role by other means.  +optional
+	RoleMappings []RoleMapping `json:"roleMappings,omitempty"`
 	// StageLinks defines deep links shown when viewing Stage resources within this project. These are shown in addition to any cluster-level StageLinks defined in ClusterConfig.  +optional
 	StageLinks []DeepLink `json:"stageLinks,omitempty"`
 	// WebhookReceivers describes Project-specific webhook receivers used for processing events from various external platforms
@@ -144,6 +146,38 @@ func (o *ProjectConfigSpec) SetPromotionWindows(v []PromotionWindow) {
 	o.PromotionWindows = v
 }
 
+// GetRoleMappings returns the RoleMappings field value if set, zero value otherwise.
+func (o *ProjectConfigSpec) GetRoleMappings() []RoleMapping {
+	if o == nil || IsNil(o.RoleMappings) {
+		var ret []RoleMapping
+		return ret
+	}
+	return o.RoleMappings
+}
+
+// GetRoleMappingsOk returns a tuple with the RoleMappings field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ProjectConfigSpec) GetRoleMappingsOk() ([]RoleMapping, bool) {
+	if o == nil || IsNil(o.RoleMappings) {
+		return nil, false
+	}
+	return o.RoleMappings, true
+}
+
+// HasRoleMappings returns a boolean if a field has been set.
+func (o *ProjectConfigSpec) HasRoleMappings() bool {
+	if o != nil && !IsNil(o.RoleMappings) {
+		return true
+	}
+
+	return false
+}
+
+// SetRoleMappings gets a reference to the given []RoleMapping and assigns it to the RoleMappings field.
+func (o *ProjectConfigSpec) SetRoleMappings(v []RoleMapping) {
+	o.RoleMappings = v
+}
+
 // GetStageLinks returns the StageLinks field value if set, zero value otherwise.
 func (o *ProjectConfigSpec) GetStageLinks() []DeepLink {
 	if o == nil || IsNil(o.StageLinks) {
@@ -227,6 +261,9 @@ func (o ProjectConfigSpec) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.PromotionWindows) {
 		toSerialize["promotionWindows"] = o.PromotionWindows
 	}
+	if !IsNil(o.RoleMappings) {
+		toSerialize["roleMappings"] = o.RoleMappings
+	}
 	if !IsNil(o.StageLinks) {
 		toSerialize["stageLinks"] = o.StageLinks
 	}
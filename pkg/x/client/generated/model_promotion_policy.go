@@ -19,6 +19,8 @@ var _ MappedNullable = &PromotionPolicy{}
 
 // PromotionPolicy struct for PromotionPolicy
 type PromotionPolicy struct {
+	// Approval describes the approvals Freight requires before it is approved for promotion to the Stage. When nil, a single approval by any user permitted to promote to the Stage suffices.
+	Approval *ApprovalPolicy `json:"approval,omitempty"`
 	// AutoPromotionEnabled indicates whether new Freight can automatically be promoted into the Stage referenced by the Stage field. Note: There are may be other conditions also required for an auto-promotion to occur. This field defaults to false, but is commonly set to true for Stages that subscribe to Warehouses instead of other, upstream Stages. This allows users to define Stages that are automatically updated as soon as new artifacts are detected.
 	AutoPromotionEnabled *bool `json:"autoPromotionEnabled,omitempty"`
 	// AutoRollback describes the conditions under which this Stage should automatically roll back to the last known-good (verified) Freight. When nil, auto-rollback is disabled.  Kargo Enterprise only: This field is ignored in Kargo OSS.
@@ -46,6 +48,38 @@ func NewPromotionPolicyWithDefaults() *PromotionPolicy {
 	return &this
 }
 
+// GetApproval returns the Approval field value if set, zero value otherwise.
+func (o *PromotionPolicy) GetApproval() ApprovalPolicy {
+	if o == nil || IsNil(o.Approval) {
+		var ret ApprovalPolicy
+		return ret
+	}
+	return *o.Approval
+}
+
+// GetApprovalOk returns a tuple with the Approval field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *PromotionPolicy) GetApprovalOk() (*ApprovalPolicy, bool) {
+	if o == nil || IsNil(o.Approval) {
+		return nil, false
+	}
+	return o.Approval, true
+}
+
+// HasApproval returns a boolean if a field has been set.
+func (o *PromotionPolicy) HasApproval() bool {
+	if o != nil && !IsNil(o.Approval) {
+		return true
+	}
+
+	return false
+}
+
+// SetApproval gets a reference to the given ApprovalPolicy and assigns it to the Approval field.
+func (o *PromotionPolicy) SetApproval(v ApprovalPolicy) {
+	o.Approval = &v
+}
+
 // GetAutoPromotionEnabled returns the AutoPromotionEnabled field value if set, zero value otherwise.
 func (o *PromotionPolicy) GetAutoPromotionEnabled() bool {
 	if o == nil || IsNil(o.AutoPromotionEnabled) {
@@ -184,6 +218,9 @@ func (o PromotionPolicy) MarshalJSON() ([]byte, error) {
 
 func (o PromotionPolicy) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.Approval) {
+		toSerialize["approval"] = o.Approval
+	}
 	if !IsNil(o.AutoPromotionEnabled) {
 		toSerialize["autoPromotionEnabled"] = o.AutoPromotionEnabled
 	}
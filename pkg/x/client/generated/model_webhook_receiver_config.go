@@ -21,14 +21,22 @@ var _ MappedNullable = &WebhookReceiverConfig{}
 
 // WebhookReceiverConfig struct for WebhookReceiverConfig
 type WebhookReceiverConfig struct {
+	// ArtifactRegistry contains the configuration for a webhook receiver that is compatible with Google Artifact Registry notifications delivered by a Google Cloud Pub/Sub push subscription.
+	ArtifactRegistry *ArtifactRegistryWebhookReceiverConfig `json:"artifactRegistry,omitempty"`
 	// Artifactory contains the configuration for a webhook receiver that is compatible with JFrog Artifactory payloads.
 	Artifactory *ArtifactoryWebhookReceiverConfig `json:"artifactory,omitempty"`
 	// Azure contains the configuration for a webhook receiver that is compatible with Azure Container Registry (ACR) and Azure DevOps payloads.
 	Azure *AzureWebhookReceiverConfig `json:"azure,omitempty"`
+	// AzureEventGrid contains the configuration for a webhook receiver that is compatible with Azure Container Registry (ACR) events delivered by Azure Event Grid.
+	AzureEventGrid *AzureEventGridWebhookReceiverConfig `json:"azureEventGrid,omitempty"`
 	// Bitbucket contains the configuration for a webhook receiver that is compatible with Bitbucket payloads.
 	Bitbucket *BitbucketWebhookReceiverConfig `json:"bitbucket,omitempty"`
+	// CloudEvents contains the configuration for a webhook receiver that accepts CloudEvents from any compliant producer.
+	CloudEvents *CloudEventsWebhookReceiverConfig `json:"cloudEvents,omitempty"`
 	// DockerHub contains the configuration for a webhook receiver that is compatible with DockerHub payloads.
 	Dockerhub *DockerHubWebhookReceiverConfig `json:"dockerhub,omitempty"`
+	// ECR contains the configuration for a webhook receiver that is compatible with Amazon Elastic Container Registry (ECR) events delivered by Amazon EventBridge via an API destination.
+	Ecr *ECRWebhookReceiverConfig `json:"ecr,omitempty"`
 	// Generic contains the configuration for a generic webhook receiver.
 	Generic *GenericWebhookReceiverConfig `json:"generic,omitempty"`
 	// Gitea contains the configuration for a webhook receiver that is compatible with Gitea payloads.
@@ -65,6 +73,38 @@ func NewWebhookReceiverConfigWithDefaults() *WebhookReceiverConfig {
 	return &this
 }
 
+// GetArtifactRegistry returns the ArtifactRegistry field value if set, zero value otherwise.
+func (o *WebhookReceiverConfig) GetArtifactRegistry() ArtifactRegistryWebhookReceiverConfig {
+	if o == nil || IsNil(o.ArtifactRegistry) {
+		var ret ArtifactRegistryWebhookReceiverConfig
+		return ret
+	}
+	return *o.ArtifactRegistry
+}
+
+// GetArtifactRegistryOk returns a tuple with the ArtifactRegistry field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *WebhookReceiverConfig) GetArtifactRegistryOk() (*ArtifactRegistryWebhookReceiverConfig, bool) {
+	if o == nil || IsNil(o.ArtifactRegistry) {
+		return nil, false
+	}
+	return o.ArtifactRegistry, true
+}
+
+// HasArtifactRegistry returns a boolean if a field has been set.
+func (o *WebhookReceiverConfig) HasArtifactRegistry() bool {
+	if o != nil && !IsNil(o.ArtifactRegistry) {
+		return true
+	}
+
+	return false
+}
+
+// SetArtifactRegistry gets a reference to the given ArtifactRegistryWebhookReceiverConfig and assigns it to the ArtifactRegistry field.
+func (o *WebhookReceiverConfig) SetArtifactRegistry(v ArtifactRegistryWebhookReceiverConfig) {
+	o.ArtifactRegistry = &v
+}
+
 // GetArtifactory returns the Artifactory field value if set, zero value otherwise.
 func (o *WebhookReceiverConfig) GetArtifactory() ArtifactoryWebhookReceiverConfig {
 	if o == nil || IsNil(o.Artifactory) {
@@ -129,6 +169,38 @@ func (o *WebhookReceiverConfig) SetAzure(v AzureWebhookReceiverConfig) {
 	o.Azure = &v
 }
 
+// GetAzureEventGrid returns the AzureEventGrid field value if set, zero value otherwise.
+func (o *WebhookReceiverConfig) GetAzureEventGrid() AzureEventGridWebhookReceiverConfig {
+	if o == nil || IsNil(o.AzureEventGrid) {
+		var ret AzureEventGridWebhookReceiverConfig
+		return ret
+	}
+	return *o.AzureEventGrid
+}
+
+// GetAzureEventGridOk returns a tuple with the AzureEventGrid field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *WebhookReceiverConfig) GetAzureEventGridOk() (*AzureEventGridWebhookReceiverConfig, bool) {
+	if o == nil || IsNil(o.AzureEventGrid) {
+		return nil, false
+	}
+	return o.AzureEventGrid, true
+}
+
+// HasAzureEventGrid returns a boolean if a field has been set.
+func (o *WebhookReceiverConfig) HasAzureEventGrid() bool {
+	if o != nil && !IsNil(o.AzureEventGrid) {
+		return true
+	}
+
+	return false
+}
+
+// SetAzureEventGrid gets a reference to the given AzureEventGridWebhookReceiverConfig and assigns it to the AzureEventGrid field.
+func (o *WebhookReceiverConfig) SetAzureEventGrid(v AzureEventGridWebhookReceiverConfig) {
+	o.AzureEventGrid = &v
+}
+
 // GetBitbucket returns the Bitbucket field value if set, zero value otherwise.
 func (o *WebhookReceiverConfig) GetBitbucket() BitbucketWebhookReceiverConfig {
 	if o == nil || IsNil(o.Bitbucket) {
@@ -161,6 +233,38 @@ func (o *WebhookReceiverConfig) SetBitbucket(v BitbucketWebhookReceiverConfig) {
 	o.Bitbucket = &v
 }
 
+// GetCloudEvents returns the CloudEvents field value if set, zero value otherwise.
+func (o *WebhookReceiverConfig) GetCloudEvents() CloudEventsWebhookReceiverConfig {
+	if o == nil || IsNil(o.CloudEvents) {
+		var ret CloudEventsWebhookReceiverConfig
+		return ret
+	}
+	return *o.CloudEvents
+}
+
+// GetCloudEventsOk returns a tuple with the CloudEvents field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *WebhookReceiverConfig) GetCloudEventsOk() (*CloudEventsWebhookReceiverConfig, bool) {
+	if o == nil || IsNil(o.CloudEvents) {
+		return nil, false
+	}
+	return o.CloudEvents, true
+}
+
+// HasCloudEvents returns a boolean if a field has been set.
+func (o *WebhookReceiverConfig) HasCloudEvents() bool {
+	if o != nil && !IsNil(o.CloudEvents) {
+		return true
+	}
+
+	return false
+}
+
+// SetCloudEvents gets a reference to the given CloudEventsWebhookReceiverConfig and assigns it to the CloudEvents field.
+func (o *WebhookReceiverConfig) SetCloudEvents(v CloudEventsWebhookReceiverConfig) {
+	o.CloudEvents = &v
+}
+
 // GetDockerhub returns the Dockerhub field value if set, zero value otherwise.
 func (o *WebhookReceiverConfig) GetDockerhub() DockerHubWebhookReceiverConfig {
 	if o == nil || IsNil(o.Dockerhub) {
@@ -193,6 +297,38 @@ func (o *WebhookReceiverConfig) SetDockerhub(v DockerHubWebhookReceiverConfig) {
 	o.Dockerhub = &v
 }
 
+// GetEcr returns the Ecr field value if set, zero value otherwise.
+func (o *WebhookReceiverConfig) GetEcr() ECRWebhookReceiverConfig {
+	if o == nil || IsNil(o.Ecr) {
+		var ret ECRWebhookReceiverConfig
+		return ret
+	}
+	return *o.Ecr
+}
+
+// GetEcrOk returns a tuple with the Ecr field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *WebhookReceiverConfig) GetEcrOk() (*ECRWebhookReceiverConfig, bool) {
+	if o == nil || IsNil(o.Ecr) {
+		return nil, false
+	}
+	return o.Ecr, true
+}
+
+// HasEcr returns a boolean if a field has been set.
+func (o *WebhookReceiverConfig) HasEcr() bool {
+	if o != nil && !IsNil(o.Ecr) {
+		return true
+	}
+
+	return false
+}
+
+// SetEcr gets a reference to the given ECRWebhookReceiverConfig and assigns it to the Ecr field.
+func (o *WebhookReceiverConfig) SetEcr(v ECRWebhookReceiverConfig) {
+	o.Ecr = &v
+}
+
 // GetGeneric returns the Generic field value if set, zero value otherwise.
 func (o *WebhookReceiverConfig) GetGeneric() GenericWebhookReceiverConfig {
 	if o == nil || IsNil(o.Generic) {
@@ -419,18 +555,30 @@ func (o WebhookReceiverConfig) MarshalJSON() ([]byte, error) {
 
 func (o WebhookReceiverConfig) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
+	if !IsNil(o.ArtifactRegistry) {
+		toSerialize["artifactRegistry"] = o.ArtifactRegistry
+	}
 	if !IsNil(o.Artifactory) {
 		toSerialize["artifactory"] = o.Artifactory
 	}
 	if !IsNil(o.Azure) {
 		toSerialize["azure"] = o.Azure
 	}
+	if !IsNil(o.AzureEventGrid) {
+		toSerialize["azureEventGrid"] = o.AzureEventGrid
+	}
 	if !IsNil(o.Bitbucket) {
 		toSerialize["bitbucket"] = o.Bitbucket
 	}
+	if !IsNil(o.CloudEvents) {
+		toSerialize["cloudEvents"] = o.CloudEvents
+	}
 	if !IsNil(o.Dockerhub) {
 		toSerialize["dockerhub"] = o.Dockerhub
 	}
+	if !IsNil(o.Ecr) {
+		toSerialize["ecr"] = o.Ecr
+	}
 	if !IsNil(o.Generic) {
 		toSerialize["generic"] = o.Generic
 	}
@@ -137,6 +137,100 @@ type ArgoCDAppWait struct {
 	WaitFor []WaitFor `json:"waitFor,omitempty"`
 }
 
+type ChangeRequestCloseConfig struct {
+	// The body of the HTTP request that closes the change request. Occurrences of {{changeID}}
+	// and {{result}} are replaced with the ID of the change request and the result of the
+	// Promotion, respectively.
+	Body string `json:"body,omitempty"`
+	// The ID of the change request to close. This is typically the changeID output of a
+	// change-request-open step. If empty, the step is skipped, since no change request was
+	// opened.
+	ChangeID string `json:"changeID"`
+	// Headers to include in the HTTP request that closes the change request.
+	Headers []ChangeRequestCloseConfigHeader `json:"headers,omitempty"`
+	// Whether to skip TLS verification when making the request. (Not recommended.)
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+	// The HTTP method to use for the request that closes the change request. If not specified,
+	// the default is PATCH.
+	Method string `json:"method,omitempty"`
+	// The result of the Promotion with which to close the change request. If not specified, the
+	// default is Succeeded.
+	Result *Result `json:"result,omitempty"`
+	// The maximum time to wait for the request to complete. If not specified, the default is 10
+	// seconds.
+	Timeout string `json:"timeout,omitempty"`
+	// The URL to send the HTTP request that closes the change request to. Occurrences of
+	// {{changeID}} and {{result}} are replaced with the ID of the change request and the result
+	// of the Promotion, respectively.
+	URL string `json:"url"`
+}
+
+type ChangeRequestCloseConfigHeader struct {
+	// The name of the header.
+	Name string `json:"name"`
+	// The value of the header. Occurrences of {{changeID}} and {{result}} are replaced with the
+	// ID of the change request and the result of the Promotion, respectively.
+	Value string `json:"value"`
+}
+
+type ChangeRequestOpenConfig struct {
+	// Describes the HTTP request that creates the change request.
+	Create ChangeRequestCreate `json:"create"`
+	// Whether to skip TLS verification when making requests. (Not recommended.)
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+	// Describes the HTTP request that retrieves the change request while waiting for it to be
+	// approved or rejected.
+	Poll ChangeRequestPoll `json:"poll"`
+	// The suggested interval at which to poll the change request while waiting for it to be
+	// approved or rejected. This is only a suggestion: the controller enforces a lower bound
+	// and may reconcile sooner in response to other events. If not specified, the default is 1
+	// minute.
+	PollInterval string `json:"pollInterval,omitempty"`
+	// The maximum time to wait for each request to complete. If not specified, the default is
+	// 10 seconds.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Describes the HTTP request that creates the change request.
+type ChangeRequestCreate struct {
+	// The body of the HTTP request that creates the change request.
+	Body string `json:"body,omitempty"`
+	// Headers to include in the HTTP request that creates the change request.
+	Headers []ChangeRequestOpenConfigHeader `json:"headers,omitempty"`
+	// An expression to evaluate to extract the ID of the change request from the HTTP response.
+	IDExpression string `json:"idExpression"`
+	// The HTTP method to use for the request that creates the change request. If not specified,
+	// the default is POST.
+	Method string `json:"method,omitempty"`
+	// The URL to send the HTTP request that creates the change request to.
+	URL string `json:"url"`
+}
+
+type ChangeRequestOpenConfigHeader struct {
+	// The name of the header.
+	Name string `json:"name"`
+	// The value of the header. Occurrences of {{changeID}} are replaced with the ID of the
+	// change request.
+	Value string `json:"value"`
+}
+
+// Describes the HTTP request that retrieves the change request while waiting for it to be
+// approved or rejected.
+type ChangeRequestPoll struct {
+	// An expression to evaluate to determine if the change request has been approved.
+	ApprovedExpression string `json:"approvedExpression"`
+	// Headers to include in the HTTP request that retrieves the change request.
+	Headers []ChangeRequestOpenConfigHeader `json:"headers,omitempty"`
+	// The HTTP method to use for the request that retrieves the change request. If not
+	// specified, the default is GET.
+	Method string `json:"method,omitempty"`
+	// An expression to evaluate to determine if the change request has been rejected.
+	RejectedExpression string `json:"rejectedExpression,omitempty"`
+	// The URL to send the HTTP request that retrieves the change request to. Occurrences of
+	// {{changeID}} are replaced with the ID of the change request.
+	URL string `json:"url"`
+}
+
 type CopyConfig struct {
 	// Ignore is a (multiline) string of glob patterns to ignore when copying files. It accepts
 	// the same syntax as .gitignore files.
@@ -829,6 +923,15 @@ const (
 	Sync      WaitFor = "sync"
 )
 
+// The result of the Promotion with which to close the change request. If not specified, the
+// default is Succeeded.
+type Result string
+
+const (
+	Failed    Result = "Failed"
+	Succeeded Result = "Succeeded"
+)
+
 // The name of the Git provider to use. Currently 'azure', 'bitbucket', 'gitea', 'github',
 // and 'gitlab' are supported. Kargo will try to infer the provider if it is not explicitly
 // specified.